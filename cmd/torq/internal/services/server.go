@@ -10,6 +10,7 @@ import (
 
 	"github.com/lncapital/torq/internal/automation"
 	"github.com/lncapital/torq/internal/cache"
+	"github.com/lncapital/torq/internal/probes"
 	"github.com/lncapital/torq/internal/services_helpers"
 	"github.com/lncapital/torq/internal/workflows"
 )
@@ -100,7 +101,7 @@ func StartScheduledService(ctx context.Context, db *sqlx.DB) {
 
 func StartRebalanceService(ctx context.Context, conn *grpc.ClientConn, db *sqlx.DB, nodeId int) {
 
-	serviceType := services_helpers.LndServiceRebalanceService
+	serviceType := services_helpers.GetRebalanceServiceType(cache.GetNodeConnectionDetails(nodeId).Implementation)
 
 	defer log.Info().Msgf("%v terminated for nodeId: %v", serviceType.String(), nodeId)
 
@@ -119,6 +120,27 @@ func StartRebalanceService(ctx context.Context, conn *grpc.ClientConn, db *sqlx.
 	cache.SetInactiveNodeServiceState(serviceType, nodeId)
 }
 
+func StartProbeService(ctx context.Context, conn *grpc.ClientConn, db *sqlx.DB, nodeId int) {
+
+	serviceType := services_helpers.LndServiceProbeService
+
+	defer log.Info().Msgf("%v terminated for nodeId: %v", serviceType.String(), nodeId)
+
+	defer func() {
+		if err := recover(); err != nil {
+			log.Error().Msgf("%v is panicking (nodeId: %v) %v", serviceType.String(), nodeId, string(debug.Stack()))
+			cache.SetFailedNodeServiceState(serviceType, nodeId)
+			return
+		}
+	}()
+
+	cache.SetActiveNodeServiceState(serviceType, nodeId)
+
+	probes.ProbeServiceStart(ctx, conn, db, nodeId)
+
+	cache.SetInactiveNodeServiceState(serviceType, nodeId)
+}
+
 func StartMaintenanceService(ctx context.Context, db *sqlx.DB) {
 
 	serviceType := services_helpers.MaintenanceService
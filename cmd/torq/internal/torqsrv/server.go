@@ -18,21 +18,26 @@ import (
 	mgin "github.com/ulule/limiter/v3/drivers/middleware/gin"
 	"github.com/ulule/limiter/v3/drivers/store/memory"
 
+	"github.com/lncapital/torq/internal/audit"
 	"github.com/lncapital/torq/internal/auth"
 	"github.com/lncapital/torq/internal/automation"
 	"github.com/lncapital/torq/internal/categories"
 	"github.com/lncapital/torq/internal/channel_history"
 	"github.com/lncapital/torq/internal/channels"
+	"github.com/lncapital/torq/internal/communications"
 	"github.com/lncapital/torq/internal/corridors"
 	"github.com/lncapital/torq/internal/flow"
 	"github.com/lncapital/torq/internal/forwards"
+	"github.com/lncapital/torq/internal/htlcs"
 	"github.com/lncapital/torq/internal/invoices"
 	"github.com/lncapital/torq/internal/lightning"
 	"github.com/lncapital/torq/internal/messages"
+	"github.com/lncapital/torq/internal/metrics"
 	"github.com/lncapital/torq/internal/nodes"
 	"github.com/lncapital/torq/internal/on_chain_tx"
 	"github.com/lncapital/torq/internal/payments"
 	"github.com/lncapital/torq/internal/peers"
+	"github.com/lncapital/torq/internal/probes"
 	"github.com/lncapital/torq/internal/services"
 	"github.com/lncapital/torq/internal/settings"
 	"github.com/lncapital/torq/internal/tags"
@@ -41,7 +46,8 @@ import (
 	"github.com/lncapital/torq/web"
 )
 
-func Start(host string, port int, apiPswd string, cookiePath string, db *sqlx.DB, autoLogin bool) error {
+func Start(host string, port int, apiPswd string, cookiePath string, db *sqlx.DB, autoLogin bool,
+	metricsEnabled bool) error {
 	r := gin.Default()
 
 	if err := auth.RefreshCookieFile(cookiePath); err != nil {
@@ -55,6 +61,11 @@ func Start(host string, port int, apiPswd string, cookiePath string, db *sqlx.DB
 
 	registerRoutes(r, db, apiPswd, cookiePath, autoLogin)
 
+	if metricsEnabled {
+		metricsRoutes := r.Group("/metrics", auth.AuthRequired(autoLogin, db))
+		metrics.RegisterMetricsRoutes(metricsRoutes, db)
+	}
+
 	fmt.Println("Listening on port " + strconv.Itoa(port))
 
 	if err := r.Run(host + ":" + strconv.Itoa(port)); err != nil {
@@ -117,7 +128,7 @@ func registerRoutes(r *gin.Engine, db *sqlx.DB, apiPwd string, cookiePath string
 	applyCors(r)
 	// Websocket
 	ws := r.Group("/ws")
-	ws.Use(auth.AuthRequired(autoLogin))
+	ws.Use(auth.AuthRequired(autoLogin, db))
 	ws.GET("", func(c *gin.Context) {
 		err := WebsocketHandler(c, db)
 		log.Debug().Msgf("WebsocketHandler: %v", err)
@@ -129,7 +140,8 @@ func registerRoutes(r *gin.Engine, db *sqlx.DB, apiPwd string, cookiePath string
 
 	// Limit login attempts to 10 per minute.
 	rl := NewLoginRateLimitMiddleware()
-	api.POST("/login", rl, auth.Login(apiPwd))
+	api.POST("/login", rl, auth.Login(apiPwd, db))
+	api.POST("/login/totp", rl, auth.TotpLogin(db))
 	api.POST("/cookie-login", rl, auth.CookieLogin(cookiePath))
 	api.GET("auto-login-setting", rl, auth.AutoLoginSetting(autoLogin))
 
@@ -143,95 +155,152 @@ func registerRoutes(r *gin.Engine, db *sqlx.DB, apiPwd string, cookiePath string
 		services.RegisterUnauthenticatedRoutes(unauthorisedServicesRoutes, db)
 	}
 
-	api.Use(auth.AuthRequired(autoLogin)).Use(auth.TorqRequired)
+	// Roles: viewers can only read (GET), operators can run workflows, rebalance and update policies
+	// and admins can also open and close channels, move funds, manage users and change settings.
+	api.Use(auth.AuthRequired(autoLogin, db)).Use(auth.TorqRequired).Use(audit.Required(db))
 	{
+		auditRoutes := api.Group("/audit", auth.RoleRequired(auth.RoleAdmin))
+		{
+			audit.RegisterAuditRoutes(auditRoutes, db)
+		}
 
-		tableViewRoutes := api.Group("/table-views")
+		currentUserRoutes := api.Group("/users")
+		{
+			auth.RegisterCurrentUserRoutes(currentUserRoutes, db)
+		}
+
+		userRoutes := api.Group("/users", auth.RoleRequired(auth.RoleAdmin))
+		{
+			auth.RegisterUserRoutes(userRoutes, db)
+		}
+
+		apiTokenRoutes := api.Group("/api-tokens", auth.RoleRequired(auth.RoleAdmin))
+		{
+			auth.RegisterApiTokenRoutes(apiTokenRoutes, db)
+		}
+
+		tableViewRoutes := api.Group("/table-views", auth.WriteRoleRequired(auth.RoleOperator))
 		{
 			views.RegisterTableViewRoutes(tableViewRoutes, db)
 		}
 
-		categoryRoutes := api.Group("/categories")
+		categoryRoutes := api.Group("/categories", auth.WriteRoleRequired(auth.RoleOperator))
 		{
 			categories.RegisterCategoryRoutes(categoryRoutes, db)
 		}
 
-		tagRoutes := api.Group("/tags")
+		tagRoutes := api.Group("/tags", auth.WriteRoleRequired(auth.RoleOperator))
 		{
 			tags.RegisterTagRoutes(tagRoutes, db)
 		}
 
-		corridorRoutes := api.Group("/corridors")
+		corridorRoutes := api.Group("/corridors", auth.WriteRoleRequired(auth.RoleOperator))
 		{
 			corridors.RegisterCorridorRoutes(corridorRoutes, db)
 		}
 
-		paymentRoutes := api.Group("/payments")
+		paymentRoutes := api.Group("/payments", auth.WriteRoleRequired(auth.RoleOperator))
 		{
 			payments.RegisterPaymentsRoutes(paymentRoutes, db)
 		}
 
-		invoiceRoutes := api.Group("/invoices")
+		invoiceRoutes := api.Group("/invoices", auth.WriteRoleRequired(auth.RoleOperator))
 		{
 			invoices.RegisterInvoicesRoutes(invoiceRoutes, db)
 		}
 
-		onChainTx := api.Group("/on-chain-tx")
+		onChainTx := api.Group("/on-chain-tx", auth.WriteRoleRequired(auth.RoleOperator))
 		{
 			on_chain_tx.RegisterOnChainTxsRoutes(onChainTx, db)
 		}
 
-		peerRoutes := api.Group("/peers")
+		peerRoutes := api.Group("/peers", auth.WriteRoleRequired(auth.RoleOperator))
 		{
 			peers.RegisterPeerRoutes(peerRoutes, db)
 		}
 
-		nodeRoutes := api.Group("/nodes")
+		probeRoutes := api.Group("/probes", auth.WriteRoleRequired(auth.RoleOperator))
+		{
+			probes.RegisterProbeRoutes(probeRoutes, db)
+		}
+
+		nodeRoutes := api.Group("/nodes", auth.WriteRoleRequired(auth.RoleAdmin))
 		{
 			nodes.RegisterNodeRoutes(nodeRoutes, db)
 		}
 
-		channelRoutes := api.Group("/channels")
+		channelRoutes := api.Group("/channels", auth.WriteRoleRequired(auth.RoleOperator))
 		{
 			channel_history.RegisterChannelHistoryRoutes(channelRoutes, db)
 			channels.RegisterChannelRoutes(channelRoutes, db)
 		}
 
-		forwardRoutes := api.Group("/forwards")
+		forwardRoutes := api.Group("/forwards", auth.WriteRoleRequired(auth.RoleOperator))
 		{
 			forwards.RegisterForwardsRoutes(forwardRoutes, db)
 		}
 
-		flowRoutes := api.Group("/flow")
+		flowRoutes := api.Group("/flow", auth.WriteRoleRequired(auth.RoleOperator))
 		{
 			flow.RegisterFlowRoutes(flowRoutes, db)
 		}
 
-		lightningRoutes := api.Group("/lightning")
+		htlcRoutes := api.Group("/htlcs", auth.WriteRoleRequired(auth.RoleOperator))
+		{
+			htlcs.RegisterHtlcsRoutes(htlcRoutes, db)
+		}
+
+		lightningRoutes := api.Group("/lightning", auth.WriteRoleRequired(auth.RoleOperator))
 		{
 			lightning.RegisterLightningRoutes(lightningRoutes, db)
 		}
 
-		workflowRoutes := api.Group("/workflows")
+		lightningFundsRoutes := api.Group("/lightning", auth.RoleRequired(auth.RoleAdmin))
+		{
+			lightning.RegisterLightningFundsRoutes(lightningFundsRoutes, db)
+		}
+
+		approvalRoutes := api.Group("/approvals", auth.RoleRequired(auth.RoleAdmin))
+		{
+			lightning.RegisterApprovalRoutes(approvalRoutes, db)
+		}
+
+		workflowRoutes := api.Group("/workflows", auth.WriteRoleRequired(auth.RoleOperator))
 		{
 			workflows.RegisterWorkflowRoutes(workflowRoutes, db)
 		}
 
-		automationRoutes := api.Group("/automation")
+		automationRoutes := api.Group("/automation", auth.WriteRoleRequired(auth.RoleOperator))
 		{
 			automation.RegisterAutomationRoutes(automationRoutes, db)
 		}
 
-		messageRoutes := api.Group("messages")
+		// The communications hold the credentials of the targets so they are not readable below admin
+		communicationRoutes := api.Group("communications", auth.RoleRequired(auth.RoleAdmin))
+		{
+			communications.RegisterCommunicationRoutes(communicationRoutes, db)
+		}
+
+		communicationTargetRoutes := api.Group("communication-targets", auth.RoleRequired(auth.RoleOperator))
+		{
+			communications.RegisterCommunicationTargetRoutes(communicationTargetRoutes, db)
+		}
+
+		messageRoutes := api.Group("messages", auth.WriteRoleRequired(auth.RoleOperator))
 		{
 			messages.RegisterMessagesRoutes(messageRoutes)
 		}
 
-		settingRoutes := api.Group("settings")
+		settingRoutes := api.Group("settings", auth.WriteRoleRequired(auth.RoleAdmin))
 		{
 			settings.RegisterSettingRoutes(settingRoutes, db)
 		}
 
+		nodeConnectionDetailsRoutes := api.Group("settings", auth.RoleRequired(auth.RoleAdmin))
+		{
+			settings.RegisterNodeConnectionDetailsRoutes(nodeConnectionDetailsRoutes, db)
+		}
+
 		api.GET("/ping", func(c *gin.Context) {
 			c.JSON(200, gin.H{
 				"message": "pong",
@@ -1,10 +1,13 @@
 package torqsrv
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"net/url"
 
+	"github.com/lncapital/torq/internal/audit"
+	"github.com/lncapital/torq/internal/auth"
 	"github.com/lncapital/torq/internal/lightning"
 	"github.com/lncapital/torq/internal/lightning_helpers"
 	"github.com/lncapital/torq/pkg/server_errors"
@@ -20,29 +23,70 @@ import (
 type wsRequest struct {
 	Type              string                               `json:"type"`
 	NewPaymentRequest *lightning_helpers.NewPaymentRequest `json:"newPaymentRequest"`
+	Subscription      *wsSubscriptionRequest               `json:"subscription"`
 }
 
 type Pong struct {
 	Message string `json:"message"`
 }
 
+// wsApprovalRequired is sent instead of the payment progress when the payment waits for approval
+type wsApprovalRequired struct {
+	Type             string                     `json:"type"`
+	ApprovalProposal lightning.ApprovalProposal `json:"approvalProposal"`
+}
+
 type wsError struct {
 	Type  string                    `json:"type"`
 	Error server_errors.ServerError `json:"error"`
 }
 
-func processWsReq(webSocketResponseChannel chan<- interface{}, req wsRequest) {
+func processWsReq(db *sqlx.DB, webSocketResponseChannel chan<- interface{}, req wsRequest, role auth.Role, actor auth.Actor,
+	subscription *wsSubscription) {
 	switch req.Type {
 	case "ping":
 		webSocketResponseChannel <- Pong{Message: "pong"}
 		return
+	case "subscribe":
+		if req.Subscription == nil {
+			sendError(fmt.Errorf("unknown Subscription for type: %s", req.Type), req, webSocketResponseChannel)
+			break
+		}
+		err := subscription.subscribe(*req.Subscription, webSocketResponseChannel)
+		if err != nil {
+			sendError(err, req, webSocketResponseChannel)
+		}
+	case "unsubscribe":
+		subscription.unsubscribe()
+		webSocketResponseChannel <- wsSubscribed{Type: "Unsubscribed"}
 	case "newPayment":
+		if role < auth.RoleAdmin {
+			sendError(errors.New("Only admin users can send payments."), req, webSocketResponseChannel)
+			break
+		}
 		if req.NewPaymentRequest == nil {
 			sendError(fmt.Errorf("unknown NewPaymentRequest for type: %s", req.Type), req, webSocketResponseChannel)
 			break
 		}
+		amountSat, err := lightning.GetNewPaymentAmountSat(*req.NewPaymentRequest)
+		if err != nil {
+			sendError(err, req, webSocketResponseChannel)
+			break
+		}
+		approvalProposal, err := lightning.ProposeWhenApprovalRequired(db, actor, lightning.NewPaymentProposal,
+			req.NewPaymentRequest.NodeId, 0, amountSat, req.NewPaymentRequest)
+		if err != nil {
+			sendError(err, req, webSocketResponseChannel)
+			break
+		}
+		if approvalProposal != nil {
+			webSocketResponseChannel <- wsApprovalRequired{Type: "ApprovalRequired", ApprovalProposal: *approvalProposal}
+			break
+		}
 		req.NewPaymentRequest.ProgressReportChannel = webSocketResponseChannel
-		_, err := lightning.NewPayment(*req.NewPaymentRequest)
+		_, err = lightning.NewPayment(*req.NewPaymentRequest)
+		audit.AddActorAuditLog(db, actor, "websocket newPayment", req.NewPaymentRequest.NodeId, 0,
+			req.NewPaymentRequest, err)
 		if err != nil {
 			sendError(err, req, webSocketResponseChannel)
 		}
@@ -73,6 +117,10 @@ func WebsocketHandler(c *gin.Context, db *sqlx.DB) error {
 	}
 	webSocketResponseChannel := make(chan interface{})
 	done := make(chan struct{})
+	// The live event subscription ends with the connection
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	subscription := newWsSubscription(ctx)
 
 	conn, err := wsUpgrade.Upgrade(c.Writer, c.Request, nil)
 	if err != nil {
@@ -85,7 +133,7 @@ func WebsocketHandler(c *gin.Context, db *sqlx.DB) error {
 		}
 	}(conn)
 
-	go processWebsocketRequests(conn, db, done, webSocketResponseChannel)
+	go processWebsocketRequests(conn, db, done, webSocketResponseChannel, auth.GetRole(c), auth.GetActor(c), subscription)
 
 	for {
 		select {
@@ -104,7 +152,10 @@ func WebsocketHandler(c *gin.Context, db *sqlx.DB) error {
 func processWebsocketRequests(conn *websocket.Conn,
 	db *sqlx.DB,
 	done chan<- struct{},
-	webSocketResponseChannel chan<- interface{}) {
+	webSocketResponseChannel chan<- interface{},
+	role auth.Role,
+	actor auth.Actor,
+	subscription *wsSubscription) {
 
 	defer close(done)
 
@@ -119,7 +170,7 @@ func processWebsocketRequests(conn *websocket.Conn,
 			log.Debug().Err(err).Msg("WebSocket Handshake Error.")
 			return
 		case nil:
-			go processWsReq(webSocketResponseChannel, req)
+			go processWsReq(db, webSocketResponseChannel, req, role, actor, subscription)
 		default:
 			serverError := server_errors.SingleServerError("Could not parse request, please check that your JSON is correctly formated.")
 			wsr := wsError{
@@ -0,0 +1,195 @@
+package torqsrv
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/cockroachdb/errors"
+	"golang.org/x/exp/slices"
+
+	"github.com/lncapital/torq/internal/cache"
+	"github.com/lncapital/torq/internal/core"
+)
+
+const (
+	htlcEventType           = "htlc"
+	forwardEventType        = "forward"
+	channelBalanceEventType = "channelBalance"
+	channelEventType        = "channel"
+	peerEventType           = "peer"
+)
+
+var wsEventTypes = []string{ //nolint:gochecknoglobals
+	htlcEventType,
+	forwardEventType,
+	channelBalanceEventType,
+	channelEventType,
+	peerEventType,
+}
+
+// wsSubscriptionRequest filters the live events, an empty list means no filter
+type wsSubscriptionRequest struct {
+	EventTypes []string `json:"eventTypes"`
+	NodeIds    []int    `json:"nodeIds"`
+	ChannelIds []int    `json:"channelIds"`
+}
+
+type wsSubscribed struct {
+	Type         string                `json:"type"`
+	Subscription wsSubscriptionRequest `json:"subscription"`
+}
+
+type wsEvent struct {
+	Type      string `json:"type"`
+	EventType string `json:"eventType"`
+	Event     any    `json:"event"`
+}
+
+// wsSubscription is the (single) live event subscription of a websocket connection
+type wsSubscription struct {
+	ctx          context.Context
+	mutex        sync.Mutex
+	subscriberId int
+	filter       *wsSubscriptionRequest
+}
+
+func newWsSubscription(ctx context.Context) *wsSubscription {
+	return &wsSubscription{ctx: ctx}
+}
+
+func validateWsSubscriptionRequest(request wsSubscriptionRequest) error {
+	for _, eventType := range request.EventTypes {
+		if !slices.Contains(wsEventTypes, eventType) {
+			return errors.New(fmt.Sprintf("Unknown event type: %v (possible values: %v)", eventType, wsEventTypes))
+		}
+	}
+	return nil
+}
+
+// subscribe replaces the filter of an existing subscription
+func (subscription *wsSubscription) subscribe(request wsSubscriptionRequest,
+	webSocketResponseChannel chan<- interface{}) error {
+
+	err := validateWsSubscriptionRequest(request)
+	if err != nil {
+		return err
+	}
+	subscription.mutex.Lock()
+	subscription.filter = &request
+	if subscription.subscriberId == 0 {
+		subscriberId, events := cache.SubscribeEvents()
+		subscription.subscriberId = subscriberId
+		go subscription.forwardEvents(subscriberId, events, webSocketResponseChannel)
+	}
+	subscription.mutex.Unlock()
+	webSocketResponseChannel <- wsSubscribed{Type: "Subscribed", Subscription: request}
+	return nil
+}
+
+func (subscription *wsSubscription) unsubscribe() {
+	subscription.mutex.Lock()
+	defer subscription.mutex.Unlock()
+	subscription.filter = nil
+	if subscription.subscriberId != 0 {
+		cache.UnsubscribeEvents(subscription.subscriberId)
+		subscription.subscriberId = 0
+	}
+}
+
+// unsubscribeSubscriber only unsubscribes when subscriberId is still the current subscriber
+func (subscription *wsSubscription) unsubscribeSubscriber(subscriberId int) {
+	subscription.mutex.Lock()
+	defer subscription.mutex.Unlock()
+	if subscription.subscriberId != subscriberId {
+		return
+	}
+	subscription.filter = nil
+	cache.UnsubscribeEvents(subscription.subscriberId)
+	subscription.subscriberId = 0
+}
+
+// getFilter returns nil when subscriberId is no longer the current subscriber,
+// so the events that are still buffered for a previous subscriber are never matched against a newer filter.
+func (subscription *wsSubscription) getFilter(subscriberId int) *wsSubscriptionRequest {
+	subscription.mutex.Lock()
+	defer subscription.mutex.Unlock()
+	if subscription.subscriberId != subscriberId {
+		return nil
+	}
+	return subscription.filter
+}
+
+func (subscription *wsSubscription) forwardEvents(subscriberId int,
+	events <-chan any,
+	webSocketResponseChannel chan<- interface{}) {
+
+	for {
+		select {
+		case <-subscription.ctx.Done():
+			subscription.unsubscribeSubscriber(subscriberId)
+			return
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+			filter := subscription.getFilter(subscriberId)
+			if filter == nil {
+				continue
+			}
+			eventType, nodeId, channelIds := getWsEventDetails(event)
+			if eventType == "" || !filter.matches(eventType, nodeId, channelIds) {
+				continue
+			}
+			select {
+			case <-subscription.ctx.Done():
+			case webSocketResponseChannel <- wsEvent{Type: "Event", EventType: eventType, Event: event}:
+			}
+		}
+	}
+}
+
+func (filter wsSubscriptionRequest) matches(eventType string, nodeId int, channelIds []int) bool {
+	if len(filter.EventTypes) != 0 && !slices.Contains(filter.EventTypes, eventType) {
+		return false
+	}
+	if len(filter.NodeIds) != 0 && !slices.Contains(filter.NodeIds, nodeId) {
+		return false
+	}
+	if len(filter.ChannelIds) == 0 {
+		return true
+	}
+	for _, channelId := range channelIds {
+		if slices.Contains(filter.ChannelIds, channelId) {
+			return true
+		}
+	}
+	return false
+}
+
+// getWsEventDetails returns the event type, the nodeId and the channelIds of the event
+func getWsEventDetails(event any) (string, int, []int) {
+	switch e := event.(type) {
+	case core.HtlcEvent:
+		return htlcEventType, e.NodeId, getChannelIds(e.IncomingChannelId, e.OutgoingChannelId)
+	case core.ForwardEvent:
+		return forwardEventType, e.NodeId, getChannelIds(e.IncomingChannelId, e.OutgoingChannelId)
+	case core.ChannelBalanceEvent:
+		return channelBalanceEventType, e.NodeId, []int{e.ChannelId}
+	case core.ChannelEvent:
+		return channelEventType, e.NodeId, []int{e.ChannelId}
+	case core.PeerEvent:
+		return peerEventType, e.NodeId, cache.GetChannelIdsByNodeId(e.EventNodeId)
+	}
+	return "", 0, nil
+}
+
+func getChannelIds(channelIds ...*int) []int {
+	var result []int
+	for _, channelId := range channelIds {
+		if channelId != nil {
+			result = append(result, *channelId)
+		}
+	}
+	return result
+}
@@ -0,0 +1,91 @@
+package torqsrv
+
+import (
+	"context"
+	"testing"
+
+	"github.com/lncapital/torq/internal/core"
+	"github.com/lncapital/torq/testutil"
+)
+
+func TestWsSubscriptionMatches(t *testing.T) {
+	incomingChannelId := 11
+	outgoingChannelId := 12
+	htlcEvent := core.HtlcEvent{
+		EventData:         core.EventData{NodeId: 1},
+		IncomingChannelId: &incomingChannelId,
+		OutgoingChannelId: &outgoingChannelId,
+	}
+	balanceEvent := core.ChannelBalanceEvent{EventData: core.EventData{NodeId: 2}, ChannelId: 21}
+	testCases := []struct {
+		name   string
+		filter wsSubscriptionRequest
+		event  any
+		want   bool
+	}{
+		{name: "no filter", event: htlcEvent, want: true},
+		{name: "event type", filter: wsSubscriptionRequest{EventTypes: []string{htlcEventType}}, event: htlcEvent, want: true},
+		{name: "other event type", filter: wsSubscriptionRequest{EventTypes: []string{forwardEventType}}, event: htlcEvent, want: false},
+		{name: "node", filter: wsSubscriptionRequest{NodeIds: []int{1}}, event: htlcEvent, want: true},
+		{name: "other node", filter: wsSubscriptionRequest{NodeIds: []int{2}}, event: htlcEvent, want: false},
+		{name: "outgoing channel", filter: wsSubscriptionRequest{ChannelIds: []int{12}}, event: htlcEvent, want: true},
+		{name: "other channel", filter: wsSubscriptionRequest{ChannelIds: []int{21}}, event: htlcEvent, want: false},
+		{name: "balance channel", filter: wsSubscriptionRequest{NodeIds: []int{2}, ChannelIds: []int{21}}, event: balanceEvent, want: true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			eventType, nodeId, channelIds := getWsEventDetails(tc.event)
+			got := tc.filter.matches(eventType, nodeId, channelIds)
+			if got != tc.want {
+				testutil.Errorf(t, "matches(%v, %v, %v) = %v, want %v", eventType, nodeId, channelIds, got, tc.want)
+				return
+			}
+			testutil.Successf(t, "matches(%v, %v, %v) = %v", eventType, nodeId, channelIds, got)
+		})
+	}
+}
+
+func TestValidateWsSubscriptionRequest(t *testing.T) {
+	err := validateWsSubscriptionRequest(wsSubscriptionRequest{EventTypes: []string{htlcEventType, peerEventType}})
+	if err != nil {
+		testutil.Errorf(t, "validateWsSubscriptionRequest() error = %v", err)
+	}
+	err = validateWsSubscriptionRequest(wsSubscriptionRequest{EventTypes: []string{"invoice"}})
+	if err == nil {
+		testutil.Errorf(t, "validateWsSubscriptionRequest() should fail for an unknown event type")
+	}
+}
+
+func TestWsSubscriptionResubscribe(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	webSocketResponseChannel := make(chan interface{}, 10)
+	subscription := newWsSubscription(ctx)
+
+	err := subscription.subscribe(wsSubscriptionRequest{EventTypes: []string{htlcEventType}}, webSocketResponseChannel)
+	if err != nil {
+		testutil.Fatalf(t, "subscribe() error = %v", err)
+	}
+	previousSubscriberId := subscription.subscriberId
+	subscription.unsubscribe()
+	err = subscription.subscribe(wsSubscriptionRequest{EventTypes: []string{peerEventType}}, webSocketResponseChannel)
+	if err != nil {
+		testutil.Fatalf(t, "subscribe() error = %v", err)
+	}
+
+	if subscription.getFilter(previousSubscriberId) != nil {
+		testutil.Errorf(t, "getFilter() of the previous subscriber = %v, want nil", subscription.getFilter(previousSubscriberId))
+		return
+	}
+	filter := subscription.getFilter(subscription.subscriberId)
+	if filter == nil || len(filter.EventTypes) != 1 || filter.EventTypes[0] != peerEventType {
+		testutil.Errorf(t, "getFilter() of the current subscriber = %v, want %v", filter, peerEventType)
+		return
+	}
+	subscription.unsubscribeSubscriber(previousSubscriberId)
+	if subscription.getFilter(subscription.subscriberId) == nil {
+		testutil.Errorf(t, "unsubscribeSubscriber() of the previous subscriber removed the current subscription")
+		return
+	}
+	testutil.Successf(t, "getFilter() only returns the filter of the current subscriber")
+}
@@ -26,10 +26,12 @@ import (
 	"github.com/lncapital/torq/cmd/torq/internal/subscribe"
 	"github.com/lncapital/torq/cmd/torq/internal/torqsrv"
 	"github.com/lncapital/torq/cmd/torq/internal/vector_ping"
+	"github.com/lncapital/torq/internal/auth"
 	"github.com/lncapital/torq/internal/cache"
 	"github.com/lncapital/torq/internal/core"
 	"github.com/lncapital/torq/internal/corridors"
 	"github.com/lncapital/torq/internal/database"
+	"github.com/lncapital/torq/internal/encryption"
 	"github.com/lncapital/torq/internal/services_helpers"
 	"github.com/lncapital/torq/internal/settings"
 	"github.com/lncapital/torq/internal/tags"
@@ -114,6 +116,33 @@ func main() {
 			Value: false,
 			Usage: "Allows logging in without a password",
 		}),
+		altsrc.NewBoolFlag(&cli.BoolFlag{
+			Name:  "torq.metrics",
+			Value: false,
+			Usage: "Serve Prometheus metrics on /metrics (authenticated by session or API token with the metrics scope)",
+		}),
+
+		// Node credentials encryption
+		altsrc.NewStringFlag(&cli.StringFlag{
+			Name:    "torq.credentials-key",
+			EnvVars: []string{"TORQ_CREDENTIALS_KEY"},
+			Usage:   "Master key (32 bytes hex or base64 encoded) used to encrypt the node credentials in the database",
+		}),
+		altsrc.NewStringFlag(&cli.StringFlag{
+			Name:    "torq.credentials-key-file",
+			EnvVars: []string{"TORQ_CREDENTIALS_KEY_FILE"},
+			Usage:   "Path on disk to the master key used to encrypt the node credentials in the database",
+		}),
+		altsrc.NewStringFlag(&cli.StringFlag{
+			Name:    "torq.credentials-previous-keys",
+			EnvVars: []string{"TORQ_CREDENTIALS_PREVIOUS_KEYS"},
+			Usage:   "Comma separated previous master keys, credentials encrypted with these are rewrapped on startup",
+		}),
+		altsrc.NewStringFlag(&cli.StringFlag{
+			Name:    "torq.credentials-previous-key-files",
+			EnvVars: []string{"TORQ_CREDENTIALS_PREVIOUS_KEY_FILES"},
+			Usage:   "Comma separated paths on disk to previous master keys",
+		}),
 
 		// Torq database
 		altsrc.NewStringFlag(&cli.StringFlag{
@@ -189,6 +218,11 @@ func main() {
 			// Print startup message
 			fmt.Printf("Starting Torq %s\n", build.ExtendedVersion())
 
+			err := setCredentialsKeys(c)
+			if err != nil {
+				return errors.Wrap(err, "start cmd")
+			}
+
 			fmt.Println("Connecting to the Torq database")
 			db, err := database.PgConnect(c.String("db.name"), c.String("db.user"),
 				c.String("db.password"), c.String("db.host"), c.String("db.port"))
@@ -243,7 +277,7 @@ func main() {
 
 			if err = torqsrv.Start(c.String("torq.network-interface"), c.Int("torq.port"), c.String("torq.password"),
 				c.String("torq.cookie-path"),
-				db, c.Bool("torq.auto-login")); err != nil {
+				db, c.Bool("torq.auto-login"), c.Bool("torq.metrics")); err != nil {
 				return errors.Wrap(err, "Starting torq webserver")
 			}
 
@@ -255,6 +289,11 @@ func main() {
 		Name:  "migrate_up",
 		Usage: "Migrates the database to the latest version",
 		Action: func(c *cli.Context) error {
+			err := setCredentialsKeys(c)
+			if err != nil {
+				return errors.Wrap(err, "Setting credentials keys")
+			}
+
 			db, err := database.PgConnect(c.String("db.name"), c.String("db.user"),
 				c.String("db.password"), c.String("db.host"), c.String("db.port"))
 			if err != nil {
@@ -273,6 +312,35 @@ func main() {
 				return errors.Wrap(err, "Migrating database up")
 			}
 
+			updated, err := settings.EncryptNodeConnectionDetails(db)
+			if err != nil {
+				return errors.Wrap(err, "Encrypting node credentials")
+			}
+			if updated != 0 {
+				fmt.Printf("Encrypted the credentials of %v node(s)\n", updated)
+			}
+
+			updated, err = auth.EncryptTotpSecrets(db)
+			if err != nil {
+				return errors.Wrap(err, "Encrypting TOTP secrets")
+			}
+			if updated != 0 {
+				fmt.Printf("Encrypted %v TOTP secret(s)\n", updated)
+			}
+
+			return nil
+		},
+	}
+
+	generateCredentialsKey := &cli.Command{
+		Name:  "generate_credentials_key",
+		Usage: "Generates a new master key to encrypt the node credentials",
+		Action: func(c *cli.Context) error {
+			key, err := encryption.GenerateKey()
+			if err != nil {
+				return errors.Wrap(err, "Generating credentials key")
+			}
+			fmt.Println(key)
 			return nil
 		},
 	}
@@ -284,6 +352,7 @@ func main() {
 	app.Commands = cli.Commands{
 		start,
 		migrateUp,
+		generateCredentialsKey,
 	}
 
 	err = app.Run(os.Args)
@@ -315,6 +384,46 @@ func loadFlags() func(context *cli.Context) (altsrc.InputSourceContext, error) {
 	}
 }
 
+func setCredentialsKeys(c *cli.Context) error {
+	var currentKey []byte
+	var err error
+	switch {
+	case c.String("torq.credentials-key") != "":
+		currentKey, err = encryption.ParseKey(c.String("torq.credentials-key"))
+	case c.String("torq.credentials-key-file") != "":
+		currentKey, err = encryption.ReadKeyFile(c.String("torq.credentials-key-file"))
+	}
+	if err != nil {
+		return errors.Wrap(err, "Loading credentials key")
+	}
+	var previousKeys [][]byte
+	for _, encodedKey := range strings.Split(c.String("torq.credentials-previous-keys"), ",") {
+		if strings.TrimSpace(encodedKey) == "" {
+			continue
+		}
+		previousKey, err := encryption.ParseKey(encodedKey)
+		if err != nil {
+			return errors.Wrap(err, "Loading previous credentials key")
+		}
+		previousKeys = append(previousKeys, previousKey)
+	}
+	for _, path := range strings.Split(c.String("torq.credentials-previous-key-files"), ",") {
+		if strings.TrimSpace(path) == "" {
+			continue
+		}
+		previousKey, err := encryption.ReadKeyFile(strings.TrimSpace(path))
+		if err != nil {
+			return errors.Wrap(err, "Loading previous credentials key")
+		}
+		previousKeys = append(previousKeys, previousKey)
+	}
+	if len(currentKey) == 0 {
+		log.Warn().Msg("No credentials key configured, node credentials are stored unencrypted.")
+	}
+	encryption.SetKeys(currentKey, previousKeys...)
+	return nil
+}
+
 func migrateAndProcessArguments(db *sqlx.DB, c *cli.Context) {
 	fmt.Println("Checking for migrations..")
 	// Check if the database needs to be migrated.
@@ -326,6 +435,29 @@ func migrateAndProcessArguments(db *sqlx.DB, c *cli.Context) {
 		return
 	}
 
+	// Encrypts existing plaintext credentials and rewraps credentials encrypted with a previous master key
+	updated, err := settings.EncryptNodeConnectionDetails(db)
+	if err != nil {
+		log.Error().Err(err).Msg("Torq could not encrypt the node credentials.")
+		cache.CancelCoreService(services_helpers.RootService)
+		cache.SetFailedCoreServiceState(services_helpers.RootService)
+		return
+	}
+	if updated != 0 {
+		log.Info().Msgf("Torq encrypted the credentials of %v node(s).", updated)
+	}
+
+	updated, err = auth.EncryptTotpSecrets(db)
+	if err != nil {
+		log.Error().Err(err).Msg("Torq could not encrypt the TOTP secrets.")
+		cache.CancelCoreService(services_helpers.RootService)
+		cache.SetFailedCoreServiceState(services_helpers.RootService)
+		return
+	}
+	if updated != 0 {
+		log.Info().Msgf("Torq encrypted %v TOTP secret(s).", updated)
+	}
+
 	for {
 		// if node specified on cmd flags then check if we already know about it
 		if c.String("lnd.url") != "" &&
@@ -564,28 +696,29 @@ func processTorqInitialBoot(db *sqlx.DB) {
 	if cache.GetCurrentCoreServiceState(services_helpers.RootService).Status != services_helpers.Initializing {
 		return
 	}
+	// The credentials are decrypted by GetAllNodeConnectionDetails
+	allNodeConnectionDetails, err := settings.GetAllNodeConnectionDetails(db, false)
+	if err != nil {
+		log.Error().Err(err).Msg("Could not obtain the node connection details.")
+		return
+	}
+	nodeConnectionDetailsByNodeId := make(map[int]settings.NodeConnectionDetails)
+	for _, nodeConnectionDetails := range allNodeConnectionDetails {
+		nodeConnectionDetailsByNodeId[nodeConnectionDetails.NodeId] = nodeConnectionDetails
+	}
 	for _, torqNode := range cache.GetActiveTorqNodeSettings() {
-		var implementation core.Implementation
-		var grpcAddress string
-		var tls []byte
-		var macaroon []byte
-		var certificate []byte
-		var caCertificate []byte
-		var key []byte
-		var pingSystem core.PingSystem
-		var customSettings core.NodeConnectionDetailCustomSettings
-		err := db.QueryRow(`
-					SELECT implementation, grpc_address,
-					       tls_data, macaroon_data, certificate_data, key_data, ca_certificate_data,
-					       ping_system, custom_settings
-					FROM node_connection_details
-					WHERE node_id=$1`, torqNode.NodeId).Scan(&implementation, &grpcAddress,
-			&tls, &macaroon, &certificate, &key, &caCertificate,
-			&pingSystem, &customSettings)
-		if err != nil {
-			log.Error().Err(err).Msgf("Could not obtain desired state for nodeId: %v", torqNode.NodeId)
+		nodeConnectionDetails, exists := nodeConnectionDetailsByNodeId[torqNode.NodeId]
+		if !exists {
+			log.Error().Msgf("Could not obtain desired state for nodeId: %v", torqNode.NodeId)
 			continue
 		}
+		implementation := nodeConnectionDetails.Implementation
+		pingSystem := nodeConnectionDetails.PingSystem
+		customSettings := nodeConnectionDetails.CustomSettings
+		grpcAddress := ""
+		if nodeConnectionDetails.GRPCAddress != nil {
+			grpcAddress = *nodeConnectionDetails.GRPCAddress
+		}
 
 		log.Info().Msgf("Torq is setting up the desired states for nodeId: %v.", torqNode.NodeId)
 
@@ -624,6 +757,19 @@ func processTorqInitialBoot(db *sqlx.DB) {
 					if pingSystem&(*clnServiceType.GetPingSystem()) == 0 {
 						serviceStatus = services_helpers.Inactive
 					}
+				case services_helpers.ClnServiceForwardsService,
+					services_helpers.ClnServiceInvoicesService,
+					services_helpers.ClnServiceHtlcsService:
+					active := false
+					for _, cs := range clnServiceType.GetNodeConnectionDetailCustomSettings() {
+						if customSettings&cs != 0 {
+							active = true
+							break
+						}
+					}
+					if !active {
+						serviceStatus = services_helpers.Inactive
+					}
 				}
 				cache.SetDesiredNodeServiceState(clnServiceType, torqNode.NodeId, serviceStatus)
 			}
@@ -631,11 +777,11 @@ func processTorqInitialBoot(db *sqlx.DB) {
 		cache.SetNodeConnectionDetails(torqNode.NodeId, cache.NodeConnectionDetails{
 			Implementation:         implementation,
 			GRPCAddress:            grpcAddress,
-			TLSFileBytes:           tls,
-			MacaroonFileBytes:      macaroon,
-			CertificateFileBytes:   certificate,
-			KeyFileBytes:           key,
-			CaCertificateFileBytes: caCertificate,
+			TLSFileBytes:           nodeConnectionDetails.TLSDataBytes,
+			MacaroonFileBytes:      nodeConnectionDetails.MacaroonDataBytes,
+			CertificateFileBytes:   nodeConnectionDetails.CertificateDataBytes,
+			KeyFileBytes:           nodeConnectionDetails.KeyDataBytes,
+			CaCertificateFileBytes: nodeConnectionDetails.CaCertificateDataBytes,
 			CustomSettings:         customSettings,
 		})
 	}
@@ -832,6 +978,8 @@ func bootService(db *sqlx.DB, serviceType services_helpers.ServiceType, nodeId i
 		go subscribe.StartInFlightPaymentsService(ctx, conn, db, nodeId)
 	case services_helpers.LndServiceChannelBalanceCacheService:
 		go subscribe.StartChannelBalanceCacheMaintenance(ctx, conn, db, nodeId)
+	case services_helpers.LndServiceProbeService:
+		go services.StartProbeService(ctx, conn, db, nodeId)
 	// CLN NODE SPECIFIC
 	case services_helpers.ClnServiceVectorService:
 		go vector_ping.Start(ctx, conn, core.CLN, nodeId)
@@ -845,8 +993,16 @@ func bootService(db *sqlx.DB, serviceType services_helpers.ServiceType, nodeId i
 		go subscribe.StartFundsService(ctx, conn, db, nodeId)
 	case services_helpers.ClnServiceNodesService:
 		go subscribe.StartNodesService(ctx, conn, db, nodeId)
+	case services_helpers.ClnServiceForwardsService:
+		go subscribe.StartClnForwardsService(ctx, conn, db, nodeId)
+	case services_helpers.ClnServiceInvoicesService:
+		go subscribe.StartInvoicesService(ctx, conn, db, nodeId)
+	case services_helpers.ClnServiceHtlcsService:
+		go subscribe.StartHtlcsService(ctx, conn, db, nodeId)
 	case services_helpers.ClnServiceTransactionsService:
 		go subscribe.StartTransactionsService(ctx, conn, db, nodeId)
+	case services_helpers.ClnServiceRebalanceService:
+		go services.StartRebalanceService(ctx, conn, db, nodeId)
 	}
 }
 
@@ -862,7 +1018,8 @@ func isBootable(serviceType services_helpers.ServiceType, nodeId int) bool {
 		services_helpers.LndServicePaymentsService,
 		services_helpers.LndServicePeerEventStream,
 		services_helpers.LndServiceInFlightPaymentsService,
-		services_helpers.LndServiceChannelBalanceCacheService:
+		services_helpers.LndServiceChannelBalanceCacheService,
+		services_helpers.LndServiceProbeService:
 		nodeConnectionDetails := cache.GetNodeConnectionDetails(nodeId)
 		if nodeConnectionDetails.Implementation == core.LND &&
 			(nodeConnectionDetails.GRPCAddress == "" ||
@@ -879,7 +1036,11 @@ func isBootable(serviceType services_helpers.ServiceType, nodeId int) bool {
 		services_helpers.ClnServiceChannelsService,
 		services_helpers.ClnServiceFundsService,
 		services_helpers.ClnServiceNodesService,
-		services_helpers.ClnServiceTransactionsService:
+		services_helpers.ClnServiceForwardsService,
+		services_helpers.ClnServiceInvoicesService,
+		services_helpers.ClnServiceHtlcsService,
+		services_helpers.ClnServiceTransactionsService,
+		services_helpers.ClnServiceRebalanceService:
 		nodeConnectionDetails := cache.GetNodeConnectionDetails(nodeId)
 		if nodeConnectionDetails.Implementation == core.CLN &&
 			(nodeConnectionDetails.GRPCAddress == "" ||
@@ -0,0 +1,246 @@
+package audit
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/jmoiron/sqlx"
+	"github.com/rs/zerolog/log"
+
+	"github.com/lncapital/torq/internal/auth"
+	"github.com/lncapital/torq/internal/database"
+)
+
+type ActorType int
+
+const (
+	ActorUser = ActorType(iota + 1)
+	ActorApiToken
+	ActorWorkflow
+)
+
+const redacted = "[redacted]"
+
+// sensitiveKeys are (parts of) payload keys that are never stored in the audit log
+var sensitiveKeys = []string{"password", "macaroon", "tls", "token", "secret", "credentials", "seed"} //nolint:gochecknoglobals
+
+type AuditLog struct {
+	AuditLogId            int64     `json:"auditLogId" db:"audit_log_id"`
+	ActorType             ActorType `json:"actorType" db:"actor_type"`
+	Actor                 string    `json:"actor" db:"actor"`
+	UserAccountId         *int      `json:"userAccountId" db:"user_account_id"`
+	ApiTokenId            *int      `json:"apiTokenId" db:"api_token_id"`
+	WorkflowVersionNodeId *int      `json:"workflowVersionNodeId" db:"workflow_version_node_id"`
+	Action                string    `json:"action" db:"action"`
+	RequestPath           *string   `json:"requestPath" db:"request_path"`
+	NodeId                *int      `json:"nodeId" db:"node_id"`
+	ChannelId             *int      `json:"channelId" db:"channel_id"`
+	Payload               *string   `json:"payload" db:"payload"`
+	Success               bool      `json:"success" db:"success"`
+	StatusCode            *int      `json:"statusCode" db:"status_code"`
+	ErrorData             string    `json:"errorData" db:"error_data"`
+	CreatedOn             time.Time `json:"createdOn" db:"created_on"`
+}
+
+type AuditLogFilter struct {
+	From          time.Time
+	To            time.Time
+	ActorType     *ActorType
+	Actor         *string
+	Action        *string
+	NodeId        *int
+	ChannelId     *int
+	Success       *bool
+	MaximumResult int
+}
+
+// AddWorkflowAuditLog records an action performed by a workflow node
+func AddWorkflowAuditLog(db *sqlx.DB,
+	workflowVersionNodeId int,
+	workflowNodeName string,
+	action string,
+	nodeId int,
+	channelId int,
+	payload any,
+	actionError error) {
+
+	auditLog := AuditLog{
+		ActorType:             ActorWorkflow,
+		Actor:                 workflowNodeName,
+		WorkflowVersionNodeId: &workflowVersionNodeId,
+		Action:                action,
+		Payload:               marshalPayload(payload),
+		Success:               actionError == nil,
+	}
+	if nodeId != 0 {
+		auditLog.NodeId = &nodeId
+	}
+	if channelId != 0 {
+		auditLog.ChannelId = &channelId
+	}
+	if actionError != nil {
+		auditLog.ErrorData = actionError.Error()
+	}
+	addAuditLogAndLogError(db, auditLog)
+}
+
+// AddActorAuditLog records an action performed by a user or API token outside of the REST API (i.e. the websocket)
+func AddActorAuditLog(db *sqlx.DB,
+	actor auth.Actor,
+	action string,
+	nodeId int,
+	channelId int,
+	payload any,
+	actionError error) {
+
+	auditLog := AuditLog{
+		Action:  action,
+		Payload: marshalPayload(payload),
+		Success: actionError == nil,
+	}
+	setActor(&auditLog, actor)
+	if nodeId != 0 {
+		auditLog.NodeId = &nodeId
+	}
+	if channelId != 0 {
+		auditLog.ChannelId = &channelId
+	}
+	if actionError != nil {
+		auditLog.ErrorData = actionError.Error()
+	}
+	addAuditLogAndLogError(db, auditLog)
+}
+
+func setActor(auditLog *AuditLog, actor auth.Actor) {
+	auditLog.ActorType = ActorUser
+	auditLog.Actor = actor.Name
+	if actor.ApiTokenId != 0 {
+		auditLog.ActorType = ActorApiToken
+		auditLog.ApiTokenId = &actor.ApiTokenId
+	}
+	if actor.UserAccountId != 0 {
+		auditLog.UserAccountId = &actor.UserAccountId
+	}
+}
+
+func addAuditLogAndLogError(db *sqlx.DB, auditLog AuditLog) {
+	err := AddAuditLog(db, auditLog)
+	if err != nil {
+		log.Error().Err(err).Msgf("Storing the audit log for action: %v by %v", auditLog.Action, auditLog.Actor)
+	}
+}
+
+func AddAuditLog(db *sqlx.DB, auditLog AuditLog) error {
+	if auditLog.CreatedOn.IsZero() {
+		auditLog.CreatedOn = time.Now().UTC()
+	}
+	_, err := db.Exec(`
+		INSERT INTO audit_log (actor_type, actor, user_account_id, api_token_id, workflow_version_node_id, action,
+			request_path, node_id, channel_id, payload, success, status_code, error_data, created_on)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14);`,
+		auditLog.ActorType, auditLog.Actor, auditLog.UserAccountId, auditLog.ApiTokenId,
+		auditLog.WorkflowVersionNodeId, auditLog.Action, auditLog.RequestPath, auditLog.NodeId, auditLog.ChannelId,
+		auditLog.Payload, auditLog.Success, auditLog.StatusCode, auditLog.ErrorData, auditLog.CreatedOn)
+	if err != nil {
+		return errors.Wrap(err, database.SqlExecutionError)
+	}
+	return nil
+}
+
+func GetAuditLogs(db *sqlx.DB, filter AuditLogFilter) ([]AuditLog, error) {
+	auditLogs := []AuditLog{}
+	err := db.Select(&auditLogs, `
+		SELECT *
+		FROM audit_log
+		WHERE created_on>=$1 AND created_on<$2
+			AND ($3::INTEGER IS NULL OR actor_type=$3)
+			AND ($4::TEXT IS NULL OR actor=$4)
+			AND ($5::TEXT IS NULL OR action ILIKE '%' || $5 || '%')
+			AND ($6::INTEGER IS NULL OR node_id=$6)
+			AND ($7::INTEGER IS NULL OR channel_id=$7)
+			AND ($8::BOOLEAN IS NULL OR success=$8)
+		ORDER BY created_on DESC
+		LIMIT $9;`,
+		filter.From, filter.To, filter.ActorType, filter.Actor, filter.Action, filter.NodeId, filter.ChannelId,
+		filter.Success, filter.MaximumResult)
+	if err != nil {
+		return nil, errors.Wrap(err, database.SqlExecutionError)
+	}
+	return auditLogs, nil
+}
+
+func marshalPayload(payload any) *string {
+	if payload == nil {
+		return nil
+	}
+	payloadBytes, err := json.Marshal(payload)
+	if err != nil {
+		log.Error().Err(err).Msg("Marshalling the audit log payload")
+		return nil
+	}
+	return redactPayload(payloadBytes)
+}
+
+// redactPayload returns nil when the payload is not JSON
+func redactPayload(payload []byte) *string {
+	var unmarshalledPayload any
+	err := json.Unmarshal(payload, &unmarshalledPayload)
+	if err != nil {
+		return nil
+	}
+	payloadBytes, err := json.Marshal(redactValue(unmarshalledPayload))
+	if err != nil {
+		return nil
+	}
+	redactedPayload := string(payloadBytes)
+	return &redactedPayload
+}
+
+func redactValue(value any) any {
+	switch typedValue := value.(type) {
+	case map[string]any:
+		for key, mapValue := range typedValue {
+			if isSensitiveKey(key) {
+				typedValue[key] = redacted
+				continue
+			}
+			typedValue[key] = redactValue(mapValue)
+		}
+		return typedValue
+	case []any:
+		for index := range typedValue {
+			typedValue[index] = redactValue(typedValue[index])
+		}
+		return typedValue
+	}
+	return value
+}
+
+func isSensitiveKey(key string) bool {
+	lowerKey := strings.ToLower(key)
+	for _, sensitiveKey := range sensitiveKeys {
+		if strings.Contains(lowerKey, sensitiveKey) {
+			return true
+		}
+	}
+	return false
+}
+
+// getTarget returns the top level nodeId and channelId of the payload (0 when not found)
+func getTarget(payload any) (int, int) {
+	payloadMap, ok := payload.(map[string]any)
+	if !ok {
+		return 0, 0
+	}
+	return getIntValue(payloadMap["nodeId"]), getIntValue(payloadMap["channelId"])
+}
+
+func getIntValue(value any) int {
+	number, ok := value.(float64)
+	if !ok {
+		return 0
+	}
+	return int(number)
+}
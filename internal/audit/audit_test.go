@@ -0,0 +1,91 @@
+package audit
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/lncapital/torq/testutil"
+)
+
+func TestCreateRequestAuditLog(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	testCases := []struct {
+		name          string
+		path          string
+		body          string
+		contentType   string
+		wantAction    string
+		wantPayload   string
+		wantNodeId    int
+		wantChannelId int
+	}{
+		{
+			name:          "close channel",
+			path:          "/api/lightning/close",
+			body:          `{"nodeId":1,"channelId":42,"force":true}`,
+			contentType:   "application/json",
+			wantAction:    "POST /api/lightning/close",
+			wantPayload:   `{"channelId":42,"force":true,"nodeId":1}`,
+			wantNodeId:    1,
+			wantChannelId: 42,
+		},
+		{
+			name:        "redacted password",
+			path:        "/api/users",
+			body:        `{"username":"junior","password":"secret123","nested":[{"slackOAuthToken":"xoxb"}]}`,
+			contentType: "application/json",
+			wantAction:  "POST /api/users",
+			wantPayload: `{"nested":[{"slackOAuthToken":"[redacted]"}],"password":"[redacted]","username":"junior"}`,
+		},
+		{
+			name:        "path parameters",
+			path:        "/api/nodes/7",
+			wantAction:  "POST /api/nodes/:nodeId",
+			wantPayload: `{"$params":{"nodeId":"7"}}`,
+			wantNodeId:  7,
+		},
+		{
+			name:        "multipart",
+			path:        "/api/users",
+			body:        "--boundary\r\nmacaroon\r\n",
+			contentType: "multipart/form-data; boundary=boundary",
+			wantAction:  "POST /api/users",
+			wantPayload: `{"$contentType":"multipart/form-data"}`,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var got AuditLog
+			r := gin.New()
+			handler := func(c *gin.Context) {
+				got = createRequestAuditLog(c, []byte(tc.body), "")
+			}
+			r.POST("/api/lightning/close", handler)
+			r.POST("/api/users", handler)
+			r.POST("/api/nodes/:nodeId", handler)
+
+			request := httptest.NewRequest(http.MethodPost, tc.path, strings.NewReader(tc.body))
+			request.Header.Set("Content-Type", tc.contentType)
+			r.ServeHTTP(httptest.NewRecorder(), request)
+
+			gotNodeId := 0
+			if got.NodeId != nil {
+				gotNodeId = *got.NodeId
+			}
+			gotChannelId := 0
+			if got.ChannelId != nil {
+				gotChannelId = *got.ChannelId
+			}
+			if got.Action != tc.wantAction || got.Payload == nil || *got.Payload != tc.wantPayload ||
+				gotNodeId != tc.wantNodeId || gotChannelId != tc.wantChannelId || got.ActorType != ActorUser {
+				testutil.Errorf(t, "createRequestAuditLog() = %+v (payload %v)", got, got.Payload)
+				return
+			}
+			testutil.Successf(t, "createRequestAuditLog() = %v %v", got.Action, *got.Payload)
+		})
+	}
+}
@@ -0,0 +1,122 @@
+package audit
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/lncapital/torq/internal/auth"
+)
+
+const (
+	maximumPayloadBytes  = 64 * 1024
+	maximumErrorBytes    = 4 * 1024
+	unparsablePayloadKey = "$contentType"
+)
+
+// responseErrorWriter keeps the start of the response body so the error of failed requests can be stored
+type responseErrorWriter struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *responseErrorWriter) Write(data []byte) (int, error) {
+	if w.Status() >= http.StatusBadRequest && w.body.Len() < maximumErrorBytes {
+		remaining := maximumErrorBytes - w.body.Len()
+		if len(data) < remaining {
+			remaining = len(data)
+		}
+		w.body.Write(data[:remaining])
+	}
+	return w.ResponseWriter.Write(data)
+}
+
+// Required is a middleware that records every state changing (non GET) request in the audit log (use after AuthRequired)
+func Required(db *sqlx.DB) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.Request.Method == http.MethodGet || c.Request.Method == http.MethodHead ||
+			c.Request.Method == http.MethodOptions {
+			c.Next()
+			return
+		}
+
+		var requestBody []byte
+		if c.Request.Body != nil {
+			var err error
+			requestBody, err = io.ReadAll(io.LimitReader(c.Request.Body, maximumPayloadBytes+1))
+			if err != nil {
+				c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Unable to read the request body"})
+				return
+			}
+			// Restore the body (including anything beyond the audit limit) for the handlers
+			c.Request.Body = io.NopCloser(io.MultiReader(bytes.NewReader(requestBody), c.Request.Body))
+		}
+
+		writer := &responseErrorWriter{ResponseWriter: c.Writer}
+		c.Writer = writer
+
+		c.Next()
+
+		addAuditLogAndLogError(db, createRequestAuditLog(c, requestBody, writer.body.String()))
+	}
+}
+
+func createRequestAuditLog(c *gin.Context, requestBody []byte, responseError string) AuditLog {
+	statusCode := c.Writer.Status()
+	requestPath := c.Request.URL.Path
+	auditLog := AuditLog{
+		Action:      c.Request.Method + " " + c.FullPath(),
+		RequestPath: &requestPath,
+		Success:     statusCode < http.StatusBadRequest,
+		StatusCode:  &statusCode,
+	}
+	setActor(&auditLog, auth.GetActor(c))
+	if !auditLog.Success {
+		auditLog.ErrorData = responseError
+	}
+
+	var payload any
+	if len(requestBody) > maximumPayloadBytes || json.Unmarshal(requestBody, &payload) != nil {
+		// Not JSON (i.e. multipart uploads of node credentials) or too large: only record what was sent
+		payload = map[string]any{unparsablePayloadKey: c.ContentType()}
+	}
+	if len(requestBody) == 0 {
+		payload = nil
+	}
+	nodeId, channelId := getTarget(payload)
+	if nodeId == 0 {
+		nodeId, _ = strconv.Atoi(c.Param("nodeId"))
+	}
+	if channelId == 0 {
+		channelId, _ = strconv.Atoi(c.Param("channelId"))
+	}
+	if nodeId != 0 {
+		auditLog.NodeId = &nodeId
+	}
+	if channelId != 0 {
+		auditLog.ChannelId = &channelId
+	}
+	if len(c.Params) != 0 {
+		parameters := make(map[string]any)
+		for _, param := range c.Params {
+			parameters[param.Key] = param.Value
+		}
+		if payload == nil {
+			payload = map[string]any{}
+		}
+		if payloadMap, ok := payload.(map[string]any); ok {
+			payloadMap["$params"] = parameters
+		}
+	}
+	auditLog.Payload = marshalPayload(payload)
+	if auditLog.Action == c.Request.Method+" " {
+		auditLog.Action = c.Request.Method + " " + strings.TrimSuffix(requestPath, "/")
+	}
+	return auditLog
+}
@@ -0,0 +1,100 @@
+package audit
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/gin-gonic/gin"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/lncapital/torq/pkg/server_errors"
+)
+
+const (
+	defaultAuditLogCount = 100
+	maximumAuditLogCount = 1000
+)
+
+func RegisterAuditRoutes(r *gin.RouterGroup, db *sqlx.DB) {
+	// Optional filters: from and to (RFC3339), actorType, actor, action (partial match), nodeId, channelId,
+	// success and limit i.e. ?from=2023-05-01T00:00:00Z&action=close&success=true
+	r.GET("", func(c *gin.Context) { getAuditLogsHandler(c, db) })
+}
+
+func getAuditLogsHandler(c *gin.Context, db *sqlx.DB) {
+	filter, err := parseAuditLogFilter(c)
+	if err != nil {
+		server_errors.SendBadRequest(c, err.Error())
+		return
+	}
+	auditLogs, err := GetAuditLogs(db, filter)
+	if err != nil {
+		server_errors.WrapLogAndSendServerError(c, err, "Getting audit logs.")
+		return
+	}
+	c.JSON(http.StatusOK, auditLogs)
+}
+
+func parseAuditLogFilter(c *gin.Context) (AuditLogFilter, error) {
+	var err error
+	filter := AuditLogFilter{
+		To:            time.Now().UTC(),
+		MaximumResult: defaultAuditLogCount,
+	}
+	if c.Query("to") != "" {
+		filter.To, err = time.Parse(time.RFC3339, c.Query("to"))
+		if err != nil {
+			return AuditLogFilter{}, errors.New("Failed to parse to (RFC3339) in the request.")
+		}
+	}
+	filter.From = filter.To.AddDate(0, 0, -30)
+	if c.Query("from") != "" {
+		filter.From, err = time.Parse(time.RFC3339, c.Query("from"))
+		if err != nil {
+			return AuditLogFilter{}, errors.New("Failed to parse from (RFC3339) in the request.")
+		}
+	}
+	if c.Query("actorType") != "" {
+		actorType, err := strconv.Atoi(c.Query("actorType"))
+		if err != nil {
+			return AuditLogFilter{}, errors.New("Failed to parse actorType in the request.")
+		}
+		filter.ActorType = (*ActorType)(&actorType)
+	}
+	if actor := c.Query("actor"); actor != "" {
+		filter.Actor = &actor
+	}
+	if action := c.Query("action"); action != "" {
+		filter.Action = &action
+	}
+	if c.Query("nodeId") != "" {
+		nodeId, err := strconv.Atoi(c.Query("nodeId"))
+		if err != nil {
+			return AuditLogFilter{}, errors.New("Failed to parse nodeId in the request.")
+		}
+		filter.NodeId = &nodeId
+	}
+	if c.Query("channelId") != "" {
+		channelId, err := strconv.Atoi(c.Query("channelId"))
+		if err != nil {
+			return AuditLogFilter{}, errors.New("Failed to parse channelId in the request.")
+		}
+		filter.ChannelId = &channelId
+	}
+	if c.Query("success") != "" {
+		success, err := strconv.ParseBool(c.Query("success"))
+		if err != nil {
+			return AuditLogFilter{}, errors.New("Failed to parse success in the request.")
+		}
+		filter.Success = &success
+	}
+	if c.Query("limit") != "" {
+		filter.MaximumResult, err = strconv.Atoi(c.Query("limit"))
+		if err != nil || filter.MaximumResult <= 0 || filter.MaximumResult > maximumAuditLogCount {
+			return AuditLogFilter{}, errors.New("Failed to parse limit (1-1000) in the request.")
+		}
+	}
+	return filter, nil
+}
@@ -0,0 +1,224 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"golang.org/x/exp/slices"
+
+	"github.com/lncapital/torq/internal/database"
+)
+
+const (
+	apiTokenPrefix       = "torq_"
+	apiTokenPrefixLength = len(apiTokenPrefix) + 8
+	// apiTokenLastUsedInterval limits the last_used_on updates to one per interval instead of one per request
+	apiTokenLastUsedInterval = time.Minute
+)
+
+// ApiTokenScopes are the API areas (the first path element after /api) a token can be limited to.
+// User and API token management is never available through an API token.
+var ApiTokenScopes = []string{ //nolint:gochecknoglobals
+	"audit",
+	"automation",
+	"categories",
+	"channels",
+	"communications",
+	"corridors",
+	"flow",
+	"forwards",
+	"htlcs",
+	"invoices",
+	"lightning",
+	"messages",
+	"metrics",
+	"nodes",
+	"on-chain-tx",
+	"payments",
+	"peers",
+	"probes",
+	"services",
+	"settings",
+	"table-views",
+	"tags",
+	"workflows",
+	"ws",
+}
+
+type ApiToken struct {
+	ApiTokenId             int            `json:"apiTokenId" db:"api_token_id"`
+	Name                   string         `json:"name" db:"name"`
+	TokenPrefix            string         `json:"tokenPrefix" db:"token_prefix"`
+	TokenHash              string         `json:"-" db:"token_hash"`
+	Role                   Role           `json:"role" db:"role"`
+	Scopes                 pq.StringArray `json:"scopes" db:"scopes"`
+	CreatedByUserAccountId *int           `json:"createdByUserAccountId" db:"created_by_user_account_id"`
+	ExpiresOn              *time.Time     `json:"expiresOn" db:"expires_on"`
+	LastUsedOn             *time.Time     `json:"lastUsedOn" db:"last_used_on"`
+	RevokedOn              *time.Time     `json:"revokedOn" db:"revoked_on"`
+	CreatedOn              time.Time      `json:"createdOn" db:"created_on"`
+}
+
+type ApiTokenRequest struct {
+	Name      string     `json:"name"`
+	Role      Role       `json:"role"`
+	Scopes    []string   `json:"scopes"`
+	ExpiresOn *time.Time `json:"expiresOn"`
+}
+
+// NewApiToken is only returned on creation, afterwards the token can't be obtained anymore
+type NewApiToken struct {
+	ApiToken
+	Token string `json:"token"`
+}
+
+func hashApiToken(token string) string {
+	tokenHash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(tokenHash[:])
+}
+
+func generateApiToken() (string, error) {
+	tokenBytes := make([]byte, 32)
+	_, err := rand.Read(tokenBytes)
+	if err != nil {
+		return "", errors.Wrap(err, "Generating random token")
+	}
+	return apiTokenPrefix + hex.EncodeToString(tokenBytes), nil
+}
+
+// getApiTokenScope returns the scope of the request path (i.e. /api/forwards/... returns forwards)
+func getApiTokenScope(path string) string {
+	path = strings.TrimPrefix(path, "/")
+	path = strings.TrimPrefix(path, "api/")
+	scope, _, _ := strings.Cut(path, "/")
+	return scope
+}
+
+// isApiTokenScopeAllowed returns false for anything outside ApiTokenScopes, an empty scopes list allows all of them
+func isApiTokenScopeAllowed(scopes []string, scope string) bool {
+	if !slices.Contains(ApiTokenScopes, scope) {
+		return false
+	}
+	return len(scopes) == 0 || slices.Contains(scopes, scope)
+}
+
+func validateApiTokenRequest(apiTokenRequest ApiTokenRequest) error {
+	if strings.TrimSpace(apiTokenRequest.Name) == "" {
+		return errors.New("The name can't be empty.")
+	}
+	if !apiTokenRequest.Role.isValid() {
+		return errors.New(fmt.Sprintf("Unknown role: %v.", apiTokenRequest.Role))
+	}
+	for _, scope := range apiTokenRequest.Scopes {
+		if !slices.Contains(ApiTokenScopes, scope) {
+			return errors.New(fmt.Sprintf("Unknown scope: %v.", scope))
+		}
+	}
+	if apiTokenRequest.ExpiresOn != nil && apiTokenRequest.ExpiresOn.Before(time.Now()) {
+		return errors.New("The expiry date is in the past.")
+	}
+	return nil
+}
+
+func getApiTokens(db *sqlx.DB) ([]ApiToken, error) {
+	apiTokens := []ApiToken{}
+	err := db.Select(&apiTokens, `SELECT * FROM api_token ORDER BY created_on DESC;`)
+	if err != nil {
+		return nil, errors.Wrap(err, database.SqlExecutionError)
+	}
+	return apiTokens, nil
+}
+
+func addApiToken(db *sqlx.DB, apiTokenRequest ApiTokenRequest, createdByUserAccountId int) (NewApiToken, error) {
+	token, err := generateApiToken()
+	if err != nil {
+		return NewApiToken{}, err
+	}
+	scopes := apiTokenRequest.Scopes
+	if scopes == nil {
+		scopes = []string{}
+	}
+	apiToken := NewApiToken{
+		ApiToken: ApiToken{
+			Name:        apiTokenRequest.Name,
+			TokenPrefix: token[:apiTokenPrefixLength],
+			TokenHash:   hashApiToken(token),
+			Role:        apiTokenRequest.Role,
+			Scopes:      scopes,
+			ExpiresOn:   apiTokenRequest.ExpiresOn,
+			CreatedOn:   time.Now().UTC(),
+		},
+		Token: token,
+	}
+	if createdByUserAccountId != 0 {
+		apiToken.CreatedByUserAccountId = &createdByUserAccountId
+	}
+	err = db.QueryRowx(`
+		INSERT INTO api_token (name, token_prefix, token_hash, role, scopes, created_by_user_account_id,
+			expires_on, created_on)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING api_token_id;`,
+		apiToken.Name, apiToken.TokenPrefix, apiToken.TokenHash, apiToken.Role, apiToken.Scopes,
+		apiToken.CreatedByUserAccountId, apiToken.ExpiresOn, apiToken.CreatedOn).Scan(&apiToken.ApiTokenId)
+	if err != nil {
+		return NewApiToken{}, errors.Wrap(err, database.SqlExecutionError)
+	}
+	return apiToken, nil
+}
+
+// revokeApiToken keeps the token so it remains visible in the list
+func revokeApiToken(db *sqlx.DB, apiTokenId int) (int64, error) {
+	res, err := db.Exec(`UPDATE api_token SET revoked_on=$1 WHERE api_token_id=$2 AND revoked_on IS NULL;`,
+		time.Now().UTC(), apiTokenId)
+	if err != nil {
+		return 0, errors.Wrap(err, database.SqlExecutionError)
+	}
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, database.SqlAffectedRowsCheckError)
+	}
+	return rowsAffected, nil
+}
+
+func isApiTokenLastUsedOnOutdated(lastUsedOn *time.Time, now time.Time) bool {
+	return lastUsedOn == nil || now.Sub(*lastUsedOn) >= apiTokenLastUsedInterval
+}
+
+// useApiToken returns an empty token (ApiTokenId 0) when the token is unknown, revoked or expired
+func useApiToken(db *sqlx.DB, token string) (ApiToken, error) {
+	now := time.Now().UTC()
+	var apiToken ApiToken
+	err := db.Get(&apiToken, `
+		SELECT *
+		FROM api_token
+		WHERE token_hash=$1 AND revoked_on IS NULL AND (expires_on IS NULL OR expires_on>$2);`,
+		hashApiToken(token), now)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ApiToken{}, nil
+		}
+		return ApiToken{}, errors.Wrap(err, database.SqlExecutionError)
+	}
+	if !isApiTokenLastUsedOnOutdated(apiToken.LastUsedOn, now) {
+		return apiToken, nil
+	}
+	// The condition is repeated so concurrent requests don't all update the token
+	_, err = db.Exec(`
+		UPDATE api_token
+		SET last_used_on=$1
+		WHERE api_token_id=$2 AND (last_used_on IS NULL OR last_used_on<=$3);`,
+		now, apiToken.ApiTokenId, now.Add(-apiTokenLastUsedInterval))
+	if err != nil {
+		return ApiToken{}, errors.Wrap(err, database.SqlExecutionError)
+	}
+	apiToken.LastUsedOn = &now
+	return apiToken, nil
+}
@@ -0,0 +1,80 @@
+package auth
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lncapital/torq/testutil"
+)
+
+func TestIsApiTokenScopeAllowed(t *testing.T) {
+	testCases := []struct {
+		name   string
+		scopes []string
+		path   string
+		want   bool
+	}{
+		{name: "all scopes", path: "/api/forwards", want: true},
+		{name: "all scopes websocket", path: "/ws", want: true},
+		{name: "forwards only", scopes: []string{"forwards"}, path: "/api/forwards/summary", want: true},
+		{name: "forwards only payments", scopes: []string{"forwards"}, path: "/api/payments", want: false},
+		{name: "payments only", scopes: []string{"payments", "lightning"}, path: "/api/lightning/newinvoice", want: true},
+		{name: "metrics only", scopes: []string{"metrics"}, path: "/metrics", want: true},
+		{name: "metrics only forwards", scopes: []string{"metrics"}, path: "/api/forwards", want: false},
+		{name: "user management", path: "/api/users", want: false},
+		{name: "api token management", path: "/api/api-tokens/1", want: false},
+		{name: "unknown", path: "/api/unknown", want: false},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := isApiTokenScopeAllowed(tc.scopes, getApiTokenScope(tc.path))
+			if got != tc.want {
+				testutil.Errorf(t, "isApiTokenScopeAllowed(%v, %v) = %v, want %v", tc.scopes, tc.path, got, tc.want)
+				return
+			}
+			testutil.Successf(t, "isApiTokenScopeAllowed(%v, %v) = %v", tc.scopes, tc.path, got)
+		})
+	}
+}
+
+func TestGenerateApiToken(t *testing.T) {
+	token, err := generateApiToken()
+	if err != nil {
+		testutil.Fatalf(t, "generateApiToken() error = %v", err)
+	}
+	otherToken, err := generateApiToken()
+	if err != nil {
+		testutil.Fatalf(t, "generateApiToken() error = %v", err)
+	}
+	if token == otherToken || len(token) != len(apiTokenPrefix)+64 ||
+		hashApiToken(token) == hashApiToken(otherToken) {
+		testutil.Errorf(t, "generateApiToken() = %v and %v", token, otherToken)
+		return
+	}
+	testutil.Successf(t, "generateApiToken() = %v", token[:apiTokenPrefixLength])
+}
+
+func TestIsApiTokenLastUsedOnOutdated(t *testing.T) {
+	now := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+	justNow := now.Add(-10 * time.Second)
+	aMinuteAgo := now.Add(-apiTokenLastUsedInterval)
+	testCases := []struct {
+		name       string
+		lastUsedOn *time.Time
+		want       bool
+	}{
+		{name: "never used", want: true},
+		{name: "used just now", lastUsedOn: &justNow, want: false},
+		{name: "used a minute ago", lastUsedOn: &aMinuteAgo, want: true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := isApiTokenLastUsedOnOutdated(tc.lastUsedOn, now)
+			if got != tc.want {
+				testutil.Errorf(t, "isApiTokenLastUsedOnOutdated() = %v, want %v", got, tc.want)
+				return
+			}
+			testutil.Successf(t, "isApiTokenLastUsedOnOutdated() = %v", got)
+		})
+	}
+}
@@ -4,21 +4,36 @@ import (
 	"crypto/rand"
 	"crypto/subtle"
 	"encoding/hex"
+	"fmt"
 	"net/http"
 	"os"
 	"regexp"
 	"strings"
+	"time"
 
 	"github.com/cockroachdb/errors"
 	"github.com/gin-gonic/contrib/sessions"
 	"github.com/gin-gonic/gin"
+	"github.com/jmoiron/sqlx"
 	"github.com/rs/zerolog/log"
 
 	"github.com/lncapital/torq/internal/cache"
 	"github.com/lncapital/torq/internal/services_helpers"
 )
 
-const Userkey = "user"
+const (
+	Userkey          = "user"
+	UserAccountIdKey = "userAccountId"
+	// cookieLoginUser is the session user of the cookie file login, it always has the admin role
+	cookieLoginUser = "SSOUser"
+	roleKey         = "role"
+	actorKey        = "actor"
+	// The pending keys hold the user that passed the password step but still needs to pass the TOTP step
+	pendingUserkey          = "pendingUser"
+	pendingUserAccountIdKey = "pendingUserAccountId"
+	pendingOnKey            = "pendingOn"
+	pendingTotpTimeout      = 5 * time.Minute
+)
 
 func CreateSession(r *gin.Engine, apiPwd string) error {
 	cookiePwd := []byte(apiPwd)
@@ -72,15 +87,22 @@ func TorqRequired(c *gin.Context) {
 	c.Next()
 }
 
-// AuthRequired is a simple middleware to check the session
-func AuthRequired(autoLogin bool) gin.HandlerFunc {
+// AuthRequired is a simple middleware to check the session, it also stores the role of the user for RoleRequired
+func AuthRequired(autoLogin bool, db *sqlx.DB) gin.HandlerFunc {
 	return func(c *gin.Context) {
 
 		if autoLogin {
+			c.Set(roleKey, RoleAdmin)
+			c.Set(actorKey, Actor{Name: "auto-login"})
 			c.Next()
 			return
 		}
 
+		if token, exists := getBearerToken(c); exists {
+			apiTokenRequired(c, db, token)
+			return
+		}
+
 		session := sessions.Default(c)
 		user := session.Get(Userkey)
 		if user == nil {
@@ -88,13 +110,155 @@ func AuthRequired(autoLogin bool) gin.HandlerFunc {
 			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
 			return
 		}
+		role, err := getSessionRole(db, user, session.Get(UserAccountIdKey))
+		if err != nil {
+			log.Error().Err(err).Msg("Failed to obtain the role of the user")
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "Failed to obtain the role of the user"})
+			return
+		}
+		if role == 0 {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+			return
+		}
+		c.Set(roleKey, role)
+		actor := Actor{Name: fmt.Sprintf("%v", user)}
+		actor.UserAccountId, _ = session.Get(UserAccountIdKey).(int)
+		c.Set(actorKey, actor)
 		// Continue down the chain to handler etc
 		c.Next()
 	}
 }
 
-// Login creates a user session, logging them in given the right username and password
-func Login(apiPwd string) gin.HandlerFunc {
+func getBearerToken(c *gin.Context) (string, bool) {
+	authorization := c.GetHeader("Authorization")
+	if len(authorization) < len("Bearer ") || !strings.EqualFold(authorization[:len("Bearer ")], "Bearer ") {
+		return "", false
+	}
+	return strings.TrimSpace(authorization[len("Bearer "):]), true
+}
+
+// apiTokenRequired authenticates a request with an API token instead of a session
+func apiTokenRequired(c *gin.Context, db *sqlx.DB, token string) {
+	apiToken, err := useApiToken(db, token)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to verify the API token")
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "Failed to verify the API token"})
+		return
+	}
+	if apiToken.ApiTokenId == 0 {
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+	if !isApiTokenScopeAllowed(apiToken.Scopes, getApiTokenScope(c.Request.URL.Path)) {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "forbidden"})
+		return
+	}
+	c.Set(roleKey, apiToken.Role)
+	actor := Actor{Name: apiToken.Name, ApiTokenId: apiToken.ApiTokenId}
+	if apiToken.CreatedByUserAccountId != nil {
+		actor.ApiTokenCreatedByUserAccountId = *apiToken.CreatedByUserAccountId
+	}
+	c.Set(actorKey, actor)
+	c.Next()
+}
+
+// getSessionRole returns 0 when the session is no longer valid (i.e. the user was removed)
+func getSessionRole(db *sqlx.DB, user interface{}, userAccountId interface{}) (Role, error) {
+	if id, ok := userAccountId.(int); ok && id != 0 {
+		userAccount, err := getUser(db, id)
+		if err != nil {
+			return 0, err
+		}
+		return userAccount.Role, nil
+	}
+	if user == cookieLoginUser {
+		return RoleAdmin, nil
+	}
+	// The shared password session is only valid as long as there are no users
+	userCount, err := getUserCount(db)
+	if err != nil {
+		return 0, err
+	}
+	if userCount != 0 {
+		return 0, nil
+	}
+	return RoleAdmin, nil
+}
+
+// GetRole returns the role of the authenticated user or 0 when the request was not authenticated
+func GetRole(c *gin.Context) Role {
+	role, exists := c.Get(roleKey)
+	if !exists {
+		return 0
+	}
+	userRole, _ := role.(Role)
+	return userRole
+}
+
+// Actor is who performed the request, either a user (UserAccountId is 0 for the shared password or cookie login)
+// or an API token
+type Actor struct {
+	Name          string
+	UserAccountId int
+	ApiTokenId    int
+	// ApiTokenCreatedByUserAccountId is the user that created the API token (0 when unknown)
+	ApiTokenCreatedByUserAccountId int
+}
+
+// GetAccountableUserAccountId returns the user account of the user or of the creator of the API token
+// (0 when unknown) so the actions of a user and of its API tokens can be linked.
+func (actor Actor) GetAccountableUserAccountId() int {
+	if actor.UserAccountId != 0 {
+		return actor.UserAccountId
+	}
+	return actor.ApiTokenCreatedByUserAccountId
+}
+
+// GetActor returns the authenticated actor of the request (empty when the request was not authenticated)
+func GetActor(c *gin.Context) Actor {
+	actor, exists := c.Get(actorKey)
+	if !exists {
+		return Actor{}
+	}
+	authenticatedActor, _ := actor.(Actor)
+	return authenticatedActor
+}
+
+// HasRole returns true when the authenticated user has at least the required role
+func HasRole(c *gin.Context, requiredRole Role) bool {
+	return GetRole(c) >= requiredRole
+}
+
+// RoleRequired is a middleware that only allows users with at least the required role (use after AuthRequired)
+func RoleRequired(requiredRole Role) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !HasRole(c, requiredRole) {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "forbidden"})
+			return
+		}
+		c.Next()
+	}
+}
+
+// WriteRoleRequired is a middleware that allows reading (GET) for all users
+// but requires at least the required role for any other request (use after AuthRequired)
+func WriteRoleRequired(requiredRole Role) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.Request.Method == http.MethodGet || c.Request.Method == http.MethodHead {
+			c.Next()
+			return
+		}
+		if !HasRole(c, requiredRole) {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "forbidden"})
+			return
+		}
+		c.Next()
+	}
+}
+
+// Login creates a user session, logging them in given the right username and password.
+// As long as there are no users the username admin with the shared password (torq.password) is used.
+func Login(apiPwd string, db *sqlx.DB) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		session := sessions.Default(c)
 		username := c.PostForm("username")
@@ -106,15 +270,101 @@ func Login(apiPwd string) gin.HandlerFunc {
 			return
 		}
 
-		// Check for username and password match, usually from a database
-		if username != "admin" || password != apiPwd {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication failed"})
+		userCount, err := getUserCount(db)
+		if err != nil {
+			log.Error().Err(err).Msg("Unable to obtain the user count")
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to obtain the user count"})
+			return
+		}
+
+		userAccountId := 0
+		if userCount == 0 {
+			if username != "admin" || subtle.ConstantTimeCompare([]byte(password), []byte(apiPwd)) != 1 {
+				c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication failed"})
+				return
+			}
+		} else {
+			user, err := authenticateUser(db, username, password)
+			if err != nil {
+				log.Error().Err(err).Msg("Unable to authenticate the user")
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to authenticate the user"})
+				return
+			}
+			if user.UserAccountId == 0 {
+				c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication failed"})
+				return
+			}
+			userAccountId = user.UserAccountId
+		}
+
+		totp, err := getTotpConfiguration(db, userAccountId)
+		if err != nil {
+			log.Error().Err(err).Msg("Unable to obtain the two-factor authentication configuration")
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to obtain the two-factor authentication configuration"})
+			return
+		}
+		if totp.Enabled {
+			// The session only becomes valid after the second step (TotpLogin)
+			session.Delete(Userkey)
+			session.Delete(UserAccountIdKey)
+			session.Set(pendingUserkey, username)
+			session.Set(pendingUserAccountIdKey, userAccountId)
+			session.Set(pendingOnKey, time.Now().Unix())
+			if err := session.Save(); err != nil {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save session"})
+				return
+			}
+			c.JSON(http.StatusOK, gin.H{"message": "Two-factor authentication code required", "totpRequired": true})
 			return
 		}
 
 		// Save the username in the session
-		// set this to the users ID when moving to multi users setup
 		session.Set(Userkey, username)
+		session.Set(UserAccountIdKey, userAccountId)
+		if err := session.Save(); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save session"})
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"message": "Successfully authenticated user"})
+	}
+}
+
+// TotpLogin is the second login step when two-factor authentication is enabled,
+// it accepts a TOTP code or a recovery code
+func TotpLogin(db *sqlx.DB) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		session := sessions.Default(c)
+		code := strings.TrimSpace(c.PostForm("code"))
+		if code == "" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Parameters can't be empty"})
+			return
+		}
+
+		username, usernameExists := session.Get(pendingUserkey).(string)
+		userAccountId, userAccountIdExists := session.Get(pendingUserAccountIdKey).(int)
+		pendingOn, pendingOnExists := session.Get(pendingOnKey).(int64)
+		if !usernameExists || !userAccountIdExists || !pendingOnExists ||
+			time.Since(time.Unix(pendingOn, 0)) > pendingTotpTimeout {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Login expired, please login again"})
+			return
+		}
+
+		valid, err := verifySecondFactor(db, userAccountId, code)
+		if err != nil {
+			log.Error().Err(err).Msg("Unable to verify the two-factor authentication code")
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to verify the two-factor authentication code"})
+			return
+		}
+		if !valid {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication failed"})
+			return
+		}
+
+		session.Delete(pendingUserkey)
+		session.Delete(pendingUserAccountIdKey)
+		session.Delete(pendingOnKey)
+		session.Set(Userkey, username)
+		session.Set(UserAccountIdKey, userAccountId)
 		if err := session.Save(); err != nil {
 			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save session"})
 			return
@@ -162,8 +412,8 @@ func CookieLogin(cookiePath string) gin.HandlerFunc {
 		}
 
 		// Save the username in the session
-		// set this to the users ID when moving to multi users setup
-		session.Set(Userkey, "SSOUser")
+		session.Set(Userkey, cookieLoginUser)
+		session.Set(UserAccountIdKey, 0)
 		if err := session.Save(); err != nil {
 			log.Error().Err(err).Msg("Failed to save session")
 			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save session"})
@@ -177,6 +427,14 @@ func Logout(c *gin.Context) {
 	session := sessions.Default(c)
 
 	session.Delete(Userkey)
+	session.Delete(UserAccountIdKey)
+	session.Delete(pendingUserkey)
+	session.Delete(pendingUserAccountIdKey)
+	session.Delete(pendingOnKey)
+	if err := session.Save(); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save session"})
+		return
+	}
 
 	c.JSON(http.StatusOK, gin.H{"message": "Successfully logged out"})
 }
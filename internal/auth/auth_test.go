@@ -0,0 +1,52 @@
+package auth
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/lncapital/torq/testutil"
+)
+
+func TestRoleMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	testCases := []struct {
+		name       string
+		role       Role
+		method     string
+		path       string
+		wantStatus int
+	}{
+		{name: "viewer reads", role: RoleViewer, method: http.MethodGet, path: "/operator", wantStatus: http.StatusOK},
+		{name: "viewer writes", role: RoleViewer, method: http.MethodPost, path: "/operator", wantStatus: http.StatusForbidden},
+		{name: "operator writes", role: RoleOperator, method: http.MethodPost, path: "/operator", wantStatus: http.StatusOK},
+		{name: "admin writes", role: RoleAdmin, method: http.MethodPost, path: "/operator", wantStatus: http.StatusOK},
+		{name: "operator closes channel", role: RoleOperator, method: http.MethodPost, path: "/admin", wantStatus: http.StatusForbidden},
+		{name: "admin closes channel", role: RoleAdmin, method: http.MethodPost, path: "/admin", wantStatus: http.StatusOK},
+		{name: "operator reads admin only", role: RoleOperator, method: http.MethodGet, path: "/admin", wantStatus: http.StatusForbidden},
+		{name: "unauthenticated", method: http.MethodGet, path: "/admin", wantStatus: http.StatusForbidden},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := gin.New()
+			r.Use(func(c *gin.Context) {
+				if tc.role != 0 {
+					c.Set(roleKey, tc.role)
+				}
+			})
+			ok := func(c *gin.Context) { c.Status(http.StatusOK) }
+			r.Group("/operator", WriteRoleRequired(RoleOperator)).Handle(tc.method, "", ok)
+			r.Group("/admin", RoleRequired(RoleAdmin)).Handle(tc.method, "", ok)
+
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest(tc.method, tc.path, nil))
+			if w.Code != tc.wantStatus {
+				testutil.Errorf(t, "%v %v as %v = %v, want %v", tc.method, tc.path, tc.role, w.Code, tc.wantStatus)
+				return
+			}
+			testutil.Successf(t, "%v %v as %v = %v", tc.method, tc.path, tc.role, w.Code)
+		})
+	}
+}
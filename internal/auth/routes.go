@@ -0,0 +1,396 @@
+package auth
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/gin-gonic/contrib/sessions"
+	"github.com/gin-gonic/gin"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/lncapital/torq/internal/database"
+	"github.com/lncapital/torq/pkg/server_errors"
+)
+
+type currentUser struct {
+	UserAccountId int    `json:"userAccountId"`
+	Username      string `json:"username"`
+	Role          Role   `json:"role"`
+}
+
+type passwordRequest struct {
+	Password string `json:"password"`
+}
+
+// RegisterUserRoutes are the user management routes (admin only)
+func RegisterUserRoutes(r *gin.RouterGroup, db *sqlx.DB) {
+	r.GET("", func(c *gin.Context) { getUsersHandler(c, db) })
+	r.POST("", func(c *gin.Context) { addUserHandler(c, db) })
+	r.PUT("", func(c *gin.Context) { setUserHandler(c, db) })
+	r.DELETE(":userAccountId", func(c *gin.Context) { removeUserHandler(c, db) })
+}
+
+// RegisterApiTokenRoutes are the API token management routes (admin only)
+func RegisterApiTokenRoutes(r *gin.RouterGroup, db *sqlx.DB) {
+	r.GET("", func(c *gin.Context) { getApiTokensHandler(c, db) })
+	r.GET("scopes", func(c *gin.Context) { c.JSON(http.StatusOK, ApiTokenScopes) })
+	r.POST("", func(c *gin.Context) { addApiTokenHandler(c, db) })
+	r.DELETE(":apiTokenId", func(c *gin.Context) { revokeApiTokenHandler(c, db) })
+}
+
+// RegisterCurrentUserRoutes are the routes of the authenticated user (all roles)
+func RegisterCurrentUserRoutes(r *gin.RouterGroup, db *sqlx.DB) {
+	r.GET("me", func(c *gin.Context) { getCurrentUserHandler(c) })
+	r.PUT("me/password", func(c *gin.Context) { setCurrentUserPasswordHandler(c, db) })
+
+	// Two-factor authentication: enrol (secret and otpauth URI), confirm with a code (returns the recovery codes),
+	// regenerate the recovery codes and disable (both require a code)
+	r.GET("me/totp", func(c *gin.Context) { getTotpHandler(c, db) })
+	r.POST("me/totp", func(c *gin.Context) { enrolTotpHandler(c, db) })
+	r.POST("me/totp/confirm", func(c *gin.Context) { confirmTotpHandler(c, db) })
+	r.POST("me/totp/recovery-codes", func(c *gin.Context) { regenerateRecoveryCodesHandler(c, db) })
+	r.POST("me/totp/disable", func(c *gin.Context) { disableTotpHandler(c, db) })
+}
+
+func getUsersHandler(c *gin.Context, db *sqlx.DB) {
+	users, err := getUsers(db)
+	if err != nil {
+		server_errors.WrapLogAndSendServerError(c, err, "Getting users.")
+		return
+	}
+	c.JSON(http.StatusOK, users)
+}
+
+func validateUserRequest(userRequest UserRequest) error {
+	if strings.TrimSpace(userRequest.Username) == "" {
+		return errors.New("The username can't be empty.")
+	}
+	if !userRequest.Role.isValid() {
+		return errors.New(fmt.Sprintf("Unknown role: %v.", userRequest.Role))
+	}
+	return nil
+}
+
+func addUserHandler(c *gin.Context, db *sqlx.DB) {
+	var userRequest UserRequest
+	if err := c.BindJSON(&userRequest); err != nil {
+		server_errors.SendBadRequestFromError(c, errors.Wrap(err, server_errors.JsonParseError))
+		return
+	}
+	if err := validateUserRequest(userRequest); err != nil {
+		server_errors.SendUnprocessableEntityFromError(c, err)
+		return
+	}
+	user, err := addUser(db, userRequest)
+	if err != nil {
+		sendUserError(c, err, fmt.Sprintf("Adding user: %v", userRequest.Username))
+		return
+	}
+	c.JSON(http.StatusOK, user)
+}
+
+func setUserHandler(c *gin.Context, db *sqlx.DB) {
+	var userRequest UserRequest
+	if err := c.BindJSON(&userRequest); err != nil {
+		server_errors.SendBadRequestFromError(c, errors.Wrap(err, server_errors.JsonParseError))
+		return
+	}
+	if userRequest.UserAccountId == 0 {
+		server_errors.SendBadRequest(c, "Failed to find userAccountId in the request.")
+		return
+	}
+	if err := validateUserRequest(userRequest); err != nil {
+		server_errors.SendUnprocessableEntityFromError(c, err)
+		return
+	}
+	user, err := setUser(db, userRequest)
+	if err != nil {
+		sendUserError(c, err, fmt.Sprintf("Setting user for userAccountId: %v", userRequest.UserAccountId))
+		return
+	}
+	c.JSON(http.StatusOK, user)
+}
+
+func removeUserHandler(c *gin.Context, db *sqlx.DB) {
+	userAccountId, err := strconv.Atoi(c.Param("userAccountId"))
+	if err != nil {
+		server_errors.SendBadRequest(c, "Failed to find/parse userAccountId in the request.")
+		return
+	}
+	count, err := removeUser(db, userAccountId)
+	if err != nil {
+		sendUserError(c, err, fmt.Sprintf("Removing user for userAccountId: %v", userAccountId))
+		return
+	}
+	c.JSON(http.StatusOK, map[string]interface{}{"message": fmt.Sprintf("Successfully deleted %v user(s).", count)})
+}
+
+func getCurrentUserHandler(c *gin.Context) {
+	session := sessions.Default(c)
+	user := currentUser{}
+	if username, ok := session.Get(Userkey).(string); ok {
+		user.Username = username
+	}
+	if userAccountId, ok := session.Get(UserAccountIdKey).(int); ok {
+		user.UserAccountId = userAccountId
+	}
+	user.Role = GetRole(c)
+	c.JSON(http.StatusOK, user)
+}
+
+func setCurrentUserPasswordHandler(c *gin.Context, db *sqlx.DB) {
+	userAccountId, ok := sessions.Default(c).Get(UserAccountIdKey).(int)
+	if !ok || userAccountId == 0 {
+		server_errors.SendUnprocessableEntity(c, "The shared password can only be changed in the configuration.")
+		return
+	}
+	var request passwordRequest
+	if err := c.BindJSON(&request); err != nil {
+		server_errors.SendBadRequestFromError(c, errors.Wrap(err, server_errors.JsonParseError))
+		return
+	}
+	err := setUserPassword(db, userAccountId, request.Password)
+	if err != nil {
+		sendUserError(c, err, fmt.Sprintf("Setting password for userAccountId: %v", userAccountId))
+		return
+	}
+	c.JSON(http.StatusOK, map[string]interface{}{"message": "Successfully changed the password."})
+}
+
+func getApiTokensHandler(c *gin.Context, db *sqlx.DB) {
+	apiTokens, err := getApiTokens(db)
+	if err != nil {
+		server_errors.WrapLogAndSendServerError(c, err, "Getting API tokens.")
+		return
+	}
+	c.JSON(http.StatusOK, apiTokens)
+}
+
+func addApiTokenHandler(c *gin.Context, db *sqlx.DB) {
+	var apiTokenRequest ApiTokenRequest
+	if err := c.BindJSON(&apiTokenRequest); err != nil {
+		server_errors.SendBadRequestFromError(c, errors.Wrap(err, server_errors.JsonParseError))
+		return
+	}
+	if err := validateApiTokenRequest(apiTokenRequest); err != nil {
+		server_errors.SendUnprocessableEntityFromError(c, err)
+		return
+	}
+	userAccountId, _ := sessions.Default(c).Get(UserAccountIdKey).(int)
+	apiToken, err := addApiToken(db, apiTokenRequest, userAccountId)
+	if err != nil {
+		server_errors.WrapLogAndSendServerError(c, err, fmt.Sprintf("Adding API token: %v", apiTokenRequest.Name))
+		return
+	}
+	c.JSON(http.StatusOK, apiToken)
+}
+
+func revokeApiTokenHandler(c *gin.Context, db *sqlx.DB) {
+	apiTokenId, err := strconv.Atoi(c.Param("apiTokenId"))
+	if err != nil {
+		server_errors.SendBadRequest(c, "Failed to find/parse apiTokenId in the request.")
+		return
+	}
+	count, err := revokeApiToken(db, apiTokenId)
+	if err != nil {
+		server_errors.WrapLogAndSendServerError(c, err, fmt.Sprintf("Revoking API token for apiTokenId: %v", apiTokenId))
+		return
+	}
+	c.JSON(http.StatusOK, map[string]interface{}{"message": fmt.Sprintf("Successfully revoked %v API token(s).", count)})
+}
+
+type totpCodeRequest struct {
+	Code string `json:"code"`
+}
+
+type totpStatus struct {
+	Enabled                bool `json:"enabled"`
+	RemainingRecoveryCodes int  `json:"remainingRecoveryCodes"`
+}
+
+type totpEnrolment struct {
+	Secret string `json:"secret"`
+	Uri    string `json:"uri"`
+}
+
+type totpRecoveryCodes struct {
+	RecoveryCodes []string `json:"recoveryCodes"`
+}
+
+// getTotpUserAccountId returns false when two-factor authentication does not apply (API tokens),
+// userAccountId 0 is the shared password login
+func getTotpUserAccountId(c *gin.Context) (int, bool) {
+	actor := GetActor(c)
+	if actor.ApiTokenId != 0 {
+		server_errors.SendUnprocessableEntity(c, "Two-factor authentication is not available for API tokens.")
+		return 0, false
+	}
+	return actor.UserAccountId, true
+}
+
+func getTotpHandler(c *gin.Context, db *sqlx.DB) {
+	userAccountId, ok := getTotpUserAccountId(c)
+	if !ok {
+		return
+	}
+	totp, err := getTotpConfiguration(db, userAccountId)
+	if err != nil {
+		server_errors.WrapLogAndSendServerError(c, err, "Getting two-factor authentication configuration.")
+		return
+	}
+	c.JSON(http.StatusOK, totpStatus{Enabled: totp.Enabled, RemainingRecoveryCodes: len(totp.RecoveryCodeHashes)})
+}
+
+func enrolTotpHandler(c *gin.Context, db *sqlx.DB) {
+	userAccountId, ok := getTotpUserAccountId(c)
+	if !ok {
+		return
+	}
+	totp, err := getTotpConfiguration(db, userAccountId)
+	if err != nil {
+		server_errors.WrapLogAndSendServerError(c, err, "Getting two-factor authentication configuration.")
+		return
+	}
+	if totp.Enabled {
+		server_errors.SendUnprocessableEntity(c, "Two-factor authentication is already enabled, disable it first.")
+		return
+	}
+	secret, err := generateTotpSecret()
+	if err != nil {
+		server_errors.WrapLogAndSendServerError(c, err, "Generating two-factor authentication secret.")
+		return
+	}
+	totp = totpConfiguration{Secret: &secret}
+	err = setTotpConfiguration(db, userAccountId, totp)
+	if err != nil {
+		server_errors.WrapLogAndSendServerError(c, err, "Storing two-factor authentication secret.")
+		return
+	}
+	accountName := GetActor(c).Name
+	if userAccountId == 0 {
+		accountName = "admin"
+	}
+	c.JSON(http.StatusOK, totpEnrolment{Secret: secret, Uri: getTotpUri(secret, accountName)})
+}
+
+func confirmTotpHandler(c *gin.Context, db *sqlx.DB) {
+	userAccountId, ok := getTotpUserAccountId(c)
+	if !ok {
+		return
+	}
+	var request totpCodeRequest
+	if err := c.BindJSON(&request); err != nil {
+		server_errors.SendBadRequestFromError(c, errors.Wrap(err, server_errors.JsonParseError))
+		return
+	}
+	totp, err := getTotpConfiguration(db, userAccountId)
+	if err != nil {
+		server_errors.WrapLogAndSendServerError(c, err, "Getting two-factor authentication configuration.")
+		return
+	}
+	if totp.Enabled || totp.Secret == nil {
+		server_errors.SendUnprocessableEntity(c, "There is no two-factor authentication enrolment to confirm.")
+		return
+	}
+	step, valid := verifyTotpCode(*totp.Secret, request.Code, time.Now(), nil)
+	if !valid {
+		server_errors.SendUnprocessableEntity(c, "Invalid two-factor authentication code.")
+		return
+	}
+	recoveryCodes, recoveryCodeHashes, err := generateRecoveryCodes()
+	if err != nil {
+		server_errors.WrapLogAndSendServerError(c, err, "Generating recovery codes.")
+		return
+	}
+	totp.Enabled = true
+	totp.LastUsedStep = &step
+	totp.RecoveryCodeHashes = recoveryCodeHashes
+	err = setTotpConfiguration(db, userAccountId, totp)
+	if err != nil {
+		server_errors.WrapLogAndSendServerError(c, err, "Enabling two-factor authentication.")
+		return
+	}
+	c.JSON(http.StatusOK, totpRecoveryCodes{RecoveryCodes: recoveryCodes})
+}
+
+// verifyTotpCodeRequest sends the error response and returns false when the code is missing or invalid
+func verifyTotpCodeRequest(c *gin.Context, db *sqlx.DB, userAccountId int) bool {
+	var request totpCodeRequest
+	if err := c.BindJSON(&request); err != nil {
+		server_errors.SendBadRequestFromError(c, errors.Wrap(err, server_errors.JsonParseError))
+		return false
+	}
+	totp, err := getTotpConfiguration(db, userAccountId)
+	if err != nil {
+		server_errors.WrapLogAndSendServerError(c, err, "Getting two-factor authentication configuration.")
+		return false
+	}
+	if !totp.Enabled {
+		server_errors.SendUnprocessableEntity(c, "Two-factor authentication is not enabled.")
+		return false
+	}
+	valid, err := verifySecondFactor(db, userAccountId, request.Code)
+	if err != nil {
+		server_errors.WrapLogAndSendServerError(c, err, "Verifying two-factor authentication code.")
+		return false
+	}
+	if !valid {
+		server_errors.SendUnprocessableEntity(c, "Invalid two-factor authentication code.")
+		return false
+	}
+	return true
+}
+
+func regenerateRecoveryCodesHandler(c *gin.Context, db *sqlx.DB) {
+	userAccountId, ok := getTotpUserAccountId(c)
+	if !ok || !verifyTotpCodeRequest(c, db, userAccountId) {
+		return
+	}
+	totp, err := getTotpConfiguration(db, userAccountId)
+	if err != nil {
+		server_errors.WrapLogAndSendServerError(c, err, "Getting two-factor authentication configuration.")
+		return
+	}
+	recoveryCodes, recoveryCodeHashes, err := generateRecoveryCodes()
+	if err != nil {
+		server_errors.WrapLogAndSendServerError(c, err, "Generating recovery codes.")
+		return
+	}
+	totp.RecoveryCodeHashes = recoveryCodeHashes
+	err = setTotpConfiguration(db, userAccountId, totp)
+	if err != nil {
+		server_errors.WrapLogAndSendServerError(c, err, "Storing recovery codes.")
+		return
+	}
+	c.JSON(http.StatusOK, totpRecoveryCodes{RecoveryCodes: recoveryCodes})
+}
+
+func disableTotpHandler(c *gin.Context, db *sqlx.DB) {
+	userAccountId, ok := getTotpUserAccountId(c)
+	if !ok || !verifyTotpCodeRequest(c, db, userAccountId) {
+		return
+	}
+	err := setTotpConfiguration(db, userAccountId, totpConfiguration{})
+	if err != nil {
+		server_errors.WrapLogAndSendServerError(c, err, "Disabling two-factor authentication.")
+		return
+	}
+	c.JSON(http.StatusOK, map[string]interface{}{"message": "Successfully disabled two-factor authentication."})
+}
+
+// sendUserError sends validation failures as unprocessable entity and anything else as server error
+func sendUserError(c *gin.Context, err error, message string) {
+	if errors.Is(err, database.SqlUniqueConstraintError) {
+		server_errors.SendUnprocessableEntity(c, "A user with this username already exists.")
+		return
+	}
+	if errors.Is(err, errLastAdmin) || errors.Is(err, errInvalidPassword) {
+		server_errors.SendUnprocessableEntityFromError(c, err)
+		return
+	}
+	server_errors.WrapLogAndSendServerError(c, err, message)
+}
@@ -0,0 +1,277 @@
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1" //nolint:gosec // RFC 6238 (and authenticator apps) use HMAC-SHA1
+	"crypto/sha256"
+	"crypto/subtle"
+	"database/sql"
+	"encoding/base32"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+
+	"github.com/lncapital/torq/internal/database"
+	"github.com/lncapital/torq/internal/encryption"
+)
+
+const (
+	totpIssuer        = "Torq"
+	totpPeriodSeconds = 30
+	totpDigits        = 6
+	// totpSkewSteps allows the code of the previous and next period to compensate for clock drift
+	totpSkewSteps     = 1
+	recoveryCodeCount = 10
+)
+
+// totpConfiguration is stored in the settings table for the shared password login (userAccountId 0)
+// and in the user_account table for the user accounts.
+// The secret is stored encrypted (StoredSecret), Secret is the plaintext.
+type totpConfiguration struct {
+	Secret             *string        `db:"-"`
+	StoredSecret       []byte         `db:"totp_secret"`
+	Enabled            bool           `db:"totp_enabled"`
+	RecoveryCodeHashes pq.StringArray `db:"totp_recovery_code_hashes"`
+	LastUsedStep       *int64         `db:"totp_last_used_step"`
+}
+
+var totpEncoding = base32.StdEncoding.WithPadding(base32.NoPadding) //nolint:gochecknoglobals
+
+func generateTotpSecret() (string, error) {
+	secret := make([]byte, 20)
+	_, err := rand.Read(secret)
+	if err != nil {
+		return "", errors.Wrap(err, "Generating random TOTP secret")
+	}
+	return totpEncoding.EncodeToString(secret), nil
+}
+
+// getTotpUri returns the otpauth URI that authenticator apps import (usually as QR code)
+func getTotpUri(secret string, accountName string) string {
+	parameters := url.Values{}
+	parameters.Set("secret", secret)
+	parameters.Set("issuer", totpIssuer)
+	parameters.Set("algorithm", "SHA1")
+	parameters.Set("digits", fmt.Sprintf("%v", totpDigits))
+	parameters.Set("period", fmt.Sprintf("%v", totpPeriodSeconds))
+	return fmt.Sprintf("otpauth://totp/%v:%v?%v",
+		url.PathEscape(totpIssuer), url.PathEscape(accountName), parameters.Encode())
+}
+
+func getTotpStep(now time.Time) int64 {
+	return now.Unix() / totpPeriodSeconds
+}
+
+// getTotpCode calculates the code of a step as defined in RFC 4226 and RFC 6238
+func getTotpCode(secret string, step int64) (string, error) {
+	key, err := totpEncoding.DecodeString(strings.ToUpper(secret))
+	if err != nil {
+		return "", errors.Wrap(err, "Decoding TOTP secret")
+	}
+	counter := make([]byte, 8)
+	binary.BigEndian.PutUint64(counter, uint64(step))
+	mac := hmac.New(sha1.New, key)
+	mac.Write(counter)
+	sum := mac.Sum(nil)
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+	modulo := uint32(1)
+	for i := 0; i < totpDigits; i++ {
+		modulo *= 10
+	}
+	return fmt.Sprintf("%0*d", totpDigits, value%modulo), nil
+}
+
+// verifyTotpCode returns the matching step, steps up to lastUsedStep are rejected so a code can't be used twice
+func verifyTotpCode(secret string, code string, now time.Time, lastUsedStep *int64) (int64, bool) {
+	code = strings.ReplaceAll(code, " ", "")
+	if len(code) != totpDigits {
+		return 0, false
+	}
+	currentStep := getTotpStep(now)
+	for step := currentStep - totpSkewSteps; step <= currentStep+totpSkewSteps; step++ {
+		if lastUsedStep != nil && step <= *lastUsedStep {
+			continue
+		}
+		expectedCode, err := getTotpCode(secret, step)
+		if err != nil {
+			return 0, false
+		}
+		if subtle.ConstantTimeCompare([]byte(expectedCode), []byte(code)) == 1 {
+			return step, true
+		}
+	}
+	return 0, false
+}
+
+func normalizeRecoveryCode(code string) string {
+	return strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(code))
+}
+
+func hashRecoveryCode(code string) string {
+	codeHash := sha256.Sum256([]byte(normalizeRecoveryCode(code)))
+	return hex.EncodeToString(codeHash[:])
+}
+
+// generateRecoveryCodes returns the codes (only shown once) and the hashes (to store)
+func generateRecoveryCodes() ([]string, []string, error) {
+	var codes []string
+	var codeHashes []string
+	for i := 0; i < recoveryCodeCount; i++ {
+		randomBytes := make([]byte, 5)
+		_, err := rand.Read(randomBytes)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "Generating random recovery code")
+		}
+		code := hex.EncodeToString(randomBytes)
+		code = code[:5] + "-" + code[5:]
+		codes = append(codes, code)
+		codeHashes = append(codeHashes, hashRecoveryCode(code))
+	}
+	return codes, codeHashes, nil
+}
+
+// verifySecondFactor accepts a TOTP code or an (unused) recovery code and stores that it was used.
+// The code is consumed with a conditional update so concurrent logins can't use the same code twice.
+func verifySecondFactor(db *sqlx.DB, userAccountId int, code string) (bool, error) {
+	configuration, err := getTotpConfiguration(db, userAccountId)
+	if err != nil {
+		return false, err
+	}
+	if configuration.Secret == nil {
+		return false, nil
+	}
+	step, valid := verifyTotpCode(*configuration.Secret, code, time.Now(), configuration.LastUsedStep)
+	if valid {
+		return consumeSecondFactor(db, userAccountId,
+			"totp_last_used_step=$1", "(totp_last_used_step IS NULL OR totp_last_used_step<$1)", step)
+	}
+	codeHash := hashRecoveryCode(code)
+	for _, recoveryCodeHash := range configuration.RecoveryCodeHashes {
+		if subtle.ConstantTimeCompare([]byte(recoveryCodeHash), []byte(codeHash)) == 1 {
+			return consumeSecondFactor(db, userAccountId,
+				"totp_recovery_code_hashes=array_remove(totp_recovery_code_hashes, $1)",
+				"$1=ANY(totp_recovery_code_hashes)", recoveryCodeHash)
+		}
+	}
+	return false, nil
+}
+
+// consumeSecondFactor returns false when the condition no longer holds (the code was used concurrently)
+func consumeSecondFactor(db *sqlx.DB, userAccountId int, set string, condition string, value any) (bool, error) {
+	query := `UPDATE settings SET ` + set + `, updated_on=$2 WHERE ` + condition + `;`
+	args := []any{value, time.Now().UTC()}
+	if userAccountId != 0 {
+		query = `UPDATE user_account SET ` + set + `, updated_on=$2 WHERE user_account_id=$3 AND ` + condition + `;`
+		args = append(args, userAccountId)
+	}
+	res, err := db.Exec(query, args...)
+	if err != nil {
+		return false, errors.Wrap(err, database.SqlExecutionError)
+	}
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return false, errors.Wrap(err, database.SqlAffectedRowsCheckError)
+	}
+	return rowsAffected != 0, nil
+}
+
+func getTotpConfiguration(db *sqlx.DB, userAccountId int) (totpConfiguration, error) {
+	var configuration totpConfiguration
+	var err error
+	if userAccountId == 0 {
+		err = db.Get(&configuration, `
+			SELECT totp_secret, totp_enabled, totp_recovery_code_hashes, totp_last_used_step
+			FROM settings
+			LIMIT 1;`)
+	} else {
+		err = db.Get(&configuration, `
+			SELECT totp_secret, totp_enabled, totp_recovery_code_hashes, totp_last_used_step
+			FROM user_account
+			WHERE user_account_id=$1;`, userAccountId)
+	}
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return totpConfiguration{}, nil
+		}
+		return totpConfiguration{}, errors.Wrap(err, database.SqlExecutionError)
+	}
+	if configuration.StoredSecret != nil {
+		secret, err := encryption.Decrypt(configuration.StoredSecret)
+		if err != nil {
+			return totpConfiguration{}, errors.Wrap(err, "Decrypting TOTP secret")
+		}
+		plaintextSecret := string(secret)
+		configuration.Secret = &plaintextSecret
+	}
+	return configuration, nil
+}
+
+func setTotpConfiguration(db *sqlx.DB, userAccountId int, configuration totpConfiguration) error {
+	if configuration.RecoveryCodeHashes == nil {
+		configuration.RecoveryCodeHashes = pq.StringArray{}
+	}
+	configuration.StoredSecret = nil
+	if configuration.Secret != nil {
+		storedSecret, err := encryption.Encrypt([]byte(*configuration.Secret))
+		if err != nil {
+			return errors.Wrap(err, "Encrypting TOTP secret")
+		}
+		configuration.StoredSecret = storedSecret
+	}
+	var err error
+	if userAccountId == 0 {
+		_, err = db.Exec(`
+			UPDATE settings
+			SET totp_secret=$1, totp_enabled=$2, totp_recovery_code_hashes=$3, totp_last_used_step=$4, updated_on=$5;`,
+			configuration.StoredSecret, configuration.Enabled, configuration.RecoveryCodeHashes, configuration.LastUsedStep,
+			time.Now().UTC())
+	} else {
+		_, err = db.Exec(`
+			UPDATE user_account
+			SET totp_secret=$1, totp_enabled=$2, totp_recovery_code_hashes=$3, totp_last_used_step=$4, updated_on=$5
+			WHERE user_account_id=$6;`,
+			configuration.StoredSecret, configuration.Enabled, configuration.RecoveryCodeHashes, configuration.LastUsedStep,
+			time.Now().UTC(), userAccountId)
+	}
+	if err != nil {
+		return errors.Wrap(err, database.SqlExecutionError)
+	}
+	return nil
+}
+
+// EncryptTotpSecrets encrypts plaintext TOTP secrets and rewraps secrets that were encrypted with a previous
+// master key. It returns the amount of secrets that were updated.
+func EncryptTotpSecrets(db *sqlx.DB) (int, error) {
+	updated := 0
+	for _, table := range []string{"settings", "user_account"} {
+		var storedSecrets [][]byte
+		err := db.Select(&storedSecrets, `SELECT totp_secret FROM `+table+` WHERE totp_secret IS NOT NULL;`)
+		if err != nil {
+			return 0, errors.Wrap(err, database.SqlExecutionError)
+		}
+		for _, storedSecret := range storedSecrets {
+			if !encryption.NeedsRotation(storedSecret) {
+				continue
+			}
+			rotatedSecret, err := encryption.Rotate(storedSecret)
+			if err != nil {
+				return 0, errors.Wrapf(err, "Encrypting TOTP secret in %v", table)
+			}
+			_, err = db.Exec(`UPDATE `+table+` SET totp_secret=$1 WHERE totp_secret=$2;`, rotatedSecret, storedSecret)
+			if err != nil {
+				return 0, errors.Wrap(err, database.SqlExecutionError)
+			}
+			updated++
+		}
+	}
+	return updated, nil
+}
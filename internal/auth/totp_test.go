@@ -0,0 +1,74 @@
+package auth
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lncapital/torq/testutil"
+)
+
+// rfc6238Secret is the SHA1 test secret of RFC 6238 (12345678901234567890) in base32
+const rfc6238Secret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestVerifyTotpCode(t *testing.T) {
+	usedStep := getTotpStep(time.Unix(1111111109, 0))
+	testCases := []struct {
+		name         string
+		now          int64
+		code         string
+		lastUsedStep *int64
+		want         bool
+	}{
+		{name: "RFC 6238 59", now: 59, code: "287082", want: true},
+		{name: "RFC 6238 1111111109", now: 1111111109, code: "081804", want: true},
+		{name: "RFC 6238 1234567890", now: 1234567890, code: "005924", want: true},
+		{name: "with spaces", now: 1234567890, code: "005 924", want: true},
+		{name: "previous period", now: 1234567890 + totpPeriodSeconds, code: "005924", want: true},
+		{name: "expired", now: 1234567890 + 3*totpPeriodSeconds, code: "005924", want: false},
+		{name: "wrong code", now: 59, code: "287083", want: false},
+		{name: "wrong length", now: 59, code: "94287082", want: false},
+		{name: "replayed", now: 1111111109, code: "081804", lastUsedStep: &usedStep, want: false},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, got := verifyTotpCode(rfc6238Secret, tc.code, time.Unix(tc.now, 0), tc.lastUsedStep)
+			if got != tc.want {
+				testutil.Errorf(t, "verifyTotpCode(%v, %v) = %v, want %v", tc.code, tc.now, got, tc.want)
+				return
+			}
+			testutil.Successf(t, "verifyTotpCode(%v, %v) = %v", tc.code, tc.now, got)
+		})
+	}
+}
+
+func TestTotpEnrolment(t *testing.T) {
+	secret, err := generateTotpSecret()
+	if err != nil {
+		testutil.Fatalf(t, "generateTotpSecret() error = %v", err)
+	}
+	code, err := getTotpCode(secret, getTotpStep(time.Now()))
+	if err != nil {
+		testutil.Fatalf(t, "getTotpCode() error = %v", err)
+	}
+	if _, valid := verifyTotpCode(secret, code, time.Now(), nil); !valid {
+		testutil.Errorf(t, "verifyTotpCode() rejected the generated code %v", code)
+		return
+	}
+	uri := getTotpUri(secret, "junior operator")
+	if !strings.HasPrefix(uri, "otpauth://totp/Torq:junior%20operator?") || !strings.Contains(uri, "secret="+secret) {
+		testutil.Errorf(t, "getTotpUri() = %v", uri)
+		return
+	}
+	recoveryCodes, recoveryCodeHashes, err := generateRecoveryCodes()
+	if err != nil {
+		testutil.Fatalf(t, "generateRecoveryCodes() error = %v", err)
+	}
+	if len(recoveryCodes) != recoveryCodeCount ||
+		hashRecoveryCode(strings.ToUpper(recoveryCodes[0])) != recoveryCodeHashes[0] ||
+		hashRecoveryCode(strings.ReplaceAll(recoveryCodes[1], "-", "")) != recoveryCodeHashes[1] {
+		testutil.Errorf(t, "generateRecoveryCodes() = %v", recoveryCodes)
+		return
+	}
+	testutil.Successf(t, "getTotpUri() = %v", uri)
+}
@@ -0,0 +1,323 @@
+package auth
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/lncapital/torq/internal/database"
+)
+
+type Role int
+
+const (
+	RoleViewer = Role(iota + 1)
+	RoleOperator
+	RoleAdmin
+)
+
+const minimumPasswordLength = 8
+
+func (r Role) isValid() bool {
+	return r >= RoleViewer && r <= RoleAdmin
+}
+
+func (r Role) String() string {
+	switch r {
+	case RoleViewer:
+		return "viewer"
+	case RoleOperator:
+		return "operator"
+	case RoleAdmin:
+		return "admin"
+	}
+	return "unknown"
+}
+
+type User struct {
+	UserAccountId int    `json:"userAccountId" db:"user_account_id"`
+	Username      string `json:"username" db:"username"`
+	PasswordHash  string `json:"-" db:"password_hash"`
+	Role          Role   `json:"role" db:"role"`
+	TotpEnabled   bool   `json:"totpEnabled" db:"totp_enabled"`
+	// TelegramUserId and SlackUserId link the chat users to the account for the chat approvals
+	TelegramUserId *string   `json:"telegramUserId" db:"telegram_user_id"`
+	SlackUserId    *string   `json:"slackUserId" db:"slack_user_id"`
+	CreatedOn      time.Time `json:"createdOn" db:"created_on"`
+	UpdatedOn      time.Time `json:"updatedOn" db:"updated_on"`
+}
+
+type UserRequest struct {
+	UserAccountId  int     `json:"userAccountId"`
+	Username       string  `json:"username"`
+	Password       *string `json:"password"`
+	Role           Role    `json:"role"`
+	TelegramUserId *string `json:"telegramUserId"`
+	SlackUserId    *string `json:"slackUserId"`
+}
+
+var (
+	errLastAdmin       = errors.New("At least one admin user is required.") //nolint:gochecknoglobals
+	errInvalidPassword = errors.New("Invalid password.")                    //nolint:gochecknoglobals
+)
+
+func hashPassword(password string) (string, error) {
+	if len(password) < minimumPasswordLength {
+		return "", errors.Mark(errors.New(fmt.Sprintf("The password needs to be at least %v characters long.",
+			minimumPasswordLength)), errInvalidPassword)
+	}
+	passwordHash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return "", errors.Wrap(err, "Hashing the password")
+	}
+	return string(passwordHash), nil
+}
+
+func getUsers(db *sqlx.DB) ([]User, error) {
+	users := []User{}
+	err := db.Select(&users, `
+		SELECT user_account_id, username, password_hash, role, totp_enabled, telegram_user_id, slack_user_id,
+			created_on, updated_on
+		FROM user_account
+		ORDER BY username;`)
+	if err != nil {
+		return nil, errors.Wrap(err, database.SqlExecutionError)
+	}
+	return users, nil
+}
+
+func getUserCount(db *sqlx.DB) (int, error) {
+	var userCount int
+	err := db.Get(&userCount, `SELECT COUNT(*) FROM user_account;`)
+	if err != nil {
+		return 0, errors.Wrap(err, database.SqlExecutionError)
+	}
+	return userCount, nil
+}
+
+// getUserByUsername returns an empty user (UserAccountId 0) when the username does not exist
+func getUserByUsername(db *sqlx.DB, username string) (User, error) {
+	var user User
+	err := db.Get(&user, `
+		SELECT user_account_id, username, password_hash, role, totp_enabled, telegram_user_id, slack_user_id,
+			created_on, updated_on
+		FROM user_account
+		WHERE username=$1;`, username)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return User{}, nil
+		}
+		return User{}, errors.Wrap(err, database.SqlExecutionError)
+	}
+	return user, nil
+}
+
+// GetUserByTelegramUserId returns an empty user (UserAccountId 0) when the Telegram user is not linked
+func GetUserByTelegramUserId(db *sqlx.DB, telegramUserId string) (User, error) {
+	return getUserByChatUserId(db, "telegram_user_id", telegramUserId)
+}
+
+// GetUserBySlackUserId returns an empty user (UserAccountId 0) when the Slack user is not linked
+func GetUserBySlackUserId(db *sqlx.DB, slackUserId string) (User, error) {
+	return getUserByChatUserId(db, "slack_user_id", slackUserId)
+}
+
+func getUserByChatUserId(db *sqlx.DB, column string, chatUserId string) (User, error) {
+	if chatUserId == "" {
+		return User{}, nil
+	}
+	var user User
+	err := db.Get(&user, `
+		SELECT user_account_id, username, password_hash, role, totp_enabled, telegram_user_id, slack_user_id,
+			created_on, updated_on
+		FROM user_account
+		WHERE `+column+`=$1;`, chatUserId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return User{}, nil
+		}
+		return User{}, errors.Wrap(err, database.SqlExecutionError)
+	}
+	return user, nil
+}
+
+// getUser returns an empty user (UserAccountId 0) when the user does not exist
+func getUser(db *sqlx.DB, userAccountId int) (User, error) {
+	var user User
+	err := db.Get(&user, `
+		SELECT user_account_id, username, password_hash, role, totp_enabled, telegram_user_id, slack_user_id,
+			created_on, updated_on
+		FROM user_account
+		WHERE user_account_id=$1;`, userAccountId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return User{}, nil
+		}
+		return User{}, errors.Wrap(err, database.SqlExecutionError)
+	}
+	return user, nil
+}
+
+func addUser(db *sqlx.DB, userRequest UserRequest) (User, error) {
+	if userRequest.Password == nil {
+		return User{}, errors.Mark(errors.New("A password is required."), errInvalidPassword)
+	}
+	if userRequest.Role != RoleAdmin {
+		// The shared password stops working once users exist so the first user needs to be an admin
+		err := verifyOtherAdminExists(db, 0)
+		if err != nil {
+			return User{}, err
+		}
+	}
+	passwordHash, err := hashPassword(*userRequest.Password)
+	if err != nil {
+		return User{}, err
+	}
+	user := User{
+		Username:       userRequest.Username,
+		PasswordHash:   passwordHash,
+		Role:           userRequest.Role,
+		TelegramUserId: getChatUserId(userRequest.TelegramUserId),
+		SlackUserId:    getChatUserId(userRequest.SlackUserId),
+		CreatedOn:      time.Now().UTC(),
+	}
+	user.UpdatedOn = user.CreatedOn
+	err = db.QueryRowx(`
+		INSERT INTO user_account (username, password_hash, role, telegram_user_id, slack_user_id, created_on, updated_on)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING user_account_id;`,
+		user.Username, user.PasswordHash, user.Role, user.TelegramUserId, user.SlackUserId,
+		user.CreatedOn, user.UpdatedOn).Scan(&user.UserAccountId)
+	if err != nil {
+		if err, ok := err.(*pq.Error); ok {
+			if err.Code == "23505" {
+				return User{}, database.SqlUniqueConstraintError
+			}
+		}
+		return User{}, errors.Wrap(err, database.SqlExecutionError)
+	}
+	return user, nil
+}
+
+// setUser updates the username and role and only updates the password when one is provided
+func setUser(db *sqlx.DB, userRequest UserRequest) (User, error) {
+	user, err := getUser(db, userRequest.UserAccountId)
+	if err != nil {
+		return User{}, err
+	}
+	if user.UserAccountId == 0 {
+		return User{}, errors.Wrap(sql.ErrNoRows, database.SqlExecutionError)
+	}
+	if user.Role == RoleAdmin && userRequest.Role != RoleAdmin {
+		err = verifyOtherAdminExists(db, user.UserAccountId)
+		if err != nil {
+			return User{}, err
+		}
+	}
+	if userRequest.Password != nil {
+		user.PasswordHash, err = hashPassword(*userRequest.Password)
+		if err != nil {
+			return User{}, err
+		}
+	}
+	user.Username = userRequest.Username
+	user.Role = userRequest.Role
+	user.TelegramUserId = getChatUserId(userRequest.TelegramUserId)
+	user.SlackUserId = getChatUserId(userRequest.SlackUserId)
+	user.UpdatedOn = time.Now().UTC()
+	_, err = db.Exec(`
+		UPDATE user_account
+		SET username=$1, password_hash=$2, role=$3, telegram_user_id=$4, slack_user_id=$5, updated_on=$6
+		WHERE user_account_id=$7;`,
+		user.Username, user.PasswordHash, user.Role, user.TelegramUserId, user.SlackUserId, user.UpdatedOn,
+		user.UserAccountId)
+	if err != nil {
+		if err, ok := err.(*pq.Error); ok {
+			if err.Code == "23505" {
+				return User{}, database.SqlUniqueConstraintError
+			}
+		}
+		return User{}, errors.Wrap(err, database.SqlExecutionError)
+	}
+	return user, nil
+}
+
+// getChatUserId returns nil for an empty chat user id so multiple users can be unlinked
+func getChatUserId(chatUserId *string) *string {
+	if chatUserId == nil || strings.TrimSpace(*chatUserId) == "" {
+		return nil
+	}
+	trimmed := strings.TrimSpace(*chatUserId)
+	return &trimmed
+}
+
+func setUserPassword(db *sqlx.DB, userAccountId int, password string) error {
+	passwordHash, err := hashPassword(password)
+	if err != nil {
+		return err
+	}
+	_, err = db.Exec(`UPDATE user_account SET password_hash=$1, updated_on=$2 WHERE user_account_id=$3;`,
+		passwordHash, time.Now().UTC(), userAccountId)
+	if err != nil {
+		return errors.Wrap(err, database.SqlExecutionError)
+	}
+	return nil
+}
+
+func removeUser(db *sqlx.DB, userAccountId int) (int64, error) {
+	user, err := getUser(db, userAccountId)
+	if err != nil {
+		return 0, err
+	}
+	if user.Role == RoleAdmin {
+		err = verifyOtherAdminExists(db, user.UserAccountId)
+		if err != nil {
+			return 0, err
+		}
+	}
+	res, err := db.Exec(`DELETE FROM user_account WHERE user_account_id=$1;`, userAccountId)
+	if err != nil {
+		return 0, errors.Wrap(err, database.SqlExecutionError)
+	}
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, database.SqlAffectedRowsCheckError)
+	}
+	return rowsAffected, nil
+}
+
+// verifyOtherAdminExists makes sure that the users can't lock themselves out of the admin functionality
+func verifyOtherAdminExists(db *sqlx.DB, userAccountId int) error {
+	var adminCount int
+	err := db.Get(&adminCount, `SELECT COUNT(*) FROM user_account WHERE role=$1 AND user_account_id!=$2;`,
+		RoleAdmin, userAccountId)
+	if err != nil {
+		return errors.Wrap(err, database.SqlExecutionError)
+	}
+	if adminCount == 0 {
+		return errLastAdmin
+	}
+	return nil
+}
+
+// authenticateUser returns an empty user (UserAccountId 0) when the username and password don't match
+func authenticateUser(db *sqlx.DB, username string, password string) (User, error) {
+	user, err := getUserByUsername(db, username)
+	if err != nil {
+		return User{}, err
+	}
+	if user.UserAccountId == 0 {
+		return User{}, nil
+	}
+	err = bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(password))
+	if err != nil {
+		return User{}, nil
+	}
+	return user, nil
+}
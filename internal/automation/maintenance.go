@@ -12,16 +12,23 @@ import (
 	"github.com/lncapital/torq/internal/cache"
 	"github.com/lncapital/torq/internal/core"
 	"github.com/lncapital/torq/internal/database"
+	"github.com/lncapital/torq/internal/lightning"
 	"github.com/lncapital/torq/internal/vector"
 )
 
 const maintenanceQueueTickerSeconds = 60 * 60
 const maintenanceVectorDelayMilliseconds = 500
+const approvalExpiryTickerSeconds = 60
+
+// auditLogRetentionDays needs to match the retention of the audit_log_append_only trigger
+const auditLogRetentionDays = 365
 
 func MaintenanceServiceStart(ctx context.Context, db *sqlx.DB) {
 
 	ticker := time.NewTicker(maintenanceQueueTickerSeconds * time.Second)
 	defer ticker.Stop()
+	approvalExpiryTicker := time.NewTicker(approvalExpiryTickerSeconds * time.Second)
+	defer approvalExpiryTicker.Stop()
 
 	for {
 		select {
@@ -32,13 +39,30 @@ func MaintenanceServiceStart(ctx context.Context, db *sqlx.DB) {
 			processMissingChannelData(db)
 			processMissingTransactionData(db)
 			deleteWorkflowLogs(db)
+			deleteAuditLogs(db)
+		case <-approvalExpiryTicker.C:
+			lightning.ExpireApprovalProposals(db)
 		}
 	}
 }
 
+func deleteAuditLogs(db *sqlx.DB) {
+	res, err := db.Exec(`DELETE FROM audit_log WHERE created_on < $1`,
+		time.Now().AddDate(0, 0, -auditLogRetentionDays))
+	if err != nil {
+		log.Error().Err(err).Msgf("Couldn't delete audit logs older then %v days.", auditLogRetentionDays)
+		return
+	}
+	rowsAffected, err := res.RowsAffected()
+	if err == nil && rowsAffected != 0 {
+		log.Info().Msgf("%v audit log records deleted (which were older then %v days).",
+			rowsAffected, auditLogRetentionDays)
+	}
+}
+
 func deleteWorkflowLogs(db *sqlx.DB) {
 	res, err := db.Exec(`DELETE FROM workflow_version_node_log WHERE created_on < $1`,
-		time.Now().Add(7*24*time.Hour))
+		time.Now().Add(-7*24*time.Hour))
 	if err != nil {
 		log.Error().Err(err).Msgf("Couldn't delete workflow logs older then 7 days.")
 		return
@@ -48,6 +72,17 @@ func deleteWorkflowLogs(db *sqlx.DB) {
 		log.Info().Msgf("%v workflow log records deleted (which were older then 7 days).", rowsAffected)
 	}
 
+	res, err = db.Exec(`DELETE FROM workflow_run WHERE started_on < $1`,
+		time.Now().Add(-7*24*time.Hour))
+	if err != nil {
+		log.Error().Err(err).Msgf("Couldn't delete workflow runs older then 7 days.")
+		return
+	}
+	rowsAffected, err = res.RowsAffected()
+	if err == nil && rowsAffected != 0 {
+		log.Info().Msgf("%v workflow run records deleted (which were older then 7 days).", rowsAffected)
+	}
+
 	res, err = db.Exec(`
 		DELETE FROM workflow_version_node_log
 		WHERE created_on < (
@@ -2,7 +2,9 @@ package automation
 
 import (
 	"context"
+	"fmt"
 	"net/http"
+	"strconv"
 
 	"github.com/cockroachdb/errors"
 	"github.com/gin-gonic/gin"
@@ -10,12 +12,18 @@ import (
 
 	"github.com/lncapital/torq/internal/cache"
 	"github.com/lncapital/torq/internal/lightning_helpers"
+	"github.com/lncapital/torq/internal/rebalances"
 	"github.com/lncapital/torq/internal/workflows"
 	"github.com/lncapital/torq/pkg/server_errors"
 )
 
 func RegisterAutomationRoutes(r *gin.RouterGroup, db *sqlx.DB) {
 	r.POST("rebalance", func(c *gin.Context) { rebalanceHandler(c, db) })
+	r.GET("rebalance/hop-memory", func(c *gin.Context) { getRebalanceHopMemoryHandler(c, db) })
+	r.GET("rebalance/budgets", func(c *gin.Context) { getRebalanceBudgetsHandler(c, db) })
+	r.POST("rebalance/budgets", func(c *gin.Context) { addRebalanceBudgetHandler(c, db) })
+	r.PUT("rebalance/budgets", func(c *gin.Context) { setRebalanceBudgetHandler(c, db) })
+	r.DELETE("rebalance/budgets/:rebalanceBudgetId", func(c *gin.Context) { removeRebalanceBudgetHandler(c, db) })
 }
 
 func rebalanceHandler(c *gin.Context, db *sqlx.DB) {
@@ -40,3 +48,113 @@ func rebalanceHandler(c *gin.Context, db *sqlx.DB) {
 	}
 	c.JSON(http.StatusOK, response)
 }
+
+// getRebalanceHopMemoryHandler shows the shared memory of failed and successful rebalance hops
+// with the penalty for the (optional) amountMsat.
+func getRebalanceHopMemoryHandler(c *gin.Context, db *sqlx.DB) {
+	var amountMsat uint64
+	if c.Query("amountMsat") != "" {
+		var err error
+		amountMsat, err = strconv.ParseUint(c.Query("amountMsat"), 10, 64)
+		if err != nil {
+			server_errors.SendBadRequest(c, "amountMsat must be a positive number")
+			return
+		}
+	}
+	penalties, err := workflows.GetRebalanceHopMemoryPenalties(db, amountMsat, c.Query("publicKey"))
+	if err != nil {
+		server_errors.WrapLogAndSendServerError(c, err, "Obtaining the rebalance hop memory")
+		return
+	}
+	c.JSON(http.StatusOK, penalties)
+}
+
+// getRebalanceBudgetsHandler returns the rebalance budgets with the fees spent within their period
+func getRebalanceBudgetsHandler(c *gin.Context, db *sqlx.DB) {
+	rebalanceBudgetSpends, err := rebalances.GetRebalanceBudgetSpends(db)
+	if err != nil {
+		server_errors.WrapLogAndSendServerError(c, err, "Obtaining the rebalance budgets")
+		return
+	}
+	c.JSON(http.StatusOK, rebalanceBudgetSpends)
+}
+
+func addRebalanceBudgetHandler(c *gin.Context, db *sqlx.DB) {
+	var rebalanceBudget rebalances.RebalanceBudget
+	if err := c.BindJSON(&rebalanceBudget); err != nil {
+		server_errors.SendBadRequestFromError(c, errors.Wrap(err, server_errors.JsonParseError))
+		return
+	}
+	if !validateRebalanceBudget(c, rebalanceBudget) {
+		return
+	}
+	rebalanceBudget, err := rebalances.AddRebalanceBudget(db, rebalanceBudget)
+	if err != nil {
+		server_errors.WrapLogAndSendServerError(c, err, "Adding rebalance budget")
+		return
+	}
+	c.JSON(http.StatusOK, rebalanceBudget)
+}
+
+func setRebalanceBudgetHandler(c *gin.Context, db *sqlx.DB) {
+	var rebalanceBudget rebalances.RebalanceBudget
+	if err := c.BindJSON(&rebalanceBudget); err != nil {
+		server_errors.SendBadRequestFromError(c, errors.Wrap(err, server_errors.JsonParseError))
+		return
+	}
+	if rebalanceBudget.RebalanceBudgetId == 0 {
+		server_errors.SendBadRequest(c, "Failed to find rebalanceBudgetId in the request.")
+		return
+	}
+	if !validateRebalanceBudget(c, rebalanceBudget) {
+		return
+	}
+	rebalanceBudget, err := rebalances.SetRebalanceBudget(db, rebalanceBudget)
+	if err != nil {
+		server_errors.WrapLogAndSendServerError(c, err,
+			fmt.Sprintf("Setting rebalance budget for rebalanceBudgetId: %v", rebalanceBudget.RebalanceBudgetId))
+		return
+	}
+	c.JSON(http.StatusOK, rebalanceBudget)
+}
+
+func removeRebalanceBudgetHandler(c *gin.Context, db *sqlx.DB) {
+	rebalanceBudgetId, err := strconv.Atoi(c.Param("rebalanceBudgetId"))
+	if err != nil {
+		server_errors.SendBadRequest(c, "Failed to find/parse rebalanceBudgetId in the request.")
+		return
+	}
+	err = rebalances.RemoveRebalanceBudget(db, rebalanceBudgetId)
+	if err != nil {
+		server_errors.WrapLogAndSendServerError(c, err,
+			fmt.Sprintf("Removing rebalance budget for rebalanceBudgetId: %v", rebalanceBudgetId))
+		return
+	}
+	c.JSON(http.StatusOK, nil)
+}
+
+func validateRebalanceBudget(c *gin.Context, rebalanceBudget rebalances.RebalanceBudget) bool {
+	scopes := 0
+	if rebalanceBudget.NodeId != nil {
+		scopes++
+	}
+	if rebalanceBudget.TagId != nil {
+		scopes++
+	}
+	if rebalanceBudget.WorkflowId != nil {
+		scopes++
+	}
+	if scopes != 1 {
+		server_errors.SendUnprocessableEntity(c, "A rebalance budget requires exactly one of nodeId, tagId or workflowId.")
+		return false
+	}
+	if rebalanceBudget.MaximumFeeMsat == 0 {
+		server_errors.SendUnprocessableEntity(c, "Failed to find maximumFeeMsat in the request.")
+		return false
+	}
+	if rebalanceBudget.PeriodDays <= 0 {
+		server_errors.SendUnprocessableEntity(c, "The periodDays of a rebalance budget must be at least 1.")
+		return false
+	}
+	return true
+}
@@ -47,25 +47,25 @@ const (
 type ChannelBalanceStateHtlcInclude uint
 
 const (
-	// pendingHtlcsLocalBalanceAdjustedDownwards:
+	// PendingHtlcsLocalBalanceAdjustedDownwards:
 	//   LocalBalance = ConfirmedLocalBalance - PendingDecreasingForwardHTLCsAmount - PendingPaymentHTLCsAmount
-	pendingHtlcsLocalBalanceAdjustedDownwards ChannelBalanceStateHtlcInclude = iota
-	// pendingHtlcsRemoteBalanceAdjustedDownwards:
+	PendingHtlcsLocalBalanceAdjustedDownwards ChannelBalanceStateHtlcInclude = iota
+	// PendingHtlcsRemoteBalanceAdjustedDownwards:
 	//   RemoteBalance = ConfirmedRemoteBalance - PendingIncreasingForwardHTLCsAmount - PendingInvoiceHTLCsAmount
-	pendingHtlcsRemoteBalanceAdjustedDownwards
-	// pendingHtlcsLocalAndRemoteBalanceAdjustedDownwards:
+	PendingHtlcsRemoteBalanceAdjustedDownwards
+	// PendingHtlcsLocalAndRemoteBalanceAdjustedDownwards:
 	//   LocalBalance = ConfirmedLocalBalance - PendingDecreasingForwardHTLCsAmount - PendingPaymentHTLCsAmount
 	//   RemoteBalance = ConfirmedRemoteBalance - PendingIncreasingForwardHTLCsAmount - PendingInvoiceHTLCsAmount
-	pendingHtlcsLocalAndRemoteBalanceAdjustedDownwards
-	// pendingHtlcsLocalBalanceAdjustedUpwards:
+	PendingHtlcsLocalAndRemoteBalanceAdjustedDownwards
+	// PendingHtlcsLocalBalanceAdjustedUpwards:
 	//   LocalBalance = ConfirmedLocalBalance + PendingIncreasingForwardHTLCsAmount + PendingInvoiceHTLCsAmount
-	pendingHtlcsLocalBalanceAdjustedUpwards
+	PendingHtlcsLocalBalanceAdjustedUpwards
 	//   RemoteBalance = ConfirmedRemoteBalance + PendingDecreasingForwardHTLCsAmount + PendingPaymentHTLCsAmount
-	pendingHtlcsRemoteBalanceAdjustedUpwards
-	// pendingHtlcsLocalAndRemoteBalanceAdjustedUpwards:
+	PendingHtlcsRemoteBalanceAdjustedUpwards
+	// PendingHtlcsLocalAndRemoteBalanceAdjustedUpwards:
 	//   LocalBalance = ConfirmedLocalBalance + PendingIncreasingForwardHTLCsAmount + PendingInvoiceHTLCsAmount
 	//   RemoteBalance = ConfirmedRemoteBalance + PendingDecreasingForwardHTLCsAmount + PendingPaymentHTLCsAmount
-	pendingHtlcsLocalAndRemoteBalanceAdjustedUpwards
+	PendingHtlcsLocalAndRemoteBalanceAdjustedUpwards
 )
 
 type ChannelStateInclude uint
@@ -412,6 +412,7 @@ func handleChannelStateSettingsOperation(channelStateCache ChannelStateCache,
 						channelBalanceEvent.BalanceDeltaAbsolute = -1 * channelBalanceEvent.BalanceDeltaAbsolute
 					}
 					if channelBalanceEvent.BalanceDelta != 0 {
+						PublishEvent(channelBalanceEvent)
 						ChannelBalanceChanges <- channelBalanceEvent
 					}
 				}
@@ -591,6 +592,7 @@ func handleChannelStateSettingsOperation(channelStateCache ChannelStateCache,
 					PeerLocalBalance:              channelStateSetting.PeerLocalBalance + channelStateCache.Amount,
 					PeerLocalBalancePerMilleRatio: int(channelStateSetting.PeerLocalBalance / channelStateSetting.PeerChannelCapacity * 1000),
 				}
+				PublishEvent(channelBalanceEvent)
 				ChannelBalanceChanges <- channelBalanceEvent
 			} else {
 				channelSettings := GetChannelSettingByChannelId(channelStateCache.ChannelId)
@@ -913,20 +915,20 @@ func isNodeReady(channelStateSettingsStatusCache map[nodeIdType]core.Status, nod
 func processHtlcInclude(channelStateCache ChannelStateCache, settings ChannelStateSettingsCache, capacity int64) ChannelBalanceStateSettingsCache {
 	localBalance := settings.LocalBalance
 	remoteBalance := settings.RemoteBalance
-	if channelStateCache.HtlcInclude == pendingHtlcsLocalBalanceAdjustedDownwards ||
-		channelStateCache.HtlcInclude == pendingHtlcsLocalAndRemoteBalanceAdjustedDownwards {
+	if channelStateCache.HtlcInclude == PendingHtlcsLocalBalanceAdjustedDownwards ||
+		channelStateCache.HtlcInclude == PendingHtlcsLocalAndRemoteBalanceAdjustedDownwards {
 		localBalance = settings.LocalBalance - settings.PendingOutgoingHtlcAmount
 	}
-	if channelStateCache.HtlcInclude == pendingHtlcsRemoteBalanceAdjustedDownwards ||
-		channelStateCache.HtlcInclude == pendingHtlcsLocalAndRemoteBalanceAdjustedDownwards {
+	if channelStateCache.HtlcInclude == PendingHtlcsRemoteBalanceAdjustedDownwards ||
+		channelStateCache.HtlcInclude == PendingHtlcsLocalAndRemoteBalanceAdjustedDownwards {
 		remoteBalance = settings.RemoteBalance - settings.PendingIncomingHtlcAmount
 	}
-	if channelStateCache.HtlcInclude == pendingHtlcsLocalBalanceAdjustedUpwards ||
-		channelStateCache.HtlcInclude == pendingHtlcsLocalAndRemoteBalanceAdjustedUpwards {
+	if channelStateCache.HtlcInclude == PendingHtlcsLocalBalanceAdjustedUpwards ||
+		channelStateCache.HtlcInclude == PendingHtlcsLocalAndRemoteBalanceAdjustedUpwards {
 		localBalance = settings.LocalBalance + settings.PendingIncomingHtlcAmount
 	}
-	if channelStateCache.HtlcInclude == pendingHtlcsRemoteBalanceAdjustedUpwards ||
-		channelStateCache.HtlcInclude == pendingHtlcsLocalAndRemoteBalanceAdjustedUpwards {
+	if channelStateCache.HtlcInclude == PendingHtlcsRemoteBalanceAdjustedUpwards ||
+		channelStateCache.HtlcInclude == PendingHtlcsLocalAndRemoteBalanceAdjustedUpwards {
 		remoteBalance = settings.RemoteBalance + settings.PendingOutgoingHtlcAmount
 	}
 	return ChannelBalanceStateSettingsCache{
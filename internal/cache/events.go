@@ -0,0 +1,47 @@
+package cache
+
+import (
+	"sync"
+)
+
+const eventSubscriberBufferSize = 1000
+
+var (
+	eventSubscribersMutex sync.RWMutex               //nolint:gochecknoglobals
+	eventSubscribers      = make(map[int]chan<- any) //nolint:gochecknoglobals
+	lastEventSubscriberId int                        //nolint:gochecknoglobals
+)
+
+// SubscribeEvents returns a channel with the live events (i.e. core.HtlcEvent, core.ForwardEvent,
+// core.ChannelBalanceEvent, core.ChannelEvent and core.PeerEvent) until UnsubscribeEvents is called.
+func SubscribeEvents() (int, <-chan any) {
+	events := make(chan any, eventSubscriberBufferSize)
+	eventSubscribersMutex.Lock()
+	defer eventSubscribersMutex.Unlock()
+	lastEventSubscriberId++
+	eventSubscribers[lastEventSubscriberId] = events
+	return lastEventSubscriberId, events
+}
+
+// UnsubscribeEvents closes the channel of the subscriber
+func UnsubscribeEvents(subscriberId int) {
+	eventSubscribersMutex.Lock()
+	defer eventSubscribersMutex.Unlock()
+	events, exists := eventSubscribers[subscriberId]
+	if exists {
+		close(events)
+		delete(eventSubscribers, subscriberId)
+	}
+}
+
+// PublishEvent never blocks the (event stream) caller: a subscriber that is not keeping up misses the event.
+func PublishEvent(event any) {
+	eventSubscribersMutex.RLock()
+	defer eventSubscribersMutex.RUnlock()
+	for _, events := range eventSubscribers {
+		select {
+		case events <- event:
+		default:
+		}
+	}
+}
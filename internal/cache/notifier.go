@@ -0,0 +1,22 @@
+package cache
+
+import (
+	"github.com/rs/zerolog/log"
+
+	"github.com/lncapital/torq/internal/core"
+)
+
+const notifierEventsBufferSize = 1000
+
+// NotifierEvents is consumed by the notifier service that sends the alerts to the subscribed communications
+var NotifierEvents = make(chan core.NotifierEvent, notifierEventsBufferSize) //nolint:gochecknoglobals
+
+// SendNotifierEvent never blocks the (event stream) caller: when the notifier is not keeping up the event is dropped.
+func SendNotifierEvent(notifierEvent core.NotifierEvent) {
+	select {
+	case NotifierEvents <- notifierEvent:
+	default:
+		log.Warn().Msgf("Notifier event dropped (notifier is not keeping up) for nodeId: %v, notificationType: %v",
+			notifierEvent.NodeId, notifierEvent.NotificationType)
+	}
+}
@@ -0,0 +1,31 @@
+package cache
+
+import (
+	"sync"
+)
+
+var (
+	probePaymentHashesMutex sync.RWMutex                //nolint:gochecknoglobals
+	probePaymentHashes      = make(map[string]struct{}) //nolint:gochecknoglobals
+)
+
+// AddProbePaymentHash marks the payment (hex encoded payment hash) as a probe until RemoveProbePaymentHash is called.
+// Probes are not stored as payments and don't raise the payment alerts.
+func AddProbePaymentHash(paymentHash string) {
+	probePaymentHashesMutex.Lock()
+	defer probePaymentHashesMutex.Unlock()
+	probePaymentHashes[paymentHash] = struct{}{}
+}
+
+func RemoveProbePaymentHash(paymentHash string) {
+	probePaymentHashesMutex.Lock()
+	defer probePaymentHashesMutex.Unlock()
+	delete(probePaymentHashes, paymentHash)
+}
+
+func IsProbePaymentHash(paymentHash string) bool {
+	probePaymentHashesMutex.RLock()
+	defer probePaymentHashesMutex.RUnlock()
+	_, exists := probePaymentHashes[paymentHash]
+	return exists
+}
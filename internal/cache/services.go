@@ -987,7 +987,10 @@ func ActivateLndService(ctx context.Context,
 			services_helpers.LndServiceHtlcEventStream,
 			services_helpers.LndServiceForwardsService,
 			services_helpers.LndServiceInvoiceStream,
-			services_helpers.LndServicePaymentsService:
+			services_helpers.LndServicePaymentsService,
+			services_helpers.ClnServiceForwardsService,
+			services_helpers.ClnServiceInvoicesService,
+			services_helpers.ClnServiceHtlcsService:
 			active := false
 			for _, cs := range serviceType.GetNodeConnectionDetailCustomSettings() {
 				if customSettings&cs != 0 {
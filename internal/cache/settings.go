@@ -2,6 +2,7 @@ package cache
 
 import (
 	"context"
+	"time"
 )
 
 var SettingsCacheChannel = make(chan SettingsCache) //nolint:gochecknoglobals
@@ -15,6 +16,13 @@ const (
 	writeSettings
 	writeBlockHeight
 	writeVectorUrl
+	writeHtlcFailureBurstSettings
+)
+
+// The defaults match the column defaults of htlc_failure_burst_window_minutes and htlc_failure_burst_threshold
+const (
+	DefaultHtlcFailureBurstWindowMinutes = 5
+	DefaultHtlcFailureBurstThreshold     = 25
 )
 
 type SettingsCache struct {
@@ -31,6 +39,8 @@ type SettingsCache struct {
 	TelegramLowPriorityCredentials  *string
 	BlockHeight                     uint32
 	VectorUrl                       string
+	HtlcFailureBurstWindowMinutes   int
+	HtlcFailureBurstThreshold       int
 	Out                             chan<- SettingsCache
 }
 
@@ -47,6 +57,8 @@ type SettingsDataCache struct {
 	TelegramLowPriorityCredentials  *string
 	BlockHeight                     uint32
 	VectorUrl                       string
+	HtlcFailureBurstWindowMinutes   int
+	HtlcFailureBurstThreshold       int
 }
 
 func (s SettingsCache) GetTelegramCredential(highPriority bool) string {
@@ -113,6 +125,14 @@ func handleSettingsOperation(settingsCache SettingsCache, data SettingsDataCache
 		settingsCache.TelegramLowPriorityCredentials = data.TelegramLowPriorityCredentials
 		settingsCache.BlockHeight = data.BlockHeight
 		settingsCache.VectorUrl = data.VectorUrl
+		settingsCache.HtlcFailureBurstWindowMinutes = data.HtlcFailureBurstWindowMinutes
+		if data.HtlcFailureBurstWindowMinutes <= 0 {
+			settingsCache.HtlcFailureBurstWindowMinutes = DefaultHtlcFailureBurstWindowMinutes
+		}
+		settingsCache.HtlcFailureBurstThreshold = data.HtlcFailureBurstThreshold
+		if data.HtlcFailureBurstThreshold <= 0 {
+			settingsCache.HtlcFailureBurstThreshold = DefaultHtlcFailureBurstThreshold
+		}
 		settingsCache.Out <- settingsCache
 	case writeSettings:
 		data.DefaultLanguage = settingsCache.DefaultLanguage
@@ -129,6 +149,9 @@ func handleSettingsOperation(settingsCache SettingsCache, data SettingsDataCache
 		data.VectorUrl = settingsCache.VectorUrl
 	case writeBlockHeight:
 		data.BlockHeight = settingsCache.BlockHeight
+	case writeHtlcFailureBurstSettings:
+		data.HtlcFailureBurstWindowMinutes = settingsCache.HtlcFailureBurstWindowMinutes
+		data.HtlcFailureBurstThreshold = settingsCache.HtlcFailureBurstThreshold
 	}
 	return data
 }
@@ -183,6 +206,20 @@ func SetBlockHeight(blockHeight uint32) {
 	SettingsCacheChannel <- settingsCache
 }
 
+func SetHtlcFailureBurstSettings(windowMinutes int, threshold int) {
+	SettingsCacheChannel <- SettingsCache{
+		HtlcFailureBurstWindowMinutes: windowMinutes,
+		HtlcFailureBurstThreshold:     threshold,
+		Type:                          writeHtlcFailureBurstSettings,
+	}
+}
+
+// GetHtlcFailureBurstSettings returns the window and the number of failed HTLCs of an HTLC failure burst
+func GetHtlcFailureBurstSettings() (time.Duration, int) {
+	settings := GetSettings()
+	return time.Duration(settings.HtlcFailureBurstWindowMinutes) * time.Minute, settings.HtlcFailureBurstThreshold
+}
+
 func SetVectorUrlBase(vectorUrlBase string) {
 	settingsCache := SettingsCache{
 		VectorUrl: vectorUrlBase,
@@ -16,6 +16,7 @@ import (
 
 	"github.com/lncapital/torq/internal/cache"
 	"github.com/lncapital/torq/internal/core"
+	"github.com/lncapital/torq/internal/probes"
 	"github.com/lncapital/torq/internal/tags"
 
 	"github.com/lncapital/torq/pkg/server_errors"
@@ -87,6 +88,11 @@ type ChannelBody struct {
 	OneMl                        string               `json:"oneMl"`
 	PeerAlias                    string               `json:"peerAlias"`
 	Private                      bool                 `json:"private"`
+	// The latest probe round (nil when the channel was never probed), see probes.ChannelProbe
+	ProbeReachability        *float64   `json:"probeReachability"`
+	ProbeFeePpm              *float64   `json:"probeFeePpm"`
+	ProbeLiquidityLowerBound *int64     `json:"probeLiquidityLowerBound"`
+	ProbedOn                 *time.Time `json:"probedOn"`
 }
 
 type PendingHtlcs struct {
@@ -280,6 +286,13 @@ func GetChannelsByIds(nodeId int, channelIds []int) ([]ChannelBody, error) {
 			deltaSeconds := uint64(time.Since(*channelSettings.ClosedOn).Seconds())
 			chanBody.ClosedOnSecondsDelta = &deltaSeconds
 		}
+		channelProbe := probes.GetChannelProbe(channelSettings.ChannelId)
+		if channelProbe != nil {
+			chanBody.ProbeReachability = &channelProbe.Reachability
+			chanBody.ProbeFeePpm = &channelProbe.FeePpm
+			chanBody.ProbeLiquidityLowerBound = &channelProbe.LiquidityLowerBoundSat
+			chanBody.ProbedOn = &channelProbe.ProbedOn
+		}
 		channelsBody = append(channelsBody, chanBody)
 	}
 	return channelsBody, nil
@@ -9,15 +9,18 @@ import (
 	"github.com/cockroachdb/errors"
 	"github.com/jmoiron/sqlx"
 	"github.com/rs/zerolog/log"
+	"golang.org/x/exp/slices"
 	"google.golang.org/grpc"
 
 	"github.com/lncapital/torq/internal/cache"
 	"github.com/lncapital/torq/internal/channels"
 	"github.com/lncapital/torq/internal/core"
 	"github.com/lncapital/torq/internal/graph_events"
+	"github.com/lncapital/torq/internal/lnd"
 	"github.com/lncapital/torq/internal/nodes"
 	"github.com/lncapital/torq/internal/services_helpers"
 	"github.com/lncapital/torq/proto/cln"
+	"github.com/lncapital/torq/proto/lnrpc"
 )
 
 const streamChannelsTickerSeconds = 10
@@ -74,7 +77,8 @@ func listAndProcessChannels(ctx context.Context, db *sqlx.DB, client client_List
 			openChannelIds = append(openChannelIds, currentChannel.ChannelId)
 		}
 	}
-	processedChannelIds := make(map[int]bool, len(currentChannels))
+	// processedChannelIds has the public key of the peer for each processed channel
+	processedChannelIds := make(map[int]string, len(currentChannels))
 
 	publicKey, err := hex.DecodeString(nodeSettings.PublicKey)
 	if err != nil {
@@ -104,8 +108,27 @@ func listAndProcessChannels(ctx context.Context, db *sqlx.DB, client client_List
 		return errors.Wrapf(err, "storing destination channels for nodeId: %v", nodeSettings.NodeId)
 	}
 
+	if !bootStrapping {
+		for channelId, peerPublicKey := range processedChannelIds {
+			if slices.Contains(openChannelIds, channelId) {
+				continue
+			}
+			channel, err := channels.GetChannel(db, channelId)
+			if err != nil {
+				return errors.Wrapf(err, "obtaining new channel with channelId: %v for nodeId: %v",
+					channelId, nodeSettings.NodeId)
+			}
+			peerNodeId := channel.FirstNodeId
+			if peerNodeId == nodeSettings.NodeId {
+				peerNodeId = channel.SecondNodeId
+			}
+			lnd.SendChannelAlert(nodeSettings.NodeId, channel, peerNodeId, peerPublicKey)
+			publishChannelEvent(nodeSettings.NodeId, channelId, lnrpc.ChannelEventUpdate_OPEN_CHANNEL)
+		}
+	}
+
 	for _, openChannelId := range openChannelIds {
-		if !processedChannelIds[openChannelId] {
+		if _, processed := processedChannelIds[openChannelId]; !processed {
 			log.Info().Msgf("Channel with channelId: %v got dropped from the list for nodeId: %v",
 				openChannelId, nodeSettings.NodeId)
 			channel, err := channels.GetChannel(db, openChannelId)
@@ -124,6 +147,9 @@ func listAndProcessChannels(ctx context.Context, db *sqlx.DB, client client_List
 			if peerNodeId == nodeSettings.NodeId {
 				peerNodeId = channel.SecondNodeId
 			}
+			lnd.SendChannelAlert(nodeSettings.NodeId, channel, peerNodeId,
+				cache.GetNodeSettingsByNodeId(peerNodeId).PublicKey)
+			publishChannelEvent(nodeSettings.NodeId, openChannelId, lnrpc.ChannelEventUpdate_CLOSED_CHANNEL)
 
 			// This stops the graph from listening to node updates
 			chans, err := channels.GetOpenChannelsForNodeId(db, nodeSettings.NodeId)
@@ -147,7 +173,7 @@ func listAndProcessChannels(ctx context.Context, db *sqlx.DB, client client_List
 func storeChannels(db *sqlx.DB,
 	clnChannels []*cln.ListchannelsChannels,
 	nodeSettings cache.NodeSettingsCache,
-	processedChannelIds map[int]bool) error {
+	processedChannelIds map[int]string) error {
 
 	processedShortChannelIds := make(map[string]bool)
 	for _, clnChannel := range clnChannels {
@@ -179,7 +205,7 @@ func storeChannels(db *sqlx.DB,
 				return errors.Wrapf(err, "process channel for nodeId: %v", nodeSettings.NodeId)
 			}
 			processedShortChannelIds[clnChannel.ShortChannelId] = true
-			processedChannelIds[channelId] = true
+			processedChannelIds[channelId] = peerPublicKey
 
 			announcingNodeId := cache.GetPeerNodeIdByPublicKey(
 				sourcePublicKey, nodeSettings.Chain, nodeSettings.Network)
@@ -307,3 +333,16 @@ func insertRoutingPolicy(
 	}
 	return nil
 }
+
+// publishChannelEvent publishes the opened and closed channels as live events
+// (CLN has no channel event stream like LND)
+func publishChannelEvent(nodeId int, channelId int, eventType lnrpc.ChannelEventUpdate_UpdateType) {
+	cache.PublishEvent(core.ChannelEvent{
+		EventData: core.EventData{
+			EventTime: time.Now().UTC(),
+			NodeId:    nodeId,
+		},
+		Type:      eventType,
+		ChannelId: channelId,
+	})
+}
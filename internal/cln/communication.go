@@ -11,15 +11,19 @@ import (
 	"time"
 
 	"github.com/cockroachdb/errors"
+	"github.com/jmoiron/sqlx"
 	"github.com/rs/zerolog/log"
 	"google.golang.org/grpc"
 
 	"github.com/lncapital/torq/internal/cache"
 	"github.com/lncapital/torq/internal/core"
+	"github.com/lncapital/torq/internal/database"
 	"github.com/lncapital/torq/internal/graph_events"
 	"github.com/lncapital/torq/internal/lightning_helpers"
+	"github.com/lncapital/torq/internal/lnd"
 	"github.com/lncapital/torq/pkg/cln_connect"
 	"github.com/lncapital/torq/proto/cln"
+	"github.com/lncapital/torq/proto/lnrpc/zpay32"
 )
 
 const routingPolicyUpdateLimiterSeconds = 5 * 60
@@ -194,6 +198,16 @@ func OpenChannel(request lightning_helpers.OpenChannelRequest) lightning_helpers
 	return lightning_helpers.OpenChannelResponse{}
 }
 
+func BatchOpenChannel(request lightning_helpers.BatchOpenChannelRequest) lightning_helpers.BatchOpenChannelResponse {
+	responseChan := make(chan any)
+	processConcurrent(context.Background(), 300, request, responseChan)
+	response := <-responseChan
+	if res, ok := response.(lightning_helpers.BatchOpenChannelResponse); ok {
+		return res
+	}
+	return lightning_helpers.BatchOpenChannelResponse{}
+}
+
 func CloseChannel(request lightning_helpers.CloseChannelRequest) lightning_helpers.CloseChannelResponse {
 	responseChan := make(chan any)
 	processConcurrent(context.Background(), 300, request, responseChan)
@@ -234,6 +248,28 @@ func NewPayment(request lightning_helpers.NewPaymentRequest) lightning_helpers.N
 	return lightning_helpers.NewPaymentResponse{}
 }
 
+func DecodeInvoice(request lightning_helpers.DecodeInvoiceRequest) lightning_helpers.DecodeInvoiceResponse {
+	responseChan := make(chan any)
+	processSequential(context.Background(), 2, request, responseChan)
+	response := <-responseChan
+	if res, ok := response.(lightning_helpers.DecodeInvoiceResponse); ok {
+		return res
+	}
+	return lightning_helpers.DecodeInvoiceResponse{}
+}
+
+func ChannelStatusUpdate(
+	request lightning_helpers.ChannelStatusUpdateRequest) lightning_helpers.ChannelStatusUpdateResponse {
+
+	responseChan := make(chan any)
+	processSequential(context.Background(), 2, request, responseChan)
+	response := <-responseChan
+	if res, ok := response.(lightning_helpers.ChannelStatusUpdateResponse); ok {
+		return res
+	}
+	return lightning_helpers.ChannelStatusUpdateResponse{}
+}
+
 const concurrentWorkLimit = 10
 
 var serviceSequential = lightningService{limit: make(chan struct{}, 1)}                   //nolint:gochecknoglobals
@@ -331,6 +367,9 @@ func processRequestByType(ctx context.Context, req any, responseChan chan<- any)
 	case lightning_helpers.OpenChannelRequest:
 		responseChan <- processOpenChannelRequest(ctx, r)
 		return
+	case lightning_helpers.BatchOpenChannelRequest:
+		responseChan <- processBatchOpenChannelRequest(ctx, r)
+		return
 	case lightning_helpers.CloseChannelRequest:
 		responseChan <- processCloseChannelRequest(ctx, r)
 		return
@@ -343,6 +382,12 @@ func processRequestByType(ctx context.Context, req any, responseChan chan<- any)
 	case lightning_helpers.NewPaymentRequest:
 		responseChan <- processNewPaymentRequest(ctx, r)
 		return
+	case lightning_helpers.DecodeInvoiceRequest:
+		responseChan <- processDecodeInvoiceRequest(ctx, r)
+		return
+	case lightning_helpers.ChannelStatusUpdateRequest:
+		responseChan <- processChannelStatusUpdateRequest(ctx, r)
+		return
 	}
 
 	responseChan <- nil
@@ -1253,8 +1298,12 @@ func processNewPaymentRequest(ctx context.Context,
 
 	if err != nil {
 		response.Error = err.Error()
+		sendPaymentFailedAlert(request, err.Error())
 		return response
 	}
+	if resp.Status == cln.PayResponse_FAILED {
+		sendPaymentFailedAlert(request, resp.Status.String())
+	}
 
 	response.Hash = hex.EncodeToString(resp.PaymentHash)
 	response.PaymentStatus = resp.Status.String()
@@ -1272,3 +1321,443 @@ func processNewPaymentRequest(ctx context.Context,
 	response.Status = lightning_helpers.Active
 	return response
 }
+
+// sendPaymentFailedAlert the amount is unknown (0) when the request relies on the amount of the invoice
+func sendPaymentFailedAlert(request lightning_helpers.NewPaymentRequest, failureReason string) {
+	var amountSat int64
+	if request.AmtMSat != nil {
+		amountSat = *request.AmtMSat / 1_000
+	}
+	lnd.SendPaymentFailedAlert(request.NodeId, nil, amountSat, failureReason)
+}
+
+// processBatchOpenChannelRequest opens all channels of a batch in one funding transaction using multifundchannel.
+func processBatchOpenChannelRequest(ctx context.Context,
+	request lightning_helpers.BatchOpenChannelRequest) lightning_helpers.BatchOpenChannelResponse {
+
+	response := lightning_helpers.BatchOpenChannelResponse{
+		CommunicationResponse: lightning_helpers.CommunicationResponse{
+			Status: lightning_helpers.Inactive,
+		},
+		Request: request,
+	}
+
+	multiFundChannelReq, err := prepareBatchOpenRequest(request)
+	if err != nil {
+		response.Error = err.Error()
+		return response
+	}
+
+	connection, err := getConnection(request.NodeId)
+	if err != nil {
+		log.Error().Err(err).Msgf("Failed to obtain a GRPC connection.")
+		response.Error = err.Error()
+		return response
+	}
+
+	resp, err := cln.NewNodeClient(connection).MultiFundChannel(ctx, multiFundChannelReq)
+	if err != nil {
+		log.Error().Err(err).Msgf("Failed to open batch of %v channels for nodeId: %v",
+			len(multiFundChannelReq.Destinations), request.NodeId)
+		response.Error = err.Error()
+		return response
+	}
+	for _, channel := range resp.ChannelIds {
+		response.PendingChannelPoints = append(response.PendingChannelPoints,
+			fmt.Sprintf("%v:%v", hex.EncodeToString(resp.Txid), channel.Outnum))
+	}
+	response.Status = lightning_helpers.Active
+	return response
+}
+
+func prepareBatchOpenRequest(request lightning_helpers.BatchOpenChannelRequest) (*cln.MultifundchannelRequest, error) {
+	if request.NodeId == 0 {
+		return nil, errors.New("Node id is missing")
+	}
+	if len(request.Channels) == 0 {
+		return nil, errors.New("Channels array is empty")
+	}
+	if request.TargetConf != nil && request.SatPerVbyte != nil {
+		return nil, errors.New("Either targetConf or satPerVbyte accepted")
+	}
+
+	var satPerVbyte *uint64
+	if request.SatPerVbyte != nil {
+		fee := uint64(*request.SatPerVbyte)
+		satPerVbyte = &fee
+	}
+
+	// All destinations have to be funded otherwise nothing is broadcast
+	minChannels := int64(len(request.Channels))
+	multiFundChannelReq := &cln.MultifundchannelRequest{Minchannels: &minChannels}
+	for _, channel := range request.Channels {
+		if channel.LocalFundingAmount == 0 {
+			return nil, errors.New(fmt.Sprintf("Local funding amount 0 for %v", channel.NodePublicKey))
+		}
+		openChanReq, err := prepareOpenRequest(lightning_helpers.OpenChannelRequest{
+			CommunicationRequest: request.CommunicationRequest,
+			SatPerVbyte:          satPerVbyte,
+			NodePubKey:           channel.NodePublicKey,
+			LocalFundingAmount:   channel.LocalFundingAmount,
+			PushSat:              channel.PushSat,
+			TargetConf:           request.TargetConf,
+			Private:              channel.Private,
+		})
+		if err != nil {
+			return nil, errors.Wrapf(err, "Preparing the channel for %v", channel.NodePublicKey)
+		}
+		multiFundChannelReq.Feerate = openChanReq.Feerate
+		multiFundChannelReq.Destinations = append(multiFundChannelReq.Destinations, &cln.MultifundchannelDestinations{
+			Id:       channel.NodePublicKey,
+			Amount:   openChanReq.Amount,
+			Announce: openChanReq.Announce,
+			PushMsat: openChanReq.PushMsat,
+			Mindepth: openChanReq.Mindepth,
+		})
+	}
+	return multiFundChannelReq, nil
+}
+
+// processDecodeInvoiceRequest decodes the invoice locally because the CLN gRPC interface does not expose decodepay.
+func processDecodeInvoiceRequest(ctx context.Context,
+	request lightning_helpers.DecodeInvoiceRequest) lightning_helpers.DecodeInvoiceResponse {
+
+	response := lightning_helpers.DecodeInvoiceResponse{
+		CommunicationResponse: lightning_helpers.CommunicationResponse{
+			Status: lightning_helpers.Inactive,
+		},
+		Request: request,
+	}
+
+	nodeSettings := cache.GetNodeSettingsByNodeId(request.NodeId)
+	decodedInvoice, err := zpay32.Decode(request.Invoice, getChainParams(nodeSettings.Network))
+	if err != nil {
+		response.Error = err.Error()
+		return response
+	}
+
+	response = constructDecodedInvoice(request.Invoice, decodedInvoice, response)
+
+	connection, err := getConnection(request.NodeId)
+	if err != nil {
+		log.Error().Err(err).Msgf("Failed to obtain a GRPC connection.")
+		response.Error = err.Error()
+		return response
+	}
+
+	destination, err := hex.DecodeString(response.DestinationPubKey)
+	if err != nil {
+		response.Error = err.Error()
+		return response
+	}
+	nodes, err := cln.NewNodeClient(connection).ListNodes(ctx, &cln.ListnodesRequest{Id: destination})
+	if err != nil {
+		response.Error = err.Error()
+		return response
+	}
+	for _, node := range nodes.Nodes {
+		if node != nil && node.Alias != nil {
+			response.NodeAlias = *node.Alias
+		}
+	}
+
+	response.Status = lightning_helpers.Active
+	return response
+}
+
+func constructDecodedInvoice(paymentRequest string,
+	decodedInvoice *zpay32.Invoice,
+	response lightning_helpers.DecodeInvoiceResponse) lightning_helpers.DecodeInvoiceResponse {
+
+	response.PaymentRequest = paymentRequest
+	if decodedInvoice.Destination != nil {
+		response.DestinationPubKey = hex.EncodeToString(decodedInvoice.Destination.SerializeCompressed())
+	}
+	if decodedInvoice.PaymentHash != nil {
+		response.RHash = hex.EncodeToString(decodedInvoice.PaymentHash[:])
+	}
+	if decodedInvoice.Description != nil {
+		response.Memo = *decodedInvoice.Description
+	}
+	if decodedInvoice.MilliSat != nil {
+		response.ValueMsat = int64(*decodedInvoice.MilliSat)
+	}
+	if decodedInvoice.FallbackAddr != nil {
+		response.FallbackAddr = decodedInvoice.FallbackAddr.String()
+	}
+	if decodedInvoice.PaymentAddr != nil {
+		response.PaymentAddr = hex.EncodeToString(decodedInvoice.PaymentAddr[:])
+	}
+	response.CreatedAt = decodedInvoice.Timestamp.Unix()
+	response.Expiry = int64(decodedInvoice.Expiry().Seconds())
+	response.CltvExpiry = int64(decodedInvoice.MinFinalCLTVExpiry())
+	response.RouteHints = constructRouteHints(decodedInvoice.RouteHints)
+	response.Features = constructFeatureMap(decodedInvoice.Features)
+	return response
+}
+
+func constructRouteHints(routeHints [][]zpay32.HopHint) []lightning_helpers.RouteHint {
+	var r []lightning_helpers.RouteHint
+	for _, rh := range routeHints {
+		var hopHints []lightning_helpers.HopHint
+		for _, hh := range rh {
+			hopHint := lightning_helpers.HopHint{
+				LNDShortChannelId: hh.ChannelID,
+				ShortChannelId:    core.ConvertLNDShortChannelID(hh.ChannelID),
+				FeeBase:           hh.FeeBaseMSat,
+				CltvExpiryDelta:   uint32(hh.CLTVExpiryDelta),
+				FeeProportional:   hh.FeeProportionalMillionths,
+			}
+			if hh.NodeID != nil {
+				hopHint.NodeId = hex.EncodeToString(hh.NodeID.SerializeCompressed())
+			}
+			hopHints = append(hopHints, hopHint)
+		}
+		r = append(r, lightning_helpers.RouteHint{
+			HopHints: hopHints,
+		})
+	}
+	return r
+}
+
+func constructFeatureMap(features *zpay32.FeatureVector) lightning_helpers.FeatureMap {
+	f := lightning_helpers.FeatureMap{}
+	if features == nil {
+		return f
+	}
+	for bit := range features.Features() {
+		f[uint32(bit)] = lightning_helpers.Feature{
+			Name:       features.Name(bit),
+			IsKnown:    features.IsKnown(bit),
+			IsRequired: bit.IsRequired(),
+		}
+	}
+	return f
+}
+
+// processChannelStatusUpdateRequest enables or disables a channel.
+// NB: CLN's setchannel cannot toggle the disabled flag of a channel update so disabling only clamps the maximum HTLC
+// size to the minimum HTLC size, the channel keeps forwarding HTLCs of that size. The maximum HTLC size of before the
+// clamp is stored and restored when the channel is enabled again (the channel capacity when it is unknown).
+func processChannelStatusUpdateRequest(ctx context.Context,
+	request lightning_helpers.ChannelStatusUpdateRequest) lightning_helpers.ChannelStatusUpdateResponse {
+
+	response := validateChannelStatusUpdateRequest(request)
+	if response != nil {
+		return *response
+	}
+
+	channelState := cache.GetChannelState(request.NodeId, request.ChannelId, true)
+	if channelState == nil {
+		return lightning_helpers.ChannelStatusUpdateResponse{
+			CommunicationResponse: lightning_helpers.CommunicationResponse{
+				Status: lightning_helpers.Inactive,
+			},
+			Request: request,
+		}
+	}
+	if !channelStatusUpdateRequestContainsUpdates(request, channelState) {
+		return lightning_helpers.ChannelStatusUpdateResponse{
+			CommunicationResponse: lightning_helpers.CommunicationResponse{
+				Status: lightning_helpers.Active,
+			},
+			Request: request,
+		}
+	}
+
+	response = channelStatusUpdateRequestIsRepeated(request)
+	if response != nil {
+		return *response
+	}
+
+	connection, err := getConnection(request.NodeId)
+	if err != nil {
+		log.Error().Err(err).Msgf("Failed to obtain a GRPC connection.")
+		return lightning_helpers.ChannelStatusUpdateResponse{
+			CommunicationResponse: lightning_helpers.CommunicationResponse{
+				Status: lightning_helpers.Inactive,
+				Error:  err.Error(),
+			},
+			Request: request,
+		}
+	}
+
+	disabledMaxHtlcMsat, err := getDisabledMaxHtlcMsat(request.Db, request.ChannelId)
+	if err == nil && request.ChannelStatus == core.Inactive && disabledMaxHtlcMsat == nil {
+		err = setDisabledMaxHtlcMsat(request.Db, request.ChannelId, &channelState.LocalMaxHtlcMsat)
+	}
+	var resp *cln.SetchannelResponse
+	if err == nil {
+		channelSettings := cache.GetChannelSettingByChannelId(request.ChannelId)
+		resp, err = cln.NewNodeClient(connection).SetChannel(ctx, &cln.SetchannelRequest{
+			Id: *channelSettings.ShortChannelId,
+			Htlcmax: &cln.Amount{Msat: getChannelStatusMaxHtlcMsat(request.ChannelStatus, channelState,
+				uint64(channelSettings.Capacity)*1_000, disabledMaxHtlcMsat)},
+		})
+	}
+	if err == nil {
+		err = getSetChannelWarningError(resp)
+	}
+	if err == nil && request.ChannelStatus == core.Active {
+		err = setDisabledMaxHtlcMsat(request.Db, request.ChannelId, nil)
+	}
+	if err != nil {
+		log.Error().Err(err).Msgf("Failed to update channel status for channelId: %v on nodeId: %v",
+			request.ChannelId, request.NodeId)
+		return lightning_helpers.ChannelStatusUpdateResponse{
+			CommunicationResponse: lightning_helpers.CommunicationResponse{
+				Status: lightning_helpers.Inactive,
+				Error:  err.Error(),
+			},
+			Request: request,
+		}
+	}
+	return lightning_helpers.ChannelStatusUpdateResponse{
+		CommunicationResponse: lightning_helpers.CommunicationResponse{
+			Status: lightning_helpers.Active,
+		},
+		Request: request,
+	}
+}
+
+// getChannelStatusMaxHtlcMsat returns the maximum HTLC size that disables (clamp to the minimum HTLC size)
+// or enables (restore the maximum HTLC size of before the clamp or the capacity) the channel
+func getChannelStatusMaxHtlcMsat(channelStatus core.Status,
+	channelState *cache.ChannelStateSettingsCache,
+	capacityMsat uint64,
+	disabledMaxHtlcMsat *uint64) uint64 {
+
+	if channelStatus != core.Active {
+		return channelState.LocalMinHtlcMsat
+	}
+	if disabledMaxHtlcMsat != nil && *disabledMaxHtlcMsat > channelState.LocalMinHtlcMsat {
+		return *disabledMaxHtlcMsat
+	}
+	return capacityMsat
+}
+
+// getSetChannelWarningError returns an error when CLN refused the minimum HTLC size.
+// CLN caps the maximum HTLC size to what the channel allows so WarningHtlcmaxTooHigh is expected.
+func getSetChannelWarningError(resp *cln.SetchannelResponse) error {
+	if resp == nil {
+		return nil
+	}
+	for _, channel := range resp.Channels {
+		if channel.WarningHtlcminTooLow != nil {
+			return errors.New(*channel.WarningHtlcminTooLow)
+		}
+	}
+	return nil
+}
+
+// getDisabledMaxHtlcMsat returns nil when the channel was not disabled through Torq
+func getDisabledMaxHtlcMsat(db *sqlx.DB, channelId int) (*uint64, error) {
+	var disabledMaxHtlcMsat *uint64
+	err := db.Get(&disabledMaxHtlcMsat, `SELECT disabled_max_htlc_msat FROM channel WHERE channel_id=$1;`, channelId)
+	if err != nil {
+		return nil, errors.Wrap(err, database.SqlExecutionError)
+	}
+	return disabledMaxHtlcMsat, nil
+}
+
+func setDisabledMaxHtlcMsat(db *sqlx.DB, channelId int, disabledMaxHtlcMsat *uint64) error {
+	_, err := db.Exec(`UPDATE channel SET disabled_max_htlc_msat=$1, updated_on=$2 WHERE channel_id=$3;`,
+		disabledMaxHtlcMsat, time.Now().UTC(), channelId)
+	if err != nil {
+		return errors.Wrap(err, database.SqlExecutionError)
+	}
+	return nil
+}
+
+func channelStatusUpdateRequestIsRepeated(
+	request lightning_helpers.ChannelStatusUpdateRequest) *lightning_helpers.ChannelStatusUpdateResponse {
+
+	secondsAgo := routingPolicyUpdateLimiterSeconds
+	channelEventsFromGraph, err := graph_events.GetChannelEventFromGraph(request.Db, request.ChannelId, &secondsAgo)
+	if err != nil {
+		return &lightning_helpers.ChannelStatusUpdateResponse{
+			CommunicationResponse: lightning_helpers.CommunicationResponse{
+				Status: lightning_helpers.Inactive,
+				Error:  err.Error(),
+			},
+			Request: request,
+		}
+	}
+
+	if len(channelEventsFromGraph) > 1 {
+		disabled := isChannelEventDisabled(channelEventsFromGraph[0])
+		disabledCounter := 0
+		for i := 0; i < len(channelEventsFromGraph); i++ {
+			if disabled != isChannelEventDisabled(channelEventsFromGraph[i]) {
+				disabledCounter++
+				disabled = isChannelEventDisabled(channelEventsFromGraph[i])
+			}
+		}
+		if disabledCounter > 2 {
+			return &lightning_helpers.ChannelStatusUpdateResponse{
+				CommunicationResponse: lightning_helpers.CommunicationResponse{
+					Status: lightning_helpers.Inactive,
+					Error: fmt.Sprintf("Channel status update ignored due to rate limiter for channelId: %v",
+						request.ChannelId),
+				},
+				Request: request,
+			}
+		}
+	}
+	return nil
+}
+
+func isChannelEventDisabled(channelEvent graph_events.ChannelEventFromGraph) bool {
+	return channelEvent.Disabled || channelEvent.MaxHtlcMsat <= channelEvent.MinHtlcMsat
+}
+
+func isChannelDisabled(channelState *cache.ChannelStateSettingsCache) bool {
+	return channelState.LocalDisabled || channelState.LocalMaxHtlcMsat <= channelState.LocalMinHtlcMsat
+}
+
+func channelStatusUpdateRequestContainsUpdates(request lightning_helpers.ChannelStatusUpdateRequest,
+	channelState *cache.ChannelStateSettingsCache) bool {
+
+	if request.ChannelStatus == core.Active && isChannelDisabled(channelState) {
+		return true
+	}
+	if request.ChannelStatus == core.Inactive && !isChannelDisabled(channelState) {
+		return true
+	}
+	return false
+}
+
+func validateChannelStatusUpdateRequest(
+	request lightning_helpers.ChannelStatusUpdateRequest) *lightning_helpers.ChannelStatusUpdateResponse {
+
+	if request.ChannelId == 0 {
+		return &lightning_helpers.ChannelStatusUpdateResponse{
+			CommunicationResponse: lightning_helpers.CommunicationResponse{
+				Status: lightning_helpers.Inactive,
+				Error:  "ChannelId is 0",
+			},
+			Request: request,
+		}
+	}
+	if request.ChannelStatus != core.Active &&
+		request.ChannelStatus != core.Inactive {
+		return &lightning_helpers.ChannelStatusUpdateResponse{
+			CommunicationResponse: lightning_helpers.CommunicationResponse{
+				Status: lightning_helpers.Inactive,
+				Error:  "ChannelStatus is not Active nor Inactive",
+			},
+			Request: request,
+		}
+	}
+	channelSettings := cache.GetChannelSettingByChannelId(request.ChannelId)
+	if channelSettings.ShortChannelId == nil || *channelSettings.ShortChannelId == "" {
+		return &lightning_helpers.ChannelStatusUpdateResponse{
+			CommunicationResponse: lightning_helpers.CommunicationResponse{
+				Status: lightning_helpers.Inactive,
+				Error:  "ShortChannelId is not known",
+			},
+			Request: request,
+		}
+	}
+	return nil
+}
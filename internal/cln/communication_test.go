@@ -0,0 +1,113 @@
+package cln
+
+import (
+	"testing"
+
+	"github.com/lncapital/torq/internal/cache"
+	"github.com/lncapital/torq/internal/core"
+	"github.com/lncapital/torq/internal/lightning_helpers"
+	"github.com/lncapital/torq/proto/cln"
+	"github.com/lncapital/torq/testutil"
+)
+
+const testPublicKey = "03a1b2c3d4e5f60718293a4b5c6d7e8f90a1b2c3d4e5f60718293a4b5c6d7e8f90"
+
+func TestPrepareBatchOpenRequest(t *testing.T) {
+	channel := lightning_helpers.BatchOpenChannel{NodePublicKey: testPublicKey, LocalFundingAmount: 1_000_000}
+	testCases := []struct {
+		name     string
+		channels []lightning_helpers.BatchOpenChannel
+		wantErr  bool
+	}{
+		{name: "single channel", channels: []lightning_helpers.BatchOpenChannel{channel}},
+		{name: "multiple channels", channels: []lightning_helpers.BatchOpenChannel{channel, channel}},
+		{name: "no channels", wantErr: true},
+		{name: "no funding amount", channels: []lightning_helpers.BatchOpenChannel{channel, {NodePublicKey: testPublicKey}},
+			wantErr: true},
+		{name: "invalid public key", channels: []lightning_helpers.BatchOpenChannel{{NodePublicKey: "zz", LocalFundingAmount: 1}},
+			wantErr: true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			request := lightning_helpers.BatchOpenChannelRequest{Channels: tc.channels}
+			request.NodeId = 1
+			multiFundChannelReq, err := prepareBatchOpenRequest(request)
+			if (err != nil) != tc.wantErr {
+				testutil.Errorf(t, "prepareBatchOpenRequest() error = %v, wantErr %v", err, tc.wantErr)
+				return
+			}
+			if err == nil {
+				if len(multiFundChannelReq.Destinations) != len(tc.channels) ||
+					multiFundChannelReq.GetMinchannels() != int64(len(tc.channels)) {
+					testutil.Errorf(t, "prepareBatchOpenRequest() destinations = %v, minchannels = %v, want %v",
+						len(multiFundChannelReq.Destinations), multiFundChannelReq.GetMinchannels(), len(tc.channels))
+					return
+				}
+				for _, destination := range multiFundChannelReq.Destinations {
+					if destination.Id != testPublicKey || destination.Amount.GetAmount().Msat != 1_000_000_000 {
+						testutil.Errorf(t, "prepareBatchOpenRequest() destination = %v, amount = %v",
+							destination.Id, destination.Amount.GetAmount().Msat)
+						return
+					}
+				}
+			}
+			testutil.Successf(t, "prepareBatchOpenRequest() error = %v", err)
+		})
+	}
+}
+
+func TestGetChannelStatusMaxHtlcMsat(t *testing.T) {
+	channelState := &cache.ChannelStateSettingsCache{LocalMinHtlcMsat: 1_000, LocalMaxHtlcMsat: 500_000_000}
+	previousMaxHtlcMsat := uint64(250_000_000)
+	clampedMaxHtlcMsat := uint64(1_000)
+	testCases := []struct {
+		name                string
+		channelStatus       core.Status
+		disabledMaxHtlcMsat *uint64
+		want                uint64
+	}{
+		{name: "disable clamps to the minimum", channelStatus: core.Inactive, want: 1_000},
+		{name: "enable restores the previous maximum", channelStatus: core.Active,
+			disabledMaxHtlcMsat: &previousMaxHtlcMsat, want: 250_000_000},
+		{name: "enable without previous maximum", channelStatus: core.Active, want: 1_000_000_000},
+		{name: "enable with a clamped previous maximum", channelStatus: core.Active,
+			disabledMaxHtlcMsat: &clampedMaxHtlcMsat, want: 1_000_000_000},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := getChannelStatusMaxHtlcMsat(tc.channelStatus, channelState, 1_000_000_000, tc.disabledMaxHtlcMsat)
+			if got != tc.want {
+				testutil.Errorf(t, "getChannelStatusMaxHtlcMsat() = %v, want %v", got, tc.want)
+				return
+			}
+			testutil.Successf(t, "getChannelStatusMaxHtlcMsat() = %v", got)
+		})
+	}
+}
+
+func TestGetSetChannelWarningError(t *testing.T) {
+	warning := "htlcmin too low"
+	maxWarning := "htlcmax too high"
+	testCases := []struct {
+		name    string
+		resp    *cln.SetchannelResponse
+		wantErr bool
+	}{
+		{name: "no response"},
+		{name: "no warnings", resp: &cln.SetchannelResponse{Channels: []*cln.SetchannelChannels{{}}}},
+		{name: "htlcmax too high",
+			resp: &cln.SetchannelResponse{Channels: []*cln.SetchannelChannels{{WarningHtlcmaxTooHigh: &maxWarning}}}},
+		{name: "htlcmin too low", wantErr: true,
+			resp: &cln.SetchannelResponse{Channels: []*cln.SetchannelChannels{{WarningHtlcminTooLow: &warning}}}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := getSetChannelWarningError(tc.resp)
+			if (err != nil) != tc.wantErr {
+				testutil.Errorf(t, "getSetChannelWarningError() error = %v, wantErr %v", err, tc.wantErr)
+				return
+			}
+			testutil.Successf(t, "getSetChannelWarningError() error = %v", err)
+		})
+	}
+}
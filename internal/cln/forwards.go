@@ -0,0 +1,260 @@
+package cln
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/jmoiron/sqlx"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc"
+
+	"github.com/lncapital/torq/internal/cache"
+	"github.com/lncapital/torq/internal/core"
+	"github.com/lncapital/torq/internal/lnd"
+	"github.com/lncapital/torq/internal/services_helpers"
+	"github.com/lncapital/torq/proto/cln"
+)
+
+// CLN has no pagination for ListForwards (see listForwards) so the interval is longer than for the other services.
+const streamForwardsTickerSeconds = 30
+
+// CLN reports forwards by received time so a forward that settles after a more recent one was stored
+// would otherwise be skipped. We re-request this window, duplicates are ignored by the database.
+const streamForwardsGraceSeconds = 60 * 60
+
+type client_ListForwards interface {
+	ListForwards(ctx context.Context,
+		in *cln.ListforwardsRequest,
+		opts ...grpc.CallOption) (*cln.ListforwardsResponse, error)
+}
+
+// clnForwardsList is the last response of ListForwards for a node, shared by the forwards and the HTLC services.
+type clnForwardsList struct {
+	mutex    sync.Mutex
+	forwards []*cln.ListforwardsForwards
+	listedOn time.Time
+}
+
+var (
+	clnForwardsListsMutex sync.Mutex                       //nolint:gochecknoglobals
+	clnForwardsLists      = make(map[int]*clnForwardsList) //nolint:gochecknoglobals
+)
+
+// listForwards returns the forwards of the node. The CLN gRPC interface in use has no index/start pagination for
+// ListForwards so the full list is requested, to limit the cost the forwards and the HTLC services share one call
+// per tick (a list younger than the tick is reused). The services need different statuses (the HTLC service also
+// stores the failed forwards) so the list is not filtered by status.
+func listForwards(ctx context.Context,
+	client client_ListForwards,
+	nodeId int) ([]*cln.ListforwardsForwards, error) {
+
+	clnForwardsListsMutex.Lock()
+	forwardsList, exists := clnForwardsLists[nodeId]
+	if !exists {
+		forwardsList = &clnForwardsList{}
+		clnForwardsLists[nodeId] = forwardsList
+	}
+	clnForwardsListsMutex.Unlock()
+
+	forwardsList.mutex.Lock()
+	defer forwardsList.mutex.Unlock()
+	if time.Since(forwardsList.listedOn) < (streamForwardsTickerSeconds-1)*time.Second {
+		return forwardsList.forwards, nil
+	}
+	clnForwards, err := client.ListForwards(ctx, &cln.ListforwardsRequest{})
+	if err != nil {
+		return nil, errors.Wrapf(err, "listing forwards for nodeId: %v", nodeId)
+	}
+	forwardsList.forwards = clnForwards.Forwards
+	forwardsList.listedOn = time.Now()
+	return forwardsList.forwards, nil
+}
+
+func SubscribeAndStoreForwards(ctx context.Context,
+	client client_ListForwards,
+	db *sqlx.DB,
+	nodeSettings cache.NodeSettingsCache) {
+
+	serviceType := services_helpers.ClnServiceForwardsService
+
+	cache.SetInitializingNodeServiceState(serviceType, nodeSettings.NodeId)
+
+	ticker := time.NewTicker(streamForwardsTickerSeconds * time.Second)
+	defer ticker.Stop()
+	tickerChannel := ticker.C
+
+	var enforcedReferenceDate *time.Time
+	if !cache.HasCustomSetting(nodeSettings.NodeId, core.ImportHistoricForwards) {
+		log.Info().Msgf("Import of historic forwards is disabled for nodeId: %v", nodeSettings.NodeId)
+		now := time.Now().UTC()
+		enforcedReferenceDate = &now
+	}
+
+	err := listAndProcessForwards(ctx, db, client, serviceType, nodeSettings, enforcedReferenceDate, true)
+	if err != nil {
+		processError(ctx, serviceType, nodeSettings, err)
+		return
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			cache.SetInactiveNodeServiceState(serviceType, nodeSettings.NodeId)
+			return
+		case <-tickerChannel:
+			err = listAndProcessForwards(ctx, db, client, serviceType, nodeSettings, enforcedReferenceDate, false)
+			if err != nil {
+				processError(ctx, serviceType, nodeSettings, err)
+				return
+			}
+		}
+	}
+}
+
+func listAndProcessForwards(ctx context.Context, db *sqlx.DB, client client_ListForwards,
+	serviceType services_helpers.ServiceType,
+	nodeSettings cache.NodeSettingsCache,
+	enforcedReferenceDate *time.Time,
+	bootStrapping bool) error {
+
+	lastTime, err := fetchLastForwardTime(db, nodeSettings.NodeId)
+	if err != nil {
+		return errors.Wrapf(err, "obtaining last forward time for nodeId: %v", nodeSettings.NodeId)
+	}
+	if !lastTime.IsZero() {
+		lastTime = lastTime.Add(-streamForwardsGraceSeconds * time.Second)
+	}
+	if enforcedReferenceDate != nil && lastTime.Before(*enforcedReferenceDate) {
+		lastTime = *enforcedReferenceDate
+	}
+
+	// Only the settled forwards after lastTime are processed (see getNewForwards)
+	clnForwards, err := listForwards(ctx, client, nodeSettings.NodeId)
+	if err != nil {
+		return err
+	}
+
+	err = storeForwards(db, clnForwards, lastTime, nodeSettings, bootStrapping)
+	if err != nil {
+		return errors.Wrapf(err, "storing forwards for nodeId: %v", nodeSettings.NodeId)
+	}
+
+	if bootStrapping {
+		log.Info().Msgf("Initial import of forwards is done for nodeId: %v", nodeSettings.NodeId)
+		cache.SetActiveNodeServiceState(serviceType, nodeSettings.NodeId)
+	}
+	return nil
+}
+
+// fetchLastForwardTime fetches the time of the latest recorded forward for the node.
+func fetchLastForwardTime(db *sqlx.DB, nodeId int) (time.Time, error) {
+	var lastTime time.Time
+	err := db.Get(&lastTime, `SELECT time FROM forward WHERE node_id=$1 ORDER BY time DESC LIMIT 1;`, nodeId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return time.Time{}, nil
+		}
+		return time.Time{}, errors.Wrap(err, "query row of last forward time")
+	}
+	return lastTime, nil
+}
+
+func storeForwards(db *sqlx.DB,
+	clnForwards []*cln.ListforwardsForwards,
+	lastTime time.Time,
+	nodeSettings cache.NodeSettingsCache,
+	bootStrapping bool) error {
+
+	var forwardEvents []core.ForwardEvent
+	tx := db.MustBegin()
+	stmt, err := tx.Prepare(`INSERT INTO forward(time, time_ns, fee_msat,
+				incoming_amount_msat, outgoing_amount_msat, incoming_channel_id, outgoing_channel_id, node_id)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+			ON CONFLICT (time, time_ns) DO NOTHING;`)
+	if err != nil {
+		_ = tx.Rollback()
+		return errors.Wrap(err, "SQL Statement prepare")
+	}
+	defer stmt.Close()
+	for _, clnForward := range getNewForwards(clnForwards, lastTime) {
+		forwardTime := convertSeconds(clnForward.ReceivedTime)
+		incomingChannelId := getChannelIdByShortChannelId(clnForward.InChannel)
+		if incomingChannelId == nil {
+			log.Error().Msgf("Forward received for a non existing channel (incomingShortChannelId: %v)",
+				clnForward.InChannel)
+		}
+		outgoingChannelId := getChannelIdByShortChannelId(clnForward.GetOutChannel())
+		if outgoingChannelId == nil {
+			log.Error().Msgf("Forward received for a non existing channel (outgoingShortChannelId: %v)",
+				clnForward.GetOutChannel())
+		}
+		amountInMsat := clnForward.GetInMsat().GetMsat()
+		amountOutMsat := clnForward.GetOutMsat().GetMsat()
+		feeMsat := clnForward.GetFeeMsat().GetMsat()
+		result, err := stmt.Exec(forwardTime, forwardTime.UnixNano(), feeMsat, amountInMsat, amountOutMsat,
+			incomingChannelId, outgoingChannelId, nodeSettings.NodeId)
+		if err != nil {
+			_ = tx.Rollback()
+			return errors.Wrapf(err, "storeForwards->tx.Exec(%v)", clnForward)
+		}
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			_ = tx.Rollback()
+			return errors.Wrap(err, "obtaining rows affected")
+		}
+		if rowsAffected == 0 {
+			continue
+		}
+		forwardEvents = append(forwardEvents, core.ForwardEvent{
+			EventData: core.EventData{
+				EventTime: time.Now().UTC(),
+				NodeId:    nodeSettings.NodeId,
+			},
+			Timestamp:         forwardTime,
+			FeeMsat:           feeMsat,
+			AmountInMsat:      amountInMsat,
+			AmountOutMsat:     amountOutMsat,
+			IncomingChannelId: incomingChannelId,
+			OutgoingChannelId: outgoingChannelId,
+		})
+	}
+	err = tx.Commit()
+	if err != nil {
+		return errors.Wrap(err, "DB Commit")
+	}
+	if !bootStrapping {
+		for _, forwardEvent := range forwardEvents {
+			lnd.ProcessForwardEvent(forwardEvent)
+		}
+	}
+	return nil
+}
+
+// getNewForwards returns the settled forwards received after lastTime
+func getNewForwards(clnForwards []*cln.ListforwardsForwards, lastTime time.Time) []*cln.ListforwardsForwards {
+	var newForwards []*cln.ListforwardsForwards
+	for _, clnForward := range clnForwards {
+		if clnForward == nil || clnForward.Status != cln.ListforwardsForwards_SETTLED {
+			continue
+		}
+		if !convertSeconds(clnForward.ReceivedTime).After(lastTime) {
+			continue
+		}
+		newForwards = append(newForwards, clnForward)
+	}
+	return newForwards
+}
+
+func getChannelIdByShortChannelId(shortChannelId string) *int {
+	if shortChannelId == "" {
+		return nil
+	}
+	channelId := cache.GetChannelIdByShortChannelId(&shortChannelId)
+	if channelId == 0 {
+		return nil
+	}
+	return &channelId
+}
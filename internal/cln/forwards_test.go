@@ -0,0 +1,84 @@
+package cln
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+
+	"github.com/lncapital/torq/proto/cln"
+	"github.com/lncapital/torq/testutil"
+)
+
+func TestGetNewForwards(t *testing.T) {
+	lastTime := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	settled := &cln.ListforwardsForwards{Status: cln.ListforwardsForwards_SETTLED,
+		ReceivedTime: float64(lastTime.Unix()) + 0.5}
+	clnForwards := []*cln.ListforwardsForwards{
+		nil,
+		settled,
+		{Status: cln.ListforwardsForwards_SETTLED, ReceivedTime: float64(lastTime.Unix())},
+		{Status: cln.ListforwardsForwards_FAILED, ReceivedTime: float64(lastTime.Unix()) + 1},
+		{Status: cln.ListforwardsForwards_OFFERED, ReceivedTime: float64(lastTime.Unix()) + 1},
+	}
+
+	newForwards := getNewForwards(clnForwards, lastTime)
+	if len(newForwards) != 1 || newForwards[0] != settled {
+		testutil.Errorf(t, "getNewForwards() = %v, want %v", newForwards, settled)
+		return
+	}
+	testutil.Successf(t, "getNewForwards() = %v", newForwards)
+}
+
+func TestConvertSeconds(t *testing.T) {
+	got := convertSeconds(1_672_531_200.123456789)
+	want := time.Date(2023, 1, 1, 0, 0, 0, 123_457_000, time.UTC)
+	if !got.Equal(want) || got.Location() != time.UTC {
+		testutil.Errorf(t, "convertSeconds() = %v, want %v", got, want)
+		return
+	}
+	testutil.Successf(t, "convertSeconds() = %v", got)
+}
+
+type countingListForwardsClient struct {
+	calls int
+}
+
+func (client *countingListForwardsClient) ListForwards(context.Context, *cln.ListforwardsRequest,
+	...grpc.CallOption) (*cln.ListforwardsResponse, error) {
+
+	client.calls++
+	return &cln.ListforwardsResponse{Forwards: []*cln.ListforwardsForwards{{InChannel: "103x1x0"}}}, nil
+}
+
+func TestListForwardsSharesOneCallPerTick(t *testing.T) {
+	nodeId := -1
+	defer func() {
+		clnForwardsListsMutex.Lock()
+		delete(clnForwardsLists, nodeId)
+		clnForwardsListsMutex.Unlock()
+	}()
+
+	client := &countingListForwardsClient{}
+	// The forwards service and the HTLC service within the same tick
+	for i := 0; i < 2; i++ {
+		forwards, err := listForwards(context.Background(), client, nodeId)
+		if err != nil || len(forwards) != 1 {
+			testutil.Fatalf(t, "listForwards() = %v, %v", forwards, err)
+		}
+	}
+	if client.calls != 1 {
+		testutil.Errorf(t, "ListForwards calls within one tick = %v, want 1", client.calls)
+		return
+	}
+
+	// The next tick
+	clnForwardsLists[nodeId].listedOn = time.Now().Add(-streamForwardsTickerSeconds * time.Second)
+	_, err := listForwards(context.Background(), client, nodeId)
+	if err != nil || client.calls != 2 {
+		testutil.Errorf(t, "ListForwards calls after one tick = %v, want 2 (err: %v)", client.calls, err)
+		return
+	}
+	testutil.Successf(t, "ListForwards calls = %v", client.calls)
+}
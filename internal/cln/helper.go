@@ -0,0 +1,29 @@
+package cln
+
+import (
+	"time"
+
+	"github.com/btcsuite/btcd/chaincfg"
+
+	"github.com/lncapital/torq/internal/core"
+)
+
+// convertSeconds converts the fractional unix timestamps CLN uses (i.e. received_time) into UTC time.
+func convertSeconds(seconds float64) time.Time {
+	return time.Unix(0, int64(seconds*float64(time.Second))).Round(time.Microsecond).UTC()
+}
+
+func getChainParams(network core.Network) *chaincfg.Params {
+	switch network {
+	case core.TestNet:
+		return &chaincfg.TestNet3Params
+	case core.RegTest:
+		return &chaincfg.RegressionNetParams
+	case core.SigNet:
+		return &chaincfg.SigNetParams
+	case core.SimNet:
+		return &chaincfg.SimNetParams
+	default:
+		return &chaincfg.MainNetParams
+	}
+}
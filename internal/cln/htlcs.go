@@ -0,0 +1,287 @@
+package cln
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/jmoiron/sqlx"
+	"github.com/rs/zerolog/log"
+
+	"github.com/lncapital/torq/internal/cache"
+	"github.com/lncapital/torq/internal/core"
+	"github.com/lncapital/torq/internal/lnd"
+	"github.com/lncapital/torq/internal/services_helpers"
+	"github.com/lncapital/torq/proto/cln"
+)
+
+// SubscribeAndStoreHtlcs polls the forwards of CLN and stores the resolved ones in the database as HTLC events.
+// NB: CLN does not expose an HTLC event stream so failures are only known once the forward is resolved.
+// Like with LND the dataset is not guaranteed to be complete, it is primarily used to diagnose a channel / node.
+func SubscribeAndStoreHtlcs(ctx context.Context,
+	client client_ListForwards,
+	db *sqlx.DB,
+	nodeSettings cache.NodeSettingsCache) {
+
+	serviceType := services_helpers.ClnServiceHtlcsService
+
+	cache.SetInitializingNodeServiceState(serviceType, nodeSettings.NodeId)
+
+	ticker := time.NewTicker(streamForwardsTickerSeconds * time.Second)
+	defer ticker.Stop()
+	tickerChannel := ticker.C
+
+	// Just like the LND HTLC event stream we only record HTLC events from the moment the service starts.
+	serviceStart := time.Now().UTC()
+
+	err := listAndProcessHtlcs(ctx, db, client, serviceType, nodeSettings, serviceStart, true)
+	if err != nil {
+		processError(ctx, serviceType, nodeSettings, err)
+		return
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			cache.SetInactiveNodeServiceState(serviceType, nodeSettings.NodeId)
+			return
+		case <-tickerChannel:
+			err = listAndProcessHtlcs(ctx, db, client, serviceType, nodeSettings, serviceStart, false)
+			if err != nil {
+				processError(ctx, serviceType, nodeSettings, err)
+				return
+			}
+		}
+	}
+}
+
+func listAndProcessHtlcs(ctx context.Context, db *sqlx.DB, client client_ListForwards,
+	serviceType services_helpers.ServiceType,
+	nodeSettings cache.NodeSettingsCache,
+	serviceStart time.Time,
+	bootStrapping bool) error {
+
+	lastTime, err := fetchLastHtlcEventTime(db, nodeSettings.NodeId)
+	if err != nil {
+		return errors.Wrapf(err, "obtaining last HTLC event time for nodeId: %v", nodeSettings.NodeId)
+	}
+	lastTime = lastTime.Add(-streamForwardsGraceSeconds * time.Second)
+	if lastTime.Before(serviceStart) {
+		lastTime = serviceStart
+	}
+
+	// The forwards are shared with the forwards service, the existing HTLC events are verified in bulk.
+	clnForwards, err := listForwards(ctx, client, nodeSettings.NodeId)
+	if err != nil {
+		return err
+	}
+
+	err = storeHtlcs(db, clnForwards, lastTime, nodeSettings, bootStrapping)
+	if err != nil {
+		return errors.Wrapf(err, "storing HTLC events for nodeId: %v", nodeSettings.NodeId)
+	}
+
+	if bootStrapping {
+		log.Info().Msgf("Initial import of HTLC events is done for nodeId: %v", nodeSettings.NodeId)
+		cache.SetActiveNodeServiceState(serviceType, nodeSettings.NodeId)
+	}
+	return nil
+}
+
+func fetchLastHtlcEventTime(db *sqlx.DB, nodeId int) (time.Time, error) {
+	var lastTime time.Time
+	err := db.Get(&lastTime, `SELECT time FROM htlc_event WHERE node_id=$1 ORDER BY time DESC LIMIT 1;`, nodeId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return time.Time{}, nil
+		}
+		return time.Time{}, errors.Wrap(err, "query row of last HTLC event time")
+	}
+	return lastTime, nil
+}
+
+type htlcEvent struct {
+	clnForward *cln.ListforwardsForwards
+	eventTime  time.Time
+	eventType  string
+}
+
+func storeHtlcs(db *sqlx.DB,
+	clnForwards []*cln.ListforwardsForwards,
+	lastTime time.Time,
+	nodeSettings cache.NodeSettingsCache,
+	bootStrapping bool) error {
+
+	existingHtlcEvents, err := getExistingHtlcEvents(db, nodeSettings.NodeId, lastTime)
+	if err != nil {
+		return errors.Wrapf(err, "obtaining existing HTLC events for nodeId: %v", nodeSettings.NodeId)
+	}
+	htlcEvents := getNewHtlcEvents(clnForwards, lastTime, existingHtlcEvents)
+	if len(htlcEvents) == 0 {
+		return nil
+	}
+
+	var publishedEvents []core.HtlcEvent
+	tx := db.MustBegin()
+	for _, event := range htlcEvents {
+		publishedEvent, err := storeHtlc(tx, event, nodeSettings)
+		if err != nil {
+			_ = tx.Rollback()
+			return errors.Wrapf(err, "persisting HTLC event for nodeId: %v", nodeSettings.NodeId)
+		}
+		publishedEvents = append(publishedEvents, publishedEvent)
+	}
+	err = tx.Commit()
+	if err != nil {
+		return errors.Wrap(err, "DB Commit")
+	}
+	for _, publishedEvent := range publishedEvents {
+		cache.PublishEvent(publishedEvent)
+	}
+	if !bootStrapping {
+		for _, event := range htlcEvents {
+			if event.eventType == "ForwardFailEvent" || event.eventType == "LinkFailEvent" {
+				lnd.ProcessHtlcFailure(nodeSettings.NodeId, event.eventTime)
+			}
+		}
+	}
+	return nil
+}
+
+// getNewHtlcEvents returns the resolved forwards after lastTime that are not stored yet.
+func getNewHtlcEvents(clnForwards []*cln.ListforwardsForwards,
+	lastTime time.Time,
+	existingHtlcEvents map[string]bool) []htlcEvent {
+
+	var htlcEvents []htlcEvent
+	for _, clnForward := range clnForwards {
+		if clnForward == nil {
+			continue
+		}
+		var eventType string
+		switch clnForward.Status {
+		case cln.ListforwardsForwards_SETTLED:
+			eventType = "SettleEvent"
+		case cln.ListforwardsForwards_FAILED:
+			eventType = "ForwardFailEvent"
+		case cln.ListforwardsForwards_LOCAL_FAILED:
+			eventType = "LinkFailEvent"
+		default:
+			// Offered HTLCs are still in flight, they get stored once resolved.
+			continue
+		}
+		eventTime := convertSeconds(clnForward.ReceivedTime)
+		if !eventTime.After(lastTime) {
+			continue
+		}
+		key := getHtlcEventKey(eventTime, eventType, clnForward.InHtlcId)
+		if existingHtlcEvents[key] {
+			continue
+		}
+		// The same forward can't be stored twice when CLN would list it twice
+		existingHtlcEvents[key] = true
+		htlcEvents = append(htlcEvents, htlcEvent{clnForward: clnForward, eventTime: eventTime, eventType: eventType})
+	}
+	return htlcEvents
+}
+
+func getHtlcEventKey(eventTime time.Time, eventType string, incomingHtlcId *uint64) string {
+	if incomingHtlcId == nil {
+		return fmt.Sprintf("%v_%v_", eventTime.UnixNano(), eventType)
+	}
+	return fmt.Sprintf("%v_%v_%v", eventTime.UnixNano(), eventType, *incomingHtlcId)
+}
+
+// getExistingHtlcEvents obtains the keys of the HTLC events after lastTime in one query
+func getExistingHtlcEvents(db *sqlx.DB, nodeId int, lastTime time.Time) (map[string]bool, error) {
+	rows, err := db.Queryx(`
+		SELECT time, event_type, incoming_htlc_id
+		FROM htlc_event
+		WHERE node_id=$1 AND time>$2 AND event_origin='FORWARD';`, nodeId, lastTime)
+	if err != nil {
+		return nil, errors.Wrap(err, "DB Query HTLC events")
+	}
+	defer rows.Close()
+	existingHtlcEvents := make(map[string]bool)
+	for rows.Next() {
+		var eventTime time.Time
+		var eventType string
+		var incomingHtlcId *uint64
+		err = rows.Scan(&eventTime, &eventType, &incomingHtlcId)
+		if err != nil {
+			return nil, errors.Wrap(err, "DB Scan HTLC event")
+		}
+		existingHtlcEvents[getHtlcEventKey(eventTime, eventType, incomingHtlcId)] = true
+	}
+	return existingHtlcEvents, nil
+}
+
+func storeHtlc(tx *sqlx.Tx, event htlcEvent, nodeSettings cache.NodeSettingsCache) (core.HtlcEvent, error) {
+	clnForward := event.clnForward
+	eventTime := event.eventTime
+	eventType := event.eventType
+	jb, err := json.Marshal(clnForward)
+	if err != nil {
+		return core.HtlcEvent{}, errors.Wrapf(err, "marshalling HTLC event (%v) %v", eventType, clnForward)
+	}
+	eventOrigin := "FORWARD"
+	timestampNs := uint64(eventTime.UnixNano())
+	incomingAmountMsat := clnForward.GetInMsat().GetMsat()
+	var outgoingAmountMsat *uint64
+	if clnForward.OutMsat != nil {
+		outgoingAmountMsat = &clnForward.OutMsat.Msat
+	}
+	incomingChannelId := getChannelIdByShortChannelId(clnForward.InChannel)
+	outgoingChannelId := getChannelIdByShortChannelId(clnForward.GetOutChannel())
+	_, err = tx.Exec(`
+		INSERT INTO htlc_event (
+			time,
+			event_origin,
+			timestamp_ns,
+			data,
+			event_type,
+			incoming_amt_msat,
+			outgoing_amt_msat,
+			outgoing_htlc_id,
+			incoming_htlc_id,
+			incoming_channel_id,
+			outgoing_channel_id,
+			node_id
+		) VALUES($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)`,
+		eventTime,
+		eventOrigin,
+		timestampNs,
+		string(jb),
+		eventType,
+		incomingAmountMsat,
+		outgoingAmountMsat,
+		clnForward.OutHtlcId,
+		clnForward.InHtlcId,
+		incomingChannelId,
+		outgoingChannelId,
+		nodeSettings.NodeId,
+	)
+	if err != nil {
+		return core.HtlcEvent{}, errors.Wrap(err, "DB Exec adding HTLC Event")
+	}
+	return core.HtlcEvent{
+		EventData: core.EventData{
+			EventTime: time.Now().UTC(),
+			NodeId:    nodeSettings.NodeId,
+		},
+		Timestamp:         eventTime,
+		Data:              string(jb),
+		EventOrigin:       &eventOrigin,
+		EventType:         &eventType,
+		OutgoingHtlcId:    clnForward.OutHtlcId,
+		IncomingHtlcId:    clnForward.InHtlcId,
+		TimestampNs:       &timestampNs,
+		IncomingAmtMsat:   &incomingAmountMsat,
+		OutgoingAmtMsat:   outgoingAmountMsat,
+		IncomingChannelId: incomingChannelId,
+		OutgoingChannelId: outgoingChannelId,
+	}, nil
+}
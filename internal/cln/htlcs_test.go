@@ -0,0 +1,62 @@
+package cln
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lncapital/torq/proto/cln"
+	"github.com/lncapital/torq/testutil"
+)
+
+func TestGetNewHtlcEvents(t *testing.T) {
+	lastTime := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	before := float64(lastTime.Add(-time.Minute).Unix())
+	after := float64(lastTime.Add(time.Minute).Unix())
+	storedHtlcId := uint64(1)
+	newHtlcId := uint64(2)
+	clnForwards := []*cln.ListforwardsForwards{
+		nil,
+		{Status: cln.ListforwardsForwards_SETTLED, ReceivedTime: after, InHtlcId: &storedHtlcId},
+		{Status: cln.ListforwardsForwards_SETTLED, ReceivedTime: after, InHtlcId: &newHtlcId},
+		{Status: cln.ListforwardsForwards_SETTLED, ReceivedTime: after, InHtlcId: &newHtlcId},
+		{Status: cln.ListforwardsForwards_FAILED, ReceivedTime: after},
+		{Status: cln.ListforwardsForwards_LOCAL_FAILED, ReceivedTime: after},
+		{Status: cln.ListforwardsForwards_OFFERED, ReceivedTime: after},
+		{Status: cln.ListforwardsForwards_SETTLED, ReceivedTime: before},
+	}
+	existingHtlcEvents := map[string]bool{
+		getHtlcEventKey(convertSeconds(after), "SettleEvent", &storedHtlcId): true,
+	}
+
+	htlcEvents := getNewHtlcEvents(clnForwards, lastTime, existingHtlcEvents)
+	wantEventTypes := []string{"SettleEvent", "ForwardFailEvent", "LinkFailEvent"}
+	if len(htlcEvents) != len(wantEventTypes) {
+		testutil.Fatalf(t, "getNewHtlcEvents() = %v, want %v", htlcEvents, wantEventTypes)
+	}
+	for i, wantEventType := range wantEventTypes {
+		if htlcEvents[i].eventType != wantEventType || !htlcEvents[i].eventTime.Equal(convertSeconds(after)) {
+			testutil.Errorf(t, "getNewHtlcEvents()[%v] = %v, want %v", i, htlcEvents[i], wantEventType)
+			return
+		}
+	}
+	if *htlcEvents[0].clnForward.InHtlcId != newHtlcId {
+		testutil.Errorf(t, "getNewHtlcEvents()[0] has incoming HTLC id %v, want %v",
+			*htlcEvents[0].clnForward.InHtlcId, newHtlcId)
+		return
+	}
+	testutil.Successf(t, "getNewHtlcEvents() = %v", htlcEvents)
+}
+
+func TestGetHtlcEventKey(t *testing.T) {
+	eventTime := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	htlcId := uint64(0)
+	if getHtlcEventKey(eventTime, "SettleEvent", nil) == getHtlcEventKey(eventTime, "SettleEvent", &htlcId) {
+		testutil.Errorf(t, "getHtlcEventKey() should differ for a missing and a zero HTLC id")
+		return
+	}
+	if getHtlcEventKey(eventTime, "SettleEvent", &htlcId) != getHtlcEventKey(eventTime.Local(), "SettleEvent", &htlcId) {
+		testutil.Errorf(t, "getHtlcEventKey() should not depend on the time zone")
+		return
+	}
+	testutil.Successf(t, "getHtlcEventKey() = %v", getHtlcEventKey(eventTime, "SettleEvent", &htlcId))
+}
@@ -0,0 +1,382 @@
+package cln
+
+import (
+	"context"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/jmoiron/sqlx"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc"
+
+	"github.com/lncapital/torq/internal/cache"
+	"github.com/lncapital/torq/internal/core"
+	"github.com/lncapital/torq/internal/database"
+	"github.com/lncapital/torq/internal/lnd"
+	"github.com/lncapital/torq/internal/services_helpers"
+	"github.com/lncapital/torq/proto/cln"
+	"github.com/lncapital/torq/proto/lnrpc"
+	"github.com/lncapital/torq/proto/lnrpc/zpay32"
+)
+
+// WaitAnyInvoice blocks until an invoice gets paid, after this timeout we refresh the full list
+// so newly created and expired invoices are picked up too.
+// NB: the CLN gRPC interface has no pagination for ListInvoices so the refresh is kept infrequent.
+const streamInvoicesTimeoutSeconds = 10 * 60
+
+type client_Invoices interface {
+	ListInvoices(ctx context.Context,
+		in *cln.ListinvoicesRequest,
+		opts ...grpc.CallOption) (*cln.ListinvoicesResponse, error)
+	WaitAnyInvoice(ctx context.Context,
+		in *cln.WaitanyinvoiceRequest,
+		opts ...grpc.CallOption) (*cln.WaitanyinvoiceResponse, error)
+}
+
+type existingInvoice struct {
+	InvoiceId    int    `db:"invoice_id"`
+	RHash        string `db:"r_hash"`
+	InvoiceState string `db:"invoice_state"`
+}
+
+type invoice struct {
+	InvoiceId         int       `db:"invoice_id"`
+	Memo              string    `db:"memo"`
+	RPreimage         string    `db:"r_preimage"`
+	RHash             string    `db:"r_hash"`
+	ValueMsat         uint64    `db:"value_msat"`
+	CreationDate      time.Time `db:"creation_date"`
+	SettleDate        time.Time `db:"settle_date"`
+	PaymentRequest    string    `db:"payment_request"`
+	Destination       string    `db:"destination_pub_key"`
+	DescriptionHash   []byte    `db:"description_hash"`
+	Expiry            int64     `db:"expiry"`
+	CltvExpiry        uint64    `db:"cltv_expiry"`
+	RouteHints        []byte    `db:"route_hints"`
+	SettleIndex       *uint64   `db:"settle_index"`
+	AmtPaidMsat       uint64    `db:"amt_paid_msat"`
+	InvoiceState      string    `db:"invoice_state"`
+	Features          []byte    `db:"features"`
+	PaymentAddr       string    `db:"payment_addr"`
+	DestinationNodeId *int      `db:"destination_node_id"`
+	NodeId            int       `db:"node_id"`
+	CreatedOn         time.Time `db:"created_on"`
+	UpdatedOn         time.Time `db:"updated_on"`
+}
+
+func SubscribeAndStoreInvoices(ctx context.Context,
+	client client_Invoices,
+	db *sqlx.DB,
+	nodeSettings cache.NodeSettingsCache) {
+
+	serviceType := services_helpers.ClnServiceInvoicesService
+
+	cache.SetInitializingNodeServiceState(serviceType, nodeSettings.NodeId)
+
+	err := listAndProcessInvoices(ctx, db, client, serviceType, nodeSettings, true)
+	if err != nil {
+		processError(ctx, serviceType, nodeSettings, err)
+		return
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			cache.SetInactiveNodeServiceState(serviceType, nodeSettings.NodeId)
+			return
+		default:
+		}
+
+		lastPayIndex, err := fetchLastPayIndex(db, nodeSettings.NodeId)
+		if err != nil {
+			processError(ctx, serviceType, nodeSettings, err)
+			return
+		}
+
+		timeout := uint64(streamInvoicesTimeoutSeconds)
+		paidInvoice, err := client.WaitAnyInvoice(ctx, &cln.WaitanyinvoiceRequest{
+			LastpayIndex: &lastPayIndex,
+			Timeout:      &timeout,
+		})
+		if err != nil {
+			if errors.Is(ctx.Err(), context.Canceled) {
+				cache.SetInactiveNodeServiceState(serviceType, nodeSettings.NodeId)
+				return
+			}
+			// Most likely the timeout expired, when CLN is unreachable the refresh will fail too.
+			err = listAndProcessInvoices(ctx, db, client, serviceType, nodeSettings, false)
+			if err != nil {
+				processError(ctx, serviceType, nodeSettings, err)
+				return
+			}
+			continue
+		}
+
+		clnInvoice := &cln.ListinvoicesInvoices{
+			Label:              paidInvoice.Label,
+			Description:        &paidInvoice.Description,
+			PaymentHash:        paidInvoice.PaymentHash,
+			Status:             cln.ListinvoicesInvoices_PAID,
+			ExpiresAt:          paidInvoice.ExpiresAt,
+			AmountMsat:         paidInvoice.AmountMsat,
+			Bolt11:             paidInvoice.Bolt11,
+			Bolt12:             paidInvoice.Bolt12,
+			PayIndex:           paidInvoice.PayIndex,
+			AmountReceivedMsat: paidInvoice.AmountReceivedMsat,
+			PaidAt:             paidInvoice.PaidAt,
+			PaymentPreimage:    paidInvoice.PaymentPreimage,
+		}
+		if paidInvoice.Status == cln.WaitanyinvoiceResponse_EXPIRED {
+			clnInvoice.Status = cln.ListinvoicesInvoices_EXPIRED
+		}
+		err = processInvoice(db, clnInvoice, nodeSettings, false)
+		if err != nil {
+			processError(ctx, serviceType, nodeSettings, err)
+			return
+		}
+	}
+}
+
+func listAndProcessInvoices(ctx context.Context, db *sqlx.DB, client client_Invoices,
+	serviceType services_helpers.ServiceType,
+	nodeSettings cache.NodeSettingsCache,
+	bootStrapping bool) error {
+
+	clnInvoices, err := client.ListInvoices(ctx, &cln.ListinvoicesRequest{})
+	if err != nil {
+		return errors.Wrapf(err, "listing invoices for nodeId: %v", nodeSettings.NodeId)
+	}
+
+	existingInvoices, err := getExistingInvoices(db, nodeSettings.NodeId)
+	if err != nil {
+		return errors.Wrapf(err, "obtaining existing invoices for nodeId: %v", nodeSettings.NodeId)
+	}
+	for _, clnInvoice := range getChangedInvoices(clnInvoices.Invoices, existingInvoices) {
+		existing := existingInvoices[hex.EncodeToString(clnInvoice.PaymentHash)]
+		err = storeInvoice(db, clnInvoice, existing.InvoiceId, nodeSettings, bootStrapping)
+		if err != nil {
+			return errors.Wrapf(err, "storing invoices for nodeId: %v", nodeSettings.NodeId)
+		}
+	}
+
+	if bootStrapping {
+		log.Info().Msgf("Initial import of invoices is done for nodeId: %v", nodeSettings.NodeId)
+		cache.SetActiveNodeServiceState(serviceType, nodeSettings.NodeId)
+	}
+	return nil
+}
+
+func fetchLastPayIndex(db *sqlx.DB, nodeId int) (uint64, error) {
+	var payIndex uint64
+	err := db.Get(&payIndex, `SELECT COALESCE(MAX(settle_index), 0) FROM invoice WHERE node_id=$1;`, nodeId)
+	if err != nil {
+		return 0, errors.Wrapf(err, "obtaining maximum pay index for nodeId: %v", nodeId)
+	}
+	return payIndex, nil
+}
+
+// getExistingInvoices obtains the state of all stored invoices of the node in one query
+func getExistingInvoices(db *sqlx.DB, nodeId int) (map[string]existingInvoice, error) {
+	var invoices []existingInvoice
+	err := db.Select(&invoices, `SELECT invoice_id, r_hash, invoice_state FROM invoice WHERE node_id=$1;`, nodeId)
+	if err != nil {
+		return nil, errors.Wrap(err, database.SqlExecutionError)
+	}
+	existingInvoices := make(map[string]existingInvoice, len(invoices))
+	for _, existing := range invoices {
+		existingInvoices[existing.RHash] = existing
+	}
+	return existingInvoices, nil
+}
+
+// getChangedInvoices returns the invoices that are new or have a different state than the stored invoice.
+// Unchanged invoices are skipped before their payment request gets decoded.
+func getChangedInvoices(clnInvoices []*cln.ListinvoicesInvoices,
+	existingInvoices map[string]existingInvoice) []*cln.ListinvoicesInvoices {
+
+	var changedInvoices []*cln.ListinvoicesInvoices
+	for _, clnInvoice := range clnInvoices {
+		if clnInvoice == nil {
+			continue
+		}
+		existing, exists := existingInvoices[hex.EncodeToString(clnInvoice.PaymentHash)]
+		if exists && existing.InvoiceState == getInvoiceState(clnInvoice.Status).String() {
+			continue
+		}
+		changedInvoices = append(changedInvoices, clnInvoice)
+	}
+	return changedInvoices
+}
+
+func processInvoice(db *sqlx.DB,
+	clnInvoice *cln.ListinvoicesInvoices,
+	nodeSettings cache.NodeSettingsCache,
+	bootStrapping bool) error {
+
+	rHash := hex.EncodeToString(clnInvoice.PaymentHash)
+	var existing existingInvoice
+	err := db.Get(&existing, `SELECT invoice_id, r_hash, invoice_state FROM invoice WHERE node_id=$1 AND r_hash=$2;`,
+		nodeSettings.NodeId, rHash)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return errors.Wrapf(err, "obtaining existing invoice for r_hash: %v", rHash)
+	}
+	if len(getChangedInvoices([]*cln.ListinvoicesInvoices{clnInvoice},
+		map[string]existingInvoice{existing.RHash: existing})) == 0 {
+		return nil
+	}
+	return storeInvoice(db, clnInvoice, existing.InvoiceId, nodeSettings, bootStrapping)
+}
+
+// storeInvoice inserts the invoice or updates the existing invoice (when existingInvoiceId is not 0)
+func storeInvoice(db *sqlx.DB,
+	clnInvoice *cln.ListinvoicesInvoices,
+	existingInvoiceId int,
+	nodeSettings cache.NodeSettingsCache,
+	bootStrapping bool) error {
+
+	inv := constructInvoice(clnInvoice, nodeSettings)
+	if existingInvoiceId == 0 {
+		err := insertInvoice(db, inv)
+		if err != nil {
+			return errors.Wrapf(err, "inserting invoice for r_hash: %v", inv.RHash)
+		}
+	} else {
+		inv.InvoiceId = existingInvoiceId
+		err := updateInvoice(db, inv)
+		if err != nil {
+			return errors.Wrapf(err, "updating invoice for r_hash: %v", inv.RHash)
+		}
+	}
+
+	if !bootStrapping {
+		lnd.ProcessInvoiceEvent(constructInvoiceEvent(clnInvoice, inv, nodeSettings))
+	}
+	return nil
+}
+
+func constructInvoice(clnInvoice *cln.ListinvoicesInvoices, nodeSettings cache.NodeSettingsCache) invoice {
+	now := time.Now().UTC()
+	inv := invoice{
+		Memo:           clnInvoice.GetDescription(),
+		RPreimage:      hex.EncodeToString(clnInvoice.PaymentPreimage),
+		RHash:          hex.EncodeToString(clnInvoice.PaymentHash),
+		ValueMsat:      clnInvoice.GetAmountMsat().GetMsat(),
+		CreationDate:   now,
+		SettleDate:     time.Unix(0, 0).UTC(),
+		PaymentRequest: clnInvoice.GetBolt11(),
+		SettleIndex:    clnInvoice.PayIndex,
+		AmtPaidMsat:    clnInvoice.GetAmountReceivedMsat().GetMsat(),
+		InvoiceState:   getInvoiceState(clnInvoice.Status).String(),
+		RouteHints:     []byte("null"),
+		Features:       []byte("null"),
+		NodeId:         nodeSettings.NodeId,
+		CreatedOn:      now,
+		UpdatedOn:      now,
+	}
+	if clnInvoice.PaidAt != nil {
+		inv.SettleDate = time.Unix(int64(*clnInvoice.PaidAt), 0).UTC()
+	}
+
+	if inv.PaymentRequest == "" {
+		return inv
+	}
+	decodedInvoice, err := zpay32.Decode(inv.PaymentRequest, getChainParams(nodeSettings.Network))
+	if err != nil {
+		log.Error().Err(err).Msgf("Failed to decode payment request for nodeId: %v", nodeSettings.NodeId)
+		return inv
+	}
+	inv.CreationDate = decodedInvoice.Timestamp.UTC()
+	inv.Expiry = int64(decodedInvoice.Expiry().Seconds())
+	inv.CltvExpiry = decodedInvoice.MinFinalCLTVExpiry()
+	if decodedInvoice.Destination != nil {
+		inv.Destination = fmt.Sprintf("%x", decodedInvoice.Destination.SerializeCompressed())
+		destinationNodeId := cache.GetPeerNodeIdByPublicKey(inv.Destination, nodeSettings.Chain, nodeSettings.Network)
+		if destinationNodeId != 0 {
+			inv.DestinationNodeId = &destinationNodeId
+		}
+	}
+	if decodedInvoice.DescriptionHash != nil {
+		inv.DescriptionHash = decodedInvoice.DescriptionHash[:]
+	}
+	if decodedInvoice.PaymentAddr != nil {
+		inv.PaymentAddr = hex.EncodeToString(decodedInvoice.PaymentAddr[:])
+	}
+	routeHints, err := json.Marshal(decodedInvoice.RouteHints)
+	if err == nil {
+		inv.RouteHints = routeHints
+	}
+	features, err := json.Marshal(decodedInvoice.Features)
+	if err == nil {
+		inv.Features = features
+	}
+	return inv
+}
+
+// constructInvoiceEvent leaves AddIndex unset: the CLN gRPC interface in use has no created_index and the pay_index
+// (stored as settle_index) only exists for paid invoices so it does not have the LND add_index semantics.
+func constructInvoiceEvent(clnInvoice *cln.ListinvoicesInvoices,
+	inv invoice,
+	nodeSettings cache.NodeSettingsCache) core.InvoiceEvent {
+
+	invoiceEvent := core.InvoiceEvent{
+		EventData: core.EventData{
+			EventTime: time.Now().UTC(),
+			NodeId:    nodeSettings.NodeId,
+		},
+		ValueMSat:         inv.ValueMsat,
+		State:             getInvoiceState(clnInvoice.Status),
+		DestinationNodeId: inv.DestinationNodeId,
+	}
+	if clnInvoice.Status == cln.ListinvoicesInvoices_PAID {
+		invoiceEvent.AmountPaidMsat = inv.AmtPaidMsat
+		invoiceEvent.SettledDate = inv.SettleDate
+	}
+	return invoiceEvent
+}
+
+// getInvoiceState maps the CLN invoice status onto the LND states stored by Torq.
+func getInvoiceState(status cln.ListinvoicesInvoices_ListinvoicesInvoicesStatus) lnrpc.Invoice_InvoiceState {
+	switch status {
+	case cln.ListinvoicesInvoices_PAID:
+		return lnrpc.Invoice_SETTLED
+	case cln.ListinvoicesInvoices_EXPIRED:
+		return lnrpc.Invoice_CANCELED
+	default:
+		return lnrpc.Invoice_OPEN
+	}
+}
+
+func insertInvoice(db *sqlx.DB, inv invoice) error {
+	_, err := db.NamedExec(`
+		INSERT INTO invoice (
+			memo, r_preimage, r_hash, value_msat, creation_date, settle_date, payment_request,
+			destination_pub_key, description_hash, expiry, cltv_expiry, route_hints, private,
+			settle_index, amt_paid_msat, invoice_state, htlcs, features, is_keysend, payment_addr, is_amp,
+			destination_node_id, node_id, created_on, updated_on
+		) VALUES(
+			:memo, :r_preimage, :r_hash, :value_msat, :creation_date, :settle_date, :payment_request,
+			:destination_pub_key, :description_hash, :expiry, :cltv_expiry, :route_hints, false,
+			:settle_index, :amt_paid_msat, :invoice_state, '[]', :features, false, :payment_addr, false,
+			:destination_node_id, :node_id, :created_on, :updated_on
+		);`, inv)
+	if err != nil {
+		return errors.Wrap(err, "insert invoice")
+	}
+	return nil
+}
+
+func updateInvoice(db *sqlx.DB, inv invoice) error {
+	_, err := db.NamedExec(`
+		UPDATE invoice
+		SET r_preimage=:r_preimage, settle_date=:settle_date, settle_index=:settle_index,
+			amt_paid_msat=:amt_paid_msat, invoice_state=:invoice_state, updated_on=:updated_on
+		WHERE invoice_id=:invoice_id;`, inv)
+	if err != nil {
+		return errors.Wrap(err, "update invoice")
+	}
+	return nil
+}
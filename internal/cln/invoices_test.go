@@ -0,0 +1,62 @@
+package cln
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/lncapital/torq/internal/cache"
+	"github.com/lncapital/torq/proto/cln"
+	"github.com/lncapital/torq/proto/lnrpc"
+	"github.com/lncapital/torq/testutil"
+)
+
+func TestGetChangedInvoices(t *testing.T) {
+	unchanged := &cln.ListinvoicesInvoices{PaymentHash: []byte{1}, Status: cln.ListinvoicesInvoices_UNPAID}
+	paid := &cln.ListinvoicesInvoices{PaymentHash: []byte{2}, Status: cln.ListinvoicesInvoices_PAID}
+	expired := &cln.ListinvoicesInvoices{PaymentHash: []byte{3}, Status: cln.ListinvoicesInvoices_EXPIRED}
+	created := &cln.ListinvoicesInvoices{PaymentHash: []byte{4}, Status: cln.ListinvoicesInvoices_UNPAID}
+	existingInvoices := map[string]existingInvoice{
+		hex.EncodeToString([]byte{1}): {InvoiceId: 1, InvoiceState: lnrpc.Invoice_OPEN.String()},
+		hex.EncodeToString([]byte{2}): {InvoiceId: 2, InvoiceState: lnrpc.Invoice_OPEN.String()},
+		hex.EncodeToString([]byte{3}): {InvoiceId: 3, InvoiceState: lnrpc.Invoice_OPEN.String()},
+	}
+
+	changedInvoices := getChangedInvoices(
+		[]*cln.ListinvoicesInvoices{nil, unchanged, paid, expired, created}, existingInvoices)
+	want := []*cln.ListinvoicesInvoices{paid, expired, created}
+	if len(changedInvoices) != len(want) {
+		testutil.Fatalf(t, "getChangedInvoices() = %v, want %v", changedInvoices, want)
+	}
+	for i := range want {
+		if changedInvoices[i] != want[i] {
+			testutil.Errorf(t, "getChangedInvoices()[%v] = %v, want %v", i, changedInvoices[i], want[i])
+			return
+		}
+	}
+	testutil.Successf(t, "getChangedInvoices() = %v", changedInvoices)
+}
+
+func TestConstructInvoice(t *testing.T) {
+	paidAt := uint64(1_672_531_200)
+	payIndex := uint64(7)
+	description := "memo"
+	clnInvoice := &cln.ListinvoicesInvoices{
+		Description:        &description,
+		PaymentHash:        []byte{1, 2},
+		PaymentPreimage:    []byte{3, 4},
+		Status:             cln.ListinvoicesInvoices_PAID,
+		AmountMsat:         &cln.Amount{Msat: 1_000},
+		AmountReceivedMsat: &cln.Amount{Msat: 1_001},
+		PayIndex:           &payIndex,
+		PaidAt:             &paidAt,
+	}
+
+	inv := constructInvoice(clnInvoice, cache.NodeSettingsCache{NodeId: 1})
+	if inv.RHash != "0102" || inv.RPreimage != "0304" || inv.Memo != description ||
+		inv.ValueMsat != 1_000 || inv.AmtPaidMsat != 1_001 || inv.SettleIndex == nil || *inv.SettleIndex != payIndex ||
+		inv.InvoiceState != lnrpc.Invoice_SETTLED.String() || inv.SettleDate.Unix() != int64(paidAt) || inv.NodeId != 1 {
+		testutil.Errorf(t, "constructInvoice() = %+v", inv)
+		return
+	}
+	testutil.Successf(t, "constructInvoice() = %+v", inv)
+}
@@ -12,10 +12,12 @@ import (
 
 	"github.com/lncapital/torq/internal/cache"
 	"github.com/lncapital/torq/internal/core"
+	"github.com/lncapital/torq/internal/lnd"
 	"github.com/lncapital/torq/internal/nodes"
 	"github.com/lncapital/torq/internal/services_helpers"
 	"github.com/lncapital/torq/internal/settings"
 	"github.com/lncapital/torq/proto/cln"
+	"github.com/lncapital/torq/proto/lnrpc"
 )
 
 const streamPeersTickerSeconds = 60
@@ -124,6 +126,7 @@ func storePeers(db *sqlx.DB, peers []*cln.ListpeersPeers, nodeSettings cache.Nod
 				if err != nil {
 					return errors.Wrapf(err, "add new node connection history for nodeId: %v", nodeSettings.NodeId)
 				}
+				publishPeerEvent(nodeSettings.NodeId, peerNodeId, lnrpc.PeerEvent_PEER_ONLINE)
 			}
 		}
 		if !peer.Connected {
@@ -134,6 +137,10 @@ func storePeers(db *sqlx.DB, peers []*cln.ListpeersPeers, nodeSettings cache.Nod
 				if err != nil {
 					return errors.Wrapf(err, "add new node disconnection history for nodeId: %v", nodeSettings.NodeId)
 				}
+				publishPeerEvent(nodeSettings.NodeId, peerNodeId, lnrpc.PeerEvent_PEER_OFFLINE)
+				if connectionStatus != nil && *connectionStatus == core.NodeConnectionStatusConnected {
+					lnd.SendPeerDisconnectedAlert(nodeSettings.NodeId, peerNodeId, peerPublicKey)
+				}
 			}
 		}
 		processedPeerNodeIds[peerNodeId] = true
@@ -160,8 +167,24 @@ func storePeers(db *sqlx.DB, peers []*cln.ListpeersPeers, nodeSettings cache.Nod
 			if err != nil {
 				return errors.Wrapf(err, "add new node disconnection history for nodeId: %v", nodeSettings.NodeId)
 			}
+			publishPeerEvent(nodeSettings.NodeId, peerNodeId, lnrpc.PeerEvent_PEER_OFFLINE)
+			if connectionStatus != nil && *connectionStatus == core.NodeConnectionStatusConnected {
+				lnd.SendPeerDisconnectedAlert(nodeSettings.NodeId, peerNodeId, peerPublicKey)
+			}
 		}
 	}
 
 	return nil
 }
+
+// publishPeerEvent publishes the connection changes as live events (CLN has no peer event stream like LND)
+func publishPeerEvent(nodeId int, peerNodeId int, eventType lnrpc.PeerEvent_EventType) {
+	cache.PublishEvent(core.PeerEvent{
+		EventData: core.EventData{
+			EventTime: time.Now().UTC(),
+			NodeId:    nodeId,
+		},
+		Type:        eventType,
+		EventNodeId: peerNodeId,
+	})
+}
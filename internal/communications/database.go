@@ -12,38 +12,105 @@ import (
 	"github.com/lncapital/torq/internal/database"
 )
 
+// defaultPaymentFailedMinimumSat matches the column default of payment_failed_minimum_sat
+const defaultPaymentFailedMinimumSat = 1_000_000
+
 type Communication struct {
 	CommunicationId int `json:"communicationId" db:"communication_id"`
 	// CommunicationType bitshifted value use the Add/Has/Remove methods...
-	ActivationFlagNodeDetails bool                    `json:"activationFlagNodeDetails" db:"activation_flag_node_details"`
-	TargetType                CommunicationTargetType `json:"targetType" db:"target_type"`
-	TargetName                string                  `json:"targetName" db:"target_name"`
-	TargetText                string                  `json:"targetText" db:"target_text"`
-	TargetNumber              int64                   `json:"targetNumber" db:"target_number"`
-	NodeId                    int                     `json:"nodeId" db:"node_id"`
-	ChannelId                 *int                    `json:"channelId" db:"channel_id"`
-	CreatedOn                 time.Time               `json:"createdOn" db:"created_on"`
-	UpdatedOn                 time.Time               `json:"updatedOn" db:"updated_on"`
+	ActivationFlagNodeDetails              bool `json:"activationFlagNodeDetails" db:"activation_flag_node_details"`
+	ActivationFlagChannelOpened            bool `json:"activationFlagChannelOpened" db:"activation_flag_channel_opened"`
+	ActivationFlagChannelClosed            bool `json:"activationFlagChannelClosed" db:"activation_flag_channel_closed"`
+	ActivationFlagChannelForceClosed       bool `json:"activationFlagChannelForceClosed" db:"activation_flag_channel_force_closed"`
+	ActivationFlagPeerDisconnected         bool `json:"activationFlagPeerDisconnected" db:"activation_flag_peer_disconnected"`
+	ActivationFlagPaymentFailed            bool `json:"activationFlagPaymentFailed" db:"activation_flag_payment_failed"`
+	ActivationFlagHtlcFailureBurst         bool `json:"activationFlagHtlcFailureBurst" db:"activation_flag_htlc_failure_burst"`
+	ActivationFlagRebalanceSucceeded       bool `json:"activationFlagRebalanceSucceeded" db:"activation_flag_rebalance_succeeded"`
+	ActivationFlagRebalanceBudgetExhausted bool `json:"activationFlagRebalanceBudgetExhausted" db:"activation_flag_rebalance_budget_exhausted"`
+	// PaymentFailedMinimumSat failed payments below this amount are not notified (see ActivationFlagPaymentFailed)
+	PaymentFailedMinimumSat int64                   `json:"paymentFailedMinimumSat" db:"payment_failed_minimum_sat"`
+	TargetType              CommunicationTargetType `json:"targetType" db:"target_type"`
+	TargetName              string                  `json:"targetName" db:"target_name"`
+	TargetText              string                  `json:"targetText" db:"target_text"`
+	TargetNumber            int64                   `json:"targetNumber" db:"target_number"`
+	// TargetSettings JSON with the settings of the sink i.e. WebhookSettings, EmailSettings or MatrixSettings
+	TargetSettings *string   `json:"targetSettings" db:"target_settings"`
+	NodeId         int       `json:"nodeId" db:"node_id"`
+	ChannelId      *int      `json:"channelId" db:"channel_id"`
+	CreatedOn      time.Time `json:"createdOn" db:"created_on"`
+	UpdatedOn      time.Time `json:"updatedOn" db:"updated_on"`
+}
+
+// CommunicationTarget is the read-only view of a communication for users below admin (i.e. to select it in a workflow)
+type CommunicationTarget struct {
+	CommunicationId int                     `json:"communicationId" db:"communication_id"`
+	NodeId          int                     `json:"nodeId" db:"node_id"`
+	ChannelId       *int                    `json:"channelId" db:"channel_id"`
+	TargetType      CommunicationTargetType `json:"targetType" db:"target_type"`
+	TargetName      string                  `json:"targetName" db:"target_name"`
 }
 
 func (communication *Communication) AddCommunicationType(communicationType CommunicationType) {
-	switch communicationType {
-	case NodeDetailsChanged:
-		communication.ActivationFlagNodeDetails = true
+	activationFlag := communication.getActivationFlag(communicationType)
+	if activationFlag != nil {
+		*activationFlag = true
 	}
 }
 func (communication *Communication) HasCommunicationType(communicationType CommunicationType) bool {
+	activationFlag := communication.getActivationFlag(communicationType)
+	return activationFlag != nil && *activationFlag
+}
+func (communication *Communication) RemoveCommunicationType(communicationType CommunicationType) {
+	activationFlag := communication.getActivationFlag(communicationType)
+	if activationFlag != nil {
+		*activationFlag = false
+	}
+}
+
+func (communication *Communication) getActivationFlag(communicationType CommunicationType) *bool {
 	switch communicationType {
 	case NodeDetailsChanged:
-		return communication.ActivationFlagNodeDetails
+		return &communication.ActivationFlagNodeDetails
+	case ChannelOpened:
+		return &communication.ActivationFlagChannelOpened
+	case ChannelClosed:
+		return &communication.ActivationFlagChannelClosed
+	case ChannelForceClosed:
+		return &communication.ActivationFlagChannelForceClosed
+	case PeerDisconnected:
+		return &communication.ActivationFlagPeerDisconnected
+	case PaymentFailed:
+		return &communication.ActivationFlagPaymentFailed
+	case HtlcFailureBurst:
+		return &communication.ActivationFlagHtlcFailureBurst
+	case RebalanceSucceeded:
+		return &communication.ActivationFlagRebalanceSucceeded
+	case RebalanceBudgetExhausted:
+		return &communication.ActivationFlagRebalanceBudgetExhausted
 	}
-	return false
+	return nil
 }
-func (communication *Communication) RemoveCommunicationType(communicationType CommunicationType) {
+
+func getActivationFlagColumn(communicationType CommunicationType) string {
 	switch communicationType {
-	case NodeDetailsChanged:
-		communication.ActivationFlagNodeDetails = false
+	case ChannelOpened:
+		return "activation_flag_channel_opened"
+	case ChannelClosed:
+		return "activation_flag_channel_closed"
+	case ChannelForceClosed:
+		return "activation_flag_channel_force_closed"
+	case PeerDisconnected:
+		return "activation_flag_peer_disconnected"
+	case PaymentFailed:
+		return "activation_flag_payment_failed"
+	case HtlcFailureBurst:
+		return "activation_flag_htlc_failure_burst"
+	case RebalanceSucceeded:
+		return "activation_flag_rebalance_succeeded"
+	case RebalanceBudgetExhausted:
+		return "activation_flag_rebalance_budget_exhausted"
 	}
+	return "activation_flag_node_details"
 }
 
 func GetNodeIdsByCommunication(db *sqlx.DB,
@@ -91,9 +158,8 @@ func GetCommunicationIdsByCommunicationTargetType(db *sqlx.DB,
 }
 
 func GetCommunicationSettings(db *sqlx.DB, communicationId int) (map[CommunicationType]bool, error) {
-	var activationFlagNodeDetails bool
-	err := db.Get(&activationFlagNodeDetails, `SELECT activation_flag_node_details FROM communication WHERE communication_id=$1;`,
-		communicationId)
+	var communication Communication
+	err := db.Get(&communication, `SELECT * FROM communication WHERE communication_id=$1;`, communicationId)
 	if err != nil {
 		if !errors.Is(err, sql.ErrNoRows) {
 			return nil, errors.Wrap(err, database.SqlExecutionError)
@@ -101,10 +167,7 @@ func GetCommunicationSettings(db *sqlx.DB, communicationId int) (map[Communicati
 	}
 	result := make(map[CommunicationType]bool)
 	for _, ct := range GetCommunicationTypes() {
-		switch ct {
-		case NodeDetailsChanged:
-			result[ct] = activationFlagNodeDetails
-		}
+		result[ct] = communication.HasCommunicationType(ct)
 	}
 	return result, nil
 }
@@ -113,12 +176,24 @@ func AddCommunication(db *sqlx.DB, communication Communication) (int, error) {
 	communication.CreatedOn = time.Now().UTC()
 	communication.UpdatedOn = communication.CreatedOn
 	err := db.QueryRowx(`INSERT INTO communication
-    	(activation_flag_node_details, target_type, target_name, target_text, target_number,
-    	 node_id, channel_id, created_on, updated_on)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9) RETURNING communication_id;`,
+    	(activation_flag_node_details, target_type, target_name, target_text, target_number, target_settings,
+    	 node_id, channel_id, created_on, updated_on,
+    	 activation_flag_channel_opened, activation_flag_channel_closed, activation_flag_channel_force_closed,
+    	 activation_flag_peer_disconnected, activation_flag_payment_failed, activation_flag_htlc_failure_burst,
+    	 activation_flag_rebalance_succeeded, activation_flag_rebalance_budget_exhausted,
+    	 payment_failed_minimum_sat)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19)
+		RETURNING communication_id;`,
 		communication.ActivationFlagNodeDetails, communication.TargetType, communication.TargetName,
-		communication.TargetText, communication.TargetNumber, communication.NodeId, communication.ChannelId,
-		communication.CreatedOn, communication.UpdatedOn).Scan(&communication.CommunicationId)
+		communication.TargetText, communication.TargetNumber, communication.TargetSettings,
+		communication.NodeId, communication.ChannelId,
+		communication.CreatedOn, communication.UpdatedOn,
+		communication.ActivationFlagChannelOpened, communication.ActivationFlagChannelClosed,
+		communication.ActivationFlagChannelForceClosed, communication.ActivationFlagPeerDisconnected,
+		communication.ActivationFlagPaymentFailed, communication.ActivationFlagHtlcFailureBurst,
+		communication.ActivationFlagRebalanceSucceeded,
+		communication.ActivationFlagRebalanceBudgetExhausted,
+		communication.PaymentFailedMinimumSat).Scan(&communication.CommunicationId)
 	if err != nil {
 		return 0, errors.Wrap(err, database.SqlExecutionError)
 	}
@@ -173,6 +248,26 @@ func GetCommunicationsForNodeDetails(db *sqlx.DB, nodeId int,
 	return communications, nil
 }
 
+// GetCommunicationsForCommunicationType returns the node communications subscribed to the alert type.
+// When a channelId is provided the communications of that channel are included.
+func GetCommunicationsForCommunicationType(db *sqlx.DB, nodeId int, channelId *int,
+	communicationType CommunicationType,
+	communicationTargetTypes ...CommunicationTargetType) ([]Communication, error) {
+	var communications []Communication
+	err := db.Select(&communications, `
+		SELECT *
+		FROM communication
+		WHERE node_id=$1 AND target_type=ANY($2) AND `+getActivationFlagColumn(communicationType)+`=$3 AND
+		      (channel_id IS NULL OR channel_id=$4);`,
+		nodeId, pq.Array(communicationTargetTypes), true, channelId)
+	if err != nil {
+		if !errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.Wrap(err, database.SqlExecutionError)
+		}
+	}
+	return communications, nil
+}
+
 func GetCommunicationsByNodeIdAndTargetTypes(db *sqlx.DB, nodeId int,
 	communicationTargetTypes ...CommunicationTargetType) ([]Communication, error) {
 	var communications []Communication
@@ -208,12 +303,22 @@ func SetCommunication(db *sqlx.DB, communication Communication) (Communication,
 	res, err := db.Exec(`
 		UPDATE communication
 		SET activation_flag_node_details=$3, target_name=$4, target_type=$5, target_text=$6, target_number=$7,
-		    node_id=$8, channel_id=$9, updated_on=$10
+		    node_id=$8, channel_id=$9, updated_on=$10, target_settings=$11,
+		    activation_flag_channel_opened=$12, activation_flag_channel_closed=$13,
+		    activation_flag_channel_force_closed=$14, activation_flag_peer_disconnected=$15,
+		    activation_flag_payment_failed=$16, activation_flag_htlc_failure_burst=$17,
+		    activation_flag_rebalance_succeeded=$18, activation_flag_rebalance_budget_exhausted=$19,
+		    payment_failed_minimum_sat=$20
 		WHERE communication_id=$1 AND updated_on=$2;`,
 		communication.CommunicationId, communication.UpdatedOn,
 		communication.ActivationFlagNodeDetails, communication.TargetName, communication.TargetType,
 		communication.TargetText, communication.TargetNumber,
-		communication.NodeId, communication.ChannelId, updatedOn)
+		communication.NodeId, communication.ChannelId, updatedOn, communication.TargetSettings,
+		communication.ActivationFlagChannelOpened, communication.ActivationFlagChannelClosed,
+		communication.ActivationFlagChannelForceClosed, communication.ActivationFlagPeerDisconnected,
+		communication.ActivationFlagPaymentFailed, communication.ActivationFlagHtlcFailureBurst,
+		communication.ActivationFlagRebalanceSucceeded, communication.ActivationFlagRebalanceBudgetExhausted,
+		communication.PaymentFailedMinimumSat)
 	if err != nil {
 		return Communication{}, errors.Wrap(err, database.SqlExecutionError)
 	}
@@ -252,3 +357,47 @@ func GetCommunicationIdsByTargetText(db *sqlx.DB, targetText string) ([]int, err
 	return communicationIds, nil
 
 }
+
+func GetCommunicationsByNodeId(db *sqlx.DB, nodeId int) ([]Communication, error) {
+	var communications []Communication
+	err := db.Select(&communications,
+		`SELECT * FROM communication WHERE node_id=$1 ORDER BY communication_id;`, nodeId)
+	if err != nil {
+		if !errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.Wrap(err, database.SqlExecutionError)
+		}
+	}
+	return communications, nil
+}
+
+// GetCommunicationTargets returns the communications without their target text and settings
+func GetCommunicationTargets(db *sqlx.DB) ([]CommunicationTarget, error) {
+	var communicationTargets []CommunicationTarget
+	err := db.Select(&communicationTargets, `
+		SELECT communication_id, node_id, channel_id, target_type, target_name
+		FROM communication
+		ORDER BY node_id, communication_id;`)
+	if err != nil {
+		if !errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.Wrap(err, database.SqlExecutionError)
+		}
+	}
+	return communicationTargets, nil
+}
+
+func GetCommunication(db *sqlx.DB, communicationId int) (Communication, error) {
+	var communication Communication
+	err := db.Get(&communication, `SELECT * FROM communication WHERE communication_id=$1;`, communicationId)
+	if err != nil {
+		return Communication{}, errors.Wrap(err, database.SqlExecutionError)
+	}
+	return communication, nil
+}
+
+func RemoveCommunication(db *sqlx.DB, communicationId int) error {
+	_, err := db.Exec(`DELETE FROM communication WHERE communication_id=$1;`, communicationId)
+	if err != nil {
+		return errors.Wrap(err, database.SqlExecutionError)
+	}
+	return nil
+}
@@ -0,0 +1,131 @@
+package communications
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/cockroachdb/errors"
+)
+
+// EmailSettings are the target settings of a CommunicationEmail. The recipient address is the TargetText.
+type EmailSettings struct {
+	Host     string `json:"host"`
+	Port     int    `json:"port"`
+	Username string `json:"username"`
+	Password string `json:"password"`
+	From     string `json:"from"`
+}
+
+type emailSink struct{}
+
+func (emailSink) Send(ctx context.Context, communication Communication, message string) error {
+	var settings EmailSettings
+	err := getTargetSettings(communication, &settings)
+	if err != nil {
+		return err
+	}
+	if settings.Host == "" || settings.From == "" || communication.TargetText == "" {
+		return newPermanentError(errors.New(fmt.Sprintf(
+			"SMTP host, sender or recipient is missing for communicationId: %v", communication.CommunicationId)))
+	}
+	port := settings.Port
+	if port == 0 {
+		port = 587
+	}
+	address := net.JoinHostPort(settings.Host, strconv.Itoa(port))
+
+	dialer := net.Dialer{}
+	conn, err := dialer.DialContext(ctx, "tcp", address)
+	if err != nil {
+		return errors.Wrapf(err, "Connecting to SMTP server: %v", address)
+	}
+	deadline, hasDeadline := ctx.Deadline()
+	if hasDeadline {
+		err = conn.SetDeadline(deadline)
+		if err != nil {
+			conn.Close()
+			return errors.Wrapf(err, "Setting deadline for SMTP server: %v", address)
+		}
+	}
+	client, err := smtp.NewClient(conn, settings.Host)
+	if err != nil {
+		conn.Close()
+		return errors.Wrapf(err, "Greeting SMTP server: %v", address)
+	}
+	defer client.Close()
+
+	if startTls, _ := client.Extension("STARTTLS"); startTls {
+		err = client.StartTLS(&tls.Config{ServerName: settings.Host, MinVersion: tls.VersionTLS12})
+		if err != nil {
+			return errors.Wrapf(err, "Starting TLS with SMTP server: %v", address)
+		}
+	}
+	if settings.Username != "" {
+		// PlainAuth refuses to send the credentials without TLS unless the server is localhost
+		err = client.Auth(smtp.PlainAuth("", settings.Username, settings.Password, settings.Host))
+		if err != nil {
+			return newPermanentError(errors.Wrapf(err, "Authenticating with SMTP server: %v", address))
+		}
+	}
+	if err = client.Mail(settings.From); err != nil {
+		return errors.Wrapf(err, "Setting sender %v", settings.From)
+	}
+	if err = client.Rcpt(communication.TargetText); err != nil {
+		return errors.Wrapf(err, "Setting recipient %v", communication.TargetText)
+	}
+	writer, err := client.Data()
+	if err != nil {
+		return errors.Wrap(err, "Starting SMTP data")
+	}
+	_, err = writer.Write([]byte(buildEmail(settings.From, communication.TargetText, message, time.Now())))
+	if err != nil {
+		return errors.Wrap(err, "Writing SMTP data")
+	}
+	if err = writer.Close(); err != nil {
+		return errors.Wrap(err, "Finishing SMTP data")
+	}
+	return client.Quit()
+}
+
+func (emailSink) RetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaximumAttempts: 3,
+		InitialBackoff:  10 * time.Second,
+		MaximumBackoff:  1 * time.Minute,
+		Timeout:         30 * time.Second,
+	}
+}
+
+func buildEmail(from string, to string, message string, date time.Time) string {
+	// Peer aliases end up in the message so line breaks are normalized and the headers can't contain control characters
+	message = strings.NewReplacer("\r\n", "\n", "\r", "\n").Replace(message)
+	subject := []rune(stripControlCharacters(strings.SplitN(strings.TrimSpace(message), "\n", 2)[0]))
+	if len(subject) > 78 {
+		subject = append(subject[:75], []rune("...")...)
+	}
+	return "From: " + stripControlCharacters(from) + "\r\n" +
+		"To: " + stripControlCharacters(to) + "\r\n" +
+		"Subject: " + mime.QEncoding.Encode("utf-8", "Torq: "+string(subject)) + "\r\n" +
+		"Date: " + date.Format(time.RFC1123Z) + "\r\n" +
+		"MIME-Version: 1.0\r\n" +
+		"Content-Type: text/plain; charset=UTF-8\r\n" +
+		"\r\n" +
+		strings.ReplaceAll(message, "\n", "\r\n") + "\r\n"
+}
+
+func stripControlCharacters(value string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) {
+			return -1
+		}
+		return r
+	}, value)
+}
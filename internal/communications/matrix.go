@@ -0,0 +1,74 @@
+package communications
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/errors"
+)
+
+// MatrixSettings are the target settings of a CommunicationMatrix. The room id is the TargetText.
+type MatrixSettings struct {
+	HomeserverUrl string `json:"homeserverUrl"`
+	AccessToken   string `json:"accessToken"`
+}
+
+type matrixMessage struct {
+	MessageType string `json:"msgtype"`
+	Body        string `json:"body"`
+}
+
+type matrixSink struct{}
+
+func (matrixSink) Send(ctx context.Context, communication Communication, message string) error {
+	var settings MatrixSettings
+	err := getTargetSettings(communication, &settings)
+	if err != nil {
+		return err
+	}
+	if settings.HomeserverUrl == "" || settings.AccessToken == "" || communication.TargetText == "" {
+		return newPermanentError(errors.New(fmt.Sprintf(
+			"Matrix homeserver, access token or room is missing for communicationId: %v",
+			communication.CommunicationId)))
+	}
+	body, err := json.Marshal(matrixMessage{MessageType: "m.text", Body: message})
+	if err != nil {
+		return newPermanentError(errors.Wrap(err, "Marshalling matrix message"))
+	}
+	// Matrix requires a transaction id that is unique per access token, retries reuse it so the homeserver
+	// does not post the message twice when an attempt timed out after the message was sent
+	transactionId := fmt.Sprintf("torq-%v-%v", communication.CommunicationId, getDeliveryId(ctx))
+	requestUrl := fmt.Sprintf("%v/_matrix/client/v3/rooms/%v/send/m.room.message/%v",
+		strings.TrimSuffix(settings.HomeserverUrl, "/"),
+		url.PathEscape(communication.TargetText),
+		url.PathEscape(transactionId))
+	request, err := http.NewRequestWithContext(ctx, http.MethodPut, requestUrl, bytes.NewReader(body))
+	if err != nil {
+		return newPermanentError(errors.Wrapf(err, "Creating matrix request for room: %v", communication.TargetText))
+	}
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("Authorization", "Bearer "+settings.AccessToken)
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		return errors.Wrapf(err, "Sending matrix message to room: %v", communication.TargetText)
+	}
+	defer response.Body.Close()
+	_, _ = io.Copy(io.Discard, response.Body)
+	return getHttpResponseError(response, "matrix homeserver")
+}
+
+func (matrixSink) RetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaximumAttempts: 5,
+		InitialBackoff:  2 * time.Second,
+		MaximumBackoff:  30 * time.Second,
+		Timeout:         10 * time.Second,
+	}
+}
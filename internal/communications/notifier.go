@@ -14,6 +14,8 @@ import (
 	"golang.org/x/exp/slices"
 
 	"github.com/lncapital/torq/build"
+	"github.com/lncapital/torq/internal/audit"
+	"github.com/lncapital/torq/internal/auth"
 	"github.com/lncapital/torq/internal/cache"
 	"github.com/lncapital/torq/internal/core"
 	"github.com/lncapital/torq/internal/lightning"
@@ -58,20 +60,47 @@ const (
 	CommunicationTelegramHighPriority = CommunicationTargetType(iota)
 	CommunicationTelegramLowPriority
 	CommunicationSlack
+	CommunicationWebhook
+	CommunicationEmail
+	CommunicationMatrix
 )
 
-type CommunicationType byte
+type CommunicationType uint16
 
 // When adding here also add to GetCommunicationTypes
 const (
 	NodeDetailsChanged CommunicationType = 1 << iota
+	ChannelOpened
+	ChannelClosed
+	ChannelForceClosed
+	PeerDisconnected
+	PaymentFailed
+	HtlcFailureBurst
+	RebalanceSucceeded
+	RebalanceBudgetExhausted
 )
 
 func GetCommunicationType(communicationTypeString string) *CommunicationType {
 	var communicationType CommunicationType
 	switch communicationTypeString {
-	case "nodeDetailsDeactivate", "nodeDetailsActivate":
+	case DeactivateNodeDetailButton, ActivateNodeDetailButton:
 		communicationType = NodeDetailsChanged
+	case DeactivateChannelOpenedButton, ActivateChannelOpenedButton:
+		communicationType = ChannelOpened
+	case DeactivateChannelClosedButton, ActivateChannelClosedButton:
+		communicationType = ChannelClosed
+	case DeactivateChannelForceClosedButton, ActivateChannelForceClosedButton:
+		communicationType = ChannelForceClosed
+	case DeactivatePeerDisconnectedButton, ActivatePeerDisconnectedButton:
+		communicationType = PeerDisconnected
+	case DeactivatePaymentFailedButton, ActivatePaymentFailedButton:
+		communicationType = PaymentFailed
+	case DeactivateHtlcFailureBurstButton, ActivateHtlcFailureBurstButton:
+		communicationType = HtlcFailureBurst
+	case DeactivateRebalanceSucceededButton, ActivateRebalanceSucceededButton:
+		communicationType = RebalanceSucceeded
+	case DeactivateRebalanceBudgetExhaustedButton, ActivateRebalanceBudgetExhaustedButton:
+		communicationType = RebalanceBudgetExhausted
 	default:
 		return nil
 	}
@@ -81,9 +110,40 @@ func GetCommunicationType(communicationTypeString string) *CommunicationType {
 func GetCommunicationTypes() []CommunicationType {
 	return []CommunicationType{
 		NodeDetailsChanged,
+		ChannelOpened,
+		ChannelClosed,
+		ChannelForceClosed,
+		PeerDisconnected,
+		PaymentFailed,
+		HtlcFailureBurst,
+		RebalanceSucceeded,
+		RebalanceBudgetExhausted,
 	}
 }
 
+// getCommunicationTypeByNotificationType returns the alert type a communication subscribes to for the notification
+func getCommunicationTypeByNotificationType(notificationType core.NotificationType) CommunicationType {
+	switch notificationType {
+	case core.ChannelOpenedNotification:
+		return ChannelOpened
+	case core.ChannelClosedNotification:
+		return ChannelClosed
+	case core.ChannelForceClosedNotification:
+		return ChannelForceClosed
+	case core.PeerDisconnectedNotification:
+		return PeerDisconnected
+	case core.PaymentFailedNotification:
+		return PaymentFailed
+	case core.HtlcFailureBurstNotification:
+		return HtlcFailureBurst
+	case core.RebalanceSucceededNotification:
+		return RebalanceSucceeded
+	case core.RebalanceBudgetExhaustedNotification:
+		return RebalanceBudgetExhausted
+	}
+	return NodeDetailsChanged
+}
+
 type MessageForSlack struct {
 	Channel string
 	ReplyTo string
@@ -122,8 +182,28 @@ const (
 	SettingsButton   = "settings"
 	PublicKeyButton  = "publickey"
 
-	ActivateNodeDetailButton   = "nodeDetailsActivate"
-	DeactivateNodeDetailButton = "nodeDetailsDeactivate"
+	ActivateNodeDetailButton                 = "nodeDetailsActivate"
+	DeactivateNodeDetailButton               = "nodeDetailsDeactivate"
+	ActivateChannelOpenedButton              = "channelOpenedActivate"
+	DeactivateChannelOpenedButton            = "channelOpenedDeactivate"
+	ActivateChannelClosedButton              = "channelClosedActivate"
+	DeactivateChannelClosedButton            = "channelClosedDeactivate"
+	ActivateChannelForceClosedButton         = "channelForceClosedActivate"
+	DeactivateChannelForceClosedButton       = "channelForceClosedDeactivate"
+	ActivatePeerDisconnectedButton           = "peerDisconnectedActivate"
+	DeactivatePeerDisconnectedButton         = "peerDisconnectedDeactivate"
+	ActivatePaymentFailedButton              = "paymentFailedActivate"
+	DeactivatePaymentFailedButton            = "paymentFailedDeactivate"
+	ActivateHtlcFailureBurstButton           = "htlcFailureBurstActivate"
+	DeactivateHtlcFailureBurstButton         = "htlcFailureBurstDeactivate"
+	ActivateRebalanceSucceededButton         = "rebalanceSucceededActivate"
+	DeactivateRebalanceSucceededButton       = "rebalanceSucceededDeactivate"
+	ActivateRebalanceBudgetExhaustedButton   = "rebalanceBudgetExhaustedActivate"
+	DeactivateRebalanceBudgetExhaustedButton = "rebalanceBudgetExhaustedDeactivate"
+
+	// The approval buttons carry the approvalProposalId (i.e. "approveProposal 12")
+	ApproveProposalButton = "approveProposal"
+	RejectProposalButton  = "rejectProposal"
 )
 
 func getButtons() [7]string {
@@ -143,6 +223,8 @@ func Notify(ctx context.Context, db *sqlx.DB) {
 	ticker := time.NewTicker(30 * time.Second)
 	defer ticker.Stop()
 
+	go handleNotifierEvents(ctx, db)
+
 	for {
 		select {
 		case <-ctx.Done():
@@ -151,8 +233,7 @@ func Notify(ctx context.Context, db *sqlx.DB) {
 		case <-ticker.C:
 			for _, torqNodeSettings := range cache.GetActiveTorqNodeSettings() {
 				communications, err := GetCommunicationsForNodeDetails(db,
-					torqNodeSettings.NodeId,
-					CommunicationTelegramHighPriority, CommunicationTelegramLowPriority, CommunicationSlack)
+					torqNodeSettings.NodeId, GetSinkCommunicationTargetTypes()...)
 				if err != nil {
 					log.Error().Err(err).Msgf("Getting communications failed for nodeId: %v",
 						torqNodeSettings.NodeId)
@@ -213,24 +294,47 @@ func Notify(ctx context.Context, db *sqlx.DB) {
 	}
 }
 
+// handleNotifierEvents delivers the alerts (see cache.SendNotifierEvent) without delaying the node status checks.
+func handleNotifierEvents(ctx context.Context, db *sqlx.DB) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case notifierEvent := <-cache.NotifierEvents:
+			HandleNotification(db, notifierEvent)
+		}
+	}
+}
+
 func HandleNotification(db *sqlx.DB, notifierEvent core.NotifierEvent) {
 	var err error
 	var communications []Communication
 	switch notifierEvent.NotificationType {
-	case core.NodeDetails:
+	case core.NodeDetails, core.ApprovalRequestedNotification:
 		communications, err = GetCommunicationsForNodeDetails(db,
-			notifierEvent.NodeId,
-			CommunicationTelegramHighPriority, CommunicationTelegramLowPriority, CommunicationSlack)
+			notifierEvent.NodeId, GetSinkCommunicationTargetTypes()...)
+	default:
+		communications, err = GetCommunicationsForCommunicationType(db,
+			notifierEvent.NodeId, notifierEvent.ChannelId,
+			getCommunicationTypeByNotificationType(notifierEvent.NotificationType),
+			GetSinkCommunicationTargetTypes()...)
 	}
 	if err != nil {
 		log.Error().Err(err).Msgf(
-			"Getting user communications for Telegram (high) nodeId: %v", notifierEvent.NodeId)
+			"Getting user communications for nodeId: %v", notifierEvent.NodeId)
 		return
 	}
+	if notifierEvent.NotificationType == core.PaymentFailedNotification {
+		communications = getPaymentFailedCommunications(communications, notifierEvent.PaymentAmountSat)
+	}
 	if len(communications) == 0 {
 		log.Debug().Msgf("Notifier could not find communication settings for %v", notifierEvent)
 		return
 	}
+	if notifierEvent.ApprovalProposalId != nil && notifierEvent.Notification != nil {
+		sendApprovalRequest(*notifierEvent.Notification, *notifierEvent.ApprovalProposalId, communications)
+		return
+	}
 	if notifierEvent.Notification != nil && *notifierEvent.Notification != "" {
 		sendBotMessages(*notifierEvent.Notification, communications)
 		return
@@ -254,6 +358,17 @@ func HandleNotification(db *sqlx.DB, notifierEvent core.NotifierEvent) {
 	}
 }
 
+// getPaymentFailedCommunications drops the communications with a minimum amount above the failed payment amount.
+func getPaymentFailedCommunications(communications []Communication, amountSat int64) []Communication {
+	var result []Communication
+	for _, communication := range communications {
+		if amountSat >= communication.PaymentFailedMinimumSat {
+			result = append(result, communication)
+		}
+	}
+	return result
+}
+
 func compareGraphSyncTime(previousInformation lightning_helpers.InformationResponse,
 	newInformation lightning_helpers.InformationResponse,
 	graphInSyncTime map[nodeIdType]time.Time,
@@ -340,39 +455,23 @@ func compareVersion(previousInformation lightning_helpers.InformationResponse,
 	return message
 }
 
-func sendBotMessages(communicationMessage string, communicationDestinations []Communication) {
-	for _, communication := range communicationDestinations {
-		log.Info().Msgf("Notifier sending telegram communication: %v", communicationMessage)
-		switch communication.TargetType {
-		case CommunicationTelegramHighPriority:
-			log.Info().Msgf("Notifier sending HIGH priority telegram communication (%v): %v", communication.TargetName, communicationMessage)
-			bot := MessageForBot{
-				Message: communicationMessage,
-				Telegram: MessageForTelegram{
-					Id: communication.TargetNumber,
-				},
-			}
-			SendTelegramBotMessages(bot, CommunicationTelegramHighPriority)
-		case CommunicationTelegramLowPriority:
-			log.Info().Msgf("Notifier sending LOW priority telegram communication (%v): %v", communication.TargetName, communicationMessage)
-			bot := MessageForBot{
-				Message: communicationMessage,
-				Telegram: MessageForTelegram{
-					Id: communication.TargetNumber,
-				},
-			}
-			SendTelegramBotMessages(bot, CommunicationTelegramLowPriority)
-		case CommunicationSlack:
-			log.Info().Msgf("Notifier sending Slack communication (%v): %v", communication.TargetName, communicationMessage)
-			SendSlackBotMessages(MessageForBot{
-				Message: communicationMessage,
-				Slack: MessageForSlack{
-					Channel: communication.TargetText,
-					Color:   "#283B4C",
-				},
-			})
+// SendCommunicationMessage sends the message to the selected communications (i.e. from a workflow node).
+func SendCommunicationMessage(ctx context.Context, db *sqlx.DB, communicationIds []int, message string) error {
+	var communicationDestinations []Communication
+	for _, communicationId := range communicationIds {
+		communication, err := GetCommunication(db, communicationId)
+		if err != nil {
+			return errors.Wrapf(err, "Getting communication for communicationId: %v", communicationId)
 		}
+		communicationDestinations = append(communicationDestinations, communication)
 	}
+	return fanOut(ctx, message, communicationDestinations)
+}
+
+// sendBotMessages fans out the message to the sinks of the communications (see RegisterSink)
+// The failed deliveries are logged by fanOut.
+func sendBotMessages(communicationMessage string, communicationDestinations []Communication) {
+	_ = fanOut(context.Background(), communicationMessage, communicationDestinations)
 }
 
 func HandleMessage(db *sqlx.DB,
@@ -387,9 +486,7 @@ func HandleMessage(db *sqlx.DB,
 	case StatusButton:
 		messageForBot = processStatusRequest(db, communicationTargetType, publicKeyFromChannel, messageForBot)
 	case SettingsButton:
-		if messageForBot.IsTelegram() {
-			SendNodeSettingsMenu(db, communicationTargetType, messageForBot)
-		}
+		SendNodeSettingsMenu(db, communicationTargetType, messageForBot)
 	case RegisterButton:
 		messageForBot = processRegisterRequest(db, communicationTargetType, cache.GetActiveTorqNodeSettings(),
 			messageForBot)
@@ -403,12 +500,7 @@ func HandleMessage(db *sqlx.DB,
 		messageForBot.Menus = []Menu{MenuMain}
 	}
 	if messageForBot.HasMessage() || messageForBot.HasMenu() {
-		switch communicationTargetType {
-		case CommunicationSlack:
-			SendSlackBotMessages(messageForBot)
-		case CommunicationTelegramHighPriority, CommunicationTelegramLowPriority:
-			SendTelegramBotMessages(messageForBot, communicationTargetType)
-		}
+		sendBotReply(messageForBot, communicationTargetType)
 	}
 }
 
@@ -433,13 +525,169 @@ func HandleButton(db *sqlx.DB,
 		messageForBot = processSettingsRequest(db, communicationTargetType, messageFromChannel, messageForBot)
 	}
 	if messageForBot.HasMessage() || messageForBot.HasMenu() {
+		sendBotReply(messageForBot, communicationTargetType)
+	}
+}
+
+func sendBotReply(messageForBot MessageForBot, communicationTargetType CommunicationTargetType) {
+	switch communicationTargetType {
+	case CommunicationSlack:
+		SendSlackBotMessages(messageForBot)
+	case CommunicationTelegramHighPriority, CommunicationTelegramLowPriority:
+		SendTelegramBotMessages(messageForBot, communicationTargetType)
+	}
+}
+
+// sendApprovalRequest adds approve and reject buttons for Telegram and Slack, the other sinks only get the message
+func sendApprovalRequest(message string, approvalProposalId int, communicationDestinations []Communication) {
+	var otherDestinations []Communication
+	for _, communication := range communicationDestinations {
+		switch communication.TargetType {
+		case CommunicationTelegramHighPriority, CommunicationTelegramLowPriority:
+			markup := getApprovalMarkup(approvalProposalId)
+			SendTelegramBotMessages(MessageForBot{
+				Message: message,
+				Telegram: MessageForTelegram{
+					Id:          communication.TargetNumber,
+					ReplyMarkup: &markup,
+				},
+			}, communication.TargetType)
+		case CommunicationSlack:
+			sendSlackApprovalRequest(communication.TargetText, message, approvalProposalId)
+		default:
+			otherDestinations = append(otherDestinations, communication)
+		}
+	}
+	if len(otherDestinations) != 0 {
+		sendBotMessages(message, otherDestinations)
+	}
+}
+
+// parseApprovalButton returns the approvalProposalId and whether it was approved (ok is false for other buttons)
+func parseApprovalButton(buttonData string) (approvalProposalId int, approve bool, ok bool) {
+	button, value, found := strings.Cut(strings.TrimSpace(buttonData), " ")
+	if !found || (button != ApproveProposalButton && button != RejectProposalButton) {
+		return 0, false, false
+	}
+	approvalProposalId, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil {
+		return 0, false, false
+	}
+	return approvalProposalId, button == ApproveProposalButton, true
+}
+
+// handleApprovalButton decides on a proposal when the chat/channel is registered for the node of the proposal
+// and the chat user is linked to an admin user account. Approving executes the proposal which can take a while
+// (i.e. opening a channel) so it runs outside the update loop of the bot.
+func handleApprovalButton(db *sqlx.DB,
+	messageForBot MessageForBot,
+	approvalProposalId int,
+	approve bool,
+	chatUserId string,
+	communicationTargetType CommunicationTargetType) {
+
+	go func() {
+		messageForBot = processApprovalRequest(db, messageForBot, approvalProposalId, approve, chatUserId,
+			communicationTargetType)
 		switch communicationTargetType {
 		case CommunicationSlack:
 			SendSlackBotMessages(messageForBot)
 		case CommunicationTelegramHighPriority, CommunicationTelegramLowPriority:
 			SendTelegramBotMessages(messageForBot, communicationTargetType)
 		}
+	}()
+}
+
+func processApprovalRequest(db *sqlx.DB,
+	messageForBot MessageForBot,
+	approvalProposalId int,
+	approve bool,
+	chatUserId string,
+	communicationTargetType CommunicationTargetType) MessageForBot {
+
+	approvalProposal, err := lightning.GetApprovalProposal(db, approvalProposalId)
+	if err != nil {
+		log.Error().Err(err).Msgf("Failed to obtain approvalProposalId: %v", approvalProposalId)
+		messageForBot.Message = "We could not find the proposal."
+		return messageForBot
+	}
+	communications, err := GetCommunicationsByNodeIdAndTargetTypes(db, approvalProposal.NodeId,
+		communicationTargetType)
+	if err != nil {
+		log.Error().Err(err).Msgf("Failed to obtain communications for nodeId: %v", approvalProposal.NodeId)
+		messageForBot.Message = "Something went wrong verifying existing configurations."
+		return messageForBot
 	}
+	registered := false
+	for _, communication := range communications {
+		if (messageForBot.IsSlack() && communication.TargetText == messageForBot.Slack.Channel) ||
+			(messageForBot.IsTelegram() && communication.TargetNumber == messageForBot.Telegram.Id) {
+			registered = true
+			break
+		}
+	}
+	if !registered {
+		messageForBot.Message = "This chat is not registered for the node of the proposal."
+		return messageForBot
+	}
+
+	var user auth.User
+	if communicationTargetType == CommunicationSlack {
+		user, err = auth.GetUserBySlackUserId(db, chatUserId)
+	} else {
+		user, err = auth.GetUserByTelegramUserId(db, chatUserId)
+	}
+	if err != nil {
+		log.Error().Err(err).Msgf("Failed to obtain the user account of chat user: %v", chatUserId)
+		messageForBot.Message = "Something went wrong verifying your user account."
+		return messageForBot
+	}
+	if rejection := getChatApproverRejection(user, chatUserId, approvalProposal); rejection != "" {
+		messageForBot.Message = rejection
+		return messageForBot
+	}
+
+	nodeId := approvalProposal.NodeId
+	approver := auth.Actor{Name: user.Username, UserAccountId: user.UserAccountId}
+	action := "reject proposal"
+	if approve {
+		action = "approve proposal"
+		approvalProposal, err = lightning.ApproveProposal(db, approvalProposalId, approver)
+	} else {
+		approvalProposal, err = lightning.RejectProposal(db, approvalProposalId, approver)
+	}
+	audit.AddActorAuditLog(db, approver, action, nodeId, 0,
+		map[string]int{"approvalProposalId": approvalProposalId}, err)
+	if err != nil {
+		messageForBot.Message = fmt.Sprintf("Proposal %v could not be decided: %v", approvalProposalId, err.Error())
+		return messageForBot
+	}
+	switch approvalProposal.Status {
+	case lightning.ApprovalExecuted:
+		messageForBot.Message = fmt.Sprintf("Proposal %v approved by %v and executed.",
+			approvalProposalId, user.Username)
+	case lightning.ApprovalFailed:
+		messageForBot.Message = fmt.Sprintf("Proposal %v approved by %v but the execution failed.",
+			approvalProposalId, user.Username)
+	default:
+		messageForBot.Message = fmt.Sprintf("Proposal %v rejected by %v.", approvalProposalId, user.Username)
+	}
+	return messageForBot
+}
+
+// getChatApproverRejection returns why the user of the chat can't decide on the proposal (empty when allowed)
+func getChatApproverRejection(user auth.User, chatUserId string, approvalProposal lightning.ApprovalProposal) string {
+	if user.UserAccountId == 0 {
+		return fmt.Sprintf("Your chat user (id: %v) is not linked to a Torq user account.", chatUserId)
+	}
+	if user.Role != auth.RoleAdmin {
+		return "Only admin users can decide on proposals."
+	}
+	if approvalProposal.RequestedByUserAccountId != nil &&
+		*approvalProposal.RequestedByUserAccountId == user.UserAccountId {
+		return "A proposal needs to be decided by another user than the requester."
+	}
+	return ""
 }
 
 func processSettingsRequest(db *sqlx.DB,
@@ -447,11 +695,6 @@ func processSettingsRequest(db *sqlx.DB,
 	settings string,
 	messageForBot MessageForBot) MessageForBot {
 
-	nodeSettings := map[string]bool{
-		"nodeDetailsDeactivate": true,
-		"nodeDetailsActivate":   true,
-	}
-
 	if settings == "" {
 		SendNodeSettingsMenu(db, communicationTargetType, messageForBot)
 		return messageForBot
@@ -459,7 +702,7 @@ func processSettingsRequest(db *sqlx.DB,
 	var channelIds []int
 	var nodeIds []int
 	var err error
-	if _, exists := nodeSettings[settings]; exists {
+	if GetCommunicationType(settings) != nil {
 		nodeIds, err = GetNodeIdsByCommunication(db, communicationTargetType)
 		cachedPublicKey := PublicKeys[communicationTargetType][messageForBot.GetChannelIdentifier()]
 		if PublicKeys[communicationTargetType][messageForBot.GetChannelIdentifier()] != "" {
@@ -567,8 +810,9 @@ func processRegisterRequest(db *sqlx.DB,
 	}
 	for _, unregisteredNodeId := range unregisteredNodeIds {
 		communication := Communication{
-			TargetType: communicationTargetType,
-			NodeId:     unregisteredNodeId,
+			TargetType:              communicationTargetType,
+			NodeId:                  unregisteredNodeId,
+			PaymentFailedMinimumSat: defaultPaymentFailedMinimumSat,
 		}
 		if messageForBot.IsSlack() {
 			communication.TargetName = messageForBot.Slack.ReplyTo
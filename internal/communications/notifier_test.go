@@ -0,0 +1,160 @@
+package communications
+
+import (
+	"testing"
+
+	"github.com/lncapital/torq/internal/auth"
+	"github.com/lncapital/torq/internal/lightning"
+	"github.com/lncapital/torq/testutil"
+	"github.com/slack-go/slack"
+	"golang.org/x/exp/slices"
+)
+
+func TestCommunicationTypeSettings(t *testing.T) {
+	settingsMenuButtons := getSettingsMenuButtons()
+	for _, communicationType := range GetCommunicationTypes() {
+		settingsMenuButton, exists := settingsMenuButtons[communicationType]
+		if !exists {
+			testutil.Errorf(t, "getSettingsMenuButtons() is missing communicationType: %v", communicationType)
+			continue
+		}
+		for _, button := range []string{settingsMenuButton.activateButton, settingsMenuButton.deactivateButton} {
+			parsed := GetCommunicationType(button)
+			if parsed == nil || *parsed != communicationType {
+				testutil.Errorf(t, "GetCommunicationType(%v) = %v, want %v", button, parsed, communicationType)
+				continue
+			}
+		}
+		var communication Communication
+		communication.AddCommunicationType(communicationType)
+		for _, other := range GetCommunicationTypes() {
+			if communication.HasCommunicationType(other) != (other == communicationType) {
+				testutil.Errorf(t, "HasCommunicationType(%v) after AddCommunicationType(%v) = %v",
+					other, communicationType, communication.HasCommunicationType(other))
+			}
+		}
+		communication.RemoveCommunicationType(communicationType)
+		if communication.HasCommunicationType(communicationType) {
+			testutil.Errorf(t, "HasCommunicationType(%v) after RemoveCommunicationType", communicationType)
+			continue
+		}
+		testutil.Successf(t, "communicationType: %v", communicationType)
+	}
+}
+
+func TestParseApprovalButton(t *testing.T) {
+	markup := getApprovalMarkup(12)
+	approveData := *markup.InlineKeyboard[0][0].CallbackData
+	rejectData := *markup.InlineKeyboard[0][1].CallbackData
+	testCases := []struct {
+		name        string
+		buttonData  string
+		wantId      int
+		wantApprove bool
+		wantOk      bool
+	}{
+		{name: "approve", buttonData: approveData, wantId: 12, wantApprove: true, wantOk: true},
+		{name: "reject", buttonData: rejectData, wantId: 12, wantApprove: false, wantOk: true},
+		{name: "settings button", buttonData: ActivateChannelOpenedButton, wantOk: false},
+		{name: "invalid id", buttonData: ApproveProposalButton + " twelve", wantOk: false},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			id, approve, ok := parseApprovalButton(tc.buttonData)
+			if id != tc.wantId || approve != tc.wantApprove || ok != tc.wantOk {
+				testutil.Errorf(t, "parseApprovalButton(%v) = %v, %v, %v", tc.buttonData, id, approve, ok)
+				return
+			}
+			testutil.Successf(t, "parseApprovalButton(%v) = %v, %v, %v", tc.buttonData, id, approve, ok)
+		})
+	}
+}
+
+func TestGetChatApproverRejection(t *testing.T) {
+	requesterId := 1
+	approvalProposal := lightning.ApprovalProposal{RequestedBy: "requester", RequestedByUserAccountId: &requesterId}
+	testCases := []struct {
+		name         string
+		user         auth.User
+		wantRejected bool
+	}{
+		{name: "not linked", user: auth.User{}, wantRejected: true},
+		{name: "operator", user: auth.User{UserAccountId: 2, Username: "operator", Role: auth.RoleOperator},
+			wantRejected: true},
+		{name: "requester", user: auth.User{UserAccountId: 1, Username: "requester", Role: auth.RoleAdmin},
+			wantRejected: true},
+		{name: "other admin", user: auth.User{UserAccountId: 3, Username: "admin", Role: auth.RoleAdmin},
+			wantRejected: false},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			rejection := getChatApproverRejection(tc.user, "12345", approvalProposal)
+			if (rejection != "") != tc.wantRejected {
+				testutil.Errorf(t, "getChatApproverRejection() = %v, want rejected: %v", rejection, tc.wantRejected)
+				return
+			}
+			testutil.Successf(t, "getChatApproverRejection() = %v", rejection)
+		})
+	}
+}
+
+func TestGetPaymentFailedCommunications(t *testing.T) {
+	communications := []Communication{
+		{CommunicationId: 1, PaymentFailedMinimumSat: 0},
+		{CommunicationId: 2, PaymentFailedMinimumSat: defaultPaymentFailedMinimumSat},
+		{CommunicationId: 3, PaymentFailedMinimumSat: 5_000_000},
+	}
+	testCases := []struct {
+		name      string
+		amountSat int64
+		wantIds   []int
+	}{
+		{name: "small payment", amountSat: 1_000, wantIds: []int{1}},
+		{name: "at the default minimum", amountSat: defaultPaymentFailedMinimumSat, wantIds: []int{1, 2}},
+		{name: "large payment", amountSat: 10_000_000, wantIds: []int{1, 2, 3}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var ids []int
+			for _, communication := range getPaymentFailedCommunications(communications, tc.amountSat) {
+				ids = append(ids, communication.CommunicationId)
+			}
+			if !slices.Equal(ids, tc.wantIds) {
+				testutil.Errorf(t, "getPaymentFailedCommunications(%v) = %v, want %v", tc.amountSat, ids, tc.wantIds)
+				return
+			}
+			testutil.Successf(t, "getPaymentFailedCommunications(%v) = %v", tc.amountSat, ids)
+		})
+	}
+}
+
+func TestGetSlackSettingsMenuButtons(t *testing.T) {
+	settings := map[CommunicationType]bool{PaymentFailed: true, HtlcFailureBurst: true}
+	settingsMenuButtons := getSettingsMenuButtons()
+	buttons := getSlackSettingsMenuButtons(settings)
+	if len(buttons) != len(GetCommunicationTypes()) {
+		testutil.Fatalf(t, "getSlackSettingsMenuButtons() returned %v buttons, want %v",
+			len(buttons), len(GetCommunicationTypes()))
+	}
+	for i, communicationType := range GetCommunicationTypes() {
+		button, ok := buttons[i].(*slack.ButtonBlockElement)
+		if !ok {
+			testutil.Errorf(t, "getSlackSettingsMenuButtons()[%v] is not a button", i)
+			continue
+		}
+		wantActionId := settingsMenuButtons[communicationType].activateButton
+		if settings[communicationType] {
+			wantActionId = settingsMenuButtons[communicationType].deactivateButton
+		}
+		if button.ActionID != wantActionId {
+			testutil.Errorf(t, "getSlackSettingsMenuButtons()[%v] = %v, want %v", i, button.ActionID, wantActionId)
+			continue
+		}
+		parsed := GetCommunicationType(button.ActionID)
+		if parsed == nil || *parsed != communicationType {
+			testutil.Errorf(t, "GetCommunicationType(%v) = %v, want %v", button.ActionID, parsed, communicationType)
+			continue
+		}
+		testutil.Successf(t, "getSlackSettingsMenuButtons()[%v] = %v", i, button.ActionID)
+	}
+}
@@ -0,0 +1,184 @@
+package communications
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/gin-gonic/gin"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/lncapital/torq/pkg/server_errors"
+)
+
+func RegisterCommunicationRoutes(r *gin.RouterGroup, db *sqlx.DB) {
+	r.GET("node/:nodeId", func(c *gin.Context) { getCommunicationsHandler(c, db) })
+	r.POST("", func(c *gin.Context) { addCommunicationHandler(c, db) })
+	r.PUT("", func(c *gin.Context) { setCommunicationHandler(c, db) })
+	r.DELETE(":communicationId", func(c *gin.Context) { removeCommunicationHandler(c, db) })
+	// Sends a test message to verify the settings of the communication
+	r.POST(":communicationId/test", func(c *gin.Context) { testCommunicationHandler(c, db) })
+}
+
+// RegisterCommunicationTargetRoutes exposes the communications to select them (i.e. in the workflow editor)
+// without the target text and settings.
+func RegisterCommunicationTargetRoutes(r *gin.RouterGroup, db *sqlx.DB) {
+	r.GET("", func(c *gin.Context) { getCommunicationTargetsHandler(c, db) })
+}
+
+func getCommunicationsHandler(c *gin.Context, db *sqlx.DB) {
+	nodeId, err := strconv.Atoi(c.Param("nodeId"))
+	if err != nil {
+		server_errors.SendBadRequest(c, "Failed to find/parse nodeId in the request.")
+		return
+	}
+	communications, err := GetCommunicationsByNodeId(db, nodeId)
+	if err != nil {
+		server_errors.WrapLogAndSendServerError(c, err, fmt.Sprintf("Getting communications for nodeId: %v", nodeId))
+		return
+	}
+	for i := range communications {
+		communications[i] = redactTargetSettings(communications[i])
+	}
+	c.JSON(http.StatusOK, communications)
+}
+
+func getCommunicationTargetsHandler(c *gin.Context, db *sqlx.DB) {
+	communicationTargets, err := GetCommunicationTargets(db)
+	if err != nil {
+		server_errors.WrapLogAndSendServerError(c, err, "Getting communication targets")
+		return
+	}
+	c.JSON(http.StatusOK, communicationTargets)
+}
+
+func addCommunicationHandler(c *gin.Context, db *sqlx.DB) {
+	communication := Communication{PaymentFailedMinimumSat: defaultPaymentFailedMinimumSat}
+	if err := c.BindJSON(&communication); err != nil {
+		server_errors.SendBadRequestFromError(c, errors.Wrap(err, server_errors.JsonParseError))
+		return
+	}
+	communication = restoreTargetSettings(communication, Communication{})
+	if !validateCommunication(c, communication) {
+		return
+	}
+	communicationId, err := AddCommunication(db, communication)
+	if err != nil {
+		server_errors.WrapLogAndSendServerError(c, err, "Adding communication")
+		return
+	}
+	communication, err = GetCommunication(db, communicationId)
+	if err != nil {
+		server_errors.WrapLogAndSendServerError(c, err,
+			fmt.Sprintf("Getting communication for communicationId: %v", communicationId))
+		return
+	}
+	c.JSON(http.StatusOK, redactTargetSettings(communication))
+}
+
+func setCommunicationHandler(c *gin.Context, db *sqlx.DB) {
+	var communication Communication
+	if err := c.BindJSON(&communication); err != nil {
+		server_errors.SendBadRequestFromError(c, errors.Wrap(err, server_errors.JsonParseError))
+		return
+	}
+	if communication.CommunicationId == 0 {
+		server_errors.SendBadRequest(c, "Failed to find communicationId in the request.")
+		return
+	}
+	storedCommunication, err := GetCommunication(db, communication.CommunicationId)
+	if err != nil {
+		server_errors.WrapLogAndSendServerError(c, err,
+			fmt.Sprintf("Getting communication for communicationId: %v", communication.CommunicationId))
+		return
+	}
+	communication = restoreTargetSettings(communication, storedCommunication)
+	if !validateCommunication(c, communication) {
+		return
+	}
+	communication, err = SetCommunication(db, communication)
+	if err != nil {
+		server_errors.WrapLogAndSendServerError(c, err,
+			fmt.Sprintf("Setting communication for communicationId: %v", communication.CommunicationId))
+		return
+	}
+	c.JSON(http.StatusOK, redactTargetSettings(communication))
+}
+
+func removeCommunicationHandler(c *gin.Context, db *sqlx.DB) {
+	communicationId, err := strconv.Atoi(c.Param("communicationId"))
+	if err != nil {
+		server_errors.SendBadRequest(c, "Failed to find/parse communicationId in the request.")
+		return
+	}
+	err = RemoveCommunication(db, communicationId)
+	if err != nil {
+		server_errors.WrapLogAndSendServerError(c, err,
+			fmt.Sprintf("Removing communication for communicationId: %v", communicationId))
+		return
+	}
+	c.JSON(http.StatusOK, nil)
+}
+
+func testCommunicationHandler(c *gin.Context, db *sqlx.DB) {
+	communicationId, err := strconv.Atoi(c.Param("communicationId"))
+	if err != nil {
+		server_errors.SendBadRequest(c, "Failed to find/parse communicationId in the request.")
+		return
+	}
+	communication, err := GetCommunication(db, communicationId)
+	if err != nil {
+		server_errors.WrapLogAndSendServerError(c, err,
+			fmt.Sprintf("Getting communication for communicationId: %v", communicationId))
+		return
+	}
+	sink := getSink(communication.TargetType)
+	if sink == nil {
+		server_errors.SendUnprocessableEntity(c, "There is no sink for the targetType of the communication.")
+		return
+	}
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 2*time.Minute)
+	defer cancel()
+	err = deliver(ctx, sink, communication, "Test notification from Torq")
+	if err != nil {
+		server_errors.SendUnprocessableEntityFromError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, nil)
+}
+
+func validateCommunication(c *gin.Context, communication Communication) bool {
+	if communication.NodeId == 0 {
+		server_errors.SendBadRequest(c, "Failed to find nodeId in the request.")
+		return false
+	}
+	if getSink(communication.TargetType) == nil {
+		server_errors.SendUnprocessableEntity(c, "Unknown targetType.")
+		return false
+	}
+	if communication.PaymentFailedMinimumSat < 0 {
+		server_errors.SendUnprocessableEntity(c, "The paymentFailedMinimumSat cannot be negative.")
+		return false
+	}
+	var err error
+	switch communication.TargetType {
+	case CommunicationWebhook:
+		var settings WebhookSettings
+		err = getTargetSettings(communication, &settings)
+		if err == nil && settings.Secret == "" {
+			err = errors.New("The secret of the webhook is required.")
+		}
+	case CommunicationEmail:
+		err = getTargetSettings(communication, &EmailSettings{})
+	case CommunicationMatrix:
+		err = getTargetSettings(communication, &MatrixSettings{})
+	}
+	if err != nil {
+		server_errors.SendUnprocessableEntityFromError(c, err)
+		return false
+	}
+	return true
+}
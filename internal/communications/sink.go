@@ -0,0 +1,318 @@
+package communications
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/rs/zerolog/log"
+)
+
+// Sink delivers a notification message to a communication target (i.e. a Slack channel or a webhook url).
+// New sinks are registered with RegisterSink for their CommunicationTargetType.
+type Sink interface {
+	Send(ctx context.Context, communication Communication, message string) error
+	RetryPolicy() RetryPolicy
+}
+
+type RetryPolicy struct {
+	MaximumAttempts int
+	InitialBackoff  time.Duration
+	MaximumBackoff  time.Duration
+	// Timeout of a single attempt
+	Timeout time.Duration
+}
+
+// backoff returns the delay before the attempt (the first attempt is 1) doubling the delay on every attempt.
+func (rp RetryPolicy) backoff(attempt int) time.Duration {
+	if attempt <= 1 {
+		return 0
+	}
+	backoff := rp.InitialBackoff
+	for i := 2; i < attempt && backoff < rp.MaximumBackoff; i++ {
+		backoff = backoff * 2
+	}
+	if rp.MaximumBackoff != 0 && backoff > rp.MaximumBackoff {
+		return rp.MaximumBackoff
+	}
+	return backoff
+}
+
+// permanentError is returned by a sink when retrying will not help (i.e. invalid settings).
+type permanentError struct {
+	error
+}
+
+func newPermanentError(err error) error {
+	return permanentError{error: err}
+}
+
+func isPermanentError(err error) bool {
+	var pe permanentError
+	return errors.As(err, &pe)
+}
+
+var sinksMutex = &sync.RWMutex{}              //nolint:gochecknoglobals
+var sinks = map[CommunicationTargetType]Sink{ //nolint:gochecknoglobals
+	CommunicationTelegramHighPriority: telegramSink{targetType: CommunicationTelegramHighPriority},
+	CommunicationTelegramLowPriority:  telegramSink{targetType: CommunicationTelegramLowPriority},
+	CommunicationSlack:                slackSink{},
+	CommunicationWebhook:              webhookSink{},
+	CommunicationEmail:                emailSink{},
+	CommunicationMatrix:               matrixSink{},
+}
+
+func RegisterSink(targetType CommunicationTargetType, sink Sink) {
+	sinksMutex.Lock()
+	defer sinksMutex.Unlock()
+	sinks[targetType] = sink
+}
+
+func getSink(targetType CommunicationTargetType) Sink {
+	sinksMutex.RLock()
+	defer sinksMutex.RUnlock()
+	return sinks[targetType]
+}
+
+func GetSinkCommunicationTargetTypes() []CommunicationTargetType {
+	sinksMutex.RLock()
+	defer sinksMutex.RUnlock()
+	var targetTypes []CommunicationTargetType
+	for targetType := range sinks {
+		targetTypes = append(targetTypes, targetType)
+	}
+	return targetTypes
+}
+
+// deliver sends the message to the sink and retries according to the RetryPolicy of the sink.
+// deliveryIdKey is the context key of the id of the notification that is the same for every attempt
+type deliveryIdKey struct{}
+
+// getDeliveryId returns the id of the notification so sinks can de-duplicate retries (i.e. the Matrix transaction id)
+func getDeliveryId(ctx context.Context) string {
+	deliveryId, ok := ctx.Value(deliveryIdKey{}).(string)
+	if !ok {
+		return fmt.Sprintf("%v", time.Now().UnixNano())
+	}
+	return deliveryId
+}
+
+func deliver(ctx context.Context, sink Sink, communication Communication, message string) error {
+	ctx = context.WithValue(ctx, deliveryIdKey{}, fmt.Sprintf("%v", time.Now().UnixNano()))
+	retryPolicy := sink.RetryPolicy()
+	maximumAttempts := retryPolicy.MaximumAttempts
+	if maximumAttempts < 1 {
+		maximumAttempts = 1
+	}
+	var err error
+	for attempt := 1; attempt <= maximumAttempts; attempt++ {
+		if attempt > 1 {
+			select {
+			case <-ctx.Done():
+				return errors.Wrapf(ctx.Err(), "Delivering to communicationId: %v (last error: %v)",
+					communication.CommunicationId, err)
+			case <-time.After(retryPolicy.backoff(attempt)):
+			}
+		}
+		err = sendWithTimeout(ctx, sink, communication, message, retryPolicy.Timeout)
+		if err == nil {
+			return nil
+		}
+		if isPermanentError(err) {
+			break
+		}
+		log.Debug().Err(err).Msgf("Notifier attempt %v/%v failed for communicationId: %v",
+			attempt, maximumAttempts, communication.CommunicationId)
+	}
+	return errors.Wrapf(err, "Delivering to communicationId: %v", communication.CommunicationId)
+}
+
+func sendWithTimeout(ctx context.Context,
+	sink Sink,
+	communication Communication,
+	message string,
+	timeout time.Duration) error {
+
+	if timeout == 0 {
+		return sink.Send(ctx, communication, message)
+	}
+	attemptCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	return sink.Send(attemptCtx, communication, message)
+}
+
+// fanOut delivers the message to all communications in parallel and waits for all deliveries to finish.
+// The returned error holds the failed deliveries (nil when all deliveries succeeded).
+func fanOut(ctx context.Context, communicationMessage string, communicationDestinations []Communication) error {
+	var wg sync.WaitGroup
+	var deliveryErrorsMutex sync.Mutex
+	var deliveryErrors []string
+	for _, communication := range communicationDestinations {
+		sink := getSink(communication.TargetType)
+		if sink == nil {
+			log.Error().Msgf("Notifier has no sink for targetType: %v (communicationId: %v)",
+				communication.TargetType, communication.CommunicationId)
+			deliveryErrors = append(deliveryErrors, fmt.Sprintf("No sink for targetType: %v (communicationId: %v)",
+				communication.TargetType, communication.CommunicationId))
+			continue
+		}
+		wg.Add(1)
+		go func(sink Sink, communication Communication) {
+			defer wg.Done()
+			log.Info().Msgf("Notifier sending communication of targetType %v (%v): %v",
+				communication.TargetType, communication.TargetName, communicationMessage)
+			err := deliver(ctx, sink, communication, communicationMessage)
+			if err != nil {
+				log.Error().Err(err).Msgf("Notifier failed to send communication (%v): %v",
+					communication.TargetName, communicationMessage)
+				deliveryErrorsMutex.Lock()
+				deliveryErrors = append(deliveryErrors, err.Error())
+				deliveryErrorsMutex.Unlock()
+			}
+		}(sink, communication)
+	}
+	wg.Wait()
+	if len(deliveryErrors) == 0 {
+		return nil
+	}
+	sort.Strings(deliveryErrors)
+	return errors.New(fmt.Sprintf("%v of %v deliveries failed: %v",
+		len(deliveryErrors), len(communicationDestinations), strings.Join(deliveryErrors, "; ")))
+}
+
+func getTargetSettings(communication Communication, targetSettings any) error {
+	if communication.TargetSettings == nil || *communication.TargetSettings == "" {
+		return newPermanentError(errors.New(fmt.Sprintf(
+			"Target settings are missing for communicationId: %v", communication.CommunicationId)))
+	}
+	err := json.Unmarshal([]byte(*communication.TargetSettings), targetSettings)
+	if err != nil {
+		return newPermanentError(errors.Wrapf(err,
+			"Unmarshalling target settings for communicationId: %v", communication.CommunicationId))
+	}
+	return nil
+}
+
+// redactedSecret replaces the secrets of the target settings in the API responses, sending it back keeps the secret
+const redactedSecret = "********"
+
+// getTargetSettingsSecretKeys returns the JSON keys of the target settings that hold a secret
+func getTargetSettingsSecretKeys(targetType CommunicationTargetType) []string {
+	switch targetType {
+	case CommunicationWebhook:
+		return []string{"secret"}
+	case CommunicationEmail:
+		return []string{"password"}
+	case CommunicationMatrix:
+		return []string{"accessToken"}
+	}
+	return nil
+}
+
+// redactTargetSettings replaces the secrets of the target settings with redactedSecret
+func redactTargetSettings(communication Communication) Communication {
+	communication.TargetSettings = replaceTargetSettingsSecrets(communication,
+		func(key string, value any) any {
+			if value == "" {
+				return value
+			}
+			return redactedSecret
+		})
+	return communication
+}
+
+// restoreTargetSettings replaces the redactedSecret values with the secrets of the stored communication
+func restoreTargetSettings(communication Communication, storedCommunication Communication) Communication {
+	storedSettings := make(map[string]any)
+	if storedCommunication.TargetSettings != nil && storedCommunication.TargetType == communication.TargetType {
+		err := json.Unmarshal([]byte(*storedCommunication.TargetSettings), &storedSettings)
+		if err != nil {
+			log.Error().Err(err).Msgf("Unmarshalling target settings for communicationId: %v",
+				storedCommunication.CommunicationId)
+		}
+	}
+	communication.TargetSettings = replaceTargetSettingsSecrets(communication,
+		func(key string, value any) any {
+			if value != redactedSecret {
+				return value
+			}
+			storedValue, exists := storedSettings[key]
+			if !exists {
+				return ""
+			}
+			return storedValue
+		})
+	return communication
+}
+
+func replaceTargetSettingsSecrets(communication Communication, replace func(key string, value any) any) *string {
+	secretKeys := getTargetSettingsSecretKeys(communication.TargetType)
+	if len(secretKeys) == 0 || communication.TargetSettings == nil || *communication.TargetSettings == "" {
+		return communication.TargetSettings
+	}
+	targetSettings := make(map[string]any)
+	err := json.Unmarshal([]byte(*communication.TargetSettings), &targetSettings)
+	if err != nil {
+		// Invalid settings are rejected by the validation, they can't hold a parsable secret
+		return communication.TargetSettings
+	}
+	for _, key := range secretKeys {
+		if value, exists := targetSettings[key]; exists {
+			targetSettings[key] = replace(key, value)
+		}
+	}
+	targetSettingsBytes, err := json.Marshal(targetSettings)
+	if err != nil {
+		return communication.TargetSettings
+	}
+	targetSettingsString := string(targetSettingsBytes)
+	return &targetSettingsString
+}
+
+type slackSink struct{}
+
+func (slackSink) Send(ctx context.Context, communication Communication, message string) error {
+	return sendSlackBotMessage(ctx, MessageForBot{
+		Message: message,
+		Slack: MessageForSlack{
+			Channel: communication.TargetText,
+			Color:   "#283B4C",
+		},
+	})
+}
+
+func (slackSink) RetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaximumAttempts: 5,
+		InitialBackoff:  2 * time.Second,
+		MaximumBackoff:  30 * time.Second,
+		Timeout:         10 * time.Second,
+	}
+}
+
+type telegramSink struct {
+	targetType CommunicationTargetType
+}
+
+// Send can't be cancelled by the context (the Telegram client has no context support)
+func (ts telegramSink) Send(_ context.Context, communication Communication, message string) error {
+	return sendTelegramBotMessage(MessageForBot{
+		Message: message,
+		Telegram: MessageForTelegram{
+			Id: communication.TargetNumber,
+		},
+	}, ts.targetType)
+}
+
+func (telegramSink) RetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaximumAttempts: 5,
+		InitialBackoff:  2 * time.Second,
+		MaximumBackoff:  30 * time.Second,
+	}
+}
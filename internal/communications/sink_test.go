@@ -0,0 +1,396 @@
+package communications
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/lncapital/torq/testutil"
+)
+
+// fastRetrySink replaces the retry policy of a sink so the tests don't wait for the production backoff
+type fastRetrySink struct {
+	Sink
+}
+
+func (fastRetrySink) RetryPolicy() RetryPolicy {
+	return RetryPolicy{MaximumAttempts: 3, InitialBackoff: time.Millisecond, MaximumBackoff: time.Millisecond}
+}
+
+func stringPointer(s string) *string {
+	return &s
+}
+
+func TestWebhookSink(t *testing.T) {
+	secret := "webhook-secret"
+	testCases := []struct {
+		name         string
+		statusCodes  []int
+		wantErr      bool
+		wantAttempts int32
+	}{
+		{name: "success", statusCodes: []int{http.StatusOK}, wantErr: false, wantAttempts: 1},
+		{name: "retried server error", statusCodes: []int{http.StatusBadGateway, http.StatusOK},
+			wantErr: false, wantAttempts: 2},
+		{name: "server error exhausts attempts", statusCodes: []int{http.StatusInternalServerError},
+			wantErr: true, wantAttempts: 3},
+		{name: "client error is not retried", statusCodes: []int{http.StatusUnauthorized},
+			wantErr: true, wantAttempts: 1},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var attempts int32
+			var payload WebhookPayload
+			var validSignature bool
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				attempt := atomic.AddInt32(&attempts, 1)
+				body, _ := io.ReadAll(r.Body)
+				validSignature = r.Header.Get(WebhookSignatureHeader) == "sha256="+SignWebhookPayload(secret, body)
+				_ = json.Unmarshal(body, &payload)
+				statusCode := tc.statusCodes[len(tc.statusCodes)-1]
+				if int(attempt) <= len(tc.statusCodes) {
+					statusCode = tc.statusCodes[attempt-1]
+				}
+				w.WriteHeader(statusCode)
+			}))
+			defer server.Close()
+
+			communication := Communication{
+				CommunicationId: 1,
+				NodeId:          2,
+				TargetType:      CommunicationWebhook,
+				TargetText:      server.URL,
+				TargetSettings:  stringPointer(`{"secret":"` + secret + `"}`),
+			}
+			err := deliver(context.Background(), fastRetrySink{Sink: webhookSink{}}, communication, "Channel closed")
+			if (err != nil) != tc.wantErr {
+				testutil.Errorf(t, "deliver() error = %v, wantErr %v", err, tc.wantErr)
+				return
+			}
+			if attempts != tc.wantAttempts {
+				testutil.Errorf(t, "deliver() attempts = %v, want %v", attempts, tc.wantAttempts)
+				return
+			}
+			if !validSignature {
+				testutil.Errorf(t, "deliver() signature was invalid")
+				return
+			}
+			if payload.Message != "Channel closed" || payload.NodeId != 2 {
+				testutil.Errorf(t, "deliver() payload = %v", payload)
+				return
+			}
+			testutil.Successf(t, "deliver() attempts = %v, want %v", attempts, tc.wantAttempts)
+		})
+	}
+}
+
+func TestWebhookSinkMissingSettings(t *testing.T) {
+	err := deliver(context.Background(), fastRetrySink{Sink: webhookSink{}},
+		Communication{TargetType: CommunicationWebhook, TargetText: "http://127.0.0.1:1"}, "message")
+	if err == nil || !isPermanentError(err) {
+		testutil.Errorf(t, "deliver() error = %v, want permanent error", err)
+		return
+	}
+	testutil.Successf(t, "deliver() error = %v", err)
+}
+
+func TestMatrixSink(t *testing.T) {
+	var path string
+	var authorization string
+	var message matrixMessage
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.EscapedPath()
+		authorization = r.Header.Get("Authorization")
+		_ = json.NewDecoder(r.Body).Decode(&message)
+		_, _ = w.Write([]byte(`{"event_id":"$1"}`))
+	}))
+	defer server.Close()
+
+	communication := Communication{
+		TargetType:     CommunicationMatrix,
+		TargetText:     "!room:example.org",
+		TargetSettings: stringPointer(`{"homeserverUrl":"` + server.URL + `/","accessToken":"token"}`),
+	}
+	err := deliver(context.Background(), fastRetrySink{Sink: matrixSink{}}, communication, "Peer disconnected")
+	if err != nil {
+		testutil.Errorf(t, "deliver() error = %v", err)
+		return
+	}
+	if !strings.HasPrefix(path, "/_matrix/client/v3/rooms/%21room:example.org/send/m.room.message/") {
+		testutil.Errorf(t, "deliver() path = %v", path)
+		return
+	}
+	if authorization != "Bearer token" || message.MessageType != "m.text" || message.Body != "Peer disconnected" {
+		testutil.Errorf(t, "deliver() authorization = %v, message = %v", authorization, message)
+		return
+	}
+	testutil.Successf(t, "deliver() path = %v", path)
+}
+
+func TestMatrixSinkRetryTransactionId(t *testing.T) {
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.EscapedPath())
+		if len(paths) == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		_, _ = w.Write([]byte(`{"event_id":"$1"}`))
+	}))
+	defer server.Close()
+
+	communication := Communication{
+		TargetType:     CommunicationMatrix,
+		TargetText:     "!room:example.org",
+		TargetSettings: stringPointer(`{"homeserverUrl":"` + server.URL + `","accessToken":"token"}`),
+	}
+	err := deliver(context.Background(), fastRetrySink{Sink: matrixSink{}}, communication, "Peer disconnected")
+	if err != nil {
+		testutil.Errorf(t, "deliver() error = %v", err)
+		return
+	}
+	if len(paths) != 2 || paths[0] != paths[1] {
+		testutil.Errorf(t, "deliver() paths = %v, want the same transaction id for every attempt", paths)
+		return
+	}
+	testutil.Successf(t, "deliver() paths = %v", paths)
+}
+
+// startSmtpStandIn accepts one SMTP session and returns the received DATA on the channel
+func startSmtpStandIn(t *testing.T) (string, int, <-chan string) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	received := make(chan string, 1)
+	go func() {
+		defer listener.Close()
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		reader := bufio.NewReader(conn)
+		write := func(line string) { _, _ = conn.Write([]byte(line + "\r\n")) }
+		write("220 localhost stand-in")
+		var data strings.Builder
+		inData := false
+		for {
+			line, err := reader.ReadString('\n')
+			if err != nil {
+				return
+			}
+			if inData {
+				if line == ".\r\n" {
+					inData = false
+					received <- data.String()
+					write("250 OK")
+					continue
+				}
+				data.WriteString(line)
+				continue
+			}
+			command := strings.ToUpper(strings.TrimSpace(line))
+			switch {
+			case strings.HasPrefix(command, "EHLO"):
+				write("250-localhost")
+				write("250 AUTH PLAIN")
+			case strings.HasPrefix(command, "AUTH"):
+				write("235 Authenticated")
+			case strings.HasPrefix(command, "DATA"):
+				inData = true
+				write("354 Send data")
+			case strings.HasPrefix(command, "QUIT"):
+				write("221 Bye")
+				return
+			default:
+				write("250 OK")
+			}
+		}
+	}()
+	host, portString, _ := net.SplitHostPort(listener.Addr().String())
+	port, _ := strconv.Atoi(portString)
+	return host, port, received
+}
+
+func TestEmailSink(t *testing.T) {
+	host, port, received := startSmtpStandIn(t)
+	communication := Communication{
+		TargetType: CommunicationEmail,
+		TargetText: "operator@example.org",
+		TargetSettings: stringPointer(`{"host":"` + host + `","port":` + strconv.Itoa(port) +
+			`,"username":"torq","password":"secret","from":"torq@example.org"}`),
+	}
+	err := deliver(context.Background(), fastRetrySink{Sink: emailSink{}}, communication, "Force close detected\nDetails")
+	if err != nil {
+		testutil.Errorf(t, "deliver() error = %v", err)
+		return
+	}
+	select {
+	case data := <-received:
+		if !strings.Contains(data, "To: operator@example.org") ||
+			!strings.Contains(data, "Subject: Torq: Force close detected") ||
+			!strings.Contains(data, "Details") {
+			testutil.Errorf(t, "deliver() data = %v", data)
+			return
+		}
+		testutil.Successf(t, "deliver() delivered the email")
+	case <-time.After(5 * time.Second):
+		testutil.Errorf(t, "deliver() the SMTP stand-in did not receive the email")
+	}
+}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	retryPolicy := RetryPolicy{InitialBackoff: time.Second, MaximumBackoff: 5 * time.Second}
+	testCases := []struct {
+		attempt int
+		want    time.Duration
+	}{
+		{attempt: 1, want: 0},
+		{attempt: 2, want: time.Second},
+		{attempt: 3, want: 2 * time.Second},
+		{attempt: 4, want: 4 * time.Second},
+		{attempt: 5, want: 5 * time.Second},
+		{attempt: 10, want: 5 * time.Second},
+	}
+	for _, tc := range testCases {
+		t.Run(strconv.Itoa(tc.attempt), func(t *testing.T) {
+			got := retryPolicy.backoff(tc.attempt)
+			if got != tc.want {
+				testutil.Errorf(t, "backoff() = %v, want %v", got, tc.want)
+			} else {
+				testutil.Successf(t, "backoff() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestRedactTargetSettings(t *testing.T) {
+	stored := Communication{
+		CommunicationId: 1,
+		TargetType:      CommunicationEmail,
+		TargetSettings:  stringPointer(`{"host":"smtp.example.com","password":"hunter22","username":"torq"}`),
+	}
+	redacted := redactTargetSettings(stored)
+	var settings EmailSettings
+	err := getTargetSettings(redacted, &settings)
+	if err != nil {
+		testutil.Fatalf(t, "getTargetSettings() error: %v", err)
+	}
+	if settings.Password != redactedSecret || settings.Username != "torq" || settings.Host != "smtp.example.com" {
+		testutil.Fatalf(t, "redactTargetSettings() = %v", *redacted.TargetSettings)
+	}
+
+	testCases := []struct {
+		name           string
+		targetSettings string
+		wantPassword   string
+	}{
+		{name: "placeholder keeps the stored password", targetSettings: `{"password":"********"}`,
+			wantPassword: "hunter22"},
+		{name: "new password", targetSettings: `{"password":"correct horse"}`, wantPassword: "correct horse"},
+		{name: "cleared password", targetSettings: `{"password":""}`, wantPassword: ""},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			communication := Communication{CommunicationId: 1, TargetType: CommunicationEmail,
+				TargetSettings: stringPointer(tc.targetSettings)}
+			restored := restoreTargetSettings(communication, stored)
+			var restoredSettings EmailSettings
+			err := getTargetSettings(restored, &restoredSettings)
+			if err != nil || restoredSettings.Password != tc.wantPassword {
+				testutil.Errorf(t, "restoreTargetSettings() password = %v, want %v (error: %v)",
+					restoredSettings.Password, tc.wantPassword, err)
+				return
+			}
+			testutil.Successf(t, "restoreTargetSettings() = %v", *restored.TargetSettings)
+		})
+	}
+
+	webhook := restoreTargetSettings(Communication{TargetType: CommunicationWebhook,
+		TargetSettings: stringPointer(`{"secret":"********"}`)}, Communication{})
+	if *webhook.TargetSettings != `{"secret":""}` {
+		testutil.Errorf(t, "restoreTargetSettings() without stored secret = %v", *webhook.TargetSettings)
+	}
+}
+
+func TestBuildEmail(t *testing.T) {
+	date := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	testCases := []struct {
+		name        string
+		message     string
+		wantSubject string
+	}{
+		{name: "first line", message: "Peer disconnected\nalias", wantSubject: "Subject: Torq: Peer disconnected\r\n"},
+		{name: "carriage return", message: "Peer evil\rBcc: victim@example.org",
+			wantSubject: "Subject: Torq: Peer evil\r\n"},
+		{name: "control characters", message: "Peer \x00evil\x1b", wantSubject: "Subject: Torq: Peer evil\r\n"},
+		{name: "non ascii", message: "Peer ⚡ disconnected",
+			wantSubject: "Subject: =?utf-8?q?Torq:_Peer_=E2=9A=A1_disconnected?=\r\n"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			email := buildEmail("torq@example.org", "ops@example.org", tc.message, date)
+			header, _, _ := strings.Cut(email, "\r\n\r\n")
+			if !strings.Contains(header, tc.wantSubject) || strings.Count(header, "\r\n") != 5 ||
+				strings.Count(header, "\r") != 5 {
+				testutil.Errorf(t, "buildEmail() header = %q, want subject %q", header, tc.wantSubject)
+				return
+			}
+			testutil.Successf(t, "buildEmail() header = %q", header)
+		})
+	}
+}
+
+func TestFanOutReportsFailedDeliveries(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/unauthorized" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	delivered := Communication{CommunicationId: 1, TargetType: CommunicationWebhook, TargetText: server.URL,
+		TargetSettings: stringPointer(`{"secret":"secret"}`)}
+	rejected := Communication{CommunicationId: 2, TargetType: CommunicationWebhook,
+		TargetText: server.URL + "/unauthorized", TargetSettings: stringPointer(`{"secret":"secret"}`)}
+	withoutSink := Communication{CommunicationId: 3, TargetType: CommunicationTargetType(-1)}
+
+	testCases := []struct {
+		name                      string
+		communicationDestinations []Communication
+		wantErr                   string
+	}{
+		{name: "all delivered", communicationDestinations: []Communication{delivered}},
+		{name: "rejected delivery", communicationDestinations: []Communication{delivered, rejected},
+			wantErr: "1 of 2 deliveries failed"},
+		{name: "missing sink", communicationDestinations: []Communication{delivered, rejected, withoutSink},
+			wantErr: "2 of 3 deliveries failed"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := fanOut(context.Background(), "Channel closed", tc.communicationDestinations)
+			if tc.wantErr == "" && err != nil {
+				testutil.Errorf(t, "fanOut() error = %v", err)
+				return
+			}
+			if tc.wantErr != "" && (err == nil || !strings.HasPrefix(err.Error(), tc.wantErr)) {
+				testutil.Errorf(t, "fanOut() error = %v, want %v", err, tc.wantErr)
+				return
+			}
+			testutil.Successf(t, "fanOut() error = %v", err)
+		})
+	}
+}
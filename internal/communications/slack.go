@@ -2,7 +2,9 @@ package communications
 
 import (
 	"context"
+	"fmt"
 	"runtime/debug"
+	"strconv"
 	"strings"
 
 	"github.com/cockroachdb/errors"
@@ -10,6 +12,7 @@ import (
 	"github.com/slack-go/slack"
 	"github.com/slack-go/slack/slackevents"
 	"github.com/slack-go/slack/socketmode"
+	"golang.org/x/exp/slices"
 
 	"github.com/rs/zerolog/log"
 
@@ -89,6 +92,14 @@ func processEvents(ctx context.Context, socketClient *socketmode.Client, db *sql
 				}
 				socketClient.Ack(*event.Request)
 				handleSlashCommand(db, command)
+			case socketmode.EventTypeInteractive:
+				callback, ok := event.Data.(slack.InteractionCallback)
+				if !ok {
+					log.Debug().Msgf("Could not type cast the event to the InteractionCallback: %v", event)
+					continue
+				}
+				socketClient.Ack(*event.Request)
+				handleInteraction(db, callback)
 			default:
 				log.Trace().Msgf("Could not type cast the event.Type: %v", event.Type)
 				log.Trace().Msgf("Could not type cast the event.Data: %v", event.Data)
@@ -99,7 +110,30 @@ func processEvents(ctx context.Context, socketClient *socketmode.Client, db *sql
 }
 
 func SendSlackBotMessages(botMessage MessageForBot) {
+	err := sendSlackBotMessage(context.Background(), botMessage)
+	if err != nil {
+		log.Error().Err(err).Msgf("Slack bot Send failed: %v", botMessage.Message)
+	}
+}
+
+// slackPermanentErrors are the Slack API errors that retrying will not solve
+var slackPermanentErrors = []string{ //nolint:gochecknoglobals
+	"channel_not_found",
+	"not_in_channel",
+	"is_archived",
+	"invalid_auth",
+	"not_authed",
+	"account_inactive",
+	"token_revoked",
+}
+
+// sendSlackBotMessage returns the error of the Slack API so the notifier can retry
+func sendSlackBotMessage(ctx context.Context, botMessage MessageForBot) error {
 	log.Debug().Msgf("Sending out slack message to %v: %v", botMessage.Slack.Channel, botMessage.Message)
+	oauth, _ := cache.GetSettings().GetSlackCredential()
+	if oauth == "" {
+		return newPermanentError(errors.New("Slack is not configured"))
+	}
 	attachment := slack.Attachment{
 		Text: botMessage.Message,
 	}
@@ -109,10 +143,15 @@ func SendSlackBotMessages(botMessage MessageForBot) {
 	if botMessage.Slack.Color != "" {
 		attachment.Color = botMessage.Slack.Color
 	}
-	_, _, err := getSlackClient().PostMessage(botMessage.Slack.Channel, slack.MsgOptionAttachments(attachment))
+	_, _, err := getSlackClient().PostMessageContext(ctx, botMessage.Slack.Channel, slack.MsgOptionAttachments(attachment))
 	if err != nil {
-		log.Error().Err(err).Msgf("Slack bot Send failed: %v", botMessage.Message)
+		var slackError slack.SlackErrorResponse
+		if errors.As(err, &slackError) && slices.Contains(slackPermanentErrors, slackError.Err) {
+			return newPermanentError(errors.Wrapf(err, "Sending slack message to channel: %v", botMessage.Slack.Channel))
+		}
+		return errors.Wrapf(err, "Sending slack message to channel: %v", botMessage.Slack.Channel)
 	}
+	return nil
 }
 
 func handleSlashCommand(db *sqlx.DB, command slack.SlashCommand) {
@@ -159,6 +198,81 @@ func handleEventMessage(db *sqlx.DB, socketClient *socketmode.Client, event slac
 	}
 }
 
+// handleInteraction processes the approve and reject buttons of the approval requests
+// and the activate and deactivate buttons of the settings menu
+func handleInteraction(db *sqlx.DB, callback slack.InteractionCallback) {
+	if callback.Type != slack.InteractionTypeBlockActions {
+		return
+	}
+	for _, blockAction := range callback.ActionCallback.BlockActions {
+		messageForBot := MessageForBot{
+			Slack: MessageForSlack{
+				Channel: callback.Channel.ID,
+				ReplyTo: callback.User.Name,
+				Color:   "#283B4C",
+			},
+		}
+		if GetCommunicationType(blockAction.ActionID) != nil {
+			HandleButton(db, messageForBot, SettingsButton, blockAction.ActionID, CommunicationSlack)
+			continue
+		}
+		approvalProposalId, approve, ok := parseApprovalButton(blockAction.ActionID + " " + blockAction.Value)
+		if !ok {
+			continue
+		}
+		handleApprovalButton(db, messageForBot, approvalProposalId, approve, callback.User.ID, CommunicationSlack)
+	}
+}
+
+func sendSlackSettingsMenu(channel string, message string, settings map[CommunicationType]bool) {
+	log.Debug().Msgf("Sending out slack settings menu to %v", channel)
+	blocks := []slack.Block{
+		slack.NewSectionBlock(slack.NewTextBlockObject(slack.PlainTextType, message, false, false), nil, nil),
+		slack.NewActionBlock("nodeSettings", getSlackSettingsMenuButtons(settings)...),
+	}
+	_, _, err := getSlackClient().PostMessage(channel, slack.MsgOptionText(message, false), slack.MsgOptionBlocks(blocks...))
+	if err != nil {
+		log.Error().Err(err).Msgf("Slack bot Send failed: %v", message)
+	}
+}
+
+// getSlackSettingsMenuButtons has the same buttons as the Telegram settings menu (see getNodeSettingsMenuMarkup)
+func getSlackSettingsMenuButtons(settings map[CommunicationType]bool) []slack.BlockElement {
+	var buttons []slack.BlockElement
+	settingsMenuButtons := getSettingsMenuButtons()
+	for _, communicationType := range GetCommunicationTypes() {
+		settingsMenuButton := settingsMenuButtons[communicationType]
+		text := settingsMenuButton.activateText
+		actionId := settingsMenuButton.activateButton
+		if settings[communicationType] {
+			text = settingsMenuButton.deactivateText
+			actionId = settingsMenuButton.deactivateButton
+		}
+		buttons = append(buttons, slack.NewButtonBlockElement(actionId, actionId,
+			slack.NewTextBlockObject(slack.PlainTextType, text, true, false)))
+	}
+	return buttons
+}
+
+func sendSlackApprovalRequest(channel string, message string, approvalProposalId int) {
+	log.Debug().Msgf("Sending out slack approval request to %v: %v", channel, message)
+	blocks := []slack.Block{
+		slack.NewSectionBlock(slack.NewTextBlockObject(slack.PlainTextType, message, false, false), nil, nil),
+		slack.NewActionBlock(fmt.Sprintf("approvalProposal%v", approvalProposalId),
+			slack.NewButtonBlockElement(ApproveProposalButton, strconv.Itoa(approvalProposalId),
+				slack.NewTextBlockObject(slack.PlainTextType, approveProposalText, true, false)).
+				WithStyle(slack.StylePrimary),
+			slack.NewButtonBlockElement(RejectProposalButton, strconv.Itoa(approvalProposalId),
+				slack.NewTextBlockObject(slack.PlainTextType, rejectProposalText, true, false)).
+				WithStyle(slack.StyleDanger),
+		),
+	}
+	_, _, err := getSlackClient().PostMessage(channel, slack.MsgOptionText(message, false), slack.MsgOptionBlocks(blocks...))
+	if err != nil {
+		log.Error().Err(err).Msgf("Slack bot Send failed: %v", message)
+	}
+}
+
 func extractCommand(eventText string) string {
 	for _, button := range getButtons() {
 		if strings.Contains(eventText, "/"+button) || strings.Contains(eventText, " "+button) {
@@ -3,9 +3,11 @@ package communications
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"strings"
 	"sync"
 
+	"github.com/cockroachdb/errors"
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 	"github.com/jmoiron/sqlx"
 	"github.com/rs/zerolog/log"
@@ -36,9 +38,28 @@ const (
 	registerText = "register ⚡️"
 	settingsText = "settings ⚙️"
 
-	deactivateNodeDetailText = "Node details 🛑"
+	deactivateNodeDetailText               = "Node details 🛑"
+	deactivateChannelOpenedText            = "Channel opened 🛑"
+	deactivateChannelClosedText            = "Channel closed 🛑"
+	deactivateChannelForceClosedText       = "Channel force closed 🛑"
+	deactivatePeerDisconnectedText         = "Peer disconnected 🛑"
+	deactivatePaymentFailedText            = "Large payment failed 🛑"
+	deactivateHtlcFailureBurstText         = "HTLC failure burst 🛑"
+	deactivateRebalanceSucceededText       = "Rebalance succeeded 🛑"
+	deactivateRebalanceBudgetExhaustedText = "Rebalance budget exhausted 🛑"
 
-	activateNodeDetailText = "Node details 🟢"
+	activateNodeDetailText               = "Node details 🟢"
+	activateChannelOpenedText            = "Channel opened 🟢"
+	activateChannelClosedText            = "Channel closed 🟢"
+	activateChannelForceClosedText       = "Channel force closed 🟢"
+	activatePeerDisconnectedText         = "Peer disconnected 🟢"
+	activatePaymentFailedText            = "Large payment failed 🟢"
+	activateHtlcFailureBurstText         = "HTLC failure burst 🟢"
+	activateRebalanceSucceededText       = "Rebalance succeeded 🟢"
+	activateRebalanceBudgetExhaustedText = "Rebalance budget exhausted 🟢"
+
+	approveProposalText = "Approve ✅"
+	rejectProposalText  = "Reject 🛑"
 
 	SupportLink = "https://t.me/joinchat/V-Dks6zjBK4xZWY0"
 	SupportText = "LN.capital telegram channel"
@@ -173,37 +194,70 @@ func getTelegramBot(targetType CommunicationTargetType) (*Telegram, error) {
 	return nil, nil
 }
 
-func SendTelegramBotMessages(botMessage MessageForBot, targetType CommunicationTargetType) {
+// sendTelegramBotMessage sends the message (without the menus) and returns the error of the Telegram API
+// so the notifier can retry
+func sendTelegramBotMessage(botMessage MessageForBot, targetType CommunicationTargetType) error {
 	telegram, err := getTelegramBot(targetType)
 	if err != nil {
-		log.Error().Err(err).Msgf("Telegram bot connection failed")
-		return
+		return errors.Wrap(err, "Obtaining telegram bot")
+	}
+	if telegram == nil || telegram.bot == nil {
+		return newPermanentError(errors.New(fmt.Sprintf("Telegram bot is not configured for targetType: %v", targetType)))
+	}
+	msg := getTelegramMessage(botMessage)
+	log.Info().Msgf("Sending out telegram message to %v: %v", botMessage.Telegram.Id, msg.Text)
+	_, err = telegram.bot.Send(msg)
+	if err != nil {
+		var telegramError *tgbotapi.Error
+		// 400 (i.e. chat not found) and 403 (i.e. bot was blocked) will not be solved by retrying
+		if errors.As(err, &telegramError) && (telegramError.Code == 400 || telegramError.Code == 403) {
+			return newPermanentError(errors.Wrapf(err, "Sending telegram message to: %v", botMessage.Telegram.Id))
+		}
+		return errors.Wrapf(err, "Sending telegram message to: %v", botMessage.Telegram.Id)
+	}
+	return nil
+}
+
+func getTelegramMessage(botMessage MessageForBot) tgbotapi.MessageConfig {
+	msg := tgbotapi.NewMessage(botMessage.Telegram.Id, "")
+	msg.Text = escapeTelegramMessage(botMessage)
+	msg.ParseMode = tgbotapi.ModeMarkdownV2
+	if botMessage.Telegram.ParseMode != "" {
+		msg.ParseMode = botMessage.Telegram.ParseMode
+	}
+	if botMessage.Telegram.ReplyToMessageId != 0 {
+		msg.ReplyToMessageID = botMessage.Telegram.ReplyToMessageId
 	}
+	if botMessage.Telegram.ReplyMarkup != nil {
+		msg.ReplyMarkup = *botMessage.Telegram.ReplyMarkup
+	}
+	return msg
+}
+
+func escapeTelegramMessage(botMessage MessageForBot) string {
+	message := botMessage.Message
 	if botMessage.Telegram.ParseMode == "" || botMessage.Telegram.ParseMode == tgbotapi.ModeMarkdownV2 {
 		escapes := []string{"_", "*", "[", "]", "(", ")", "~", "`", ">", "#", "+", "-", "=", "|", "{", "}", ".", "!"}
 		for _, e := range escapes {
-			botMessage.Message = strings.ReplaceAll(botMessage.Message, e, "\\"+e)
+			message = strings.ReplaceAll(message, e, "\\"+e)
 		}
 	}
-	log.Info().Msgf("Sending out telegram message to %v: %v", botMessage.Telegram.Id, botMessage.Message)
+	return message
+}
+
+func SendTelegramBotMessages(botMessage MessageForBot, targetType CommunicationTargetType) {
+	telegram, err := getTelegramBot(targetType)
+	if err != nil {
+		log.Error().Err(err).Msgf("Telegram bot connection failed")
+		return
+	}
 	if botMessage.HasMessage() {
-		msg := tgbotapi.NewMessage(botMessage.Telegram.Id, "")
-		msg.Text = botMessage.Message
-		msg.ParseMode = tgbotapi.ModeMarkdownV2
-		if botMessage.Telegram.ParseMode != "" {
-			msg.ParseMode = botMessage.Telegram.ParseMode
-		}
-		if botMessage.Telegram.ReplyToMessageId != 0 {
-			msg.ReplyToMessageID = botMessage.Telegram.ReplyToMessageId
-		}
-		if botMessage.Telegram.ReplyMarkup != nil {
-			msg.ReplyMarkup = *botMessage.Telegram.ReplyMarkup
-		}
-		_, err = telegram.bot.Send(msg)
+		err = sendTelegramBotMessage(botMessage, targetType)
 		if err != nil {
-			log.Error().Err(err).Msgf("Telegram bot Send failed: %v", msg.Text)
+			log.Error().Err(err).Msgf("Telegram bot Send failed: %v", botMessage.Message)
 		}
 	}
+	botMessage.Message = escapeTelegramMessage(botMessage)
 	if botMessage.HasMenu() {
 		for _, menu := range botMessage.Menus {
 			telegramMenu, exists := getMenus()[menu]
@@ -271,8 +325,21 @@ func handleUpdate(db *sqlx.DB, update tgbotapi.Update, communicationTargetType C
 		}
 		var command string
 		text := update.CallbackQuery.Data
+		if approvalProposalId, approve, ok := parseApprovalButton(text); ok {
+			var chatUserId string
+			if update.CallbackQuery.From != nil {
+				chatUserId = strconv.FormatInt(update.CallbackQuery.From.ID, 10)
+			}
+			handleApprovalButton(db, messageForBot, approvalProposalId, approve, chatUserId, communicationTargetType)
+			break
+		}
+		// The settings menu buttons (i.e. nodeDetailsActivate) carry the setting as callback data
+		if GetCommunicationType(text) != nil {
+			HandleButton(db, messageForBot, SettingsButton, text, communicationTargetType)
+			break
+		}
 		for _, button := range getButtons() {
-			if strings.Contains(text, "/"+button) || strings.Contains(text, " "+button) {
+			if text == button || strings.Contains(text, "/"+button) || strings.Contains(text, " "+button) {
 				command = button
 				index := strings.LastIndex(text, button) + len(button)
 				if index < len(text) {
@@ -310,7 +377,7 @@ func SendNodeSettingsMenu(db *sqlx.DB,
 		specifiedNodeId := cache.GetPeerNodeIdByPublicKey(publicKey, core.Bitcoin, core.MainNet)
 		nodeIds, err := GetNodeIdsByCommunication(db, communicationTargetType)
 		if err != nil {
-			log.Error().Err(err).Msg("Telegram bot failed to obtain existing nodeId")
+			log.Error().Err(err).Msg("Bot failed to obtain existing nodeId")
 		}
 		var nodeId int
 		if slices.Contains(nodeIds, specifiedNodeId) {
@@ -318,12 +385,12 @@ func SendNodeSettingsMenu(db *sqlx.DB,
 		}
 		if nodeId == 0 {
 			messageForBot.Message = fmt.Sprintf("Public key: %v is not registered", publicKey)
-			SendTelegramBotMessages(messageForBot, communicationTargetType)
+			sendBotReply(messageForBot, communicationTargetType)
 			return
 		}
 		communicationIds, err := GetCommunicationIdsByNodeId(db, nodeId, communicationTargetType)
 		if err != nil {
-			log.Error().Err(err).Msg("Telegram bot failed to obtain existing nodeId")
+			log.Error().Err(err).Msg("Bot failed to obtain existing nodeId")
 		}
 		if len(communicationIds) != 0 {
 			communicationId = communicationIds[0]
@@ -331,7 +398,7 @@ func SendNodeSettingsMenu(db *sqlx.DB,
 	}
 	communicationIds, err := GetCommunicationIdsByCommunicationTargetType(db, communicationTargetType)
 	if err != nil {
-		log.Error().Err(err).Msg("Telegram bot failed to obtain existing nodeId")
+		log.Error().Err(err).Msg("Bot failed to obtain existing nodeId")
 	}
 	if len(communicationIds) != 0 {
 		communicationId = communicationIds[0]
@@ -339,28 +406,79 @@ func SendNodeSettingsMenu(db *sqlx.DB,
 
 	if communicationId == 0 {
 		messageForBot.Message = "/register > Node Registration"
-		SendTelegramBotMessages(messageForBot, communicationTargetType)
+		sendBotReply(messageForBot, communicationTargetType)
 		return
 	}
 	settings, err := GetCommunicationSettings(db, communicationId)
 	if err != nil {
-		log.Error().Err(err).Msg("Telegram bot failed to obtain existing settings")
+		log.Error().Err(err).Msg("Bot failed to obtain existing settings")
+	}
+	if messageForBot.IsSlack() {
+		sendSlackSettingsMenu(messageForBot.Slack.Channel, publicKeyMsg, settings)
+		return
 	}
 	messageForBot.Message = publicKeyMsg
-	markup := getNodeSettingsMenuMarkup(settings[NodeDetailsChanged])
+	markup := getNodeSettingsMenuMarkup(settings)
 	messageForBot.Telegram.ReplyMarkup = &markup
 	messageForBot.Telegram.ParseMode = tgbotapi.ModeHTML
 	SendTelegramBotMessages(messageForBot, communicationTargetType)
 }
 
-func getNodeSettingsMenuMarkup(nodeDetailsActive bool) tgbotapi.InlineKeyboardMarkup {
+type settingsMenuButton struct {
+	activateText     string
+	activateButton   string
+	deactivateText   string
+	deactivateButton string
+}
+
+func getSettingsMenuButtons() map[CommunicationType]settingsMenuButton {
+	return map[CommunicationType]settingsMenuButton{
+		NodeDetailsChanged: {activateNodeDetailText, ActivateNodeDetailButton,
+			deactivateNodeDetailText, DeactivateNodeDetailButton},
+		ChannelOpened: {activateChannelOpenedText, ActivateChannelOpenedButton,
+			deactivateChannelOpenedText, DeactivateChannelOpenedButton},
+		ChannelClosed: {activateChannelClosedText, ActivateChannelClosedButton,
+			deactivateChannelClosedText, DeactivateChannelClosedButton},
+		ChannelForceClosed: {activateChannelForceClosedText, ActivateChannelForceClosedButton,
+			deactivateChannelForceClosedText, DeactivateChannelForceClosedButton},
+		PeerDisconnected: {activatePeerDisconnectedText, ActivatePeerDisconnectedButton,
+			deactivatePeerDisconnectedText, DeactivatePeerDisconnectedButton},
+		PaymentFailed: {activatePaymentFailedText, ActivatePaymentFailedButton,
+			deactivatePaymentFailedText, DeactivatePaymentFailedButton},
+		HtlcFailureBurst: {activateHtlcFailureBurstText, ActivateHtlcFailureBurstButton,
+			deactivateHtlcFailureBurstText, DeactivateHtlcFailureBurstButton},
+		RebalanceSucceeded: {activateRebalanceSucceededText, ActivateRebalanceSucceededButton,
+			deactivateRebalanceSucceededText, DeactivateRebalanceSucceededButton},
+		RebalanceBudgetExhausted: {activateRebalanceBudgetExhaustedText, ActivateRebalanceBudgetExhaustedButton,
+			deactivateRebalanceBudgetExhaustedText, DeactivateRebalanceBudgetExhaustedButton},
+	}
+}
+
+func getNodeSettingsMenuMarkup(settings map[CommunicationType]bool) tgbotapi.InlineKeyboardMarkup {
 	var rows [][]tgbotapi.InlineKeyboardButton
-	var button tgbotapi.InlineKeyboardButton
-	if nodeDetailsActive {
-		button = tgbotapi.NewInlineKeyboardButtonData(deactivateNodeDetailText, DeactivateNodeDetailButton)
-	} else {
-		button = tgbotapi.NewInlineKeyboardButtonData(activateNodeDetailText, ActivateNodeDetailButton)
+	settingsMenuButtons := getSettingsMenuButtons()
+	for _, communicationType := range GetCommunicationTypes() {
+		settingsMenuButton := settingsMenuButtons[communicationType]
+		var button tgbotapi.InlineKeyboardButton
+		if settings[communicationType] {
+			button = tgbotapi.NewInlineKeyboardButtonData(settingsMenuButton.deactivateText,
+				settingsMenuButton.deactivateButton)
+		} else {
+			button = tgbotapi.NewInlineKeyboardButtonData(settingsMenuButton.activateText,
+				settingsMenuButton.activateButton)
+		}
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(button))
 	}
-	rows = append(rows, tgbotapi.NewInlineKeyboardRow(button))
 	return tgbotapi.NewInlineKeyboardMarkup(rows...)
 }
+
+func getApprovalMarkup(approvalProposalId int) tgbotapi.InlineKeyboardMarkup {
+	return tgbotapi.NewInlineKeyboardMarkup(
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(approveProposalText,
+				fmt.Sprintf("%v %v", ApproveProposalButton, approvalProposalId)),
+			tgbotapi.NewInlineKeyboardButtonData(rejectProposalText,
+				fmt.Sprintf("%v %v", RejectProposalButton, approvalProposalId)),
+		),
+	)
+}
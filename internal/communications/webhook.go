@@ -0,0 +1,101 @@
+package communications
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/cockroachdb/errors"
+)
+
+// WebhookSignatureHeader contains the hex encoded HMAC-SHA256 of the request body using the secret of the webhook.
+const WebhookSignatureHeader = "X-Torq-Signature"
+
+// WebhookSettings are the target settings of a CommunicationWebhook. The url is the TargetText.
+type WebhookSettings struct {
+	Secret string `json:"secret"`
+}
+
+type WebhookPayload struct {
+	CommunicationId int       `json:"communicationId"`
+	NodeId          int       `json:"nodeId"`
+	ChannelId       *int      `json:"channelId"`
+	TargetName      string    `json:"targetName"`
+	Message         string    `json:"message"`
+	CreatedOn       time.Time `json:"createdOn"`
+}
+
+type webhookSink struct{}
+
+func (webhookSink) Send(ctx context.Context, communication Communication, message string) error {
+	var settings WebhookSettings
+	err := getTargetSettings(communication, &settings)
+	if err != nil {
+		return err
+	}
+	if communication.TargetText == "" {
+		return newPermanentError(errors.New(fmt.Sprintf(
+			"Webhook url is missing for communicationId: %v", communication.CommunicationId)))
+	}
+	body, err := json.Marshal(WebhookPayload{
+		CommunicationId: communication.CommunicationId,
+		NodeId:          communication.NodeId,
+		ChannelId:       communication.ChannelId,
+		TargetName:      communication.TargetName,
+		Message:         message,
+		CreatedOn:       time.Now().UTC(),
+	})
+	if err != nil {
+		return newPermanentError(errors.Wrap(err, "Marshalling webhook payload"))
+	}
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, communication.TargetText, bytes.NewReader(body))
+	if err != nil {
+		return newPermanentError(errors.Wrapf(err, "Creating webhook request for url: %v", communication.TargetText))
+	}
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set(WebhookSignatureHeader, "sha256="+SignWebhookPayload(settings.Secret, body))
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		return errors.Wrapf(err, "Posting webhook to url: %v", communication.TargetText)
+	}
+	defer response.Body.Close()
+	_, _ = io.Copy(io.Discard, response.Body)
+	return getHttpResponseError(response, "webhook")
+}
+
+func (webhookSink) RetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaximumAttempts: 5,
+		InitialBackoff:  2 * time.Second,
+		MaximumBackoff:  30 * time.Second,
+		Timeout:         10 * time.Second,
+	}
+}
+
+// SignWebhookPayload returns the hex encoded HMAC-SHA256 so receivers can verify the payload originates from Torq.
+func SignWebhookPayload(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// getHttpResponseError returns a permanent error for client errors as retrying will not help
+// (except for 408 request timeout and 429 too many requests).
+func getHttpResponseError(response *http.Response, sinkName string) error {
+	if response.StatusCode >= 200 && response.StatusCode < 300 {
+		return nil
+	}
+	err := errors.New(fmt.Sprintf("The %v responded with status: %v", sinkName, response.Status))
+	if response.StatusCode >= 400 && response.StatusCode < 500 &&
+		response.StatusCode != http.StatusRequestTimeout && response.StatusCode != http.StatusTooManyRequests {
+		return newPermanentError(err)
+	}
+	return err
+}
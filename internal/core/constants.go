@@ -107,6 +107,15 @@ type NotificationType int
 
 const (
 	NodeDetails NotificationType = iota
+	ChannelOpenedNotification
+	ChannelClosedNotification
+	ChannelForceClosedNotification
+	PeerDisconnectedNotification
+	PaymentFailedNotification
+	HtlcFailureBurstNotification
+	RebalanceSucceededNotification
+	RebalanceBudgetExhaustedNotification
+	ApprovalRequestedNotification
 )
 
 type NodeConnectionSetting int
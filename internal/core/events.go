@@ -156,7 +156,13 @@ type ForwardEvent struct {
 
 type NotifierEvent struct {
 	EventData
+	// ChannelId when the notification concerns a channel (communications of that channel are included)
+	ChannelId *int
+	// PaymentAmountSat of a failed payment (communications with a greater minimum amount are skipped)
+	PaymentAmountSat int64
 	Notification     *string
 	NotificationType NotificationType
 	NodeGraphEvent   *NodeGraphEvent
+	// ApprovalProposalId when the notification asks to approve or reject a fund moving request
+	ApprovalProposalId *int
 }
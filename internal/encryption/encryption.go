@@ -0,0 +1,264 @@
+// Package encryption implements the envelope encryption of secrets that are stored in the database.
+//
+// Every value is encrypted with its own random data key (AES-256-GCM), the data key is encrypted (wrapped) with the
+// master key. The master key itself never touches the database. Rotating the master key only rewraps the data keys.
+package encryption
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/cockroachdb/errors"
+)
+
+const (
+	keySize   = 32
+	keyIdSize = 8
+	nonceSize = 12
+	// wrappedKeySize is the data key encrypted with the master key including the GCM tag
+	wrappedKeySize = keySize + 16
+	headerSize     = len(envelopeMagic) + keyIdSize + nonceSize + wrappedKeySize + nonceSize
+)
+
+// envelopeMagic marks an encrypted value (and the version of the format), everything else is legacy plaintext
+const envelopeMagic = "TORQENC1"
+
+var ErrUnknownKey = errors.New("The value is encrypted with an unknown master key") //nolint:gochecknoglobals
+
+type masterKey struct {
+	id  []byte
+	key []byte
+}
+
+var (
+	keysMutex    sync.RWMutex //nolint:gochecknoglobals
+	currentKey   *masterKey   //nolint:gochecknoglobals
+	previousKeys []masterKey  //nolint:gochecknoglobals
+)
+
+// ParseKey accepts a 32 byte key encoded as hex or base64
+func ParseKey(encodedKey string) ([]byte, error) {
+	encodedKey = strings.TrimSpace(encodedKey)
+	key, err := hex.DecodeString(encodedKey)
+	if err != nil {
+		key, err = base64.StdEncoding.DecodeString(encodedKey)
+		if err != nil {
+			return nil, errors.New("The master key needs to be hex or base64 encoded.")
+		}
+	}
+	if len(key) != keySize {
+		return nil, errors.New(fmt.Sprintf("The master key needs to be %v bytes long (got %v).", keySize, len(key)))
+	}
+	return key, nil
+}
+
+// ReadKeyFile reads a key from a file (i.e. a docker or kubernetes secret)
+func ReadKeyFile(path string) ([]byte, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Reading master key file: %v", path)
+	}
+	return ParseKey(string(content))
+}
+
+// GenerateKey returns a new hex encoded master key
+func GenerateKey() (string, error) {
+	key := make([]byte, keySize)
+	_, err := rand.Read(key)
+	if err != nil {
+		return "", errors.Wrap(err, "Generating random master key")
+	}
+	return hex.EncodeToString(key), nil
+}
+
+func newMasterKey(key []byte) masterKey {
+	keyHash := sha256.Sum256(key)
+	return masterKey{id: keyHash[:keyIdSize], key: key}
+}
+
+// SetKeys configures the master key used to encrypt and the previous master keys that can still decrypt.
+// Without a current key values are stored as plaintext.
+func SetKeys(current []byte, previous ...[]byte) {
+	keysMutex.Lock()
+	defer keysMutex.Unlock()
+	currentKey = nil
+	if len(current) != 0 {
+		key := newMasterKey(current)
+		currentKey = &key
+	}
+	previousKeys = nil
+	for _, previousKey := range previous {
+		previousKeys = append(previousKeys, newMasterKey(previousKey))
+	}
+}
+
+func IsEnabled() bool {
+	keysMutex.RLock()
+	defer keysMutex.RUnlock()
+	return currentKey != nil
+}
+
+func IsEncrypted(value []byte) bool {
+	return len(value) >= headerSize && bytes.HasPrefix(value, []byte(envelopeMagic))
+}
+
+// Encrypt returns the envelope of the value (the value itself when no master key is configured)
+func Encrypt(value []byte) ([]byte, error) {
+	keysMutex.RLock()
+	defer keysMutex.RUnlock()
+	if currentKey == nil || len(value) == 0 || IsEncrypted(value) {
+		return value, nil
+	}
+	dataKey := make([]byte, keySize)
+	_, err := rand.Read(dataKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "Generating random data key")
+	}
+	wrappedKey, err := seal(currentKey.key, dataKey, currentKey.id)
+	if err != nil {
+		return nil, errors.Wrap(err, "Wrapping the data key")
+	}
+	ciphertext, err := seal(dataKey, value, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "Encrypting the value")
+	}
+	envelope := make([]byte, 0, headerSize+len(ciphertext))
+	envelope = append(envelope, envelopeMagic...)
+	envelope = append(envelope, currentKey.id...)
+	envelope = append(envelope, wrappedKey...)
+	return append(envelope, ciphertext...), nil
+}
+
+// Decrypt returns the value of the envelope, legacy plaintext values are returned as is
+func Decrypt(value []byte) ([]byte, error) {
+	if !IsEncrypted(value) {
+		return value, nil
+	}
+	dataKey, ciphertext, err := unwrap(value)
+	if err != nil {
+		return nil, err
+	}
+	plaintext, err := open(dataKey, ciphertext, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "Decrypting the value")
+	}
+	return plaintext, nil
+}
+
+// NeedsRotation returns true when the value is plaintext or wrapped with another than the current master key
+func NeedsRotation(value []byte) bool {
+	keysMutex.RLock()
+	defer keysMutex.RUnlock()
+	if currentKey == nil || len(value) == 0 {
+		return false
+	}
+	if !IsEncrypted(value) {
+		return true
+	}
+	return !bytes.Equal(getKeyId(value), currentKey.id)
+}
+
+// Rotate encrypts plaintext values and rewraps the data key of values encrypted with a previous master key
+func Rotate(value []byte) ([]byte, error) {
+	if !NeedsRotation(value) {
+		return value, nil
+	}
+	if !IsEncrypted(value) {
+		return Encrypt(value)
+	}
+	dataKey, ciphertext, err := unwrap(value)
+	if err != nil {
+		return nil, err
+	}
+	keysMutex.RLock()
+	defer keysMutex.RUnlock()
+	wrappedKey, err := seal(currentKey.key, dataKey, currentKey.id)
+	if err != nil {
+		return nil, errors.Wrap(err, "Rewrapping the data key")
+	}
+	envelope := make([]byte, 0, len(value))
+	envelope = append(envelope, envelopeMagic...)
+	envelope = append(envelope, currentKey.id...)
+	envelope = append(envelope, wrappedKey...)
+	return append(envelope, ciphertext...), nil
+}
+
+func getKeyId(envelope []byte) []byte {
+	return envelope[len(envelopeMagic) : len(envelopeMagic)+keyIdSize]
+}
+
+// unwrap returns the data key and the encrypted value (nonce included)
+func unwrap(envelope []byte) ([]byte, []byte, error) {
+	keyId := getKeyId(envelope)
+	keysMutex.RLock()
+	var key *masterKey
+	if currentKey != nil && bytes.Equal(currentKey.id, keyId) {
+		key = currentKey
+	}
+	for index := range previousKeys {
+		if key == nil && bytes.Equal(previousKeys[index].id, keyId) {
+			key = &previousKeys[index]
+		}
+	}
+	keysMutex.RUnlock()
+	if key == nil {
+		return nil, nil, errors.Wrapf(ErrUnknownKey, "key id: %x", keyId)
+	}
+	wrappedKeyStart := len(envelopeMagic) + keyIdSize
+	ciphertextStart := wrappedKeyStart + nonceSize + wrappedKeySize
+	dataKey, err := open(key.key, envelope[wrappedKeyStart:ciphertextStart], keyId)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "Unwrapping the data key")
+	}
+	return dataKey, envelope[ciphertextStart:], nil
+}
+
+// seal returns the nonce followed by the AES-256-GCM ciphertext
+func seal(key []byte, plaintext []byte, additionalData []byte) ([]byte, error) {
+	gcm, err := newGcm(key)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, nonceSize)
+	_, err = rand.Read(nonce)
+	if err != nil {
+		return nil, errors.Wrap(err, "Generating random nonce")
+	}
+	return gcm.Seal(nonce, nonce, plaintext, additionalData), nil
+}
+
+func open(key []byte, sealed []byte, additionalData []byte) ([]byte, error) {
+	if len(sealed) < nonceSize {
+		return nil, errors.New("The encrypted value is truncated.")
+	}
+	gcm, err := newGcm(key)
+	if err != nil {
+		return nil, err
+	}
+	plaintext, err := gcm.Open(nil, sealed[:nonceSize], sealed[nonceSize:], additionalData)
+	if err != nil {
+		return nil, errors.Wrap(err, "Authenticating the encrypted value")
+	}
+	return plaintext, nil
+}
+
+func newGcm(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, errors.Wrap(err, "Creating AES cipher")
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, errors.Wrap(err, "Creating GCM")
+	}
+	return gcm, nil
+}
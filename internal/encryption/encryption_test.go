@@ -0,0 +1,111 @@
+package encryption
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/cockroachdb/errors"
+
+	"github.com/lncapital/torq/testutil"
+)
+
+func generateTestKey(t *testing.T) []byte {
+	encodedKey, err := GenerateKey()
+	if err != nil {
+		testutil.Fatalf(t, "GenerateKey: %v", err)
+	}
+	key, err := ParseKey(encodedKey)
+	if err != nil {
+		testutil.Fatalf(t, "ParseKey: %v", err)
+	}
+	return key
+}
+
+func TestEncryptDecrypt(t *testing.T) {
+	macaroon := []byte("0201036c6e6402f801030a10")
+	oldKey := generateTestKey(t)
+	newKey := generateTestKey(t)
+	defer SetKeys(nil)
+
+	SetKeys(nil)
+	plaintext, err := Encrypt(macaroon)
+	if err != nil {
+		testutil.Fatalf(t, "Encrypt without key: %v", err)
+	}
+	if !bytes.Equal(plaintext, macaroon) || NeedsRotation(plaintext) {
+		testutil.Errorf(t, "Without a key the value should be stored as plaintext")
+	}
+
+	SetKeys(oldKey)
+	if !NeedsRotation(plaintext) {
+		testutil.Errorf(t, "Plaintext values should be encrypted when a key is configured")
+	}
+	encrypted, err := Encrypt(macaroon)
+	if err != nil {
+		testutil.Fatalf(t, "Encrypt: %v", err)
+	}
+	if !IsEncrypted(encrypted) || bytes.Contains(encrypted, macaroon) {
+		testutil.Fatalf(t, "The value is not encrypted")
+	}
+	decrypted, err := Decrypt(encrypted)
+	if err != nil || !bytes.Equal(decrypted, macaroon) {
+		testutil.Fatalf(t, "Decrypt: %v", err)
+	}
+	legacy, err := Decrypt(macaroon)
+	if err != nil || !bytes.Equal(legacy, macaroon) {
+		testutil.Errorf(t, "Plaintext values should be returned as is: %v", err)
+	}
+
+	SetKeys(newKey)
+	_, err = Decrypt(encrypted)
+	if !errors.Is(err, ErrUnknownKey) {
+		testutil.Errorf(t, "Decrypt with unknown key should fail with ErrUnknownKey, got: %v", err)
+	}
+
+	SetKeys(newKey, oldKey)
+	if !NeedsRotation(encrypted) {
+		testutil.Fatalf(t, "Values encrypted with a previous key need rotation")
+	}
+	rotated, err := Rotate(encrypted)
+	if err != nil {
+		testutil.Fatalf(t, "Rotate: %v", err)
+	}
+	if NeedsRotation(rotated) {
+		testutil.Errorf(t, "Rotated value still needs rotation")
+	}
+
+	SetKeys(newKey)
+	decrypted, err = Decrypt(rotated)
+	if err != nil || !bytes.Equal(decrypted, macaroon) {
+		testutil.Errorf(t, "Decrypt rotated value without previous key: %v", err)
+	}
+
+	tampered := append([]byte{}, rotated...)
+	tampered[len(tampered)-1] ^= 0xff
+	_, err = Decrypt(tampered)
+	if err == nil {
+		testutil.Errorf(t, "Decrypt of a tampered value should fail")
+	}
+	testutil.Successf(t, "Envelope encryption, rotation and legacy plaintext work")
+}
+
+func TestParseKey(t *testing.T) {
+	testCases := []struct {
+		name    string
+		key     string
+		wantErr bool
+	}{
+		{name: "hex", key: "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f"},
+		{name: "base64", key: "AAECAwQFBgcICQoLDA0ODxAREhMUFRYXGBkaGxwdHh8=\n"},
+		{name: "too short", key: "000102030405060708090a0b0c0d0e0f", wantErr: true},
+		{name: "invalid", key: "not a key", wantErr: true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := ParseKey(tc.key)
+			if (err != nil) != tc.wantErr {
+				testutil.Errorf(t, "ParseKey(%v) error = %v, wantErr %v", tc.key, err, tc.wantErr)
+			}
+		})
+	}
+}
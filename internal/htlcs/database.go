@@ -0,0 +1,116 @@
+package htlcs
+
+import (
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+
+	"github.com/lncapital/torq/internal/cache"
+	"github.com/lncapital/torq/internal/database"
+)
+
+// resolvedForwardHtlcs holds one row per resolved forward HTLC.
+// LND reports a ForwardEvent followed by a SettleEvent or ForwardFailEvent (without amounts) or only a LinkFailEvent.
+// CLN only reports the resolution (with amounts but without a failure reason).
+// $1 = from, $2 = to, $3 = time zone, $4 = nodeIds
+const resolvedForwardHtlcs = `
+	WITH htlc AS (
+		SELECT he.node_id,
+			he.incoming_channel_id,
+			he.outgoing_channel_id,
+			he.event_type IN ('ForwardFailEvent', 'LinkFailEvent') AS failed,
+			COALESCE(he.outgoing_amt_msat, fe.outgoing_amt_msat, he.incoming_amt_msat, fe.incoming_amt_msat) AS amount_msat,
+			COALESCE(NULLIF(NULLIF(he.lnd_failure_detail, 'NO_DETAIL'), 'UNKNOWN'), he.bolt_failure_code, he.event_type) AS reason
+		FROM htlc_event he
+		LEFT JOIN htlc_event fe ON fe.event_type = 'ForwardEvent'
+			AND he.outgoing_amt_msat IS NULL
+			AND fe.node_id = he.node_id
+			AND fe.incoming_channel_id = he.incoming_channel_id
+			AND fe.incoming_htlc_id = he.incoming_htlc_id
+			AND fe.outgoing_channel_id = he.outgoing_channel_id
+			AND fe.outgoing_htlc_id = he.outgoing_htlc_id
+			AND fe.time <= he.time
+			AND fe.time > he.time - INTERVAL '1 day'
+		WHERE he.event_origin = 'FORWARD'
+			AND he.event_type <> 'ForwardEvent'
+			AND he.node_id = ANY($4)
+			AND he.time::timestamp AT TIME ZONE $3 >= $1::timestamp AT TIME ZONE $3
+			AND he.time::timestamp AT TIME ZONE $3 <= $2::timestamp AT TIME ZONE $3
+	)`
+
+func getChannelPairs(db *sqlx.DB, nodeIds []int, from time.Time, to time.Time) ([]ChannelPair, error) {
+	var channelPairs []ChannelPair
+	err := db.Select(&channelPairs, resolvedForwardHtlcs+`
+		SELECT node_id, incoming_channel_id, outgoing_channel_id,
+			COUNT(*) AS attempts,
+			COUNT(*) FILTER (WHERE failed) AS failures,
+			COALESCE(SUM(amount_msat) FILTER (WHERE failed), 0)::bigint AS failed_amount_msat
+		FROM htlc
+		GROUP BY node_id, incoming_channel_id, outgoing_channel_id
+		HAVING COUNT(*) FILTER (WHERE failed) > 0
+		ORDER BY failures DESC, failed_amount_msat DESC;`,
+		from, to, cache.GetSettings().PreferredTimeZone, pq.Array(nodeIds))
+	if err != nil {
+		return nil, errors.Wrap(err, database.SqlExecutionError)
+	}
+	return channelPairs, nil
+}
+
+func getFailureReasons(db *sqlx.DB, nodeIds []int, from time.Time, to time.Time) ([]FailureReason, error) {
+	var failureReasons []FailureReason
+	err := db.Select(&failureReasons, resolvedForwardHtlcs+`
+		SELECT reason,
+			COUNT(*) AS failures,
+			COALESCE(SUM(amount_msat), 0)::bigint AS failed_amount_msat
+		FROM htlc
+		WHERE failed
+		GROUP BY reason
+		ORDER BY failures DESC;`,
+		from, to, cache.GetSettings().PreferredTimeZone, pq.Array(nodeIds))
+	if err != nil {
+		return nil, errors.Wrap(err, database.SqlExecutionError)
+	}
+	return failureReasons, nil
+}
+
+// getAmountBucketCounts returns the counts by index of width_bucket (0 is below the first boundary)
+func getAmountBucketCounts(db *sqlx.DB, nodeIds []int, from time.Time, to time.Time) ([]amountBucketCount, error) {
+	var amountBucketCounts []amountBucketCount
+	err := db.Select(&amountBucketCounts, resolvedForwardHtlcs+`
+		SELECT width_bucket(amount_msat / 1000, $5::numeric[]) AS bucket,
+			COUNT(*) AS attempts,
+			COUNT(*) FILTER (WHERE failed) AS failures,
+			COALESCE(SUM(amount_msat) FILTER (WHERE failed), 0)::bigint AS failed_amount_msat
+		FROM htlc
+		WHERE amount_msat IS NOT NULL
+		GROUP BY bucket
+		ORDER BY bucket;`,
+		from, to, cache.GetSettings().PreferredTimeZone, pq.Array(nodeIds), pq.Array(amountBucketBoundariesSat))
+	if err != nil {
+		return nil, errors.Wrap(err, database.SqlExecutionError)
+	}
+	return amountBucketCounts, nil
+}
+
+func getLiquidityFailures(db *sqlx.DB, nodeIds []int, from time.Time, to time.Time) ([]LiquidityFailure, error) {
+	var liquidityFailures []LiquidityFailure
+	err := db.Select(&liquidityFailures, resolvedForwardHtlcs+`
+		SELECT node_id, outgoing_channel_id AS channel_id,
+			COUNT(*) FILTER (WHERE reason = $5) AS insufficient_balance_failures,
+			COALESCE(SUM(amount_msat) FILTER (WHERE reason = $5), 0)::bigint AS insufficient_balance_amount_msat,
+			COUNT(*) FILTER (WHERE reason = $6) AS exceeds_max_htlc_failures,
+			COALESCE(SUM(amount_msat) FILTER (WHERE reason = $6), 0)::bigint AS exceeds_max_htlc_amount_msat,
+			COALESCE(MAX(amount_msat) FILTER (WHERE reason = $6), 0)::bigint AS largest_exceeds_max_htlc_amount_msat
+		FROM htlc
+		WHERE failed AND outgoing_channel_id IS NOT NULL AND reason IN ($5, $6)
+		GROUP BY node_id, outgoing_channel_id
+		ORDER BY insufficient_balance_amount_msat + exceeds_max_htlc_amount_msat DESC;`,
+		from, to, cache.GetSettings().PreferredTimeZone, pq.Array(nodeIds),
+		insufficientBalanceReason, exceedsMaxHtlcReason)
+	if err != nil {
+		return nil, errors.Wrap(err, database.SqlExecutionError)
+	}
+	return liquidityFailures, nil
+}
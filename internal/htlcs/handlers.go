@@ -0,0 +1,214 @@
+package htlcs
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/lncapital/torq/internal/cache"
+	"github.com/lncapital/torq/internal/core"
+	"github.com/lncapital/torq/pkg/server_errors"
+)
+
+const (
+	insufficientBalanceReason = "INSUFFICIENT_BALANCE"
+	exceedsMaxHtlcReason      = "HTLC_EXCEEDS_MAX"
+
+	rebalanceRecommendation    = "rebalance"
+	raiseMaxHtlcRecommendation = "raiseMaxHtlc"
+)
+
+// amountBucketBoundariesSat are the (outgoing) amount boundaries of the amount buckets
+var amountBucketBoundariesSat = []int64{10_000, 100_000, 1_000_000, 5_000_000} //nolint:gochecknoglobals
+
+type HtlcAnalytics struct {
+	ChannelPairs      []ChannelPair      `json:"channelPairs"`
+	FailureReasons    []FailureReason    `json:"failureReasons"`
+	AmountBuckets     []AmountBucket     `json:"amountBuckets"`
+	LiquidityFailures []LiquidityFailure `json:"liquidityFailures"`
+}
+
+type ChannelPair struct {
+	NodeId                 int     `json:"nodeId" db:"node_id"`
+	IncomingChannelId      *int    `json:"incomingChannelId" db:"incoming_channel_id"`
+	IncomingShortChannelId *string `json:"incomingShortChannelId"`
+	IncomingPeerAlias      string  `json:"incomingPeerAlias"`
+	OutgoingChannelId      *int    `json:"outgoingChannelId" db:"outgoing_channel_id"`
+	OutgoingShortChannelId *string `json:"outgoingShortChannelId"`
+	OutgoingPeerAlias      string  `json:"outgoingPeerAlias"`
+	Attempts               int64   `json:"attempts" db:"attempts"`
+	Failures               int64   `json:"failures" db:"failures"`
+	FailedAmountMsat       int64   `json:"failedAmountMsat" db:"failed_amount_msat"`
+}
+
+// FailureReason is the LND failure detail, or the BOLT failure code when there is no detail.
+// CLN does not expose the failure reason so those failures are reported by event type.
+type FailureReason struct {
+	Reason           string `json:"reason" db:"reason"`
+	Failures         int64  `json:"failures" db:"failures"`
+	FailedAmountMsat int64  `json:"failedAmountMsat" db:"failed_amount_msat"`
+}
+
+// AmountBucket holds the HTLCs with fromSat <= amount < toSat, the last bucket has no toSat
+type AmountBucket struct {
+	FromSat          int64  `json:"fromSat"`
+	ToSat            *int64 `json:"toSat"`
+	Attempts         int64  `json:"attempts"`
+	Failures         int64  `json:"failures"`
+	FailedAmountMsat int64  `json:"failedAmountMsat"`
+}
+
+type amountBucketCount struct {
+	Bucket           int   `db:"bucket"`
+	Attempts         int64 `db:"attempts"`
+	Failures         int64 `db:"failures"`
+	FailedAmountMsat int64 `db:"failed_amount_msat"`
+}
+
+// LiquidityFailure are the forwards lost on an outgoing channel because of
+// insufficient outbound liquidity or the max_htlc limit.
+type LiquidityFailure struct {
+	NodeId                          int      `json:"nodeId" db:"node_id"`
+	ChannelId                       int      `json:"channelId" db:"channel_id"`
+	ShortChannelId                  *string  `json:"shortChannelId"`
+	PeerAlias                       string   `json:"peerAlias"`
+	InsufficientBalanceFailures     int64    `json:"insufficientBalanceFailures" db:"insufficient_balance_failures"`
+	InsufficientBalanceAmountMsat   int64    `json:"insufficientBalanceAmountMsat" db:"insufficient_balance_amount_msat"`
+	ExceedsMaxHtlcFailures          int64    `json:"exceedsMaxHtlcFailures" db:"exceeds_max_htlc_failures"`
+	ExceedsMaxHtlcAmountMsat        int64    `json:"exceedsMaxHtlcAmountMsat" db:"exceeds_max_htlc_amount_msat"`
+	LargestExceedsMaxHtlcAmountMsat int64    `json:"largestExceedsMaxHtlcAmountMsat" db:"largest_exceeds_max_htlc_amount_msat"`
+	Capacity                        int64    `json:"capacity"`
+	LocalBalance                    *int64   `json:"localBalance"`
+	LocalMaxHtlcMsat                *uint64  `json:"localMaxHtlcMsat"`
+	Recommendations                 []string `json:"recommendations"`
+}
+
+func getHtlcAnalyticsHandler(c *gin.Context, db *sqlx.DB) {
+	from, err := time.Parse("2006-01-02", c.Query("from"))
+	if err != nil {
+		server_errors.LogAndSendServerError(c, err)
+		return
+	}
+	to, err := time.Parse("2006-01-02", c.Query("to"))
+	if err != nil {
+		server_errors.LogAndSendServerError(c, err)
+		return
+	}
+	if c.Query("network") == "" {
+		server_errors.SendBadRequest(c, "Network missing")
+		return
+	}
+	network, err := strconv.Atoi(c.Query("network"))
+	if err != nil {
+		server_errors.SendBadRequest(c, "Can't process network")
+		return
+	}
+
+	r, err := getHtlcAnalytics(db, cache.GetAllTorqNodeIdsByNetwork(core.Bitcoin, core.Network(network)), from, to)
+	if err != nil {
+		server_errors.LogAndSendServerError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, r)
+}
+
+func getHtlcAnalytics(db *sqlx.DB, nodeIds []int, from time.Time, to time.Time) (HtlcAnalytics, error) {
+	channelPairs, err := getChannelPairs(db, nodeIds, from, to)
+	if err != nil {
+		return HtlcAnalytics{}, err
+	}
+	for i := range channelPairs {
+		channelPairs[i].IncomingShortChannelId, channelPairs[i].IncomingPeerAlias =
+			getChannelDetails(channelPairs[i].NodeId, channelPairs[i].IncomingChannelId)
+		channelPairs[i].OutgoingShortChannelId, channelPairs[i].OutgoingPeerAlias =
+			getChannelDetails(channelPairs[i].NodeId, channelPairs[i].OutgoingChannelId)
+	}
+
+	failureReasons, err := getFailureReasons(db, nodeIds, from, to)
+	if err != nil {
+		return HtlcAnalytics{}, err
+	}
+
+	amountBucketCounts, err := getAmountBucketCounts(db, nodeIds, from, to)
+	if err != nil {
+		return HtlcAnalytics{}, err
+	}
+
+	liquidityFailures, err := getLiquidityFailures(db, nodeIds, from, to)
+	if err != nil {
+		return HtlcAnalytics{}, err
+	}
+	for i := range liquidityFailures {
+		channelId := liquidityFailures[i].ChannelId
+		liquidityFailures[i].ShortChannelId, liquidityFailures[i].PeerAlias =
+			getChannelDetails(liquidityFailures[i].NodeId, &channelId)
+		liquidityFailures[i].Capacity = cache.GetChannelSettingByChannelId(channelId).Capacity
+		channelState := cache.GetChannelState(liquidityFailures[i].NodeId, channelId, false)
+		if channelState != nil {
+			liquidityFailures[i].LocalBalance = &channelState.LocalBalance
+			liquidityFailures[i].LocalMaxHtlcMsat = &channelState.LocalMaxHtlcMsat
+		}
+		liquidityFailures[i].Recommendations = getRecommendations(liquidityFailures[i])
+	}
+
+	return HtlcAnalytics{
+		ChannelPairs:      channelPairs,
+		FailureReasons:    failureReasons,
+		AmountBuckets:     getAmountBuckets(amountBucketCounts),
+		LiquidityFailures: liquidityFailures,
+	}, nil
+}
+
+// getChannelDetails returns the short channel id and the alias of the remote node of the channel
+func getChannelDetails(nodeId int, channelId *int) (*string, string) {
+	if channelId == nil {
+		return nil, ""
+	}
+	channelSettings := cache.GetChannelSettingByChannelId(*channelId)
+	remoteNodeId := channelSettings.FirstNodeId
+	if remoteNodeId == nodeId {
+		remoteNodeId = channelSettings.SecondNodeId
+	}
+	return channelSettings.ShortChannelId, cache.GetNodeAlias(remoteNodeId)
+}
+
+// getAmountBuckets returns all buckets (also the ones without HTLCs) in ascending order
+func getAmountBuckets(amountBucketCounts []amountBucketCount) []AmountBucket {
+	amountBuckets := make([]AmountBucket, len(amountBucketBoundariesSat)+1)
+	for i := range amountBuckets {
+		if i > 0 {
+			amountBuckets[i].FromSat = amountBucketBoundariesSat[i-1]
+		}
+		if i < len(amountBucketBoundariesSat) {
+			toSat := amountBucketBoundariesSat[i]
+			amountBuckets[i].ToSat = &toSat
+		}
+	}
+	for _, amountBucketCount := range amountBucketCounts {
+		if amountBucketCount.Bucket < 0 || amountBucketCount.Bucket >= len(amountBuckets) {
+			continue
+		}
+		amountBuckets[amountBucketCount.Bucket].Attempts += amountBucketCount.Attempts
+		amountBuckets[amountBucketCount.Bucket].Failures += amountBucketCount.Failures
+		amountBuckets[amountBucketCount.Bucket].FailedAmountMsat += amountBucketCount.FailedAmountMsat
+	}
+	return amountBuckets
+}
+
+// getRecommendations suggests a rebalance for insufficient outbound liquidity and
+// raising the max_htlc when it is (still) below the largest rejected HTLC.
+func getRecommendations(liquidityFailure LiquidityFailure) []string {
+	recommendations := []string{}
+	if liquidityFailure.InsufficientBalanceFailures > 0 {
+		recommendations = append(recommendations, rebalanceRecommendation)
+	}
+	if liquidityFailure.ExceedsMaxHtlcFailures > 0 &&
+		(liquidityFailure.LocalMaxHtlcMsat == nil ||
+			*liquidityFailure.LocalMaxHtlcMsat < uint64(liquidityFailure.LargestExceedsMaxHtlcAmountMsat)) {
+		recommendations = append(recommendations, raiseMaxHtlcRecommendation)
+	}
+	return recommendations
+}
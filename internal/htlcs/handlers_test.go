@@ -0,0 +1,63 @@
+package htlcs
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/lncapital/torq/testutil"
+)
+
+func TestGetAmountBuckets(t *testing.T) {
+	amountBuckets := getAmountBuckets([]amountBucketCount{
+		{Bucket: 0, Attempts: 10, Failures: 1, FailedAmountMsat: 5_000_000},
+		{Bucket: 4, Attempts: 2, Failures: 2, FailedAmountMsat: 16_000_000_000},
+	})
+	if len(amountBuckets) != len(amountBucketBoundariesSat)+1 {
+		testutil.Fatalf(t, "getAmountBuckets() returned %v buckets, want %v", len(amountBuckets), len(amountBucketBoundariesSat)+1)
+	}
+	if amountBuckets[0].FromSat != 0 || *amountBuckets[0].ToSat != 10_000 || amountBuckets[0].Attempts != 10 {
+		testutil.Errorf(t, "getAmountBuckets() first bucket = %+v", amountBuckets[0])
+	}
+	if amountBuckets[2].FromSat != 100_000 || *amountBuckets[2].ToSat != 1_000_000 || amountBuckets[2].Attempts != 0 {
+		testutil.Errorf(t, "getAmountBuckets() empty bucket = %+v", amountBuckets[2])
+	}
+	if amountBuckets[4].FromSat != 5_000_000 || amountBuckets[4].ToSat != nil || amountBuckets[4].Failures != 2 {
+		testutil.Errorf(t, "getAmountBuckets() last bucket = %+v", amountBuckets[4])
+	}
+}
+
+func TestGetRecommendations(t *testing.T) {
+	lowMaxHtlcMsat := uint64(1_000_000)
+	highMaxHtlcMsat := uint64(10_000_000)
+	testCases := []struct {
+		name             string
+		liquidityFailure LiquidityFailure
+		want             []string
+	}{
+		{name: "insufficient balance",
+			liquidityFailure: LiquidityFailure{InsufficientBalanceFailures: 3},
+			want:             []string{rebalanceRecommendation}},
+		{name: "max htlc too low",
+			liquidityFailure: LiquidityFailure{ExceedsMaxHtlcFailures: 1,
+				LargestExceedsMaxHtlcAmountMsat: 2_000_000, LocalMaxHtlcMsat: &lowMaxHtlcMsat},
+			want: []string{raiseMaxHtlcRecommendation}},
+		{name: "max htlc already raised",
+			liquidityFailure: LiquidityFailure{ExceedsMaxHtlcFailures: 1,
+				LargestExceedsMaxHtlcAmountMsat: 2_000_000, LocalMaxHtlcMsat: &highMaxHtlcMsat},
+			want: []string{}},
+		{name: "both",
+			liquidityFailure: LiquidityFailure{InsufficientBalanceFailures: 1, ExceedsMaxHtlcFailures: 1,
+				LargestExceedsMaxHtlcAmountMsat: 2_000_000},
+			want: []string{rebalanceRecommendation, raiseMaxHtlcRecommendation}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := getRecommendations(tc.liquidityFailure)
+			if !reflect.DeepEqual(got, tc.want) {
+				testutil.Errorf(t, "getRecommendations() = %v, want %v", got, tc.want)
+				return
+			}
+			testutil.Successf(t, "getRecommendations() = %v", got)
+		})
+	}
+}
@@ -0,0 +1,10 @@
+package htlcs
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/jmoiron/sqlx"
+)
+
+func RegisterHtlcsRoutes(r *gin.RouterGroup, db *sqlx.DB) {
+	r.GET("analytics", func(c *gin.Context) { getHtlcAnalyticsHandler(c, db) })
+}
@@ -0,0 +1,405 @@
+package lightning
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/jmoiron/sqlx"
+	"github.com/rs/zerolog/log"
+
+	"github.com/lncapital/torq/internal/audit"
+	"github.com/lncapital/torq/internal/auth"
+	"github.com/lncapital/torq/internal/cache"
+	"github.com/lncapital/torq/internal/core"
+	"github.com/lncapital/torq/internal/database"
+	"github.com/lncapital/torq/internal/lightning_helpers"
+)
+
+type ApprovalProposalType int
+
+const (
+	OpenChannelProposal = ApprovalProposalType(iota + 1)
+	BatchOpenChannelProposal
+	CloseChannelProposal
+	OnChainPaymentProposal
+	NewPaymentProposal
+)
+
+func (apt ApprovalProposalType) String() string {
+	switch apt {
+	case OpenChannelProposal:
+		return "open channel"
+	case BatchOpenChannelProposal:
+		return "batch open channels"
+	case CloseChannelProposal:
+		return "close channel"
+	case OnChainPaymentProposal:
+		return "on-chain payment"
+	case NewPaymentProposal:
+		return "payment"
+	}
+	return "unknown"
+}
+
+type ApprovalStatus int
+
+const (
+	ApprovalPending = ApprovalStatus(iota + 1)
+	// ApprovalApproved means the request is being executed
+	ApprovalApproved
+	ApprovalRejected
+	ApprovalExpired
+	ApprovalExecuted
+	ApprovalFailed
+)
+
+var (
+	errApprovalNotPending = errors.New("The proposal is no longer pending.")                //nolint:gochecknoglobals
+	errApprovalRequester  = errors.New("The proposal needs to be decided by another user.") //nolint:gochecknoglobals
+)
+
+type ApprovalProposal struct {
+	ApprovalProposalId       int                  `json:"approvalProposalId" db:"approval_proposal_id"`
+	ProposalType             ApprovalProposalType `json:"proposalType" db:"proposal_type"`
+	Status                   ApprovalStatus       `json:"status" db:"status"`
+	NodeId                   int                  `json:"nodeId" db:"node_id"`
+	ChannelId                *int                 `json:"channelId" db:"channel_id"`
+	AmountSat                int64                `json:"amountSat" db:"amount_sat"`
+	Request                  string               `json:"request" db:"request"`
+	RequestedBy              string               `json:"requestedBy" db:"requested_by"`
+	RequestedByUserAccountId *int                 `json:"requestedByUserAccountId" db:"requested_by_user_account_id"`
+	RequestedByApiTokenId    *int                 `json:"requestedByApiTokenId" db:"requested_by_api_token_id"`
+	DecidedBy                *string              `json:"decidedBy" db:"decided_by"`
+	DecidedByUserAccountId   *int                 `json:"decidedByUserAccountId" db:"decided_by_user_account_id"`
+	DecidedOn                *time.Time           `json:"decidedOn" db:"decided_on"`
+	Response                 *string              `json:"response" db:"response"`
+	ErrorData                *string              `json:"errorData" db:"error_data"`
+	ExpiresOn                time.Time            `json:"expiresOn" db:"expires_on"`
+	CreatedOn                time.Time            `json:"createdOn" db:"created_on"`
+	UpdatedOn                time.Time            `json:"updatedOn" db:"updated_on"`
+}
+
+type approvalSettings struct {
+	ThresholdSat  *int64 `db:"approval_threshold_sat"`
+	ExpiryMinutes int    `db:"approval_expiry_minutes"`
+}
+
+// isApprovalRequired returns true when the amount is above the threshold (no threshold disables approvals)
+func (as approvalSettings) isApprovalRequired(amountSat int64) bool {
+	return as.ThresholdSat != nil && amountSat > *as.ThresholdSat
+}
+
+func getApprovalSettings(db *sqlx.DB) (approvalSettings, error) {
+	var settings approvalSettings
+	err := db.Get(&settings, `SELECT approval_threshold_sat, approval_expiry_minutes FROM settings LIMIT 1;`)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return approvalSettings{}, nil
+		}
+		return approvalSettings{}, errors.Wrap(err, database.SqlExecutionError)
+	}
+	return settings, nil
+}
+
+func getOpenChannelAmountSat(request lightning_helpers.OpenChannelRequest) int64 {
+	return request.LocalFundingAmount
+}
+
+func getBatchOpenChannelAmountSat(request lightning_helpers.BatchOpenChannelRequest) int64 {
+	var amountSat int64
+	for _, channel := range request.Channels {
+		amountSat += channel.LocalFundingAmount
+	}
+	return amountSat
+}
+
+// getCloseChannelAmountSat returns the local balance (or the capacity when the balance is unknown)
+func getCloseChannelAmountSat(request lightning_helpers.CloseChannelRequest) int64 {
+	channelState := cache.GetChannelState(request.NodeId, request.ChannelId, true)
+	if channelState != nil {
+		return channelState.LocalBalance
+	}
+	return cache.GetChannelSettingByChannelId(request.ChannelId).Capacity
+}
+
+// getOnChainPaymentAmountSat considers sending the complete wallet always above the threshold
+func getOnChainPaymentAmountSat(request lightning_helpers.OnChainPaymentRequest) int64 {
+	if request.SendAll != nil && *request.SendAll {
+		return math.MaxInt64
+	}
+	return request.AmountSat
+}
+
+// GetNewPaymentAmountSat returns the amount of the payment (the invoice amount when no amount was provided)
+func GetNewPaymentAmountSat(request lightning_helpers.NewPaymentRequest) (int64, error) {
+	if request.AmtMSat != nil && *request.AmtMSat != 0 {
+		return *request.AmtMSat / 1000, nil
+	}
+	if request.Invoice == nil || *request.Invoice == "" {
+		return 0, nil
+	}
+	decodedInvoice, err := DecodeInvoice(lightning_helpers.DecodeInvoiceRequest{
+		CommunicationRequest: request.CommunicationRequest,
+		Invoice:              *request.Invoice,
+	})
+	if err != nil {
+		return 0, errors.Wrap(err, "Decoding invoice for the approval threshold")
+	}
+	return decodedInvoice.ValueMsat / 1000, nil
+}
+
+// ProposeWhenApprovalRequired stores the request as pending proposal when the amount is above the approval threshold.
+// The proposal is nil when the request can be executed immediately.
+func ProposeWhenApprovalRequired(db *sqlx.DB,
+	actor auth.Actor,
+	proposalType ApprovalProposalType,
+	nodeId int,
+	channelId int,
+	amountSat int64,
+	request any) (*ApprovalProposal, error) {
+
+	settings, err := getApprovalSettings(db)
+	if err != nil {
+		return nil, err
+	}
+	if !settings.isApprovalRequired(amountSat) {
+		return nil, nil
+	}
+	requestBytes, err := json.Marshal(request)
+	if err != nil {
+		return nil, errors.Wrap(err, "Marshalling the approval proposal request")
+	}
+	now := time.Now().UTC()
+	approvalProposal := ApprovalProposal{
+		ProposalType: proposalType,
+		Status:       ApprovalPending,
+		NodeId:       nodeId,
+		AmountSat:    amountSat,
+		Request:      string(requestBytes),
+		RequestedBy:  actor.Name,
+		ExpiresOn:    now.Add(time.Duration(settings.ExpiryMinutes) * time.Minute),
+		CreatedOn:    now,
+		UpdatedOn:    now,
+	}
+	if channelId != 0 {
+		approvalProposal.ChannelId = &channelId
+	}
+	// A proposal requested with an API token is linked to the user that created the token
+	if requestedByUserAccountId := actor.GetAccountableUserAccountId(); requestedByUserAccountId != 0 {
+		approvalProposal.RequestedByUserAccountId = &requestedByUserAccountId
+	}
+	if actor.ApiTokenId != 0 {
+		approvalProposal.RequestedByApiTokenId = &actor.ApiTokenId
+	}
+	err = db.QueryRowx(`
+		INSERT INTO approval_proposal (proposal_type, status, node_id, channel_id, amount_sat, request, requested_by,
+			requested_by_user_account_id, requested_by_api_token_id, expires_on, created_on, updated_on)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+		RETURNING approval_proposal_id;`,
+		approvalProposal.ProposalType, approvalProposal.Status, approvalProposal.NodeId, approvalProposal.ChannelId,
+		approvalProposal.AmountSat, approvalProposal.Request, approvalProposal.RequestedBy,
+		approvalProposal.RequestedByUserAccountId, approvalProposal.RequestedByApiTokenId,
+		approvalProposal.ExpiresOn, approvalProposal.CreatedOn, approvalProposal.UpdatedOn).
+		Scan(&approvalProposal.ApprovalProposalId)
+	if err != nil {
+		return nil, errors.Wrap(err, database.SqlExecutionError)
+	}
+
+	message := fmt.Sprintf("Approval requested by %v for %v of %v sat (proposal %v), expires on %v",
+		approvalProposal.RequestedBy, approvalProposal.ProposalType.String(), approvalProposal.AmountSat,
+		approvalProposal.ApprovalProposalId, approvalProposal.ExpiresOn.Format(time.RFC3339))
+	if amountSat == math.MaxInt64 {
+		message = fmt.Sprintf("Approval requested by %v for %v of all funds (proposal %v), expires on %v",
+			approvalProposal.RequestedBy, approvalProposal.ProposalType.String(),
+			approvalProposal.ApprovalProposalId, approvalProposal.ExpiresOn.Format(time.RFC3339))
+	}
+	cache.SendNotifierEvent(core.NotifierEvent{
+		EventData: core.EventData{
+			EventTime: now,
+			NodeId:    nodeId,
+		},
+		ChannelId:          approvalProposal.ChannelId,
+		Notification:       &message,
+		NotificationType:   core.ApprovalRequestedNotification,
+		ApprovalProposalId: &approvalProposal.ApprovalProposalId,
+	})
+	return &approvalProposal, nil
+}
+
+func GetApprovalProposals(db *sqlx.DB, status *ApprovalStatus) ([]ApprovalProposal, error) {
+	approvalProposals := []ApprovalProposal{}
+	err := db.Select(&approvalProposals, `
+		SELECT *
+		FROM approval_proposal
+		WHERE $1::INTEGER IS NULL OR status=$1
+		ORDER BY created_on DESC
+		LIMIT 500;`, status)
+	if err != nil {
+		return nil, errors.Wrap(err, database.SqlExecutionError)
+	}
+	return approvalProposals, nil
+}
+
+func GetApprovalProposal(db *sqlx.DB, approvalProposalId int) (ApprovalProposal, error) {
+	var approvalProposal ApprovalProposal
+	err := db.Get(&approvalProposal, `SELECT * FROM approval_proposal WHERE approval_proposal_id=$1;`,
+		approvalProposalId)
+	if err != nil {
+		return ApprovalProposal{}, errors.Wrap(err, database.SqlExecutionError)
+	}
+	return approvalProposal, nil
+}
+
+// isRequester returns true when the actor requested the proposal (a proposal can't be decided by the requester).
+// The user that created an API token is considered the same requester as the API token.
+func (ap ApprovalProposal) isRequester(actor auth.Actor) bool {
+	if ap.RequestedByApiTokenId != nil && *ap.RequestedByApiTokenId == actor.ApiTokenId {
+		return true
+	}
+	userAccountId := actor.GetAccountableUserAccountId()
+	if userAccountId != 0 && ap.RequestedByUserAccountId != nil {
+		return *ap.RequestedByUserAccountId == userAccountId
+	}
+	return ap.RequestedBy == actor.Name
+}
+
+// ApproveProposal executes the request of the pending proposal, the result is stored on the proposal
+func ApproveProposal(db *sqlx.DB, approvalProposalId int, approver auth.Actor) (ApprovalProposal, error) {
+	approvalProposal, err := decideApprovalProposal(db, approvalProposalId, approver, ApprovalApproved)
+	if err != nil {
+		return ApprovalProposal{}, err
+	}
+
+	response, err := executeApprovalProposal(db, approvalProposal)
+	audit.AddActorAuditLog(db, approver, "approved "+approvalProposal.ProposalType.String(),
+		approvalProposal.NodeId, getIntValue(approvalProposal.ChannelId), json.RawMessage(approvalProposal.Request), err)
+
+	approvalProposal.Status = ApprovalExecuted
+	if err != nil {
+		approvalProposal.Status = ApprovalFailed
+		errorData := err.Error()
+		approvalProposal.ErrorData = &errorData
+	}
+	if response != nil {
+		responseBytes, marshalErr := json.Marshal(response)
+		if marshalErr != nil {
+			log.Error().Err(marshalErr).Msgf("Marshalling the response of approvalProposalId: %v", approvalProposalId)
+		} else {
+			responseString := string(responseBytes)
+			approvalProposal.Response = &responseString
+		}
+	}
+	approvalProposal.UpdatedOn = time.Now().UTC()
+	_, err = db.Exec(`
+		UPDATE approval_proposal
+		SET status=$1, response=$2, error_data=$3, updated_on=$4
+		WHERE approval_proposal_id=$5;`,
+		approvalProposal.Status, approvalProposal.Response, approvalProposal.ErrorData, approvalProposal.UpdatedOn,
+		approvalProposalId)
+	if err != nil {
+		return approvalProposal, errors.Wrap(err, database.SqlExecutionError)
+	}
+	return approvalProposal, nil
+}
+
+func RejectProposal(db *sqlx.DB, approvalProposalId int, approver auth.Actor) (ApprovalProposal, error) {
+	return decideApprovalProposal(db, approvalProposalId, approver, ApprovalRejected)
+}
+
+// decideApprovalProposal moves a pending (and not expired) proposal to the new status, this can only happen once
+func decideApprovalProposal(db *sqlx.DB,
+	approvalProposalId int,
+	approver auth.Actor,
+	status ApprovalStatus) (ApprovalProposal, error) {
+
+	approvalProposal, err := GetApprovalProposal(db, approvalProposalId)
+	if err != nil {
+		return ApprovalProposal{}, err
+	}
+	if approvalProposal.isRequester(approver) {
+		return ApprovalProposal{}, errApprovalRequester
+	}
+	var decidedByUserAccountId *int
+	if approverUserAccountId := approver.GetAccountableUserAccountId(); approverUserAccountId != 0 {
+		decidedByUserAccountId = &approverUserAccountId
+	}
+	now := time.Now().UTC()
+	err = db.Get(&approvalProposal, `
+		UPDATE approval_proposal
+		SET status=$1, decided_by=$2, decided_by_user_account_id=$3, decided_on=$4, updated_on=$4
+		WHERE approval_proposal_id=$5 AND status=$6 AND expires_on>$4
+		RETURNING *;`,
+		status, approver.Name, decidedByUserAccountId, now, approvalProposalId, ApprovalPending)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ApprovalProposal{}, errApprovalNotPending
+		}
+		return ApprovalProposal{}, errors.Wrap(err, database.SqlExecutionError)
+	}
+	return approvalProposal, nil
+}
+
+func executeApprovalProposal(db *sqlx.DB, approvalProposal ApprovalProposal) (any, error) {
+	switch approvalProposal.ProposalType {
+	case OpenChannelProposal:
+		var request lightning_helpers.OpenChannelRequest
+		if err := json.Unmarshal([]byte(approvalProposal.Request), &request); err != nil {
+			return nil, errors.Wrap(err, "Unmarshalling the open channel request")
+		}
+		return OpenChannel(request)
+	case BatchOpenChannelProposal:
+		var request lightning_helpers.BatchOpenChannelRequest
+		if err := json.Unmarshal([]byte(approvalProposal.Request), &request); err != nil {
+			return nil, errors.Wrap(err, "Unmarshalling the batch open channel request")
+		}
+		return BatchOpenChannel(request)
+	case CloseChannelProposal:
+		var request lightning_helpers.CloseChannelRequest
+		if err := json.Unmarshal([]byte(approvalProposal.Request), &request); err != nil {
+			return nil, errors.Wrap(err, "Unmarshalling the close channel request")
+		}
+		request.Db = db
+		return CloseChannel(request)
+	case OnChainPaymentProposal:
+		var request lightning_helpers.OnChainPaymentRequest
+		if err := json.Unmarshal([]byte(approvalProposal.Request), &request); err != nil {
+			return nil, errors.Wrap(err, "Unmarshalling the on-chain payment request")
+		}
+		return OnChainPayment(request)
+	case NewPaymentProposal:
+		var request lightning_helpers.NewPaymentRequest
+		if err := json.Unmarshal([]byte(approvalProposal.Request), &request); err != nil {
+			return nil, errors.Wrap(err, "Unmarshalling the payment request")
+		}
+		return NewPayment(request)
+	}
+	return nil, errors.New(fmt.Sprintf("Unknown proposal type: %v", approvalProposal.ProposalType))
+}
+
+// ExpireApprovalProposals discards the pending proposals that were not decided in time
+func ExpireApprovalProposals(db *sqlx.DB) {
+	now := time.Now().UTC()
+	res, err := db.Exec(`
+		UPDATE approval_proposal
+		SET status=$1, updated_on=$2
+		WHERE status=$3 AND expires_on<=$2;`, ApprovalExpired, now, ApprovalPending)
+	if err != nil {
+		log.Error().Err(err).Msg("Couldn't expire the pending approval proposals.")
+		return
+	}
+	rowsAffected, err := res.RowsAffected()
+	if err == nil && rowsAffected != 0 {
+		log.Info().Msgf("%v pending approval proposal(s) expired.", rowsAffected)
+	}
+}
+
+func getIntValue(value *int) int {
+	if value == nil {
+		return 0
+	}
+	return *value
+}
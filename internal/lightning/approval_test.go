@@ -0,0 +1,114 @@
+package lightning
+
+import (
+	"testing"
+
+	"github.com/lncapital/torq/internal/auth"
+	"github.com/lncapital/torq/internal/lightning_helpers"
+	"github.com/lncapital/torq/testutil"
+)
+
+func TestIsApprovalRequired(t *testing.T) {
+	threshold := int64(1_000_000)
+	sendAll := true
+	testCases := []struct {
+		name         string
+		thresholdSat *int64
+		amountSat    int64
+		want         bool
+	}{
+		{name: "disabled", amountSat: 50_000_000, want: false},
+		{name: "below threshold", thresholdSat: &threshold, amountSat: 999_999, want: false},
+		{name: "at threshold", thresholdSat: &threshold, amountSat: 1_000_000, want: false},
+		{name: "above threshold", thresholdSat: &threshold, amountSat: 1_000_001, want: true},
+		{
+			name:         "batch open",
+			thresholdSat: &threshold,
+			amountSat: getBatchOpenChannelAmountSat(lightning_helpers.BatchOpenChannelRequest{
+				Channels: []lightning_helpers.BatchOpenChannel{
+					{LocalFundingAmount: 600_000},
+					{LocalFundingAmount: 600_000},
+				},
+			}),
+			want: true,
+		},
+		{
+			name:         "send all",
+			thresholdSat: &threshold,
+			amountSat: getOnChainPaymentAmountSat(lightning_helpers.OnChainPaymentRequest{
+				AmountSat: 1,
+				SendAll:   &sendAll,
+			}),
+			want: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := approvalSettings{ThresholdSat: tc.thresholdSat}.isApprovalRequired(tc.amountSat)
+			if got != tc.want {
+				testutil.Errorf(t, "isApprovalRequired(%v) = %v, want %v", tc.amountSat, got, tc.want)
+				return
+			}
+			testutil.Successf(t, "isApprovalRequired(%v) = %v", tc.amountSat, got)
+		})
+	}
+}
+
+func TestApprovalProposalRequester(t *testing.T) {
+	userAccountId := 3
+	approvalProposal := ApprovalProposal{RequestedBy: "junior", RequestedByUserAccountId: &userAccountId}
+	testCases := []struct {
+		name  string
+		actor auth.Actor
+		want  bool
+	}{
+		{name: "same user", actor: auth.Actor{Name: "junior", UserAccountId: 3}, want: true},
+		{name: "renamed user", actor: auth.Actor{Name: "senior", UserAccountId: 3}, want: true},
+		{name: "other user", actor: auth.Actor{Name: "senior", UserAccountId: 4}, want: false},
+		{name: "chat approver", actor: auth.Actor{Name: "telegram:senior"}, want: false},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := approvalProposal.isRequester(tc.actor)
+			if got != tc.want {
+				testutil.Errorf(t, "isRequester(%+v) = %v, want %v", tc.actor, got, tc.want)
+				return
+			}
+			testutil.Successf(t, "isRequester(%+v) = %v", tc.actor, got)
+		})
+	}
+}
+
+func TestApprovalProposalRequesterWithApiToken(t *testing.T) {
+	userAccountId := 3
+	apiTokenId := 7
+	approvalProposal := ApprovalProposal{
+		RequestedBy:              "ci",
+		RequestedByUserAccountId: &userAccountId,
+		RequestedByApiTokenId:    &apiTokenId,
+	}
+	testCases := []struct {
+		name  string
+		actor auth.Actor
+		want  bool
+	}{
+		{name: "creator of the API token", actor: auth.Actor{Name: "admin", UserAccountId: 3}, want: true},
+		{name: "same API token", actor: auth.Actor{Name: "ci", ApiTokenId: 7, ApiTokenCreatedByUserAccountId: 3},
+			want: true},
+		{name: "other API token of the creator",
+			actor: auth.Actor{Name: "deploy", ApiTokenId: 8, ApiTokenCreatedByUserAccountId: 3}, want: true},
+		{name: "other user", actor: auth.Actor{Name: "senior", UserAccountId: 4}, want: false},
+		{name: "API token of another user",
+			actor: auth.Actor{Name: "deploy", ApiTokenId: 8, ApiTokenCreatedByUserAccountId: 4}, want: false},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := approvalProposal.isRequester(tc.actor)
+			if got != tc.want {
+				testutil.Errorf(t, "isRequester(%+v) = %v, want %v", tc.actor, got, tc.want)
+				return
+			}
+			testutil.Successf(t, "isRequester(%+v) = %v", tc.actor, got)
+		})
+	}
+}
@@ -356,7 +356,7 @@ func BatchOpenChannel(
 		if !cache.IsClnServiceActive(request.NodeId) {
 			return lightning_helpers.BatchOpenChannelResponse{}, ServiceInactiveError
 		}
-		return lightning_helpers.BatchOpenChannelResponse{}, UnsupportedOperationError
+		response = cln.BatchOpenChannel(request)
 	}
 	if response.Error != "" {
 		return lightning_helpers.BatchOpenChannelResponse{}, errors.New(response.Error)
@@ -491,7 +491,7 @@ func DecodeInvoice(request lightning_helpers.DecodeInvoiceRequest) (lightning_he
 		if !cache.IsClnServiceActive(request.NodeId) {
 			return lightning_helpers.DecodeInvoiceResponse{}, ServiceInactiveError
 		}
-		return lightning_helpers.DecodeInvoiceResponse{}, UnsupportedOperationError
+		response = cln.DecodeInvoice(request)
 	}
 	if response.Error != "" {
 		return lightning_helpers.DecodeInvoiceResponse{}, errors.New(response.Error)
@@ -518,7 +518,7 @@ func ChannelStatusUpdate(request lightning_helpers.ChannelStatusUpdateRequest) e
 		if !cache.IsClnServiceActive(request.NodeId) {
 			return ServiceInactiveError
 		}
-		return UnsupportedOperationError
+		response = cln.ChannelStatusUpdate(request)
 	}
 	if response.Error != "" {
 		return errors.New(response.Error)
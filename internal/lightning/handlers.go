@@ -1,6 +1,7 @@
 package lightning
 
 import (
+	"database/sql"
 	"fmt"
 	"net/http"
 	"strconv"
@@ -13,19 +14,25 @@ import (
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
+	"github.com/lncapital/torq/internal/auth"
 	"github.com/lncapital/torq/internal/cache"
 	"github.com/lncapital/torq/internal/core"
 	"github.com/lncapital/torq/internal/lightning_helpers"
 	"github.com/lncapital/torq/pkg/server_errors"
 )
 
-func batchOpenHandler(c *gin.Context) {
+func batchOpenHandler(c *gin.Context, db *sqlx.DB) {
 	var batchOpnReq lightning_helpers.BatchOpenChannelRequest
 	if err := c.BindJSON(&batchOpnReq); err != nil {
 		server_errors.SendBadRequestFromError(c, errors.Wrap(err, server_errors.JsonParseError))
 		return
 	}
 
+	if proposeWhenApprovalRequiredHandler(c, db, BatchOpenChannelProposal, batchOpnReq.NodeId, 0,
+		getBatchOpenChannelAmountSat(batchOpnReq), batchOpnReq) {
+		return
+	}
+
 	response, err := BatchOpenChannel(batchOpnReq)
 	if err != nil {
 		server_errors.WrapLogAndSendServerError(c, err, "Batch open channels")
@@ -36,7 +43,7 @@ func batchOpenHandler(c *gin.Context) {
 }
 
 // openChannelHandler opens a channel to a peer
-func openChannelHandler(c *gin.Context) {
+func openChannelHandler(c *gin.Context, db *sqlx.DB) {
 	var openChannelRequest lightning_helpers.OpenChannelRequest
 	err := c.BindJSON(&openChannelRequest)
 	if err != nil {
@@ -44,6 +51,11 @@ func openChannelHandler(c *gin.Context) {
 		return
 	}
 
+	if proposeWhenApprovalRequiredHandler(c, db, OpenChannelProposal, openChannelRequest.NodeId, 0,
+		getOpenChannelAmountSat(openChannelRequest), openChannelRequest) {
+		return
+	}
+
 	response, err := OpenChannel(openChannelRequest)
 	switch {
 	case err != nil && strings.Contains(err.Error(), "connecting to "):
@@ -113,6 +125,11 @@ func closeChannelHandler(c *gin.Context, db *sqlx.DB) {
 		return
 	}
 
+	if proposeWhenApprovalRequiredHandler(c, db, CloseChannelProposal, closeChannelRequest.NodeId,
+		closeChannelRequest.ChannelId, getCloseChannelAmountSat(closeChannelRequest), closeChannelRequest) {
+		return
+	}
+
 	closeChannelRequest.Db = db
 	response, err := CloseChannel(closeChannelRequest)
 	if err != nil {
@@ -227,7 +244,7 @@ func decodeInvoiceHandler(c *gin.Context) {
 	c.JSON(http.StatusOK, di)
 }
 
-func sendCoinsHandler(c *gin.Context) {
+func sendCoinsHandler(c *gin.Context, db *sqlx.DB) {
 	var requestBody lightning_helpers.OnChainPaymentRequest
 
 	if err := c.BindJSON(&requestBody); err != nil {
@@ -235,6 +252,11 @@ func sendCoinsHandler(c *gin.Context) {
 		return
 	}
 
+	if proposeWhenApprovalRequiredHandler(c, db, OnChainPaymentProposal, requestBody.NodeId, 0,
+		getOnChainPaymentAmountSat(requestBody), requestBody) {
+		return
+	}
+
 	resp, err := OnChainPayment(requestBody)
 	if err != nil {
 		server_errors.WrapLogAndSendServerError(c, err, "Sending on-chain payment")
@@ -261,3 +283,81 @@ func newAddressHandler(c *gin.Context) {
 
 	c.JSON(http.StatusOK, resp)
 }
+
+// proposeWhenApprovalRequiredHandler returns true when the request was stored as proposal (or failed to be stored)
+func proposeWhenApprovalRequiredHandler(c *gin.Context,
+	db *sqlx.DB,
+	proposalType ApprovalProposalType,
+	nodeId int,
+	channelId int,
+	amountSat int64,
+	request any) bool {
+
+	approvalProposal, err := ProposeWhenApprovalRequired(db, auth.GetActor(c), proposalType, nodeId, channelId,
+		amountSat, request)
+	if err != nil {
+		server_errors.WrapLogAndSendServerError(c, err, "Storing the approval proposal")
+		return true
+	}
+	if approvalProposal == nil {
+		return false
+	}
+	c.JSON(http.StatusAccepted, approvalProposal)
+	return true
+}
+
+func getApprovalProposalsHandler(c *gin.Context, db *sqlx.DB) {
+	var status *ApprovalStatus
+	if c.Query("status") != "" {
+		statusId, err := strconv.Atoi(c.Query("status"))
+		if err != nil {
+			server_errors.SendBadRequest(c, "Failed to parse status in the request.")
+			return
+		}
+		approvalStatus := ApprovalStatus(statusId)
+		status = &approvalStatus
+	}
+	approvalProposals, err := GetApprovalProposals(db, status)
+	if err != nil {
+		server_errors.WrapLogAndSendServerError(c, err, "Getting approval proposals")
+		return
+	}
+	c.JSON(http.StatusOK, approvalProposals)
+}
+
+func approveProposalHandler(c *gin.Context, db *sqlx.DB) {
+	decideProposalHandler(c, db, ApproveProposal)
+}
+
+func rejectProposalHandler(c *gin.Context, db *sqlx.DB) {
+	decideProposalHandler(c, db, RejectProposal)
+}
+
+func decideProposalHandler(c *gin.Context,
+	db *sqlx.DB,
+	decide func(db *sqlx.DB, approvalProposalId int, approver auth.Actor) (ApprovalProposal, error)) {
+
+	approvalProposalId, err := strconv.Atoi(c.Param("approvalProposalId"))
+	if err != nil {
+		server_errors.SendBadRequest(c, "Failed to find/parse approvalProposalId in the request.")
+		return
+	}
+	actor := auth.GetActor(c)
+	if actor.ApiTokenId != 0 {
+		server_errors.SendUnprocessableEntity(c, "Proposals can't be decided with an API token.")
+		return
+	}
+	approvalProposal, err := decide(db, approvalProposalId, actor)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		c.JSON(http.StatusNotFound, server_errors.SingleServerError("Proposal not found."))
+		return
+	case errors.Is(err, errApprovalNotPending) || errors.Is(err, errApprovalRequester):
+		server_errors.SendUnprocessableEntityFromError(c, err)
+		return
+	case err != nil:
+		server_errors.WrapLogAndSendServerError(c, err, "Deciding approval proposal")
+		return
+	}
+	c.JSON(http.StatusOK, approvalProposal)
+}
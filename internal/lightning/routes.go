@@ -5,14 +5,25 @@ import (
 	"github.com/jmoiron/sqlx"
 )
 
-func RegisterLightningRoutes(r *gin.RouterGroup, db *sqlx.DB) {
-	r.POST("open", func(c *gin.Context) { openChannelHandler(c) })
-	r.POST("openbatch", func(c *gin.Context) { batchOpenHandler(c) })
+// RegisterLightningFundsRoutes are the routes that open and close channels or move on-chain funds
+func RegisterLightningFundsRoutes(r *gin.RouterGroup, db *sqlx.DB) {
+	r.POST("open", func(c *gin.Context) { openChannelHandler(c, db) })
+	r.POST("openbatch", func(c *gin.Context) { batchOpenHandler(c, db) })
 	r.POST("close", func(c *gin.Context) { closeChannelHandler(c, db) })
+	r.POST("sendcoins", func(c *gin.Context) { sendCoinsHandler(c, db) })
+}
+
+// RegisterApprovalRoutes are the routes to decide on the fund moving requests that are above the approval threshold
+func RegisterApprovalRoutes(r *gin.RouterGroup, db *sqlx.DB) {
+	r.GET("", func(c *gin.Context) { getApprovalProposalsHandler(c, db) })
+	r.POST(":approvalProposalId/approve", func(c *gin.Context) { approveProposalHandler(c, db) })
+	r.POST(":approvalProposalId/reject", func(c *gin.Context) { rejectProposalHandler(c, db) })
+}
+
+func RegisterLightningRoutes(r *gin.RouterGroup, db *sqlx.DB) {
 	r.PUT("updateRoutingPolicy", func(c *gin.Context) { updateRoutingPolicyHandler(c, db) })
 	r.GET("/:network/walletBalances", func(c *gin.Context) { getNodesWalletBalancesHandler(c) })
 	r.POST("newinvoice", func(c *gin.Context) { newInvoiceHandler(c) })
 	r.GET("decode", func(c *gin.Context) { decodeInvoiceHandler(c) })
-	r.POST("sendcoins", func(c *gin.Context) { sendCoinsHandler(c) })
 	r.POST("new-address", func(c *gin.Context) { newAddressHandler(c) })
 }
@@ -27,6 +27,8 @@ type FailedRequest struct {
 type RebalanceResponse struct {
 	Request RebalanceRequest `json:"request"`
 	CommunicationResponse
+	// Changed is true when the request started a rebalancer or updated the settings of a running rebalancer
+	Changed bool `json:"changed"`
 }
 
 type InformationResponse struct {
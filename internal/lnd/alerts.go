@@ -0,0 +1,145 @@
+package lnd
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/lncapital/torq/internal/cache"
+	"github.com/lncapital/torq/internal/channels"
+	"github.com/lncapital/torq/internal/core"
+)
+
+// The alerts are sent from the LND and the CLN services (just like the Process*Event functions).
+
+var (
+	htlcFailureBurstsMutex sync.Mutex                        //nolint:gochecknoglobals
+	htlcFailureBursts      = make(map[int]*htlcFailureBurst) //nolint:gochecknoglobals
+)
+
+// htlcFailureBurst tracks the failed HTLCs (forward and link failures) of a node within the burst window
+// (see cache.GetHtlcFailureBurstSettings)
+type htlcFailureBurst struct {
+	failures  []time.Time
+	alertedOn time.Time
+}
+
+// addFailure returns true when the failure completes a burst that has not been alerted within the window yet.
+func (burst *htlcFailureBurst) addFailure(failureTime time.Time, window time.Duration, threshold int) bool {
+	burst.failures = append(burst.failures, failureTime)
+	windowStart := failureTime.Add(-window)
+	expired := 0
+	for expired < len(burst.failures) && !burst.failures[expired].After(windowStart) {
+		expired++
+	}
+	burst.failures = burst.failures[expired:]
+	if len(burst.failures) < threshold {
+		return false
+	}
+	if !burst.alertedOn.IsZero() && failureTime.Sub(burst.alertedOn) < window {
+		return false
+	}
+	burst.alertedOn = failureTime
+	return true
+}
+
+// ProcessHtlcFailure notifies the communications subscribed to HTLC failure bursts
+// when the failed HTLC (forward or link failure) completes a burst.
+func ProcessHtlcFailure(nodeId int, failureTime time.Time) {
+	window, threshold := cache.GetHtlcFailureBurstSettings()
+	htlcFailureBurstsMutex.Lock()
+	burst, exists := htlcFailureBursts[nodeId]
+	if !exists {
+		burst = &htlcFailureBurst{}
+		htlcFailureBursts[nodeId] = burst
+	}
+	alert := burst.addFailure(failureTime, window, threshold)
+	failureCount := len(burst.failures)
+	htlcFailureBurstsMutex.Unlock()
+	if !alert {
+		return
+	}
+	message := fmt.Sprintf("HTLC failure burst: %v failed HTLCs in the last %v minutes",
+		failureCount, window.Minutes())
+	cache.SendNotifierEvent(core.NotifierEvent{
+		EventData: core.EventData{
+			EventTime: time.Now().UTC(),
+			NodeId:    nodeId,
+		},
+		Notification:     &message,
+		NotificationType: core.HtlcFailureBurstNotification,
+	})
+}
+
+// SendChannelAlert notifies the communications subscribed to channel openings, closures or force closures.
+func SendChannelAlert(nodeId int, channel channels.Channel, remoteNodeId int, remotePublicKey string) {
+	peer := cache.GetNodeAlias(remoteNodeId)
+	if peer == "" {
+		peer = remotePublicKey
+	}
+	shortChannelId := ""
+	if channel.ShortChannelID != nil {
+		shortChannelId = *channel.ShortChannelID
+	}
+	var notificationType core.NotificationType
+	var message string
+	switch channel.Status {
+	case core.Open:
+		notificationType = core.ChannelOpenedNotification
+		message = fmt.Sprintf("Channel opened with %v (%v), capacity: %v sat", peer, shortChannelId, channel.Capacity)
+	case core.CooperativeClosed:
+		notificationType = core.ChannelClosedNotification
+		message = fmt.Sprintf("Channel closed with %v (%v), capacity: %v sat", peer, shortChannelId, channel.Capacity)
+	case core.LocalForceClosed, core.RemoteForceClosed, core.BreachClosed:
+		notificationType = core.ChannelForceClosedNotification
+		message = fmt.Sprintf("Channel force closed (%v) with %v (%v), capacity: %v sat",
+			channel.Status.String(), peer, shortChannelId, channel.Capacity)
+	default:
+		return
+	}
+	cache.SendNotifierEvent(core.NotifierEvent{
+		EventData: core.EventData{
+			EventTime: time.Now().UTC(),
+			NodeId:    nodeId,
+		},
+		ChannelId:        &channel.ChannelID,
+		Notification:     &message,
+		NotificationType: notificationType,
+	})
+}
+
+// SendPeerDisconnectedAlert notifies the communications subscribed to peer disconnects (only for channel peers).
+func SendPeerDisconnectedAlert(nodeId int, eventNodeId int, publicKey string) {
+	if len(cache.GetChannelIdsByNodeId(eventNodeId)) == 0 {
+		return
+	}
+	peer := cache.GetNodeAlias(eventNodeId)
+	if peer == "" {
+		peer = publicKey
+	}
+	message := fmt.Sprintf("Peer disconnected: %v", peer)
+	cache.SendNotifierEvent(core.NotifierEvent{
+		EventData: core.EventData{
+			EventTime: time.Now().UTC(),
+			NodeId:    nodeId,
+		},
+		Notification:     &message,
+		NotificationType: core.PeerDisconnectedNotification,
+	})
+}
+
+// SendPaymentFailedAlert notifies the communications subscribed to failed payments.
+// Each communication has its own minimum amount (see PaymentAmountSat) so the notifier does the filtering.
+func SendPaymentFailedAlert(nodeId int, outgoingChannelId *int, amountSat int64, failureReason string) {
+	message := fmt.Sprintf("Payment of %v sat failed (%v)", amountSat, failureReason)
+	cache.SendNotifierEvent(core.NotifierEvent{
+		EventData: core.EventData{
+			EventTime: time.Now().UTC(),
+			NodeId:    nodeId,
+		},
+		ChannelId:        outgoingChannelId,
+		Notification:     &message,
+		NotificationType: core.PaymentFailedNotification,
+		PaymentAmountSat: amountSat,
+	})
+}
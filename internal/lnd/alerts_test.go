@@ -0,0 +1,55 @@
+package lnd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lncapital/torq/internal/cache"
+	"github.com/lncapital/torq/testutil"
+)
+
+func TestHtlcFailureBurst(t *testing.T) {
+	start := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	window := cache.DefaultHtlcFailureBurstWindowMinutes * time.Minute
+	threshold := cache.DefaultHtlcFailureBurstThreshold
+	testCases := []struct {
+		name      string
+		window    time.Duration
+		threshold int
+		interval  time.Duration
+		failures  int
+		wantAlert []int
+	}{
+		{name: "below threshold", window: window, threshold: threshold, interval: time.Second, failures: threshold - 1},
+		{name: "burst alerts once within the window", window: window, threshold: threshold, interval: time.Second,
+			failures: 2 * threshold, wantAlert: []int{threshold}},
+		{name: "spread failures never burst", window: window, threshold: threshold,
+			interval: window / time.Duration(threshold-1), failures: 3 * threshold},
+		{name: "sustained burst alerts once per window", window: window, threshold: threshold,
+			interval: 10 * time.Second, failures: 90, wantAlert: []int{threshold, threshold + 30, threshold + 60}},
+		{name: "configured window and threshold", window: time.Minute, threshold: 5, interval: 10 * time.Second,
+			failures: 20, wantAlert: []int{5, 11, 17}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var burst htlcFailureBurst
+			var alerts []int
+			for i := 1; i <= tc.failures; i++ {
+				if burst.addFailure(start.Add(time.Duration(i)*tc.interval), tc.window, tc.threshold) {
+					alerts = append(alerts, i)
+				}
+			}
+			if len(alerts) != len(tc.wantAlert) {
+				testutil.Errorf(t, "addFailure() alerts = %v, want %v", alerts, tc.wantAlert)
+				return
+			}
+			for i := range alerts {
+				if alerts[i] != tc.wantAlert[i] {
+					testutil.Errorf(t, "addFailure() alerts = %v, want %v", alerts, tc.wantAlert)
+					return
+				}
+			}
+			testutil.Successf(t, "addFailure() alerts = %v", alerts)
+		})
+	}
+}
@@ -302,6 +302,7 @@ func ProcessChannelEvent(channelEvent core.ChannelEvent) {
 	if channelEvent.NodeId == 0 || channelEvent.ChannelId == 0 {
 		return
 	}
+	cache.PublishEvent(channelEvent)
 	var status core.Status
 	switch channelEvent.Type {
 	case lnrpc.ChannelEventUpdate_ACTIVE_CHANNEL:
@@ -331,6 +332,7 @@ func ProcessForwardEvent(forwardEvent core.ForwardEvent) {
 	if forwardEvent.NodeId == 0 {
 		return
 	}
+	cache.PublishEvent(forwardEvent)
 	if forwardEvent.IncomingChannelId != nil {
 		cache.SetChannelStateBalanceUpdateMsat(forwardEvent.NodeId, *forwardEvent.IncomingChannelId, true,
 			forwardEvent.AmountInMsat, core.BalanceUpdateForwardEvent)
@@ -363,6 +365,7 @@ func ProcessPeerEvent(peerEvent core.PeerEvent) {
 	if peerEvent.NodeId == 0 || peerEvent.EventNodeId == 0 {
 		return
 	}
+	cache.PublishEvent(peerEvent)
 	var status core.Status
 	switch peerEvent.Type {
 	case lnrpc.PeerEvent_PEER_ONLINE:
@@ -113,6 +113,7 @@ func storeChannelEvent(ctx context.Context,
 		if err != nil {
 			return errors.Wrap(err, "Insert Open Channel Event")
 		}
+		SendChannelAlert(nodeSettings.NodeId, channel, remoteNodeId, remotePublicKey)
 		return nil
 	case lnrpc.ChannelEventUpdate_CLOSED_CHANNEL:
 		c := ce.GetClosedChannel()
@@ -149,6 +150,7 @@ func storeChannelEvent(ctx context.Context,
 		if err != nil {
 			return errors.Wrap(err, "Insert Closed Channel Event")
 		}
+		SendChannelAlert(nodeSettings.NodeId, channel, remoteNodeId, remotePublicKey)
 
 		// This stops the graph from listening to node updates
 		chans, err := channels.GetOpenChannelsForNodeId(db, remoteNodeId)
@@ -95,9 +95,35 @@ func storeFullEvent(db *sqlx.DB, h *routerrpc.HtlcEvent, nodeId int, eventType s
 	if err != nil {
 		return HtlcEvent{}, errors.Wrapf(err, "Storing HTLC Event (%v)", eventType)
 	}
+	cache.PublishEvent(htlcEvent.toCoreHtlcEvent())
 	return htlcEvent, nil
 }
 
+func (htlcEvent HtlcEvent) toCoreHtlcEvent() core.HtlcEvent {
+	return core.HtlcEvent{
+		EventData: core.EventData{
+			EventTime: time.Now().UTC(),
+			NodeId:    htlcEvent.NodeId,
+		},
+		Timestamp:         htlcEvent.Time,
+		Data:              htlcEvent.Data,
+		EventOrigin:       htlcEvent.EventOrigin,
+		EventType:         htlcEvent.EventType,
+		OutgoingHtlcId:    htlcEvent.OutgoingHtlcId,
+		IncomingHtlcId:    htlcEvent.IncomingHtlcId,
+		TimestampNs:       htlcEvent.TimestampNs,
+		IncomingAmtMsat:   htlcEvent.IncomingAmtMsat,
+		OutgoingAmtMsat:   htlcEvent.OutgoingAmtMsat,
+		IncomingTimelock:  htlcEvent.IncomingTimelock,
+		OutgoingTimelock:  htlcEvent.OutgoingTimelock,
+		BoltFailureCode:   htlcEvent.BoltFailureCode,
+		BoltFailureString: htlcEvent.BoltFailureString,
+		LndFailureDetail:  htlcEvent.LndFailureDetail,
+		OutgoingChannelId: htlcEvent.OutgoingChannelId,
+		IncomingChannelId: htlcEvent.IncomingChannelId,
+	}
+}
+
 func getChannelIdByLndShortChannelId(lndShortChannelId uint64) *int {
 	var channelId *int
 	shortChannelId := core.ConvertLNDShortChannelID(lndShortChannelId)
@@ -211,6 +237,7 @@ func SubscribeAndStoreHtlcEvents(ctx context.Context,
 					"Failed to store forward event of type HtlcEvent_ForwardFailEvent for nodeId: %v",
 					nodeSettings.NodeId)
 			}
+			ProcessHtlcFailure(nodeSettings.NodeId, time.Now().UTC())
 		case *routerrpc.HtlcEvent_LinkFailEvent:
 			_, err = storeLinkFailEvent(db, htlcEvent, nodeSettings.NodeId)
 			if err != nil {
@@ -219,6 +246,7 @@ func SubscribeAndStoreHtlcEvents(ctx context.Context,
 					"Failed to store forward event of type HtlcEvent_LinkFailEvent for nodeId: %v",
 					nodeSettings.NodeId)
 			}
+			ProcessHtlcFailure(nodeSettings.NodeId, time.Now().UTC())
 		case *routerrpc.HtlcEvent_SettleEvent:
 			_, err = storeSettleEvent(db, htlcEvent, nodeSettings.NodeId)
 			if err != nil {
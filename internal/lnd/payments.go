@@ -174,6 +174,9 @@ func storePayments(db *sqlx.DB, p []*lnrpc.Payment, nodeSettings cache.NodeSetti
 		tx := db.MustBegin()
 
 		for _, payment := range p {
+			if cache.IsProbePaymentHash(payment.PaymentHash) {
+				continue
+			}
 			htlcJson, err := json.Marshal(payment.Htlcs)
 			if err != nil {
 				return errors.Wrap(err, "JSON Marshal the payment HTLCs")
@@ -247,6 +250,10 @@ func storePayments(db *sqlx.DB, p []*lnrpc.Payment, nodeSettings cache.NodeSetti
 	if !bootStrapping {
 		for _, paymentEvent := range paymentEvents {
 			ProcessPaymentEvent(paymentEvent)
+			if paymentEvent.PaymentStatus == lnrpc.Payment_FAILED && paymentEvent.RebalanceAmountMsat == nil {
+				SendPaymentFailedAlert(paymentEvent.NodeId, paymentEvent.OutgoingChannelId, paymentEvent.AmountPaid,
+					paymentEvent.PaymentFailureReason.String())
+			}
 		}
 	}
 
@@ -86,6 +86,9 @@ func SubscribePeerEvents(ctx context.Context,
 				Type:        peerEvent.Type,
 				EventNodeId: eventNodeId,
 			})
+			if peerEvent.Type == lnrpc.PeerEvent_PEER_OFFLINE {
+				SendPeerDisconnectedAlert(nodeSettings.NodeId, eventNodeId, peerEvent.PubKey)
+			}
 		}
 	}
 }
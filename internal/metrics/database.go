@@ -0,0 +1,103 @@
+package metrics
+
+import (
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/lncapital/torq/internal/core"
+	"github.com/lncapital/torq/internal/database"
+	"github.com/lncapital/torq/internal/workflows"
+)
+
+type channelForwardsRow struct {
+	NodeId       int   `db:"node_id"`
+	ChannelId    int   `db:"channel_id"`
+	CountOut     int64 `db:"count_out"`
+	CountIn      int64 `db:"count_in"`
+	AmountOutSat int64 `db:"amount_out_sat"`
+	AmountInSat  int64 `db:"amount_in_sat"`
+	FeeMsat      int64 `db:"fee_msat"`
+}
+
+type rebalanceRow struct {
+	OutgoingChannelId int   `db:"outgoing_channel_id"`
+	IncomingChannelId int   `db:"incoming_channel_id"`
+	AttemptCount      int64 `db:"attempt_count"`
+	SuccessCount      int64 `db:"success_count"`
+	SuccessAmountMsat int64 `db:"success_amount_msat"`
+	SuccessFeeMsat    int64 `db:"success_fee_msat"`
+}
+
+type workflowRunRow struct {
+	WorkflowId             int                         `db:"workflow_id"`
+	WorkflowName           string                      `db:"workflow_name"`
+	Status                 workflows.WorkflowRunStatus `db:"status"`
+	RunCount               int64                       `db:"run_count"`
+	DurationSecondsSum     float64                     `db:"duration_seconds_sum"`
+	DurationSecondsMaximum float64                     `db:"duration_seconds_maximum"`
+}
+
+func getChannelForwards(db *sqlx.DB, since time.Time) ([]channelForwardsRow, error) {
+	var rows []channelForwardsRow
+	err := db.Select(&rows, `
+		SELECT node_id, channel_id,
+			COALESCE(SUM(count_out), 0)::BIGINT AS count_out,
+			COALESCE(SUM(count_in), 0)::BIGINT AS count_in,
+			FLOOR(COALESCE(SUM(amount_out_msat), 0)/1000)::BIGINT AS amount_out_sat,
+			FLOOR(COALESCE(SUM(amount_in_msat), 0)/1000)::BIGINT AS amount_in_sat,
+			COALESCE(SUM(fee_out_msat), 0)::BIGINT AS fee_msat
+		FROM (
+			SELECT node_id, outgoing_channel_id AS channel_id,
+				outgoing_amount_msat AS amount_out_msat, 0 AS amount_in_msat, fee_msat AS fee_out_msat,
+				1 AS count_out, 0 AS count_in
+			FROM forward
+			WHERE time >= $1::timestamp AND outgoing_channel_id IS NOT NULL
+			UNION ALL
+			SELECT node_id, incoming_channel_id AS channel_id,
+				0 AS amount_out_msat, incoming_amount_msat AS amount_in_msat, 0 AS fee_out_msat,
+				0 AS count_out, 1 AS count_in
+			FROM forward
+			WHERE time >= $1::timestamp AND incoming_channel_id IS NOT NULL
+		) AS f
+		GROUP BY node_id, channel_id;`, since)
+	if err != nil {
+		return nil, errors.Wrap(err, database.SqlExecutionError)
+	}
+	return rows, nil
+}
+
+func getRebalances(db *sqlx.DB, since time.Time) ([]rebalanceRow, error) {
+	var rows []rebalanceRow
+	err := db.Select(&rows, `
+		SELECT outgoing_channel_id, incoming_channel_id,
+			COUNT(*) AS attempt_count,
+			COUNT(*) FILTER (WHERE status=$2) AS success_count,
+			COALESCE(SUM(total_amount_msat) FILTER (WHERE status=$2), 0)::BIGINT AS success_amount_msat,
+			COALESCE(SUM(total_fee_msat) FILTER (WHERE status=$2), 0)::BIGINT AS success_fee_msat
+		FROM rebalance_log
+		WHERE created_on >= $1
+		GROUP BY outgoing_channel_id, incoming_channel_id;`, since, core.Active)
+	if err != nil {
+		return nil, errors.Wrap(err, database.SqlExecutionError)
+	}
+	return rows, nil
+}
+
+func getWorkflowRuns(db *sqlx.DB, since time.Time) ([]workflowRunRow, error) {
+	var rows []workflowRunRow
+	err := db.Select(&rows, `
+		SELECT wr.workflow_id, w.name AS workflow_name, wr.status,
+			COUNT(*) AS run_count,
+			COALESCE(SUM(EXTRACT(EPOCH FROM (wr.ended_on - wr.started_on))), 0)::DOUBLE PRECISION AS duration_seconds_sum,
+			COALESCE(MAX(EXTRACT(EPOCH FROM (wr.ended_on - wr.started_on))), 0)::DOUBLE PRECISION AS duration_seconds_maximum
+		FROM workflow_run wr
+		JOIN workflow w ON w.workflow_id = wr.workflow_id
+		WHERE wr.started_on >= $1
+		GROUP BY wr.workflow_id, w.name, wr.status;`, since)
+	if err != nil {
+		return nil, errors.Wrap(err, database.SqlExecutionError)
+	}
+	return rows, nil
+}
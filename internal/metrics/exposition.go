@@ -0,0 +1,87 @@
+package metrics
+
+import (
+	"bufio"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+)
+
+const contentType = "text/plain; version=0.0.4; charset=utf-8"
+
+type metricType string
+
+const gauge = metricType("gauge")
+
+type sample struct {
+	// labels are key value pairs
+	labels []string
+	value  float64
+}
+
+// metricFamily is a metric with all its samples in the Prometheus text exposition format
+type metricFamily struct {
+	name       string
+	help       string
+	metricType metricType
+	samples    []sample
+}
+
+func newGauge(name string, help string) *metricFamily {
+	return &metricFamily{name: name, help: help, metricType: gauge}
+}
+
+// add appends a sample, labels are provided as key value pairs
+func (family *metricFamily) add(value float64, labels ...string) {
+	family.samples = append(family.samples, sample{labels: labels, value: value})
+}
+
+func writeMetricFamilies(writer io.Writer, families []*metricFamily) error {
+	bufferedWriter := bufio.NewWriter(writer)
+	for _, family := range families {
+		if len(family.samples) == 0 {
+			continue
+		}
+		bufferedWriter.WriteString("# HELP " + family.name + " " + escapeHelp(family.help) + "\n")
+		bufferedWriter.WriteString("# TYPE " + family.name + " " + string(family.metricType) + "\n")
+		for _, familySample := range family.samples {
+			bufferedWriter.WriteString(family.name)
+			if len(familySample.labels) != 0 {
+				bufferedWriter.WriteString("{")
+				for index := 0; index+1 < len(familySample.labels); index += 2 {
+					if index != 0 {
+						bufferedWriter.WriteString(",")
+					}
+					bufferedWriter.WriteString(familySample.labels[index] + "=\"" +
+						escapeLabelValue(familySample.labels[index+1]) + "\"")
+				}
+				bufferedWriter.WriteString("}")
+			}
+			bufferedWriter.WriteString(" " + formatValue(familySample.value) + "\n")
+		}
+	}
+	return errors.Wrap(bufferedWriter.Flush(), "Writing metrics")
+}
+
+func escapeHelp(help string) string {
+	return strings.NewReplacer(`\`, `\\`, "\n", `\n`).Replace(help)
+}
+
+func escapeLabelValue(value string) string {
+	return strings.NewReplacer(`\`, `\\`, "\n", `\n`, `"`, `\"`).Replace(value)
+}
+
+func formatValue(value float64) string {
+	switch {
+	case math.IsNaN(value):
+		return "NaN"
+	case math.IsInf(value, 1):
+		return "+Inf"
+	case math.IsInf(value, -1):
+		return "-Inf"
+	}
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
@@ -0,0 +1,37 @@
+package metrics
+
+import (
+	"bytes"
+	"math"
+	"testing"
+
+	"github.com/lncapital/torq/testutil"
+)
+
+func TestWriteMetricFamilies(t *testing.T) {
+	localBalance := newGauge("torq_channel_local_balance_sat", "Local balance of the channel.")
+	localBalance.add(150000, "node_id", "1", "peer_alias", `my "node"\`)
+	localBalance.add(0.5, "node_id", "2", "peer_alias", "line\nbreak")
+	empty := newGauge("torq_empty", "Families without samples are skipped.")
+	withoutLabels := newGauge("torq_without_labels", "Help with a\nnewline.")
+	withoutLabels.add(math.Inf(1))
+
+	var buffer bytes.Buffer
+	err := writeMetricFamilies(&buffer, []*metricFamily{localBalance, empty, withoutLabels})
+	if err != nil {
+		testutil.Fatalf(t, "writeMetricFamilies: %v", err)
+	}
+	want := `# HELP torq_channel_local_balance_sat Local balance of the channel.
+# TYPE torq_channel_local_balance_sat gauge
+torq_channel_local_balance_sat{node_id="1",peer_alias="my \"node\"\\"} 150000
+torq_channel_local_balance_sat{node_id="2",peer_alias="line\nbreak"} 0.5
+# HELP torq_without_labels Help with a\nnewline.
+# TYPE torq_without_labels gauge
+torq_without_labels +Inf
+`
+	if buffer.String() != want {
+		testutil.Errorf(t, "writeMetricFamilies() =\n%v\nwant\n%v", buffer.String(), want)
+		return
+	}
+	testutil.Successf(t, "writeMetricFamilies() writes the Prometheus text exposition format")
+}
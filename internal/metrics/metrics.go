@@ -0,0 +1,248 @@
+// Package metrics exposes the live state of Torq in the Prometheus text exposition format.
+//
+// Balances, HTLCs and service states come from the cache. Forwards, rebalances and workflow runs are aggregated
+// from the database over the last 24 hours.
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/lncapital/torq/internal/cache"
+	"github.com/lncapital/torq/internal/services_helpers"
+	"github.com/lncapital/torq/internal/workflows"
+	"github.com/lncapital/torq/pkg/server_errors"
+)
+
+const aggregationWindow = 24 * time.Hour
+
+func RegisterMetricsRoutes(r *gin.RouterGroup, db *sqlx.DB) {
+	r.GET("", func(c *gin.Context) { getMetricsHandler(c, db) })
+}
+
+func getMetricsHandler(c *gin.Context, db *sqlx.DB) {
+	families, err := collectMetrics(db)
+	if err != nil {
+		server_errors.WrapLogAndSendServerError(c, err, "Collecting metrics")
+		return
+	}
+	c.Status(http.StatusOK)
+	c.Header("Content-Type", contentType)
+	err = writeMetricFamilies(c.Writer, families)
+	if err != nil {
+		_ = c.Error(err)
+	}
+}
+
+func collectMetrics(db *sqlx.DB) ([]*metricFamily, error) {
+	var families []*metricFamily
+	families = append(families, collectNodeMetrics()...)
+	families = append(families, collectChannelMetrics()...)
+	families = append(families, collectServiceMetrics()...)
+
+	since := time.Now().UTC().Add(-aggregationWindow)
+	forwardFamilies, err := collectForwardMetrics(db, since)
+	if err != nil {
+		return nil, err
+	}
+	families = append(families, forwardFamilies...)
+	rebalanceFamilies, err := collectRebalanceMetrics(db, since)
+	if err != nil {
+		return nil, err
+	}
+	families = append(families, rebalanceFamilies...)
+	workflowFamilies, err := collectWorkflowMetrics(db, since)
+	if err != nil {
+		return nil, err
+	}
+	return append(families, workflowFamilies...), nil
+}
+
+func collectNodeMetrics() []*metricFamily {
+	nodeActive := newGauge("torq_node_active", "1 when the node connection is active in Torq.")
+	activeNodeIds := make(map[int]bool)
+	for _, nodeSettings := range cache.GetActiveTorqNodeSettings() {
+		activeNodeIds[nodeSettings.NodeId] = true
+	}
+	for _, nodeId := range cache.GetAllTorqNodeIds() {
+		nodeSettings := cache.GetNodeSettingsByNodeId(nodeId)
+		name := ""
+		if nodeSettings.Name != nil {
+			name = *nodeSettings.Name
+		}
+		nodeActive.add(boolValue(activeNodeIds[nodeId]),
+			"node_id", strconv.Itoa(nodeId), "node_name", name, "public_key", nodeSettings.PublicKey)
+	}
+	return []*metricFamily{nodeActive}
+}
+
+func collectChannelMetrics() []*metricFamily {
+	localBalance := newGauge("torq_channel_local_balance_sat", "Local balance of the channel.")
+	remoteBalance := newGauge("torq_channel_remote_balance_sat", "Remote balance of the channel.")
+	capacity := newGauge("torq_channel_capacity_sat", "Capacity of the channel.")
+	localDisabled := newGauge("torq_channel_local_disabled", "1 when the channel is disabled by the local node.")
+	pendingHtlcs := newGauge("torq_channel_pending_htlcs", "Amount of pending HTLCs on the channel.")
+	pendingHtlcsAmount := newGauge("torq_channel_pending_htlcs_sat", "Total amount of the pending HTLCs on the channel.")
+	for _, nodeSettings := range cache.GetActiveTorqNodeSettings() {
+		for _, channelState := range cache.GetChannelStates(nodeSettings.NodeId, false) {
+			channelSettings := cache.GetChannelSettingByChannelId(channelState.ChannelId)
+			shortChannelId := ""
+			if channelSettings.ShortChannelId != nil {
+				shortChannelId = *channelSettings.ShortChannelId
+			}
+			labels := []string{
+				"node_id", strconv.Itoa(channelState.NodeId),
+				"channel_id", strconv.Itoa(channelState.ChannelId),
+				"short_channel_id", shortChannelId,
+				"peer_alias", cache.GetNodeAlias(channelState.RemoteNodeId),
+			}
+			localBalance.add(float64(channelState.LocalBalance), labels...)
+			remoteBalance.add(float64(channelState.RemoteBalance), labels...)
+			capacity.add(float64(channelSettings.Capacity), labels...)
+			localDisabled.add(boolValue(channelState.LocalDisabled), labels...)
+			pendingHtlcs.add(float64(channelState.PendingIncomingHtlcCount),
+				append(labels, "direction", "incoming")...)
+			pendingHtlcs.add(float64(channelState.PendingOutgoingHtlcCount),
+				append(labels, "direction", "outgoing")...)
+			pendingHtlcsAmount.add(float64(channelState.PendingIncomingHtlcAmount),
+				append(labels, "direction", "incoming")...)
+			pendingHtlcsAmount.add(float64(channelState.PendingOutgoingHtlcAmount),
+				append(labels, "direction", "outgoing")...)
+		}
+	}
+	return []*metricFamily{localBalance, remoteBalance, capacity, localDisabled, pendingHtlcs, pendingHtlcsAmount}
+}
+
+func collectServiceMetrics() []*metricFamily {
+	serviceStatus := newGauge("torq_service_status",
+		"Current status of the service, the sample with the current status has value 1.")
+	for _, serviceType := range services_helpers.GetCoreServiceTypes() {
+		addServiceStatus(serviceStatus, serviceType, "", cache.GetCurrentCoreServiceState(serviceType).Status)
+	}
+	for _, nodeId := range cache.GetLndNodeIds() {
+		for _, serviceType := range services_helpers.GetLndServiceTypes() {
+			addServiceStatus(serviceStatus, serviceType, strconv.Itoa(nodeId),
+				cache.GetCurrentNodeServiceState(serviceType, nodeId).Status)
+		}
+	}
+	for _, nodeId := range cache.GetClnNodeIds() {
+		for _, serviceType := range services_helpers.GetClnServiceTypes() {
+			addServiceStatus(serviceStatus, serviceType, strconv.Itoa(nodeId),
+				cache.GetCurrentNodeServiceState(serviceType, nodeId).Status)
+		}
+	}
+
+	activeRebalancers := newGauge("torq_rebalancers_active", "Amount of active rebalancers.")
+	for nodeId, count := range workflows.GetActiveRebalancerCounts() {
+		activeRebalancers.add(float64(count), "node_id", strconv.Itoa(nodeId))
+	}
+	return []*metricFamily{serviceStatus, activeRebalancers}
+}
+
+// addServiceStatus adds a sample for every status so a change of status doesn't make a series disappear
+func addServiceStatus(family *metricFamily,
+	serviceType services_helpers.ServiceType,
+	nodeId string,
+	currentStatus services_helpers.ServiceStatus) {
+
+	for _, status := range []services_helpers.ServiceStatus{
+		services_helpers.Inactive,
+		services_helpers.Pending,
+		services_helpers.Initializing,
+		services_helpers.Active,
+	} {
+		status := status
+		family.add(boolValue(status == currentStatus),
+			"service", serviceType.String(), "node_id", nodeId, "status", status.String())
+	}
+}
+
+func collectForwardMetrics(db *sqlx.DB, since time.Time) ([]*metricFamily, error) {
+	rows, err := getChannelForwards(db, since)
+	if err != nil {
+		return nil, err
+	}
+	forwards := newGauge("torq_channel_forwards_24h", "Amount of forwards through the channel in the last 24 hours.")
+	forwardsAmount := newGauge("torq_channel_forwards_amount_24h_sat",
+		"Forwarded amount through the channel in the last 24 hours.")
+	forwardFees := newGauge("torq_channel_forward_fees_24h_msat",
+		"Fees earned by forwarding out of the channel in the last 24 hours.")
+	for _, row := range rows {
+		labels := []string{"node_id", strconv.Itoa(row.NodeId), "channel_id", strconv.Itoa(row.ChannelId)}
+		forwards.add(float64(row.CountIn), append(labels, "direction", "incoming")...)
+		forwards.add(float64(row.CountOut), append(labels, "direction", "outgoing")...)
+		forwardsAmount.add(float64(row.AmountInSat), append(labels, "direction", "incoming")...)
+		forwardsAmount.add(float64(row.AmountOutSat), append(labels, "direction", "outgoing")...)
+		forwardFees.add(float64(row.FeeMsat), labels...)
+	}
+	return []*metricFamily{forwards, forwardsAmount, forwardFees}, nil
+}
+
+func collectRebalanceMetrics(db *sqlx.DB, since time.Time) ([]*metricFamily, error) {
+	rows, err := getRebalances(db, since)
+	if err != nil {
+		return nil, err
+	}
+	attempts := newGauge("torq_rebalance_attempts_24h", "Amount of rebalance attempts in the last 24 hours.")
+	amount := newGauge("torq_rebalance_amount_24h_msat", "Successfully rebalanced amount in the last 24 hours.")
+	cost := newGauge("torq_rebalance_cost_24h_msat", "Fees paid for successful rebalances in the last 24 hours.")
+	for _, row := range rows {
+		labels := []string{
+			"outgoing_channel_id", strconv.Itoa(row.OutgoingChannelId),
+			"incoming_channel_id", strconv.Itoa(row.IncomingChannelId),
+		}
+		attempts.add(float64(row.SuccessCount), append(labels, "result", "success")...)
+		attempts.add(float64(row.AttemptCount-row.SuccessCount), append(labels, "result", "failure")...)
+		amount.add(float64(row.SuccessAmountMsat), labels...)
+		cost.add(float64(row.SuccessFeeMsat), labels...)
+	}
+	return []*metricFamily{attempts, amount, cost}, nil
+}
+
+func collectWorkflowMetrics(db *sqlx.DB, since time.Time) ([]*metricFamily, error) {
+	rows, err := getWorkflowRuns(db, since)
+	if err != nil {
+		return nil, err
+	}
+	runs := newGauge("torq_workflow_runs_24h", "Amount of workflow runs started in the last 24 hours.")
+	durationSum := newGauge("torq_workflow_run_duration_24h_seconds_sum",
+		"Total duration of the finished workflow runs started in the last 24 hours.")
+	durationMaximum := newGauge("torq_workflow_run_duration_24h_seconds_max",
+		"Longest duration of the finished workflow runs started in the last 24 hours.")
+	for _, row := range rows {
+		labels := []string{
+			"workflow_id", strconv.Itoa(row.WorkflowId),
+			"workflow_name", row.WorkflowName,
+			"status", getWorkflowRunStatusLabel(row.Status),
+		}
+		runs.add(float64(row.RunCount), labels...)
+		if row.Status != workflows.WorkflowRunRunning {
+			durationSum.add(row.DurationSecondsSum, labels...)
+			durationMaximum.add(row.DurationSecondsMaximum, labels...)
+		}
+	}
+	return []*metricFamily{runs, durationSum, durationMaximum}, nil
+}
+
+func getWorkflowRunStatusLabel(status workflows.WorkflowRunStatus) string {
+	switch status {
+	case workflows.WorkflowRunRunning:
+		return "running"
+	case workflows.WorkflowRunSucceeded:
+		return "succeeded"
+	case workflows.WorkflowRunFailed:
+		return "failed"
+	}
+	return "unknown"
+}
+
+func boolValue(value bool) float64 {
+	if value {
+		return 1
+	}
+	return 0
+}
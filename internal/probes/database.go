@@ -0,0 +1,106 @@
+package probes
+
+import (
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+
+	"github.com/lncapital/torq/internal/database"
+)
+
+func GetProbeDestinations(db *sqlx.DB) ([]ProbeDestination, error) {
+	var destinations []ProbeDestination
+	err := db.Select(&destinations, `SELECT * FROM probe_destination ORDER BY probe_destination_id;`)
+	if err != nil {
+		return nil, errors.Wrap(err, database.SqlExecutionError)
+	}
+	return destinations, nil
+}
+
+func addProbeDestination(db *sqlx.DB, destination ProbeDestination) (ProbeDestination, error) {
+	destination.CreatedOn = time.Now().UTC()
+	destination.UpdatedOn = destination.CreatedOn
+	err := db.QueryRowx(`INSERT INTO probe_destination (name, public_key, amount_msat, created_on, updated_on)
+		VALUES ($1, $2, $3, $4, $5) RETURNING probe_destination_id;`,
+		destination.Name, destination.PublicKey, destination.AmountMsat,
+		destination.CreatedOn, destination.UpdatedOn).Scan(&destination.ProbeDestinationId)
+	if err != nil {
+		if err, ok := err.(*pq.Error); ok {
+			if err.Code == "23505" {
+				return ProbeDestination{}, database.SqlUniqueConstraintError
+			}
+		}
+		return ProbeDestination{}, errors.Wrap(err, database.SqlExecutionError)
+	}
+	return destination, nil
+}
+
+func setProbeDestination(db *sqlx.DB, destination ProbeDestination) (ProbeDestination, error) {
+	destination.UpdatedOn = time.Now().UTC()
+	_, err := db.Exec(`UPDATE probe_destination SET name=$1, public_key=$2, amount_msat=$3, updated_on=$4
+		WHERE probe_destination_id=$5;`,
+		destination.Name, destination.PublicKey, destination.AmountMsat, destination.UpdatedOn,
+		destination.ProbeDestinationId)
+	if err != nil {
+		if err, ok := err.(*pq.Error); ok {
+			if err.Code == "23505" {
+				return ProbeDestination{}, database.SqlUniqueConstraintError
+			}
+		}
+		return ProbeDestination{}, errors.Wrap(err, database.SqlExecutionError)
+	}
+	return destination, nil
+}
+
+func removeProbeDestination(db *sqlx.DB, probeDestinationId int) error {
+	_, err := db.Exec(`DELETE FROM probe_destination WHERE probe_destination_id=$1;`, probeDestinationId)
+	if err != nil {
+		return errors.Wrap(err, database.SqlExecutionError)
+	}
+	return nil
+}
+
+func addProbeResult(db *sqlx.DB, result ProbeResult) error {
+	_, err := db.NamedExec(`INSERT INTO probe_result (time, node_id, channel_id, destination_public_key, amount_msat,
+			success, liquidity_lower_bound_msat, liquidity_upper_bound_msat, fee_msat, hops, attempts, failure_reason)
+		VALUES (:time, :node_id, :channel_id, :destination_public_key, :amount_msat,
+			:success, :liquidity_lower_bound_msat, :liquidity_upper_bound_msat, :fee_msat, :hops, :attempts, :failure_reason);`,
+		result)
+	if err != nil {
+		return errors.Wrap(err, database.SqlExecutionError)
+	}
+	return nil
+}
+
+// getLatestProbeResults returns the latest result by channel and (current) destination of the last week
+func getLatestProbeResults(db *sqlx.DB, nodeIds []int) ([]ProbeResult, error) {
+	var results []ProbeResult
+	err := db.Select(&results, `
+		SELECT DISTINCT ON (pr.channel_id, pr.destination_public_key) pr.*
+		FROM probe_result pr
+		JOIN probe_destination pd ON pd.public_key = pr.destination_public_key
+		WHERE pr.node_id = ANY($1) AND pr.time >= $2
+		ORDER BY pr.channel_id, pr.destination_public_key, pr.time DESC;`,
+		pq.Array(nodeIds), time.Now().UTC().AddDate(0, 0, -7))
+	if err != nil {
+		return nil, errors.Wrap(err, database.SqlExecutionError)
+	}
+	return results, nil
+}
+
+func loadChannelProbes(db *sqlx.DB, nodeId int) error {
+	results, err := getLatestProbeResults(db, []int{nodeId})
+	if err != nil {
+		return err
+	}
+	resultsByChannelId := make(map[int][]ProbeResult)
+	for _, result := range results {
+		resultsByChannelId[result.ChannelId] = append(resultsByChannelId[result.ChannelId], result)
+	}
+	for channelId, channelResults := range resultsByChannelId {
+		setChannelProbe(channelId, summarizeProbeResults(channelResults))
+	}
+	return nil
+}
@@ -0,0 +1,194 @@
+package probes
+
+import (
+	"context"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/jmoiron/sqlx"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc"
+
+	"github.com/lncapital/torq/internal/cache"
+)
+
+const (
+	probeTickerMinutes = 60
+	// probeMaximumAttempts is the number of probes per channel and destination (i.e. the bisection steps + 1)
+	probeMaximumAttempts = 5
+	// probeMinimumAmountMsat stops the bisection, smaller amounts are not worth knowing
+	probeMinimumAmountMsat = 10_000_000
+	probeTimeoutSeconds    = 60
+	probeFeeLimitPpm       = 5_000
+)
+
+type ProbeDestination struct {
+	ProbeDestinationId int       `json:"probeDestinationId" db:"probe_destination_id"`
+	Name               string    `json:"name" db:"name"`
+	PublicKey          string    `json:"publicKey" db:"public_key"`
+	AmountMsat         uint64    `json:"amountMsat" db:"amount_msat"`
+	CreatedOn          time.Time `json:"createdOn" db:"created_on"`
+	UpdatedOn          time.Time `json:"updatedOn" db:"updated_on"`
+}
+
+// ProbeResult is the outcome of probing a destination through a single (first hop) channel.
+type ProbeResult struct {
+	Time                    time.Time `json:"time" db:"time"`
+	NodeId                  int       `json:"nodeId" db:"node_id"`
+	ChannelId               int       `json:"channelId" db:"channel_id"`
+	DestinationPublicKey    string    `json:"destinationPublicKey" db:"destination_public_key"`
+	AmountMsat              uint64    `json:"amountMsat" db:"amount_msat"`
+	Success                 bool      `json:"success" db:"success"`
+	LiquidityLowerBoundMsat uint64    `json:"liquidityLowerBoundMsat" db:"liquidity_lower_bound_msat"`
+	LiquidityUpperBoundMsat *uint64   `json:"liquidityUpperBoundMsat" db:"liquidity_upper_bound_msat"`
+	FeeMsat                 *int64    `json:"feeMsat" db:"fee_msat"`
+	Hops                    *int      `json:"hops" db:"hops"`
+	Attempts                int       `json:"attempts" db:"attempts"`
+	FailureReason           *string   `json:"failureReason" db:"failure_reason"`
+}
+
+type probeChannel struct {
+	NodeId            int
+	ChannelId         int
+	LndShortChannelId uint64
+}
+
+type probeAttempt struct {
+	reachable bool
+	// liquidityFailure is true when a smaller amount might still reach the destination
+	liquidityFailure bool
+	feeMsat          int64
+	hops             int
+	failureReason    string
+}
+
+type prober interface {
+	probe(ctx context.Context, channel probeChannel, destinationPublicKey string, amountMsat uint64) (probeAttempt, error)
+}
+
+// ProbeServiceStart probes all destinations through all channels of the node every probeTickerMinutes.
+func ProbeServiceStart(ctx context.Context, conn *grpc.ClientConn, db *sqlx.DB, nodeId int) {
+	err := loadChannelProbes(db, nodeId)
+	if err != nil {
+		log.Error().Err(err).Msgf("Failed to load the latest probe results for nodeId: %v", nodeId)
+	}
+
+	lndProber := newLndProber(conn)
+
+	ticker := time.NewTicker(probeTickerMinutes * time.Minute)
+	defer ticker.Stop()
+
+	for {
+		err = probeNode(ctx, db, lndProber, nodeId)
+		if err != nil {
+			log.Error().Err(err).Msgf("Failed to probe for nodeId: %v", nodeId)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func probeNode(ctx context.Context, db *sqlx.DB, p prober, nodeId int) error {
+	destinations, err := GetProbeDestinations(db)
+	if err != nil {
+		return errors.Wrap(err, "Obtaining the probe destinations")
+	}
+	if len(destinations) == 0 {
+		return nil
+	}
+	for _, channel := range getProbeChannels(nodeId) {
+		var results []ProbeResult
+		for _, destination := range destinations {
+			if ctx.Err() != nil {
+				return nil //nolint:nilerr
+			}
+			result := probeDestination(ctx, p, channel, destination)
+			err = addProbeResult(db, result)
+			if err != nil {
+				return errors.Wrapf(err, "Storing the probe result for channelId: %v", channel.ChannelId)
+			}
+			results = append(results, result)
+		}
+		setChannelProbe(channel.ChannelId, summarizeProbeResults(results))
+	}
+	return nil
+}
+
+// getProbeChannels returns the channels that can send out a probe
+func getProbeChannels(nodeId int) []probeChannel {
+	var channels []probeChannel
+	for _, channelState := range cache.GetChannelStates(nodeId, true) {
+		if channelState.LocalDisabled || channelState.RemoteDisabled {
+			continue
+		}
+		channelSettings := cache.GetChannelSettingByChannelId(channelState.ChannelId)
+		if channelSettings.LndShortChannelId == nil {
+			continue
+		}
+		channels = append(channels, probeChannel{
+			NodeId:            nodeId,
+			ChannelId:         channelState.ChannelId,
+			LndShortChannelId: *channelSettings.LndShortChannelId,
+		})
+	}
+	return channels
+}
+
+// probeDestination bisects the amount when the requested amount fails for lack of liquidity.
+func probeDestination(ctx context.Context, p prober, channel probeChannel, destination ProbeDestination) ProbeResult {
+	result := ProbeResult{
+		Time:                 time.Now().UTC(),
+		NodeId:               channel.NodeId,
+		ChannelId:            channel.ChannelId,
+		DestinationPublicKey: destination.PublicKey,
+		AmountMsat:           destination.AmountMsat,
+	}
+	amountMsat := destination.AmountMsat
+	// Once the destination was reached the failures of the bisection only narrow the liquidity bounds
+	for result.Attempts < probeMaximumAttempts {
+		attempt, err := p.probe(ctx, channel, destination.PublicKey, amountMsat)
+		result.Attempts++
+		if err != nil {
+			if !result.Success {
+				failureReason := err.Error()
+				result.FailureReason = &failureReason
+			}
+			break
+		}
+		switch {
+		case attempt.reachable:
+			result.Success = true
+			result.LiquidityLowerBoundMsat = amountMsat
+			feeMsat := attempt.feeMsat
+			result.FeeMsat = &feeMsat
+			hops := attempt.hops
+			result.Hops = &hops
+			result.FailureReason = nil
+		case attempt.liquidityFailure:
+			upperBoundMsat := amountMsat
+			result.LiquidityUpperBoundMsat = &upperBoundMsat
+			if !result.Success {
+				failureReason := attempt.failureReason
+				result.FailureReason = &failureReason
+			}
+		default:
+			if !result.Success {
+				failureReason := attempt.failureReason
+				result.FailureReason = &failureReason
+			}
+			return result
+		}
+		if result.LiquidityUpperBoundMsat == nil {
+			return result
+		}
+		amountMsat = (result.LiquidityLowerBoundMsat + *result.LiquidityUpperBoundMsat) / 2
+		if amountMsat < probeMinimumAmountMsat ||
+			*result.LiquidityUpperBoundMsat-result.LiquidityLowerBoundMsat < probeMinimumAmountMsat {
+			return result
+		}
+	}
+	return result
+}
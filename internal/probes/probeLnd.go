@@ -0,0 +1,108 @@
+package probes
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+
+	"github.com/cockroachdb/errors"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc"
+
+	"github.com/lncapital/torq/internal/cache"
+	"github.com/lncapital/torq/proto/lnrpc"
+	"github.com/lncapital/torq/proto/lnrpc/routerrpc"
+)
+
+type lndProber struct {
+	client lnrpc.LightningClient
+	router routerrpc.RouterClient
+}
+
+func newLndProber(conn *grpc.ClientConn) *lndProber {
+	return &lndProber{
+		client: lnrpc.NewLightningClient(conn),
+		router: routerrpc.NewRouterClient(conn),
+	}
+}
+
+// probe sends a payment with a random payment hash, the destination rejecting it means it was reachable.
+func (lp *lndProber) probe(ctx context.Context,
+	channel probeChannel,
+	destinationPublicKey string,
+	amountMsat uint64) (probeAttempt, error) {
+
+	destination, err := hex.DecodeString(destinationPublicKey)
+	if err != nil {
+		return probeAttempt{}, errors.Wrapf(err, "Decoding public key: %v", destinationPublicKey)
+	}
+	paymentHash := make([]byte, 32)
+	_, err = rand.Read(paymentHash)
+	if err != nil {
+		return probeAttempt{}, errors.Wrap(err, "Generating the payment hash")
+	}
+
+	// The payments subscription skips the probe until it is removed from the payment history of LND
+	cache.AddProbePaymentHash(hex.EncodeToString(paymentHash))
+	defer cache.RemoveProbePaymentHash(hex.EncodeToString(paymentHash))
+
+	stream, err := lp.router.SendPaymentV2(ctx, &routerrpc.SendPaymentRequest{
+		Dest:              destination,
+		AmtMsat:           int64(amountMsat),
+		PaymentHash:       paymentHash,
+		TimeoutSeconds:    probeTimeoutSeconds,
+		FeeLimitMsat:      int64(amountMsat * probeFeeLimitPpm / 1_000_000),
+		OutgoingChanIds:   []uint64{channel.LndShortChannelId},
+		MaxParts:          1,
+		NoInflightUpdates: true,
+	})
+	if err != nil {
+		return probeAttempt{}, errors.Wrapf(err, "SendPaymentV2 for channelId: %v", channel.ChannelId)
+	}
+	defer lp.deletePayment(paymentHash)
+
+	for {
+		payment, err := stream.Recv()
+		if err != nil {
+			return probeAttempt{}, errors.Wrapf(err, "Receiving the probe status for channelId: %v", channel.ChannelId)
+		}
+		switch payment.Status {
+		case lnrpc.Payment_SUCCEEDED:
+			// Someone knew the preimage of a random payment hash
+			return getReachableProbeAttempt(payment), nil
+		case lnrpc.Payment_FAILED:
+			switch payment.FailureReason {
+			case lnrpc.PaymentFailureReason_FAILURE_REASON_INCORRECT_PAYMENT_DETAILS:
+				return getReachableProbeAttempt(payment), nil
+			case lnrpc.PaymentFailureReason_FAILURE_REASON_NO_ROUTE,
+				lnrpc.PaymentFailureReason_FAILURE_REASON_INSUFFICIENT_BALANCE:
+				return probeAttempt{liquidityFailure: true, failureReason: payment.FailureReason.String()}, nil
+			default:
+				return probeAttempt{failureReason: payment.FailureReason.String()}, nil
+			}
+		}
+	}
+}
+
+func getReachableProbeAttempt(payment *lnrpc.Payment) probeAttempt {
+	attempt := probeAttempt{reachable: true}
+	for _, htlc := range payment.Htlcs {
+		if htlc.Route == nil {
+			continue
+		}
+		if htlc.Status == lnrpc.HTLCAttempt_SUCCEEDED ||
+			(htlc.Failure != nil && htlc.Failure.Code == lnrpc.Failure_INCORRECT_OR_UNKNOWN_PAYMENT_DETAILS) {
+			attempt.feeMsat = htlc.Route.TotalFeesMsat
+			attempt.hops = len(htlc.Route.Hops)
+		}
+	}
+	return attempt
+}
+
+// deletePayment removes the probe from the payment history of LND
+func (lp *lndProber) deletePayment(paymentHash []byte) {
+	_, err := lp.client.DeletePayment(context.Background(), &lnrpc.DeletePaymentRequest{PaymentHash: paymentHash})
+	if err != nil {
+		log.Debug().Err(err).Msgf("Failed to delete the probe with payment hash: %v", hex.EncodeToString(paymentHash))
+	}
+}
@@ -0,0 +1,132 @@
+package probes
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/errors"
+
+	"github.com/lncapital/torq/testutil"
+)
+
+// testProber reaches the destination up to liquidityMsat, failureReason applies after failureAfterAttempts
+type testProber struct {
+	liquidityMsat        uint64
+	failureReason        string
+	failureAfterAttempts int
+	err                  error
+	amountsMsat          []uint64
+}
+
+func (tp *testProber) probe(_ context.Context, _ probeChannel, _ string, amountMsat uint64) (probeAttempt, error) {
+	tp.amountsMsat = append(tp.amountsMsat, amountMsat)
+	if tp.err != nil {
+		return probeAttempt{}, tp.err
+	}
+	if tp.failureReason != "" && len(tp.amountsMsat) > tp.failureAfterAttempts {
+		return probeAttempt{failureReason: tp.failureReason}, nil
+	}
+	if amountMsat <= tp.liquidityMsat {
+		return probeAttempt{reachable: true, feeMsat: 1_000, hops: 3}, nil
+	}
+	return probeAttempt{liquidityFailure: true, failureReason: "FAILURE_REASON_NO_ROUTE"}, nil
+}
+
+func TestProbeDestination(t *testing.T) {
+	destination := ProbeDestination{PublicKey: "destination", AmountMsat: 800_000_000}
+	testCases := []struct {
+		name           string
+		prober         *testProber
+		wantSuccess    bool
+		wantLowerMsat  uint64
+		wantUpperMsat  *uint64
+		wantAttempts   int
+		wantFailure    bool
+		wantAmountMsat []uint64
+	}{
+		{name: "reachable",
+			prober:         &testProber{liquidityMsat: 1_000_000_000},
+			wantSuccess:    true,
+			wantLowerMsat:  800_000_000,
+			wantAttempts:   1,
+			wantAmountMsat: []uint64{800_000_000}},
+		{name: "bisection",
+			prober:         &testProber{liquidityMsat: 300_000_000},
+			wantSuccess:    true,
+			wantLowerMsat:  300_000_000,
+			wantUpperMsat:  uint64Pointer(350_000_000),
+			wantAttempts:   5,
+			wantAmountMsat: []uint64{800_000_000, 400_000_000, 200_000_000, 300_000_000, 350_000_000}},
+		{name: "failure after reaching the destination",
+			prober:         &testProber{liquidityMsat: 500_000_000, failureReason: "FAILURE_REASON_TIMEOUT", failureAfterAttempts: 2},
+			wantSuccess:    true,
+			wantLowerMsat:  400_000_000,
+			wantUpperMsat:  uint64Pointer(800_000_000),
+			wantAttempts:   3,
+			wantAmountMsat: []uint64{800_000_000, 400_000_000, 600_000_000}},
+		{name: "unreachable",
+			prober:         &testProber{failureReason: "FAILURE_REASON_TIMEOUT"},
+			wantAttempts:   1,
+			wantFailure:    true,
+			wantAmountMsat: []uint64{800_000_000}},
+		{name: "error",
+			prober:         &testProber{err: errors.New("connection lost")},
+			wantAttempts:   1,
+			wantFailure:    true,
+			wantAmountMsat: []uint64{800_000_000}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result := probeDestination(context.Background(), tc.prober, probeChannel{ChannelId: 1}, destination)
+			if result.Success != tc.wantSuccess || result.LiquidityLowerBoundMsat != tc.wantLowerMsat ||
+				result.Attempts != tc.wantAttempts || (result.FailureReason != nil) != tc.wantFailure {
+				testutil.Errorf(t, "probeDestination() = %+v", result)
+				return
+			}
+			if (result.LiquidityUpperBoundMsat == nil) != (tc.wantUpperMsat == nil) ||
+				(tc.wantUpperMsat != nil && *result.LiquidityUpperBoundMsat != *tc.wantUpperMsat) {
+				testutil.Errorf(t, "probeDestination() upper bound = %v, want %v", result.LiquidityUpperBoundMsat, tc.wantUpperMsat)
+				return
+			}
+			if len(tc.prober.amountsMsat) != len(tc.wantAmountMsat) {
+				testutil.Errorf(t, "probeDestination() probed %v, want %v", tc.prober.amountsMsat, tc.wantAmountMsat)
+				return
+			}
+			for i := range tc.wantAmountMsat {
+				if tc.prober.amountsMsat[i] != tc.wantAmountMsat[i] {
+					testutil.Errorf(t, "probeDestination() probed %v, want %v", tc.prober.amountsMsat, tc.wantAmountMsat)
+					return
+				}
+			}
+			testutil.Successf(t, "probeDestination() = %+v", result)
+		})
+	}
+}
+
+func TestSummarizeProbeResults(t *testing.T) {
+	if summarizeProbeResults(nil) != nil {
+		testutil.Errorf(t, "summarizeProbeResults(nil) should be nil")
+	}
+	probedOn := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	feeMsat := int64(500_000)
+	channelProbe := summarizeProbeResults([]ProbeResult{
+		{Time: probedOn, Success: true, LiquidityLowerBoundMsat: 1_000_000_000, FeeMsat: &feeMsat},
+		{Time: probedOn.Add(time.Minute), Success: true, LiquidityLowerBoundMsat: 500_000_000, FeeMsat: &feeMsat},
+		{Time: probedOn, Success: false},
+		{Time: probedOn, Success: false},
+	})
+	if channelProbe == nil {
+		testutil.Fatalf(t, "summarizeProbeResults() should not be nil")
+	}
+	if channelProbe.Reachability != 0.5 || channelProbe.FeePpm != 750 ||
+		channelProbe.LiquidityLowerBoundSat != 500_000 || !channelProbe.ProbedOn.Equal(probedOn.Add(time.Minute)) {
+		testutil.Errorf(t, "summarizeProbeResults() = %+v", *channelProbe)
+		return
+	}
+	testutil.Successf(t, "summarizeProbeResults() = %+v", *channelProbe)
+}
+
+func uint64Pointer(value uint64) *uint64 {
+	return &value
+}
@@ -0,0 +1,73 @@
+package probes
+
+import (
+	"sync"
+	"time"
+)
+
+// ChannelProbe summarizes the latest probe round of a channel for the channels view and the workflow filters.
+type ChannelProbe struct {
+	// Reachability is the share (0-1) of the destinations that were reachable through the channel
+	Reachability float64
+	// FeePpm is the average fee rate to the reachable destinations
+	FeePpm float64
+	// LiquidityLowerBoundSat is the amount that reached every reachable destination
+	LiquidityLowerBoundSat int64
+	ProbedOn               time.Time
+}
+
+var (
+	channelProbesMutex sync.RWMutex                 //nolint:gochecknoglobals
+	channelProbes      = make(map[int]ChannelProbe) //nolint:gochecknoglobals
+)
+
+// GetChannelProbe returns nil when the channel was never probed
+func GetChannelProbe(channelId int) *ChannelProbe {
+	channelProbesMutex.RLock()
+	defer channelProbesMutex.RUnlock()
+	channelProbe, exists := channelProbes[channelId]
+	if !exists {
+		return nil
+	}
+	return &channelProbe
+}
+
+func setChannelProbe(channelId int, channelProbe *ChannelProbe) {
+	if channelProbe == nil {
+		return
+	}
+	channelProbesMutex.Lock()
+	defer channelProbesMutex.Unlock()
+	channelProbes[channelId] = *channelProbe
+}
+
+// summarizeProbeResults expects the results of a single channel
+func summarizeProbeResults(results []ProbeResult) *ChannelProbe {
+	if len(results) == 0 {
+		return nil
+	}
+	channelProbe := ChannelProbe{}
+	reachable := 0
+	var feePpmTotal float64
+	for _, result := range results {
+		if result.Time.After(channelProbe.ProbedOn) {
+			channelProbe.ProbedOn = result.Time
+		}
+		if !result.Success {
+			continue
+		}
+		lowerBoundSat := int64(result.LiquidityLowerBoundMsat / 1000)
+		if reachable == 0 || lowerBoundSat < channelProbe.LiquidityLowerBoundSat {
+			channelProbe.LiquidityLowerBoundSat = lowerBoundSat
+		}
+		if result.FeeMsat != nil && result.LiquidityLowerBoundMsat != 0 {
+			feePpmTotal += float64(*result.FeeMsat) * 1_000_000 / float64(result.LiquidityLowerBoundMsat)
+		}
+		reachable++
+	}
+	channelProbe.Reachability = float64(reachable) / float64(len(results))
+	if reachable != 0 {
+		channelProbe.FeePpm = feePpmTotal / float64(reachable)
+	}
+	return &channelProbe
+}
@@ -0,0 +1,123 @@
+package probes
+
+import (
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/cockroachdb/errors"
+	"github.com/gin-gonic/gin"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/lncapital/torq/internal/cache"
+	"github.com/lncapital/torq/internal/core"
+	"github.com/lncapital/torq/internal/database"
+	"github.com/lncapital/torq/pkg/server_errors"
+)
+
+func RegisterProbeRoutes(r *gin.RouterGroup, db *sqlx.DB) {
+	r.GET("destinations", func(c *gin.Context) { getProbeDestinationsHandler(c, db) })
+	r.POST("destinations", func(c *gin.Context) { addProbeDestinationHandler(c, db) })
+	r.PUT("destinations", func(c *gin.Context) { setProbeDestinationHandler(c, db) })
+	r.DELETE("destinations/:probeDestinationId", func(c *gin.Context) { removeProbeDestinationHandler(c, db) })
+	r.GET("results", func(c *gin.Context) { getProbeResultsHandler(c, db) })
+}
+
+func getProbeDestinationsHandler(c *gin.Context, db *sqlx.DB) {
+	destinations, err := GetProbeDestinations(db)
+	if err != nil {
+		server_errors.WrapLogAndSendServerError(c, err, "Getting probe destinations.")
+		return
+	}
+	c.JSON(http.StatusOK, destinations)
+}
+
+func addProbeDestinationHandler(c *gin.Context, db *sqlx.DB) {
+	var destination ProbeDestination
+	if err := c.BindJSON(&destination); err != nil {
+		server_errors.SendBadRequestFromError(c, errors.Wrap(err, server_errors.JsonParseError))
+		return
+	}
+	if err := validateProbeDestination(destination); err != nil {
+		server_errors.SendUnprocessableEntity(c, err.Error())
+		return
+	}
+	storedDestination, err := addProbeDestination(db, destination)
+	if err != nil {
+		if errors.Is(err, database.SqlUniqueConstraintError) {
+			server_errors.SendUnprocessableEntity(c, "The destination is already probed.")
+			return
+		}
+		server_errors.WrapLogAndSendServerError(c, err, "Adding probe destination.")
+		return
+	}
+	c.JSON(http.StatusOK, storedDestination)
+}
+
+func setProbeDestinationHandler(c *gin.Context, db *sqlx.DB) {
+	var destination ProbeDestination
+	if err := c.BindJSON(&destination); err != nil {
+		server_errors.SendBadRequestFromError(c, errors.Wrap(err, server_errors.JsonParseError))
+		return
+	}
+	if err := validateProbeDestination(destination); err != nil {
+		server_errors.SendUnprocessableEntity(c, err.Error())
+		return
+	}
+	storedDestination, err := setProbeDestination(db, destination)
+	if err != nil {
+		if errors.Is(err, database.SqlUniqueConstraintError) {
+			server_errors.SendUnprocessableEntity(c, "The destination is already probed.")
+			return
+		}
+		server_errors.WrapLogAndSendServerError(c, err,
+			fmt.Sprintf("Setting probe destination for probeDestinationId: %v", destination.ProbeDestinationId))
+		return
+	}
+	c.JSON(http.StatusOK, storedDestination)
+}
+
+func removeProbeDestinationHandler(c *gin.Context, db *sqlx.DB) {
+	probeDestinationId, err := strconv.Atoi(c.Param("probeDestinationId"))
+	if err != nil {
+		server_errors.SendBadRequest(c, "Failed to find/parse probeDestinationId in the request.")
+		return
+	}
+	err = removeProbeDestination(db, probeDestinationId)
+	if err != nil {
+		server_errors.WrapLogAndSendServerError(c, err,
+			fmt.Sprintf("Removing probe destination for probeDestinationId: %v", probeDestinationId))
+		return
+	}
+	c.JSON(http.StatusOK, nil)
+}
+
+// getProbeResultsHandler returns the latest result by channel and destination
+func getProbeResultsHandler(c *gin.Context, db *sqlx.DB) {
+	network, err := strconv.Atoi(c.Query("network"))
+	if err != nil {
+		server_errors.SendBadRequest(c, "Can't process network")
+		return
+	}
+	results, err := getLatestProbeResults(db, cache.GetAllTorqNodeIdsByNetwork(core.Bitcoin, core.Network(network)))
+	if err != nil {
+		server_errors.WrapLogAndSendServerError(c, err, "Getting probe results.")
+		return
+	}
+	c.JSON(http.StatusOK, results)
+}
+
+func validateProbeDestination(destination ProbeDestination) error {
+	if destination.Name == "" {
+		return errors.New("Failed to find name in the request.")
+	}
+	publicKey, err := hex.DecodeString(destination.PublicKey)
+	if err != nil || len(publicKey) != 33 {
+		return errors.New(fmt.Sprintf("Invalid public key: %v", destination.PublicKey))
+	}
+	if destination.AmountMsat < probeMinimumAmountMsat {
+		return errors.New(fmt.Sprintf("The amount should be at least %v msat", probeMinimumAmountMsat))
+	}
+	return nil
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/cockroachdb/errors"
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 
 	"github.com/lncapital/torq/internal/core"
 	"github.com/lncapital/torq/internal/database"
@@ -86,3 +87,196 @@ func GetLatestResultByOrigin(db *sqlx.DB,
 	}
 	return rebalanceResult, nil
 }
+
+// GetRebalanceChannelStatistics returns the rebalance statistics of the channelIds since the provided time.
+// When incoming is true the statistics are based on the rebalances where the channel was the incoming channel.
+func GetRebalanceChannelStatistics(db *sqlx.DB,
+	incoming bool, channelIds []int, since time.Time) (map[int]RebalanceChannelStatistics, error) {
+
+	channelIdColumn := "outgoing_channel_id"
+	if incoming {
+		channelIdColumn = "incoming_channel_id"
+	}
+	var statistics []RebalanceChannelStatistics
+	err := db.Select(&statistics, `
+		SELECT `+channelIdColumn+` AS channel_id,
+		       COUNT(*) AS attempt_count,
+		       COUNT(*) FILTER (WHERE status=$3) AS success_count,
+		       COALESCE(SUM(total_amount_msat) FILTER (WHERE status=$3), 0) AS success_amount_msat,
+		       COALESCE(SUM(total_fee_msat) FILTER (WHERE status=$3), 0) AS success_fee_msat
+		FROM rebalance_log
+		WHERE `+channelIdColumn+`=ANY($1) AND created_on>=$2
+		GROUP BY `+channelIdColumn+`;`,
+		pq.Array(channelIds), since, core.Active)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Getting rebalance statistics for channelIds: %v", channelIds)
+	}
+	statisticsByChannelId := make(map[int]RebalanceChannelStatistics)
+	for _, channelStatistics := range statistics {
+		statisticsByChannelId[channelStatistics.ChannelId] = channelStatistics
+	}
+	return statisticsByChannelId, nil
+}
+
+func AddRebalanceHopFailure(db *sqlx.DB,
+	fromPublicKey string, toPublicKey string, shortChannelId string, amountMsat uint64) error {
+
+	now := time.Now().UTC()
+	_, err := db.Exec(`
+		INSERT INTO rebalance_hop_memory (from_public_key, to_public_key, short_channel_id,
+		                                  fail_amount_msat, fail_time, fail_count, created_on, updated_on)
+		VALUES ($1, $2, NULLIF($3, ''), $4, $5, 1, $5, $5)
+		ON CONFLICT (from_public_key, to_public_key) DO UPDATE
+		SET short_channel_id=COALESCE(EXCLUDED.short_channel_id, rebalance_hop_memory.short_channel_id),
+		    fail_amount_msat=EXCLUDED.fail_amount_msat,
+		    fail_time=EXCLUDED.fail_time,
+		    fail_count=rebalance_hop_memory.fail_count+1,
+		    updated_on=EXCLUDED.updated_on;`,
+		fromPublicKey, toPublicKey, shortChannelId, amountMsat, now)
+	if err != nil {
+		return errors.Wrap(err, database.SqlExecutionError)
+	}
+	return nil
+}
+
+func AddRebalanceHopSuccess(db *sqlx.DB,
+	fromPublicKey string, toPublicKey string, shortChannelId string, amountMsat uint64) error {
+
+	now := time.Now().UTC()
+	_, err := db.Exec(`
+		INSERT INTO rebalance_hop_memory (from_public_key, to_public_key, short_channel_id,
+		                                  success_amount_msat, success_time, success_count, created_on, updated_on)
+		VALUES ($1, $2, NULLIF($3, ''), $4, $5, 1, $5, $5)
+		ON CONFLICT (from_public_key, to_public_key) DO UPDATE
+		SET short_channel_id=COALESCE(EXCLUDED.short_channel_id, rebalance_hop_memory.short_channel_id),
+		    success_amount_msat=EXCLUDED.success_amount_msat,
+		    success_time=EXCLUDED.success_time,
+		    success_count=rebalance_hop_memory.success_count+1,
+		    updated_on=EXCLUDED.updated_on;`,
+		fromPublicKey, toPublicKey, shortChannelId, amountMsat, now)
+	if err != nil {
+		return errors.Wrap(err, database.SqlExecutionError)
+	}
+	return nil
+}
+
+// GetRebalanceHopMemories returns the hop memories that were updated since the provided time.
+// When publicKey is not empty only the pairs with that node are returned.
+func GetRebalanceHopMemories(db *sqlx.DB, since time.Time, publicKey string) ([]RebalanceHopMemory, error) {
+	var hopMemories []RebalanceHopMemory
+	err := db.Select(&hopMemories, `
+		SELECT *
+		FROM rebalance_hop_memory
+		WHERE updated_on>=$1 AND ($2='' OR from_public_key=$2 OR to_public_key=$2)
+		ORDER BY updated_on DESC;`, since, publicKey)
+	if err != nil {
+		return nil, errors.Wrap(err, database.SqlExecutionError)
+	}
+	return hopMemories, nil
+}
+
+func AddRebalanceBudget(db *sqlx.DB, rebalanceBudget RebalanceBudget) (RebalanceBudget, error) {
+	rebalanceBudget.CreatedOn = time.Now().UTC()
+	rebalanceBudget.UpdateOn = rebalanceBudget.CreatedOn
+	err := db.QueryRowx(`
+		INSERT INTO rebalance_budget (node_id, tag_id, workflow_id, maximum_fee_msat, period_days, created_on, updated_on)
+		VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING rebalance_budget_id;`,
+		rebalanceBudget.NodeId, rebalanceBudget.TagId, rebalanceBudget.WorkflowId,
+		rebalanceBudget.MaximumFeeMsat, rebalanceBudget.PeriodDays,
+		rebalanceBudget.CreatedOn, rebalanceBudget.UpdateOn).
+		Scan(&rebalanceBudget.RebalanceBudgetId)
+	if err != nil {
+		return RebalanceBudget{}, errors.Wrap(err, database.SqlExecutionError)
+	}
+	return rebalanceBudget, nil
+}
+
+func SetRebalanceBudget(db *sqlx.DB, rebalanceBudget RebalanceBudget) (RebalanceBudget, error) {
+	rebalanceBudget.UpdateOn = time.Now().UTC()
+	_, err := db.Exec(`
+		UPDATE rebalance_budget
+		SET node_id=$1, tag_id=$2, workflow_id=$3, maximum_fee_msat=$4, period_days=$5, updated_on=$6
+		WHERE rebalance_budget_id=$7;`,
+		rebalanceBudget.NodeId, rebalanceBudget.TagId, rebalanceBudget.WorkflowId,
+		rebalanceBudget.MaximumFeeMsat, rebalanceBudget.PeriodDays,
+		rebalanceBudget.UpdateOn, rebalanceBudget.RebalanceBudgetId)
+	if err != nil {
+		return RebalanceBudget{}, errors.Wrapf(err, "Update rebalance budget %v", rebalanceBudget.RebalanceBudgetId)
+	}
+	return rebalanceBudget, nil
+}
+
+func RemoveRebalanceBudget(db *sqlx.DB, rebalanceBudgetId int) error {
+	_, err := db.Exec(`DELETE FROM rebalance_budget WHERE rebalance_budget_id=$1;`, rebalanceBudgetId)
+	if err != nil {
+		return errors.Wrap(err, database.SqlExecutionError)
+	}
+	return nil
+}
+
+// rebalanceBudgetSpendSql calculates the fees of the successful rebalances within the period of each budget.
+// $1 is the workflow origin and $2 the successful status.
+const rebalanceBudgetSpendSql = `
+	SELECT rb.*, COALESCE(spent.fee_msat, 0) AS spent_fee_msat
+	FROM rebalance_budget rb
+	LEFT JOIN LATERAL (
+		SELECT SUM(rl.total_fee_msat) AS fee_msat
+		FROM rebalance_log rl
+		JOIN rebalance r ON r.rebalance_id=rl.rebalance_id
+		JOIN channel oc ON oc.channel_id=rl.outgoing_channel_id
+		JOIN channel ic ON ic.channel_id=rl.incoming_channel_id
+		LEFT JOIN workflow_version_node wvn ON r.origin=$1 AND wvn.workflow_version_node_id=r.origin_id
+		LEFT JOIN workflow_version wv ON wv.workflow_version_id=wvn.workflow_version_id
+		WHERE rl.status=$2 AND rl.created_on>=NOW()-rb.period_days*INTERVAL '1 day' AND (
+			rb.node_id IN (oc.first_node_id, oc.second_node_id) OR
+			wv.workflow_id=rb.workflow_id OR
+			EXISTS (
+				SELECT 1
+				FROM tagged_entity te
+				WHERE te.tag_id=rb.tag_id AND (
+					te.channel_id IN (ic.channel_id, oc.channel_id) OR
+					te.node_id IN (ic.first_node_id, ic.second_node_id, oc.first_node_id, oc.second_node_id))
+			)
+		)
+	) spent ON TRUE`
+
+func GetRebalanceBudgetSpends(db *sqlx.DB) ([]RebalanceBudgetSpend, error) {
+	var rebalanceBudgetSpends []RebalanceBudgetSpend
+	err := db.Select(&rebalanceBudgetSpends, rebalanceBudgetSpendSql+`
+		ORDER BY rb.rebalance_budget_id;`,
+		lightning_helpers.RebalanceWorkflowNode, core.Active)
+	if err != nil {
+		return nil, errors.Wrap(err, database.SqlExecutionError)
+	}
+	return rebalanceBudgetSpends, nil
+}
+
+// GetApplicableRebalanceBudgetSpends returns the budgets of the node, of the tags on the incoming and outgoing channel
+// (or their nodes) and of the workflow of the workflowVersionNodeId (0 when the rebalance did not originate from a
+// workflow). Both channels are used just like in rebalanceBudgetSpendSql so a tag budget is verified where it is spent.
+// A channel id of 0 is ignored i.e. when the counterpart channel is not known yet.
+func GetApplicableRebalanceBudgetSpends(db *sqlx.DB,
+	nodeId int, incomingChannelId int, outgoingChannelId int, workflowVersionNodeId int) ([]RebalanceBudgetSpend, error) {
+
+	var rebalanceBudgetSpends []RebalanceBudgetSpend
+	err := db.Select(&rebalanceBudgetSpends, rebalanceBudgetSpendSql+`
+		WHERE rb.node_id=$3 OR
+		      rb.workflow_id=(
+		          SELECT wv.workflow_id
+		          FROM workflow_version_node wvn
+		          JOIN workflow_version wv ON wv.workflow_version_id=wvn.workflow_version_id
+		          WHERE wvn.workflow_version_node_id=$5) OR
+		      rb.tag_id IN (
+		          SELECT te.tag_id
+		          FROM tagged_entity te
+		          JOIN channel c ON c.channel_id IN ($4, $6)
+		          WHERE te.channel_id=c.channel_id OR te.node_id IN (c.first_node_id, c.second_node_id))
+		ORDER BY rb.rebalance_budget_id;`,
+		lightning_helpers.RebalanceWorkflowNode, core.Active, nodeId, incomingChannelId, workflowVersionNodeId,
+		outgoingChannelId)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Getting rebalance budgets for nodeId: %v, incomingChannelId: %v, "+
+			"outgoingChannelId: %v", nodeId, incomingChannelId, outgoingChannelId)
+	}
+	return rebalanceBudgetSpends, nil
+}
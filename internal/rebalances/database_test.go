@@ -0,0 +1,89 @@
+package rebalances
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/lncapital/torq/internal/core"
+	"github.com/lncapital/torq/internal/tags"
+	"github.com/lncapital/torq/testutil"
+)
+
+func TestGetApplicableRebalanceBudgetSpendsOfCounterpartTag(t *testing.T) {
+	srv, err := testutil.InitTestDBConn()
+	if err != nil {
+		panic(err)
+	}
+
+	db, cancel, err := srv.NewTestDatabase(true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cancel()
+
+	focusChannelId := getTestChannelId(t, db, 1111)
+	counterpartChannelId := getTestChannelId(t, db, 2222)
+
+	var tagId int
+	err = db.QueryRowx(`INSERT INTO tag (name, style, created_on, updated_on) VALUES ($1, $2, $3, $3) RETURNING tag_id;`,
+		"counterpart", "primary", time.Now().UTC()).Scan(&tagId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The tag sits only on the counterpart channel
+	err = tags.TagEntity(db, tags.TagEntityRequest{TagId: tagId, ChannelId: &counterpartChannelId})
+	if err != nil {
+		t.Fatal(err)
+	}
+	rebalanceBudget, err := AddRebalanceBudget(db, RebalanceBudget{
+		TagId:          &tagId,
+		MaximumFeeMsat: 1_000_000,
+		PeriodDays:     7,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		name              string
+		incomingChannelId int
+		outgoingChannelId int
+		want              bool
+	}{
+		{name: "focus channel only", incomingChannelId: focusChannelId, want: false},
+		{name: "counterpart incoming", incomingChannelId: counterpartChannelId, outgoingChannelId: focusChannelId, want: true},
+		{name: "counterpart outgoing", incomingChannelId: focusChannelId, outgoingChannelId: counterpartChannelId, want: true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			rebalanceBudgetSpends, err := GetApplicableRebalanceBudgetSpends(db,
+				0, tc.incomingChannelId, tc.outgoingChannelId, 0)
+			if err != nil {
+				testutil.Fatalf(t, "GetApplicableRebalanceBudgetSpends() error = %v", err)
+			}
+			got := false
+			for _, rebalanceBudgetSpend := range rebalanceBudgetSpends {
+				if rebalanceBudgetSpend.RebalanceBudgetId == rebalanceBudget.RebalanceBudgetId {
+					got = true
+				}
+			}
+			if got != tc.want {
+				testutil.Errorf(t, "GetApplicableRebalanceBudgetSpends() contains tag budget = %v, want %v", got, tc.want)
+				return
+			}
+			testutil.Successf(t, "GetApplicableRebalanceBudgetSpends() contains tag budget = %v", got)
+		})
+	}
+}
+
+func getTestChannelId(t *testing.T, db *sqlx.DB, lndShortChannelId uint64) int {
+	var channelId int
+	err := db.Get(&channelId, `SELECT channel_id FROM channel WHERE short_channel_id=$1;`,
+		core.ConvertLNDShortChannelID(lndShortChannelId))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return channelId
+}
@@ -46,3 +46,46 @@ type RebalanceResult struct {
 	CreatedOn         time.Time   `json:"createdOn" db:"created_on"`
 	UpdateOn          time.Time   `json:"updatedOn" db:"updated_on"`
 }
+
+// RebalanceChannelStatistics summarizes the historic rebalance attempts of a channel in one direction.
+type RebalanceChannelStatistics struct {
+	ChannelId         int    `json:"channelId" db:"channel_id"`
+	AttemptCount      int    `json:"attemptCount" db:"attempt_count"`
+	SuccessCount      int    `json:"successCount" db:"success_count"`
+	SuccessAmountMsat uint64 `json:"successAmountMsat" db:"success_amount_msat"`
+	SuccessFeeMsat    uint64 `json:"successFeeMsat" db:"success_fee_msat"`
+}
+
+// RebalanceHopMemory is the shared memory of the rebalance attempts over a pair of nodes.
+// Only the latest failure and the latest success are remembered (like LND's mission control).
+type RebalanceHopMemory struct {
+	FromPublicKey     string     `json:"fromPublicKey" db:"from_public_key"`
+	ToPublicKey       string     `json:"toPublicKey" db:"to_public_key"`
+	ShortChannelId    *string    `json:"shortChannelId" db:"short_channel_id"`
+	FailAmountMsat    *uint64    `json:"failAmountMsat" db:"fail_amount_msat"`
+	FailTime          *time.Time `json:"failTime" db:"fail_time"`
+	FailCount         int        `json:"failCount" db:"fail_count"`
+	SuccessAmountMsat *uint64    `json:"successAmountMsat" db:"success_amount_msat"`
+	SuccessTime       *time.Time `json:"successTime" db:"success_time"`
+	SuccessCount      int        `json:"successCount" db:"success_count"`
+	CreatedOn         time.Time  `json:"createdOn" db:"created_on"`
+	UpdateOn          time.Time  `json:"updatedOn" db:"updated_on"`
+}
+
+// RebalanceBudget limits the total fees of successful rebalances over a rolling period.
+// A budget applies to exactly one of: a node, a tag (of a channel or its peer) or a workflow.
+type RebalanceBudget struct {
+	RebalanceBudgetId int       `json:"rebalanceBudgetId" db:"rebalance_budget_id"`
+	NodeId            *int      `json:"nodeId" db:"node_id"`
+	TagId             *int      `json:"tagId" db:"tag_id"`
+	WorkflowId        *int      `json:"workflowId" db:"workflow_id"`
+	MaximumFeeMsat    uint64    `json:"maximumFeeMsat" db:"maximum_fee_msat"`
+	PeriodDays        int       `json:"periodDays" db:"period_days"`
+	CreatedOn         time.Time `json:"createdOn" db:"created_on"`
+	UpdateOn          time.Time `json:"updatedOn" db:"updated_on"`
+}
+
+type RebalanceBudgetSpend struct {
+	RebalanceBudget
+	SpentFeeMsat uint64 `json:"spentFeeMsat" db:"spent_fee_msat"`
+}
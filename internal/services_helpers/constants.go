@@ -41,6 +41,8 @@ const (
 	ClnServiceInvoicesService
 	ClnServiceHtlcsService
 	ClnServiceTransactionsService
+	ClnServiceRebalanceService
+	LndServiceProbeService
 )
 
 type ServiceStatus int
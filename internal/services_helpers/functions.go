@@ -54,6 +54,7 @@ func GetLndServiceTypes() []ServiceType {
 		LndServicePeerEventStream,
 		LndServiceInFlightPaymentsService,
 		LndServiceChannelBalanceCacheService,
+		LndServiceProbeService,
 	}
 }
 
@@ -65,7 +66,11 @@ func GetClnServiceTypes() []ServiceType {
 		ClnServiceChannelsService,
 		ClnServiceFundsService,
 		ClnServiceNodesService,
+		ClnServiceForwardsService,
+		ClnServiceInvoicesService,
+		ClnServiceHtlcsService,
 		ClnServiceTransactionsService,
+		ClnServiceRebalanceService,
 	}
 }
 
@@ -122,6 +127,8 @@ func (st *ServiceType) String() string {
 		return "LndServiceInFlightPaymentsService"
 	case LndServiceChannelBalanceCacheService:
 		return "LndServiceChannelBalanceCacheService"
+	case LndServiceProbeService:
+		return "LndServiceProbeService"
 	case ClnServiceVectorService:
 		return "ClnServiceVectorService"
 	case ClnServiceAmbossService:
@@ -134,8 +141,16 @@ func (st *ServiceType) String() string {
 		return "ClnServiceFundsService"
 	case ClnServiceNodesService:
 		return "ClnServiceNodesService"
+	case ClnServiceForwardsService:
+		return "ClnServiceForwardsService"
+	case ClnServiceInvoicesService:
+		return "ClnServiceInvoicesService"
+	case ClnServiceHtlcsService:
+		return "ClnServiceHtlcsService"
 	case ClnServiceTransactionsService:
 		return "ClnServiceTransactionsService"
+	case ClnServiceRebalanceService:
+		return "ClnServiceRebalanceService"
 	}
 	return core.UnknownEnumString
 }
@@ -151,6 +166,8 @@ func (st *ServiceType) IsChannelBalanceCache() bool {
 		*st == ClnServiceChannelsService ||
 		*st == ClnServiceFundsService ||
 		*st == ClnServiceNodesService ||
+		*st == ClnServiceForwardsService ||
+		*st == ClnServiceInvoicesService ||
 		*st == ClnServiceTransactionsService) {
 		return true
 	}
@@ -170,7 +187,8 @@ func (st *ServiceType) IsLndService() bool {
 		*st == LndServicePaymentsService ||
 		*st == LndServicePeerEventStream ||
 		*st == LndServiceInFlightPaymentsService ||
-		*st == LndServiceChannelBalanceCacheService) {
+		*st == LndServiceChannelBalanceCacheService ||
+		*st == LndServiceProbeService) {
 		return true
 	}
 	return false
@@ -183,12 +201,23 @@ func (st *ServiceType) IsClnService() bool {
 		*st == ClnServiceChannelsService ||
 		*st == ClnServiceFundsService ||
 		*st == ClnServiceNodesService ||
-		*st == ClnServiceTransactionsService) {
+		*st == ClnServiceForwardsService ||
+		*st == ClnServiceInvoicesService ||
+		*st == ClnServiceHtlcsService ||
+		*st == ClnServiceTransactionsService ||
+		*st == ClnServiceRebalanceService) {
 		return true
 	}
 	return false
 }
 
+func GetRebalanceServiceType(implementation core.Implementation) ServiceType {
+	if implementation == core.CLN {
+		return ClnServiceRebalanceService
+	}
+	return LndServiceRebalanceService
+}
+
 func (st *ServiceType) GetImplementation() *core.Implementation {
 	if st == nil {
 		return nil
@@ -211,13 +240,13 @@ func (st *ServiceType) GetNodeConnectionDetailCustomSettings() []core.NodeConnec
 	switch *st {
 	case LndServicePaymentsService:
 		return []core.NodeConnectionDetailCustomSettings{core.ImportFailedPayments, core.ImportPayments}
-	case LndServiceHtlcEventStream:
+	case LndServiceHtlcEventStream, ClnServiceHtlcsService:
 		return []core.NodeConnectionDetailCustomSettings{core.ImportHtlcEvents}
 	case LndServiceTransactionStream:
 		return []core.NodeConnectionDetailCustomSettings{core.ImportTransactions}
-	case LndServiceInvoiceStream:
+	case LndServiceInvoiceStream, ClnServiceInvoicesService:
 		return []core.NodeConnectionDetailCustomSettings{core.ImportInvoices}
-	case LndServiceForwardsService:
+	case LndServiceForwardsService, ClnServiceForwardsService:
 		return []core.NodeConnectionDetailCustomSettings{core.ImportForwards, core.ImportHistoricForwards}
 	default:
 		log.Error().Msgf("DEVELOPMENT ERROR: ServiceType not supported")
@@ -12,11 +12,18 @@ import (
 	"github.com/lncapital/torq/internal/cache"
 	"github.com/lncapital/torq/internal/core"
 	"github.com/lncapital/torq/internal/database"
+	"github.com/lncapital/torq/internal/encryption"
 )
 
 func getSettings(db *sqlx.DB) (settings, error) {
 	var settingsData settings
-	err := db.Get(&settingsData, `SELECT * FROM settings LIMIT 1;`)
+	err := db.Get(&settingsData, `
+		SELECT settings_id, default_date_range, default_language, preferred_timezone, week_starts_on, torq_uuid,
+			mixpanel_opt_out, slack_oauth_token, slack_bot_app_token, telegram_high_priority_credentials,
+			telegram_low_priority_credentials, approval_threshold_sat, approval_expiry_minutes,
+			htlc_failure_burst_window_minutes, htlc_failure_burst_threshold, created_on, updated_on
+		FROM settings
+		LIMIT 1;`)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return settings{}, nil
@@ -34,6 +41,8 @@ func InitializeSettingsCache(db *sqlx.DB) error {
 			settingsData.PreferredTimezone, settingsData.TorqUuid, settingsData.MixpanelOptOut,
 			settingsData.SlackOAuthToken, settingsData.SlackBotAppToken,
 			settingsData.TelegramHighPriorityCredentials, settingsData.TelegramLowPriorityCredentials)
+		cache.SetHtlcFailureBurstSettings(settingsData.HtlcFailureBurstWindowMinutes,
+			settingsData.HtlcFailureBurstThreshold)
 	} else {
 		log.Error().Err(err).Msg("Failed to obtain settings for SettingsCache cache.")
 	}
@@ -63,11 +72,16 @@ func updateSettings(db *sqlx.DB, settings settings) (err error) {
 		  slack_bot_app_token = $7,
 		  telegram_high_priority_credentials = $8,
 		  telegram_low_priority_credentials = $9,
-		  updated_on = $10;`,
+		  approval_threshold_sat = $10,
+		  approval_expiry_minutes = $11,
+		  htlc_failure_burst_window_minutes = $12,
+		  htlc_failure_burst_threshold = $13,
+		  updated_on = $14;`,
 		settings.DefaultDateRange, settings.DefaultLanguage, settings.PreferredTimezone, settings.WeekStartsOn,
 		settings.MixpanelOptOut, settings.SlackOAuthToken, settings.SlackBotAppToken,
 		settings.TelegramHighPriorityCredentials, settings.TelegramLowPriorityCredentials,
-		time.Now().UTC())
+		settings.ApprovalThresholdSat, settings.ApprovalExpiryMinutes,
+		settings.HtlcFailureBurstWindowMinutes, settings.HtlcFailureBurstThreshold, time.Now().UTC())
 	if err != nil {
 		return errors.Wrap(err, database.SqlExecutionError)
 	}
@@ -75,6 +89,7 @@ func updateSettings(db *sqlx.DB, settings settings) (err error) {
 		settings.PreferredTimezone, settings.TorqUuid, settings.MixpanelOptOut,
 		settings.SlackOAuthToken, settings.SlackBotAppToken,
 		settings.TelegramHighPriorityCredentials, settings.TelegramLowPriorityCredentials)
+	cache.SetHtlcFailureBurstSettings(settings.HtlcFailureBurstWindowMinutes, settings.HtlcFailureBurstThreshold)
 	return nil
 }
 
@@ -87,6 +102,10 @@ func getNodeConnectionDetails(db *sqlx.DB, nodeId int) (NodeConnectionDetails, e
 		}
 		return NodeConnectionDetails{}, errors.Wrap(err, database.SqlExecutionError)
 	}
+	err = nodeConnectionDetailsData.decryptCredentials()
+	if err != nil {
+		return NodeConnectionDetails{}, errors.Wrapf(err, "Decrypting credentials for nodeId: %v", nodeId)
+	}
 	return nodeConnectionDetailsData, nil
 }
 
@@ -124,6 +143,13 @@ func GetAllNodeConnectionDetails(db *sqlx.DB, includeDeleted bool) ([]NodeConnec
 		}
 		return nil, errors.Wrap(err, database.SqlExecutionError)
 	}
+	for index := range nodeConnectionDetailsArray {
+		err = nodeConnectionDetailsArray[index].decryptCredentials()
+		if err != nil {
+			return nil, errors.Wrapf(err, "Decrypting credentials for nodeId: %v",
+				nodeConnectionDetailsArray[index].NodeId)
+		}
+	}
 	return nodeConnectionDetailsArray, nil
 }
 
@@ -520,7 +546,11 @@ func setCustomSettings(db *sqlx.DB,
 func SetNodeConnectionDetails(db *sqlx.DB, ncd NodeConnectionDetails) (NodeConnectionDetails, error) {
 	updatedOn := time.Now().UTC()
 	ncd.UpdatedOn = &updatedOn
-	_, err := db.Exec(`
+	encrypted, err := ncd.encryptCredentials()
+	if err != nil {
+		return ncd, errors.Wrapf(err, "Encrypting credentials for nodeId: %v", ncd.NodeId)
+	}
+	_, err = db.Exec(`
 		UPDATE node_connection_details
 		SET implementation = $1, name = $2, grpc_address = $3,
 		    tls_file_name = $4, tls_data = $5, macaroon_file_name = $6, macaroon_data = $7,
@@ -530,9 +560,9 @@ func SetNodeConnectionDetails(db *sqlx.DB, ncd NodeConnectionDetails) (NodeConne
 			custom_settings = $17, node_start_date = $18
 		WHERE node_id = $19;`,
 		ncd.Implementation, ncd.Name, ncd.GRPCAddress,
-		ncd.TLSFileName, ncd.TLSDataBytes, ncd.MacaroonFileName, ncd.MacaroonDataBytes,
-		ncd.CertificateFileName, ncd.CertificateDataBytes, ncd.KeyFileName, ncd.KeyDataBytes,
-		ncd.CaCertificateFileName, ncd.CaCertificateDataBytes,
+		ncd.TLSFileName, encrypted.TLSDataBytes, ncd.MacaroonFileName, encrypted.MacaroonDataBytes,
+		ncd.CertificateFileName, encrypted.CertificateDataBytes, ncd.KeyFileName, encrypted.KeyDataBytes,
+		ncd.CaCertificateFileName, encrypted.CaCertificateDataBytes,
 		ncd.Status, ncd.PingSystem, ncd.UpdatedOn,
 		ncd.CustomSettings, ncd.NodeStartDate, ncd.NodeId)
 	if err != nil {
@@ -596,7 +626,11 @@ func SetNodeConnectionDetailsByConnectionDetails(
 func addNodeConnectionDetails(db *sqlx.DB, ncd NodeConnectionDetails) (NodeConnectionDetails, error) {
 	updatedOn := time.Now().UTC()
 	ncd.UpdatedOn = &updatedOn
-	_, err := db.Exec(`
+	encrypted, err := ncd.encryptCredentials()
+	if err != nil {
+		return ncd, errors.Wrapf(err, "Encrypting credentials for nodeId: %v", ncd.NodeId)
+	}
+	_, err = db.Exec(`
 		INSERT INTO node_connection_details
 		    (node_id, name, implementation, grpc_address,
 		     tls_file_name, tls_data, macaroon_file_name, macaroon_data,
@@ -605,9 +639,9 @@ func addNodeConnectionDetails(db *sqlx.DB, ncd NodeConnectionDetails) (NodeConne
 		     status_id, ping_system, custom_settings, node_start_date, created_on, updated_on)
 		VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13,$14,$15,$16,$17,$18,$19,$20);`,
 		ncd.NodeId, ncd.Name, ncd.Implementation, ncd.GRPCAddress,
-		ncd.TLSFileName, ncd.TLSDataBytes, ncd.MacaroonFileName, ncd.MacaroonDataBytes,
-		ncd.CertificateFileName, ncd.CertificateDataBytes, ncd.KeyFileName, ncd.KeyDataBytes,
-		ncd.CaCertificateFileName, ncd.CaCertificateDataBytes,
+		ncd.TLSFileName, encrypted.TLSDataBytes, ncd.MacaroonFileName, encrypted.MacaroonDataBytes,
+		ncd.CertificateFileName, encrypted.CertificateDataBytes, ncd.KeyFileName, encrypted.KeyDataBytes,
+		ncd.CaCertificateFileName, encrypted.CaCertificateDataBytes,
 		ncd.Status, ncd.PingSystem, ncd.CustomSettings, ncd.NodeStartDate,
 		ncd.CreateOn, ncd.UpdatedOn)
 	if err != nil {
@@ -635,3 +669,55 @@ func addNodeConnectionDetails(db *sqlx.DB, ncd NodeConnectionDetails) (NodeConne
 	}
 	return ncd, nil
 }
+
+// EncryptNodeConnectionDetails encrypts plaintext credentials and rewraps credentials that were encrypted with a
+// previous master key. It returns the amount of nodes that were updated.
+func EncryptNodeConnectionDetails(db *sqlx.DB) (int, error) {
+	var nodeConnectionDetailsArray []NodeConnectionDetails
+	err := db.Select(&nodeConnectionDetailsArray, `
+		SELECT node_id, tls_data, macaroon_data, certificate_data, key_data, ca_certificate_data
+		FROM node_connection_details
+		ORDER BY node_id;`)
+	if err != nil {
+		return 0, errors.Wrap(err, database.SqlExecutionError)
+	}
+	tx, err := db.Beginx()
+	if err != nil {
+		return 0, errors.Wrap(err, database.SqlExecutionError)
+	}
+	updated := 0
+	for _, ncd := range nodeConnectionDetailsArray {
+		rotated := false
+		for _, credential := range ncd.getCredentials() {
+			if !encryption.NeedsRotation(*credential) {
+				continue
+			}
+			*credential, err = encryption.Rotate(*credential)
+			if err != nil {
+				_ = tx.Rollback()
+				return 0, errors.Wrapf(err, "Encrypting credentials for nodeId: %v", ncd.NodeId)
+			}
+			rotated = true
+		}
+		if !rotated {
+			continue
+		}
+		_, err = tx.Exec(`
+			UPDATE node_connection_details
+			SET tls_data = $1, macaroon_data = $2, certificate_data = $3, key_data = $4, ca_certificate_data = $5,
+			    updated_on = $6
+			WHERE node_id = $7;`,
+			ncd.TLSDataBytes, ncd.MacaroonDataBytes, ncd.CertificateDataBytes, ncd.KeyDataBytes,
+			ncd.CaCertificateDataBytes, time.Now().UTC(), ncd.NodeId)
+		if err != nil {
+			_ = tx.Rollback()
+			return 0, errors.Wrap(err, database.SqlExecutionError)
+		}
+		updated++
+	}
+	err = tx.Commit()
+	if err != nil {
+		return 0, errors.Wrap(err, database.SqlExecutionError)
+	}
+	return updated, nil
+}
@@ -10,6 +10,7 @@ import (
 
 	"github.com/lncapital/torq/internal/cache"
 	"github.com/lncapital/torq/internal/core"
+	"github.com/lncapital/torq/internal/encryption"
 )
 
 type NodeConnectionDetails struct {
@@ -40,6 +41,40 @@ type NodeConnectionDetails struct {
 	UpdatedOn              *time.Time                              `json:"updatedOn"  db:"updated_on"`
 }
 
+func (ncd *NodeConnectionDetails) getCredentials() []*[]byte {
+	return []*[]byte{
+		&ncd.TLSDataBytes,
+		&ncd.MacaroonDataBytes,
+		&ncd.CertificateDataBytes,
+		&ncd.KeyDataBytes,
+		&ncd.CaCertificateDataBytes,
+	}
+}
+
+// decryptCredentials replaces the stored (encrypted) credentials with their plaintext
+func (ncd *NodeConnectionDetails) decryptCredentials() error {
+	for _, credential := range ncd.getCredentials() {
+		plaintext, err := encryption.Decrypt(*credential)
+		if err != nil {
+			return errors.Wrap(err, "Decrypting node credential")
+		}
+		*credential = plaintext
+	}
+	return nil
+}
+
+// encryptCredentials returns a copy with the credentials as they should be stored in the database
+func (ncd NodeConnectionDetails) encryptCredentials() (NodeConnectionDetails, error) {
+	for _, credential := range ncd.getCredentials() {
+		ciphertext, err := encryption.Encrypt(*credential)
+		if err != nil {
+			return NodeConnectionDetails{}, errors.Wrap(err, "Encrypting node credential")
+		}
+		*credential = ciphertext
+	}
+	return ncd, nil
+}
+
 func GetNodeIdByGRPC(db *sqlx.DB, grpcAddress string) (int, error) {
 	allNodeConnectionDetails, err := GetAllNodeConnectionDetails(db, true)
 	if err != nil {
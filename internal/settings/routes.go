@@ -19,6 +19,7 @@ import (
 	"github.com/lncapital/torq/internal/services_helpers"
 	"github.com/lncapital/torq/proto/lnrpc"
 
+	"github.com/lncapital/torq/internal/auth"
 	"github.com/lncapital/torq/internal/cache"
 	"github.com/lncapital/torq/internal/core"
 	"github.com/lncapital/torq/pkg/cln_connect"
@@ -39,10 +40,19 @@ type settings struct {
 	SlackBotAppToken                *string    `json:"slackBotAppToken" db:"slack_bot_app_token"`
 	TelegramHighPriorityCredentials *string    `json:"telegramHighPriorityCredentials" db:"telegram_high_priority_credentials"`
 	TelegramLowPriorityCredentials  *string    `json:"telegramLowPriorityCredentials" db:"telegram_low_priority_credentials"`
+	ApprovalThresholdSat            *int64     `json:"approvalThresholdSat" db:"approval_threshold_sat"`
+	ApprovalExpiryMinutes           int        `json:"approvalExpiryMinutes" db:"approval_expiry_minutes"`
+	HtlcFailureBurstWindowMinutes   int        `json:"htlcFailureBurstWindowMinutes" db:"htlc_failure_burst_window_minutes"`
+	HtlcFailureBurstThreshold       int        `json:"htlcFailureBurstThreshold" db:"htlc_failure_burst_threshold"`
 	CreatedOn                       time.Time  `json:"createdOn" db:"created_on"`
 	UpdateOn                        *time.Time `json:"updatedOn" db:"updated_on"`
 }
 
+const defaultApprovalExpiryMinutes = 60
+
+// maximumHtlcFailureBurstWindowMinutes limits the failed HTLCs that are kept in memory for the burst alert
+const maximumHtlcFailureBurstWindowMinutes = 24 * 60
+
 type timeZone struct {
 	Name string `json:"name" db:"name"`
 }
@@ -85,6 +95,10 @@ func setService(serviceType services_helpers.ServiceType, nodeId int, active boo
 func RegisterSettingRoutes(r *gin.RouterGroup, db *sqlx.DB) {
 	r.GET("", func(c *gin.Context) { getSettingsHandler(c, db) })
 	r.PUT("", func(c *gin.Context) { updateSettingsHandler(c, db) })
+}
+
+// RegisterNodeConnectionDetailsRoutes are the routes that expose or change the node credentials
+func RegisterNodeConnectionDetailsRoutes(r *gin.RouterGroup, db *sqlx.DB) {
 	r.GET("nodeConnectionDetails", func(c *gin.Context) { getAllNodeConnectionDetailsHandler(c, db) })
 	r.GET("nodeConnectionDetails/:nodeId", func(c *gin.Context) { getNodeConnectionDetailsHandler(c, db) })
 	r.POST("nodeConnectionDetails", func(c *gin.Context) { addNodeConnectionDetailsHandler(c, db) })
@@ -120,6 +134,12 @@ func getSettingsHandler(c *gin.Context, db *sqlx.DB) {
 		server_errors.LogAndSendServerError(c, err)
 		return
 	}
+	if !auth.HasRole(c, auth.RoleAdmin) {
+		setts.SlackOAuthToken = nil
+		setts.SlackBotAppToken = nil
+		setts.TelegramHighPriorityCredentials = nil
+		setts.TelegramLowPriorityCredentials = nil
+	}
 	c.JSON(http.StatusOK, setts)
 }
 
@@ -129,6 +149,33 @@ func updateSettingsHandler(c *gin.Context, db *sqlx.DB) {
 		server_errors.SendBadRequestFromError(c, errors.Wrap(err, server_errors.JsonParseError))
 		return
 	}
+	if setts.ApprovalThresholdSat != nil && *setts.ApprovalThresholdSat < 0 {
+		server_errors.SendUnprocessableEntity(c, "The approval threshold can't be negative.")
+		return
+	}
+	if setts.ApprovalExpiryMinutes == 0 {
+		setts.ApprovalExpiryMinutes = defaultApprovalExpiryMinutes
+	}
+	if setts.ApprovalExpiryMinutes < 0 {
+		server_errors.SendUnprocessableEntity(c, "The approval expiry can't be negative.")
+		return
+	}
+	if setts.HtlcFailureBurstWindowMinutes == 0 {
+		setts.HtlcFailureBurstWindowMinutes = cache.DefaultHtlcFailureBurstWindowMinutes
+	}
+	if setts.HtlcFailureBurstWindowMinutes < 0 || setts.HtlcFailureBurstWindowMinutes > maximumHtlcFailureBurstWindowMinutes {
+		server_errors.SendUnprocessableEntity(c,
+			fmt.Sprintf("The HTLC failure burst window must be between 1 and %v minutes.",
+				maximumHtlcFailureBurstWindowMinutes))
+		return
+	}
+	if setts.HtlcFailureBurstThreshold == 0 {
+		setts.HtlcFailureBurstThreshold = cache.DefaultHtlcFailureBurstThreshold
+	}
+	if setts.HtlcFailureBurstThreshold < 0 {
+		server_errors.SendUnprocessableEntity(c, "The HTLC failure burst threshold can't be negative.")
+		return
+	}
 	err := updateSettings(db, setts)
 	if err != nil {
 		server_errors.LogAndSendServerError(c, err)
@@ -925,6 +925,50 @@ func getTableViewColumnDefinitions() []tableViewColumnDefinition {
 				PageChannels: 54,
 			},
 		},
+		{
+			key:        "probeReachability",
+			sortable:   true,
+			filterable: true,
+			heading:    "Probe Reachability",
+			visualType: "NumericCell",
+			valueType:  "number",
+			pages: map[TableViewPage]int{
+				PageChannels: 56,
+			},
+		},
+		{
+			key:        "probeFeePpm",
+			sortable:   true,
+			filterable: true,
+			heading:    "Probe Fee (PPM)",
+			visualType: "NumericCell",
+			valueType:  "number",
+			pages: map[TableViewPage]int{
+				PageChannels: 57,
+			},
+		},
+		{
+			key:        "probeLiquidityLowerBound",
+			sortable:   true,
+			filterable: true,
+			heading:    "Probe Liquidity Lower Bound",
+			visualType: "NumericCell",
+			valueType:  "number",
+			pages: map[TableViewPage]int{
+				PageChannels: 58,
+			},
+		},
+		{
+			key:        "probedOn",
+			sortable:   true,
+			filterable: true,
+			heading:    "Probed On",
+			visualType: "DateCell",
+			valueType:  "date",
+			pages: map[TableViewPage]int{
+				PageChannels: 59,
+			},
+		},
 		{
 			key:        "date",
 			sortable:   true,
@@ -24,6 +24,7 @@ const (
 	WorkflowNodeRebalanceAutoRun
 	WorkflowNodeDataSourceTorqChannels
 	WorkflowNodeChannelBalanceEventFilter
+	WorkflowNodeSendNotification
 )
 
 type WorkflowParameterType string
@@ -49,6 +50,7 @@ const (
 	WorkflowParameterLabelAllChannels           = WorkflowParameterLabel("allChannels")
 	WorkflowParameterLabelEventChannels         = WorkflowParameterLabel("eventChannels")
 	WorkflowParameterLabelEvents                = WorkflowParameterLabel("events")
+	WorkflowParameterLabelNonMatchingChannels   = WorkflowParameterLabel("nonMatchingChannels")
 )
 
 type WorkflowNodeTypeParameters struct {
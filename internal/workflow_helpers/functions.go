@@ -107,6 +107,14 @@ func GetWorkflowNodes() map[WorkflowNodeType]WorkflowNodeTypeParameters {
 	removeTagOptionalOutputs[WorkflowParameterLabelChannels] = WorkflowParameterTypeChannelIds
 	removeTagOptionalOutputs[WorkflowParameterLabelTagSettings] = WorkflowParameterTypeTagSettings
 
+	sendNotificationOptionalInputs := channelsOnly
+	sendNotificationOptionalOutputs := channelsOnly
+
+	filterOnVariableRequiredInputs := channelsOnly
+	filterOnVariableRequiredOutputs := channelsOnly
+	filterOnVariableOptionalOutputs := make(map[WorkflowParameterLabel]WorkflowParameterType)
+	filterOnVariableOptionalOutputs[WorkflowParameterLabelNonMatchingChannels] = WorkflowParameterTypeChannelIds
+
 	return map[WorkflowNodeType]WorkflowNodeTypeParameters{
 		WorkflowTrigger: {
 			WorkflowNodeType: WorkflowTrigger,
@@ -241,6 +249,13 @@ func GetWorkflowNodes() map[WorkflowNodeType]WorkflowNodeTypeParameters {
 			RequiredOutputs:  make(map[WorkflowParameterLabel]WorkflowParameterType),
 			OptionalOutputs:  removeTagOptionalOutputs,
 		},
+		WorkflowNodeSendNotification: {
+			WorkflowNodeType: WorkflowNodeSendNotification,
+			RequiredInputs:   make(map[WorkflowParameterLabel]WorkflowParameterType),
+			OptionalInputs:   sendNotificationOptionalInputs,
+			RequiredOutputs:  make(map[WorkflowParameterLabel]WorkflowParameterType),
+			OptionalOutputs:  sendNotificationOptionalOutputs,
+		},
 		WorkflowNodeSetVariable: {
 			WorkflowNodeType: WorkflowNodeSetVariable,
 			RequiredInputs:   make(map[WorkflowParameterLabel]WorkflowParameterType),
@@ -250,10 +265,10 @@ func GetWorkflowNodes() map[WorkflowNodeType]WorkflowNodeTypeParameters {
 		},
 		WorkflowNodeFilterOnVariable: {
 			WorkflowNodeType: WorkflowNodeFilterOnVariable,
-			RequiredInputs:   make(map[WorkflowParameterLabel]WorkflowParameterType),
-			OptionalInputs:   all,
-			RequiredOutputs:  make(map[WorkflowParameterLabel]WorkflowParameterType),
-			OptionalOutputs:  all,
+			RequiredInputs:   filterOnVariableRequiredInputs,
+			OptionalInputs:   make(map[WorkflowParameterLabel]WorkflowParameterType),
+			RequiredOutputs:  filterOnVariableRequiredOutputs,
+			OptionalOutputs:  filterOnVariableOptionalOutputs,
 		},
 	}
 }
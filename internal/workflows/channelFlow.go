@@ -0,0 +1,189 @@
+package workflows
+
+import (
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+// ChannelFlow contains the rolling forwarding performance of a channel (amounts and revenue are in sats).
+// It is added to the channel data of the workflow filters, i.e. the filter key revenue7d.
+type ChannelFlow struct {
+	ForwardedAmountOut1d  int64 `json:"forwardedAmountOut1d" db:"forwarded_amount_out_1d"`
+	ForwardedAmountOut7d  int64 `json:"forwardedAmountOut7d" db:"forwarded_amount_out_7d"`
+	ForwardedAmountOut30d int64 `json:"forwardedAmountOut30d" db:"forwarded_amount_out_30d"`
+	ForwardedAmountIn1d   int64 `json:"forwardedAmountIn1d" db:"forwarded_amount_in_1d"`
+	ForwardedAmountIn7d   int64 `json:"forwardedAmountIn7d" db:"forwarded_amount_in_7d"`
+	ForwardedAmountIn30d  int64 `json:"forwardedAmountIn30d" db:"forwarded_amount_in_30d"`
+	// Revenue is the fee earned by forwarding out of the channel
+	Revenue1d          int64 `json:"revenue1d" db:"revenue_1d"`
+	Revenue7d          int64 `json:"revenue7d" db:"revenue_7d"`
+	Revenue30d         int64 `json:"revenue30d" db:"revenue_30d"`
+	ForwardCountOut1d  int64 `json:"forwardCountOut1d" db:"forward_count_out_1d"`
+	ForwardCountOut7d  int64 `json:"forwardCountOut7d" db:"forward_count_out_7d"`
+	ForwardCountOut30d int64 `json:"forwardCountOut30d" db:"forward_count_out_30d"`
+	ForwardCountIn1d   int64 `json:"forwardCountIn1d" db:"forward_count_in_1d"`
+	ForwardCountIn7d   int64 `json:"forwardCountIn7d" db:"forward_count_in_7d"`
+	ForwardCountIn30d  int64 `json:"forwardCountIn30d" db:"forward_count_in_30d"`
+	// HtlcFailureRate is the share (0-1) of the HTLCs forwarded out of the channel that failed
+	HtlcFailureRate1d  float64 `json:"htlcFailureRate1d" db:"-"`
+	HtlcFailureRate7d  float64 `json:"htlcFailureRate7d" db:"-"`
+	HtlcFailureRate30d float64 `json:"htlcFailureRate30d" db:"-"`
+}
+
+type channelForwardsRow struct {
+	ChannelId int `db:"channel_id"`
+	ChannelFlow
+}
+
+type channelHtlcsRow struct {
+	ChannelId   int   `db:"channel_id"`
+	Attempts1d  int64 `db:"attempts_1d"`
+	Attempts7d  int64 `db:"attempts_7d"`
+	Attempts30d int64 `db:"attempts_30d"`
+	Failures1d  int64 `db:"failures_1d"`
+	Failures7d  int64 `db:"failures_7d"`
+	Failures30d int64 `db:"failures_30d"`
+}
+
+// GetChannelFlows returns the ChannelFlow by channelId. Channels without forwards are missing from the result.
+func GetChannelFlows(db *sqlx.DB, channelIds []int) (map[int]ChannelFlow, error) {
+	channelFlows := make(map[int]ChannelFlow)
+	if len(channelIds) == 0 {
+		return channelFlows, nil
+	}
+	now := time.Now().UTC()
+	oneDay := now.AddDate(0, 0, -1)
+	sevenDays := now.AddDate(0, 0, -7)
+	thirtyDays := now.AddDate(0, 0, -30)
+
+	var forwardsRows []channelForwardsRow
+	err := db.Select(&forwardsRows, `
+		SELECT channel_id,
+			FLOOR(COALESCE(SUM(amount_out_msat) FILTER (WHERE time >= $2::timestamp), 0)/1000)::BIGINT AS forwarded_amount_out_1d,
+			FLOOR(COALESCE(SUM(amount_out_msat) FILTER (WHERE time >= $3::timestamp), 0)/1000)::BIGINT AS forwarded_amount_out_7d,
+			FLOOR(COALESCE(SUM(amount_out_msat), 0)/1000)::BIGINT AS forwarded_amount_out_30d,
+			FLOOR(COALESCE(SUM(amount_in_msat) FILTER (WHERE time >= $2::timestamp), 0)/1000)::BIGINT AS forwarded_amount_in_1d,
+			FLOOR(COALESCE(SUM(amount_in_msat) FILTER (WHERE time >= $3::timestamp), 0)/1000)::BIGINT AS forwarded_amount_in_7d,
+			FLOOR(COALESCE(SUM(amount_in_msat), 0)/1000)::BIGINT AS forwarded_amount_in_30d,
+			FLOOR(COALESCE(SUM(fee_out_msat) FILTER (WHERE time >= $2::timestamp), 0)/1000)::BIGINT AS revenue_1d,
+			FLOOR(COALESCE(SUM(fee_out_msat) FILTER (WHERE time >= $3::timestamp), 0)/1000)::BIGINT AS revenue_7d,
+			FLOOR(COALESCE(SUM(fee_out_msat), 0)/1000)::BIGINT AS revenue_30d,
+			COALESCE(SUM(count_out) FILTER (WHERE time >= $2::timestamp), 0)::BIGINT AS forward_count_out_1d,
+			COALESCE(SUM(count_out) FILTER (WHERE time >= $3::timestamp), 0)::BIGINT AS forward_count_out_7d,
+			COALESCE(SUM(count_out), 0)::BIGINT AS forward_count_out_30d,
+			COALESCE(SUM(count_in) FILTER (WHERE time >= $2::timestamp), 0)::BIGINT AS forward_count_in_1d,
+			COALESCE(SUM(count_in) FILTER (WHERE time >= $3::timestamp), 0)::BIGINT AS forward_count_in_7d,
+			COALESCE(SUM(count_in), 0)::BIGINT AS forward_count_in_30d
+		FROM (
+			SELECT time, outgoing_channel_id AS channel_id,
+				outgoing_amount_msat AS amount_out_msat, 0 AS amount_in_msat, fee_msat AS fee_out_msat,
+				1 AS count_out, 0 AS count_in
+			FROM forward
+			WHERE outgoing_channel_id = ANY($1) AND time >= $4::timestamp
+			UNION ALL
+			SELECT time, incoming_channel_id AS channel_id,
+				0 AS amount_out_msat, incoming_amount_msat AS amount_in_msat, 0 AS fee_out_msat,
+				0 AS count_out, 1 AS count_in
+			FROM forward
+			WHERE incoming_channel_id = ANY($1) AND time >= $4::timestamp
+		) AS f
+		GROUP BY channel_id;`,
+		pq.Array(channelIds), oneDay, sevenDays, thirtyDays)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Obtaining the forwards for channelIds: %v", channelIds)
+	}
+	for _, forwardsRow := range forwardsRows {
+		channelFlows[forwardsRow.ChannelId] = forwardsRow.ChannelFlow
+	}
+
+	// LND reports a ForwardEvent and a SettleEvent for a successful forward while CLN only reports the SettleEvent
+	var htlcsRows []channelHtlcsRow
+	err = db.Select(&htlcsRows, `
+		SELECT outgoing_channel_id AS channel_id,
+			COUNT(*) FILTER (WHERE time >= $2::timestamp AND event_type != 'ForwardEvent') AS attempts_1d,
+			COUNT(*) FILTER (WHERE time >= $3::timestamp AND event_type != 'ForwardEvent') AS attempts_7d,
+			COUNT(*) FILTER (WHERE event_type != 'ForwardEvent') AS attempts_30d,
+			COUNT(*) FILTER (WHERE time >= $2::timestamp AND event_type IN ('ForwardFailEvent', 'LinkFailEvent')) AS failures_1d,
+			COUNT(*) FILTER (WHERE time >= $3::timestamp AND event_type IN ('ForwardFailEvent', 'LinkFailEvent')) AS failures_7d,
+			COUNT(*) FILTER (WHERE event_type IN ('ForwardFailEvent', 'LinkFailEvent')) AS failures_30d
+		FROM htlc_event
+		WHERE event_origin = 'FORWARD' AND outgoing_channel_id = ANY($1) AND time >= $4::timestamp
+		GROUP BY outgoing_channel_id;`,
+		pq.Array(channelIds), oneDay, sevenDays, thirtyDays)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Obtaining the HTLC events for channelIds: %v", channelIds)
+	}
+	for _, htlcsRow := range htlcsRows {
+		channelFlow := channelFlows[htlcsRow.ChannelId]
+		channelFlow.HtlcFailureRate1d = getHtlcFailureRate(htlcsRow.Failures1d, htlcsRow.Attempts1d)
+		channelFlow.HtlcFailureRate7d = getHtlcFailureRate(htlcsRow.Failures7d, htlcsRow.Attempts7d)
+		channelFlow.HtlcFailureRate30d = getHtlcFailureRate(htlcsRow.Failures30d, htlcsRow.Attempts30d)
+		channelFlows[htlcsRow.ChannelId] = channelFlow
+	}
+	return channelFlows, nil
+}
+
+// channelFlowsCacheDuration is how long the rebalancers reuse the flows of a channel
+const channelFlowsCacheDuration = time.Minute
+
+type cachedChannelFlow struct {
+	channelFlow ChannelFlow
+	cachedOn    time.Time
+}
+
+var (
+	channelFlowsCacheMutex sync.Mutex                        //nolint:gochecknoglobals
+	channelFlowsCache      = make(map[int]cachedChannelFlow) //nolint:gochecknoglobals
+)
+
+// getCachedChannelFlows returns the ChannelFlow by channelId like GetChannelFlows but only obtains the flows of the
+// channels that are not cached (or older than channelFlowsCacheDuration). The rebalancers look for new channels far
+// more often than the flows of a channel change.
+func getCachedChannelFlows(channelIds []int,
+	getChannelFlows func(channelIds []int) (map[int]ChannelFlow, error)) (map[int]ChannelFlow, error) {
+
+	channelFlowsCacheMutex.Lock()
+	defer channelFlowsCacheMutex.Unlock()
+
+	now := time.Now()
+	for channelId, cached := range channelFlowsCache {
+		if now.Sub(cached.cachedOn) >= channelFlowsCacheDuration {
+			delete(channelFlowsCache, channelId)
+		}
+	}
+	var missingChannelIds []int
+	for _, channelId := range channelIds {
+		if _, exists := channelFlowsCache[channelId]; !exists {
+			missingChannelIds = append(missingChannelIds, channelId)
+		}
+	}
+	if len(missingChannelIds) != 0 {
+		missingChannelFlows, err := getChannelFlows(missingChannelIds)
+		if err != nil {
+			return nil, err
+		}
+		// Channels without forwards are cached as well (with an empty ChannelFlow)
+		for _, channelId := range missingChannelIds {
+			channelFlowsCache[channelId] = cachedChannelFlow{channelFlow: missingChannelFlows[channelId], cachedOn: now}
+		}
+	}
+	channelFlows := make(map[int]ChannelFlow)
+	for _, channelId := range channelIds {
+		channelFlows[channelId] = channelFlowsCache[channelId].channelFlow
+	}
+	return channelFlows, nil
+}
+
+func getHtlcFailureRate(failures int64, attempts int64) float64 {
+	if attempts <= 0 {
+		return 0
+	}
+	if failures >= attempts {
+		return 1
+	}
+	return float64(failures) / float64(attempts)
+}
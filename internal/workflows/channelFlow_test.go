@@ -0,0 +1,142 @@
+package workflows
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/lncapital/torq/internal/channels"
+	"github.com/lncapital/torq/testutil"
+)
+
+func TestGetHtlcFailureRate(t *testing.T) {
+	testCases := []struct {
+		name     string
+		failures int64
+		attempts int64
+		want     float64
+	}{
+		{name: "no attempts", failures: 0, attempts: 0, want: 0},
+		{name: "no failures", failures: 0, attempts: 10, want: 0},
+		{name: "quarter failed", failures: 5, attempts: 20, want: 0.25},
+		{name: "all failed", failures: 10, attempts: 10, want: 1},
+		{name: "more failures than attempts", failures: 12, attempts: 10, want: 1},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := getHtlcFailureRate(tc.failures, tc.attempts)
+			if got != tc.want {
+				testutil.Errorf(t, "getHtlcFailureRate() = %v, want %v", got, tc.want)
+				return
+			}
+			testutil.Successf(t, "getHtlcFailureRate() = %v, want %v", got, tc.want)
+		})
+	}
+}
+
+func TestFilterChannelBodyChannelIdsOnFlow(t *testing.T) {
+	linkedChannels := []channels.ChannelBody{{ChannelId: 1}, {ChannelId: 2}, {ChannelId: 3}}
+	channelFlows := map[int]ChannelFlow{
+		1: {Revenue7d: 250, HtlcFailureRate1d: 0.1},
+		2: {Revenue7d: 20, HtlcFailureRate1d: 0.6},
+	}
+	testCases := []struct {
+		name   string
+		filter Filter
+		want   []int
+	}{
+		{
+			name:   "revenue below 100 sats",
+			filter: Filter{FuncName: "lt", Key: "revenue7d", Parameter: 100, Category: "number"},
+			want:   []int{2, 3},
+		},
+		{
+			name:   "failure rate above half",
+			filter: Filter{FuncName: "gt", Key: "htlcFailureRate1d", Parameter: 0.5, Category: "number"},
+			want:   []int{2},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := FilterChannelBodyChannelIds(FilterClauses{And: []FilterClauses{{Filter: tc.filter}}}, linkedChannels, channelFlows)
+			if len(got) != len(tc.want) {
+				testutil.Errorf(t, "FilterChannelBodyChannelIds() = %v, want %v", got, tc.want)
+				return
+			}
+			for i := range got {
+				if got[i] != tc.want[i] {
+					testutil.Errorf(t, "FilterChannelBodyChannelIds() = %v, want %v", got, tc.want)
+					return
+				}
+			}
+			testutil.Successf(t, "FilterChannelBodyChannelIds() = %v, want %v", got, tc.want)
+		})
+	}
+}
+
+func TestGetCachedChannelFlows(t *testing.T) {
+	channelFlowsCacheMutex.Lock()
+	channelFlowsCache = make(map[int]cachedChannelFlow)
+	channelFlowsCacheMutex.Unlock()
+
+	var requestedChannelIds [][]int
+	getChannelFlows := func(channelIds []int) (map[int]ChannelFlow, error) {
+		requestedChannelIds = append(requestedChannelIds, channelIds)
+		// Channel 2 has no forwards
+		channelFlows := make(map[int]ChannelFlow)
+		for _, channelId := range channelIds {
+			if channelId != 2 {
+				channelFlows[channelId] = ChannelFlow{Revenue7d: int64(channelId * 100)}
+			}
+		}
+		return channelFlows, nil
+	}
+
+	testCases := []struct {
+		name            string
+		channelIds      []int
+		expire          bool
+		wantRequested   []int
+		wantChannelFlow map[int]ChannelFlow
+	}{
+		{name: "empty cache", channelIds: []int{1, 2}, wantRequested: []int{1, 2},
+			wantChannelFlow: map[int]ChannelFlow{1: {Revenue7d: 100}, 2: {}}},
+		{name: "cached", channelIds: []int{1, 2},
+			wantChannelFlow: map[int]ChannelFlow{1: {Revenue7d: 100}, 2: {}}},
+		{name: "partially cached", channelIds: []int{2, 3}, wantRequested: []int{3},
+			wantChannelFlow: map[int]ChannelFlow{2: {}, 3: {Revenue7d: 300}}},
+		{name: "expired", channelIds: []int{1}, expire: true, wantRequested: []int{1},
+			wantChannelFlow: map[int]ChannelFlow{1: {Revenue7d: 100}}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.expire {
+				channelFlowsCacheMutex.Lock()
+				for channelId, cached := range channelFlowsCache {
+					cached.cachedOn = cached.cachedOn.Add(-channelFlowsCacheDuration - time.Second)
+					channelFlowsCache[channelId] = cached
+				}
+				channelFlowsCacheMutex.Unlock()
+			}
+			requestedChannelIds = nil
+			got, err := getCachedChannelFlows(tc.channelIds, getChannelFlows)
+			if err != nil {
+				testutil.Fatalf(t, "getCachedChannelFlows() error = %v", err)
+			}
+			var requested []int
+			if len(requestedChannelIds) > 1 {
+				testutil.Errorf(t, "getCachedChannelFlows() requested %v times", len(requestedChannelIds))
+				return
+			}
+			if len(requestedChannelIds) == 1 {
+				requested = requestedChannelIds[0]
+			}
+			if !reflect.DeepEqual(requested, tc.wantRequested) || !reflect.DeepEqual(got, tc.wantChannelFlow) {
+				testutil.Errorf(t, "getCachedChannelFlows() = %v (requested %v), want %v (requested %v)",
+					got, requested, tc.wantChannelFlow, tc.wantRequested)
+				return
+			}
+			testutil.Successf(t, "getCachedChannelFlows() = %v (requested %v)", got, requested)
+		})
+	}
+}
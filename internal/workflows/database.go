@@ -1284,12 +1284,14 @@ func removeNodeLink(db *sqlx.DB, workflowVersionNodeLinkId int) (int64, error) {
 func addWorkflowVersionNodeLog(db *sqlx.DB, workflowVersionNodeLog WorkflowVersionNodeLog) (WorkflowVersionNodeLog, error) {
 	workflowVersionNodeLog.CreatedOn = time.Now().UTC()
 	_, err := db.Exec(`INSERT INTO workflow_version_node_log
-    	(trigger_reference, input_data, output_data, debug_data, error_data, workflow_version_node_id, triggering_workflow_version_node_id, created_on)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8);`,
+    	(trigger_reference, input_data, output_data, debug_data, error_data, workflow_version_node_id, triggering_workflow_version_node_id, created_on,
+    	 workflow_run_id, duration_milliseconds)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10);`,
 		workflowVersionNodeLog.TriggerReference,
 		workflowVersionNodeLog.InputData, workflowVersionNodeLog.OutputData, workflowVersionNodeLog.DebugData,
 		workflowVersionNodeLog.ErrorData, workflowVersionNodeLog.WorkflowVersionNodeId,
-		workflowVersionNodeLog.TriggeringWorkflowVersionNodeId, workflowVersionNodeLog.CreatedOn)
+		workflowVersionNodeLog.TriggeringWorkflowVersionNodeId, workflowVersionNodeLog.CreatedOn,
+		workflowVersionNodeLog.WorkflowRunId, workflowVersionNodeLog.DurationMilliseconds)
 	if err != nil {
 		return WorkflowVersionNodeLog{}, errors.Wrap(err, database.SqlExecutionError)
 	}
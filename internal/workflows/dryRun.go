@@ -0,0 +1,156 @@
+package workflows
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/cockroachdb/errors"
+	"github.com/jmoiron/sqlx"
+	"github.com/rs/zerolog/log"
+
+	"github.com/lncapital/torq/internal/lightning_helpers"
+	"github.com/lncapital/torq/internal/workflow_helpers"
+)
+
+// WorkflowDryRun contains the actions a workflow would have executed.
+// When it's passed to processWorkflowNode the action nodes record their actions here instead of executing them.
+type WorkflowDryRun struct {
+	Reference              string                                `json:"reference"`
+	RoutingPolicyUpdates   []WorkflowDryRunRoutingPolicy         `json:"routingPolicyUpdates"`
+	RebalanceRequests      []WorkflowDryRunRebalance             `json:"rebalanceRequests"`
+	RebalanceCancellations []WorkflowDryRunRebalanceCancellation `json:"rebalanceCancellations"`
+	TagChanges             []WorkflowDryRunTagChange             `json:"tagChanges"`
+	Notifications          []WorkflowDryRunNotification          `json:"notifications"`
+}
+
+type WorkflowDryRunRoutingPolicy struct {
+	WorkflowVersionNodeId int `json:"workflowVersionNodeId"`
+	lightning_helpers.RoutingPolicyUpdateRequest
+}
+
+type WorkflowDryRunRebalance struct {
+	WorkflowVersionNodeId int `json:"workflowVersionNodeId"`
+	NodeId                int `json:"nodeId"`
+	lightning_helpers.RebalanceRequest
+}
+
+// WorkflowDryRunRebalanceCancellation is the cancellation of the rebalancers started by the node.
+// With a ChannelId only the rebalancer of that channel is cancelled
+// otherwise all rebalancers of the node are cancelled except the ones of ExceptChannelIds.
+type WorkflowDryRunRebalanceCancellation struct {
+	WorkflowVersionNodeId int   `json:"workflowVersionNodeId"`
+	ChannelId             *int  `json:"channelId"`
+	ExceptChannelIds      []int `json:"exceptChannelIds"`
+}
+
+type WorkflowDryRunTagChange struct {
+	WorkflowVersionNodeId int  `json:"workflowVersionNodeId"`
+	Remove                bool `json:"remove"`
+	TagId                 int  `json:"tagId"`
+	ChannelId             *int `json:"channelId"`
+	NodeId                *int `json:"nodeId"`
+}
+
+type WorkflowDryRunNotification struct {
+	WorkflowVersionNodeId int    `json:"workflowVersionNodeId"`
+	CommunicationIds      []int  `json:"communicationIds"`
+	Message               string `json:"message"`
+}
+
+// forWorkflowVersionNode returns the actions recorded by a single node (used for the node log).
+func (dryRun *WorkflowDryRun) forWorkflowVersionNode(workflowVersionNodeId int) WorkflowDryRun {
+	nodeDryRun := WorkflowDryRun{Reference: dryRun.Reference}
+	for _, routingPolicyUpdate := range dryRun.RoutingPolicyUpdates {
+		if routingPolicyUpdate.WorkflowVersionNodeId == workflowVersionNodeId {
+			nodeDryRun.RoutingPolicyUpdates = append(nodeDryRun.RoutingPolicyUpdates, routingPolicyUpdate)
+		}
+	}
+	for _, rebalanceRequest := range dryRun.RebalanceRequests {
+		if rebalanceRequest.WorkflowVersionNodeId == workflowVersionNodeId {
+			nodeDryRun.RebalanceRequests = append(nodeDryRun.RebalanceRequests, rebalanceRequest)
+		}
+	}
+	for _, rebalanceCancellation := range dryRun.RebalanceCancellations {
+		if rebalanceCancellation.WorkflowVersionNodeId == workflowVersionNodeId {
+			nodeDryRun.RebalanceCancellations = append(nodeDryRun.RebalanceCancellations, rebalanceCancellation)
+		}
+	}
+	for _, tagChange := range dryRun.TagChanges {
+		if tagChange.WorkflowVersionNodeId == workflowVersionNodeId {
+			nodeDryRun.TagChanges = append(nodeDryRun.TagChanges, tagChange)
+		}
+	}
+	for _, notification := range dryRun.Notifications {
+		if notification.WorkflowVersionNodeId == workflowVersionNodeId {
+			nodeDryRun.Notifications = append(nodeDryRun.Notifications, notification)
+		}
+	}
+	return nodeDryRun
+}
+
+func (dryRun *WorkflowDryRun) isEmpty() bool {
+	return len(dryRun.RoutingPolicyUpdates) == 0 && len(dryRun.RebalanceRequests) == 0 &&
+		len(dryRun.RebalanceCancellations) == 0 && len(dryRun.TagChanges) == 0 && len(dryRun.Notifications) == 0
+}
+
+// DryRunWorkflow walks the stages and filters of the workflow exactly like ProcessWorkflow
+// but returns the routing policy updates, rebalance requests and cancellations, tag changes and notifications
+// instead of executing them.
+func DryRunWorkflow(ctx context.Context, db *sqlx.DB,
+	workflowTriggerNode WorkflowNode,
+	reference string,
+	events []any) (WorkflowDryRun, error) {
+
+	dryRun := WorkflowDryRun{Reference: reference}
+	err := processWorkflow(ctx, db, workflowTriggerNode, reference, events, &dryRun)
+	if err != nil {
+		return WorkflowDryRun{}, err
+	}
+	log.Info().Msgf("Dry run for WorkflowVersionId: %v (reference: %v) would update %v routing policies, "+
+		"request %v rebalances and %v rebalancer cancellations, change %v tags and send %v notifications",
+		workflowTriggerNode.WorkflowVersionId, reference, len(dryRun.RoutingPolicyUpdates),
+		len(dryRun.RebalanceRequests), len(dryRun.RebalanceCancellations), len(dryRun.TagChanges),
+		len(dryRun.Notifications))
+	return dryRun, nil
+}
+
+// getManualTriggerWorkflowNode prepares the trigger node the same way the ScheduledTriggerMonitor does for a manual trigger.
+func getManualTriggerWorkflowNode(db *sqlx.DB, workflowVersionNodeId int) (WorkflowNode, error) {
+	workflowTriggerNode, err := GetWorkflowNode(db, workflowVersionNodeId)
+	if err != nil {
+		return WorkflowNode{}, errors.Wrapf(err, "Obtaining the triggering WorkflowNode for WorkflowVersionNodeId: %v",
+			workflowVersionNodeId)
+	}
+	triggerGroupWorkflowVersionNodeId, err := GetTriggerGroupWorkflowVersionNodeId(db, workflowVersionNodeId)
+	if err != nil {
+		return WorkflowNode{}, errors.Wrapf(err, "Obtaining the group node id for WorkflowVersionNodeId: %v",
+			workflowVersionNodeId)
+	}
+	if triggerGroupWorkflowVersionNodeId == 0 {
+		return WorkflowNode{}, errors.New(fmt.Sprintf("No group node found for WorkflowVersionNodeId: %v",
+			workflowVersionNodeId))
+	}
+	groupWorkflowVersionNode, err := GetWorkflowNode(db, triggerGroupWorkflowVersionNodeId)
+	if err != nil {
+		return WorkflowNode{}, errors.Wrapf(err, "Obtaining the group WorkflowNode for WorkflowVersionNodeId: %v",
+			triggerGroupWorkflowVersionNodeId)
+	}
+	workflowTriggerNode.ChildNodes = groupWorkflowVersionNode.ChildNodes
+	workflowTriggerNode.ParentNodes = make(map[int]*WorkflowNode)
+	workflowTriggerNode.LinkDetails = groupWorkflowVersionNode.LinkDetails
+	// Never directly run the group node
+	if workflowTriggerNode.Type == workflow_helpers.WorkflowTrigger {
+		workflowTriggerNode.Type = workflow_helpers.WorkflowNodeManualTrigger
+	}
+	return workflowTriggerNode, nil
+}
+
+func marshalDryRun(dryRun WorkflowDryRun) string {
+	marshalledDryRun, err := json.Marshal(dryRun)
+	if err != nil {
+		log.Error().Err(err).Msgf("Marshalling dry run for reference: %v", dryRun.Reference)
+		return ""
+	}
+	return string(marshalledDryRun)
+}
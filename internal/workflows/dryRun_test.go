@@ -0,0 +1,152 @@
+package workflows
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/lncapital/torq/internal/cache"
+	"github.com/lncapital/torq/internal/core"
+	"github.com/lncapital/torq/internal/lightning_helpers"
+	"github.com/lncapital/torq/testutil"
+)
+
+func TestWorkflowDryRunForWorkflowVersionNode(t *testing.T) {
+	channelId := 7
+	dryRun := WorkflowDryRun{
+		Reference: "dryrun_1",
+		RoutingPolicyUpdates: []WorkflowDryRunRoutingPolicy{
+			{WorkflowVersionNodeId: 1, RoutingPolicyUpdateRequest: lightning_helpers.RoutingPolicyUpdateRequest{ChannelId: 7}},
+			{WorkflowVersionNodeId: 2, RoutingPolicyUpdateRequest: lightning_helpers.RoutingPolicyUpdateRequest{ChannelId: 8}},
+		},
+		RebalanceRequests: []WorkflowDryRunRebalance{
+			{WorkflowVersionNodeId: 3, NodeId: 1, RebalanceRequest: lightning_helpers.RebalanceRequest{IncomingChannelId: 7}},
+		},
+		RebalanceCancellations: []WorkflowDryRunRebalanceCancellation{
+			{WorkflowVersionNodeId: 3, ExceptChannelIds: []int{7}},
+		},
+		TagChanges: []WorkflowDryRunTagChange{
+			{WorkflowVersionNodeId: 2, TagId: 4, ChannelId: &channelId},
+			{WorkflowVersionNodeId: 2, Remove: true, TagId: 5, ChannelId: &channelId},
+		},
+		Notifications: []WorkflowDryRunNotification{
+			{WorkflowVersionNodeId: 4, CommunicationIds: []int{1}, Message: "message"},
+		},
+	}
+
+	testCases := []struct {
+		name                  string
+		workflowVersionNodeId int
+		wantPolicies          int
+		wantRebalances        int
+		wantCancellations     int
+		wantTags              int
+		wantNotifications     int
+		wantEmpty             bool
+	}{
+		{name: "routing policy node", workflowVersionNodeId: 1, wantPolicies: 1},
+		{name: "routing policy and tag node", workflowVersionNodeId: 2, wantPolicies: 1, wantTags: 2},
+		{name: "rebalance node", workflowVersionNodeId: 3, wantRebalances: 1, wantCancellations: 1},
+		{name: "notification node", workflowVersionNodeId: 4, wantNotifications: 1},
+		{name: "filter node", workflowVersionNodeId: 5, wantEmpty: true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := dryRun.forWorkflowVersionNode(tc.workflowVersionNodeId)
+			if len(got.RoutingPolicyUpdates) != tc.wantPolicies ||
+				len(got.RebalanceRequests) != tc.wantRebalances ||
+				len(got.RebalanceCancellations) != tc.wantCancellations ||
+				len(got.TagChanges) != tc.wantTags ||
+				len(got.Notifications) != tc.wantNotifications ||
+				got.isEmpty() != tc.wantEmpty ||
+				got.Reference != dryRun.Reference {
+				testutil.Errorf(t, "forWorkflowVersionNode(%v) = %v", tc.workflowVersionNodeId, got)
+				return
+			}
+			testutil.Successf(t, "forWorkflowVersionNode(%v) = %v", tc.workflowVersionNodeId, got)
+		})
+	}
+}
+
+func TestProcessRebalanceRunDryRunHasNoSideEffects(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go cache.NodesCacheHandler(cache.NodesCacheChannel, ctx)
+	go cache.ChannelsCacheHandler(cache.ChannelsCacheChannel, ctx)
+
+	torqNodeId := 1
+	peerNodeId := 2
+	cache.SetTorqNode(torqNodeId, "torq", core.Active, "torqPublicKey", core.Bitcoin, core.MainNet)
+	for _, channelId := range []int{10, 11, 12} {
+		cache.SetChannel(channelId, nil, nil, core.Open, nil, nil, nil, nil, 1_000_000, false,
+			torqNodeId, peerNodeId, nil, nil, nil, nil, nil, nil, 0)
+	}
+	channelSettings := cache.GetChannelSettingByChannelId(10)
+
+	// Any rebalancer change (start, update or cancel) would end up on this channel
+	rebalancesCacheChannel := RebalancesCacheChannel
+	RebalancesCacheChannel = make(chan RebalanceCache, 10)
+	defer func() { RebalancesCacheChannel = rebalancesCacheChannel }()
+
+	amountMsat := uint64(100_000_000)
+	maximumCostMsat := uint64(1_000)
+	rebalanceSettings := []RebalanceConfiguration{{
+		IncomingChannelIds:    []int{10},
+		OutgoingChannelIds:    []int{11},
+		Focus:                 RebalancerFocusIncomingChannels,
+		AmountMsat:            &amountMsat,
+		MaximumCostMsat:       &maximumCostMsat,
+		WorkflowUnfocusedPath: []WorkflowNode{{WorkflowVersionNodeId: 2}},
+	}}
+
+	testCases := []struct {
+		name                  string
+		eventChannelIds       []int
+		wantCancellations     int
+		wantCancelledChannels []int
+	}{
+		{name: "without events", wantCancellations: 1},
+		{name: "with events", eventChannelIds: []int{10, 12}, wantCancellations: 1, wantCancelledChannels: []int{12}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			dryRun := WorkflowDryRun{Reference: "dryrun_1"}
+			// Without a database connection any database access panics
+			responses, err := processRebalanceRun(nil, tc.eventChannelIds, rebalanceSettings,
+				WorkflowNode{WorkflowVersionNodeId: 3}, dryRun.Reference, &dryRun)
+			if err != nil {
+				testutil.Fatalf(t, "processRebalanceRun() error = %v", err)
+			}
+			if len(responses) != 0 || len(dryRun.RebalanceRequests) != 1 ||
+				dryRun.RebalanceRequests[0].NodeId != torqNodeId ||
+				len(dryRun.RebalanceCancellations) != tc.wantCancellations {
+				testutil.Errorf(t, "processRebalanceRun() responses = %v, dryRun = %v", responses, dryRun)
+				return
+			}
+			var cancelledChannels []int
+			for _, rebalanceCancellation := range dryRun.RebalanceCancellations {
+				if rebalanceCancellation.ChannelId != nil {
+					cancelledChannels = append(cancelledChannels, *rebalanceCancellation.ChannelId)
+				} else if !reflect.DeepEqual(rebalanceCancellation.ExceptChannelIds, []int{10}) {
+					testutil.Errorf(t, "processRebalanceRun() ExceptChannelIds = %v, want [10]",
+						rebalanceCancellation.ExceptChannelIds)
+					return
+				}
+			}
+			if !reflect.DeepEqual(cancelledChannels, tc.wantCancelledChannels) {
+				testutil.Errorf(t, "processRebalanceRun() cancelled channels = %v, want %v",
+					cancelledChannels, tc.wantCancelledChannels)
+				return
+			}
+			if len(RebalancesCacheChannel) != 0 {
+				testutil.Errorf(t, "processRebalanceRun() changed %v rebalancers", len(RebalancesCacheChannel))
+				return
+			}
+			if !reflect.DeepEqual(cache.GetChannelSettingByChannelId(10), channelSettings) {
+				testutil.Errorf(t, "processRebalanceRun() changed the channel cache")
+				return
+			}
+			testutil.Successf(t, "processRebalanceRun() dryRun = %v", dryRun)
+		})
+	}
+}
@@ -0,0 +1,442 @@
+package workflows
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/cockroachdb/errors"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/lncapital/torq/internal/cache"
+	"github.com/lncapital/torq/internal/channels"
+)
+
+// Policy formulas are arithmetic expressions over the channel data (and the workflow variables)
+// i.e. clamp(500 * (1 - local_ratio), 50, 2000) or local_balance * 0.9
+// Identifiers are case-insensitive and underscores are ignored so local_balance and localBalance are the same.
+// Supported are + - * / parentheses and the functions min, max, clamp, abs, round, floor and ceil.
+
+type policyFormulaFunction struct {
+	arguments int // -1 means at least one argument
+	evaluate  func(arguments []float64) float64
+}
+
+var policyFormulaFunctions = map[string]policyFormulaFunction{ //nolint:gochecknoglobals
+	"min": {arguments: -1, evaluate: func(arguments []float64) float64 {
+		result := arguments[0]
+		for _, argument := range arguments[1:] {
+			result = math.Min(result, argument)
+		}
+		return result
+	}},
+	"max": {arguments: -1, evaluate: func(arguments []float64) float64 {
+		result := arguments[0]
+		for _, argument := range arguments[1:] {
+			result = math.Max(result, argument)
+		}
+		return result
+	}},
+	"clamp": {arguments: 3, evaluate: func(arguments []float64) float64 {
+		return math.Min(math.Max(arguments[0], arguments[1]), arguments[2])
+	}},
+	"abs":   {arguments: 1, evaluate: func(arguments []float64) float64 { return math.Abs(arguments[0]) }},
+	"round": {arguments: 1, evaluate: func(arguments []float64) float64 { return math.Round(arguments[0]) }},
+	"floor": {arguments: 1, evaluate: func(arguments []float64) float64 { return math.Floor(arguments[0]) }},
+	"ceil":  {arguments: 1, evaluate: func(arguments []float64) float64 { return math.Ceil(arguments[0]) }},
+}
+
+type policyFormulaParser struct {
+	formula  string
+	position int
+	values   map[string]float64
+	// validating only checks the syntax, the identifiers are unknown until the formula is evaluated for a channel
+	validating bool
+}
+
+// evaluatePolicyFormula calculates the formula with the (normalized) identifiers in values.
+func evaluatePolicyFormula(formula string, values map[string]float64) (float64, error) {
+	parser := policyFormulaParser{formula: formula, values: values}
+	result, err := parser.parse()
+	if err != nil {
+		return 0, err
+	}
+	if math.IsNaN(result) || math.IsInf(result, 0) {
+		return 0, errors.New(fmt.Sprintf("Formula does not result in a number: %v", formula))
+	}
+	return result, nil
+}
+
+// validatePolicyFormula checks the syntax and the functions of the formula.
+func validatePolicyFormula(formula string) error {
+	parser := policyFormulaParser{formula: formula, validating: true}
+	_, err := parser.parse()
+	return err
+}
+
+func (parser *policyFormulaParser) parse() (float64, error) {
+	result, err := parser.parseExpression()
+	if err != nil {
+		return 0, errors.Wrapf(err, "Evaluating formula: %v", parser.formula)
+	}
+	parser.skipSpaces()
+	if parser.position != len(parser.formula) {
+		return 0, errors.New(fmt.Sprintf("Unexpected %q at position %v in formula: %v",
+			parser.formula[parser.position], parser.position, parser.formula))
+	}
+	return result, nil
+}
+
+func normalizePolicyFormulaIdentifier(identifier string) string {
+	return strings.ToLower(strings.ReplaceAll(identifier, "_", ""))
+}
+
+func (parser *policyFormulaParser) skipSpaces() {
+	for parser.position < len(parser.formula) && unicode.IsSpace(rune(parser.formula[parser.position])) {
+		parser.position++
+	}
+}
+
+func (parser *policyFormulaParser) peek() byte {
+	parser.skipSpaces()
+	if parser.position >= len(parser.formula) {
+		return 0
+	}
+	return parser.formula[parser.position]
+}
+
+// parseExpression handles + and -
+func (parser *policyFormulaParser) parseExpression() (float64, error) {
+	result, err := parser.parseTerm()
+	if err != nil {
+		return 0, err
+	}
+	for {
+		operator := parser.peek()
+		if operator != '+' && operator != '-' {
+			return result, nil
+		}
+		parser.position++
+		right, err := parser.parseTerm()
+		if err != nil {
+			return 0, err
+		}
+		if operator == '+' {
+			result += right
+		} else {
+			result -= right
+		}
+	}
+}
+
+// parseTerm handles * and /
+func (parser *policyFormulaParser) parseTerm() (float64, error) {
+	result, err := parser.parseFactor()
+	if err != nil {
+		return 0, err
+	}
+	for {
+		operator := parser.peek()
+		if operator != '*' && operator != '/' {
+			return result, nil
+		}
+		parser.position++
+		right, err := parser.parseFactor()
+		if err != nil {
+			return 0, err
+		}
+		if operator == '*' {
+			result *= right
+			continue
+		}
+		if right == 0 {
+			if parser.validating {
+				continue
+			}
+			return 0, errors.New("Division by zero")
+		}
+		result /= right
+	}
+}
+
+// parseFactor handles unary minus, parentheses, numbers, identifiers and function calls
+func (parser *policyFormulaParser) parseFactor() (float64, error) {
+	character := parser.peek()
+	switch {
+	case character == '-':
+		parser.position++
+		result, err := parser.parseFactor()
+		return -result, err
+	case character == '(':
+		parser.position++
+		result, err := parser.parseExpression()
+		if err != nil {
+			return 0, err
+		}
+		if parser.peek() != ')' {
+			return 0, errors.New(fmt.Sprintf("Missing ) at position %v", parser.position))
+		}
+		parser.position++
+		return result, nil
+	case character == '.' || (character >= '0' && character <= '9'):
+		start := parser.position
+		for parser.position < len(parser.formula) &&
+			(parser.formula[parser.position] == '.' || unicode.IsDigit(rune(parser.formula[parser.position]))) {
+			parser.position++
+		}
+		result, err := strconv.ParseFloat(parser.formula[start:parser.position], 64)
+		if err != nil {
+			return 0, errors.Wrapf(err, "Parsing number at position %v", start)
+		}
+		return result, nil
+	case character == '_' || unicode.IsLetter(rune(character)):
+		start := parser.position
+		for parser.position < len(parser.formula) &&
+			(parser.formula[parser.position] == '_' ||
+				unicode.IsLetter(rune(parser.formula[parser.position])) ||
+				unicode.IsDigit(rune(parser.formula[parser.position]))) {
+			parser.position++
+		}
+		identifier := parser.formula[start:parser.position]
+		if parser.peek() == '(' {
+			return parser.parseFunction(identifier)
+		}
+		if parser.validating {
+			return 1, nil
+		}
+		value, exists := parser.values[normalizePolicyFormulaIdentifier(identifier)]
+		if !exists {
+			return 0, errors.New(fmt.Sprintf("Unknown identifier %v", identifier))
+		}
+		return value, nil
+	case character == 0:
+		return 0, errors.New("Unexpected end of formula")
+	}
+	return 0, errors.New(fmt.Sprintf("Unexpected %q at position %v", character, parser.position))
+}
+
+func (parser *policyFormulaParser) parseFunction(name string) (float64, error) {
+	function, exists := policyFormulaFunctions[strings.ToLower(name)]
+	if !exists {
+		return 0, errors.New(fmt.Sprintf("Unknown function %v", name))
+	}
+	// skip the (
+	parser.position++
+	var arguments []float64
+	if parser.peek() != ')' {
+		for {
+			argument, err := parser.parseExpression()
+			if err != nil {
+				return 0, err
+			}
+			arguments = append(arguments, argument)
+			if parser.peek() != ',' {
+				break
+			}
+			parser.position++
+		}
+	}
+	if parser.peek() != ')' {
+		return 0, errors.New(fmt.Sprintf("Missing ) for function %v at position %v", name, parser.position))
+	}
+	parser.position++
+	if (function.arguments == -1 && len(arguments) == 0) ||
+		(function.arguments != -1 && len(arguments) != function.arguments) {
+		return 0, errors.New(fmt.Sprintf("Wrong number of arguments for function %v", name))
+	}
+	return function.evaluate(arguments), nil
+}
+
+// getPolicyFormulaValues converts the channel data (see ChannelBodyWithFlowToMap) into formula values.
+// Numeric workflow variables are available too, channel fields take precedence.
+// local_ratio and remote_ratio are added as the share (0-1) of the capacity.
+func getPolicyFormulaValues(channel map[string]any, variables workflowVariables) map[string]float64 {
+	values := make(map[string]float64)
+	for name, value := range variables {
+		if number, ok := getPolicyFormulaNumber(value); ok {
+			values[normalizePolicyFormulaIdentifier(name)] = number
+		}
+	}
+	for key, value := range channel {
+		if number, ok := getPolicyFormulaNumber(value); ok {
+			values[normalizePolicyFormulaIdentifier(key)] = number
+		}
+	}
+	if capacity := values["capacity"]; capacity > 0 {
+		values["localratio"] = values["localbalance"] / capacity
+		values["remoteratio"] = values["remotebalance"] / capacity
+	}
+	return values
+}
+
+func getPolicyFormulaNumber(value any) (float64, bool) {
+	switch typedValue := value.(type) {
+	case int:
+		return float64(typedValue), true
+	case int32:
+		return float64(typedValue), true
+	case int64:
+		return float64(typedValue), true
+	case uint32:
+		return float64(typedValue), true
+	case uint64:
+		return float64(typedValue), true
+	case float64:
+		return typedValue, true
+	case *uint32:
+		if typedValue != nil {
+			return float64(*typedValue), true
+		}
+	case *uint64:
+		if typedValue != nil {
+			return float64(*typedValue), true
+		}
+	case *int64:
+		if typedValue != nil {
+			return float64(*typedValue), true
+		}
+	case *float64:
+		if typedValue != nil {
+			return *typedValue, true
+		}
+	}
+	return 0, false
+}
+
+func (configuration ChannelPolicyConfiguration) hasFormulas() bool {
+	return configuration.FeeRateFormula != nil || configuration.FeeBaseFormula != nil ||
+		configuration.MinHtlcFormula != nil || configuration.MaxHtlcFormula != nil ||
+		configuration.TimeLockDeltaFormula != nil
+}
+
+// applyPolicyFormulas replaces the values of the configuration with the calculated formulas and removes the formulas.
+func applyPolicyFormulas(configuration ChannelPolicyConfiguration,
+	values map[string]float64) (ChannelPolicyConfiguration, error) {
+
+	if configuration.FeeRateFormula != nil {
+		feeRate, err := evaluatePolicyFormulaUnsigned(*configuration.FeeRateFormula, values, 1)
+		if err != nil {
+			return ChannelPolicyConfiguration{}, errors.Wrap(err, "Fee rate")
+		}
+		if feeRate > math.MaxUint32 {
+			return ChannelPolicyConfiguration{}, errors.New(fmt.Sprintf("Fee rate too large: %v", feeRate))
+		}
+		feeRateMilliMsat := int64(feeRate)
+		configuration.FeeRateMilliMsat = &feeRateMilliMsat
+	}
+	if configuration.FeeBaseFormula != nil {
+		feeBase, err := evaluatePolicyFormulaUnsigned(*configuration.FeeBaseFormula, values, 1000)
+		if err != nil {
+			return ChannelPolicyConfiguration{}, errors.Wrap(err, "Base fee")
+		}
+		if feeBase > math.MaxUint32 {
+			return ChannelPolicyConfiguration{}, errors.New(fmt.Sprintf("Base fee too large: %v", feeBase))
+		}
+		feeBaseMsat := int64(feeBase)
+		configuration.FeeBaseMsat = &feeBaseMsat
+	}
+	if configuration.MinHtlcFormula != nil {
+		minHtlcMsat, err := evaluatePolicyFormulaUnsigned(*configuration.MinHtlcFormula, values, 1000)
+		if err != nil {
+			return ChannelPolicyConfiguration{}, errors.Wrap(err, "Minimum HTLC")
+		}
+		configuration.MinHtlcMsat = &minHtlcMsat
+	}
+	if configuration.MaxHtlcFormula != nil {
+		maxHtlcMsat, err := evaluatePolicyFormulaUnsigned(*configuration.MaxHtlcFormula, values, 1000)
+		if err != nil {
+			return ChannelPolicyConfiguration{}, errors.Wrap(err, "Maximum HTLC")
+		}
+		configuration.MaxHtlcMsat = &maxHtlcMsat
+	}
+	if configuration.TimeLockDeltaFormula != nil {
+		timeLockDelta, err := evaluatePolicyFormulaUnsigned(*configuration.TimeLockDeltaFormula, values, 1)
+		if err != nil {
+			return ChannelPolicyConfiguration{}, errors.Wrap(err, "Time lock delta")
+		}
+		if timeLockDelta > math.MaxUint16 {
+			return ChannelPolicyConfiguration{}, errors.New(fmt.Sprintf("Time lock delta too large: %v", timeLockDelta))
+		}
+		timeLockDelta32 := uint32(timeLockDelta)
+		configuration.TimeLockDelta = &timeLockDelta32
+	}
+	configuration.FeeRateFormula = nil
+	configuration.FeeBaseFormula = nil
+	configuration.MinHtlcFormula = nil
+	configuration.MaxHtlcFormula = nil
+	configuration.TimeLockDeltaFormula = nil
+	return configuration, nil
+}
+
+// getPolicyFormulaErrors returns the syntax errors of the formulas by parameter name (i.e. feeRateFormula)
+func (configuration ChannelPolicyConfiguration) getPolicyFormulaErrors() map[string]string {
+	formulas := map[string]*string{
+		"feeRateFormula":       configuration.FeeRateFormula,
+		"feeBaseFormula":       configuration.FeeBaseFormula,
+		"minHtlcFormula":       configuration.MinHtlcFormula,
+		"maxHtlcFormula":       configuration.MaxHtlcFormula,
+		"timeLockDeltaFormula": configuration.TimeLockDeltaFormula,
+	}
+	formulaErrors := make(map[string]string)
+	for name, formula := range formulas {
+		if formula == nil {
+			continue
+		}
+		err := validatePolicyFormula(*formula)
+		if err != nil {
+			formulaErrors[name] = errors.Cause(err).Error()
+		}
+	}
+	return formulaErrors
+}
+
+func evaluatePolicyFormulaUnsigned(formula string, values map[string]float64, multiplier float64) (uint64, error) {
+	result, err := evaluatePolicyFormula(formula, values)
+	if err != nil {
+		return 0, err
+	}
+	if result < 0 {
+		return 0, errors.New(fmt.Sprintf("Negative result %v for formula: %v", result, formula))
+	}
+	return uint64(math.Round(result * multiplier)), nil
+}
+
+// getPolicyFormulaChannels returns the channel data for the formulas by channelId
+func getPolicyFormulaChannels(db *sqlx.DB, channelIds []int) (map[int]map[string]any, error) {
+	var linkedChannels []channels.ChannelBody
+	for _, torqNodeId := range cache.GetAllTorqNodeIds() {
+		linkedChannelsByNode, err := channels.GetChannelsByIds(torqNodeId, channelIds)
+		if err != nil {
+			return nil, errors.Wrapf(err, "Getting the channels for channelIds: %v", channelIds)
+		}
+		linkedChannels = append(linkedChannels, linkedChannelsByNode...)
+	}
+	channelFlows, err := GetChannelFlows(db, channelIds)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Getting the channel flows for channelIds: %v", channelIds)
+	}
+	channelsByChannelId := make(map[int]map[string]any)
+	for i, channel := range ChannelBodyWithFlowToMap(linkedChannels, channelFlows) {
+		channelsByChannelId[linkedChannels[i].ChannelId] = channel
+	}
+	return channelsByChannelId, nil
+}
+
+// getPolicyFormulaChannelsForNode only obtains the channel data when the node has formulas
+func getPolicyFormulaChannelsForNode(db *sqlx.DB,
+	workflowNode WorkflowNode,
+	channelIds []int) (map[int]map[string]any, error) {
+
+	var channelPolicyConfiguration ChannelPolicyConfiguration
+	err := json.Unmarshal([]byte(workflowNode.Parameters), &channelPolicyConfiguration)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Parse parameters for WorkflowVersionNodeId: %v", workflowNode.WorkflowVersionNodeId)
+	}
+	if !channelPolicyConfiguration.hasFormulas() {
+		return nil, nil
+	}
+	return getPolicyFormulaChannels(db, channelIds)
+}
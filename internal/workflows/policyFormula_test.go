@@ -0,0 +1,166 @@
+package workflows
+
+import (
+	"testing"
+
+	"github.com/lncapital/torq/testutil"
+)
+
+func TestEvaluatePolicyFormula(t *testing.T) {
+	values := getPolicyFormulaValues(
+		map[string]any{"localbalance": int64(250_000), "remotebalance": int64(750_000), "capacity": int64(1_000_000),
+			"revenue7d": int64(40), "peeralias": "carol"},
+		workflowVariables{"maximumFeeRate": float64(1500), "alias": "dave"})
+	testCases := []struct {
+		name    string
+		formula string
+		want    float64
+		wantErr bool
+	}{
+		{name: "number", formula: "100", want: 100},
+		{name: "precedence", formula: "2 + 3 * 4 - 6 / 2", want: 11},
+		{name: "parentheses and unary minus", formula: "-(2 + 3) * -2", want: 10},
+		{name: "local ratio", formula: "500 * (1 - local_ratio)", want: 375},
+		{name: "clamp lower bound", formula: "clamp(500 * (1 - local_ratio) - 400, 50, 2000)", want: 50},
+		{name: "clamp upper bound", formula: "clamp(5000 * (1 - localRatio), 50, 2000)", want: 2000},
+		{name: "channel field", formula: "local_balance * 0.9", want: 225_000},
+		{name: "workflow variable", formula: "min(maximum_fee_rate, 2000, revenue_7d * 100)", want: 1500},
+		{name: "rounding", formula: "round(1.5) + floor(1.5) + ceil(1.2) + abs(-1)", want: 6},
+		{name: "string field", formula: "peer_alias", wantErr: true},
+		{name: "unknown identifier", formula: "unknown * 2", wantErr: true},
+		{name: "unknown function", formula: "sqrt(4)", wantErr: true},
+		{name: "wrong number of arguments", formula: "clamp(1, 2)", wantErr: true},
+		{name: "division by zero", formula: "1 / (local_ratio - 0.25)", wantErr: true},
+		{name: "missing parenthesis", formula: "(1 + 2", wantErr: true},
+		{name: "trailing characters", formula: "1 + 2)", wantErr: true},
+		{name: "empty", formula: "", wantErr: true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := evaluatePolicyFormula(tc.formula, values)
+			if (err != nil) != tc.wantErr {
+				testutil.Errorf(t, "evaluatePolicyFormula() error = %v, wantErr %v", err, tc.wantErr)
+				return
+			}
+			if got != tc.want {
+				testutil.Errorf(t, "evaluatePolicyFormula() = %v, want %v", got, tc.want)
+				return
+			}
+			testutil.Successf(t, "evaluatePolicyFormula() = %v, want %v", got, tc.want)
+		})
+	}
+}
+
+func TestValidatePolicyFormula(t *testing.T) {
+	testCases := []struct {
+		name    string
+		formula string
+		wantErr bool
+	}{
+		{name: "identifiers", formula: "clamp(500 * (1 - local_ratio), 50, maximum_fee_rate)"},
+		{name: "division by an identifier", formula: "1 / (local_ratio - 1)"},
+		{name: "unknown function", formula: "sqrt(4)", wantErr: true},
+		{name: "wrong number of arguments", formula: "clamp(1, 2)", wantErr: true},
+		{name: "missing parenthesis", formula: "(1 + 2", wantErr: true},
+		{name: "trailing characters", formula: "1 + 2)", wantErr: true},
+		{name: "empty", formula: "", wantErr: true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validatePolicyFormula(tc.formula)
+			if (err != nil) != tc.wantErr {
+				testutil.Errorf(t, "validatePolicyFormula() error = %v, wantErr %v", err, tc.wantErr)
+				return
+			}
+			testutil.Successf(t, "validatePolicyFormula() error = %v", err)
+		})
+	}
+}
+
+func TestApplyPolicyFormulas(t *testing.T) {
+	values := getPolicyFormulaValues(
+		map[string]any{"localbalance": int64(250_000), "remotebalance": int64(750_000), "capacity": int64(1_000_000)},
+		nil)
+	formula := func(formula string) *string { return &formula }
+	feeBaseMsat := int64(1000)
+
+	testCases := []struct {
+		name              string
+		configuration     ChannelPolicyConfiguration
+		wantFeeRate       int64
+		wantFeeBaseMsat   int64
+		wantMaxHtlcMsat   uint64
+		wantTimeLockDelta uint32
+		wantErr           bool
+	}{
+		{
+			name: "formulas",
+			configuration: ChannelPolicyConfiguration{
+				FeeRateFormula:       formula("clamp(500 * (1 - local_ratio), 50, 2000)"),
+				FeeBaseFormula:       formula("0.5"),
+				MaxHtlcFormula:       formula("local_balance * 0.9"),
+				TimeLockDeltaFormula: formula("40 + 4"),
+			},
+			wantFeeRate:       375,
+			wantFeeBaseMsat:   500,
+			wantMaxHtlcMsat:   225_000_000,
+			wantTimeLockDelta: 44,
+		},
+		{
+			name: "fixed value without formula",
+			configuration: ChannelPolicyConfiguration{
+				FeeBaseMsat:    &feeBaseMsat,
+				FeeRateFormula: formula("100"),
+			},
+			wantFeeRate:     100,
+			wantFeeBaseMsat: 1000,
+		},
+		{
+			name:          "negative maximum HTLC",
+			configuration: ChannelPolicyConfiguration{MaxHtlcFormula: formula("local_balance - capacity")},
+			wantErr:       true,
+		},
+		{
+			name:          "negative fee rate",
+			configuration: ChannelPolicyConfiguration{FeeRateFormula: formula("100 - remote_balance")},
+			wantErr:       true,
+		},
+		{
+			name:          "negative base fee",
+			configuration: ChannelPolicyConfiguration{FeeBaseFormula: formula("-1")},
+			wantErr:       true,
+		},
+		{
+			name:          "fee rate too large",
+			configuration: ChannelPolicyConfiguration{FeeRateFormula: formula("capacity * capacity")},
+			wantErr:       true,
+		},
+		{
+			name:          "time lock delta too large",
+			configuration: ChannelPolicyConfiguration{TimeLockDeltaFormula: formula("capacity")},
+			wantErr:       true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := applyPolicyFormulas(tc.configuration, values)
+			if (err != nil) != tc.wantErr {
+				testutil.Errorf(t, "applyPolicyFormulas() error = %v, wantErr %v", err, tc.wantErr)
+				return
+			}
+			if tc.wantErr {
+				testutil.Successf(t, "applyPolicyFormulas() error = %v", err)
+				return
+			}
+			if got.hasFormulas() ||
+				got.FeeRateMilliMsat == nil || *got.FeeRateMilliMsat != tc.wantFeeRate ||
+				got.FeeBaseMsat == nil || *got.FeeBaseMsat != tc.wantFeeBaseMsat ||
+				(tc.wantMaxHtlcMsat != 0 && (got.MaxHtlcMsat == nil || *got.MaxHtlcMsat != tc.wantMaxHtlcMsat)) ||
+				(tc.wantTimeLockDelta != 0 && (got.TimeLockDelta == nil || *got.TimeLockDelta != tc.wantTimeLockDelta)) {
+				testutil.Errorf(t, "applyPolicyFormulas() = %+v", got)
+				return
+			}
+			testutil.Successf(t, "applyPolicyFormulas() = %+v", got)
+		})
+	}
+}
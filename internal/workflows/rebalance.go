@@ -2,10 +2,8 @@ package workflows
 
 import (
 	"context"
-	"encoding/hex"
 	"encoding/json"
 	"fmt"
-	"math/rand"
 	"sort"
 	"time"
 
@@ -17,9 +15,6 @@ import (
 
 	"github.com/lncapital/torq/internal/lightning_helpers"
 	"github.com/lncapital/torq/internal/workflow_helpers"
-	"github.com/lncapital/torq/proto/lnrpc/routerrpc"
-
-	"github.com/lncapital/torq/proto/lnrpc"
 
 	"github.com/lncapital/torq/internal/cache"
 	"github.com/lncapital/torq/internal/channels"
@@ -50,16 +45,18 @@ type Rebalancer struct {
 	RebalanceCancel context.CancelFunc
 	Runners         map[int]*RebalanceRunner
 	Request         lightning_helpers.RebalanceRequest
+	// hopMemory is loaded once when the rebalancer (re)starts and shared by its runners
+	hopMemory *rebalanceHopMemory
 }
 
 type RebalanceRunner struct {
 	RebalanceId       int
 	OutgoingChannelId int
 	IncomingChannelId int
-	Invoices          map[uint64]*lnrpc.AddInvoiceResponse
+	Invoices          map[uint64]RebalanceInvoice
 	// FailedHops map[hopSourcePublicKey_hopDestinationPublicKey]amountMsat
 	FailedHops       map[string]uint64
-	FailedPairs      []*lnrpc.NodePair
+	FailedPairs      []RebalanceHopPair
 	FailedChannelIds []int
 	Status           core.Status
 	Ctx              context.Context
@@ -71,7 +68,7 @@ func (runner *RebalanceRunner) addFailedHop(
 	hopDestinationPublicKey string,
 	amountMsat uint64) {
 
-	runner.FailedHops[hopSourcePublicKey+"_"+hopDestinationPublicKey] = amountMsat
+	runner.FailedHops[getRebalanceHopKey(hopSourcePublicKey, hopDestinationPublicKey)] = amountMsat
 }
 
 func (runner *RebalanceRunner) isFailedHop(
@@ -79,16 +76,20 @@ func (runner *RebalanceRunner) isFailedHop(
 	hopDestinationPublicKey string,
 	amountMsat uint64) bool {
 
-	failedHopAmountMsat, exists := runner.FailedHops[hopSourcePublicKey+"_"+hopDestinationPublicKey]
+	// Like the hop memory the amount is the amount forwarded over the pair and
+	// a failure also applies to greater amounts
+	failedHopAmountMsat, exists := runner.FailedHops[getRebalanceHopKey(hopSourcePublicKey, hopDestinationPublicKey)]
 	return exists &&
-		core.GetDeltaPerMille(failedHopAmountMsat, amountMsat) <
-			rebalanceRouteFailedHopAllowedDeltaPerMille
+		amountMsat >= failedHopAmountMsat-failedHopAmountMsat*rebalanceRouteFailedHopAllowedDeltaPerMille/1_000
 }
 
 func RebalanceServiceStart(ctx context.Context, conn *grpc.ClientConn, db *sqlx.DB, nodeId int) {
 
-	client := lnrpc.NewLightningClient(conn)
-	router := routerrpc.NewRouterClient(conn)
+	backend, err := NewRebalanceBackend(conn, nodeId)
+	if err != nil {
+		log.Error().Err(err).Msgf("Failed to start the rebalance service for nodeId: %v", nodeId)
+		return
+	}
 
 	ticker := time.NewTicker(rebalanceQueueTickerSeconds * time.Second)
 	defer ticker.Stop()
@@ -108,7 +109,12 @@ func RebalanceServiceStart(ctx context.Context, conn *grpc.ClientConn, db *sqlx.
 				continue
 			}
 
-			pendingRebalancers := getRebalancers(&pending)
+			var pendingRebalancers []*Rebalancer
+			for _, pendingRebalancer := range getRebalancers(&pending) {
+				if pendingRebalancer.NodeId == nodeId {
+					pendingRebalancers = append(pendingRebalancers, pendingRebalancer)
+				}
+			}
 			log.Trace().Msgf("Queued (or on hold) rebalancers: %v", len(pendingRebalancers))
 			if len(pendingRebalancers) > 0 {
 				sort.Slice(pendingRebalancers, func(i, j int) bool {
@@ -147,7 +153,7 @@ func RebalanceServiceStart(ctx context.Context, conn *grpc.ClientConn, db *sqlx.
 				if pendingRebalancer != nil && pendingRebalancer.ScheduleTarget.Before(time.Now()) {
 					log.Debug().Msgf("Rebalancers: %v/%v active and %v queued or on hold",
 						len(activeRebalancers), rebalanceMaximumConcurrency, len(pendingRebalancers)-i)
-					go pendingRebalancer.start(db, client, router,
+					go pendingRebalancer.start(db, backend,
 						rebalanceRunnerTimeoutSeconds,
 						rebalanceRoutesTimeoutSeconds,
 						rebalancePayTimeoutSeconds)
@@ -280,6 +286,7 @@ func RebalanceRequests(ctx context.Context,
 				CommunicationResponse: lightning_helpers.CommunicationResponse{
 					Status: lightning_helpers.Active,
 				},
+				Changed: true,
 			}
 		}
 	} else {
@@ -344,6 +351,7 @@ func RebalanceRequests(ctx context.Context,
 				CommunicationResponse: lightning_helpers.CommunicationResponse{
 					Status: lightning_helpers.Active,
 				},
+				Changed: true,
 			}
 		}
 	}
@@ -374,8 +382,7 @@ func hasPendingOutgoingRebalance(pendingRebalancers []*Rebalancer, outgoingChann
 
 func (rebalancer *Rebalancer) start(
 	db *sqlx.DB,
-	client lnrpc.LightningClient,
-	router routerrpc.RouterClient,
+	backend RebalanceBackend,
 	runnerTimeout int,
 	routesTimeout int,
 	payTimeout int) {
@@ -407,6 +414,12 @@ func (rebalancer *Rebalancer) start(
 		}
 	}
 
+	if !rebalancer.isWithinBudget(db) {
+		return
+	}
+
+	rebalancer.hopMemory = getRebalanceHopMemory(db)
+
 	rebalancer.Status = core.Active
 	latestResult, err := rebalances.GetLatestResultByOrigin(db, rebalancer.Request.Origin, rebalancer.Request.OriginId,
 		rebalancer.Request.IncomingChannelId, rebalancer.Request.OutgoingChannelId, core.Active,
@@ -442,7 +455,7 @@ func (rebalancer *Rebalancer) start(
 			RebalanceId:       rebalancer.RebalanceId,
 			OutgoingChannelId: previousSuccess.OutgoingChannelId,
 			IncomingChannelId: previousSuccess.IncomingChannelId,
-			Invoices:          make(map[uint64]*lnrpc.AddInvoiceResponse),
+			Invoices:          make(map[uint64]RebalanceInvoice),
 			FailedHops:        make(map[string]uint64),
 			Status:            core.Active,
 			Ctx:               runnerCtx,
@@ -461,7 +474,7 @@ func (rebalancer *Rebalancer) start(
 			IncomingChannelId: previousSuccessRunner.IncomingChannelId,
 			OutgoingChannelId: previousSuccessRunner.OutgoingChannelId,
 		}
-		result = rebalancer.startRunner(db, client, router, previousSuccessRunner, routesTimeout, payTimeout, result)
+		result = rebalancer.startRunner(db, backend, previousSuccessRunner, routesTimeout, payTimeout, result)
 		if result.Status == core.Active {
 			log.Debug().Msgf("Previous success successfully reused "+
 				"for origin: %v, originReference: %v, incomingChannelId: %v, outgoingChannelId: %v",
@@ -484,7 +497,7 @@ func (rebalancer *Rebalancer) start(
 			"for origin: %v, originReference: %v, incomingChannelId: %v, outgoingChannelId: %v",
 			i, rebalancer.Request.Origin, rebalancer.Request.OriginReference,
 			rebalancer.Request.IncomingChannelId, rebalancer.Request.OutgoingChannelId)
-		go rebalancer.createRunner(db, client, router, runnerTimeout, routesTimeout, payTimeout)
+		go rebalancer.createRunner(db, backend, runnerTimeout, routesTimeout, payTimeout)
 	}
 }
 
@@ -512,8 +525,7 @@ func (rebalancer *Rebalancer) convertPreviousSuccess(previousSuccess rebalances.
 
 func (rebalancer *Rebalancer) createRunner(
 	db *sqlx.DB,
-	client lnrpc.LightningClient,
-	router routerrpc.RouterClient,
+	backend RebalanceBackend,
 	runnerTimeout int,
 	routesTimeout int,
 	payTimeout int) {
@@ -529,7 +541,7 @@ func (rebalancer *Rebalancer) createRunner(
 		IncomingChannelId: rebalancer.Request.IncomingChannelId,
 		OutgoingChannelId: rebalancer.Request.OutgoingChannelId,
 	}
-	channelId := rebalancer.getPendingChannelId()
+	channelId := rebalancer.getPendingChannelId(db)
 	if channelId == 0 {
 		for _, runner := range rebalancer.Runners {
 			if runner.Status == core.Active {
@@ -574,7 +586,7 @@ func (rebalancer *Rebalancer) createRunner(
 	result.IncomingChannelId = runner.IncomingChannelId
 	result.OutgoingChannelId = runner.OutgoingChannelId
 
-	result = rebalancer.startRunner(db, client, router, runner, routesTimeout, payTimeout, result)
+	result = rebalancer.startRunner(db, backend, runner, routesTimeout, payTimeout, result)
 	if result.Status == core.Active {
 		removeRebalancer(rebalancer)
 		runningFor := time.Since(rebalancer.ScheduleTarget).Round(1 * time.Second)
@@ -591,13 +603,12 @@ func (rebalancer *Rebalancer) createRunner(
 	runner.Cancel()
 	runner.Status = core.Inactive
 
-	rebalancer.createRunner(db, client, router, runnerTimeout, routesTimeout, payTimeout)
+	rebalancer.createRunner(db, backend, runnerTimeout, routesTimeout, payTimeout)
 }
 
 func (rebalancer *Rebalancer) startRunner(
 	db *sqlx.DB,
-	client lnrpc.LightningClient,
-	router routerrpc.RouterClient,
+	backend RebalanceBackend,
 	runner *RebalanceRunner,
 	routesTimeout int,
 	payTimeout int,
@@ -605,11 +616,11 @@ func (rebalancer *Rebalancer) startRunner(
 
 	routesCtx, routesCancel := context.WithTimeout(runner.Ctx, time.Second*time.Duration(routesTimeout))
 	defer routesCancel()
-	routes, err := runner.getRoutes(routesCtx, client, router, rebalancer.NodeId,
+	routes, err := runner.getRoutes(routesCtx, backend, rebalancer.hopMemory, rebalancer.NodeId,
 		rebalancer.Request.AmountMsat, rebalancer.Request.MaximumCostMsat)
 	if err != nil {
 		log.Debug().Err(err).Msgf(
-			"Failed to obtain routes from LND for incomingChannelId: %v, outgoingChannelId: %v",
+			"Failed to obtain routes for incomingChannelId: %v, outgoingChannelId: %v",
 			runner.IncomingChannelId, runner.OutgoingChannelId)
 		result.Status = core.Inactive
 		result.Error = err.Error()
@@ -621,26 +632,39 @@ func (rebalancer *Rebalancer) startRunner(
 	routesCancel()
 
 	for _, route := range routes {
+		// Running rebalancers share the budgets so the budget is verified (and reserved) before every attempt
+		release, withinBudget := rebalancer.reserveBudget(db, runner.IncomingChannelId, runner.OutgoingChannelId)
+		if !withinBudget {
+			// The workflow requests the rebalance again and the pending rebalancer is paused by isWithinBudget
+			rebalancer.Status = core.Inactive
+			removeRebalancer(rebalancer)
+			rebalancer.RebalanceCancel()
+			result.Status = core.Inactive
+			result.Error = "Rebalance budget exhausted"
+			return result
+		}
 		payCtx, payCancel := context.WithTimeout(runner.Ctx, time.Second*time.Duration(payTimeout))
-		result = runner.pay(payCtx, client, router, rebalancer.Request.AmountMsat, route)
+		result = runner.pay(payCtx, db, backend, rebalancer.NodeId, rebalancer.Request.AmountMsat, route)
 		payCancel()
 		if payCtx.Err() == context.DeadlineExceeded {
 			result.Error = payCtx.Err().Error()
 		}
 		rebalancer.processResult(db, result)
+		release()
 		if result.Status == core.Active {
+			rebalancer.sendSucceededAlert(result)
 			return result
 		}
 	}
 
 	if result.Status == core.Pending {
-		result = rebalancer.startRunner(db, client, router, runner, routesTimeout, payTimeout, result)
+		result = rebalancer.startRunner(db, backend, runner, routesTimeout, payTimeout, result)
 	}
 	return result
 }
 
-// TODO FIXME make channel selection smarter instead of at random...
-func (rebalancer *Rebalancer) getPendingChannelId() int {
+// getPendingChannelId returns the most promising counterpart channel that has no runner yet (see rankRebalanceCandidates).
+func (rebalancer *Rebalancer) getPendingChannelId(db *sqlx.DB) int {
 	if rebalancer.Request.WorkflowUnfocusedPath == "" {
 		return 0
 	}
@@ -718,7 +742,16 @@ func (rebalancer *Rebalancer) getPendingChannelId() int {
 					log.Error().Err(errors.New(msg)).Msg(msg)
 					return 0
 				}
-				channelIds = FilterChannelBodyChannelIds(params, linkedChannels)
+				channelFlows, err := getCachedChannelFlows(channelIds, func(channelIds []int) (map[int]ChannelFlow, error) {
+					return GetChannelFlows(db, channelIds)
+				})
+				if err != nil {
+					msg := fmt.Sprintf("Failed to obtain channel flows for originId: %v",
+						rebalancer.Request.OriginId)
+					log.Error().Err(errors.New(msg)).Msg(msg)
+					return 0
+				}
+				channelIds = FilterChannelBodyChannelIds(params, linkedChannels, channelFlows)
 			}
 
 			if len(channelIds) == 0 {
@@ -731,10 +764,8 @@ func (rebalancer *Rebalancer) getPendingChannelId() int {
 		return 0
 	}
 
-	// Randomise the sequence of the pending channels
-	rand.Seed(time.Now().UnixNano())
-	rand.Shuffle(len(channelIds), func(i, j int) { channelIds[i], channelIds[j] = channelIds[j], channelIds[i] })
-
+	incomingFocus := rebalancer.Request.IncomingChannelId != 0
+	var candidates []rebalanceCandidate
 outer:
 	for _, channelId := range channelIds {
 		for existingChannelId := range rebalancer.Runners {
@@ -747,21 +778,64 @@ outer:
 			continue outer
 		}
 		channelState := cache.GetChannelState(torqNodeId, channelId, true)
-		if channelState.LocalDisabled {
+		if channelState == nil || channelState.LocalDisabled {
 			continue outer
 		}
-
-		if rebalancer.Request.IncomingChannelId != 0 {
-			log.Debug().Msgf("New outgoingChannelId (%v) was chosen for incomingChannelId (%v) and originId: %v",
-				channelId, rebalancer.Request.IncomingChannelId, rebalancer.Request.OriginId)
+		candidate := rebalanceCandidate{
+			channelId:        channelId,
+			feeRateMilliMsat: channelState.LocalFeeRateMilliMsat,
 		}
-		if rebalancer.Request.OutgoingChannelId != 0 {
-			log.Debug().Msgf("New incomingChannelId (%v) was chosen for outgoingChannelId (%v) and originId: %v",
-				channelId, rebalancer.Request.OutgoingChannelId, rebalancer.Request.OriginId)
+		// Outgoing candidates need local liquidity, incoming candidates need remote liquidity.
+		if incomingFocus {
+			balanceState := cache.GetChannelBalanceState(torqNodeId, channelId, true,
+				cache.PendingHtlcsLocalBalanceAdjustedDownwards)
+			if balanceState != nil {
+				candidate.liquiditySurplusPerMille = balanceState.LocalBalancePerMilleRatio
+			}
+		} else {
+			balanceState := cache.GetChannelBalanceState(torqNodeId, channelId, true,
+				cache.PendingHtlcsRemoteBalanceAdjustedDownwards)
+			if balanceState != nil {
+				candidate.liquiditySurplusPerMille = balanceState.RemoteBalancePerMilleRatio
+			}
 		}
-		return channelId
+		candidates = append(candidates, candidate)
+	}
+	if len(candidates) == 0 {
+		return 0
+	}
+
+	candidateChannelIds := make([]int, len(candidates))
+	for i := range candidates {
+		candidateChannelIds[i] = candidates[i].channelId
+	}
+	// The candidates are outgoing channels when the focus is an incoming channel and vice versa.
+	statistics, err := rebalances.GetRebalanceChannelStatistics(db, !incomingFocus, candidateChannelIds,
+		time.Now().AddDate(0, 0, -rebalanceChannelStatisticsDays))
+	if err != nil {
+		log.Error().Err(err).Msgf("Failed to obtain the rebalance statistics for originId: %v",
+			rebalancer.Request.OriginId)
+	}
+	for i := range candidates {
+		candidates[i].statistics = statistics[candidates[i].channelId]
+	}
+
+	var maximumCostPpm uint64
+	if rebalancer.Request.AmountMsat != 0 {
+		maximumCostPpm = rebalancer.Request.MaximumCostMsat * 1_000_000 / rebalancer.Request.AmountMsat
+	}
+	rankRebalanceCandidates(candidates, maximumCostPpm, incomingFocus)
+
+	channelId := candidates[0].channelId
+	if rebalancer.Request.IncomingChannelId != 0 {
+		log.Debug().Msgf("New outgoingChannelId (%v) was chosen for incomingChannelId (%v) and originId: %v (score: %.3f)",
+			channelId, rebalancer.Request.IncomingChannelId, rebalancer.Request.OriginId, candidates[0].score)
 	}
-	return 0
+	if rebalancer.Request.OutgoingChannelId != 0 {
+		log.Debug().Msgf("New incomingChannelId (%v) was chosen for outgoingChannelId (%v) and originId: %v (score: %.3f)",
+			channelId, rebalancer.Request.OutgoingChannelId, rebalancer.Request.OriginId, candidates[0].score)
+	}
+	return channelId
 }
 
 func (rebalancer *Rebalancer) addRunner(
@@ -771,7 +845,7 @@ func (rebalancer *Rebalancer) addRunner(
 
 	runner := RebalanceRunner{
 		RebalanceId: rebalancer.RebalanceId,
-		Invoices:    make(map[uint64]*lnrpc.AddInvoiceResponse),
+		Invoices:    make(map[uint64]RebalanceInvoice),
 		FailedHops:  make(map[string]uint64),
 		Ctx:         runnerCtx,
 		Cancel:      runnerCancel,
@@ -799,63 +873,82 @@ func (rebalancer *Rebalancer) processResult(db *sqlx.DB, result rebalances.Rebal
 	}
 }
 
+// sendSucceededAlert notifies the communications subscribed to successful rebalances.
+func (rebalancer *Rebalancer) sendSucceededAlert(result rebalances.RebalanceResult) {
+	message := fmt.Sprintf("Rebalance succeeded: %v sat from channel %v to channel %v for %v msat fee",
+		result.TotalAmountMsat/1000,
+		getChannelDescription(result.OutgoingChannelId), getChannelDescription(result.IncomingChannelId),
+		result.TotalFeeMsat)
+	cache.SendNotifierEvent(core.NotifierEvent{
+		EventData: core.EventData{
+			EventTime: time.Now().UTC(),
+			NodeId:    rebalancer.NodeId,
+		},
+		Notification:     &message,
+		NotificationType: core.RebalanceSucceededNotification,
+	})
+}
+
+func getChannelDescription(channelId int) string {
+	channelSettings := cache.GetChannelSettingByChannelId(channelId)
+	if channelSettings.ShortChannelId != nil && *channelSettings.ShortChannelId != "" {
+		return *channelSettings.ShortChannelId
+	}
+	return fmt.Sprintf("%v", channelId)
+}
+
 func (runner *RebalanceRunner) getRoutes(
 	ctx context.Context,
-	client lnrpc.LightningClient,
-	router routerrpc.RouterClient,
+	backend RebalanceBackend,
+	hopMemory *rebalanceHopMemory,
 	nodeId int,
 	amountMsat uint64,
-	fixedFeeMsat uint64) ([]*lnrpc.Route, error) {
+	fixedFeeMsat uint64) ([]RebalanceRoute, error) {
 
 	outgoingChannel := cache.GetChannelSettingByChannelId(runner.OutgoingChannelId)
 	incomingChannel := cache.GetChannelSettingByChannelId(runner.IncomingChannelId)
 	var remoteNode cache.NodeSettingsCache
-	if outgoingChannel.FirstNodeId == nodeId {
+	if incomingChannel.FirstNodeId == nodeId {
 		remoteNode = cache.GetNodeSettingsByNodeId(incomingChannel.SecondNodeId)
 	} else {
 		remoteNode = cache.GetNodeSettingsByNodeId(incomingChannel.FirstNodeId)
 	}
-	remoteNodePublicKey, err := hex.DecodeString(remoteNode.PublicKey)
-	if err != nil {
-		return nil, errors.Wrapf(err, "Decoding public key for outgoing nodeId: %v", outgoingChannel.SecondNodeId)
-	}
-	if outgoingChannel.LndShortChannelId == nil {
-		return nil, errors.Wrapf(err,
-			"Outgoing channel has no LND Short Channel Id for outgoing nodeId: %v", outgoingChannel.SecondNodeId)
-	}
 
-	routes, err := client.QueryRoutes(ctx, &lnrpc.QueryRoutesRequest{
-		PubKey:            cache.GetNodeSettingsByNodeId(nodeId).PublicKey,
-		OutgoingChanId:    *outgoingChannel.LndShortChannelId,
-		LastHopPubkey:     remoteNodePublicKey,
-		AmtMsat:           int64(amountMsat),
-		UseMissionControl: true,
-		FeeLimit:          &lnrpc.FeeLimit{Limit: &lnrpc.FeeLimit_FixedMsat{FixedMsat: int64(fixedFeeMsat)}},
-		IgnoredPairs:      runner.FailedPairs,
+	routes, err := backend.GetRoutes(ctx, RebalanceRoutesRequest{
+		NodeId:           nodeId,
+		OutgoingChannel:  outgoingChannel,
+		IncomingChannel:  incomingChannel,
+		LastHopPublicKey: remoteNode.PublicKey,
+		AmountMsat:       amountMsat,
+		FixedFeeMsat:     fixedFeeMsat,
+		FailedPairs:      append(hopMemory.getFailedPairs(amountMsat), runner.FailedPairs...),
 	})
 	if err != nil {
-		return nil, errors.Wrapf(err,
-			"QueryRoutes for outgoing nodeId: %v, publicKey: %v", outgoingChannel.FirstNodeId, remoteNode.PublicKey)
+		return nil, errors.Wrapf(err, "Obtaining routes for outgoing channelId: %v, incoming channelId: %v",
+			runner.OutgoingChannelId, runner.IncomingChannelId)
 	}
 
-	var result []*lnrpc.Route
-	for i := range routes.Routes {
-		if runner.validateRoute(nodeId, routes.Routes[i]) {
-			result = append(result, routes.Routes[i])
+	nodePublicKey := cache.GetNodeSettingsByNodeId(nodeId).PublicKey
+	var result []RebalanceRoute
+	for i := range routes {
+		if runner.validateRoute(nodePublicKey, routes[i], hopMemory) {
+			result = append(result, routes[i])
 		}
 	}
 	if len(result) == 0 {
-		return runner.getRoutes(ctx, client, router, nodeId, amountMsat, fixedFeeMsat)
+		return runner.getRoutes(ctx, backend, hopMemory, nodeId, amountMsat, fixedFeeMsat)
 	}
+	sortRoutesByPenalty(nodePublicKey, result, hopMemory)
 	return result, nil
 }
 
 func (runner *RebalanceRunner) pay(
 	ctx context.Context,
-	client lnrpc.LightningClient,
-	router routerrpc.RouterClient,
+	db *sqlx.DB,
+	backend RebalanceBackend,
+	nodeId int,
 	amountMsat uint64,
-	route *lnrpc.Route) rebalances.RebalanceResult {
+	route RebalanceRoute) rebalances.RebalanceResult {
 
 	rebalanceResult := rebalances.RebalanceResult{
 		OutgoingChannelId: runner.OutgoingChannelId,
@@ -865,102 +958,110 @@ func (runner *RebalanceRunner) pay(
 		Status:            core.Inactive,
 	}
 
-	invoice, err := runner.createInvoice(ctx, client, amountMsat)
+	invoice, err := runner.createInvoice(ctx, backend, amountMsat)
 	if err != nil {
 		log.Debug().Err(err).Msgf("Failed to create an invoice for %v msats", amountMsat)
 		rebalanceResult.Error = err.Error()
 		return rebalanceResult
 	}
-	lastHop := route.Hops[len(route.Hops)-1]
-	lastHop.MppRecord = &lnrpc.MPPRecord{
-		PaymentAddr:  invoice.PaymentAddr,
-		TotalAmtMsat: int64(amountMsat),
-	}
 
-	result, err := router.SendToRouteV2(ctx,
-		&routerrpc.SendToRouteRequest{
-			PaymentHash: invoice.RHash,
-			Route:       route,
-		})
-	if result != nil && result.Route != nil {
-		rebalanceResult.TotalFeeMsat = uint64(result.Route.TotalFeesMsat)
-		rebalanceResult.TotalTimeLock = result.Route.TotalTimeLock
-		rebalanceResult.TotalAmountMsat = uint64(result.Route.TotalAmtMsat)
-	}
-	if err != nil {
-		log.Debug().Err(err).Msgf("Failed to call SendToRouteV2 for route: %v", route)
-		rebalanceResult.Error = err.Error()
-		return rebalanceResult
-	}
-	if result.Status == lnrpc.HTLCAttempt_FAILED {
+	payment := backend.SendToRoute(ctx, invoice, route)
+	rebalanceResult.TotalFeeMsat = payment.TotalFeeMsat
+	rebalanceResult.TotalTimeLock = payment.TotalTimeLock
+	rebalanceResult.TotalAmountMsat = payment.TotalAmountMsat
+	if payment.Status != core.Active {
 		rebalanceResult.Status = core.Inactive
-		if result.Failure.FailureSourceIndex >= uint32(len(route.Hops)) {
+		if payment.FailureSourceIndex == nil {
+			rebalanceResult.Error = payment.Error
+			return rebalanceResult
+		}
+		failureSourceIndex := *payment.FailureSourceIndex
+		if failureSourceIndex >= uint32(len(route.Hops)) {
 			rebalanceResult.Error = fmt.Sprintf("%s unknown hop index: %d. Maximum hop index: %d",
-				result.Failure.Code.String(), result.Failure.FailureSourceIndex, len(route.Hops))
+				payment.Error, failureSourceIndex, len(route.Hops))
 			return rebalanceResult
 		}
-		if result.Failure.FailureSourceIndex == 0 {
+		if failureSourceIndex == 0 {
 			rebalanceResult.Error = fmt.Sprintf("%s unknown hop index %d. Minimum hop index is greater than 0",
-				result.Failure.Code.String(), result.Failure.FailureSourceIndex)
+				payment.Error, failureSourceIndex)
 			return rebalanceResult
 		}
-		prevHop := route.Hops[result.Failure.FailureSourceIndex-1]
-		failedHop := route.Hops[result.Failure.FailureSourceIndex]
-		if result.Failure.Code == lnrpc.Failure_TEMPORARY_CHANNEL_FAILURE {
+		prevHop := route.Hops[failureSourceIndex-1]
+		failedHop := route.Hops[failureSourceIndex]
+		if payment.Status == core.Pending {
 			rebalanceResult.Status = core.Pending
-			runner.addFailedHop(prevHop.PubKey, failedHop.PubKey, uint64(prevHop.AmtToForwardMsat))
+			runner.addFailedHop(prevHop.PublicKey, failedHop.PublicKey, failedHop.AmountToForwardMsat)
+			failedHopIndex := int(failureSourceIndex)
+			storeRebalanceHopMemory(db, nodeId, route, &failedHopIndex)
 		}
 		rebalanceResult.Error = fmt.Sprintf("error: %s occured at hop index %d (%v -> %v)",
-			result.Failure.Code.String(), result.Failure.FailureSourceIndex, prevHop.PubKey, failedHop.PubKey)
+			payment.Error, failureSourceIndex, prevHop.PublicKey, failedHop.PublicKey)
 		return rebalanceResult
 	}
 	delete(runner.Invoices, amountMsat)
+	storeRebalanceHopMemory(db, nodeId, route, nil)
 	rebalanceResult.Status = core.Active
-	if result != nil && result.Route != nil {
-		hopsJsonByteArray, err := json.Marshal(result.Route.Hops)
-		if err != nil {
-			log.Error().Err(err).Msgf("Marshalling the route hops for rebalancerId: %v", runner.RebalanceId)
-			return rebalanceResult
-		}
-		rebalanceResult.Hops = string(hopsJsonByteArray)
-	}
+	rebalanceResult.Hops = payment.Hops
 	return rebalanceResult
 }
 
-func (runner *RebalanceRunner) validateRoute(nodeId int, route *lnrpc.Route) bool {
-	previousHopPublicKey := cache.GetNodeSettingsByNodeId(nodeId).PublicKey
+// validateRoute rejects routes over a pair that failed for this runner
+// and routes that are likely to fail again according to the hop memory.
+// The (worst) pair of a rejected route is excluded when the routes are obtained again.
+func (runner *RebalanceRunner) validateRoute(
+	nodePublicKey string,
+	route RebalanceRoute,
+	hopMemory *rebalanceHopMemory) bool {
+
+	previousHopPublicKey := nodePublicKey
 	for _, h := range route.Hops {
-		if runner.isFailedHop(previousHopPublicKey, h.PubKey, uint64(h.AmtToForwardMsat)) {
-			from, err := hex.DecodeString(previousHopPublicKey)
-			if err != nil {
-				return false
-			}
-			to, err := hex.DecodeString(h.PubKey)
-			if err != nil {
-				return false
-			}
-			runner.FailedPairs = append(runner.FailedPairs, &lnrpc.NodePair{From: from, To: to})
+		if runner.isFailedHop(previousHopPublicKey, h.PublicKey, h.AmountToForwardMsat) {
+			runner.FailedPairs = append(runner.FailedPairs, RebalanceHopPair{
+				FromPublicKey:  previousHopPublicKey,
+				ToPublicKey:    h.PublicKey,
+				ShortChannelId: h.ShortChannelId,
+			})
 			return false
 		}
-		previousHopPublicKey = h.PubKey
+		previousHopPublicKey = h.PublicKey
+	}
+	penalty, worstHopIndex := hopMemory.getRoutePenalty(nodePublicKey, route)
+	if penalty >= rebalanceHopMemoryPruneThreshold {
+		failedPair := RebalanceHopPair{
+			FromPublicKey:  nodePublicKey,
+			ToPublicKey:    route.Hops[worstHopIndex].PublicKey,
+			ShortChannelId: route.Hops[worstHopIndex].ShortChannelId,
+		}
+		if worstHopIndex > 0 {
+			failedPair.FromPublicKey = route.Hops[worstHopIndex-1].PublicKey
+		}
+		runner.FailedPairs = append(runner.FailedPairs, failedPair)
+		return false
 	}
 	return true
 }
 
+// sortRoutesByPenalty tries the routes with the least remembered failures first
+func sortRoutesByPenalty(nodePublicKey string, routes []RebalanceRoute, hopMemory *rebalanceHopMemory) {
+	sort.SliceStable(routes, func(i, j int) bool {
+		penaltyI, _ := hopMemory.getRoutePenalty(nodePublicKey, routes[i])
+		penaltyJ, _ := hopMemory.getRoutePenalty(nodePublicKey, routes[j])
+		return penaltyI < penaltyJ
+	})
+}
+
 func (runner *RebalanceRunner) createInvoice(
 	ctx context.Context,
-	client lnrpc.LightningClient,
-	amountMsat uint64) (*lnrpc.AddInvoiceResponse, error) {
+	backend RebalanceBackend,
+	amountMsat uint64) (RebalanceInvoice, error) {
 
 	invoice, exists := runner.Invoices[amountMsat]
 	if exists {
 		return invoice, nil
 	}
-	invoice, err := client.AddInvoice(ctx, &lnrpc.Invoice{ValueMsat: int64(amountMsat),
-		Memo:   "Rebalance attempt",
-		Expiry: int64(rebalanceTimeoutSeconds)})
+	invoice, err := backend.CreateInvoice(ctx, amountMsat)
 	if err != nil {
-		return nil, errors.Wrapf(err, "AddInvoice for %v msat", amountMsat)
+		return RebalanceInvoice{}, errors.Wrapf(err, "Creating invoice for %v msat", amountMsat)
 	}
 	runner.Invoices[amountMsat] = invoice
 	return invoice, nil
@@ -1089,16 +1190,16 @@ func updateExistingRebalanceRequest(db *sqlx.DB, request lightning_helpers.Rebal
 		return nil
 	}
 	var err error
-	if rebalancer.Request.AmountMsat != request.AmountMsat {
-		err = setRebalancer(db, request, rebalancer)
-	} else if rebalancer.Request.MaximumCostMsat != request.MaximumCostMsat {
-		err = setRebalancer(db, request, rebalancer)
-	} else if rebalancer.Request.MaximumConcurrency != request.MaximumConcurrency {
+	changed := rebalancer.Request.AmountMsat != request.AmountMsat ||
+		rebalancer.Request.MaximumCostMsat != request.MaximumCostMsat ||
+		rebalancer.Request.MaximumConcurrency != request.MaximumConcurrency
+	if changed {
 		err = setRebalancer(db, request, rebalancer)
 	}
 	if err != nil {
 		return &lightning_helpers.RebalanceResponse{
 			Request: request,
+			Changed: true,
 			CommunicationResponse: lightning_helpers.CommunicationResponse{
 				Status: lightning_helpers.Inactive,
 				Error: fmt.Sprintf(
@@ -1114,6 +1215,7 @@ func updateExistingRebalanceRequest(db *sqlx.DB, request lightning_helpers.Rebal
 		CommunicationResponse: lightning_helpers.CommunicationResponse{
 			Status: lightning_helpers.Active,
 		},
+		Changed: changed,
 	}
 	if rebalancer.Request.IncomingChannelId != 0 {
 		rebalanceResponse.Message = fmt.Sprintf(
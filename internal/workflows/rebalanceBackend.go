@@ -0,0 +1,82 @@
+package workflows
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cockroachdb/errors"
+	"google.golang.org/grpc"
+
+	"github.com/lncapital/torq/internal/cache"
+	"github.com/lncapital/torq/internal/core"
+)
+
+// RebalanceBackend hides the implementation specific steps of a circular rebalance:
+// finding routes from the outgoing channel back to the incoming channel and paying ourselves over such a route.
+type RebalanceBackend interface {
+	GetRoutes(ctx context.Context, request RebalanceRoutesRequest) ([]RebalanceRoute, error)
+	CreateInvoice(ctx context.Context, amountMsat uint64) (RebalanceInvoice, error)
+	SendToRoute(ctx context.Context, invoice RebalanceInvoice, route RebalanceRoute) RebalancePayment
+}
+
+type RebalanceRoutesRequest struct {
+	NodeId          int
+	OutgoingChannel cache.ChannelSettingsCache
+	IncomingChannel cache.ChannelSettingsCache
+	// LastHopPublicKey is the public key of the remote node of the incoming channel
+	LastHopPublicKey string
+	AmountMsat       uint64
+	FixedFeeMsat     uint64
+	FailedPairs      []RebalanceHopPair
+}
+
+type RebalanceHopPair struct {
+	FromPublicKey  string
+	ToPublicKey    string
+	ShortChannelId string
+}
+
+type RebalanceHop struct {
+	PublicKey           string `json:"pubKey"`
+	ShortChannelId      string `json:"shortChannelId"`
+	AmountToForwardMsat uint64 `json:"amtToForwardMsat"`
+}
+
+type RebalanceRoute struct {
+	Hops            []RebalanceHop
+	TotalAmountMsat uint64
+	TotalFeeMsat    uint64
+	TotalTimeLock   uint32
+	// implementationRoute is the route as it was obtained from the node (i.e. *lnrpc.Route)
+	implementationRoute any
+}
+
+type RebalanceInvoice struct {
+	PaymentHash []byte
+	PaymentAddr []byte
+	AmountMsat  uint64
+}
+
+type RebalancePayment struct {
+	// Status is Active when the payment succeeded, Pending when it failed on a temporary channel failure
+	// (so the route can be retried without the failed hop) and Inactive otherwise.
+	Status          core.Status
+	TotalAmountMsat uint64
+	TotalFeeMsat    uint64
+	TotalTimeLock   uint32
+	// Hops is the JSON representation of the hops of the successful route
+	Hops string
+	// FailureSourceIndex is the index of the node that reported the failure (0 is the node itself)
+	FailureSourceIndex *uint32
+	Error              string
+}
+
+func NewRebalanceBackend(conn *grpc.ClientConn, nodeId int) (RebalanceBackend, error) {
+	switch cache.GetNodeConnectionDetails(nodeId).Implementation {
+	case core.LND:
+		return newLndRebalanceBackend(conn), nil
+	case core.CLN:
+		return newClnRebalanceBackend(conn, nodeId), nil
+	}
+	return nil, errors.New(fmt.Sprintf("Rebalancing is not supported for the implementation of nodeId: %v", nodeId))
+}
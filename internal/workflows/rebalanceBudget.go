@@ -0,0 +1,152 @@
+package workflows
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/jmoiron/sqlx"
+	"github.com/rs/zerolog/log"
+
+	"github.com/lncapital/torq/internal/cache"
+	"github.com/lncapital/torq/internal/core"
+	"github.com/lncapital/torq/internal/lightning_helpers"
+	"github.com/lncapital/torq/internal/rebalances"
+)
+
+// A rebalancer with an exhausted budget is paused for this duration before it checks its budgets again.
+const rebalanceBudgetPauseMinutes = 60
+
+// rebalanceBudgetReservations holds the maximum cost of the attempts in flight by rebalanceBudgetId, the fees of
+// those attempts are only in the budget spend once their result is stored.
+var (
+	rebalanceBudgetReservationsMutex sync.Mutex             //nolint:gochecknoglobals
+	rebalanceBudgetReservations      = make(map[int]uint64) //nolint:gochecknoglobals
+)
+
+// getExhaustedRebalanceBudget returns the first budget that cannot cover the maximum cost of another attempt.
+func getExhaustedRebalanceBudget(
+	rebalanceBudgetSpends []rebalances.RebalanceBudgetSpend,
+	maximumCostMsat uint64) *rebalances.RebalanceBudgetSpend {
+
+	for i := range rebalanceBudgetSpends {
+		if rebalanceBudgetSpends[i].SpentFeeMsat+maximumCostMsat > rebalanceBudgetSpends[i].MaximumFeeMsat {
+			return &rebalanceBudgetSpends[i]
+		}
+	}
+	return nil
+}
+
+// reserveRebalanceBudget reserves the maximum cost of an attempt on all the budgets or returns the first budget that
+// cannot cover it (including the attempts in flight). The release function needs to be called once the result of the
+// attempt is stored.
+func reserveRebalanceBudget(
+	rebalanceBudgetSpends []rebalances.RebalanceBudgetSpend,
+	maximumCostMsat uint64) (func(), *rebalances.RebalanceBudgetSpend) {
+
+	rebalanceBudgetReservationsMutex.Lock()
+	defer rebalanceBudgetReservationsMutex.Unlock()
+	reservedBudgetSpends := make([]rebalances.RebalanceBudgetSpend, len(rebalanceBudgetSpends))
+	for i := range rebalanceBudgetSpends {
+		reservedBudgetSpends[i] = rebalanceBudgetSpends[i]
+		reservedBudgetSpends[i].SpentFeeMsat += rebalanceBudgetReservations[rebalanceBudgetSpends[i].RebalanceBudgetId]
+	}
+	exhaustedBudget := getExhaustedRebalanceBudget(reservedBudgetSpends, maximumCostMsat)
+	if exhaustedBudget != nil {
+		return nil, exhaustedBudget
+	}
+	for _, rebalanceBudgetSpend := range rebalanceBudgetSpends {
+		rebalanceBudgetReservations[rebalanceBudgetSpend.RebalanceBudgetId] += maximumCostMsat
+	}
+	return func() {
+		rebalanceBudgetReservationsMutex.Lock()
+		defer rebalanceBudgetReservationsMutex.Unlock()
+		for _, rebalanceBudgetSpend := range rebalanceBudgetSpends {
+			rebalanceBudgetReservations[rebalanceBudgetSpend.RebalanceBudgetId] -= maximumCostMsat
+			if rebalanceBudgetReservations[rebalanceBudgetSpend.RebalanceBudgetId] == 0 {
+				delete(rebalanceBudgetReservations, rebalanceBudgetSpend.RebalanceBudgetId)
+			}
+		}
+	}, nil
+}
+
+func getRebalanceBudgetDescription(rebalanceBudgetSpend rebalances.RebalanceBudgetSpend) string {
+	switch {
+	case rebalanceBudgetSpend.NodeId != nil:
+		return fmt.Sprintf("nodeId: %v", *rebalanceBudgetSpend.NodeId)
+	case rebalanceBudgetSpend.TagId != nil:
+		return fmt.Sprintf("tagId: %v", *rebalanceBudgetSpend.TagId)
+	case rebalanceBudgetSpend.WorkflowId != nil:
+		return fmt.Sprintf("workflowId: %v", *rebalanceBudgetSpend.WorkflowId)
+	}
+	return fmt.Sprintf("rebalanceBudgetId: %v", rebalanceBudgetSpend.RebalanceBudgetId)
+}
+
+// isWithinBudget verifies the budgets that apply to the rebalancer before it starts.
+// The counterpart channel is not known yet so only the budgets of the focus channel are verified.
+// When a budget is exhausted the rebalancer is paused and the reason is logged in its workflow node log.
+func (rebalancer *Rebalancer) isWithinBudget(db *sqlx.DB) bool {
+	release, ok := rebalancer.reserveBudget(db, rebalancer.Request.IncomingChannelId, rebalancer.Request.OutgoingChannelId)
+	if !ok {
+		rebalancer.pause(rebalanceBudgetPauseMinutes * time.Minute)
+		return false
+	}
+	release()
+	return true
+}
+
+// reserveBudget reserves the maximum cost of an attempt on the budgets that apply to the rebalancer and the channels.
+// When a budget is exhausted the reason is logged in its workflow node log and ok is false.
+func (rebalancer *Rebalancer) reserveBudget(db *sqlx.DB,
+	incomingChannelId int, outgoingChannelId int) (release func(), ok bool) {
+
+	var workflowVersionNodeId int
+	if rebalancer.Request.Origin == lightning_helpers.RebalanceWorkflowNode {
+		workflowVersionNodeId = rebalancer.Request.OriginId
+	}
+	rebalanceBudgetSpends, err := rebalances.GetApplicableRebalanceBudgetSpends(db,
+		rebalancer.NodeId, incomingChannelId, outgoingChannelId, workflowVersionNodeId)
+	if err != nil {
+		// Failing to obtain the budgets should not cause unlimited spending
+		log.Error().Err(err).Msgf("Obtaining rebalance budgets for origin: %v, originReference: %v",
+			rebalancer.Request.Origin, rebalancer.Request.OriginReference)
+		return nil, false
+	}
+	release, exhaustedBudget := reserveRebalanceBudget(rebalanceBudgetSpends, rebalancer.Request.MaximumCostMsat)
+	if exhaustedBudget == nil {
+		return release, true
+	}
+
+	budgetErr := errors.New(fmt.Sprintf(
+		"Rebalance budget exhausted for %v: spent %vmsat of %vmsat in %v days (maximum cost per attempt: %vmsat). "+
+			"Rebalancing is paused for %v minutes.",
+		getRebalanceBudgetDescription(*exhaustedBudget), exhaustedBudget.SpentFeeMsat, exhaustedBudget.MaximumFeeMsat,
+		exhaustedBudget.PeriodDays, rebalancer.Request.MaximumCostMsat, rebalanceBudgetPauseMinutes))
+	log.Info().Msgf("%v Origin: %v, OriginId: %v, IncomingChannelId: %v, OutgoingChannelId: %v",
+		budgetErr.Error(), rebalancer.Request.Origin, rebalancer.Request.OriginId,
+		rebalancer.Request.IncomingChannelId, rebalancer.Request.OutgoingChannelId)
+	if workflowVersionNodeId != 0 {
+		AddWorkflowVersionNodeLog(db, rebalancer.Request.OriginReference, workflowVersionNodeId, 0, nil, nil, budgetErr)
+	}
+	budgetMessage := budgetErr.Error()
+	cache.SendNotifierEvent(core.NotifierEvent{
+		EventData: core.EventData{
+			EventTime: time.Now().UTC(),
+			NodeId:    rebalancer.NodeId,
+		},
+		Notification:     &budgetMessage,
+		NotificationType: core.RebalanceBudgetExhaustedNotification,
+	})
+	return nil, false
+}
+
+// pause postpones the (pending) rebalancer by rescheduling it.
+func (rebalancer *Rebalancer) pause(duration time.Duration) {
+	removeRebalancer(rebalancer)
+	rebalancer.ScheduleTarget = time.Now().UTC().Add(duration)
+	if !addRebalancer(rebalancer) {
+		log.Error().Msgf("Failed to pause the rebalancer for Origin: %v, OriginId: %v",
+			rebalancer.Request.Origin, rebalancer.Request.OriginId)
+	}
+}
@@ -0,0 +1,111 @@
+package workflows
+
+import (
+	"testing"
+
+	"github.com/lncapital/torq/internal/rebalances"
+	"github.com/lncapital/torq/testutil"
+)
+
+func TestGetExhaustedRebalanceBudget(t *testing.T) {
+	budget := func(rebalanceBudgetId int, maximumFeeMsat uint64, spentFeeMsat uint64) rebalances.RebalanceBudgetSpend {
+		return rebalances.RebalanceBudgetSpend{
+			RebalanceBudget: rebalances.RebalanceBudget{
+				RebalanceBudgetId: rebalanceBudgetId,
+				MaximumFeeMsat:    maximumFeeMsat,
+				PeriodDays:        7,
+			},
+			SpentFeeMsat: spentFeeMsat,
+		}
+	}
+
+	testCases := []struct {
+		name            string
+		budgets         []rebalances.RebalanceBudgetSpend
+		maximumCostMsat uint64
+		want            int
+	}{
+		{
+			name:            "no budgets",
+			budgets:         nil,
+			maximumCostMsat: 1_000,
+			want:            0,
+		},
+		{
+			name:            "within budget",
+			budgets:         []rebalances.RebalanceBudgetSpend{budget(1, 50_000_000, 10_000_000)},
+			maximumCostMsat: 1_000_000,
+			want:            0,
+		},
+		{
+			name:            "exactly the remaining budget",
+			budgets:         []rebalances.RebalanceBudgetSpend{budget(1, 50_000_000, 49_000_000)},
+			maximumCostMsat: 1_000_000,
+			want:            0,
+		},
+		{
+			name:            "maximum cost exceeds the remaining budget",
+			budgets:         []rebalances.RebalanceBudgetSpend{budget(1, 50_000_000, 49_500_000)},
+			maximumCostMsat: 1_000_000,
+			want:            1,
+		},
+		{
+			name: "second budget exhausted",
+			budgets: []rebalances.RebalanceBudgetSpend{
+				budget(1, 50_000_000, 10_000_000),
+				budget(2, 5_000_000, 5_000_000),
+			},
+			maximumCostMsat: 1_000,
+			want:            2,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := 0
+			exhaustedBudget := getExhaustedRebalanceBudget(tc.budgets, tc.maximumCostMsat)
+			if exhaustedBudget != nil {
+				got = exhaustedBudget.RebalanceBudgetId
+			}
+			if got != tc.want {
+				testutil.Errorf(t, "getExhaustedRebalanceBudget() = %v, want %v", got, tc.want)
+			} else {
+				testutil.Successf(t, "getExhaustedRebalanceBudget() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestReserveRebalanceBudgetWithTwoRebalancers(t *testing.T) {
+	budgetSpends := []rebalances.RebalanceBudgetSpend{{
+		RebalanceBudget: rebalances.RebalanceBudget{RebalanceBudgetId: 1, MaximumFeeMsat: 3_000_000, PeriodDays: 7},
+		SpentFeeMsat:    1_000_000,
+	}}
+	maximumCostMsat := uint64(1_000_000)
+
+	releaseFirst, exhaustedBudget := reserveRebalanceBudget(budgetSpends, maximumCostMsat)
+	if exhaustedBudget != nil {
+		testutil.Fatalf(t, "reserveRebalanceBudget() first rebalancer exhausted budget: %v", exhaustedBudget)
+	}
+	releaseSecond, exhaustedBudget := reserveRebalanceBudget(budgetSpends, maximumCostMsat)
+	if exhaustedBudget != nil {
+		testutil.Fatalf(t, "reserveRebalanceBudget() second rebalancer exhausted budget: %v", exhaustedBudget)
+	}
+	// Both attempts are in flight: the spend (1M) and the reservations (2M) use the whole budget
+	_, exhaustedBudget = reserveRebalanceBudget(budgetSpends, maximumCostMsat)
+	if exhaustedBudget == nil || exhaustedBudget.RebalanceBudgetId != 1 {
+		testutil.Fatalf(t, "reserveRebalanceBudget() third attempt should exhaust the budget")
+	}
+	releaseFirst()
+	releaseThird, exhaustedBudget := reserveRebalanceBudget(budgetSpends, maximumCostMsat)
+	if exhaustedBudget != nil {
+		testutil.Fatalf(t, "reserveRebalanceBudget() after release exhausted budget: %v", exhaustedBudget)
+	}
+	releaseSecond()
+	releaseThird()
+	if len(rebalanceBudgetReservations) != 0 {
+		testutil.Errorf(t, "rebalanceBudgetReservations after release = %v", rebalanceBudgetReservations)
+		return
+	}
+	testutil.Successf(t, "reserveRebalanceBudget() limits the attempts in flight to the budget")
+}
@@ -0,0 +1,352 @@
+package workflows
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+
+	"github.com/lncapital/torq/internal/cache"
+	"github.com/lncapital/torq/internal/core"
+	"github.com/lncapital/torq/proto/cln"
+)
+
+// rebalanceClnFinalCltvDelta is used as the CLTV delta of the rebalance invoice (which is also the final hop).
+const rebalanceClnFinalCltvDelta = 18
+const rebalanceClnRiskFactor = 10
+const rebalanceClnTemporaryChannelFailure = "WIRE_TEMPORARY_CHANNEL_FAILURE"
+
+// rebalanceClnMinimumWaitSendPaySeconds avoids a waitsendpay timeout of 0 (CLN would return immediately)
+const rebalanceClnMinimumWaitSendPaySeconds = 10
+
+// clnRpcErrorData is the data of the JSON-RPC error of sendpay/waitsendpay (only the fields used by the rebalancer)
+type clnRpcErrorData struct {
+	ErringIndex  *uint32 `json:"erring_index"`
+	FailCodeName string  `json:"failcodename"`
+}
+
+type clnRebalanceBackend struct {
+	client cln.NodeClient
+	nodeId int
+}
+
+func newClnRebalanceBackend(conn *grpc.ClientConn, nodeId int) *clnRebalanceBackend {
+	return &clnRebalanceBackend{
+		client: cln.NewNodeClient(conn),
+		nodeId: nodeId,
+	}
+}
+
+// GetRoutes obtains a route from the node to the remote node of the incoming channel via getroute.
+// The route is forced over the outgoing channel by excluding all other channels of the node.
+// The final hop (incoming channel) is added to the route manually.
+func (backend *clnRebalanceBackend) GetRoutes(
+	ctx context.Context,
+	request RebalanceRoutesRequest) ([]RebalanceRoute, error) {
+
+	if request.OutgoingChannel.ShortChannelId == nil || *request.OutgoingChannel.ShortChannelId == "" {
+		return nil, errors.New(fmt.Sprintf(
+			"Outgoing channel has no Short Channel Id for outgoing channelId: %v", request.OutgoingChannel.ChannelId))
+	}
+	if request.IncomingChannel.ShortChannelId == nil || *request.IncomingChannel.ShortChannelId == "" {
+		return nil, errors.New(fmt.Sprintf(
+			"Incoming channel has no Short Channel Id for incoming channelId: %v", request.IncomingChannel.ChannelId))
+	}
+	outgoingShortChannelId := *request.OutgoingChannel.ShortChannelId
+	incomingShortChannelId := *request.IncomingChannel.ShortChannelId
+
+	incomingChannelState := cache.GetChannelState(request.NodeId, request.IncomingChannel.ChannelId, true)
+	if incomingChannelState == nil {
+		return nil, errors.New(fmt.Sprintf(
+			"Channel state is unknown for incoming channelId: %v", request.IncomingChannel.ChannelId))
+	}
+	lastHopFeeMsat := uint64(incomingChannelState.RemoteFeeBaseMsat) +
+		request.AmountMsat*uint64(incomingChannelState.RemoteFeeRateMilliMsat)/1_000_000
+	lastHopCltv := float64(rebalanceClnFinalCltvDelta + incomingChannelState.RemoteTimeLockDelta)
+
+	lastHopPublicKey, err := hex.DecodeString(request.LastHopPublicKey)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Decoding public key: %v", request.LastHopPublicKey)
+	}
+	nodePublicKey := cache.GetNodeSettingsByNodeId(request.NodeId).PublicKey
+	nodePublicKeyBytes, err := hex.DecodeString(nodePublicKey)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Decoding public key: %v", nodePublicKey)
+	}
+
+	excludes := []string{incomingShortChannelId + "/0", incomingShortChannelId + "/1"}
+	for _, channelId := range cache.GetChannelStateChannelIds(request.NodeId, true) {
+		if channelId == request.OutgoingChannel.ChannelId || channelId == request.IncomingChannel.ChannelId {
+			continue
+		}
+		channelSettings := cache.GetChannelSettingByChannelId(channelId)
+		if channelSettings.ShortChannelId == nil || *channelSettings.ShortChannelId == "" {
+			continue
+		}
+		excludes = append(excludes, *channelSettings.ShortChannelId+"/0", *channelSettings.ShortChannelId+"/1")
+	}
+	for _, failedPair := range request.FailedPairs {
+		if failedPair.ShortChannelId == "" {
+			continue
+		}
+		excludes = append(excludes, failedPair.ShortChannelId+"/"+getClnDirection(failedPair))
+	}
+
+	routes, err := backend.client.GetRoute(ctx, &cln.GetrouteRequest{
+		Id:         lastHopPublicKey,
+		AmountMsat: &cln.Amount{Msat: request.AmountMsat + lastHopFeeMsat},
+		Riskfactor: rebalanceClnRiskFactor,
+		Cltv:       &lastHopCltv,
+		Exclude:    excludes,
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err,
+			"GetRoute for nodeId: %v, publicKey: %v", request.NodeId, request.LastHopPublicKey)
+	}
+	if len(routes.Route) == 0 {
+		return nil, errors.New(fmt.Sprintf("GetRoute returned no route for publicKey: %v", request.LastHopPublicKey))
+	}
+	if routes.Route[0].Channel != outgoingShortChannelId {
+		return nil, errors.New(fmt.Sprintf("GetRoute did not use outgoing channel: %v", outgoingShortChannelId))
+	}
+
+	var sendPayRoute []*cln.SendpayRoute
+	rebalanceRoute := RebalanceRoute{}
+	for _, hop := range routes.Route {
+		sendPayRoute = append(sendPayRoute, &cln.SendpayRoute{
+			Id:         hop.Id,
+			Channel:    hop.Channel,
+			Delay:      hop.Delay,
+			AmountMsat: hop.AmountMsat,
+		})
+		rebalanceRoute.Hops = append(rebalanceRoute.Hops, RebalanceHop{
+			PublicKey:           hex.EncodeToString(hop.Id),
+			ShortChannelId:      hop.Channel,
+			AmountToForwardMsat: hop.GetAmountMsat().GetMsat(),
+		})
+	}
+	sendPayRoute = append(sendPayRoute, &cln.SendpayRoute{
+		Id:         nodePublicKeyBytes,
+		Channel:    incomingShortChannelId,
+		Delay:      rebalanceClnFinalCltvDelta,
+		AmountMsat: &cln.Amount{Msat: request.AmountMsat},
+	})
+	rebalanceRoute.Hops = append(rebalanceRoute.Hops, RebalanceHop{
+		PublicKey:           nodePublicKey,
+		ShortChannelId:      incomingShortChannelId,
+		AmountToForwardMsat: request.AmountMsat,
+	})
+
+	rebalanceRoute.TotalAmountMsat = routes.Route[0].GetAmountMsat().GetMsat()
+	rebalanceRoute.TotalFeeMsat = rebalanceRoute.TotalAmountMsat - request.AmountMsat
+	rebalanceRoute.TotalTimeLock = routes.Route[0].Delay
+	rebalanceRoute.implementationRoute = sendPayRoute
+	if rebalanceRoute.TotalFeeMsat > request.FixedFeeMsat {
+		return nil, errors.New(fmt.Sprintf("GetRoute returned a route with fee %vmsat exceeding the maximum of %vmsat",
+			rebalanceRoute.TotalFeeMsat, request.FixedFeeMsat))
+	}
+	return []RebalanceRoute{rebalanceRoute}, nil
+}
+
+// getClnDirection returns the direction of the channel as used by CLN (0 when the source has the lesser public key)
+func getClnDirection(pair RebalanceHopPair) string {
+	if strings.ToLower(pair.FromPublicKey) < strings.ToLower(pair.ToPublicKey) {
+		return "0"
+	}
+	return "1"
+}
+
+func (backend *clnRebalanceBackend) CreateInvoice(ctx context.Context, amountMsat uint64) (RebalanceInvoice, error) {
+	expiry := uint64(rebalanceTimeoutSeconds)
+	cltv := uint32(rebalanceClnFinalCltvDelta)
+	invoice, err := backend.client.Invoice(ctx, &cln.InvoiceRequest{
+		AmountMsat:  &cln.AmountOrAny{Value: &cln.AmountOrAny_Amount{Amount: &cln.Amount{Msat: amountMsat}}},
+		Description: "Rebalance attempt",
+		Label:       fmt.Sprintf("torq-rebalance-%v-%v", backend.nodeId, time.Now().UnixNano()),
+		Expiry:      &expiry,
+		Cltv:        &cltv,
+	})
+	if err != nil {
+		return RebalanceInvoice{}, errors.Wrapf(err, "Invoice for %v msat", amountMsat)
+	}
+	return RebalanceInvoice{
+		PaymentHash: invoice.PaymentHash,
+		PaymentAddr: invoice.PaymentSecret,
+		AmountMsat:  amountMsat,
+	}, nil
+}
+
+func (backend *clnRebalanceBackend) SendToRoute(
+	ctx context.Context,
+	invoice RebalanceInvoice,
+	rebalanceRoute RebalanceRoute) RebalancePayment {
+
+	payment := RebalancePayment{
+		Status:          core.Inactive,
+		TotalAmountMsat: rebalanceRoute.TotalAmountMsat,
+		TotalFeeMsat:    rebalanceRoute.TotalFeeMsat,
+		TotalTimeLock:   rebalanceRoute.TotalTimeLock,
+	}
+
+	route, ok := rebalanceRoute.implementationRoute.([]*cln.SendpayRoute)
+	if !ok || len(route) == 0 {
+		payment.Error = "Route was not obtained from CLN"
+		return payment
+	}
+
+	_, err := backend.client.SendPay(ctx, &cln.SendpayRequest{
+		Route:         route,
+		PaymentHash:   invoice.PaymentHash,
+		PaymentSecret: invoice.PaymentAddr,
+		AmountMsat:    &cln.Amount{Msat: invoice.AmountMsat},
+	})
+	if err != nil {
+		log.Debug().Err(err).Msgf("Failed to call SendPay for route: %v", route)
+		payment.Error = err.Error()
+		return payment
+	}
+
+	deadline, hasDeadline := ctx.Deadline()
+	result, err := backend.client.WaitSendPay(ctx, &cln.WaitsendpayRequest{
+		PaymentHash: invoice.PaymentHash,
+		Timeout:     getClnWaitSendPayTimeout(deadline, hasDeadline),
+	})
+	if err != nil {
+		payment.Error = err.Error()
+		errorData, parseErr := parseClnRpcErrorData(err)
+		if parseErr != nil {
+			log.Debug().Err(parseErr).Msgf("Failed to parse the error data of WaitSendPay: %v", err)
+			return payment
+		}
+		if errorData.ErringIndex != nil {
+			payment.FailureSourceIndex = errorData.ErringIndex
+			if errorData.FailCodeName == rebalanceClnTemporaryChannelFailure {
+				payment.Status = core.Pending
+				payment.Error = rebalanceClnTemporaryChannelFailure
+			}
+		}
+		return payment
+	}
+	if result.Status != cln.WaitsendpayResponse_COMPLETE {
+		payment.Error = fmt.Sprintf("WaitSendPay returned status: %v", result.Status.String())
+		return payment
+	}
+	payment.Status = core.Active
+	if result.AmountSentMsat != nil {
+		payment.TotalAmountMsat = result.AmountSentMsat.Msat
+		payment.TotalFeeMsat = result.AmountSentMsat.Msat - invoice.AmountMsat
+	}
+	hopsJsonByteArray, err := json.Marshal(rebalanceRoute.Hops)
+	if err != nil {
+		log.Error().Err(err).Msgf("Marshalling the route hops for route: %v", route)
+		return payment
+	}
+	payment.Hops = string(hopsJsonByteArray)
+	return payment
+}
+
+func getClnWaitSendPayTimeout(deadline time.Time, hasDeadline bool) *uint32 {
+	if !hasDeadline {
+		return nil
+	}
+	timeoutSeconds := uint32(rebalanceClnMinimumWaitSendPaySeconds)
+	if time.Until(deadline).Seconds() > rebalanceClnMinimumWaitSendPaySeconds {
+		timeoutSeconds = uint32(time.Until(deadline).Seconds())
+	}
+	return &timeoutSeconds
+}
+
+// parseClnRpcErrorData obtains the data of the JSON-RPC error from the gRPC error.
+// cln-grpc only forwards the JSON-RPC error in the status message (i.e. Error calling method WaitSendPay:
+// RpcError { code: Some(204), message: "...", data: Some(Object {"erring_index": Number(1), ...}) })
+// so the data object is converted from the debug notation of serde_json to JSON.
+func parseClnRpcErrorData(err error) (clnRpcErrorData, error) {
+	message := err.Error()
+	if grpcStatus, ok := status.FromError(err); ok {
+		message = grpcStatus.Message()
+	}
+	dataIndex := strings.Index(message, "data: Some(")
+	if dataIndex == -1 {
+		return clnRpcErrorData{}, errors.New("The error has no data")
+	}
+	objectIndex := strings.Index(message[dataIndex:], "{")
+	if objectIndex == -1 {
+		return clnRpcErrorData{}, errors.New("The data of the error is not an object")
+	}
+	dataJson, err := convertClnDebugValueToJson(message[dataIndex+objectIndex:])
+	if err != nil {
+		return clnRpcErrorData{}, errors.Wrap(err, "Converting the data of the error")
+	}
+	var errorData clnRpcErrorData
+	err = json.Unmarshal([]byte(dataJson), &errorData)
+	if err != nil {
+		return clnRpcErrorData{}, errors.Wrap(err, "Unmarshalling the data of the error")
+	}
+	return errorData, nil
+}
+
+// convertClnDebugValueToJson converts the first value of the serde_json debug notation to JSON
+// i.e. Object {"erring_index": Number(1), "failcodename": String("WIRE_TEMPORARY_CHANNEL_FAILURE")}
+// JSON (without the debug notation) is returned as is.
+func convertClnDebugValueToJson(value string) (string, error) {
+	var result strings.Builder
+	depth := 0
+	for i := 0; i < len(value); i++ {
+		character := value[i]
+		switch {
+		case character == '"':
+			end := i + 1
+			for end < len(value) && value[end] != '"' {
+				if value[end] == '\\' {
+					end++
+				}
+				end++
+			}
+			if end >= len(value) {
+				return "", errors.New("Unterminated string")
+			}
+			result.WriteString(value[i : end+1])
+			i = end
+		case character == '{' || character == '[':
+			depth++
+			result.WriteByte(character)
+		case character == '}' || character == ']':
+			depth--
+			result.WriteByte(character)
+			if depth == 0 {
+				return result.String(), nil
+			}
+		case character == ':' || character == ',':
+			result.WriteByte(character)
+		case character == '-' || (character >= '0' && character <= '9'):
+			end := i + 1
+			for end < len(value) && strings.IndexByte("0123456789.eE+-", value[end]) != -1 {
+				end++
+			}
+			result.WriteString(value[i:end])
+			i = end - 1
+		case (character >= 'a' && character <= 'z') || (character >= 'A' && character <= 'Z'):
+			end := i + 1
+			for end < len(value) && ((value[end] >= 'a' && value[end] <= 'z') || (value[end] >= 'A' && value[end] <= 'Z')) {
+				end++
+			}
+			// The variants of serde_json::Value are dropped (Object, Array, Number, String and Bool)
+			switch word := value[i:end]; word {
+			case "true", "false", "null":
+				result.WriteString(word)
+			case "Null":
+				result.WriteString("null")
+			}
+			i = end - 1
+		}
+		// Whitespace and the parentheses of the variants are dropped
+	}
+	return "", errors.New("Unterminated value")
+}
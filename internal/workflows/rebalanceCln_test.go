@@ -0,0 +1,199 @@
+package workflows
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/lncapital/torq/internal/core"
+	"github.com/lncapital/torq/proto/cln"
+	"github.com/lncapital/torq/testutil"
+)
+
+const clnTemporaryChannelFailureError = `Error calling method WaitSendPay: RpcError { code: Some(204), ` +
+	`message: "failed: WIRE_TEMPORARY_CHANNEL_FAILURE (reply from remote)", data: Some(Object {` +
+	`"erring_channel": String("103x1x0"), "erring_direction": Number(1), "erring_index": Number(2), ` +
+	`"erring_node": String("02ab"), "failcode": Number(4103), ` +
+	`"failcodename": String("WIRE_TEMPORARY_CHANNEL_FAILURE"), "id": Number(3), "parts": Array [], ` +
+	`"status": String("failed"), "completed_at": Null, "partid": Number(0), "groupid": Number(1)}) }`
+
+// fakeClnNodeClient only implements the calls of the rebalancer (the other calls panic)
+type fakeClnNodeClient struct {
+	cln.NodeClient
+	waitSendPayRequest  *cln.WaitsendpayRequest
+	waitSendPayResponse *cln.WaitsendpayResponse
+	waitSendPayErr      error
+}
+
+func (client *fakeClnNodeClient) SendPay(context.Context, *cln.SendpayRequest,
+	...grpc.CallOption) (*cln.SendpayResponse, error) {
+
+	return &cln.SendpayResponse{}, nil
+}
+
+func (client *fakeClnNodeClient) WaitSendPay(_ context.Context, in *cln.WaitsendpayRequest,
+	_ ...grpc.CallOption) (*cln.WaitsendpayResponse, error) {
+
+	client.waitSendPayRequest = in
+	return client.waitSendPayResponse, client.waitSendPayErr
+}
+
+func TestParseClnRpcErrorData(t *testing.T) {
+	testCases := []struct {
+		name             string
+		err              error
+		wantErr          bool
+		wantErringIndex  *uint32
+		wantFailCodeName string
+	}{
+		{
+			name:             "temporary channel failure",
+			err:              status.Error(codes.Unknown, clnTemporaryChannelFailureError),
+			wantErringIndex:  uint32Pointer(2),
+			wantFailCodeName: "WIRE_TEMPORARY_CHANNEL_FAILURE",
+		},
+		{
+			name: "JSON data",
+			err: status.Error(codes.Unknown, `Error calling method WaitSendPay: RpcError { code: Some(204), `+
+				`message: "failed", data: Some({"erring_index":1,"failcodename":"WIRE_UNKNOWN_NEXT_PEER"}) }`),
+			wantErringIndex:  uint32Pointer(1),
+			wantFailCodeName: "WIRE_UNKNOWN_NEXT_PEER",
+		},
+		{
+			name: "erring_index only mentioned in the message",
+			err: status.Error(codes.Unknown, `Error calling method WaitSendPay: RpcError { code: Some(200), `+
+				`message: "erring_index: 5, status: timed out", data: Some(Object {"status": String("pending")}) }`),
+		},
+		{
+			name: "escaped quotes",
+			err: status.Error(codes.Unknown, `RpcError { data: Some(Object {"message": String("a \"quoted\" }"), `+
+				`"erring_index": Number(0)}) }`),
+			wantErringIndex: uint32Pointer(0),
+		},
+		{
+			name: "no data",
+			err: status.Error(codes.Unknown, `Error calling method WaitSendPay: RpcError { code: Some(208), `+
+				`message: "Never attempted payment", data: None }`),
+			wantErr: true,
+		},
+		{
+			name:    "unterminated data",
+			err:     status.Error(codes.Unknown, `RpcError { data: Some(Object {"erring_index": Number(2)`),
+			wantErr: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			errorData, err := parseClnRpcErrorData(tc.err)
+			if (err != nil) != tc.wantErr {
+				testutil.Errorf(t, "parseClnRpcErrorData() error = %v, wantErr %v", err, tc.wantErr)
+				return
+			}
+			if !equalUint32Pointers(errorData.ErringIndex, tc.wantErringIndex) ||
+				errorData.FailCodeName != tc.wantFailCodeName {
+				testutil.Errorf(t, "parseClnRpcErrorData() = %v, %v, want %v, %v",
+					errorData.ErringIndex, errorData.FailCodeName, tc.wantErringIndex, tc.wantFailCodeName)
+				return
+			}
+			testutil.Successf(t, "parseClnRpcErrorData() = %v, %v", errorData.ErringIndex, errorData.FailCodeName)
+		})
+	}
+}
+
+func TestGetClnWaitSendPayTimeout(t *testing.T) {
+	testCases := []struct {
+		name        string
+		deadline    time.Time
+		hasDeadline bool
+		want        *uint32
+	}{
+		{name: "no deadline", want: nil},
+		{name: "expired deadline", deadline: time.Now().Add(-time.Minute), hasDeadline: true,
+			want: uint32Pointer(rebalanceClnMinimumWaitSendPaySeconds)},
+		{name: "deadline within the minimum", deadline: time.Now().Add(time.Second), hasDeadline: true,
+			want: uint32Pointer(rebalanceClnMinimumWaitSendPaySeconds)},
+		{name: "deadline after the minimum", deadline: time.Now().Add(time.Minute + time.Second), hasDeadline: true,
+			want: uint32Pointer(60)},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := getClnWaitSendPayTimeout(tc.deadline, tc.hasDeadline)
+			if !equalUint32Pointers(got, tc.want) {
+				testutil.Errorf(t, "getClnWaitSendPayTimeout() = %v, want %v", got, tc.want)
+				return
+			}
+			testutil.Successf(t, "getClnWaitSendPayTimeout() = %v", got)
+		})
+	}
+}
+
+func TestClnRebalanceBackendSendToRoute(t *testing.T) {
+	route := RebalanceRoute{
+		Hops:                []RebalanceHop{{PublicKey: "02ab", ShortChannelId: "103x1x0", AmountToForwardMsat: 1_000}},
+		TotalAmountMsat:     1_010,
+		TotalFeeMsat:        10,
+		implementationRoute: []*cln.SendpayRoute{{Channel: "103x1x0"}},
+	}
+	invoice := RebalanceInvoice{PaymentHash: []byte{1}, AmountMsat: 1_000}
+	testCases := []struct {
+		name                   string
+		response               *cln.WaitsendpayResponse
+		err                    error
+		wantStatus             core.Status
+		wantFailureSourceIndex *uint32
+	}{
+		{
+			name:       "success",
+			response:   &cln.WaitsendpayResponse{Status: cln.WaitsendpayResponse_COMPLETE},
+			wantStatus: core.Active,
+		},
+		{
+			name:                   "temporary channel failure",
+			err:                    status.Error(codes.Unknown, clnTemporaryChannelFailureError),
+			wantStatus:             core.Pending,
+			wantFailureSourceIndex: uint32Pointer(2),
+		},
+		{
+			name:       "error without data",
+			err:        status.Error(codes.Unknown, "Error calling method WaitSendPay: Timed out"),
+			wantStatus: core.Inactive,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			client := &fakeClnNodeClient{waitSendPayResponse: tc.response, waitSendPayErr: tc.err}
+			backend := &clnRebalanceBackend{client: client, nodeId: 1}
+			ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+			defer cancel()
+			payment := backend.SendToRoute(ctx, invoice, route)
+			if payment.Status != tc.wantStatus ||
+				!equalUint32Pointers(payment.FailureSourceIndex, tc.wantFailureSourceIndex) {
+				testutil.Errorf(t, "SendToRoute() = %v, %v, want %v, %v", payment.Status,
+					payment.FailureSourceIndex, tc.wantStatus, tc.wantFailureSourceIndex)
+				return
+			}
+			timeout := client.waitSendPayRequest.Timeout
+			if timeout == nil || *timeout < rebalanceClnMinimumWaitSendPaySeconds {
+				testutil.Errorf(t, "WaitSendPay timeout = %v, want at least %v", timeout,
+					rebalanceClnMinimumWaitSendPaySeconds)
+				return
+			}
+			testutil.Successf(t, "SendToRoute() = %v, %v", payment.Status, payment.FailureSourceIndex)
+		})
+	}
+}
+
+func uint32Pointer(value uint32) *uint32 {
+	return &value
+}
+
+func equalUint32Pointers(a *uint32, b *uint32) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
@@ -0,0 +1,180 @@
+package workflows
+
+import (
+	"math"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/rs/zerolog/log"
+
+	"github.com/lncapital/torq/internal/cache"
+	"github.com/lncapital/torq/internal/rebalances"
+)
+
+// The failure of a hop pair is forgotten gradually: the penalty halves every half-life.
+const rebalanceHopMemoryHalfLifeMinutes = 60
+
+// Hop memories that are older than the retention are no longer used to obtain routes.
+const rebalanceHopMemoryRetentionHours = 24
+
+// Hop pairs (and routes) with a penalty from the threshold are excluded when obtaining routes.
+const rebalanceHopMemoryPruneThreshold = 0.5
+
+type RebalanceHopMemoryPenalty struct {
+	rebalances.RebalanceHopMemory
+	// Penalty is between 0 (no recent failure) and 1 (just failed for this amount)
+	Penalty float64 `json:"penalty"`
+	Pruned  bool    `json:"pruned"`
+}
+
+// getRebalanceHopPenalty calculates how likely the pair will fail again for the amount.
+// A failure for a greater amount only partially penalises smaller amounts.
+// A success for the amount after the latest failure removes the penalty.
+func getRebalanceHopPenalty(hopMemory rebalances.RebalanceHopMemory, amountMsat uint64, now time.Time) float64 {
+	if hopMemory.FailTime == nil || hopMemory.FailAmountMsat == nil || *hopMemory.FailAmountMsat == 0 {
+		return 0
+	}
+	if hopMemory.SuccessTime != nil && hopMemory.SuccessAmountMsat != nil &&
+		hopMemory.SuccessTime.After(*hopMemory.FailTime) && *hopMemory.SuccessAmountMsat >= amountMsat {
+		return 0
+	}
+	age := now.Sub(*hopMemory.FailTime)
+	if age < 0 {
+		age = 0
+	}
+	penalty := math.Pow(0.5, age.Minutes()/rebalanceHopMemoryHalfLifeMinutes)
+	if amountMsat < *hopMemory.FailAmountMsat {
+		penalty = penalty * float64(amountMsat) / float64(*hopMemory.FailAmountMsat)
+	}
+	return penalty
+}
+
+func GetRebalanceHopMemoryPenalties(db *sqlx.DB,
+	amountMsat uint64, publicKey string) ([]RebalanceHopMemoryPenalty, error) {
+
+	now := time.Now()
+	hopMemories, err := rebalances.GetRebalanceHopMemories(db,
+		now.Add(-rebalanceHopMemoryRetentionHours*time.Hour), publicKey)
+	if err != nil {
+		return nil, err
+	}
+	var penalties []RebalanceHopMemoryPenalty
+	for _, hopMemory := range hopMemories {
+		penalty := getRebalanceHopPenalty(hopMemory, amountMsat, now)
+		penalties = append(penalties, RebalanceHopMemoryPenalty{
+			RebalanceHopMemory: hopMemory,
+			Penalty:            penalty,
+			Pruned:             penalty >= rebalanceHopMemoryPruneThreshold,
+		})
+	}
+	return penalties, nil
+}
+
+// rebalanceHopMemory is the shared hop memory as it was when the rebalancer (re)started
+type rebalanceHopMemory struct {
+	// hopMemories map[hopSourcePublicKey_hopDestinationPublicKey]RebalanceHopMemory
+	hopMemories map[string]rebalances.RebalanceHopMemory
+}
+
+// getRebalanceHopMemory loads the shared hop memory once for every run of a rebalancer
+func getRebalanceHopMemory(db *sqlx.DB) *rebalanceHopMemory {
+	hopMemory := &rebalanceHopMemory{hopMemories: make(map[string]rebalances.RebalanceHopMemory)}
+	hopMemories, err := rebalances.GetRebalanceHopMemories(db,
+		time.Now().Add(-rebalanceHopMemoryRetentionHours*time.Hour), "")
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to obtain the rebalance hop memory")
+		return hopMemory
+	}
+	for _, memory := range hopMemories {
+		hopMemory.hopMemories[getRebalanceHopKey(memory.FromPublicKey, memory.ToPublicKey)] = memory
+	}
+	return hopMemory
+}
+
+func getRebalanceHopKey(hopSourcePublicKey string, hopDestinationPublicKey string) string {
+	return hopSourcePublicKey + "_" + hopDestinationPublicKey
+}
+
+// getPenalty returns the penalty of the pair for the amount that is forwarded over the pair
+// (i.e. the AmountToForwardMsat of the hop), which is also the amount that is stored in the hop memory.
+func (hopMemory *rebalanceHopMemory) getPenalty(
+	hopSourcePublicKey string,
+	hopDestinationPublicKey string,
+	amountMsat uint64) float64 {
+
+	if hopMemory == nil {
+		return 0
+	}
+	memory, exists := hopMemory.hopMemories[getRebalanceHopKey(hopSourcePublicKey, hopDestinationPublicKey)]
+	if !exists {
+		return 0
+	}
+	return getRebalanceHopPenalty(memory, amountMsat, time.Now())
+}
+
+// getFailedPairs returns the pairs that are pruned for the amount.
+// The amount of every hop of a route includes the fees of the next hops, so it's never below the rebalance amount.
+// A penalty never decreases with a greater amount so pairs pruned for the rebalance amount are pruned for any hop.
+func (hopMemory *rebalanceHopMemory) getFailedPairs(amountMsat uint64) []RebalanceHopPair {
+	if hopMemory == nil {
+		return nil
+	}
+	var failedPairs []RebalanceHopPair
+	for _, memory := range hopMemory.hopMemories {
+		if getRebalanceHopPenalty(memory, amountMsat, time.Now()) < rebalanceHopMemoryPruneThreshold {
+			continue
+		}
+		failedPair := RebalanceHopPair{
+			FromPublicKey: memory.FromPublicKey,
+			ToPublicKey:   memory.ToPublicKey,
+		}
+		if memory.ShortChannelId != nil {
+			failedPair.ShortChannelId = *memory.ShortChannelId
+		}
+		failedPairs = append(failedPairs, failedPair)
+	}
+	return failedPairs
+}
+
+// getRoutePenalty combines the penalties of the hops (with the amount of each hop) into the penalty of the route:
+// the chance the route fails because of a remembered failure. It also returns the index of the worst hop.
+func (hopMemory *rebalanceHopMemory) getRoutePenalty(sourcePublicKey string, route RebalanceRoute) (float64, int) {
+	successChance := 1.0
+	worstHopIndex := 0
+	worstHopPenalty := 0.0
+	previousHopPublicKey := sourcePublicKey
+	for i, hop := range route.Hops {
+		penalty := hopMemory.getPenalty(previousHopPublicKey, hop.PublicKey, hop.AmountToForwardMsat)
+		if penalty > worstHopPenalty {
+			worstHopIndex = i
+			worstHopPenalty = penalty
+		}
+		successChance = successChance * (1 - penalty)
+		previousHopPublicKey = hop.PublicKey
+	}
+	return 1 - successChance, worstHopIndex
+}
+
+// storeRebalanceHopMemory remembers the hops of the route up to the failed hop as successful
+// and the failed hop (when failedHopIndex is not nil) as failed.
+func storeRebalanceHopMemory(db *sqlx.DB, nodeId int, route RebalanceRoute, failedHopIndex *int) {
+	previousHopPublicKey := cache.GetNodeSettingsByNodeId(nodeId).PublicKey
+	for i, hop := range route.Hops {
+		if failedHopIndex != nil && i == *failedHopIndex {
+			err := rebalances.AddRebalanceHopFailure(db,
+				previousHopPublicKey, hop.PublicKey, hop.ShortChannelId, hop.AmountToForwardMsat)
+			if err != nil {
+				log.Error().Err(err).Msgf("Failed to store the rebalance hop failure %v -> %v",
+					previousHopPublicKey, hop.PublicKey)
+			}
+			return
+		}
+		err := rebalances.AddRebalanceHopSuccess(db,
+			previousHopPublicKey, hop.PublicKey, hop.ShortChannelId, hop.AmountToForwardMsat)
+		if err != nil {
+			log.Error().Err(err).Msgf("Failed to store the rebalance hop success %v -> %v",
+				previousHopPublicKey, hop.PublicKey)
+		}
+		previousHopPublicKey = hop.PublicKey
+	}
+}
@@ -0,0 +1,215 @@
+package workflows
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/lncapital/torq/internal/rebalances"
+	"github.com/lncapital/torq/testutil"
+)
+
+func TestGetRebalanceHopPenalty(t *testing.T) {
+	now := time.Now()
+	failAmountMsat := uint64(1_000_000_000)
+	successAmountMsat := uint64(2_000_000_000)
+	justFailed := now
+	failedOneHalfLifeAgo := now.Add(-rebalanceHopMemoryHalfLifeMinutes * time.Minute)
+	succeededAfterFailure := now.Add(time.Second)
+
+	testCases := []struct {
+		name       string
+		hopMemory  rebalances.RebalanceHopMemory
+		amountMsat uint64
+		want       float64
+	}{
+		{
+			name:       "no failure",
+			hopMemory:  rebalances.RebalanceHopMemory{},
+			amountMsat: failAmountMsat,
+			want:       0,
+		},
+		{
+			name:       "just failed for the amount",
+			hopMemory:  rebalances.RebalanceHopMemory{FailAmountMsat: &failAmountMsat, FailTime: &justFailed},
+			amountMsat: failAmountMsat,
+			want:       1,
+		},
+		{
+			name:       "just failed for a greater amount",
+			hopMemory:  rebalances.RebalanceHopMemory{FailAmountMsat: &failAmountMsat, FailTime: &justFailed},
+			amountMsat: failAmountMsat / 4,
+			want:       0.25,
+		},
+		{
+			name: "failed one half-life ago",
+			hopMemory: rebalances.RebalanceHopMemory{
+				FailAmountMsat: &failAmountMsat, FailTime: &failedOneHalfLifeAgo},
+			amountMsat: failAmountMsat,
+			want:       0.5,
+		},
+		{
+			name: "succeeded after the failure",
+			hopMemory: rebalances.RebalanceHopMemory{FailAmountMsat: &failAmountMsat, FailTime: &justFailed,
+				SuccessAmountMsat: &successAmountMsat, SuccessTime: &succeededAfterFailure},
+			amountMsat: failAmountMsat,
+			want:       0,
+		},
+		{
+			name: "succeeded after the failure for a smaller amount",
+			hopMemory: rebalances.RebalanceHopMemory{FailAmountMsat: &failAmountMsat, FailTime: &justFailed,
+				SuccessAmountMsat: &failAmountMsat, SuccessTime: &succeededAfterFailure},
+			amountMsat: successAmountMsat,
+			want:       1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := getRebalanceHopPenalty(tc.hopMemory, tc.amountMsat, now)
+			if math.Abs(got-tc.want) > 0.0001 {
+				testutil.Errorf(t, "getRebalanceHopPenalty() = %v, want %v", got, tc.want)
+			} else {
+				testutil.Successf(t, "getRebalanceHopPenalty() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestRebalanceHopMemoryGetFailedPairs(t *testing.T) {
+	failAmountMsat := uint64(1_000_000_000)
+	justFailed := time.Now()
+	shortChannelId := "800000x1x0"
+	hopMemory := &rebalanceHopMemory{hopMemories: map[string]rebalances.RebalanceHopMemory{
+		getRebalanceHopKey("a", "b"): {FromPublicKey: "a", ToPublicKey: "b", ShortChannelId: &shortChannelId,
+			FailAmountMsat: &failAmountMsat, FailTime: &justFailed},
+	}}
+
+	testCases := []struct {
+		name       string
+		amountMsat uint64
+		want       int
+	}{
+		{"failed amount", failAmountMsat, 1},
+		{"greater amount", 2 * failAmountMsat, 1},
+		{"much smaller amount", failAmountMsat / 4, 0},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			failedPairs := hopMemory.getFailedPairs(tc.amountMsat)
+			if len(failedPairs) != tc.want {
+				testutil.Errorf(t, "getFailedPairs() = %v, want %v pairs", failedPairs, tc.want)
+				return
+			}
+			if tc.want == 1 && failedPairs[0].ShortChannelId != shortChannelId {
+				testutil.Errorf(t, "getFailedPairs() = %v, want short channel id %v", failedPairs, shortChannelId)
+				return
+			}
+			testutil.Successf(t, "getFailedPairs() = %v", failedPairs)
+		})
+	}
+}
+
+func TestRebalanceRunnerValidateRoute(t *testing.T) {
+	failAmountMsat := uint64(1_000_000_000)
+	halfAmountMsat := failAmountMsat / 2
+	justFailed := time.Now()
+	// a -> b failed for the full amount and b -> c failed for half the amount
+	hopMemory := &rebalanceHopMemory{hopMemories: map[string]rebalances.RebalanceHopMemory{
+		getRebalanceHopKey("a", "b"): {FromPublicKey: "a", ToPublicKey: "b",
+			FailAmountMsat: &failAmountMsat, FailTime: &justFailed},
+		getRebalanceHopKey("b", "c"): {FromPublicKey: "b", ToPublicKey: "c",
+			FailAmountMsat: &halfAmountMsat, FailTime: &justFailed},
+	}}
+	getRoute := func(amountMsat uint64, publicKeys ...string) RebalanceRoute {
+		route := RebalanceRoute{}
+		for _, publicKey := range publicKeys {
+			route.Hops = append(route.Hops, RebalanceHop{PublicKey: publicKey, AmountToForwardMsat: amountMsat})
+		}
+		return route
+	}
+
+	testCases := []struct {
+		name           string
+		failedHops     map[string]uint64
+		route          RebalanceRoute
+		want           bool
+		wantFailedPair *RebalanceHopPair
+	}{
+		{
+			name:  "no failures",
+			route: getRoute(failAmountMsat, "d", "e", "s"),
+			want:  true,
+		},
+		{
+			name:  "partially penalised",
+			route: getRoute(failAmountMsat/4, "b", "d", "s"),
+			want:  true,
+		},
+		{
+			name:           "penalties of the hops are combined",
+			route:          getRoute(failAmountMsat/4, "b", "c", "s"),
+			wantFailedPair: &RebalanceHopPair{FromPublicKey: "b", ToPublicKey: "c"},
+		},
+		{
+			name:           "failed in the hop memory for the hop amount",
+			route:          getRoute(failAmountMsat, "d", "b", "c", "s"),
+			wantFailedPair: &RebalanceHopPair{FromPublicKey: "b", ToPublicKey: "c"},
+		},
+		{
+			name:           "failed for the runner for a smaller amount",
+			failedHops:     map[string]uint64{getRebalanceHopKey("d", "e"): failAmountMsat / 4},
+			route:          getRoute(failAmountMsat, "d", "e", "s"),
+			wantFailedPair: &RebalanceHopPair{FromPublicKey: "d", ToPublicKey: "e"},
+		},
+		{
+			name:       "failed for the runner for a greater amount",
+			failedHops: map[string]uint64{getRebalanceHopKey("d", "e"): failAmountMsat},
+			route:      getRoute(failAmountMsat/4, "d", "e", "s"),
+			want:       true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			runner := RebalanceRunner{FailedHops: tc.failedHops}
+			if runner.FailedHops == nil {
+				runner.FailedHops = make(map[string]uint64)
+			}
+			got := runner.validateRoute("a", tc.route, hopMemory)
+			if got != tc.want {
+				testutil.Errorf(t, "validateRoute() = %v, want %v", got, tc.want)
+				return
+			}
+			if tc.wantFailedPair == nil && len(runner.FailedPairs) != 0 {
+				testutil.Errorf(t, "validateRoute() failed pairs = %v, want none", runner.FailedPairs)
+				return
+			}
+			if tc.wantFailedPair != nil && (len(runner.FailedPairs) != 1 ||
+				runner.FailedPairs[0].FromPublicKey != tc.wantFailedPair.FromPublicKey ||
+				runner.FailedPairs[0].ToPublicKey != tc.wantFailedPair.ToPublicKey) {
+				testutil.Errorf(t, "validateRoute() failed pairs = %v, want %v", runner.FailedPairs, *tc.wantFailedPair)
+				return
+			}
+			testutil.Successf(t, "validateRoute() = %v", got)
+		})
+	}
+}
+
+func TestSortRoutesByPenalty(t *testing.T) {
+	failAmountMsat := uint64(1_000_000_000)
+	failedOneHalfLifeAgo := time.Now().Add(-rebalanceHopMemoryHalfLifeMinutes * time.Minute)
+	hopMemory := &rebalanceHopMemory{hopMemories: map[string]rebalances.RebalanceHopMemory{
+		getRebalanceHopKey("a", "b"): {FromPublicKey: "a", ToPublicKey: "b",
+			FailAmountMsat: &failAmountMsat, FailTime: &failedOneHalfLifeAgo},
+	}}
+	routes := []RebalanceRoute{
+		{Hops: []RebalanceHop{{PublicKey: "b", AmountToForwardMsat: failAmountMsat / 2}}, TotalFeeMsat: 1},
+		{Hops: []RebalanceHop{{PublicKey: "c", AmountToForwardMsat: failAmountMsat / 2}}, TotalFeeMsat: 2},
+	}
+	sortRoutesByPenalty("a", routes, hopMemory)
+	if routes[0].Hops[0].PublicKey != "c" || routes[1].Hops[0].PublicKey != "b" {
+		testutil.Errorf(t, "sortRoutesByPenalty() = %v", routes)
+		return
+	}
+	testutil.Successf(t, "sortRoutesByPenalty() = %v", routes)
+}
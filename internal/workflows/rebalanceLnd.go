@@ -0,0 +1,163 @@
+package workflows
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	"github.com/cockroachdb/errors"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc"
+
+	"github.com/lncapital/torq/internal/cache"
+	"github.com/lncapital/torq/internal/core"
+	"github.com/lncapital/torq/proto/lnrpc"
+	"github.com/lncapital/torq/proto/lnrpc/routerrpc"
+)
+
+type lndRebalanceBackend struct {
+	client lnrpc.LightningClient
+	router routerrpc.RouterClient
+}
+
+func newLndRebalanceBackend(conn *grpc.ClientConn) *lndRebalanceBackend {
+	return &lndRebalanceBackend{
+		client: lnrpc.NewLightningClient(conn),
+		router: routerrpc.NewRouterClient(conn),
+	}
+}
+
+func (backend *lndRebalanceBackend) GetRoutes(
+	ctx context.Context,
+	request RebalanceRoutesRequest) ([]RebalanceRoute, error) {
+
+	remoteNodePublicKey, err := hex.DecodeString(request.LastHopPublicKey)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Decoding public key: %v", request.LastHopPublicKey)
+	}
+	if request.OutgoingChannel.LndShortChannelId == nil {
+		return nil, errors.New(fmt.Sprintf(
+			"Outgoing channel has no LND Short Channel Id for outgoing channelId: %v",
+			request.OutgoingChannel.ChannelId))
+	}
+
+	var ignoredPairs []*lnrpc.NodePair
+	for _, failedPair := range request.FailedPairs {
+		from, err := hex.DecodeString(failedPair.FromPublicKey)
+		if err != nil {
+			return nil, errors.Wrapf(err, "Decoding public key: %v", failedPair.FromPublicKey)
+		}
+		to, err := hex.DecodeString(failedPair.ToPublicKey)
+		if err != nil {
+			return nil, errors.Wrapf(err, "Decoding public key: %v", failedPair.ToPublicKey)
+		}
+		ignoredPairs = append(ignoredPairs, &lnrpc.NodePair{From: from, To: to})
+	}
+
+	routes, err := backend.client.QueryRoutes(ctx, &lnrpc.QueryRoutesRequest{
+		PubKey:            cache.GetNodeSettingsByNodeId(request.NodeId).PublicKey,
+		OutgoingChanId:    *request.OutgoingChannel.LndShortChannelId,
+		LastHopPubkey:     remoteNodePublicKey,
+		AmtMsat:           int64(request.AmountMsat),
+		UseMissionControl: true,
+		FeeLimit:          &lnrpc.FeeLimit{Limit: &lnrpc.FeeLimit_FixedMsat{FixedMsat: int64(request.FixedFeeMsat)}},
+		IgnoredPairs:      ignoredPairs,
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err,
+			"QueryRoutes for nodeId: %v, publicKey: %v", request.NodeId, request.LastHopPublicKey)
+	}
+
+	var result []RebalanceRoute
+	for _, route := range routes.Routes {
+		if route == nil {
+			continue
+		}
+		rebalanceRoute := RebalanceRoute{
+			TotalAmountMsat:     uint64(route.TotalAmtMsat),
+			TotalFeeMsat:        uint64(route.TotalFeesMsat),
+			TotalTimeLock:       route.TotalTimeLock,
+			implementationRoute: route,
+		}
+		for _, hop := range route.Hops {
+			rebalanceRoute.Hops = append(rebalanceRoute.Hops, RebalanceHop{
+				PublicKey:           hop.PubKey,
+				ShortChannelId:      core.ConvertLNDShortChannelID(hop.ChanId),
+				AmountToForwardMsat: uint64(hop.AmtToForwardMsat),
+			})
+		}
+		result = append(result, rebalanceRoute)
+	}
+	return result, nil
+}
+
+func (backend *lndRebalanceBackend) CreateInvoice(ctx context.Context, amountMsat uint64) (RebalanceInvoice, error) {
+	invoice, err := backend.client.AddInvoice(ctx, &lnrpc.Invoice{ValueMsat: int64(amountMsat),
+		Memo:   "Rebalance attempt",
+		Expiry: int64(rebalanceTimeoutSeconds)})
+	if err != nil {
+		return RebalanceInvoice{}, errors.Wrapf(err, "AddInvoice for %v msat", amountMsat)
+	}
+	return RebalanceInvoice{
+		PaymentHash: invoice.RHash,
+		PaymentAddr: invoice.PaymentAddr,
+		AmountMsat:  amountMsat,
+	}, nil
+}
+
+func (backend *lndRebalanceBackend) SendToRoute(
+	ctx context.Context,
+	invoice RebalanceInvoice,
+	rebalanceRoute RebalanceRoute) RebalancePayment {
+
+	payment := RebalancePayment{
+		Status: core.Inactive,
+	}
+
+	route, ok := rebalanceRoute.implementationRoute.(*lnrpc.Route)
+	if !ok || route == nil || len(route.Hops) == 0 {
+		payment.Error = "Route was not obtained from LND"
+		return payment
+	}
+	lastHop := route.Hops[len(route.Hops)-1]
+	lastHop.MppRecord = &lnrpc.MPPRecord{
+		PaymentAddr:  invoice.PaymentAddr,
+		TotalAmtMsat: int64(invoice.AmountMsat),
+	}
+
+	result, err := backend.router.SendToRouteV2(ctx,
+		&routerrpc.SendToRouteRequest{
+			PaymentHash: invoice.PaymentHash,
+			Route:       route,
+		})
+	if result != nil && result.Route != nil {
+		payment.TotalFeeMsat = uint64(result.Route.TotalFeesMsat)
+		payment.TotalTimeLock = result.Route.TotalTimeLock
+		payment.TotalAmountMsat = uint64(result.Route.TotalAmtMsat)
+	}
+	if err != nil {
+		log.Debug().Err(err).Msgf("Failed to call SendToRouteV2 for route: %v", route)
+		payment.Error = err.Error()
+		return payment
+	}
+	if result.Status == lnrpc.HTLCAttempt_FAILED {
+		failureSourceIndex := result.Failure.FailureSourceIndex
+		payment.FailureSourceIndex = &failureSourceIndex
+		if result.Failure.Code == lnrpc.Failure_TEMPORARY_CHANNEL_FAILURE {
+			payment.Status = core.Pending
+		}
+		payment.Error = result.Failure.Code.String()
+		return payment
+	}
+	payment.Status = core.Active
+	if result.Route != nil {
+		hopsJsonByteArray, err := json.Marshal(result.Route.Hops)
+		if err != nil {
+			log.Error().Err(err).Msgf("Marshalling the route hops for route: %v", route)
+			return payment
+		}
+		payment.Hops = string(hopsJsonByteArray)
+	}
+	return payment
+}
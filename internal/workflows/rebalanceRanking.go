@@ -0,0 +1,79 @@
+package workflows
+
+import (
+	"math/rand"
+	"sort"
+	"time"
+
+	"github.com/lncapital/torq/internal/rebalances"
+)
+
+// rebalanceChannelStatisticsDays is the period of historic rebalance attempts used to rank the candidates.
+const rebalanceChannelStatisticsDays = 30
+
+const (
+	rebalanceRankingSurplusWeight     = 0.35
+	rebalanceRankingSuccessRateWeight = 0.35
+	rebalanceRankingCostWeight        = 0.15
+	rebalanceRankingFeeRateWeight     = 0.15
+	// rebalanceRankingFeeRateCeiling is the fee rate (ppm) from which the fee rate score no longer changes.
+	rebalanceRankingFeeRateCeiling = 5_000
+)
+
+type rebalanceCandidate struct {
+	channelId int
+	// liquiditySurplusPerMille is the part of the channel balance that can be moved in the rebalance direction
+	liquiditySurplusPerMille int
+	feeRateMilliMsat         int64
+	statistics               rebalances.RebalanceChannelStatistics
+	score                    float64
+}
+
+// rankRebalanceCandidates sorts the candidates with the most promising counterpart first.
+// When incomingFocus is true the candidates are outgoing channels: a cheap (low fee rate) channel is preferred.
+// Otherwise the candidates are incoming channels: an expensive (high fee rate) channel is preferred.
+// Channels with an equal score are kept in random order.
+func rankRebalanceCandidates(candidates []rebalanceCandidate, maximumCostPpm uint64, incomingFocus bool) {
+	for i := range candidates {
+		candidates[i].score = getRebalanceCandidateScore(candidates[i], maximumCostPpm, incomingFocus)
+	}
+	rand.Seed(time.Now().UnixNano())
+	rand.Shuffle(len(candidates), func(i, j int) { candidates[i], candidates[j] = candidates[j], candidates[i] })
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].score > candidates[j].score
+	})
+}
+
+func getRebalanceCandidateScore(candidate rebalanceCandidate, maximumCostPpm uint64, incomingFocus bool) float64 {
+	surplus := clampUnit(float64(candidate.liquiditySurplusPerMille) / 1_000)
+
+	// Laplace smoothing: a channel without history gets 0.5 and a single failure does not rule a channel out.
+	successRate := float64(candidate.statistics.SuccessCount+1) / float64(candidate.statistics.AttemptCount+2)
+
+	cost := 0.5
+	if candidate.statistics.SuccessAmountMsat != 0 && maximumCostPpm != 0 {
+		costPpm := float64(candidate.statistics.SuccessFeeMsat) * 1_000_000 /
+			float64(candidate.statistics.SuccessAmountMsat)
+		cost = clampUnit(1 - costPpm/float64(maximumCostPpm))
+	}
+
+	feeRate := clampUnit(float64(candidate.feeRateMilliMsat) / rebalanceRankingFeeRateCeiling)
+	if incomingFocus {
+		feeRate = 1 - feeRate
+	}
+
+	return rebalanceRankingSurplusWeight*surplus +
+		rebalanceRankingSuccessRateWeight*successRate +
+		rebalanceRankingCostWeight*cost +
+		rebalanceRankingFeeRateWeight*feeRate
+}
+
+func clampUnit(value float64) float64 {
+	if value < 0 {
+		return 0
+	}
+	if value > 1 {
+		return 1
+	}
+	return value
+}
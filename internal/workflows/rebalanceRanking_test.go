@@ -0,0 +1,99 @@
+package workflows
+
+import (
+	"testing"
+
+	"github.com/lncapital/torq/internal/rebalances"
+	"github.com/lncapital/torq/testutil"
+)
+
+func TestRankRebalanceCandidates(t *testing.T) {
+	testCases := []struct {
+		name           string
+		candidates     []rebalanceCandidate
+		maximumCostPpm uint64
+		incomingFocus  bool
+		want           int
+	}{
+		{
+			name: "liquidity surplus",
+			candidates: []rebalanceCandidate{
+				{channelId: 1, liquiditySurplusPerMille: 100},
+				{channelId: 2, liquiditySurplusPerMille: 900},
+			},
+			maximumCostPpm: 500,
+			incomingFocus:  true,
+			want:           2,
+		},
+		{
+			name: "historic success",
+			candidates: []rebalanceCandidate{
+				{channelId: 1, liquiditySurplusPerMille: 500,
+					statistics: rebalances.RebalanceChannelStatistics{AttemptCount: 20}},
+				{channelId: 2, liquiditySurplusPerMille: 500,
+					statistics: rebalances.RebalanceChannelStatistics{AttemptCount: 10, SuccessCount: 8,
+						SuccessAmountMsat: 8_000_000_000, SuccessFeeMsat: 1_600_000}},
+			},
+			maximumCostPpm: 500,
+			incomingFocus:  true,
+			want:           2,
+		},
+		{
+			name: "never successful despite surplus",
+			candidates: []rebalanceCandidate{
+				{channelId: 1, liquiditySurplusPerMille: 900,
+					statistics: rebalances.RebalanceChannelStatistics{AttemptCount: 50}},
+				{channelId: 2, liquiditySurplusPerMille: 600},
+			},
+			maximumCostPpm: 500,
+			incomingFocus:  true,
+			want:           2,
+		},
+		{
+			name: "cheaper historic cost",
+			candidates: []rebalanceCandidate{
+				{channelId: 1, liquiditySurplusPerMille: 500,
+					statistics: rebalances.RebalanceChannelStatistics{AttemptCount: 2, SuccessCount: 1,
+						SuccessAmountMsat: 1_000_000_000, SuccessFeeMsat: 450_000}},
+				{channelId: 2, liquiditySurplusPerMille: 500,
+					statistics: rebalances.RebalanceChannelStatistics{AttemptCount: 2, SuccessCount: 1,
+						SuccessAmountMsat: 1_000_000_000, SuccessFeeMsat: 50_000}},
+			},
+			maximumCostPpm: 500,
+			incomingFocus:  true,
+			want:           2,
+		},
+		{
+			name: "low fee rate for outgoing candidates",
+			candidates: []rebalanceCandidate{
+				{channelId: 1, liquiditySurplusPerMille: 500, feeRateMilliMsat: 2_000},
+				{channelId: 2, liquiditySurplusPerMille: 500, feeRateMilliMsat: 10},
+			},
+			maximumCostPpm: 500,
+			incomingFocus:  true,
+			want:           2,
+		},
+		{
+			name: "high fee rate for incoming candidates",
+			candidates: []rebalanceCandidate{
+				{channelId: 1, liquiditySurplusPerMille: 500, feeRateMilliMsat: 2_000},
+				{channelId: 2, liquiditySurplusPerMille: 500, feeRateMilliMsat: 10},
+			},
+			maximumCostPpm: 500,
+			incomingFocus:  false,
+			want:           1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			rankRebalanceCandidates(tc.candidates, tc.maximumCostPpm, tc.incomingFocus)
+			got := tc.candidates[0].channelId
+			if got != tc.want {
+				testutil.Errorf(t, "rankRebalanceCandidates() = %v, want %v", got, tc.want)
+			} else {
+				testutil.Successf(t, "rankRebalanceCandidates() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
@@ -336,3 +336,13 @@ func removeRebalancer(rebalancer *Rebalancer) {
 	rebalanceCache = copyFromRebalancer(rebalanceCache)
 	RebalancesCacheChannel <- rebalanceCache
 }
+
+// GetActiveRebalancerCounts returns the amount of active rebalancers by nodeId
+func GetActiveRebalancerCounts() map[int]int {
+	activeStatus := core.Active
+	counts := make(map[int]int)
+	for _, rebalancer := range getRebalancers(&activeStatus) {
+		counts[rebalancer.NodeId]++
+	}
+	return counts
+}
@@ -41,9 +41,17 @@ func RegisterWorkflowRoutes(r *gin.RouterGroup, db *sqlx.DB) {
 	r.DELETE("/:workflowId", func(c *gin.Context) { removeWorkflowHandler(c, db) })
 	r.POST("/trigger", func(c *gin.Context) { workFlowTriggerHandler(c, db) })
 
+	// Workflow export and import (portable between Torq instances)
+	r.GET("/:workflowId/export", func(c *gin.Context) { exportWorkflowHandler(c, db) })
+	r.POST("/import", func(c *gin.Context) { importWorkflowHandler(c, db) })
+
 	// Workflow Logs
 	r.GET("/logs/:workflowId", func(c *gin.Context) { getWorkflowLogsHandler(c, db) })
 
+	// Workflow Runs (optional from and to in RFC3339 i.e. ?from=2023-05-01T03:00:00Z&to=2023-05-01T04:00:00Z)
+	r.GET("/:workflowId/runs", func(c *gin.Context) { getWorkflowRunsHandler(c, db) })
+	r.GET("/runs/:workflowRunId", func(c *gin.Context) { getWorkflowRunHandler(c, db) })
+
 	wv := r.Group("/:workflowId/versions")
 	{
 		// Get all versions of a workflow
@@ -131,12 +139,88 @@ func workFlowTriggerHandler(c *gin.Context, db *sqlx.DB) {
 		WorkflowVersionNodeId: workflow.WorkflowVersionNodeId,
 	}
 	reference := fmt.Sprintf("%v_%v", workflow.WorkflowVersionId, time.Now().UTC().Format("20060102.150405.000000"))
+	if workflow.DryRun {
+		workflowTriggerNode, err := getManualTriggerWorkflowNode(db, workflow.WorkflowVersionNodeId)
+		if err != nil {
+			server_errors.WrapLogAndSendServerError(c, err, "Obtaining the trigger for the dry run.")
+			return
+		}
+		dryRun, err := DryRunWorkflow(c.Request.Context(), db, workflowTriggerNode, "dryrun_"+reference,
+			[]any{manualTriggerEvent})
+		if err != nil {
+			server_errors.WrapLogAndSendServerError(c, err, "Dry running Workflow.")
+			return
+		}
+		c.JSON(http.StatusOK, dryRun)
+		return
+	}
 	cache.ScheduleTrigger(reference, workflow.WorkflowVersionId, workflow_helpers.WorkflowNodeManualTrigger,
 		workflow.WorkflowVersionNodeId, manualTriggerEvent)
 
 	c.JSON(http.StatusOK, map[string]interface{}{"message": "Successfully triggered Workflow."})
 }
 
+// exportWorkflowHandler exports the latest version unless the version query parameter is provided
+func exportWorkflowHandler(c *gin.Context, db *sqlx.DB) {
+	workflowId, err := strconv.Atoi(c.Param("workflowId"))
+	if err != nil {
+		server_errors.SendBadRequest(c, "Failed to find/parse workflowId in the request.")
+		return
+	}
+	var version *int
+	if c.Query("version") != "" {
+		versionValue, err := strconv.Atoi(c.Query("version"))
+		if err != nil {
+			server_errors.SendBadRequest(c, "Failed to parse version in the request.")
+			return
+		}
+		version = &versionValue
+	}
+	workflowExport, err := exportWorkflow(db, workflowId, version)
+	if err != nil {
+		server_errors.WrapLogAndSendServerError(c, err, fmt.Sprintf("Exporting workflow for workflowId: %v", workflowId))
+		return
+	}
+	c.JSON(http.StatusOK, workflowExport)
+}
+
+func importWorkflowHandler(c *gin.Context, db *sqlx.DB) {
+	var req WorkflowImportRequest
+	if err := c.BindJSON(&req); err != nil {
+		server_errors.SendBadRequestFromError(c, errors.Wrap(err, server_errors.JsonParseError))
+		return
+	}
+	name := req.Workflow.Name
+	if req.Name != nil && *req.Name != "" {
+		name = *req.Name
+	}
+	if name == "" {
+		server_errors.SendBadRequest(c, "Workflow name missing.")
+		return
+	}
+	tagNames, err := getTagNamesById(db)
+	if err != nil {
+		server_errors.WrapLogAndSendServerError(c, err, "Obtaining tags.")
+		return
+	}
+	nodes, err := resolveWorkflowImport(req, getChannelIdByReference,
+		func(publicKey string) int { return getNodeIdByReference(db, publicKey) }, tagNames)
+	if err != nil {
+		server_errors.SendUnprocessableEntityFromError(c, errors.Wrap(err, "Resolving the workflow references"))
+		return
+	}
+	workflowVersion, err := importWorkflow(db, name, nodes, req.Workflow.Links)
+	if err != nil {
+		if errors.Is(err, database.SqlUniqueConstraintError) {
+			server_errors.SendUnprocessableEntity(c, "A workflow with this name already exists.")
+			return
+		}
+		server_errors.WrapLogAndSendServerError(c, err, "Importing workflow.")
+		return
+	}
+	c.JSON(http.StatusOK, workflowVersion)
+}
+
 func updateWorkflowHandler(c *gin.Context, db *sqlx.DB) {
 	var req UpdateWorkflow
 	if err := c.BindJSON(&req); err != nil {
@@ -495,6 +579,19 @@ func updateNodeHandler(c *gin.Context, db *sqlx.DB) {
 		return
 	}
 
+	if req.Parameters != nil && (workflowNode.Type == workflow_helpers.WorkflowNodeChannelPolicyConfigurator ||
+		workflowNode.Type == workflow_helpers.WorkflowNodeChannelPolicyAutoRun) {
+		serverError, err := getChannelPolicyParametersError(*req.Parameters)
+		if err != nil {
+			server_errors.SendBadRequestFromError(c, errors.Wrap(err, server_errors.JsonParseError))
+			return
+		}
+		if serverError != nil {
+			server_errors.SendBadRequestFieldError(c, serverError)
+			return
+		}
+	}
+
 	// Validate the request
 	resp, err := updateNode(db, req)
 	if err != nil {
@@ -517,6 +614,28 @@ func updateNodeHandler(c *gin.Context, db *sqlx.DB) {
 	c.JSON(http.StatusOK, resp)
 }
 
+// getChannelPolicyParametersError returns the invalid formulas of the channel policy parameters as field errors
+func getChannelPolicyParametersError(parameters interface{}) (*server_errors.ServerError, error) {
+	parametersMarshalled, err := json.Marshal(parameters)
+	if err != nil {
+		return nil, errors.Wrap(err, "Marshalling the channel policy parameters")
+	}
+	var channelPolicyConfiguration ChannelPolicyConfiguration
+	err = json.Unmarshal(parametersMarshalled, &channelPolicyConfiguration)
+	if err != nil {
+		return nil, errors.Wrap(err, "Unmarshalling the channel policy parameters")
+	}
+	formulaErrors := channelPolicyConfiguration.getPolicyFormulaErrors()
+	if len(formulaErrors) == 0 {
+		return nil, nil
+	}
+	serverError := &server_errors.ServerError{}
+	for name, formulaError := range formulaErrors {
+		serverError.AddFieldError(name, formulaError)
+	}
+	return serverError, nil
+}
+
 func updateNodeLVisibilitySettingsHandler(c *gin.Context, db *sqlx.DB) {
 	workflowVersionNodeId, err := strconv.Atoi(c.Param("nodeId"))
 	if err != nil {
@@ -677,6 +796,54 @@ func getWorkflowLogsHandler(c *gin.Context, db *sqlx.DB) {
 	c.JSON(http.StatusOK, workflowLogs)
 }
 
+func getWorkflowRunsHandler(c *gin.Context, db *sqlx.DB) {
+	workflowId, err := strconv.Atoi(c.Param("workflowId"))
+	if err != nil {
+		server_errors.SendBadRequest(c, "Failed to find/parse workflowId in the request.")
+		return
+	}
+	to := time.Now().UTC()
+	if c.Query("to") != "" {
+		to, err = time.Parse(time.RFC3339, c.Query("to"))
+		if err != nil {
+			server_errors.SendBadRequest(c, "Failed to parse to (RFC3339) in the request.")
+			return
+		}
+	}
+	from := to.AddDate(0, 0, -7)
+	if c.Query("from") != "" {
+		from, err = time.Parse(time.RFC3339, c.Query("from"))
+		if err != nil {
+			server_errors.SendBadRequest(c, "Failed to parse from (RFC3339) in the request.")
+			return
+		}
+	}
+	workflowRuns, err := GetWorkflowRuns(db, workflowId, from, to, workflowLogCount)
+	if err != nil {
+		server_errors.WrapLogAndSendServerError(c, err, fmt.Sprintf("Getting workflow runs for workflowId: %v", workflowId))
+		return
+	}
+	c.JSON(http.StatusOK, workflowRuns)
+}
+
+func getWorkflowRunHandler(c *gin.Context, db *sqlx.DB) {
+	workflowRunId, err := strconv.Atoi(c.Param("workflowRunId"))
+	if err != nil {
+		server_errors.SendBadRequest(c, "Failed to find/parse workflowRunId in the request.")
+		return
+	}
+	workflowRunTrace, err := GetWorkflowRunTrace(db, workflowRunId)
+	if err != nil {
+		server_errors.WrapLogAndSendServerError(c, err, fmt.Sprintf("Getting workflow run for workflowRunId: %v", workflowRunId))
+		return
+	}
+	if workflowRunTrace.WorkflowRunId == 0 {
+		c.JSON(http.StatusNotFound, map[string]interface{}{"message": fmt.Sprintf("Workflow run %v not found.", workflowRunId)})
+		return
+	}
+	c.JSON(http.StatusOK, workflowRunTrace)
+}
+
 func getNodeLogsHandler(c *gin.Context, db *sqlx.DB) {
 	workflowVersionNodeId, err := strconv.Atoi(c.Param("nodeId"))
 	if err != nil {
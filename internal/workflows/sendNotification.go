@@ -0,0 +1,148 @@
+package workflows
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/cockroachdb/errors"
+	"github.com/jmoiron/sqlx"
+	"golang.org/x/exp/slices"
+
+	"github.com/lncapital/torq/internal/cache"
+	"github.com/lncapital/torq/internal/channels"
+	"github.com/lncapital/torq/internal/communications"
+	"github.com/lncapital/torq/internal/core"
+)
+
+// notificationTemplateData is available in the message template of a WorkflowNodeSendNotification
+// i.e. "{{.PeerAlias}} ({{.ShortChannelId}}) balance ratio {{printf "%.2f" .BalanceRatio}} fee rate {{.FeeRatePpm}}"
+// or "{{.PeerAlias}} earned {{.Revenue7d}} sats in the last 7 days"
+type notificationTemplateData struct {
+	channels.ChannelBody
+	ChannelFlow
+	// BalanceRatio is the local balance divided by the capacity
+	BalanceRatio float64
+	FeeRatePpm   int64
+	// Event is the channel balance event that triggered the workflow for this channel (when there is one)
+	Event *core.ChannelBalanceEvent
+}
+
+// renderNotificationMessage renders the template for every channel and returns the lines as a single message.
+func renderNotificationMessage(messageTemplate string, templateData []notificationTemplateData) (string, error) {
+	parsedTemplate, err := template.New("notification").Option("missingkey=zero").Parse(messageTemplate)
+	if err != nil {
+		return "", errors.Wrap(err, "Parsing the message template")
+	}
+	var lines []string
+	for _, data := range templateData {
+		var line strings.Builder
+		err = parsedTemplate.Execute(&line, data)
+		if err != nil {
+			return "", errors.Wrapf(err, "Rendering the message template for channelId: %v", data.ChannelId)
+		}
+		if strings.TrimSpace(line.String()) != "" {
+			lines = append(lines, line.String())
+		}
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+func getNotificationTemplateData(db *sqlx.DB,
+	channelIds []int,
+	events []core.ChannelBalanceEvent) ([]notificationTemplateData, error) {
+
+	channelFlows, err := GetChannelFlows(db, channelIds)
+	if err != nil {
+		return nil, errors.Wrap(err, "Getting the channel flows")
+	}
+	torqNodeIds := cache.GetAllTorqNodeIds()
+	var templateData []notificationTemplateData
+	for _, channelId := range channelIds {
+		channelSettings := cache.GetChannelSettingByChannelId(channelId)
+		nodeId := channelSettings.FirstNodeId
+		if !slices.Contains(torqNodeIds, nodeId) {
+			nodeId = channelSettings.SecondNodeId
+		}
+		if !slices.Contains(torqNodeIds, nodeId) {
+			continue
+		}
+		channelBodies, err := channels.GetChannelsByIds(nodeId, []int{channelId})
+		if err != nil {
+			return nil, errors.Wrapf(err, "Getting the channel for channelId: %v", channelId)
+		}
+		for _, channelBody := range channelBodies {
+			data := notificationTemplateData{
+				ChannelBody: channelBody,
+				ChannelFlow: channelFlows[channelBody.ChannelId],
+				FeeRatePpm:  channelBody.FeeRateMilliMsat,
+			}
+			if channelBody.Capacity != 0 {
+				data.BalanceRatio = float64(channelBody.LocalBalance) / float64(channelBody.Capacity)
+			}
+			for i := range events {
+				if events[i].ChannelId == channelId {
+					data.Event = &events[i]
+					break
+				}
+			}
+			templateData = append(templateData, data)
+		}
+	}
+	return templateData, nil
+}
+
+// processSendNotification renders the message for the incoming channels and sends it to the configured communications.
+// Without a channels input the template is rendered once, with an empty channels input nothing is sent.
+// It returns the message that was sent (or would have been sent for a dry run).
+func processSendNotification(ctx context.Context,
+	db *sqlx.DB,
+	linkedChannelIds []int,
+	hasLinkedChannels bool,
+	events []core.ChannelBalanceEvent,
+	workflowNode WorkflowNode,
+	dryRun *WorkflowDryRun) (string, error) {
+
+	var params SendNotificationConfiguration
+	err := json.Unmarshal([]byte(workflowNode.Parameters), &params)
+	if err != nil {
+		return "", errors.Wrapf(err, "Parsing parameters for WorkflowVersionNodeId: %v", workflowNode.WorkflowVersionNodeId)
+	}
+	if strings.TrimSpace(params.MessageTemplate) == "" || len(params.CommunicationIds) == 0 {
+		return "", errors.New(fmt.Sprintf("Message template or communications missing for WorkflowVersionNodeId: %v",
+			workflowNode.WorkflowVersionNodeId))
+	}
+
+	templateData := []notificationTemplateData{{}}
+	if hasLinkedChannels {
+		templateData, err = getNotificationTemplateData(db, linkedChannelIds, events)
+		if err != nil {
+			return "", errors.Wrapf(err, "Obtaining the channels for WorkflowVersionNodeId: %v",
+				workflowNode.WorkflowVersionNodeId)
+		}
+	}
+	message, err := renderNotificationMessage(params.MessageTemplate, templateData)
+	if err != nil {
+		return "", errors.Wrapf(err, "Rendering the message for WorkflowVersionNodeId: %v",
+			workflowNode.WorkflowVersionNodeId)
+	}
+	if message == "" {
+		return "", nil
+	}
+	if dryRun != nil {
+		dryRun.Notifications = append(dryRun.Notifications, WorkflowDryRunNotification{
+			WorkflowVersionNodeId: workflowNode.WorkflowVersionNodeId,
+			CommunicationIds:      params.CommunicationIds,
+			Message:               message,
+		})
+		return message, nil
+	}
+	err = communications.SendCommunicationMessage(ctx, db, params.CommunicationIds, message)
+	if err != nil {
+		return "", errors.Wrapf(err, "Sending the message for WorkflowVersionNodeId: %v",
+			workflowNode.WorkflowVersionNodeId)
+	}
+	return message, nil
+}
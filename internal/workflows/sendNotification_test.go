@@ -0,0 +1,80 @@
+package workflows
+
+import (
+	"testing"
+
+	"github.com/lncapital/torq/internal/channels"
+	"github.com/lncapital/torq/internal/core"
+	"github.com/lncapital/torq/testutil"
+)
+
+func TestRenderNotificationMessage(t *testing.T) {
+	channel := func(channelId int, peerAlias string, balanceRatio float64, feeRatePpm int64) notificationTemplateData {
+		return notificationTemplateData{
+			ChannelBody:  channels.ChannelBody{ChannelId: channelId, PeerAlias: peerAlias, ShortChannelId: "800000x1x0"},
+			BalanceRatio: balanceRatio,
+			FeeRatePpm:   feeRatePpm,
+		}
+	}
+	withEvent := channel(3, "carol", 0.1, 500)
+	withEvent.Event = &core.ChannelBalanceEvent{ChannelId: 3}
+
+	testCases := []struct {
+		name     string
+		template string
+		data     []notificationTemplateData
+		want     string
+		wantErr  bool
+	}{
+		{
+			name:     "single channel",
+			template: `{{.PeerAlias}} ({{.ShortChannelId}}) ratio {{printf "%.2f" .BalanceRatio}} fee {{.FeeRatePpm}}ppm`,
+			data:     []notificationTemplateData{channel(1, "alice", 0.25, 100)},
+			want:     "alice (800000x1x0) ratio 0.25 fee 100ppm",
+		},
+		{
+			name:     "one line per channel",
+			template: `{{.PeerAlias}} needs attention`,
+			data:     []notificationTemplateData{channel(1, "alice", 0.25, 100), channel(2, "bob", 0.9, 200)},
+			want:     "alice needs attention\nbob needs attention",
+		},
+		{
+			name:     "empty lines are skipped",
+			template: `{{if .Event}}{{.PeerAlias}} triggered{{end}}`,
+			data:     []notificationTemplateData{channel(1, "alice", 0.25, 100), withEvent},
+			want:     "carol triggered",
+		},
+		{
+			name:     "without channels",
+			template: `Workflow finished`,
+			data:     []notificationTemplateData{{}},
+			want:     "Workflow finished",
+		},
+		{
+			name:     "invalid template",
+			template: `{{.PeerAlias`,
+			data:     []notificationTemplateData{channel(1, "alice", 0.25, 100)},
+			wantErr:  true,
+		},
+		{
+			name:     "unknown field",
+			template: `{{.Unknown}}`,
+			data:     []notificationTemplateData{channel(1, "alice", 0.25, 100)},
+			wantErr:  true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := renderNotificationMessage(tc.template, tc.data)
+			if (err != nil) != tc.wantErr {
+				testutil.Errorf(t, "renderNotificationMessage() error = %v, wantErr %v", err, tc.wantErr)
+				return
+			}
+			if got != tc.want {
+				testutil.Errorf(t, "renderNotificationMessage() = %q, want %q", got, tc.want)
+				return
+			}
+			testutil.Successf(t, "renderNotificationMessage() = %q", got)
+		})
+	}
+}
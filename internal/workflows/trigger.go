@@ -13,6 +13,7 @@ import (
 	"github.com/rs/zerolog/log"
 	"golang.org/x/exp/slices"
 
+	"github.com/lncapital/torq/internal/audit"
 	"github.com/lncapital/torq/internal/cache"
 	"github.com/lncapital/torq/internal/channels"
 	"github.com/lncapital/torq/internal/core"
@@ -31,12 +32,23 @@ func ProcessWorkflow(ctx context.Context, db *sqlx.DB,
 	reference string,
 	events []any) error {
 
+	return processWorkflow(ctx, db, workflowTriggerNode, reference, events, nil)
+}
+
+// processWorkflow when dryRun is not nil the actions are recorded in dryRun instead of being executed
+func processWorkflow(ctx context.Context, db *sqlx.DB,
+	workflowTriggerNode WorkflowNode,
+	reference string,
+	events []any,
+	dryRun *WorkflowDryRun) (err error) {
+
 	workflowNodeInputCache := make(map[workflowVersionNodeIdType]map[workflow_helpers.WorkflowParameterLabel]string)
 	workflowNodeInputByReferenceIdCache := make(map[workflowVersionNodeIdType]map[channelIdType]map[workflow_helpers.WorkflowParameterLabel]string)
 	workflowNodeOutputCache := make(map[workflowVersionNodeIdType]map[workflow_helpers.WorkflowParameterLabel]string)
 	workflowNodeOutputByReferenceIdCache := make(map[workflowVersionNodeIdType]map[channelIdType]map[workflow_helpers.WorkflowParameterLabel]string)
 	workflowStageOutputCache := make(map[stageType]map[workflow_helpers.WorkflowParameterLabel]string)
 	workflowStageOutputByReferenceIdCache := make(map[stageType]map[channelIdType]map[workflow_helpers.WorkflowParameterLabel]string)
+	variables := make(workflowVariables)
 
 	select {
 	case <-ctx.Done():
@@ -48,6 +60,11 @@ func ProcessWorkflow(ctx context.Context, db *sqlx.DB,
 		return nil
 	}
 
+	workflowRunId := startWorkflowRun(db, workflowTriggerNode, reference, dryRun != nil)
+	defer func() {
+		endWorkflowRun(db, workflowRunId, err)
+	}()
+
 	workflowNodeStatus := make(map[int]core.Status)
 	workflowNodeStatus[workflowTriggerNode.WorkflowVersionNodeId] = core.Active
 
@@ -130,11 +147,13 @@ func ProcessWorkflow(ctx context.Context, db *sqlx.DB,
 		}
 		done = true
 		for _, workflowVersionNode := range workflowVersionNodes {
+			startedOn := time.Now()
 			processStatus, err = processWorkflowNode(ctx, db, workflowVersionNode, workflowVersionNodes, workflowTriggerNode,
 				workflowNodeStatus, reference, workflowNodeInputCache, workflowNodeInputByReferenceIdCache,
 				workflowNodeOutputCache, workflowNodeOutputByReferenceIdCache,
-				workflowStageOutputCache, workflowStageOutputByReferenceIdCache)
+				workflowStageOutputCache, workflowStageOutputByReferenceIdCache, variables, dryRun, workflowRunId)
 			if err != nil {
+				addWorkflowRunNodeError(db, workflowRunId, reference, workflowVersionNode, workflowTriggerNode, startedOn, err)
 				return errors.Wrapf(err, "Failed to process workflow nodes for WorkflowVersionId: %v (stage: %v)",
 					workflowTriggerNode.WorkflowVersionId, workflowTriggerNode.Stage)
 			}
@@ -176,11 +195,13 @@ func ProcessWorkflow(ctx context.Context, db *sqlx.DB,
 			}
 			done = true
 			for _, workflowVersionNode := range workflowVersionNodes {
+				startedOn := time.Now()
 				processStatus, err = processWorkflowNode(ctx, db, workflowVersionNode, workflowVersionNodes, workflowTriggerNode,
 					workflowNodeStatus, reference, workflowNodeInputCache, workflowNodeInputByReferenceIdCache,
 					workflowNodeOutputCache, workflowNodeOutputByReferenceIdCache,
-					workflowStageOutputCache, workflowStageOutputByReferenceIdCache)
+					workflowStageOutputCache, workflowStageOutputByReferenceIdCache, variables, dryRun, workflowRunId)
 				if err != nil {
+					addWorkflowRunNodeError(db, workflowRunId, reference, workflowVersionNode, workflowTriggerNode, startedOn, err)
 					return errors.Wrapf(err, "Failed to process workflow nodes for WorkflowVersionId: %v (stage: %v)",
 						workflowTriggerNode.WorkflowVersionId, workflowStageTriggerNode.Stage)
 				}
@@ -265,8 +286,12 @@ func processWorkflowNode(ctx context.Context, db *sqlx.DB,
 	workflowNodeOutputCache map[workflowVersionNodeIdType]map[workflow_helpers.WorkflowParameterLabel]string,
 	workflowNodeOutputByReferenceIdCache map[workflowVersionNodeIdType]map[channelIdType]map[workflow_helpers.WorkflowParameterLabel]string,
 	workflowStageOutputCache map[stageType]map[workflow_helpers.WorkflowParameterLabel]string,
-	workflowStageOutputByReferenceIdCache map[stageType]map[channelIdType]map[workflow_helpers.WorkflowParameterLabel]string) (core.Status, error) {
+	workflowStageOutputByReferenceIdCache map[stageType]map[channelIdType]map[workflow_helpers.WorkflowParameterLabel]string,
+	variables workflowVariables,
+	dryRun *WorkflowDryRun,
+	workflowRunId int) (core.Status, error) {
 
+	startedOn := time.Now()
 	select {
 	case <-ctx.Done():
 		return core.Inactive, errors.New(fmt.Sprintf("Context terminated for WorkflowVersionId: %v", workflowNode.WorkflowVersionId))
@@ -305,6 +330,19 @@ linkedInputLoop:
 		return core.Pending, nil
 	}
 
+	resolvedParameters, missingVariableNames, err := resolveWorkflowVariables(workflowNode.Parameters, variables)
+	if err != nil {
+		return core.Inactive, errors.Wrapf(err, "Resolving variables for WorkflowVersionNodeId: %v", workflowNode.WorkflowVersionNodeId)
+	}
+	if len(missingVariableNames) != 0 {
+		if isWorkflowVariablePending(missingVariableNames, workflowNodes, workflowNodeStatus) {
+			return core.Pending, nil
+		}
+		return core.Inactive, errors.New(fmt.Sprintf("Variables %v are not set for WorkflowVersionNodeId: %v",
+			missingVariableNames, workflowNode.WorkflowVersionNodeId))
+	}
+	workflowNode.Parameters = resolvedParameters
+
 	inputs := workflowNodeInputCache[workflowVersionNodeIdType(workflowNode.WorkflowVersionNodeId)]
 	inputsByReferenceId := workflowNodeInputByReferenceIdCache[workflowVersionNodeIdType(workflowNode.WorkflowVersionNodeId)]
 	outputs := workflowNodeOutputCache[workflowVersionNodeIdType(workflowNode.WorkflowVersionNodeId)]
@@ -370,27 +408,66 @@ linkedInputLoop:
 			return core.Inactive, errors.Wrapf(err, "Adding All ChannelIds to the output for WorkflowVersionNodeId: %v", workflowNode.WorkflowVersionNodeId)
 		}
 	case workflow_helpers.WorkflowNodeSetVariable:
-		//variableName := getWorkflowNodeParameter(parameters, commons.WorkflowParameterVariableName).ValueString
-		//stringVariableParameter := getWorkflowNodeParameter(parameters, commons.WorkflowParameterVariableValueString)
-		//if stringVariableParameter.ValueString != "" {
-		//	outputs[variableName] = stringVariableParameter.ValueString
-		//} else {
-		//	outputs[variableName] = fmt.Sprintf("%d", getWorkflowNodeParameter(parameters, commons.WorkflowParameterVariableValueNumber).ValueNumber)
-		//}
+		variableName, variableValue, err := getSetVariableConfiguration(workflowNode)
+		if err != nil {
+			return core.Inactive, errors.Wrapf(err, "Setting variable for WorkflowVersionNodeId: %v", workflowNode.WorkflowVersionNodeId)
+		}
+		variables[variableName] = variableValue
 	case workflow_helpers.WorkflowNodeFilterOnVariable:
-		//variableName := getWorkflowNodeParameter(parameters, commons.WorkflowParameterVariableName).ValueString
-		//stringVariableParameter := getWorkflowNodeParameter(parameters, commons.WorkflowParameterVariableValueString)
-		//stringValue := ""
-		//if stringVariableParameter.ValueString != "" {
-		//	stringValue = stringVariableParameter.ValueString
-		//} else {
-		//	stringValue = fmt.Sprintf("%d", getWorkflowNodeParameter(parameters, commons.WorkflowParameterVariableValueNumber).ValueNumber)
-		//}
-		//if inputs[variableName] == stringValue {
-		//	activeOutputIndex = 0
-		//} else {
-		//	activeOutputIndex = 1
-		//}
+		linkedChannelIds, err := getChannelIds(inputs, workflow_helpers.WorkflowParameterLabelChannels)
+		if err != nil {
+			return core.Inactive, errors.Wrapf(err, "Obtaining linkedChannelIds for WorkflowVersionNodeId: %v", workflowNode.WorkflowVersionNodeId)
+		}
+
+		var params FilterOnVariableConfiguration
+		err = json.Unmarshal([]byte(workflowNode.Parameters), &params)
+		if err != nil {
+			return core.Inactive, errors.Wrapf(err, "Parsing parameters for WorkflowVersionNodeId: %v", workflowNode.WorkflowVersionNodeId)
+		}
+
+		if len(linkedChannelIds) == 0 {
+			return core.Inactive, errors.Newf("No ChannelIds found in the inputs for WorkflowVersionNodeId: %v", workflowNode.WorkflowVersionNodeId)
+		}
+
+		variableValue, exists := variables[params.VariableName]
+		if !exists {
+			if isWorkflowVariablePending([]string{params.VariableName}, workflowNodes, workflowNodeStatus) {
+				return core.Pending, nil
+			}
+			return core.Inactive, errors.New(fmt.Sprintf("Variable %v is not set for WorkflowVersionNodeId: %v",
+				params.VariableName, workflowNode.WorkflowVersionNodeId))
+		}
+
+		var linkedChannels []channels.ChannelBody
+		var channelFlows map[int]ChannelFlow
+		if params.ChannelKey != "" {
+			channelFlows, err = GetChannelFlows(db, linkedChannelIds)
+			if err != nil {
+				return core.Inactive, errors.Wrapf(err, "Getting the channel flows to filter for WorkflowVersionNodeId: %v", workflowNode.WorkflowVersionNodeId)
+			}
+			torqNodeIds := cache.GetAllTorqNodeIds()
+			for _, torqNodeId := range torqNodeIds {
+				linkedChannelsByNode, err := channels.GetChannelsByIds(torqNodeId, linkedChannelIds)
+				if err != nil {
+					return core.Inactive, errors.Wrapf(err, "Getting the linked channels to filters for WorkflowVersionNodeId: %v", workflowNode.WorkflowVersionNodeId)
+				}
+				linkedChannels = append(linkedChannels, linkedChannelsByNode...)
+			}
+		}
+
+		filteredChannelIds, nonMatchingChannelIds, err := filterOnVariable(params, variableValue, linkedChannelIds, linkedChannels, channelFlows)
+		if err != nil {
+			return core.Inactive, errors.Wrapf(err, "Filtering on variable for WorkflowVersionNodeId: %v", workflowNode.WorkflowVersionNodeId)
+		}
+
+		err = setChannelIds(outputs, workflow_helpers.WorkflowParameterLabelChannels, filteredChannelIds)
+		if err != nil {
+			return core.Inactive, errors.Wrapf(err, "Adding ChannelIds to the output for WorkflowVersionNodeId: %v", workflowNode.WorkflowVersionNodeId)
+		}
+		err = setChannelIds(outputs, workflow_helpers.WorkflowParameterLabelNonMatchingChannels, nonMatchingChannelIds)
+		if err != nil {
+			return core.Inactive, errors.Wrapf(err, "Adding non-matching ChannelIds to the output for WorkflowVersionNodeId: %v", workflowNode.WorkflowVersionNodeId)
+		}
 	case workflow_helpers.WorkflowNodeChannelBalanceEventFilter:
 		linkedChannelIds, err := getChannelIds(inputs, workflow_helpers.WorkflowParameterLabelChannels)
 		if err != nil {
@@ -457,7 +534,11 @@ linkedInputLoop:
 				}
 				linkedChannels = append(linkedChannels, linkedChannelsByNode...)
 			}
-			filteredChannelIds = FilterChannelBodyChannelIds(params, linkedChannels)
+			channelFlows, err := GetChannelFlows(db, linkedChannelIds)
+			if err != nil {
+				return core.Inactive, errors.Wrapf(err, "Getting the channel flows to filter for WorkflowVersionNodeId: %v", workflowNode.WorkflowVersionNodeId)
+			}
+			filteredChannelIds = FilterChannelBodyChannelIds(params, linkedChannels, channelFlows)
 		} else {
 			filteredChannelIds = linkedChannelIds
 		}
@@ -476,10 +557,37 @@ linkedInputLoop:
 			return core.Inactive, errors.Wrapf(err, "No ChannelIds found in the inputs for WorkflowVersionNodeId: %v", workflowNode.WorkflowVersionNodeId)
 		}
 
-		err = addOrRemoveTags(db, linkedChannelIds, workflowNode)
+		err = addOrRemoveTags(db, linkedChannelIds, workflowNode, dryRun)
 		if err != nil {
 			return core.Inactive, errors.Wrapf(err, "Adding or removing tags with ChannelIds: %v for WorkflowVersionNodeId: %v", linkedChannelIds, workflowNode.WorkflowVersionNodeId)
 		}
+	case workflow_helpers.WorkflowNodeSendNotification:
+		_, hasLinkedChannels := inputs[workflow_helpers.WorkflowParameterLabelChannels]
+		var linkedChannelIds []int
+		if hasLinkedChannels {
+			var err error
+			linkedChannelIds, err = getChannelIds(inputs, workflow_helpers.WorkflowParameterLabelChannels)
+			if err != nil {
+				return core.Inactive, errors.Wrapf(err, "Obtaining linkedChannelIds for WorkflowVersionNodeId: %v", workflowNode.WorkflowVersionNodeId)
+			}
+		}
+
+		events, err := getChannelBalanceEvents(inputs)
+		if err != nil {
+			return core.Inactive, errors.Wrapf(err, "Obtaining events for WorkflowVersionNodeId: %v", workflowNode.WorkflowVersionNodeId)
+		}
+
+		_, err = processSendNotification(ctx, db, linkedChannelIds, hasLinkedChannels, events, workflowNode, dryRun)
+		if err != nil {
+			return core.Inactive, errors.Wrapf(err, "Sending notification for WorkflowVersionNodeId: %v", workflowNode.WorkflowVersionNodeId)
+		}
+
+		if hasLinkedChannels {
+			err = setChannelIds(outputs, workflow_helpers.WorkflowParameterLabelChannels, linkedChannelIds)
+			if err != nil {
+				return core.Inactive, errors.Wrapf(err, "Adding ChannelIds to the output for WorkflowVersionNodeId: %v", workflowNode.WorkflowVersionNodeId)
+			}
+		}
 	case workflow_helpers.WorkflowNodeChannelPolicyConfigurator:
 		linkedChannelIds, err := getChannelIds(inputs, workflow_helpers.WorkflowParameterLabelChannels)
 		if err != nil {
@@ -490,6 +598,11 @@ linkedInputLoop:
 			return core.Inactive, errors.Wrapf(err, "No ChannelIds found in the inputs for WorkflowVersionNodeId: %v", workflowNode.WorkflowVersionNodeId)
 		}
 
+		formulaChannels, err := getPolicyFormulaChannelsForNode(db, workflowNode, linkedChannelIds)
+		if err != nil {
+			return core.Inactive, errors.Wrapf(err, "Getting the channel data for the formulas for WorkflowVersionNodeId: %v", workflowNode.WorkflowVersionNodeId)
+		}
+
 		for channelId, labelValueMap := range inputsByReferenceId {
 			if !slices.Contains(linkedChannelIds, int(channelId)) {
 				outputsByReferenceId[channelId] = labelValueMap
@@ -497,7 +610,8 @@ linkedInputLoop:
 			}
 
 			var routingPolicySettings ChannelPolicyConfiguration
-			routingPolicySettings, err = processRoutingPolicyConfigurator(channelId, inputsByReferenceId, workflowNode)
+			routingPolicySettings, err = processRoutingPolicyConfigurator(channelId, inputsByReferenceId, workflowNode,
+				formulaChannels[int(channelId)], variables)
 			if err != nil {
 				return core.Inactive, errors.Wrapf(err, "Processing Routing Policy Configurator with ChannelIds: %v for WorkflowVersionNodeId: %v", linkedChannelIds, workflowNode.WorkflowVersionNodeId)
 			}
@@ -524,6 +638,11 @@ linkedInputLoop:
 			return core.Inactive, errors.Wrapf(err, "No ChannelIds found in the inputs for WorkflowVersionNodeId: %v", workflowNode.WorkflowVersionNodeId)
 		}
 
+		formulaChannels, err := getPolicyFormulaChannelsForNode(db, workflowNode, linkedChannelIds)
+		if err != nil {
+			return core.Inactive, errors.Wrapf(err, "Getting the channel data for the formulas for WorkflowVersionNodeId: %v", workflowNode.WorkflowVersionNodeId)
+		}
+
 		for channelId, labelValueMap := range inputsByReferenceId {
 			if !slices.Contains(linkedChannelIds, int(channelId)) {
 				outputsByReferenceId[channelId] = labelValueMap
@@ -531,12 +650,13 @@ linkedInputLoop:
 			}
 
 			var routingPolicySettings ChannelPolicyConfiguration
-			routingPolicySettings, err = processRoutingPolicyConfigurator(channelId, inputsByReferenceId, workflowNode)
+			routingPolicySettings, err = processRoutingPolicyConfigurator(channelId, inputsByReferenceId, workflowNode,
+				formulaChannels[int(channelId)], variables)
 			if err != nil {
 				return core.Inactive, errors.Wrapf(err, "Processing Routing Policy Configurator with ChannelIds: %v for WorkflowVersionNodeId: %v", linkedChannelIds, workflowNode.WorkflowVersionNodeId)
 			}
 
-			err = processRoutingPolicyRun(db, routingPolicySettings, workflowNode, reference, workflowTriggerNode.Type)
+			err = processRoutingPolicyRun(db, routingPolicySettings, workflowNode, reference, workflowTriggerNode.Type, dryRun)
 			if err != nil {
 				return core.Inactive, errors.Wrapf(err, "Processing Routing Policy Configurator with ChannelIds: %v for WorkflowVersionNodeId: %v", linkedChannelIds, workflowNode.WorkflowVersionNodeId)
 			}
@@ -575,7 +695,7 @@ linkedInputLoop:
 			}
 
 			if routingPolicySettings.ChannelId != 0 {
-				err = processRoutingPolicyRun(db, routingPolicySettings, workflowNode, reference, workflowTriggerNode.Type)
+				err = processRoutingPolicyRun(db, routingPolicySettings, workflowNode, reference, workflowTriggerNode.Type, dryRun)
 				if err != nil {
 					return core.Inactive, errors.Wrapf(err, "Processing Routing Policy Configurator for WorkflowVersionNodeId: %v", workflowNode.WorkflowVersionNodeId)
 				}
@@ -729,7 +849,7 @@ linkedInputLoop:
 		}
 
 		var responses []lightning_helpers.RebalanceResponse
-		responses, err = processRebalanceRun(db, eventChannelIds, rebalanceConfigurations, workflowNode, reference)
+		responses, err = processRebalanceRun(db, eventChannelIds, rebalanceConfigurations, workflowNode, reference, dryRun)
 		if err != nil {
 			return core.Inactive, errors.Wrapf(err, "Processing Rebalance for WorkflowVersionNodeId: %v", workflowNode.WorkflowVersionNodeId)
 		}
@@ -788,7 +908,7 @@ linkedInputLoop:
 			return core.Inactive, errors.Wrapf(err, "Obtaining eventChannelIds for WorkflowVersionNodeId: %v", workflowNode.WorkflowVersionNodeId)
 		}
 
-		responses, err := processRebalanceRun(db, eventChannelIds, rebalanceConfigurations, workflowNode, reference)
+		responses, err := processRebalanceRun(db, eventChannelIds, rebalanceConfigurations, workflowNode, reference, dryRun)
 		if err != nil {
 			return core.Inactive, errors.Wrapf(err, "Processing Rebalance for WorkflowVersionNodeId: %v", workflowNode.WorkflowVersionNodeId)
 		}
@@ -833,16 +953,31 @@ linkedInputLoop:
 	if err != nil {
 		log.Error().Err(err).Msgf("Marshalling outputs for WorkflowVersionNodeId: %v", workflowNode.WorkflowVersionNodeId)
 	}
-	_, err = addWorkflowVersionNodeLog(db, WorkflowVersionNodeLog{
+	debugData := ""
+	if dryRun != nil {
+		nodeDryRun := dryRun.forWorkflowVersionNode(workflowNode.WorkflowVersionNodeId)
+		if !nodeDryRun.isEmpty() {
+			debugData = marshalDryRun(nodeDryRun)
+			log.Info().Msgf("Dry run for WorkflowVersionNodeId: %v would execute: %v",
+				workflowNode.WorkflowVersionNodeId, debugData)
+		}
+	}
+	durationMilliseconds := time.Since(startedOn).Milliseconds()
+	workflowVersionNodeLog := WorkflowVersionNodeLog{
 		TriggerReference:                reference,
 		InputData:                       string(marshalledInputs),
 		OutputData:                      string(marshalledOutputs),
-		DebugData:                       "",
+		DebugData:                       debugData,
 		ErrorData:                       "",
 		WorkflowVersionNodeId:           workflowNode.WorkflowVersionNodeId,
 		TriggeringWorkflowVersionNodeId: &workflowTriggerNode.WorkflowVersionNodeId,
 		CreatedOn:                       time.Now().UTC(),
-	})
+		DurationMilliseconds:            &durationMilliseconds,
+	}
+	if workflowRunId != 0 {
+		workflowVersionNodeLog.WorkflowRunId = &workflowRunId
+	}
+	_, err = addWorkflowVersionNodeLog(db, workflowVersionNodeLog)
 	if err != nil {
 		log.Error().Err(err).Msgf("Storing log for WorkflowVersionNodeId: %v", workflowNode.WorkflowVersionNodeId)
 	}
@@ -1023,7 +1158,8 @@ func processRebalanceRun(
 	eventChannelIds []int,
 	rebalanceSettings []RebalanceConfiguration,
 	workflowNode WorkflowNode,
-	reference string) ([]lightning_helpers.RebalanceResponse, error) {
+	reference string,
+	dryRun *WorkflowDryRun) ([]lightning_helpers.RebalanceResponse, error) {
 
 	requestsMap := make(map[int]*lightning_helpers.RebalanceRequests)
 	for _, rebalanceSetting := range rebalanceSettings {
@@ -1120,13 +1256,8 @@ func processRebalanceRun(
 		}
 	}
 	var activeChannelIds []int
-	var responses []lightning_helpers.RebalanceResponse
-	for nodeId, requests := range requestsMap {
-		if cache.GetCurrentNodeServiceState(services_helpers.LndServiceRebalanceService, nodeId).Status != services_helpers.Active {
-			return nil, errors.New(fmt.Sprintf("Rebalance service is not active for nodeId: %v", nodeId))
-		}
-		reqs := *requests
-		for _, req := range reqs.Requests {
+	for _, requests := range requestsMap {
+		for _, req := range requests.Requests {
 			if req.IncomingChannelId != 0 {
 				activeChannelIds = append(activeChannelIds, req.IncomingChannelId)
 			}
@@ -1134,7 +1265,58 @@ func processRebalanceRun(
 				activeChannelIds = append(activeChannelIds, req.OutgoingChannelId)
 			}
 		}
+	}
+	if dryRun != nil {
+		for nodeId, requests := range requestsMap {
+			for _, req := range requests.Requests {
+				dryRun.RebalanceRequests = append(dryRun.RebalanceRequests, WorkflowDryRunRebalance{
+					WorkflowVersionNodeId: workflowNode.WorkflowVersionNodeId,
+					NodeId:                nodeId,
+					RebalanceRequest:      req,
+				})
+			}
+		}
+		if len(eventChannelIds) == 0 {
+			dryRun.RebalanceCancellations = append(dryRun.RebalanceCancellations, WorkflowDryRunRebalanceCancellation{
+				WorkflowVersionNodeId: workflowNode.WorkflowVersionNodeId,
+				ExceptChannelIds:      activeChannelIds,
+			})
+		} else {
+			for _, eventChannelId := range eventChannelIds {
+				if !slices.Contains(activeChannelIds, eventChannelId) {
+					channelId := eventChannelId
+					dryRun.RebalanceCancellations = append(dryRun.RebalanceCancellations, WorkflowDryRunRebalanceCancellation{
+						WorkflowVersionNodeId: workflowNode.WorkflowVersionNodeId,
+						ChannelId:             &channelId,
+					})
+				}
+			}
+		}
+		return nil, nil
+	}
+	var responses []lightning_helpers.RebalanceResponse
+	for nodeId, requests := range requestsMap {
+		rebalanceServiceType := services_helpers.GetRebalanceServiceType(cache.GetNodeConnectionDetails(nodeId).Implementation)
+		if cache.GetCurrentNodeServiceState(rebalanceServiceType, nodeId).Status != services_helpers.Active {
+			return nil, errors.New(fmt.Sprintf("Rebalance service is not active for nodeId: %v", nodeId))
+		}
+		reqs := *requests
 		resp := RebalanceRequests(context.Background(), db, reqs, nodeId)
+		for _, rebalanceResponse := range resp {
+			var rebalanceError error
+			if rebalanceResponse.Error != "" {
+				rebalanceError = errors.New(rebalanceResponse.Error)
+			}
+			channelId := rebalanceResponse.Request.IncomingChannelId
+			if channelId == 0 {
+				channelId = rebalanceResponse.Request.OutgoingChannelId
+			}
+			// Workflows run repeatedly so only the started and updated rebalancers end up in the audit log
+			if rebalanceResponse.Changed {
+				audit.AddWorkflowAuditLog(db, workflowNode.WorkflowVersionNodeId, workflowNode.Name,
+					"workflow rebalance", nodeId, channelId, rebalanceResponse.Request, rebalanceError)
+			}
+		}
 		responses = append(responses, resp...)
 	}
 	if len(eventChannelIds) == 0 {
@@ -1154,7 +1336,9 @@ func processRebalanceRun(
 func processRoutingPolicyConfigurator(
 	channelId channelIdType,
 	inputsByChannelId map[channelIdType]map[workflow_helpers.WorkflowParameterLabel]string,
-	workflowNode WorkflowNode) (ChannelPolicyConfiguration, error) {
+	workflowNode WorkflowNode,
+	channel map[string]any,
+	variables workflowVariables) (ChannelPolicyConfiguration, error) {
 
 	var channelPolicyInputConfiguration ChannelPolicyConfiguration
 	channelPolicyInputConfigurationString, exists := inputsByChannelId[channelId][workflow_helpers.WorkflowParameterLabelRoutingPolicySettings]
@@ -1185,6 +1369,22 @@ func processRoutingPolicyConfigurator(
 	if channelPolicyConfiguration.TimeLockDelta != nil {
 		channelPolicyInputConfiguration.TimeLockDelta = channelPolicyConfiguration.TimeLockDelta
 	}
+	if channelPolicyConfiguration.hasFormulas() {
+		if channel == nil {
+			return ChannelPolicyConfiguration{}, errors.New(fmt.Sprintf("Channel data missing for channelId: %v and WorkflowVersionNodeId: %v",
+				channelId, workflowNode.WorkflowVersionNodeId))
+		}
+		channelPolicyInputConfiguration.FeeRateFormula = channelPolicyConfiguration.FeeRateFormula
+		channelPolicyInputConfiguration.FeeBaseFormula = channelPolicyConfiguration.FeeBaseFormula
+		channelPolicyInputConfiguration.MinHtlcFormula = channelPolicyConfiguration.MinHtlcFormula
+		channelPolicyInputConfiguration.MaxHtlcFormula = channelPolicyConfiguration.MaxHtlcFormula
+		channelPolicyInputConfiguration.TimeLockDeltaFormula = channelPolicyConfiguration.TimeLockDeltaFormula
+		channelPolicyInputConfiguration, err = applyPolicyFormulas(channelPolicyInputConfiguration, getPolicyFormulaValues(channel, variables))
+		if err != nil {
+			return ChannelPolicyConfiguration{}, errors.Wrapf(err, "Calculating formulas for channelId: %v and WorkflowVersionNodeId: %v",
+				channelId, workflowNode.WorkflowVersionNodeId)
+		}
+	}
 	channelPolicyInputConfiguration.ChannelId = int(channelId)
 	return channelPolicyInputConfiguration, nil
 }
@@ -1193,7 +1393,8 @@ func processRoutingPolicyRun(db *sqlx.DB,
 	routingPolicySettings ChannelPolicyConfiguration,
 	workflowNode WorkflowNode,
 	reference string,
-	triggerType workflow_helpers.WorkflowNodeType) error {
+	triggerType workflow_helpers.WorkflowNodeType,
+	dryRun *WorkflowDryRun) error {
 
 	torqNodeIds := cache.GetAllTorqNodeIds()
 	channelSettings := cache.GetChannelSettingByChannelId(routingPolicySettings.ChannelId)
@@ -1227,14 +1428,28 @@ func processRoutingPolicyRun(db *sqlx.DB,
 		TimeLockDelta:    routingPolicySettings.TimeLockDelta,
 	}
 
+	if dryRun != nil {
+		dryRun.RoutingPolicyUpdates = append(dryRun.RoutingPolicyUpdates, WorkflowDryRunRoutingPolicy{
+			WorkflowVersionNodeId:      workflowNode.WorkflowVersionNodeId,
+			RoutingPolicyUpdateRequest: request,
+		})
+		return nil
+	}
+
+	// Workflows run repeatedly so only the changes of the routing policy end up in the audit log
+	containsUpdates := routingPolicyUpdateContainsUpdates(request)
 	_, err := lightning.SetRoutingPolicy(request)
+	if containsUpdates {
+		audit.AddWorkflowAuditLog(db, workflowNode.WorkflowVersionNodeId, workflowNode.Name,
+			"workflow routingPolicyUpdate", nodeId, request.ChannelId, request, err)
+	}
 	if err != nil {
 		log.Error().Err(err).Msgf("Workflow Trigger Fired for WorkflowVersionNodeId: %v", workflowNode.WorkflowVersionNodeId)
 	}
 	return nil
 }
 
-func addOrRemoveTags(db *sqlx.DB, linkedChannelIds []int, workflowNode WorkflowNode) error {
+func addOrRemoveTags(db *sqlx.DB, linkedChannelIds []int, workflowNode WorkflowNode, dryRun *WorkflowDryRun) error {
 	var params TagParameters
 	err := json.Unmarshal([]byte(workflowNode.Parameters), &params)
 	if err != nil {
@@ -1250,7 +1465,21 @@ func addOrRemoveTags(db *sqlx.DB, linkedChannelIds []int, workflowNode WorkflowN
 			if tag.TagId == 0 {
 				continue
 			}
+			if dryRun != nil {
+				dryRun.TagChanges = append(dryRun.TagChanges, WorkflowDryRunTagChange{
+					WorkflowVersionNodeId: workflowNode.WorkflowVersionNodeId,
+					Remove:                true,
+					TagId:                 tag.TagId,
+					ChannelId:             tag.ChannelId,
+					NodeId:                tag.NodeId,
+				})
+				continue
+			}
+			if !isTagged(tag) {
+				continue
+			}
 			err = tags.UntagEntity(db, tag)
+			addWorkflowTagAuditLog(db, workflowNode, "workflow removeTag", tag, err)
 			if err != nil {
 				return errors.Wrapf(err, "Failed to remove the tags for WorkflowVersionNodeId: %v tagIDd", workflowNode.WorkflowVersionNodeId, tagToDelete.Value)
 			}
@@ -1265,8 +1494,21 @@ func addOrRemoveTags(db *sqlx.DB, linkedChannelIds []int, workflowNode WorkflowN
 			if tag.TagId == 0 {
 				continue
 			}
+			if dryRun != nil {
+				dryRun.TagChanges = append(dryRun.TagChanges, WorkflowDryRunTagChange{
+					WorkflowVersionNodeId: workflowNode.WorkflowVersionNodeId,
+					TagId:                 tag.TagId,
+					ChannelId:             tag.ChannelId,
+					NodeId:                tag.NodeId,
+				})
+				continue
+			}
+			if isTagged(tag) {
+				continue
+			}
 			tag.CreatedByWorkflowVersionNodeId = &workflowNode.WorkflowVersionNodeId
 			err = tags.TagEntity(db, tag)
+			addWorkflowTagAuditLog(db, workflowNode, "workflow addTag", tag, err)
 			if err != nil {
 				return errors.Wrapf(err, "Failed to add the tags for WorkflowVersionNodeId: %v tagIDd", workflowNode.WorkflowVersionNodeId, tagtoAdd.Value)
 			}
@@ -1275,6 +1517,42 @@ func addOrRemoveTags(db *sqlx.DB, linkedChannelIds []int, workflowNode WorkflowN
 	return nil
 }
 
+func isTagged(tag tags.TagEntityRequest) bool {
+	if tag.ChannelId != nil {
+		return slices.Contains(cache.GetTagIdsByChannelId(*tag.ChannelId), tag.TagId)
+	}
+	if tag.NodeId != nil {
+		return slices.Contains(cache.GetTagIdsByNodeId(*tag.NodeId), tag.TagId)
+	}
+	return false
+}
+
+// routingPolicyUpdateContainsUpdates returns false when the request does not change the current routing policy
+func routingPolicyUpdateContainsUpdates(request lightning_helpers.RoutingPolicyUpdateRequest) bool {
+	channelState := cache.GetChannelState(request.NodeId, request.ChannelId, true)
+	if channelState == nil {
+		return true
+	}
+	return (request.TimeLockDelta != nil && *request.TimeLockDelta != channelState.LocalTimeLockDelta) ||
+		(request.FeeRateMilliMsat != nil && *request.FeeRateMilliMsat != channelState.LocalFeeRateMilliMsat) ||
+		(request.FeeBaseMsat != nil && *request.FeeBaseMsat != channelState.LocalFeeBaseMsat) ||
+		(request.MinHtlcMsat != nil && *request.MinHtlcMsat != channelState.LocalMinHtlcMsat) ||
+		(request.MaxHtlcMsat != nil && *request.MaxHtlcMsat != channelState.LocalMaxHtlcMsat)
+}
+
+func addWorkflowTagAuditLog(db *sqlx.DB, workflowNode WorkflowNode, action string, tag tags.TagEntityRequest, err error) {
+	nodeId := 0
+	if tag.NodeId != nil {
+		nodeId = *tag.NodeId
+	}
+	channelId := 0
+	if tag.ChannelId != nil {
+		channelId = *tag.ChannelId
+	}
+	audit.AddWorkflowAuditLog(db, workflowNode.WorkflowVersionNodeId, workflowNode.Name, action,
+		nodeId, channelId, map[string]int{"tagId": tag.TagId}, err)
+}
+
 func getTagEntityRequest(channelId int, tagId int, params TagParameters, torqNodeIds []int, processedNodeIds []int) ([]int, tags.TagEntityRequest) {
 	if params.ApplyTo == "nodes" {
 		channelSettings := cache.GetChannelSettingByChannelId(channelId)
@@ -1313,8 +1591,11 @@ func filterChannelBalanceEventChannelIds(params FilterClauses, linkedChannelIds
 	return resultChannelIds
 }
 
-func FilterChannelBodyChannelIds(params FilterClauses, linkedChannels []channels.ChannelBody) []int {
-	filteredChannelIds := extractChannelIds(ApplyFilters(params, ChannelBodyToMap(linkedChannels)))
+func FilterChannelBodyChannelIds(params FilterClauses,
+	linkedChannels []channels.ChannelBody,
+	channelFlows map[int]ChannelFlow) []int {
+
+	filteredChannelIds := extractChannelIds(ApplyFilters(params, ChannelBodyWithFlowToMap(linkedChannels, channelFlows)))
 	log.Trace().Msgf("Filtering applied to %d of %d channels", len(filteredChannelIds), len(linkedChannels))
 	return filteredChannelIds
 }
@@ -1389,15 +1670,28 @@ func ChannelBodyToMap(structs []channels.ChannelBody) []map[string]interface{} {
 	return maps
 }
 
+// ChannelBodyWithFlowToMap adds the ChannelFlow fields to the channel data (channels without forwards get zero values)
+func ChannelBodyWithFlowToMap(structs []channels.ChannelBody, channelFlows map[int]ChannelFlow) []map[string]interface{} {
+	maps := ChannelBodyToMap(structs)
+	for i := range maps {
+		addStructFieldsToMap(maps[i], channelFlows[structs[i].ChannelId])
+	}
+	return maps
+}
+
 func AddStructToMap(maps []map[string]interface{}, data any) []map[string]interface{} {
+	mapValue := make(map[string]interface{})
+	addStructFieldsToMap(mapValue, data)
+	maps = append(maps, mapValue)
+	return maps
+}
+
+func addStructFieldsToMap(mapValue map[string]interface{}, data any) {
 	structValue := reflect.ValueOf(data)
 	structType := reflect.TypeOf(data)
-	mapValue := make(map[string]interface{})
 
 	for i := 0; i < structValue.NumField(); i++ {
 		field := structType.Field(i)
 		mapValue[strings.ToLower(field.Name)] = structValue.Field(i).Interface()
 	}
-	maps = append(maps, mapValue)
-	return maps
 }
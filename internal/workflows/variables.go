@@ -0,0 +1,164 @@
+package workflows
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+	"golang.org/x/exp/slices"
+
+	"github.com/lncapital/torq/internal/channels"
+	"github.com/lncapital/torq/internal/core"
+	"github.com/lncapital/torq/internal/workflow_helpers"
+)
+
+// workflowVariableReferenceKey marks a node parameter that refers to a workflow variable
+// i.e. {"feeRateMilliMsat": {"$variable": "maximumFeeRate"}}
+const workflowVariableReferenceKey = "$variable"
+
+// workflowVariables are scoped to a single run of the workflow (shared by all stages of that run)
+type workflowVariables map[string]any
+
+func getSetVariableConfiguration(workflowNode WorkflowNode) (string, any, error) {
+	var params SetVariableConfiguration
+	err := json.Unmarshal([]byte(workflowNode.Parameters), &params)
+	if err != nil {
+		return "", nil, errors.Wrapf(err, "Parsing parameters for WorkflowVersionNodeId: %v", workflowNode.WorkflowVersionNodeId)
+	}
+	if params.VariableName == "" {
+		return "", nil, errors.New(fmt.Sprintf("Variable name missing for WorkflowVersionNodeId: %v",
+			workflowNode.WorkflowVersionNodeId))
+	}
+	switch {
+	case params.ValueString != nil:
+		return params.VariableName, *params.ValueString, nil
+	case params.ValueNumber != nil:
+		return params.VariableName, *params.ValueNumber, nil
+	}
+	return "", nil, errors.New(fmt.Sprintf("Variable value missing for WorkflowVersionNodeId: %v",
+		workflowNode.WorkflowVersionNodeId))
+}
+
+// resolveWorkflowVariables replaces the variable references in the node parameters with the values of the variables.
+// It also returns the names of the referenced variables that are not set (yet).
+func resolveWorkflowVariables(parameters string, variables workflowVariables) (string, []string, error) {
+	if !strings.Contains(parameters, workflowVariableReferenceKey) {
+		return parameters, nil, nil
+	}
+	var unmarshalledParameters any
+	err := json.Unmarshal([]byte(parameters), &unmarshalledParameters)
+	if err != nil {
+		return "", nil, errors.Wrap(err, "Parsing parameters")
+	}
+	var missingVariableNames []string
+	resolvedParameters := resolveWorkflowVariableReferences(unmarshalledParameters, variables, &missingVariableNames)
+	if len(missingVariableNames) != 0 {
+		return parameters, missingVariableNames, nil
+	}
+	marshalledParameters, err := json.Marshal(resolvedParameters)
+	if err != nil {
+		return "", nil, errors.Wrap(err, "Marshalling resolved parameters")
+	}
+	return string(marshalledParameters), nil, nil
+}
+
+func resolveWorkflowVariableReferences(value any, variables workflowVariables, missingVariableNames *[]string) any {
+	switch typedValue := value.(type) {
+	case map[string]any:
+		variableName, isReference := typedValue[workflowVariableReferenceKey].(string)
+		if isReference && len(typedValue) == 1 {
+			variableValue, exists := variables[variableName]
+			if !exists {
+				if !slices.Contains(*missingVariableNames, variableName) {
+					*missingVariableNames = append(*missingVariableNames, variableName)
+				}
+				return value
+			}
+			return variableValue
+		}
+		for key, item := range typedValue {
+			typedValue[key] = resolveWorkflowVariableReferences(item, variables, missingVariableNames)
+		}
+		return typedValue
+	case []any:
+		for index, item := range typedValue {
+			typedValue[index] = resolveWorkflowVariableReferences(item, variables, missingVariableNames)
+		}
+		return typedValue
+	}
+	return value
+}
+
+// isWorkflowVariablePending returns true when one of the variables is set by a node of the stage that still has to run.
+func isWorkflowVariablePending(variableNames []string,
+	workflowNodes []WorkflowNode,
+	workflowNodeStatus map[int]core.Status) bool {
+
+	for _, workflowNode := range workflowNodes {
+		if workflowNode.Type != workflow_helpers.WorkflowNodeSetVariable || workflowNode.Status != WorkflowNodeActive {
+			continue
+		}
+		if workflowNodeStatus[workflowNode.WorkflowVersionNodeId] == core.Active {
+			continue
+		}
+		var params SetVariableConfiguration
+		err := json.Unmarshal([]byte(workflowNode.Parameters), &params)
+		if err != nil {
+			continue
+		}
+		if slices.Contains(variableNames, params.VariableName) {
+			return true
+		}
+	}
+	return false
+}
+
+func getFilterOnVariableFunction(params FilterOnVariableConfiguration) (FilterFunc, error) {
+	filterFunc, exists := GetFilterFunctions()[FilterCategoryType(params.Category)][params.FuncName]
+	if !exists {
+		return nil, errors.New(fmt.Sprintf("Unknown filter function %v for category %v", params.FuncName, params.Category))
+	}
+	return filterFunc, nil
+}
+
+// filterOnVariable returns the channels that pass the comparison with the variable and the channels that don't.
+// Without a ChannelKey either all linkedChannels pass or none do.
+func filterOnVariable(params FilterOnVariableConfiguration,
+	variableValue any,
+	linkedChannelIds []int,
+	linkedChannels []channels.ChannelBody,
+	channelFlows map[int]ChannelFlow) ([]int, []int, error) {
+
+	filterFunc, err := getFilterOnVariableFunction(params)
+	if err != nil {
+		return nil, nil, err
+	}
+	if params.ChannelKey == "" {
+		if filterFunc(map[string]any{"variable": variableValue}, "variable", params.Value) {
+			return linkedChannelIds, []int{}, nil
+		}
+		return []int{}, linkedChannelIds, nil
+	}
+	channelKey := strings.ToLower(params.ChannelKey)
+	filteredChannelIds := []int{}
+	for _, linkedChannel := range ChannelBodyWithFlowToMap(linkedChannels, channelFlows) {
+		if _, exists := linkedChannel[channelKey]; !exists {
+			return nil, nil, errors.New(fmt.Sprintf("Unknown channel key %v", params.ChannelKey))
+		}
+		channelId, ok := linkedChannel["channelid"].(int)
+		if !ok || !slices.Contains(linkedChannelIds, channelId) {
+			continue
+		}
+		if filterFunc(linkedChannel, channelKey, variableValue) {
+			filteredChannelIds = append(filteredChannelIds, channelId)
+		}
+	}
+	nonMatchingChannelIds := []int{}
+	for _, linkedChannelId := range linkedChannelIds {
+		if !slices.Contains(filteredChannelIds, linkedChannelId) {
+			nonMatchingChannelIds = append(nonMatchingChannelIds, linkedChannelId)
+		}
+	}
+	return filteredChannelIds, nonMatchingChannelIds, nil
+}
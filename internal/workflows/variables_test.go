@@ -0,0 +1,169 @@
+package workflows
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/lncapital/torq/internal/channels"
+	"github.com/lncapital/torq/internal/core"
+	"github.com/lncapital/torq/internal/workflow_helpers"
+	"github.com/lncapital/torq/testutil"
+)
+
+func TestResolveWorkflowVariables(t *testing.T) {
+	variables := workflowVariables{"maximumFeeRate": float64(1500), "alias": "carol"}
+	testCases := []struct {
+		name        string
+		parameters  string
+		want        string
+		wantMissing []string
+		wantErr     bool
+	}{
+		{
+			name:       "no references",
+			parameters: `{"feeRateMilliMsat":100}`,
+			want:       `{"feeRateMilliMsat":100}`,
+		},
+		{
+			name:       "number reference",
+			parameters: `{"feeBaseMsat":1000,"feeRateMilliMsat":{"$variable":"maximumFeeRate"}}`,
+			want:       `{"feeBaseMsat":1000,"feeRateMilliMsat":1500}`,
+		},
+		{
+			name: "nested filter reference",
+			parameters: `{"$and":[{"$filter":{"funcName":"like","key":"peerAlias","category":"string",` +
+				`"parameter":{"$variable":"alias"}}}]}`,
+			want: `{"$and":[{"$filter":{"category":"string","funcName":"like","key":"peerAlias",` +
+				`"parameter":"carol"}}]}`,
+		},
+		{
+			name:        "missing variable",
+			parameters:  `{"feeRateMilliMsat":{"$variable":"minimumFeeRate"},"feeBaseMsat":{"$variable":"minimumFeeRate"}}`,
+			want:        `{"feeRateMilliMsat":{"$variable":"minimumFeeRate"},"feeBaseMsat":{"$variable":"minimumFeeRate"}}`,
+			wantMissing: []string{"minimumFeeRate"},
+		},
+		{
+			name:       "invalid json",
+			parameters: `{"$variable":`,
+			wantErr:    true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, gotMissing, err := resolveWorkflowVariables(tc.parameters, variables)
+			if (err != nil) != tc.wantErr {
+				testutil.Errorf(t, "resolveWorkflowVariables() error = %v, wantErr %v", err, tc.wantErr)
+				return
+			}
+			if got != tc.want || !reflect.DeepEqual(gotMissing, tc.wantMissing) {
+				testutil.Errorf(t, "resolveWorkflowVariables() = %v, %v, want %v, %v", got, gotMissing, tc.want, tc.wantMissing)
+				return
+			}
+			testutil.Successf(t, "resolveWorkflowVariables() = %v, %v", got, gotMissing)
+		})
+	}
+}
+
+func TestFilterOnVariable(t *testing.T) {
+	linkedChannelIds := []int{1, 2, 3}
+	linkedChannels := []channels.ChannelBody{
+		{ChannelId: 1, FeeRateMilliMsat: 100},
+		{ChannelId: 2, FeeRateMilliMsat: 1500},
+		{ChannelId: 3, FeeRateMilliMsat: 2000},
+	}
+	channelFlows := map[int]ChannelFlow{1: {Revenue7d: 250}, 3: {Revenue7d: 50}}
+	testCases := []struct {
+		name          string
+		params        FilterOnVariableConfiguration
+		variableValue any
+		want          []int
+		wantNonMatch  []int
+		wantErr       bool
+	}{
+		{
+			name:          "branch taken",
+			params:        FilterOnVariableConfiguration{Category: "number", FuncName: "gte", Value: 1000},
+			variableValue: float64(1500),
+			want:          []int{1, 2, 3},
+			wantNonMatch:  []int{},
+		},
+		{
+			name:          "branch not taken",
+			params:        FilterOnVariableConfiguration{Category: "number", FuncName: "lt", Value: 1000},
+			variableValue: float64(1500),
+			want:          []int{},
+			wantNonMatch:  []int{1, 2, 3},
+		},
+		{
+			name:          "channels above the threshold",
+			params:        FilterOnVariableConfiguration{Category: "number", FuncName: "gte", ChannelKey: "feeRateMilliMsat"},
+			variableValue: float64(1500),
+			want:          []int{2, 3},
+			wantNonMatch:  []int{1},
+		},
+		{
+			name:          "channels below the revenue threshold",
+			params:        FilterOnVariableConfiguration{Category: "number", FuncName: "lt", ChannelKey: "revenue7d"},
+			variableValue: float64(100),
+			want:          []int{2, 3},
+			wantNonMatch:  []int{1},
+		},
+		{
+			name:          "unknown channel key",
+			params:        FilterOnVariableConfiguration{Category: "number", FuncName: "gte", ChannelKey: "unknown"},
+			variableValue: float64(1500),
+			wantErr:       true,
+		},
+		{
+			name:          "unknown function",
+			params:        FilterOnVariableConfiguration{Category: "number", FuncName: "like"},
+			variableValue: float64(1500),
+			wantErr:       true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, gotNonMatch, err := filterOnVariable(tc.params, tc.variableValue, linkedChannelIds, linkedChannels, channelFlows)
+			if (err != nil) != tc.wantErr {
+				testutil.Errorf(t, "filterOnVariable() error = %v, wantErr %v", err, tc.wantErr)
+				return
+			}
+			if !tc.wantErr && (!reflect.DeepEqual(got, tc.want) || !reflect.DeepEqual(gotNonMatch, tc.wantNonMatch)) {
+				testutil.Errorf(t, "filterOnVariable() = %v, %v, want %v, %v", got, gotNonMatch, tc.want, tc.wantNonMatch)
+				return
+			}
+			testutil.Successf(t, "filterOnVariable() = %v, %v, want %v, %v", got, gotNonMatch, tc.want, tc.wantNonMatch)
+		})
+	}
+}
+
+func TestIsWorkflowVariablePending(t *testing.T) {
+	workflowNodes := []WorkflowNode{
+		{WorkflowVersionNodeId: 1, Status: WorkflowNodeActive, Type: workflow_helpers.WorkflowNodeSetVariable,
+			Parameters: `{"variableName":"maximumFeeRate","valueNumber":1500}`},
+		{WorkflowVersionNodeId: 2, Status: WorkflowNodeActive, Type: workflow_helpers.WorkflowNodeSetVariable,
+			Parameters: `{"variableName":"alias","valueString":"carol"}`},
+		{WorkflowVersionNodeId: 3, Status: WorkflowNodeInactive, Type: workflow_helpers.WorkflowNodeSetVariable,
+			Parameters: `{"variableName":"disabled","valueNumber":1}`},
+	}
+	workflowNodeStatus := map[int]core.Status{2: core.Active}
+	testCases := []struct {
+		variableName string
+		want         bool
+	}{
+		{variableName: "maximumFeeRate", want: true},
+		{variableName: "alias", want: false},
+		{variableName: "disabled", want: false},
+		{variableName: "unknown", want: false},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.variableName, func(t *testing.T) {
+			got := isWorkflowVariablePending([]string{tc.variableName}, workflowNodes, workflowNodeStatus)
+			if got != tc.want {
+				testutil.Errorf(t, "isWorkflowVariablePending() = %v, want %v", got, tc.want)
+				return
+			}
+			testutil.Successf(t, "isWorkflowVariablePending() = %v, want %v", got, tc.want)
+		})
+	}
+}
@@ -89,6 +89,8 @@ type WorkflowToTrigger struct {
 	Type                  int `json:"type"`
 	WorkflowId            int `json:"workflowId"`
 	WorkflowVersionNodeId int `json:"workflowVersionNodeId"`
+	// DryRun processes the workflow immediately and returns the actions it would execute without executing them
+	DryRun bool `json:"dryRun"`
 }
 
 type IntervalTriggerParameters struct {
@@ -110,6 +112,30 @@ type ChannelBalanceEventFilterConfiguration struct {
 	FilterClauses       FilterClauses `json:"filterClauses"`
 }
 
+type SetVariableConfiguration struct {
+	VariableName string `json:"variableName"`
+	// Either ValueString or ValueNumber is populated
+	ValueString *string  `json:"valueString"`
+	ValueNumber *float64 `json:"valueNumber"`
+}
+
+type FilterOnVariableConfiguration struct {
+	VariableName string `json:"variableName"`
+	// Category and FuncName select the filter function i.e. number and gte (see GetFilterFunctions)
+	Category string `json:"category"`
+	FuncName string `json:"funcName"`
+	// When ChannelKey is populated the channels are filtered by comparing their ChannelKey value to the variable.
+	// Otherwise the variable is compared to Value and all channels pass (or none do) depending on the outcome.
+	ChannelKey string `json:"channelKey"`
+	Value      any    `json:"value"`
+}
+
+type SendNotificationConfiguration struct {
+	// MessageTemplate is a text/template rendered for every incoming channel (see notificationTemplateData)
+	MessageTemplate  string `json:"messageTemplate"`
+	CommunicationIds []int  `json:"communicationIds"`
+}
+
 type ChannelPolicyConfiguration struct {
 	ChannelId        int     `json:"channelId"`
 	TimeLockDelta    *uint32 `json:"timeLockDelta"`
@@ -117,6 +143,13 @@ type ChannelPolicyConfiguration struct {
 	MaxHtlcMsat      *uint64 `json:"maxHtlcMsat"`
 	FeeBaseMsat      *int64  `json:"feeBaseMsat"`
 	FeeRateMilliMsat *int64  `json:"feeRateMilliMsat"`
+	// The formulas are calculated per channel on each run and take precedence over the fixed values above.
+	// Amounts are in sats (fee rate in ppm) i.e. clamp(500 * (1 - local_ratio), 50, 2000)
+	FeeRateFormula       *string `json:"feeRateFormula,omitempty"`
+	FeeBaseFormula       *string `json:"feeBaseFormula,omitempty"`
+	MinHtlcFormula       *string `json:"minHtlcFormula,omitempty"`
+	MaxHtlcFormula       *string `json:"maxHtlcFormula,omitempty"`
+	TimeLockDeltaFormula *string `json:"timeLockDeltaFormula,omitempty"`
 }
 
 type RebalanceConfiguration struct {
@@ -238,6 +271,8 @@ type WorkflowVersionNodeLog struct {
 	WorkflowVersionNodeId           int       `json:"workflowVersionNodeId" db:"workflow_version_node_id"`
 	TriggeringWorkflowVersionNodeId *int      `json:"triggeringWorkflowVersionNodeId" db:"triggering_workflow_version_node_id"`
 	CreatedOn                       time.Time `json:"createdOn" db:"created_on"`
+	WorkflowRunId                   *int      `json:"workflowRunId" db:"workflow_run_id"`
+	DurationMilliseconds            *int64    `json:"durationMilliseconds" db:"duration_milliseconds"`
 }
 
 type WorkflowNode struct {
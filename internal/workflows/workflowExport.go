@@ -0,0 +1,609 @@
+package workflows
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/rs/zerolog/log"
+
+	"github.com/lncapital/torq/internal/cache"
+	"github.com/lncapital/torq/internal/database"
+	"github.com/lncapital/torq/internal/workflow_helpers"
+)
+
+const workflowExportFormatVersion = 1
+
+// Channels, nodes and tags are instance specific so the exported node parameters refer to them symbolically
+// i.e. {"incomingChannelIds": [{"$channel": "800000x1x0"}]}, {"addedTags": [{"label": "Sink", "value": {"$tag": "Sink"}}]}
+// and {"$filter": {"key": "peerNodeId", "parameter": {"$node": "02abc..."}}}
+const (
+	workflowExportChannelReferenceKey = "$channel"
+	workflowExportNodeReferenceKey    = "$node"
+	workflowExportTagReferenceKey     = "$tag"
+)
+
+// WorkflowExport is a self-contained copy of a workflow version that can be imported in another Torq instance.
+type WorkflowExport struct {
+	FormatVersion int       `json:"formatVersion"`
+	Name          string    `json:"name"`
+	ExportedOn    time.Time `json:"exportedOn"`
+	// Channels (short channel id or channel point), PublicKeys (of the nodes) and Tags (name)
+	// are the symbolic references used by the nodes
+	Channels   []string             `json:"channels"`
+	PublicKeys []string             `json:"publicKeys"`
+	Tags       []string             `json:"tags"`
+	Nodes      []WorkflowExportNode `json:"nodes"`
+	Links      []WorkflowExportLink `json:"links"`
+}
+
+type WorkflowExportNode struct {
+	// Reference is only used to link the nodes within the document
+	Reference          int                               `json:"reference"`
+	Name               string                            `json:"name"`
+	Stage              int                               `json:"stage"`
+	Status             WorkflowNodeStatus                `json:"status"`
+	Type               workflow_helpers.WorkflowNodeType `json:"type"`
+	Parameters         any                               `json:"parameters"`
+	VisibilitySettings WorkflowNodeVisibilitySettings    `json:"visibilitySettings"`
+}
+
+type WorkflowExportLink struct {
+	Name               string                                    `json:"name"`
+	ParentReference    int                                       `json:"parentReference"`
+	ParentOutput       workflow_helpers.WorkflowParameterLabel   `json:"parentOutput"`
+	ChildReference     int                                       `json:"childReference"`
+	ChildInput         workflow_helpers.WorkflowParameterLabel   `json:"childInput"`
+	VisibilitySettings WorkflowVersionNodeLinkVisibilitySettings `json:"visibilitySettings"`
+}
+
+type WorkflowImportRequest struct {
+	Workflow WorkflowExport `json:"workflow"`
+	// Name overrides the name of the exported workflow
+	Name *string `json:"name"`
+	// ChannelMapping, NodeMapping and TagMapping remap the symbolic references to channelIds, nodeIds and tagIds
+	// of this instance. Unmapped references are looked up by short channel id (or channel point), public key and tag name.
+	ChannelMapping map[string]int `json:"channelMapping"`
+	NodeMapping    map[string]int `json:"nodeMapping"`
+	TagMapping     map[string]int `json:"tagMapping"`
+}
+
+// workflowReferenceMapper converts the channel, node and tag references in the node parameters
+type workflowReferenceMapper struct {
+	channel func(value any) (any, error)
+	node    func(value any) (any, error)
+	tag     func(value any) (any, error)
+	// tagLabel is optional and updates the label of the tags of the AddTag and RemoveTag nodes
+	tagLabel func(value any) string
+}
+
+// mapWorkflowReferences walks the node parameters and converts the channels of the rebalance nodes,
+// the tags of the tag nodes and the tags, channels and nodes of the filters.
+func mapWorkflowReferences(value any, mapper workflowReferenceMapper) (any, error) {
+	switch typedValue := value.(type) {
+	case map[string]any:
+		for key, item := range typedValue {
+			var err error
+			switch key {
+			case "incomingChannelIds", "outgoingChannelIds":
+				typedValue[key], err = mapWorkflowReferenceArray(item, mapper.channel)
+			case "addedTags", "removedTags":
+				typedValue[key], err = mapWorkflowTagInfos(item, mapper)
+			case "$filter":
+				filter, ok := item.(map[string]any)
+				if !ok {
+					break
+				}
+				switch {
+				case filter["category"] == string(FilterCategoryTypeTag):
+					filter["parameter"], err = mapWorkflowReferenceArray(filter["parameter"], mapper.tag)
+				case filter["key"] == "channelId":
+					filter["parameter"], err = mapWorkflowReferenceValue(filter["parameter"], mapper.channel)
+				case filter["key"] == "nodeId" || filter["key"] == "peerNodeId":
+					filter["parameter"], err = mapWorkflowReferenceValue(filter["parameter"], mapper.node)
+				}
+			default:
+				typedValue[key], err = mapWorkflowReferences(item, mapper)
+			}
+			if err != nil {
+				return nil, err
+			}
+		}
+		return typedValue, nil
+	case []any:
+		for index, item := range typedValue {
+			mappedItem, err := mapWorkflowReferences(item, mapper)
+			if err != nil {
+				return nil, err
+			}
+			typedValue[index] = mappedItem
+		}
+		return typedValue, nil
+	}
+	return value, nil
+}
+
+func mapWorkflowReferenceArray(value any, mapReference func(value any) (any, error)) (any, error) {
+	items, ok := value.([]any)
+	if !ok {
+		return value, nil
+	}
+	for index, item := range items {
+		mappedItem, err := mapReference(item)
+		if err != nil {
+			return nil, err
+		}
+		items[index] = mappedItem
+	}
+	return items, nil
+}
+
+// mapWorkflowReferenceValue converts a single reference or an array of references (i.e. the parameter of a filter)
+func mapWorkflowReferenceValue(value any, mapReference func(value any) (any, error)) (any, error) {
+	switch value.(type) {
+	case nil:
+		return value, nil
+	case []any:
+		return mapWorkflowReferenceArray(value, mapReference)
+	}
+	return mapReference(value)
+}
+
+func mapWorkflowTagInfos(value any, mapper workflowReferenceMapper) (any, error) {
+	tagInfos, ok := value.([]any)
+	if !ok {
+		return value, nil
+	}
+	for _, tagInfo := range tagInfos {
+		typedTagInfo, ok := tagInfo.(map[string]any)
+		if !ok {
+			continue
+		}
+		mappedValue, err := mapper.tag(typedTagInfo["value"])
+		if err != nil {
+			return nil, err
+		}
+		typedTagInfo["value"] = mappedValue
+		if mapper.tagLabel != nil {
+			typedTagInfo["label"] = mapper.tagLabel(mappedValue)
+		}
+	}
+	return tagInfos, nil
+}
+
+func getWorkflowReferenceId(value any) (int, bool) {
+	number, ok := value.(float64)
+	if !ok || number != float64(int(number)) {
+		return 0, false
+	}
+	return int(number), true
+}
+
+func getWorkflowSymbolicReference(value any, referenceKey string) (string, bool) {
+	typedValue, ok := value.(map[string]any)
+	if !ok || len(typedValue) != 1 {
+		return "", false
+	}
+	reference, ok := typedValue[referenceKey].(string)
+	return reference, ok && reference != ""
+}
+
+func getTagNamesById(db *sqlx.DB) (map[int]string, error) {
+	rows, err := db.Queryx(`SELECT tag_id, name FROM tag;`)
+	if err != nil {
+		return nil, errors.Wrap(err, database.SqlExecutionError)
+	}
+	defer rows.Close()
+	tagNames := make(map[int]string)
+	for rows.Next() {
+		var tagId int
+		var name string
+		err = rows.Scan(&tagId, &name)
+		if err != nil {
+			return nil, errors.Wrap(err, database.SqlScanResulSetError)
+		}
+		tagNames[tagId] = name
+	}
+	return tagNames, nil
+}
+
+func getChannelReference(channelId int) (string, error) {
+	channelSettings := cache.GetChannelSettingByChannelId(channelId)
+	if channelSettings.ShortChannelId != nil && *channelSettings.ShortChannelId != "" {
+		return *channelSettings.ShortChannelId, nil
+	}
+	if channelSettings.FundingTransactionHash != nil && channelSettings.FundingOutputIndex != nil {
+		return fmt.Sprintf("%v:%v", *channelSettings.FundingTransactionHash, *channelSettings.FundingOutputIndex), nil
+	}
+	return "", errors.New(fmt.Sprintf("No short channel id or channel point found for channelId: %v", channelId))
+}
+
+func getNodeReference(nodeId int) (string, error) {
+	publicKey := cache.GetNodeSettingsByNodeId(nodeId).PublicKey
+	if publicKey == "" {
+		return "", errors.New(fmt.Sprintf("No public key found for nodeId: %v", nodeId))
+	}
+	return publicKey, nil
+}
+
+// getNodeIdByReference returns 0 when the public key is unknown or exists for multiple chains/networks
+func getNodeIdByReference(db *sqlx.DB, publicKey string) int {
+	var nodeIds []int
+	err := db.Select(&nodeIds, `SELECT node_id FROM node WHERE public_key=$1;`, publicKey)
+	if err != nil {
+		log.Error().Err(err).Msgf("Obtaining the nodeId for publicKey: %v", publicKey)
+		return 0
+	}
+	if len(nodeIds) != 1 {
+		return 0
+	}
+	return nodeIds[0]
+}
+
+func getChannelIdByReference(channelReference string) int {
+	if strings.Contains(channelReference, ":") {
+		return cache.GetChannelIdByChannelPoint(channelReference)
+	}
+	return cache.GetChannelIdByShortChannelId(&channelReference)
+}
+
+func exportWorkflow(db *sqlx.DB, workflowId int, version *int) (WorkflowExport, error) {
+	workflow, err := GetWorkflow(db, workflowId)
+	if err != nil {
+		return WorkflowExport{}, errors.Wrapf(err, "Obtaining workflow for workflowId: %v", workflowId)
+	}
+	if workflow.WorkflowId == 0 {
+		return WorkflowExport{}, errors.New(fmt.Sprintf("Unknown workflowId: %v", workflowId))
+	}
+
+	var workflowVersion WorkflowVersion
+	if version == nil {
+		err = db.Get(&workflowVersion, `SELECT * FROM workflow_version WHERE workflow_id=$1 ORDER BY version DESC LIMIT 1;`, workflowId)
+		if err != nil {
+			return WorkflowExport{}, errors.Wrap(err, database.SqlExecutionError)
+		}
+	} else {
+		workflowVersion, err = GetWorkflowVersion(db, workflowId, *version)
+		if err != nil {
+			return WorkflowExport{}, errors.Wrapf(err, "Obtaining workflow version %v for workflowId: %v", *version, workflowId)
+		}
+		if workflowVersion.WorkflowVersionId == 0 {
+			return WorkflowExport{}, errors.New(fmt.Sprintf("Unknown version %v for workflowId: %v", *version, workflowId))
+		}
+	}
+
+	nodes, err := GetWorkflowNodes(db, workflowVersion.WorkflowVersionId, workflowId, workflowVersion.Version)
+	if err != nil {
+		return WorkflowExport{}, errors.Wrapf(err, "Obtaining nodes for workflowVersionId: %v", workflowVersion.WorkflowVersionId)
+	}
+	links, err := GetWorkflowVersionNodeLinks(db, workflowVersion.WorkflowVersionId)
+	if err != nil {
+		return WorkflowExport{}, errors.Wrapf(err, "Obtaining links for workflowVersionId: %v", workflowVersion.WorkflowVersionId)
+	}
+	tagNames, err := getTagNamesById(db)
+	if err != nil {
+		return WorkflowExport{}, errors.Wrap(err, "Obtaining tags")
+	}
+	return createWorkflowExport(workflow.Name, nodes, links, getChannelReference, getNodeReference, tagNames)
+}
+
+func createWorkflowExport(name string,
+	nodes []WorkflowVersionNode,
+	links []WorkflowVersionNodeLink,
+	channelReference func(channelId int) (string, error),
+	nodeReference func(nodeId int) (string, error),
+	tagNames map[int]string) (WorkflowExport, error) {
+
+	channelReferences := make(map[string]bool)
+	nodeReferences := make(map[string]bool)
+	tagReferences := make(map[string]bool)
+	mapper := workflowReferenceMapper{
+		channel: func(value any) (any, error) {
+			channelId, ok := getWorkflowReferenceId(value)
+			if !ok {
+				return nil, errors.New(fmt.Sprintf("Invalid channelId: %v", value))
+			}
+			reference, err := channelReference(channelId)
+			if err != nil {
+				return nil, err
+			}
+			channelReferences[reference] = true
+			return map[string]any{workflowExportChannelReferenceKey: reference}, nil
+		},
+		node: func(value any) (any, error) {
+			nodeId, ok := getWorkflowReferenceId(value)
+			if !ok {
+				return nil, errors.New(fmt.Sprintf("Invalid nodeId: %v", value))
+			}
+			reference, err := nodeReference(nodeId)
+			if err != nil {
+				return nil, err
+			}
+			nodeReferences[reference] = true
+			return map[string]any{workflowExportNodeReferenceKey: reference}, nil
+		},
+		tag: func(value any) (any, error) {
+			tagId, ok := getWorkflowReferenceId(value)
+			if !ok {
+				return nil, errors.New(fmt.Sprintf("Invalid tagId: %v", value))
+			}
+			tagName, exists := tagNames[tagId]
+			if !exists {
+				return nil, errors.New(fmt.Sprintf("Unknown tagId: %v", tagId))
+			}
+			tagReferences[tagName] = true
+			return map[string]any{workflowExportTagReferenceKey: tagName}, nil
+		},
+	}
+
+	workflowExport := WorkflowExport{
+		FormatVersion: workflowExportFormatVersion,
+		Name:          name,
+		ExportedOn:    time.Now().UTC(),
+		Nodes:         []WorkflowExportNode{},
+		Links:         []WorkflowExportLink{},
+	}
+	// The nodes are sorted so that exporting the same version twice results in the same document
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i].WorkflowVersionNodeId < nodes[j].WorkflowVersionNodeId })
+	referencesByWorkflowVersionNodeId := make(map[int]int)
+	for index, node := range nodes {
+		parameters, err := mapWorkflowReferences(node.Parameters, mapper)
+		if err != nil {
+			return WorkflowExport{}, errors.Wrapf(err, "Exporting parameters for workflowVersionNodeId: %v", node.WorkflowVersionNodeId)
+		}
+		referencesByWorkflowVersionNodeId[node.WorkflowVersionNodeId] = index + 1
+		workflowExport.Nodes = append(workflowExport.Nodes, WorkflowExportNode{
+			Reference:          index + 1,
+			Name:               node.Name,
+			Stage:              node.Stage,
+			Status:             node.Status,
+			Type:               node.Type,
+			Parameters:         parameters,
+			VisibilitySettings: node.VisibilitySettings,
+		})
+	}
+	for _, link := range links {
+		parentReference, parentExists := referencesByWorkflowVersionNodeId[link.ParentWorkflowVersionNodeId]
+		childReference, childExists := referencesByWorkflowVersionNodeId[link.ChildWorkflowVersionNodeId]
+		if !parentExists || !childExists {
+			// links to deleted nodes
+			continue
+		}
+		workflowExport.Links = append(workflowExport.Links, WorkflowExportLink{
+			Name:               link.Name,
+			ParentReference:    parentReference,
+			ParentOutput:       link.ParentOutput,
+			ChildReference:     childReference,
+			ChildInput:         link.ChildInput,
+			VisibilitySettings: link.VisibilitySettings,
+		})
+	}
+	workflowExport.Channels = getSortedWorkflowReferences(channelReferences)
+	workflowExport.PublicKeys = getSortedWorkflowReferences(nodeReferences)
+	workflowExport.Tags = getSortedWorkflowReferences(tagReferences)
+	return workflowExport, nil
+}
+
+func getSortedWorkflowReferences(references map[string]bool) []string {
+	sortedReferences := make([]string, 0, len(references))
+	for reference := range references {
+		sortedReferences = append(sortedReferences, reference)
+	}
+	sort.Strings(sortedReferences)
+	return sortedReferences
+}
+
+// resolveWorkflowImport validates the document and converts the symbolic references of the node parameters.
+func resolveWorkflowImport(req WorkflowImportRequest,
+	channelIdByReference func(channelReference string) int,
+	nodeIdByReference func(publicKey string) int,
+	tagNames map[int]string) ([]WorkflowExportNode, error) {
+
+	if req.Workflow.FormatVersion != workflowExportFormatVersion {
+		return nil, errors.New(fmt.Sprintf("Unsupported format version: %v", req.Workflow.FormatVersion))
+	}
+	tagIdsByName := make(map[string]int)
+	for tagId, tagName := range tagNames {
+		tagIdsByName[tagName] = tagId
+	}
+
+	var unresolvedChannels []string
+	var unresolvedNodes []string
+	var unresolvedTags []string
+	mapper := workflowReferenceMapper{
+		channel: func(value any) (any, error) {
+			reference, ok := getWorkflowSymbolicReference(value, workflowExportChannelReferenceKey)
+			if !ok {
+				return nil, errors.New(fmt.Sprintf("Channel reference expected instead of: %v", value))
+			}
+			channelId, exists := req.ChannelMapping[reference]
+			if !exists {
+				channelId = channelIdByReference(reference)
+			}
+			if channelId == 0 {
+				unresolvedChannels = append(unresolvedChannels, reference)
+			}
+			return channelId, nil
+		},
+		node: func(value any) (any, error) {
+			reference, ok := getWorkflowSymbolicReference(value, workflowExportNodeReferenceKey)
+			if !ok {
+				return nil, errors.New(fmt.Sprintf("Node reference expected instead of: %v", value))
+			}
+			nodeId, exists := req.NodeMapping[reference]
+			if !exists {
+				nodeId = nodeIdByReference(reference)
+			}
+			if nodeId == 0 {
+				unresolvedNodes = append(unresolvedNodes, reference)
+			}
+			return nodeId, nil
+		},
+		tag: func(value any) (any, error) {
+			reference, ok := getWorkflowSymbolicReference(value, workflowExportTagReferenceKey)
+			if !ok {
+				return nil, errors.New(fmt.Sprintf("Tag reference expected instead of: %v", value))
+			}
+			tagId, exists := req.TagMapping[reference]
+			if !exists {
+				tagId = tagIdsByName[reference]
+			}
+			if _, known := tagNames[tagId]; !known {
+				unresolvedTags = append(unresolvedTags, reference)
+			}
+			return tagId, nil
+		},
+		tagLabel: func(value any) string {
+			tagId, _ := value.(int)
+			return tagNames[tagId]
+		},
+	}
+
+	references := make(map[int]bool)
+	nodes := make([]WorkflowExportNode, 0, len(req.Workflow.Nodes))
+	for _, node := range req.Workflow.Nodes {
+		if references[node.Reference] {
+			return nil, errors.New(fmt.Sprintf("Duplicate node reference: %v", node.Reference))
+		}
+		references[node.Reference] = true
+		if _, exists := workflow_helpers.GetWorkflowNodes()[node.Type]; !exists {
+			return nil, errors.New(fmt.Sprintf("Unknown node type %v for node reference: %v", node.Type, node.Reference))
+		}
+		parameters, err := mapWorkflowReferences(node.Parameters, mapper)
+		if err != nil {
+			return nil, errors.Wrapf(err, "Importing parameters for node reference: %v", node.Reference)
+		}
+		node.Parameters = parameters
+		nodes = append(nodes, node)
+	}
+	for _, link := range req.Workflow.Links {
+		if !references[link.ParentReference] || !references[link.ChildReference] {
+			return nil, errors.New(fmt.Sprintf("Link %v refers to an unknown node", link.Name))
+		}
+	}
+	if len(unresolvedChannels) != 0 || len(unresolvedNodes) != 0 || len(unresolvedTags) != 0 {
+		return nil, errors.New(fmt.Sprintf(
+			"Unresolved references (add them to the mapping) channels: %v nodes: %v tags: %v",
+			unresolvedChannels, unresolvedNodes, unresolvedTags))
+	}
+	return nodes, nil
+}
+
+// importWorkflow creates a new (inactive) workflow from the resolved nodes (see resolveWorkflowImport).
+func importWorkflow(db *sqlx.DB, name string, nodes []WorkflowExportNode, links []WorkflowExportLink) (WorkflowVersion, error) {
+	tx, err := db.Beginx()
+	if err != nil {
+		return WorkflowVersion{}, errors.Wrap(err, database.SqlBeginTransactionError)
+	}
+	workflowVersion, err := insertWorkflowImport(tx, name, nodes, links)
+	if err != nil {
+		rollbackErr := tx.Rollback()
+		if rollbackErr != nil {
+			log.Error().Err(rollbackErr).Msgf("Failed to rollback the workflow import.")
+		}
+		return WorkflowVersion{}, err
+	}
+	err = tx.Commit()
+	if err != nil {
+		return WorkflowVersion{}, errors.Wrap(err, database.SqlCommitTransactionError)
+	}
+	return workflowVersion, nil
+}
+
+func insertWorkflowImport(tx *sqlx.Tx,
+	name string,
+	nodes []WorkflowExportNode,
+	links []WorkflowExportLink) (WorkflowVersion, error) {
+
+	now := time.Now().UTC()
+	var workflowId int
+	err := tx.QueryRowx(`INSERT INTO workflow (name, status, created_on, updated_on)
+			VALUES ($1, $2, $3, $4) RETURNING workflow_id;`,
+		name, Inactive, now, now).Scan(&workflowId)
+	if err != nil {
+		if err, ok := err.(*pq.Error); ok {
+			if err.Code == "23505" {
+				return WorkflowVersion{}, database.SqlUniqueConstraintError
+			}
+		}
+		return WorkflowVersion{}, errors.Wrap(err, database.SqlExecutionError)
+	}
+
+	workflowVersion := WorkflowVersion{
+		Name:       "Initial Version",
+		Version:    1,
+		Status:     Active,
+		WorkflowId: workflowId,
+		CreatedOn:  now,
+		UpdateOn:   now,
+	}
+	err = tx.QueryRowx(`INSERT INTO workflow_version (name, version, status, workflow_id, created_on, updated_on)
+			VALUES ($1, $2, $3, $4, $5, $6) RETURNING workflow_version_id;`,
+		workflowVersion.Name,
+		workflowVersion.Version,
+		workflowVersion.Status,
+		workflowVersion.WorkflowId,
+		workflowVersion.CreatedOn,
+		workflowVersion.UpdateOn).Scan(&workflowVersion.WorkflowVersionId)
+	if err != nil {
+		return WorkflowVersion{}, errors.Wrap(err, database.SqlExecutionError)
+	}
+
+	workflowVersionNodeIds := make(map[int]int)
+	for _, node := range nodes {
+		parameters, err := json.Marshal(node.Parameters)
+		if err != nil {
+			return WorkflowVersion{}, errors.Wrapf(err, "JSON Marshaling Parameters for node reference: %v", node.Reference)
+		}
+		visibilitySettings, err := json.Marshal(node.VisibilitySettings)
+		if err != nil {
+			return WorkflowVersion{}, errors.Wrapf(err, "JSON Marshaling VisibilitySettings for node reference: %v", node.Reference)
+		}
+		var workflowVersionNodeId int
+		err = tx.QueryRowx(`INSERT INTO workflow_version_node
+				(name, stage, status, type, parameters, visibility_settings, workflow_version_id, created_on, updated_on)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9) RETURNING workflow_version_node_id;`,
+			node.Name,
+			node.Stage,
+			node.Status,
+			node.Type,
+			parameters,
+			visibilitySettings,
+			workflowVersion.WorkflowVersionId,
+			now,
+			now).Scan(&workflowVersionNodeId)
+		if err != nil {
+			return WorkflowVersion{}, errors.Wrapf(err, "Adding node reference: %v", node.Reference)
+		}
+		workflowVersionNodeIds[node.Reference] = workflowVersionNodeId
+	}
+
+	for _, link := range links {
+		visibilitySettings, err := json.Marshal(link.VisibilitySettings)
+		if err != nil {
+			return WorkflowVersion{}, errors.Wrapf(err, "JSON Marshaling VisibilitySettings for link: %v", link.Name)
+		}
+		_, err = tx.Exec(`INSERT INTO workflow_version_node_link
+				(name, visibility_settings, parent_output, parent_workflow_version_node_id,
+				 child_input, child_workflow_version_node_id, workflow_version_id, created_on, updated_on)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9);`,
+			link.Name,
+			visibilitySettings,
+			link.ParentOutput,
+			workflowVersionNodeIds[link.ParentReference],
+			link.ChildInput,
+			workflowVersionNodeIds[link.ChildReference],
+			workflowVersion.WorkflowVersionId,
+			now,
+			now)
+		if err != nil {
+			return WorkflowVersion{}, errors.Wrapf(err, "Adding link: %v", link.Name)
+		}
+	}
+	return workflowVersion, nil
+}
@@ -0,0 +1,206 @@
+package workflows
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/cockroachdb/errors"
+
+	"github.com/lncapital/torq/internal/workflow_helpers"
+	"github.com/lncapital/torq/testutil"
+)
+
+func unmarshalTestParameters(t *testing.T, parameters string) any {
+	var unmarshalledParameters any
+	err := json.Unmarshal([]byte(parameters), &unmarshalledParameters)
+	if err != nil {
+		testutil.Fatalf(t, "Unmarshalling parameters: %v", err)
+	}
+	return unmarshalledParameters
+}
+
+func TestWorkflowExportImport(t *testing.T) {
+	nodes := []WorkflowVersionNode{
+		{WorkflowVersionNodeId: 12, Name: "Tag", Stage: 1, Type: workflow_helpers.WorkflowNodeAddTag,
+			Parameters: unmarshalTestParameters(t, `{"applyTo":"channel","addedTags":[{"label":"Sink","value":5}]}`)},
+		{WorkflowVersionNodeId: 10, Name: "Trigger", Stage: 1, Type: workflow_helpers.WorkflowTrigger,
+			Parameters: unmarshalTestParameters(t, `{}`)},
+		{WorkflowVersionNodeId: 11, Name: "Filter", Stage: 1, Type: workflow_helpers.WorkflowNodeChannelFilter,
+			Parameters: unmarshalTestParameters(t, `{"$and":[{"$filter":{"funcName":"any","key":"tags","category":"tag","parameter":[5,6]}},`+
+				`{"$filter":{"funcName":"gte","key":"capacity","category":"number","parameter":5}},`+
+				`{"$filter":{"funcName":"eq","key":"channelId","category":"number","parameter":2}},`+
+				`{"$filter":{"funcName":"neq","key":"peerNodeId","category":"number","parameter":3}}]}`)},
+		{WorkflowVersionNodeId: 13, Name: "Rebalance", Stage: 2, Type: workflow_helpers.WorkflowNodeRebalanceConfigurator,
+			Parameters: unmarshalTestParameters(t, `{"focus":"incomingChannels","incomingChannelIds":[1],"outgoingChannelIds":[2,1]}`)},
+	}
+	links := []WorkflowVersionNodeLink{
+		{Name: "link", ParentWorkflowVersionNodeId: 10, ParentOutput: "channels", ChildWorkflowVersionNodeId: 11, ChildInput: "channels"},
+		{Name: "deleted", ParentWorkflowVersionNodeId: 99, ParentOutput: "channels", ChildWorkflowVersionNodeId: 11, ChildInput: "channels"},
+	}
+	shortChannelIds := map[int]string{1: "800000x1x0", 2: "800000x2x1"}
+	channelReference := func(channelId int) (string, error) {
+		shortChannelId, exists := shortChannelIds[channelId]
+		if !exists {
+			return "", errors.New("unknown channel")
+		}
+		return shortChannelId, nil
+	}
+
+	publicKeys := map[int]string{3: "02peer"}
+	nodeReference := func(nodeId int) (string, error) {
+		publicKey, exists := publicKeys[nodeId]
+		if !exists {
+			return "", errors.New("unknown node")
+		}
+		return publicKey, nil
+	}
+
+	workflowExport, err := createWorkflowExport("Autofee", nodes, links, channelReference, nodeReference,
+		map[int]string{5: "Sink", 6: "Source"})
+	if err != nil {
+		testutil.Fatalf(t, "createWorkflowExport() error = %v", err)
+	}
+	marshalledExport, err := json.Marshal(workflowExport)
+	if err != nil {
+		testutil.Fatalf(t, "Marshalling the export: %v", err)
+	}
+	if len(workflowExport.Nodes) != 4 || len(workflowExport.Links) != 1 ||
+		workflowExport.Nodes[0].Name != "Trigger" || workflowExport.Links[0].ParentReference != 1 ||
+		len(workflowExport.Channels) != 2 || len(workflowExport.PublicKeys) != 1 || len(workflowExport.Tags) != 2 ||
+		strings.Contains(string(marshalledExport), `"parameter":2`) ||
+		strings.Contains(string(marshalledExport), `"parameter":3`) {
+		testutil.Errorf(t, "createWorkflowExport() = %v", string(marshalledExport))
+		return
+	}
+	testutil.Successf(t, "createWorkflowExport() = %v", string(marshalledExport))
+
+	// the other instance knows the same channels under different channelIds and the tags under different tagIds
+	channelIds := map[string]int{"800000x1x0": 31, "800000x2x1": 32}
+	channelIdByReference := func(channelReference string) int { return channelIds[channelReference] }
+	nodeIdByReference := func(publicKey string) int { return map[string]int{"02peer": 33}[publicKey] }
+	tagNames := map[int]string{7: "Sink", 8: "Source", 9: "Drain"}
+
+	testCases := []struct {
+		name           string
+		channelMapping map[string]int
+		nodeMapping    map[string]int
+		tagMapping     map[string]int
+		wantFilter     string
+		wantRebalance  string
+		wantTag        string
+		wantErr        bool
+	}{
+		{
+			name: "lookup",
+			wantFilter: `{"$and":[{"$filter":{"category":"tag","funcName":"any","key":"tags","parameter":[7,8]}},` +
+				`{"$filter":{"category":"number","funcName":"gte","key":"capacity","parameter":5}},` +
+				`{"$filter":{"category":"number","funcName":"eq","key":"channelId","parameter":32}},` +
+				`{"$filter":{"category":"number","funcName":"neq","key":"peerNodeId","parameter":33}}]}`,
+			wantRebalance: `{"focus":"incomingChannels","incomingChannelIds":[31],"outgoingChannelIds":[32,31]}`,
+			wantTag:       `{"addedTags":[{"label":"Sink","value":7}],"applyTo":"channel"}`,
+		},
+		{
+			name:           "mapping",
+			channelMapping: map[string]int{"800000x2x1": 40},
+			nodeMapping:    map[string]int{"02peer": 41},
+			tagMapping:     map[string]int{"Sink": 9},
+			wantFilter: `{"$and":[{"$filter":{"category":"tag","funcName":"any","key":"tags","parameter":[9,8]}},` +
+				`{"$filter":{"category":"number","funcName":"gte","key":"capacity","parameter":5}},` +
+				`{"$filter":{"category":"number","funcName":"eq","key":"channelId","parameter":40}},` +
+				`{"$filter":{"category":"number","funcName":"neq","key":"peerNodeId","parameter":41}}]}`,
+			wantRebalance: `{"focus":"incomingChannels","incomingChannelIds":[31],"outgoingChannelIds":[40,31]}`,
+			wantTag:       `{"addedTags":[{"label":"Drain","value":9}],"applyTo":"channel"}`,
+		},
+		{
+			name:       "unknown tag mapping",
+			tagMapping: map[string]int{"Sink": 100},
+			wantErr:    true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var req WorkflowImportRequest
+			err := json.Unmarshal(marshalledExport, &req.Workflow)
+			if err != nil {
+				testutil.Fatalf(t, "Unmarshalling the export: %v", err)
+			}
+			req.ChannelMapping = tc.channelMapping
+			req.NodeMapping = tc.nodeMapping
+			req.TagMapping = tc.tagMapping
+			got, err := resolveWorkflowImport(req, channelIdByReference, nodeIdByReference, tagNames)
+			if (err != nil) != tc.wantErr {
+				testutil.Errorf(t, "resolveWorkflowImport() error = %v, wantErr %v", err, tc.wantErr)
+				return
+			}
+			if tc.wantErr {
+				testutil.Successf(t, "resolveWorkflowImport() error = %v", err)
+				return
+			}
+			gotParameters := make(map[string]string)
+			for _, node := range got {
+				marshalledParameters, err := json.Marshal(node.Parameters)
+				if err != nil {
+					testutil.Fatalf(t, "Marshalling parameters: %v", err)
+				}
+				gotParameters[node.Name] = string(marshalledParameters)
+			}
+			if gotParameters["Filter"] != tc.wantFilter || gotParameters["Rebalance"] != tc.wantRebalance ||
+				gotParameters["Tag"] != tc.wantTag {
+				testutil.Errorf(t, "resolveWorkflowImport() = %v", gotParameters)
+				return
+			}
+			testutil.Successf(t, "resolveWorkflowImport() = %v", gotParameters)
+		})
+	}
+}
+
+func TestResolveWorkflowImportValidation(t *testing.T) {
+	testCases := []struct {
+		name     string
+		workflow WorkflowExport
+	}{
+		{
+			name:     "unsupported format version",
+			workflow: WorkflowExport{FormatVersion: 2},
+		},
+		{
+			name: "unknown node type",
+			workflow: WorkflowExport{FormatVersion: workflowExportFormatVersion, Nodes: []WorkflowExportNode{
+				{Reference: 1, Type: workflow_helpers.WorkflowNodeType(-5)},
+			}},
+		},
+		{
+			name: "link to unknown node",
+			workflow: WorkflowExport{FormatVersion: workflowExportFormatVersion,
+				Nodes: []WorkflowExportNode{{Reference: 1, Type: workflow_helpers.WorkflowTrigger}},
+				Links: []WorkflowExportLink{{ParentReference: 1, ChildReference: 2}},
+			},
+		},
+		{
+			name: "channel id instead of reference",
+			workflow: WorkflowExport{FormatVersion: workflowExportFormatVersion, Nodes: []WorkflowExportNode{
+				{Reference: 1, Type: workflow_helpers.WorkflowNodeRebalanceConfigurator,
+					Parameters: map[string]any{"incomingChannelIds": []any{float64(1)}}},
+			}},
+		},
+		{
+			name: "node id instead of reference",
+			workflow: WorkflowExport{FormatVersion: workflowExportFormatVersion, Nodes: []WorkflowExportNode{
+				{Reference: 1, Type: workflow_helpers.WorkflowNodeChannelFilter,
+					Parameters: map[string]any{"$filter": map[string]any{"key": "peerNodeId", "parameter": float64(3)}}},
+			}},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := resolveWorkflowImport(WorkflowImportRequest{Workflow: tc.workflow},
+				func(string) int { return 0 }, func(string) int { return 0 }, map[int]string{})
+			if err == nil {
+				testutil.Errorf(t, "resolveWorkflowImport() expected an error")
+				return
+			}
+			testutil.Successf(t, "resolveWorkflowImport() error = %v", err)
+		})
+	}
+}
@@ -0,0 +1,149 @@
+package workflows
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/jmoiron/sqlx"
+	"github.com/rs/zerolog/log"
+
+	"github.com/lncapital/torq/internal/database"
+)
+
+type WorkflowRunStatus int
+
+const (
+	WorkflowRunRunning = WorkflowRunStatus(iota)
+	WorkflowRunSucceeded
+	WorkflowRunFailed
+)
+
+// WorkflowRun is a single execution of a workflow version (all stages) started by a trigger.
+type WorkflowRun struct {
+	WorkflowRunId                   int               `json:"workflowRunId" db:"workflow_run_id"`
+	WorkflowId                      int               `json:"workflowId" db:"workflow_id"`
+	WorkflowVersionId               int               `json:"workflowVersionId" db:"workflow_version_id"`
+	TriggeringWorkflowVersionNodeId int               `json:"triggeringWorkflowVersionNodeId" db:"triggering_workflow_version_node_id"`
+	TriggerReference                string            `json:"triggerReference" db:"trigger_reference"`
+	DryRun                          bool              `json:"dryRun" db:"dry_run"`
+	Status                          WorkflowRunStatus `json:"status" db:"status"`
+	ErrorData                       string            `json:"errorData" db:"error_data"`
+	StartedOn                       time.Time         `json:"startedOn" db:"started_on"`
+	EndedOn                         *time.Time        `json:"endedOn" db:"ended_on"`
+}
+
+// WorkflowRunNode is the log of a node of the run (Name and Stage are missing when the node was removed)
+type WorkflowRunNode struct {
+	Name  *string `json:"name" db:"name"`
+	Stage *int    `json:"stage" db:"stage"`
+	WorkflowVersionNodeLog
+}
+
+type WorkflowRunTrace struct {
+	WorkflowRun
+	Nodes []WorkflowRunNode `json:"nodes"`
+}
+
+// startWorkflowRun returns 0 when the run could not be stored (the workflow itself still runs)
+func startWorkflowRun(db *sqlx.DB, workflowTriggerNode WorkflowNode, reference string, dryRun bool) int {
+	var workflowRunId int
+	err := db.QueryRowx(`
+		INSERT INTO workflow_run (workflow_id, workflow_version_id, triggering_workflow_version_node_id,
+			trigger_reference, dry_run, status, error_data, started_on)
+		SELECT workflow_id, workflow_version_id, $2, $3, $4, $5, '', $6
+		FROM workflow_version
+		WHERE workflow_version_id=$1
+		RETURNING workflow_run_id;`,
+		workflowTriggerNode.WorkflowVersionId, workflowTriggerNode.WorkflowVersionNodeId, reference, dryRun,
+		WorkflowRunRunning, time.Now().UTC()).Scan(&workflowRunId)
+	if err != nil {
+		log.Error().Err(err).Msgf("Storing the workflow run for WorkflowVersionNodeId: %v", workflowTriggerNode.WorkflowVersionNodeId)
+		return 0
+	}
+	return workflowRunId
+}
+
+func endWorkflowRun(db *sqlx.DB, workflowRunId int, workflowError error) {
+	if workflowRunId == 0 {
+		return
+	}
+	status := WorkflowRunSucceeded
+	errorData := ""
+	if workflowError != nil {
+		status = WorkflowRunFailed
+		errorData = workflowError.Error()
+	}
+	_, err := db.Exec(`UPDATE workflow_run SET status=$1, error_data=$2, ended_on=$3 WHERE workflow_run_id=$4;`,
+		status, errorData, time.Now().UTC(), workflowRunId)
+	if err != nil {
+		log.Error().Err(err).Msgf("Storing the end of the workflow run for workflowRunId: %v", workflowRunId)
+	}
+}
+
+// addWorkflowRunNodeError adds the node that failed the run to the trace
+func addWorkflowRunNodeError(db *sqlx.DB,
+	workflowRunId int,
+	reference string,
+	workflowNode WorkflowNode,
+	workflowTriggerNode WorkflowNode,
+	startedOn time.Time,
+	workflowError error) {
+
+	durationMilliseconds := time.Since(startedOn).Milliseconds()
+	workflowVersionNodeLog := WorkflowVersionNodeLog{
+		TriggerReference:                reference,
+		InputData:                       "[]",
+		OutputData:                      "[]",
+		ErrorData:                       workflowError.Error(),
+		WorkflowVersionNodeId:           workflowNode.WorkflowVersionNodeId,
+		TriggeringWorkflowVersionNodeId: &workflowTriggerNode.WorkflowVersionNodeId,
+		DurationMilliseconds:            &durationMilliseconds,
+	}
+	if workflowRunId != 0 {
+		workflowVersionNodeLog.WorkflowRunId = &workflowRunId
+	}
+	_, err := addWorkflowVersionNodeLog(db, workflowVersionNodeLog)
+	if err != nil {
+		log.Error().Err(err).Msgf("Storing error log for WorkflowVersionNodeId: %v", workflowNode.WorkflowVersionNodeId)
+	}
+}
+
+func GetWorkflowRuns(db *sqlx.DB, workflowId int, from time.Time, to time.Time, maximumResultCount int) ([]WorkflowRun, error) {
+	workflowRuns := []WorkflowRun{}
+	err := db.Select(&workflowRuns, `
+		SELECT *
+		FROM workflow_run
+		WHERE workflow_id=$1 AND started_on>=$2 AND started_on<$3
+		ORDER BY started_on DESC
+		LIMIT $4;`, workflowId, from, to, maximumResultCount)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return []WorkflowRun{}, nil
+		}
+		return nil, errors.Wrap(err, database.SqlExecutionError)
+	}
+	return workflowRuns, nil
+}
+
+func GetWorkflowRunTrace(db *sqlx.DB, workflowRunId int) (WorkflowRunTrace, error) {
+	var workflowRunTrace WorkflowRunTrace
+	err := db.Get(&workflowRunTrace.WorkflowRun, `SELECT * FROM workflow_run WHERE workflow_run_id=$1;`, workflowRunId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return WorkflowRunTrace{}, nil
+		}
+		return WorkflowRunTrace{}, errors.Wrap(err, database.SqlExecutionError)
+	}
+	workflowRunTrace.Nodes = []WorkflowRunNode{}
+	err = db.Select(&workflowRunTrace.Nodes, `
+		SELECT wfvn.name, wfvn.stage, wfvnl.*
+		FROM workflow_version_node_log wfvnl
+		LEFT JOIN workflow_version_node wfvn ON wfvn.workflow_version_node_id=wfvnl.workflow_version_node_id
+		WHERE wfvnl.workflow_run_id=$1 AND wfvnl.created_on>=$2
+		ORDER BY wfvnl.created_on;`, workflowRunId, workflowRunTrace.StartedOn)
+	if err != nil {
+		return WorkflowRunTrace{}, errors.Wrap(err, database.SqlExecutionError)
+	}
+	return workflowRunTrace, nil
+}
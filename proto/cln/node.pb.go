@@ -1510,6 +1510,59 @@ func (FeeratesRequest_FeeratesStyle) EnumDescriptor() ([]byte, []int) {
 	return file_proto_cln_node_proto_rawDescGZIP(), []int{104, 0}
 }
 
+// MultiFundChannel.failed[].method
+type MultifundchannelFailed_MultifundchannelFailedMethod int32
+
+const (
+	MultifundchannelFailed_CONNECT              MultifundchannelFailed_MultifundchannelFailedMethod = 0
+	MultifundchannelFailed_OPENCHANNEL_INIT     MultifundchannelFailed_MultifundchannelFailedMethod = 1
+	MultifundchannelFailed_FUNDCHANNEL_START    MultifundchannelFailed_MultifundchannelFailedMethod = 2
+	MultifundchannelFailed_FUNDCHANNEL_COMPLETE MultifundchannelFailed_MultifundchannelFailedMethod = 3
+)
+
+// Enum value maps for MultifundchannelFailed_MultifundchannelFailedMethod.
+var (
+	MultifundchannelFailed_MultifundchannelFailedMethod_name = map[int32]string{
+		0: "CONNECT",
+		1: "OPENCHANNEL_INIT",
+		2: "FUNDCHANNEL_START",
+		3: "FUNDCHANNEL_COMPLETE",
+	}
+	MultifundchannelFailed_MultifundchannelFailedMethod_value = map[string]int32{
+		"CONNECT":              0,
+		"OPENCHANNEL_INIT":     1,
+		"FUNDCHANNEL_START":    2,
+		"FUNDCHANNEL_COMPLETE": 3,
+	}
+)
+
+func (x MultifundchannelFailed_MultifundchannelFailedMethod) Enum() *MultifundchannelFailed_MultifundchannelFailedMethod {
+	p := new(MultifundchannelFailed_MultifundchannelFailedMethod)
+	*p = x
+	return p
+}
+
+func (x MultifundchannelFailed_MultifundchannelFailedMethod) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (MultifundchannelFailed_MultifundchannelFailedMethod) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_cln_node_proto_enumTypes[28].Descriptor()
+}
+
+func (MultifundchannelFailed_MultifundchannelFailedMethod) Type() protoreflect.EnumType {
+	return &file_proto_cln_node_proto_enumTypes[28]
+}
+
+func (x MultifundchannelFailed_MultifundchannelFailedMethod) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use MultifundchannelFailed_MultifundchannelFailedMethod.Descriptor instead.
+func (MultifundchannelFailed_MultifundchannelFailedMethod) EnumDescriptor() ([]byte, []int) {
+	return file_proto_cln_node_proto_rawDescGZIP(), []int{115, 0}
+}
+
 // GetRoute.route[].style
 type GetrouteRoute_GetrouteRouteStyle int32
 
@@ -1538,11 +1591,11 @@ func (x GetrouteRoute_GetrouteRouteStyle) String() string {
 }
 
 func (GetrouteRoute_GetrouteRouteStyle) Descriptor() protoreflect.EnumDescriptor {
-	return file_proto_cln_node_proto_enumTypes[28].Descriptor()
+	return file_proto_cln_node_proto_enumTypes[29].Descriptor()
 }
 
 func (GetrouteRoute_GetrouteRouteStyle) Type() protoreflect.EnumType {
-	return &file_proto_cln_node_proto_enumTypes[28]
+	return &file_proto_cln_node_proto_enumTypes[29]
 }
 
 func (x GetrouteRoute_GetrouteRouteStyle) Number() protoreflect.EnumNumber {
@@ -1551,7 +1604,7 @@ func (x GetrouteRoute_GetrouteRouteStyle) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use GetrouteRoute_GetrouteRouteStyle.Descriptor instead.
 func (GetrouteRoute_GetrouteRouteStyle) EnumDescriptor() ([]byte, []int) {
-	return file_proto_cln_node_proto_rawDescGZIP(), []int{113, 0}
+	return file_proto_cln_node_proto_rawDescGZIP(), []int{119, 0}
 }
 
 // ListForwards.status
@@ -1591,11 +1644,11 @@ func (x ListforwardsRequest_ListforwardsStatus) String() string {
 }
 
 func (ListforwardsRequest_ListforwardsStatus) Descriptor() protoreflect.EnumDescriptor {
-	return file_proto_cln_node_proto_enumTypes[29].Descriptor()
+	return file_proto_cln_node_proto_enumTypes[30].Descriptor()
 }
 
 func (ListforwardsRequest_ListforwardsStatus) Type() protoreflect.EnumType {
-	return &file_proto_cln_node_proto_enumTypes[29]
+	return &file_proto_cln_node_proto_enumTypes[30]
 }
 
 func (x ListforwardsRequest_ListforwardsStatus) Number() protoreflect.EnumNumber {
@@ -1604,7 +1657,7 @@ func (x ListforwardsRequest_ListforwardsStatus) Number() protoreflect.EnumNumber
 
 // Deprecated: Use ListforwardsRequest_ListforwardsStatus.Descriptor instead.
 func (ListforwardsRequest_ListforwardsStatus) EnumDescriptor() ([]byte, []int) {
-	return file_proto_cln_node_proto_rawDescGZIP(), []int{114, 0}
+	return file_proto_cln_node_proto_rawDescGZIP(), []int{120, 0}
 }
 
 // ListForwards.forwards[].status
@@ -1644,11 +1697,11 @@ func (x ListforwardsForwards_ListforwardsForwardsStatus) String() string {
 }
 
 func (ListforwardsForwards_ListforwardsForwardsStatus) Descriptor() protoreflect.EnumDescriptor {
-	return file_proto_cln_node_proto_enumTypes[30].Descriptor()
+	return file_proto_cln_node_proto_enumTypes[31].Descriptor()
 }
 
 func (ListforwardsForwards_ListforwardsForwardsStatus) Type() protoreflect.EnumType {
-	return &file_proto_cln_node_proto_enumTypes[30]
+	return &file_proto_cln_node_proto_enumTypes[31]
 }
 
 func (x ListforwardsForwards_ListforwardsForwardsStatus) Number() protoreflect.EnumNumber {
@@ -1657,7 +1710,7 @@ func (x ListforwardsForwards_ListforwardsForwardsStatus) Number() protoreflect.E
 
 // Deprecated: Use ListforwardsForwards_ListforwardsForwardsStatus.Descriptor instead.
 func (ListforwardsForwards_ListforwardsForwardsStatus) EnumDescriptor() ([]byte, []int) {
-	return file_proto_cln_node_proto_rawDescGZIP(), []int{116, 0}
+	return file_proto_cln_node_proto_rawDescGZIP(), []int{122, 0}
 }
 
 // ListForwards.forwards[].style
@@ -1691,11 +1744,11 @@ func (x ListforwardsForwards_ListforwardsForwardsStyle) String() string {
 }
 
 func (ListforwardsForwards_ListforwardsForwardsStyle) Descriptor() protoreflect.EnumDescriptor {
-	return file_proto_cln_node_proto_enumTypes[31].Descriptor()
+	return file_proto_cln_node_proto_enumTypes[32].Descriptor()
 }
 
 func (ListforwardsForwards_ListforwardsForwardsStyle) Type() protoreflect.EnumType {
-	return &file_proto_cln_node_proto_enumTypes[31]
+	return &file_proto_cln_node_proto_enumTypes[32]
 }
 
 func (x ListforwardsForwards_ListforwardsForwardsStyle) Number() protoreflect.EnumNumber {
@@ -1704,7 +1757,7 @@ func (x ListforwardsForwards_ListforwardsForwardsStyle) Number() protoreflect.En
 
 // Deprecated: Use ListforwardsForwards_ListforwardsForwardsStyle.Descriptor instead.
 func (ListforwardsForwards_ListforwardsForwardsStyle) EnumDescriptor() ([]byte, []int) {
-	return file_proto_cln_node_proto_rawDescGZIP(), []int{116, 1}
+	return file_proto_cln_node_proto_rawDescGZIP(), []int{122, 1}
 }
 
 // ListPays.status
@@ -1741,11 +1794,11 @@ func (x ListpaysRequest_ListpaysStatus) String() string {
 }
 
 func (ListpaysRequest_ListpaysStatus) Descriptor() protoreflect.EnumDescriptor {
-	return file_proto_cln_node_proto_enumTypes[32].Descriptor()
+	return file_proto_cln_node_proto_enumTypes[33].Descriptor()
 }
 
 func (ListpaysRequest_ListpaysStatus) Type() protoreflect.EnumType {
-	return &file_proto_cln_node_proto_enumTypes[32]
+	return &file_proto_cln_node_proto_enumTypes[33]
 }
 
 func (x ListpaysRequest_ListpaysStatus) Number() protoreflect.EnumNumber {
@@ -1754,7 +1807,7 @@ func (x ListpaysRequest_ListpaysStatus) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use ListpaysRequest_ListpaysStatus.Descriptor instead.
 func (ListpaysRequest_ListpaysStatus) EnumDescriptor() ([]byte, []int) {
-	return file_proto_cln_node_proto_rawDescGZIP(), []int{117, 0}
+	return file_proto_cln_node_proto_rawDescGZIP(), []int{123, 0}
 }
 
 // ListPays.pays[].status
@@ -1791,11 +1844,11 @@ func (x ListpaysPays_ListpaysPaysStatus) String() string {
 }
 
 func (ListpaysPays_ListpaysPaysStatus) Descriptor() protoreflect.EnumDescriptor {
-	return file_proto_cln_node_proto_enumTypes[33].Descriptor()
+	return file_proto_cln_node_proto_enumTypes[34].Descriptor()
 }
 
 func (ListpaysPays_ListpaysPaysStatus) Type() protoreflect.EnumType {
-	return &file_proto_cln_node_proto_enumTypes[33]
+	return &file_proto_cln_node_proto_enumTypes[34]
 }
 
 func (x ListpaysPays_ListpaysPaysStatus) Number() protoreflect.EnumNumber {
@@ -1804,7 +1857,7 @@ func (x ListpaysPays_ListpaysPaysStatus) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use ListpaysPays_ListpaysPaysStatus.Descriptor instead.
 func (ListpaysPays_ListpaysPaysStatus) EnumDescriptor() ([]byte, []int) {
-	return file_proto_cln_node_proto_rawDescGZIP(), []int{119, 0}
+	return file_proto_cln_node_proto_rawDescGZIP(), []int{125, 0}
 }
 
 type GetinfoRequest struct {
@@ -10795,23 +10848,21 @@ func (x *FundchannelResponse) GetMindepth() uint32 {
 	return 0
 }
 
-type GetrouteRequest struct {
+type MultifundchannelRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Id          []byte   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	AmountMsat  *Amount  `protobuf:"bytes,9,opt,name=amount_msat,json=amountMsat,proto3" json:"amount_msat,omitempty"`
-	Riskfactor  uint64   `protobuf:"varint,3,opt,name=riskfactor,proto3" json:"riskfactor,omitempty"`
-	Cltv        *float64 `protobuf:"fixed64,4,opt,name=cltv,proto3,oneof" json:"cltv,omitempty"`
-	Fromid      []byte   `protobuf:"bytes,5,opt,name=fromid,proto3,oneof" json:"fromid,omitempty"`
-	Fuzzpercent *uint32  `protobuf:"varint,6,opt,name=fuzzpercent,proto3,oneof" json:"fuzzpercent,omitempty"`
-	Exclude     []string `protobuf:"bytes,7,rep,name=exclude,proto3" json:"exclude,omitempty"`
-	Maxhops     *uint32  `protobuf:"varint,8,opt,name=maxhops,proto3,oneof" json:"maxhops,omitempty"`
+	Destinations      []*MultifundchannelDestinations `protobuf:"bytes,1,rep,name=destinations,proto3" json:"destinations,omitempty"`
+	Feerate           *Feerate                        `protobuf:"bytes,2,opt,name=feerate,proto3,oneof" json:"feerate,omitempty"`
+	Minconf           *int64                          `protobuf:"zigzag64,3,opt,name=minconf,proto3,oneof" json:"minconf,omitempty"`
+	Utxos             []*Outpoint                     `protobuf:"bytes,4,rep,name=utxos,proto3" json:"utxos,omitempty"`
+	Minchannels       *int64                          `protobuf:"zigzag64,5,opt,name=minchannels,proto3,oneof" json:"minchannels,omitempty"`
+	CommitmentFeerate *Feerate                        `protobuf:"bytes,6,opt,name=commitment_feerate,json=commitmentFeerate,proto3,oneof" json:"commitment_feerate,omitempty"`
 }
 
-func (x *GetrouteRequest) Reset() {
-	*x = GetrouteRequest{}
+func (x *MultifundchannelRequest) Reset() {
+	*x = MultifundchannelRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_proto_cln_node_proto_msgTypes[111]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -10819,13 +10870,13 @@ func (x *GetrouteRequest) Reset() {
 	}
 }
 
-func (x *GetrouteRequest) String() string {
+func (x *MultifundchannelRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetrouteRequest) ProtoMessage() {}
+func (*MultifundchannelRequest) ProtoMessage() {}
 
-func (x *GetrouteRequest) ProtoReflect() protoreflect.Message {
+func (x *MultifundchannelRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_cln_node_proto_msgTypes[111]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -10837,77 +10888,71 @@ func (x *GetrouteRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetrouteRequest.ProtoReflect.Descriptor instead.
-func (*GetrouteRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use MultifundchannelRequest.ProtoReflect.Descriptor instead.
+func (*MultifundchannelRequest) Descriptor() ([]byte, []int) {
 	return file_proto_cln_node_proto_rawDescGZIP(), []int{111}
 }
 
-func (x *GetrouteRequest) GetId() []byte {
+func (x *MultifundchannelRequest) GetDestinations() []*MultifundchannelDestinations {
 	if x != nil {
-		return x.Id
+		return x.Destinations
 	}
 	return nil
 }
 
-func (x *GetrouteRequest) GetAmountMsat() *Amount {
+func (x *MultifundchannelRequest) GetFeerate() *Feerate {
 	if x != nil {
-		return x.AmountMsat
+		return x.Feerate
 	}
 	return nil
 }
 
-func (x *GetrouteRequest) GetRiskfactor() uint64 {
-	if x != nil {
-		return x.Riskfactor
-	}
-	return 0
-}
-
-func (x *GetrouteRequest) GetCltv() float64 {
-	if x != nil && x.Cltv != nil {
-		return *x.Cltv
+func (x *MultifundchannelRequest) GetMinconf() int64 {
+	if x != nil && x.Minconf != nil {
+		return *x.Minconf
 	}
 	return 0
 }
 
-func (x *GetrouteRequest) GetFromid() []byte {
+func (x *MultifundchannelRequest) GetUtxos() []*Outpoint {
 	if x != nil {
-		return x.Fromid
+		return x.Utxos
 	}
 	return nil
 }
 
-func (x *GetrouteRequest) GetFuzzpercent() uint32 {
-	if x != nil && x.Fuzzpercent != nil {
-		return *x.Fuzzpercent
+func (x *MultifundchannelRequest) GetMinchannels() int64 {
+	if x != nil && x.Minchannels != nil {
+		return *x.Minchannels
 	}
 	return 0
 }
 
-func (x *GetrouteRequest) GetExclude() []string {
+func (x *MultifundchannelRequest) GetCommitmentFeerate() *Feerate {
 	if x != nil {
-		return x.Exclude
+		return x.CommitmentFeerate
 	}
 	return nil
 }
 
-func (x *GetrouteRequest) GetMaxhops() uint32 {
-	if x != nil && x.Maxhops != nil {
-		return *x.Maxhops
-	}
-	return 0
-}
-
-type GetrouteResponse struct {
+type MultifundchannelDestinations struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Route []*GetrouteRoute `protobuf:"bytes,1,rep,name=route,proto3" json:"route,omitempty"`
+	Id           string       `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Amount       *AmountOrAll `protobuf:"bytes,2,opt,name=amount,proto3" json:"amount,omitempty"`
+	Announce     *bool        `protobuf:"varint,3,opt,name=announce,proto3,oneof" json:"announce,omitempty"`
+	PushMsat     *Amount      `protobuf:"bytes,4,opt,name=push_msat,json=pushMsat,proto3,oneof" json:"push_msat,omitempty"`
+	CloseTo      *string      `protobuf:"bytes,5,opt,name=close_to,json=closeTo,proto3,oneof" json:"close_to,omitempty"`
+	RequestAmt   *Amount      `protobuf:"bytes,6,opt,name=request_amt,json=requestAmt,proto3,oneof" json:"request_amt,omitempty"`
+	CompactLease *string      `protobuf:"bytes,7,opt,name=compact_lease,json=compactLease,proto3,oneof" json:"compact_lease,omitempty"`
+	Mindepth     *uint32      `protobuf:"varint,8,opt,name=mindepth,proto3,oneof" json:"mindepth,omitempty"`
+	Reserve      *Amount      `protobuf:"bytes,9,opt,name=reserve,proto3,oneof" json:"reserve,omitempty"`
 }
 
-func (x *GetrouteResponse) Reset() {
-	*x = GetrouteResponse{}
+func (x *MultifundchannelDestinations) Reset() {
+	*x = MultifundchannelDestinations{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_proto_cln_node_proto_msgTypes[112]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -10915,13 +10960,13 @@ func (x *GetrouteResponse) Reset() {
 	}
 }
 
-func (x *GetrouteResponse) String() string {
+func (x *MultifundchannelDestinations) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetrouteResponse) ProtoMessage() {}
+func (*MultifundchannelDestinations) ProtoMessage() {}
 
-func (x *GetrouteResponse) ProtoReflect() protoreflect.Message {
+func (x *MultifundchannelDestinations) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_cln_node_proto_msgTypes[112]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -10933,34 +10978,87 @@ func (x *GetrouteResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetrouteResponse.ProtoReflect.Descriptor instead.
-func (*GetrouteResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use MultifundchannelDestinations.ProtoReflect.Descriptor instead.
+func (*MultifundchannelDestinations) Descriptor() ([]byte, []int) {
 	return file_proto_cln_node_proto_rawDescGZIP(), []int{112}
 }
 
-func (x *GetrouteResponse) GetRoute() []*GetrouteRoute {
+func (x *MultifundchannelDestinations) GetId() string {
 	if x != nil {
-		return x.Route
+		return x.Id
+	}
+	return ""
+}
+
+func (x *MultifundchannelDestinations) GetAmount() *AmountOrAll {
+	if x != nil {
+		return x.Amount
 	}
 	return nil
 }
 
-type GetrouteRoute struct {
+func (x *MultifundchannelDestinations) GetAnnounce() bool {
+	if x != nil && x.Announce != nil {
+		return *x.Announce
+	}
+	return false
+}
+
+func (x *MultifundchannelDestinations) GetPushMsat() *Amount {
+	if x != nil {
+		return x.PushMsat
+	}
+	return nil
+}
+
+func (x *MultifundchannelDestinations) GetCloseTo() string {
+	if x != nil && x.CloseTo != nil {
+		return *x.CloseTo
+	}
+	return ""
+}
+
+func (x *MultifundchannelDestinations) GetRequestAmt() *Amount {
+	if x != nil {
+		return x.RequestAmt
+	}
+	return nil
+}
+
+func (x *MultifundchannelDestinations) GetCompactLease() string {
+	if x != nil && x.CompactLease != nil {
+		return *x.CompactLease
+	}
+	return ""
+}
+
+func (x *MultifundchannelDestinations) GetMindepth() uint32 {
+	if x != nil && x.Mindepth != nil {
+		return *x.Mindepth
+	}
+	return 0
+}
+
+func (x *MultifundchannelDestinations) GetReserve() *Amount {
+	if x != nil {
+		return x.Reserve
+	}
+	return nil
+}
+
+type MultifundchannelResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Id         []byte                           `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Channel    string                           `protobuf:"bytes,2,opt,name=channel,proto3" json:"channel,omitempty"`
-	Direction  uint32                           `protobuf:"varint,3,opt,name=direction,proto3" json:"direction,omitempty"`
-	Msatoshi   *uint64                          `protobuf:"varint,7,opt,name=msatoshi,proto3,oneof" json:"msatoshi,omitempty"`
-	AmountMsat *Amount                          `protobuf:"bytes,4,opt,name=amount_msat,json=amountMsat,proto3" json:"amount_msat,omitempty"`
-	Delay      uint32                           `protobuf:"varint,5,opt,name=delay,proto3" json:"delay,omitempty"`
-	Style      GetrouteRoute_GetrouteRouteStyle `protobuf:"varint,6,opt,name=style,proto3,enum=cln.GetrouteRoute_GetrouteRouteStyle" json:"style,omitempty"`
+	Tx         []byte                        `protobuf:"bytes,1,opt,name=tx,proto3" json:"tx,omitempty"`
+	Txid       []byte                        `protobuf:"bytes,2,opt,name=txid,proto3" json:"txid,omitempty"`
+	ChannelIds []*MultifundchannelChannelIds `protobuf:"bytes,3,rep,name=channel_ids,json=channelIds,proto3" json:"channel_ids,omitempty"`
+	Failed     []*MultifundchannelFailed     `protobuf:"bytes,4,rep,name=failed,proto3" json:"failed,omitempty"`
 }
 
-func (x *GetrouteRoute) Reset() {
-	*x = GetrouteRoute{}
+func (x *MultifundchannelResponse) Reset() {
+	*x = MultifundchannelResponse{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_proto_cln_node_proto_msgTypes[113]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -10968,13 +11066,13 @@ func (x *GetrouteRoute) Reset() {
 	}
 }
 
-func (x *GetrouteRoute) String() string {
+func (x *MultifundchannelResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetrouteRoute) ProtoMessage() {}
+func (*MultifundchannelResponse) ProtoMessage() {}
 
-func (x *GetrouteRoute) ProtoReflect() protoreflect.Message {
+func (x *MultifundchannelResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_cln_node_proto_msgTypes[113]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -10986,72 +11084,52 @@ func (x *GetrouteRoute) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetrouteRoute.ProtoReflect.Descriptor instead.
-func (*GetrouteRoute) Descriptor() ([]byte, []int) {
+// Deprecated: Use MultifundchannelResponse.ProtoReflect.Descriptor instead.
+func (*MultifundchannelResponse) Descriptor() ([]byte, []int) {
 	return file_proto_cln_node_proto_rawDescGZIP(), []int{113}
 }
 
-func (x *GetrouteRoute) GetId() []byte {
+func (x *MultifundchannelResponse) GetTx() []byte {
 	if x != nil {
-		return x.Id
+		return x.Tx
 	}
 	return nil
 }
 
-func (x *GetrouteRoute) GetChannel() string {
-	if x != nil {
-		return x.Channel
-	}
-	return ""
-}
-
-func (x *GetrouteRoute) GetDirection() uint32 {
-	if x != nil {
-		return x.Direction
-	}
-	return 0
-}
-
-func (x *GetrouteRoute) GetMsatoshi() uint64 {
-	if x != nil && x.Msatoshi != nil {
-		return *x.Msatoshi
-	}
-	return 0
-}
-
-func (x *GetrouteRoute) GetAmountMsat() *Amount {
+func (x *MultifundchannelResponse) GetTxid() []byte {
 	if x != nil {
-		return x.AmountMsat
+		return x.Txid
 	}
 	return nil
 }
 
-func (x *GetrouteRoute) GetDelay() uint32 {
+func (x *MultifundchannelResponse) GetChannelIds() []*MultifundchannelChannelIds {
 	if x != nil {
-		return x.Delay
+		return x.ChannelIds
 	}
-	return 0
+	return nil
 }
 
-func (x *GetrouteRoute) GetStyle() GetrouteRoute_GetrouteRouteStyle {
+func (x *MultifundchannelResponse) GetFailed() []*MultifundchannelFailed {
 	if x != nil {
-		return x.Style
+		return x.Failed
 	}
-	return GetrouteRoute_TLV
+	return nil
 }
 
-type ListforwardsRequest struct {
+type MultifundchannelChannelIds struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Status     *ListforwardsRequest_ListforwardsStatus `protobuf:"varint,1,opt,name=status,proto3,enum=cln.ListforwardsRequest_ListforwardsStatus,oneof" json:"status,omitempty"`
-	InChannel  *string                                 `protobuf:"bytes,2,opt,name=in_channel,json=inChannel,proto3,oneof" json:"in_channel,omitempty"`
-	OutChannel *string                                 `protobuf:"bytes,3,opt,name=out_channel,json=outChannel,proto3,oneof" json:"out_channel,omitempty"`
+	Id        []byte `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Outnum    uint32 `protobuf:"varint,2,opt,name=outnum,proto3" json:"outnum,omitempty"`
+	ChannelId []byte `protobuf:"bytes,3,opt,name=channel_id,json=channelId,proto3" json:"channel_id,omitempty"`
+	CloseTo   []byte `protobuf:"bytes,4,opt,name=close_to,json=closeTo,proto3,oneof" json:"close_to,omitempty"`
 }
 
-func (x *ListforwardsRequest) Reset() {
-	*x = ListforwardsRequest{}
+func (x *MultifundchannelChannelIds) Reset() {
+	*x = MultifundchannelChannelIds{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_proto_cln_node_proto_msgTypes[114]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -11059,13 +11137,13 @@ func (x *ListforwardsRequest) Reset() {
 	}
 }
 
-func (x *ListforwardsRequest) String() string {
+func (x *MultifundchannelChannelIds) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListforwardsRequest) ProtoMessage() {}
+func (*MultifundchannelChannelIds) ProtoMessage() {}
 
-func (x *ListforwardsRequest) ProtoReflect() protoreflect.Message {
+func (x *MultifundchannelChannelIds) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_cln_node_proto_msgTypes[114]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -11077,42 +11155,51 @@ func (x *ListforwardsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListforwardsRequest.ProtoReflect.Descriptor instead.
-func (*ListforwardsRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use MultifundchannelChannelIds.ProtoReflect.Descriptor instead.
+func (*MultifundchannelChannelIds) Descriptor() ([]byte, []int) {
 	return file_proto_cln_node_proto_rawDescGZIP(), []int{114}
 }
 
-func (x *ListforwardsRequest) GetStatus() ListforwardsRequest_ListforwardsStatus {
-	if x != nil && x.Status != nil {
-		return *x.Status
+func (x *MultifundchannelChannelIds) GetId() []byte {
+	if x != nil {
+		return x.Id
 	}
-	return ListforwardsRequest_OFFERED
+	return nil
 }
 
-func (x *ListforwardsRequest) GetInChannel() string {
-	if x != nil && x.InChannel != nil {
-		return *x.InChannel
+func (x *MultifundchannelChannelIds) GetOutnum() uint32 {
+	if x != nil {
+		return x.Outnum
 	}
-	return ""
+	return 0
 }
 
-func (x *ListforwardsRequest) GetOutChannel() string {
-	if x != nil && x.OutChannel != nil {
-		return *x.OutChannel
+func (x *MultifundchannelChannelIds) GetChannelId() []byte {
+	if x != nil {
+		return x.ChannelId
 	}
-	return ""
+	return nil
 }
 
-type ListforwardsResponse struct {
+func (x *MultifundchannelChannelIds) GetCloseTo() []byte {
+	if x != nil {
+		return x.CloseTo
+	}
+	return nil
+}
+
+type MultifundchannelFailed struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Forwards []*ListforwardsForwards `protobuf:"bytes,1,rep,name=forwards,proto3" json:"forwards,omitempty"`
+	Id     []byte                                              `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Method MultifundchannelFailed_MultifundchannelFailedMethod `protobuf:"varint,2,opt,name=method,proto3,enum=cln.MultifundchannelFailed_MultifundchannelFailedMethod" json:"method,omitempty"`
+	Error  *MultifundchannelFailedError                        `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
 }
 
-func (x *ListforwardsResponse) Reset() {
-	*x = ListforwardsResponse{}
+func (x *MultifundchannelFailed) Reset() {
+	*x = MultifundchannelFailed{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_proto_cln_node_proto_msgTypes[115]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -11120,13 +11207,13 @@ func (x *ListforwardsResponse) Reset() {
 	}
 }
 
-func (x *ListforwardsResponse) String() string {
+func (x *MultifundchannelFailed) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListforwardsResponse) ProtoMessage() {}
+func (*MultifundchannelFailed) ProtoMessage() {}
 
-func (x *ListforwardsResponse) ProtoReflect() protoreflect.Message {
+func (x *MultifundchannelFailed) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_cln_node_proto_msgTypes[115]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -11138,11 +11225,435 @@ func (x *ListforwardsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListforwardsResponse.ProtoReflect.Descriptor instead.
-func (*ListforwardsResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use MultifundchannelFailed.ProtoReflect.Descriptor instead.
+func (*MultifundchannelFailed) Descriptor() ([]byte, []int) {
 	return file_proto_cln_node_proto_rawDescGZIP(), []int{115}
 }
 
+func (x *MultifundchannelFailed) GetId() []byte {
+	if x != nil {
+		return x.Id
+	}
+	return nil
+}
+
+func (x *MultifundchannelFailed) GetMethod() MultifundchannelFailed_MultifundchannelFailedMethod {
+	if x != nil {
+		return x.Method
+	}
+	return MultifundchannelFailed_CONNECT
+}
+
+func (x *MultifundchannelFailed) GetError() *MultifundchannelFailedError {
+	if x != nil {
+		return x.Error
+	}
+	return nil
+}
+
+type MultifundchannelFailedError struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Code    int64  `protobuf:"zigzag64,1,opt,name=code,proto3" json:"code,omitempty"`
+	Message string `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+}
+
+func (x *MultifundchannelFailedError) Reset() {
+	*x = MultifundchannelFailedError{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_cln_node_proto_msgTypes[116]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MultifundchannelFailedError) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MultifundchannelFailedError) ProtoMessage() {}
+
+func (x *MultifundchannelFailedError) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_cln_node_proto_msgTypes[116]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MultifundchannelFailedError.ProtoReflect.Descriptor instead.
+func (*MultifundchannelFailedError) Descriptor() ([]byte, []int) {
+	return file_proto_cln_node_proto_rawDescGZIP(), []int{116}
+}
+
+func (x *MultifundchannelFailedError) GetCode() int64 {
+	if x != nil {
+		return x.Code
+	}
+	return 0
+}
+
+func (x *MultifundchannelFailedError) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type GetrouteRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id          []byte   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	AmountMsat  *Amount  `protobuf:"bytes,9,opt,name=amount_msat,json=amountMsat,proto3" json:"amount_msat,omitempty"`
+	Riskfactor  uint64   `protobuf:"varint,3,opt,name=riskfactor,proto3" json:"riskfactor,omitempty"`
+	Cltv        *float64 `protobuf:"fixed64,4,opt,name=cltv,proto3,oneof" json:"cltv,omitempty"`
+	Fromid      []byte   `protobuf:"bytes,5,opt,name=fromid,proto3,oneof" json:"fromid,omitempty"`
+	Fuzzpercent *uint32  `protobuf:"varint,6,opt,name=fuzzpercent,proto3,oneof" json:"fuzzpercent,omitempty"`
+	Exclude     []string `protobuf:"bytes,7,rep,name=exclude,proto3" json:"exclude,omitempty"`
+	Maxhops     *uint32  `protobuf:"varint,8,opt,name=maxhops,proto3,oneof" json:"maxhops,omitempty"`
+}
+
+func (x *GetrouteRequest) Reset() {
+	*x = GetrouteRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_cln_node_proto_msgTypes[117]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetrouteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetrouteRequest) ProtoMessage() {}
+
+func (x *GetrouteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_cln_node_proto_msgTypes[117]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetrouteRequest.ProtoReflect.Descriptor instead.
+func (*GetrouteRequest) Descriptor() ([]byte, []int) {
+	return file_proto_cln_node_proto_rawDescGZIP(), []int{117}
+}
+
+func (x *GetrouteRequest) GetId() []byte {
+	if x != nil {
+		return x.Id
+	}
+	return nil
+}
+
+func (x *GetrouteRequest) GetAmountMsat() *Amount {
+	if x != nil {
+		return x.AmountMsat
+	}
+	return nil
+}
+
+func (x *GetrouteRequest) GetRiskfactor() uint64 {
+	if x != nil {
+		return x.Riskfactor
+	}
+	return 0
+}
+
+func (x *GetrouteRequest) GetCltv() float64 {
+	if x != nil && x.Cltv != nil {
+		return *x.Cltv
+	}
+	return 0
+}
+
+func (x *GetrouteRequest) GetFromid() []byte {
+	if x != nil {
+		return x.Fromid
+	}
+	return nil
+}
+
+func (x *GetrouteRequest) GetFuzzpercent() uint32 {
+	if x != nil && x.Fuzzpercent != nil {
+		return *x.Fuzzpercent
+	}
+	return 0
+}
+
+func (x *GetrouteRequest) GetExclude() []string {
+	if x != nil {
+		return x.Exclude
+	}
+	return nil
+}
+
+func (x *GetrouteRequest) GetMaxhops() uint32 {
+	if x != nil && x.Maxhops != nil {
+		return *x.Maxhops
+	}
+	return 0
+}
+
+type GetrouteResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Route []*GetrouteRoute `protobuf:"bytes,1,rep,name=route,proto3" json:"route,omitempty"`
+}
+
+func (x *GetrouteResponse) Reset() {
+	*x = GetrouteResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_cln_node_proto_msgTypes[118]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetrouteResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetrouteResponse) ProtoMessage() {}
+
+func (x *GetrouteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_cln_node_proto_msgTypes[118]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetrouteResponse.ProtoReflect.Descriptor instead.
+func (*GetrouteResponse) Descriptor() ([]byte, []int) {
+	return file_proto_cln_node_proto_rawDescGZIP(), []int{118}
+}
+
+func (x *GetrouteResponse) GetRoute() []*GetrouteRoute {
+	if x != nil {
+		return x.Route
+	}
+	return nil
+}
+
+type GetrouteRoute struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id         []byte                           `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Channel    string                           `protobuf:"bytes,2,opt,name=channel,proto3" json:"channel,omitempty"`
+	Direction  uint32                           `protobuf:"varint,3,opt,name=direction,proto3" json:"direction,omitempty"`
+	Msatoshi   *uint64                          `protobuf:"varint,7,opt,name=msatoshi,proto3,oneof" json:"msatoshi,omitempty"`
+	AmountMsat *Amount                          `protobuf:"bytes,4,opt,name=amount_msat,json=amountMsat,proto3" json:"amount_msat,omitempty"`
+	Delay      uint32                           `protobuf:"varint,5,opt,name=delay,proto3" json:"delay,omitempty"`
+	Style      GetrouteRoute_GetrouteRouteStyle `protobuf:"varint,6,opt,name=style,proto3,enum=cln.GetrouteRoute_GetrouteRouteStyle" json:"style,omitempty"`
+}
+
+func (x *GetrouteRoute) Reset() {
+	*x = GetrouteRoute{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_cln_node_proto_msgTypes[119]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetrouteRoute) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetrouteRoute) ProtoMessage() {}
+
+func (x *GetrouteRoute) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_cln_node_proto_msgTypes[119]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetrouteRoute.ProtoReflect.Descriptor instead.
+func (*GetrouteRoute) Descriptor() ([]byte, []int) {
+	return file_proto_cln_node_proto_rawDescGZIP(), []int{119}
+}
+
+func (x *GetrouteRoute) GetId() []byte {
+	if x != nil {
+		return x.Id
+	}
+	return nil
+}
+
+func (x *GetrouteRoute) GetChannel() string {
+	if x != nil {
+		return x.Channel
+	}
+	return ""
+}
+
+func (x *GetrouteRoute) GetDirection() uint32 {
+	if x != nil {
+		return x.Direction
+	}
+	return 0
+}
+
+func (x *GetrouteRoute) GetMsatoshi() uint64 {
+	if x != nil && x.Msatoshi != nil {
+		return *x.Msatoshi
+	}
+	return 0
+}
+
+func (x *GetrouteRoute) GetAmountMsat() *Amount {
+	if x != nil {
+		return x.AmountMsat
+	}
+	return nil
+}
+
+func (x *GetrouteRoute) GetDelay() uint32 {
+	if x != nil {
+		return x.Delay
+	}
+	return 0
+}
+
+func (x *GetrouteRoute) GetStyle() GetrouteRoute_GetrouteRouteStyle {
+	if x != nil {
+		return x.Style
+	}
+	return GetrouteRoute_TLV
+}
+
+type ListforwardsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Status     *ListforwardsRequest_ListforwardsStatus `protobuf:"varint,1,opt,name=status,proto3,enum=cln.ListforwardsRequest_ListforwardsStatus,oneof" json:"status,omitempty"`
+	InChannel  *string                                 `protobuf:"bytes,2,opt,name=in_channel,json=inChannel,proto3,oneof" json:"in_channel,omitempty"`
+	OutChannel *string                                 `protobuf:"bytes,3,opt,name=out_channel,json=outChannel,proto3,oneof" json:"out_channel,omitempty"`
+}
+
+func (x *ListforwardsRequest) Reset() {
+	*x = ListforwardsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_cln_node_proto_msgTypes[120]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListforwardsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListforwardsRequest) ProtoMessage() {}
+
+func (x *ListforwardsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_cln_node_proto_msgTypes[120]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListforwardsRequest.ProtoReflect.Descriptor instead.
+func (*ListforwardsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_cln_node_proto_rawDescGZIP(), []int{120}
+}
+
+func (x *ListforwardsRequest) GetStatus() ListforwardsRequest_ListforwardsStatus {
+	if x != nil && x.Status != nil {
+		return *x.Status
+	}
+	return ListforwardsRequest_OFFERED
+}
+
+func (x *ListforwardsRequest) GetInChannel() string {
+	if x != nil && x.InChannel != nil {
+		return *x.InChannel
+	}
+	return ""
+}
+
+func (x *ListforwardsRequest) GetOutChannel() string {
+	if x != nil && x.OutChannel != nil {
+		return *x.OutChannel
+	}
+	return ""
+}
+
+type ListforwardsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Forwards []*ListforwardsForwards `protobuf:"bytes,1,rep,name=forwards,proto3" json:"forwards,omitempty"`
+}
+
+func (x *ListforwardsResponse) Reset() {
+	*x = ListforwardsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_cln_node_proto_msgTypes[121]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListforwardsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListforwardsResponse) ProtoMessage() {}
+
+func (x *ListforwardsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_cln_node_proto_msgTypes[121]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListforwardsResponse.ProtoReflect.Descriptor instead.
+func (*ListforwardsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_cln_node_proto_rawDescGZIP(), []int{121}
+}
+
 func (x *ListforwardsResponse) GetForwards() []*ListforwardsForwards {
 	if x != nil {
 		return x.Forwards
@@ -11170,7 +11681,7 @@ type ListforwardsForwards struct {
 func (x *ListforwardsForwards) Reset() {
 	*x = ListforwardsForwards{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_cln_node_proto_msgTypes[116]
+		mi := &file_proto_cln_node_proto_msgTypes[122]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -11183,7 +11694,7 @@ func (x *ListforwardsForwards) String() string {
 func (*ListforwardsForwards) ProtoMessage() {}
 
 func (x *ListforwardsForwards) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_cln_node_proto_msgTypes[116]
+	mi := &file_proto_cln_node_proto_msgTypes[122]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -11196,7 +11707,7 @@ func (x *ListforwardsForwards) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListforwardsForwards.ProtoReflect.Descriptor instead.
 func (*ListforwardsForwards) Descriptor() ([]byte, []int) {
-	return file_proto_cln_node_proto_rawDescGZIP(), []int{116}
+	return file_proto_cln_node_proto_rawDescGZIP(), []int{122}
 }
 
 func (x *ListforwardsForwards) GetInChannel() string {
@@ -11282,7 +11793,7 @@ type ListpaysRequest struct {
 func (x *ListpaysRequest) Reset() {
 	*x = ListpaysRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_cln_node_proto_msgTypes[117]
+		mi := &file_proto_cln_node_proto_msgTypes[123]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -11295,7 +11806,7 @@ func (x *ListpaysRequest) String() string {
 func (*ListpaysRequest) ProtoMessage() {}
 
 func (x *ListpaysRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_cln_node_proto_msgTypes[117]
+	mi := &file_proto_cln_node_proto_msgTypes[123]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -11308,7 +11819,7 @@ func (x *ListpaysRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListpaysRequest.ProtoReflect.Descriptor instead.
 func (*ListpaysRequest) Descriptor() ([]byte, []int) {
-	return file_proto_cln_node_proto_rawDescGZIP(), []int{117}
+	return file_proto_cln_node_proto_rawDescGZIP(), []int{123}
 }
 
 func (x *ListpaysRequest) GetBolt11() string {
@@ -11343,7 +11854,7 @@ type ListpaysResponse struct {
 func (x *ListpaysResponse) Reset() {
 	*x = ListpaysResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_cln_node_proto_msgTypes[118]
+		mi := &file_proto_cln_node_proto_msgTypes[124]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -11356,7 +11867,7 @@ func (x *ListpaysResponse) String() string {
 func (*ListpaysResponse) ProtoMessage() {}
 
 func (x *ListpaysResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_cln_node_proto_msgTypes[118]
+	mi := &file_proto_cln_node_proto_msgTypes[124]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -11369,7 +11880,7 @@ func (x *ListpaysResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListpaysResponse.ProtoReflect.Descriptor instead.
 func (*ListpaysResponse) Descriptor() ([]byte, []int) {
-	return file_proto_cln_node_proto_rawDescGZIP(), []int{118}
+	return file_proto_cln_node_proto_rawDescGZIP(), []int{124}
 }
 
 func (x *ListpaysResponse) GetPays() []*ListpaysPays {
@@ -11401,7 +11912,7 @@ type ListpaysPays struct {
 func (x *ListpaysPays) Reset() {
 	*x = ListpaysPays{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_cln_node_proto_msgTypes[119]
+		mi := &file_proto_cln_node_proto_msgTypes[125]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -11414,7 +11925,7 @@ func (x *ListpaysPays) String() string {
 func (*ListpaysPays) ProtoMessage() {}
 
 func (x *ListpaysPays) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_cln_node_proto_msgTypes[119]
+	mi := &file_proto_cln_node_proto_msgTypes[125]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -11427,7 +11938,7 @@ func (x *ListpaysPays) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListpaysPays.ProtoReflect.Descriptor instead.
 func (*ListpaysPays) Descriptor() ([]byte, []int) {
-	return file_proto_cln_node_proto_rawDescGZIP(), []int{119}
+	return file_proto_cln_node_proto_rawDescGZIP(), []int{125}
 }
 
 func (x *ListpaysPays) GetPaymentHash() []byte {
@@ -11527,7 +12038,7 @@ type PingRequest struct {
 func (x *PingRequest) Reset() {
 	*x = PingRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_cln_node_proto_msgTypes[120]
+		mi := &file_proto_cln_node_proto_msgTypes[126]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -11540,7 +12051,7 @@ func (x *PingRequest) String() string {
 func (*PingRequest) ProtoMessage() {}
 
 func (x *PingRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_cln_node_proto_msgTypes[120]
+	mi := &file_proto_cln_node_proto_msgTypes[126]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -11553,7 +12064,7 @@ func (x *PingRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PingRequest.ProtoReflect.Descriptor instead.
 func (*PingRequest) Descriptor() ([]byte, []int) {
-	return file_proto_cln_node_proto_rawDescGZIP(), []int{120}
+	return file_proto_cln_node_proto_rawDescGZIP(), []int{126}
 }
 
 func (x *PingRequest) GetId() []byte {
@@ -11588,7 +12099,7 @@ type PingResponse struct {
 func (x *PingResponse) Reset() {
 	*x = PingResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_cln_node_proto_msgTypes[121]
+		mi := &file_proto_cln_node_proto_msgTypes[127]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -11601,7 +12112,7 @@ func (x *PingResponse) String() string {
 func (*PingResponse) ProtoMessage() {}
 
 func (x *PingResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_cln_node_proto_msgTypes[121]
+	mi := &file_proto_cln_node_proto_msgTypes[127]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -11614,7 +12125,7 @@ func (x *PingResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PingResponse.ProtoReflect.Descriptor instead.
 func (*PingResponse) Descriptor() ([]byte, []int) {
-	return file_proto_cln_node_proto_rawDescGZIP(), []int{121}
+	return file_proto_cln_node_proto_rawDescGZIP(), []int{127}
 }
 
 func (x *PingResponse) GetTotlen() uint32 {
@@ -11640,7 +12151,7 @@ type SetchannelRequest struct {
 func (x *SetchannelRequest) Reset() {
 	*x = SetchannelRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_cln_node_proto_msgTypes[122]
+		mi := &file_proto_cln_node_proto_msgTypes[128]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -11653,7 +12164,7 @@ func (x *SetchannelRequest) String() string {
 func (*SetchannelRequest) ProtoMessage() {}
 
 func (x *SetchannelRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_cln_node_proto_msgTypes[122]
+	mi := &file_proto_cln_node_proto_msgTypes[128]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -11666,7 +12177,7 @@ func (x *SetchannelRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SetchannelRequest.ProtoReflect.Descriptor instead.
 func (*SetchannelRequest) Descriptor() ([]byte, []int) {
-	return file_proto_cln_node_proto_rawDescGZIP(), []int{122}
+	return file_proto_cln_node_proto_rawDescGZIP(), []int{128}
 }
 
 func (x *SetchannelRequest) GetId() string {
@@ -11722,7 +12233,7 @@ type SetchannelResponse struct {
 func (x *SetchannelResponse) Reset() {
 	*x = SetchannelResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_cln_node_proto_msgTypes[123]
+		mi := &file_proto_cln_node_proto_msgTypes[129]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -11735,7 +12246,7 @@ func (x *SetchannelResponse) String() string {
 func (*SetchannelResponse) ProtoMessage() {}
 
 func (x *SetchannelResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_cln_node_proto_msgTypes[123]
+	mi := &file_proto_cln_node_proto_msgTypes[129]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -11748,7 +12259,7 @@ func (x *SetchannelResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SetchannelResponse.ProtoReflect.Descriptor instead.
 func (*SetchannelResponse) Descriptor() ([]byte, []int) {
-	return file_proto_cln_node_proto_rawDescGZIP(), []int{123}
+	return file_proto_cln_node_proto_rawDescGZIP(), []int{129}
 }
 
 func (x *SetchannelResponse) GetChannels() []*SetchannelChannels {
@@ -11777,7 +12288,7 @@ type SetchannelChannels struct {
 func (x *SetchannelChannels) Reset() {
 	*x = SetchannelChannels{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_cln_node_proto_msgTypes[124]
+		mi := &file_proto_cln_node_proto_msgTypes[130]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -11790,7 +12301,7 @@ func (x *SetchannelChannels) String() string {
 func (*SetchannelChannels) ProtoMessage() {}
 
 func (x *SetchannelChannels) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_cln_node_proto_msgTypes[124]
+	mi := &file_proto_cln_node_proto_msgTypes[130]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -11803,7 +12314,7 @@ func (x *SetchannelChannels) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SetchannelChannels.ProtoReflect.Descriptor instead.
 func (*SetchannelChannels) Descriptor() ([]byte, []int) {
-	return file_proto_cln_node_proto_rawDescGZIP(), []int{124}
+	return file_proto_cln_node_proto_rawDescGZIP(), []int{130}
 }
 
 func (x *SetchannelChannels) GetPeerId() []byte {
@@ -11880,7 +12391,7 @@ type SignmessageRequest struct {
 func (x *SignmessageRequest) Reset() {
 	*x = SignmessageRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_cln_node_proto_msgTypes[125]
+		mi := &file_proto_cln_node_proto_msgTypes[131]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -11893,7 +12404,7 @@ func (x *SignmessageRequest) String() string {
 func (*SignmessageRequest) ProtoMessage() {}
 
 func (x *SignmessageRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_cln_node_proto_msgTypes[125]
+	mi := &file_proto_cln_node_proto_msgTypes[131]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -11906,7 +12417,7 @@ func (x *SignmessageRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SignmessageRequest.ProtoReflect.Descriptor instead.
 func (*SignmessageRequest) Descriptor() ([]byte, []int) {
-	return file_proto_cln_node_proto_rawDescGZIP(), []int{125}
+	return file_proto_cln_node_proto_rawDescGZIP(), []int{131}
 }
 
 func (x *SignmessageRequest) GetMessage() string {
@@ -11929,7 +12440,7 @@ type SignmessageResponse struct {
 func (x *SignmessageResponse) Reset() {
 	*x = SignmessageResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_cln_node_proto_msgTypes[126]
+		mi := &file_proto_cln_node_proto_msgTypes[132]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -11942,7 +12453,7 @@ func (x *SignmessageResponse) String() string {
 func (*SignmessageResponse) ProtoMessage() {}
 
 func (x *SignmessageResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_cln_node_proto_msgTypes[126]
+	mi := &file_proto_cln_node_proto_msgTypes[132]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -11955,7 +12466,7 @@ func (x *SignmessageResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SignmessageResponse.ProtoReflect.Descriptor instead.
 func (*SignmessageResponse) Descriptor() ([]byte, []int) {
-	return file_proto_cln_node_proto_rawDescGZIP(), []int{126}
+	return file_proto_cln_node_proto_rawDescGZIP(), []int{132}
 }
 
 func (x *SignmessageResponse) GetSignature() []byte {
@@ -11988,7 +12499,7 @@ type StopRequest struct {
 func (x *StopRequest) Reset() {
 	*x = StopRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_cln_node_proto_msgTypes[127]
+		mi := &file_proto_cln_node_proto_msgTypes[133]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -12001,7 +12512,7 @@ func (x *StopRequest) String() string {
 func (*StopRequest) ProtoMessage() {}
 
 func (x *StopRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_cln_node_proto_msgTypes[127]
+	mi := &file_proto_cln_node_proto_msgTypes[133]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -12014,7 +12525,7 @@ func (x *StopRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StopRequest.ProtoReflect.Descriptor instead.
 func (*StopRequest) Descriptor() ([]byte, []int) {
-	return file_proto_cln_node_proto_rawDescGZIP(), []int{127}
+	return file_proto_cln_node_proto_rawDescGZIP(), []int{133}
 }
 
 type StopResponse struct {
@@ -12026,7 +12537,7 @@ type StopResponse struct {
 func (x *StopResponse) Reset() {
 	*x = StopResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_cln_node_proto_msgTypes[128]
+		mi := &file_proto_cln_node_proto_msgTypes[134]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -12039,7 +12550,7 @@ func (x *StopResponse) String() string {
 func (*StopResponse) ProtoMessage() {}
 
 func (x *StopResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_cln_node_proto_msgTypes[128]
+	mi := &file_proto_cln_node_proto_msgTypes[134]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -12052,7 +12563,7 @@ func (x *StopResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StopResponse.ProtoReflect.Descriptor instead.
 func (*StopResponse) Descriptor() ([]byte, []int) {
-	return file_proto_cln_node_proto_rawDescGZIP(), []int{128}
+	return file_proto_cln_node_proto_rawDescGZIP(), []int{134}
 }
 
 var File_proto_cln_node_proto protoreflect.FileDescriptor
@@ -14066,446 +14577,549 @@ var file_proto_cln_node_proto_rawDesc = []byte{
 	0x12, 0x1f, 0x0a, 0x08, 0x6d, 0x69, 0x6e, 0x64, 0x65, 0x70, 0x74, 0x68, 0x18, 0x06, 0x20, 0x01,
 	0x28, 0x0d, 0x48, 0x01, 0x52, 0x08, 0x6d, 0x69, 0x6e, 0x64, 0x65, 0x70, 0x74, 0x68, 0x88, 0x01,
 	0x01, 0x42, 0x0b, 0x0a, 0x09, 0x5f, 0x63, 0x6c, 0x6f, 0x73, 0x65, 0x5f, 0x74, 0x6f, 0x42, 0x0b,
-	0x0a, 0x09, 0x5f, 0x6d, 0x69, 0x6e, 0x64, 0x65, 0x70, 0x74, 0x68, 0x22, 0xb5, 0x02, 0x0a, 0x0f,
-	0x47, 0x65, 0x74, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
-	0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x02, 0x69, 0x64, 0x12,
-	0x2c, 0x0a, 0x0b, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x6d, 0x73, 0x61, 0x74, 0x18, 0x09,
+	0x0a, 0x09, 0x5f, 0x6d, 0x69, 0x6e, 0x64, 0x65, 0x70, 0x74, 0x68, 0x22, 0xf9, 0x02, 0x0a, 0x17,
+	0x4d, 0x75, 0x6c, 0x74, 0x69, 0x66, 0x75, 0x6e, 0x64, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x45, 0x0a, 0x0c, 0x64, 0x65, 0x73, 0x74, 0x69,
+	0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x21, 0x2e,
+	0x63, 0x6c, 0x6e, 0x2e, 0x4d, 0x75, 0x6c, 0x74, 0x69, 0x66, 0x75, 0x6e, 0x64, 0x63, 0x68, 0x61,
+	0x6e, 0x6e, 0x65, 0x6c, 0x44, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x52, 0x0c, 0x64, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x2b,
+	0x0a, 0x07, 0x66, 0x65, 0x65, 0x72, 0x61, 0x74, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x0c, 0x2e, 0x63, 0x6c, 0x6e, 0x2e, 0x46, 0x65, 0x65, 0x72, 0x61, 0x74, 0x65, 0x48, 0x00, 0x52,
+	0x07, 0x66, 0x65, 0x65, 0x72, 0x61, 0x74, 0x65, 0x88, 0x01, 0x01, 0x12, 0x1d, 0x0a, 0x07, 0x6d,
+	0x69, 0x6e, 0x63, 0x6f, 0x6e, 0x66, 0x18, 0x03, 0x20, 0x01, 0x28, 0x12, 0x48, 0x01, 0x52, 0x07,
+	0x6d, 0x69, 0x6e, 0x63, 0x6f, 0x6e, 0x66, 0x88, 0x01, 0x01, 0x12, 0x23, 0x0a, 0x05, 0x75, 0x74,
+	0x78, 0x6f, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x63, 0x6c, 0x6e, 0x2e,
+	0x4f, 0x75, 0x74, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x52, 0x05, 0x75, 0x74, 0x78, 0x6f, 0x73, 0x12,
+	0x25, 0x0a, 0x0b, 0x6d, 0x69, 0x6e, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x73, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x12, 0x48, 0x02, 0x52, 0x0b, 0x6d, 0x69, 0x6e, 0x63, 0x68, 0x61, 0x6e, 0x6e,
+	0x65, 0x6c, 0x73, 0x88, 0x01, 0x01, 0x12, 0x40, 0x0a, 0x12, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74,
+	0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x66, 0x65, 0x65, 0x72, 0x61, 0x74, 0x65, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x63, 0x6c, 0x6e, 0x2e, 0x46, 0x65, 0x65, 0x72, 0x61, 0x74, 0x65,
+	0x48, 0x03, 0x52, 0x11, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x46, 0x65,
+	0x65, 0x72, 0x61, 0x74, 0x65, 0x88, 0x01, 0x01, 0x42, 0x0a, 0x0a, 0x08, 0x5f, 0x66, 0x65, 0x65,
+	0x72, 0x61, 0x74, 0x65, 0x42, 0x0a, 0x0a, 0x08, 0x5f, 0x6d, 0x69, 0x6e, 0x63, 0x6f, 0x6e, 0x66,
+	0x42, 0x0e, 0x0a, 0x0c, 0x5f, 0x6d, 0x69, 0x6e, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x73,
+	0x42, 0x15, 0x0a, 0x13, 0x5f, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x5f,
+	0x66, 0x65, 0x65, 0x72, 0x61, 0x74, 0x65, 0x22, 0xd5, 0x03, 0x0a, 0x1c, 0x4d, 0x75, 0x6c, 0x74,
+	0x69, 0x66, 0x75, 0x6e, 0x64, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x44, 0x65, 0x73, 0x74,
+	0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x28, 0x0a, 0x06, 0x61, 0x6d, 0x6f, 0x75,
+	0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x63, 0x6c, 0x6e, 0x2e, 0x41,
+	0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x4f, 0x72, 0x41, 0x6c, 0x6c, 0x52, 0x06, 0x61, 0x6d, 0x6f, 0x75,
+	0x6e, 0x74, 0x12, 0x1f, 0x0a, 0x08, 0x61, 0x6e, 0x6e, 0x6f, 0x75, 0x6e, 0x63, 0x65, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x08, 0x48, 0x00, 0x52, 0x08, 0x61, 0x6e, 0x6e, 0x6f, 0x75, 0x6e, 0x63, 0x65,
+	0x88, 0x01, 0x01, 0x12, 0x2d, 0x0a, 0x09, 0x70, 0x75, 0x73, 0x68, 0x5f, 0x6d, 0x73, 0x61, 0x74,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x63, 0x6c, 0x6e, 0x2e, 0x41, 0x6d, 0x6f,
+	0x75, 0x6e, 0x74, 0x48, 0x01, 0x52, 0x08, 0x70, 0x75, 0x73, 0x68, 0x4d, 0x73, 0x61, 0x74, 0x88,
+	0x01, 0x01, 0x12, 0x1e, 0x0a, 0x08, 0x63, 0x6c, 0x6f, 0x73, 0x65, 0x5f, 0x74, 0x6f, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x09, 0x48, 0x02, 0x52, 0x07, 0x63, 0x6c, 0x6f, 0x73, 0x65, 0x54, 0x6f, 0x88,
+	0x01, 0x01, 0x12, 0x31, 0x0a, 0x0b, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x5f, 0x61, 0x6d,
+	0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x63, 0x6c, 0x6e, 0x2e, 0x41, 0x6d,
+	0x6f, 0x75, 0x6e, 0x74, 0x48, 0x03, 0x52, 0x0a, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x41,
+	0x6d, 0x74, 0x88, 0x01, 0x01, 0x12, 0x28, 0x0a, 0x0d, 0x63, 0x6f, 0x6d, 0x70, 0x61, 0x63, 0x74,
+	0x5f, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x48, 0x04, 0x52, 0x0c,
+	0x63, 0x6f, 0x6d, 0x70, 0x61, 0x63, 0x74, 0x4c, 0x65, 0x61, 0x73, 0x65, 0x88, 0x01, 0x01, 0x12,
+	0x1f, 0x0a, 0x08, 0x6d, 0x69, 0x6e, 0x64, 0x65, 0x70, 0x74, 0x68, 0x18, 0x08, 0x20, 0x01, 0x28,
+	0x0d, 0x48, 0x05, 0x52, 0x08, 0x6d, 0x69, 0x6e, 0x64, 0x65, 0x70, 0x74, 0x68, 0x88, 0x01, 0x01,
+	0x12, 0x2a, 0x0a, 0x07, 0x72, 0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x0b, 0x2e, 0x63, 0x6c, 0x6e, 0x2e, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x48, 0x06,
+	0x52, 0x07, 0x72, 0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x88, 0x01, 0x01, 0x42, 0x0b, 0x0a, 0x09,
+	0x5f, 0x61, 0x6e, 0x6e, 0x6f, 0x75, 0x6e, 0x63, 0x65, 0x42, 0x0c, 0x0a, 0x0a, 0x5f, 0x70, 0x75,
+	0x73, 0x68, 0x5f, 0x6d, 0x73, 0x61, 0x74, 0x42, 0x0b, 0x0a, 0x09, 0x5f, 0x63, 0x6c, 0x6f, 0x73,
+	0x65, 0x5f, 0x74, 0x6f, 0x42, 0x0e, 0x0a, 0x0c, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x5f, 0x61, 0x6d, 0x74, 0x42, 0x10, 0x0a, 0x0e, 0x5f, 0x63, 0x6f, 0x6d, 0x70, 0x61, 0x63, 0x74,
+	0x5f, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x42, 0x0b, 0x0a, 0x09, 0x5f, 0x6d, 0x69, 0x6e, 0x64, 0x65,
+	0x70, 0x74, 0x68, 0x42, 0x0a, 0x0a, 0x08, 0x5f, 0x72, 0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x22,
+	0xb6, 0x01, 0x0a, 0x18, 0x4d, 0x75, 0x6c, 0x74, 0x69, 0x66, 0x75, 0x6e, 0x64, 0x63, 0x68, 0x61,
+	0x6e, 0x6e, 0x65, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x0e, 0x0a, 0x02,
+	0x74, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x02, 0x74, 0x78, 0x12, 0x12, 0x0a, 0x04,
+	0x74, 0x78, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x74, 0x78, 0x69, 0x64,
+	0x12, 0x41, 0x0a, 0x0b, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x5f, 0x69, 0x64, 0x73, 0x18,
+	0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x63, 0x6c, 0x6e, 0x2e, 0x4d, 0x75, 0x6c, 0x74,
+	0x69, 0x66, 0x75, 0x6e, 0x64, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x43, 0x68, 0x61, 0x6e,
+	0x6e, 0x65, 0x6c, 0x5f, 0x69, 0x64, 0x73, 0x52, 0x0a, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c,
+	0x49, 0x64, 0x73, 0x12, 0x33, 0x0a, 0x06, 0x66, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x18, 0x04, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x63, 0x6c, 0x6e, 0x2e, 0x4d, 0x75, 0x6c, 0x74, 0x69, 0x66,
+	0x75, 0x6e, 0x64, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x46, 0x61, 0x69, 0x6c, 0x65, 0x64,
+	0x52, 0x06, 0x66, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x22, 0x91, 0x01, 0x0a, 0x1b, 0x4d, 0x75, 0x6c,
+	0x74, 0x69, 0x66, 0x75, 0x6e, 0x64, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x43, 0x68, 0x61,
+	0x6e, 0x6e, 0x65, 0x6c, 0x5f, 0x69, 0x64, 0x73, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x02, 0x69, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x6f, 0x75, 0x74, 0x6e,
+	0x75, 0x6d, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x6f, 0x75, 0x74, 0x6e, 0x75, 0x6d,
+	0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x5f, 0x69, 0x64, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x49, 0x64, 0x12,
+	0x1e, 0x0a, 0x08, 0x63, 0x6c, 0x6f, 0x73, 0x65, 0x5f, 0x74, 0x6f, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x0c, 0x48, 0x00, 0x52, 0x07, 0x63, 0x6c, 0x6f, 0x73, 0x65, 0x54, 0x6f, 0x88, 0x01, 0x01, 0x42,
+	0x0b, 0x0a, 0x09, 0x5f, 0x63, 0x6c, 0x6f, 0x73, 0x65, 0x5f, 0x74, 0x6f, 0x22, 0xa6, 0x02, 0x0a,
+	0x16, 0x4d, 0x75, 0x6c, 0x74, 0x69, 0x66, 0x75, 0x6e, 0x64, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65,
+	0x6c, 0x46, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x02, 0x69, 0x64, 0x12, 0x50, 0x0a, 0x06, 0x6d, 0x65, 0x74, 0x68, 0x6f,
+	0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x38, 0x2e, 0x63, 0x6c, 0x6e, 0x2e, 0x4d, 0x75,
+	0x6c, 0x74, 0x69, 0x66, 0x75, 0x6e, 0x64, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x46, 0x61,
+	0x69, 0x6c, 0x65, 0x64, 0x2e, 0x4d, 0x75, 0x6c, 0x74, 0x69, 0x66, 0x75, 0x6e, 0x64, 0x63, 0x68,
+	0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x46, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x4d, 0x65, 0x74, 0x68, 0x6f,
+	0x64, 0x52, 0x06, 0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x12, 0x36, 0x0a, 0x05, 0x65, 0x72, 0x72,
+	0x6f, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x63, 0x6c, 0x6e, 0x2e, 0x4d,
+	0x75, 0x6c, 0x74, 0x69, 0x66, 0x75, 0x6e, 0x64, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x46,
+	0x61, 0x69, 0x6c, 0x65, 0x64, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f,
+	0x72, 0x22, 0x72, 0x0a, 0x1c, 0x4d, 0x75, 0x6c, 0x74, 0x69, 0x66, 0x75, 0x6e, 0x64, 0x63, 0x68,
+	0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x46, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x4d, 0x65, 0x74, 0x68, 0x6f,
+	0x64, 0x12, 0x0b, 0x0a, 0x07, 0x43, 0x4f, 0x4e, 0x4e, 0x45, 0x43, 0x54, 0x10, 0x00, 0x12, 0x14,
+	0x0a, 0x10, 0x4f, 0x50, 0x45, 0x4e, 0x43, 0x48, 0x41, 0x4e, 0x4e, 0x45, 0x4c, 0x5f, 0x49, 0x4e,
+	0x49, 0x54, 0x10, 0x01, 0x12, 0x15, 0x0a, 0x11, 0x46, 0x55, 0x4e, 0x44, 0x43, 0x48, 0x41, 0x4e,
+	0x4e, 0x45, 0x4c, 0x5f, 0x53, 0x54, 0x41, 0x52, 0x54, 0x10, 0x02, 0x12, 0x18, 0x0a, 0x14, 0x46,
+	0x55, 0x4e, 0x44, 0x43, 0x48, 0x41, 0x4e, 0x4e, 0x45, 0x4c, 0x5f, 0x43, 0x4f, 0x4d, 0x50, 0x4c,
+	0x45, 0x54, 0x45, 0x10, 0x03, 0x22, 0x4b, 0x0a, 0x1b, 0x4d, 0x75, 0x6c, 0x74, 0x69, 0x66, 0x75,
+	0x6e, 0x64, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x46, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x45,
+	0x72, 0x72, 0x6f, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x12, 0x52, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x22, 0xb5, 0x02, 0x0a, 0x0f, 0x47, 0x65, 0x74, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x02, 0x69, 0x64, 0x12, 0x2c, 0x0a, 0x0b, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74,
+	0x5f, 0x6d, 0x73, 0x61, 0x74, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x63, 0x6c,
+	0x6e, 0x2e, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x0a, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74,
+	0x4d, 0x73, 0x61, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x72, 0x69, 0x73, 0x6b, 0x66, 0x61, 0x63, 0x74,
+	0x6f, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0a, 0x72, 0x69, 0x73, 0x6b, 0x66, 0x61,
+	0x63, 0x74, 0x6f, 0x72, 0x12, 0x17, 0x0a, 0x04, 0x63, 0x6c, 0x74, 0x76, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x01, 0x48, 0x00, 0x52, 0x04, 0x63, 0x6c, 0x74, 0x76, 0x88, 0x01, 0x01, 0x12, 0x1b, 0x0a,
+	0x06, 0x66, 0x72, 0x6f, 0x6d, 0x69, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0c, 0x48, 0x01, 0x52,
+	0x06, 0x66, 0x72, 0x6f, 0x6d, 0x69, 0x64, 0x88, 0x01, 0x01, 0x12, 0x25, 0x0a, 0x0b, 0x66, 0x75,
+	0x7a, 0x7a, 0x70, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0d, 0x48,
+	0x02, 0x52, 0x0b, 0x66, 0x75, 0x7a, 0x7a, 0x70, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x88, 0x01,
+	0x01, 0x12, 0x18, 0x0a, 0x07, 0x65, 0x78, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x18, 0x07, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x07, 0x65, 0x78, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x12, 0x1d, 0x0a, 0x07, 0x6d,
+	0x61, 0x78, 0x68, 0x6f, 0x70, 0x73, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0d, 0x48, 0x03, 0x52, 0x07,
+	0x6d, 0x61, 0x78, 0x68, 0x6f, 0x70, 0x73, 0x88, 0x01, 0x01, 0x42, 0x07, 0x0a, 0x05, 0x5f, 0x63,
+	0x6c, 0x74, 0x76, 0x42, 0x09, 0x0a, 0x07, 0x5f, 0x66, 0x72, 0x6f, 0x6d, 0x69, 0x64, 0x42, 0x0e,
+	0x0a, 0x0c, 0x5f, 0x66, 0x75, 0x7a, 0x7a, 0x70, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x42, 0x0a,
+	0x0a, 0x08, 0x5f, 0x6d, 0x61, 0x78, 0x68, 0x6f, 0x70, 0x73, 0x22, 0x3c, 0x0a, 0x10, 0x47, 0x65,
+	0x74, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x28,
+	0x0a, 0x05, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e,
+	0x63, 0x6c, 0x6e, 0x2e, 0x47, 0x65, 0x74, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x52, 0x6f, 0x75, 0x74,
+	0x65, 0x52, 0x05, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x22, 0xa5, 0x02, 0x0a, 0x0d, 0x47, 0x65, 0x74,
+	0x72, 0x6f, 0x75, 0x74, 0x65, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x02, 0x69, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x68,
+	0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x68, 0x61,
+	0x6e, 0x6e, 0x65, 0x6c, 0x12, 0x1c, 0x0a, 0x09, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x12, 0x1f, 0x0a, 0x08, 0x6d, 0x73, 0x61, 0x74, 0x6f, 0x73, 0x68, 0x69, 0x18, 0x07,
+	0x20, 0x01, 0x28, 0x04, 0x48, 0x00, 0x52, 0x08, 0x6d, 0x73, 0x61, 0x74, 0x6f, 0x73, 0x68, 0x69,
+	0x88, 0x01, 0x01, 0x12, 0x2c, 0x0a, 0x0b, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x6d, 0x73,
+	0x61, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x63, 0x6c, 0x6e, 0x2e, 0x41,
+	0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x0a, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x4d, 0x73, 0x61,
+	0x74, 0x12, 0x14, 0x0a, 0x05, 0x64, 0x65, 0x6c, 0x61, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x05, 0x64, 0x65, 0x6c, 0x61, 0x79, 0x12, 0x3b, 0x0a, 0x05, 0x73, 0x74, 0x79, 0x6c, 0x65,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x25, 0x2e, 0x63, 0x6c, 0x6e, 0x2e, 0x47, 0x65, 0x74,
+	0x72, 0x6f, 0x75, 0x74, 0x65, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x2e, 0x47, 0x65, 0x74, 0x72, 0x6f,
+	0x75, 0x74, 0x65, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x53, 0x74, 0x79, 0x6c, 0x65, 0x52, 0x05, 0x73,
+	0x74, 0x79, 0x6c, 0x65, 0x22, 0x1d, 0x0a, 0x12, 0x47, 0x65, 0x74, 0x72, 0x6f, 0x75, 0x74, 0x65,
+	0x52, 0x6f, 0x75, 0x74, 0x65, 0x53, 0x74, 0x79, 0x6c, 0x65, 0x12, 0x07, 0x0a, 0x03, 0x54, 0x4c,
+	0x56, 0x10, 0x00, 0x42, 0x0b, 0x0a, 0x09, 0x5f, 0x6d, 0x73, 0x61, 0x74, 0x6f, 0x73, 0x68, 0x69,
+	0x22, 0xa1, 0x02, 0x0a, 0x13, 0x4c, 0x69, 0x73, 0x74, 0x66, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x48, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x2b, 0x2e, 0x63, 0x6c, 0x6e, 0x2e, 0x4c,
+	0x69, 0x73, 0x74, 0x66, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x66, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x73, 0x53,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x48, 0x00, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x88,
+	0x01, 0x01, 0x12, 0x22, 0x0a, 0x0a, 0x69, 0x6e, 0x5f, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x48, 0x01, 0x52, 0x09, 0x69, 0x6e, 0x43, 0x68, 0x61, 0x6e,
+	0x6e, 0x65, 0x6c, 0x88, 0x01, 0x01, 0x12, 0x24, 0x0a, 0x0b, 0x6f, 0x75, 0x74, 0x5f, 0x63, 0x68,
+	0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x48, 0x02, 0x52, 0x0a, 0x6f,
+	0x75, 0x74, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x88, 0x01, 0x01, 0x22, 0x4c, 0x0a, 0x12,
+	0x4c, 0x69, 0x73, 0x74, 0x66, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x73, 0x53, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x12, 0x0b, 0x0a, 0x07, 0x4f, 0x46, 0x46, 0x45, 0x52, 0x45, 0x44, 0x10, 0x00, 0x12,
+	0x0b, 0x0a, 0x07, 0x53, 0x45, 0x54, 0x54, 0x4c, 0x45, 0x44, 0x10, 0x01, 0x12, 0x10, 0x0a, 0x0c,
+	0x4c, 0x4f, 0x43, 0x41, 0x4c, 0x5f, 0x46, 0x41, 0x49, 0x4c, 0x45, 0x44, 0x10, 0x02, 0x12, 0x0a,
+	0x0a, 0x06, 0x46, 0x41, 0x49, 0x4c, 0x45, 0x44, 0x10, 0x03, 0x42, 0x09, 0x0a, 0x07, 0x5f, 0x73,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x42, 0x0d, 0x0a, 0x0b, 0x5f, 0x69, 0x6e, 0x5f, 0x63, 0x68, 0x61,
+	0x6e, 0x6e, 0x65, 0x6c, 0x42, 0x0e, 0x0a, 0x0c, 0x5f, 0x6f, 0x75, 0x74, 0x5f, 0x63, 0x68, 0x61,
+	0x6e, 0x6e, 0x65, 0x6c, 0x22, 0x4d, 0x0a, 0x14, 0x4c, 0x69, 0x73, 0x74, 0x66, 0x6f, 0x72, 0x77,
+	0x61, 0x72, 0x64, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x35, 0x0a, 0x08,
+	0x66, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x19,
+	0x2e, 0x63, 0x6c, 0x6e, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x66, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64,
+	0x73, 0x46, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x73, 0x52, 0x08, 0x66, 0x6f, 0x72, 0x77, 0x61,
+	0x72, 0x64, 0x73, 0x22, 0xc1, 0x05, 0x0a, 0x14, 0x4c, 0x69, 0x73, 0x74, 0x66, 0x6f, 0x72, 0x77,
+	0x61, 0x72, 0x64, 0x73, 0x46, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x73, 0x12, 0x1d, 0x0a, 0x0a,
+	0x69, 0x6e, 0x5f, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x09, 0x69, 0x6e, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x12, 0x21, 0x0a, 0x0a, 0x69,
+	0x6e, 0x5f, 0x68, 0x74, 0x6c, 0x63, 0x5f, 0x69, 0x64, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x04, 0x48,
+	0x00, 0x52, 0x08, 0x69, 0x6e, 0x48, 0x74, 0x6c, 0x63, 0x49, 0x64, 0x88, 0x01, 0x01, 0x12, 0x24,
+	0x0a, 0x07, 0x69, 0x6e, 0x5f, 0x6d, 0x73, 0x61, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x0b, 0x2e, 0x63, 0x6c, 0x6e, 0x2e, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x06, 0x69, 0x6e,
+	0x4d, 0x73, 0x61, 0x74, 0x12, 0x4c, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x0e, 0x32, 0x34, 0x2e, 0x63, 0x6c, 0x6e, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x66,
+	0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x73, 0x46, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x73, 0x2e,
+	0x4c, 0x69, 0x73, 0x74, 0x66, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x73, 0x46, 0x6f, 0x72, 0x77,
+	0x61, 0x72, 0x64, 0x73, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x12, 0x23, 0x0a, 0x0d, 0x72, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x64, 0x5f, 0x74,
+	0x69, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0c, 0x72, 0x65, 0x63, 0x65, 0x69,
+	0x76, 0x65, 0x64, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x24, 0x0a, 0x0b, 0x6f, 0x75, 0x74, 0x5f, 0x63,
+	0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x48, 0x01, 0x52, 0x0a,
+	0x6f, 0x75, 0x74, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x88, 0x01, 0x01, 0x12, 0x23, 0x0a,
+	0x0b, 0x6f, 0x75, 0x74, 0x5f, 0x68, 0x74, 0x6c, 0x63, 0x5f, 0x69, 0x64, 0x18, 0x0b, 0x20, 0x01,
+	0x28, 0x04, 0x48, 0x02, 0x52, 0x09, 0x6f, 0x75, 0x74, 0x48, 0x74, 0x6c, 0x63, 0x49, 0x64, 0x88,
+	0x01, 0x01, 0x12, 0x4e, 0x0a, 0x05, 0x73, 0x74, 0x79, 0x6c, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28,
+	0x0e, 0x32, 0x33, 0x2e, 0x63, 0x6c, 0x6e, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x66, 0x6f, 0x72, 0x77,
+	0x61, 0x72, 0x64, 0x73, 0x46, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x73, 0x2e, 0x4c, 0x69, 0x73,
+	0x74, 0x66, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x73, 0x46, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64,
+	0x73, 0x53, 0x74, 0x79, 0x6c, 0x65, 0x48, 0x03, 0x52, 0x05, 0x73, 0x74, 0x79, 0x6c, 0x65, 0x88,
+	0x01, 0x01, 0x12, 0x2b, 0x0a, 0x08, 0x66, 0x65, 0x65, 0x5f, 0x6d, 0x73, 0x61, 0x74, 0x18, 0x07,
 	0x20, 0x01, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x63, 0x6c, 0x6e, 0x2e, 0x41, 0x6d, 0x6f, 0x75, 0x6e,
-	0x74, 0x52, 0x0a, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x4d, 0x73, 0x61, 0x74, 0x12, 0x1e, 0x0a,
-	0x0a, 0x72, 0x69, 0x73, 0x6b, 0x66, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28,
-	0x04, 0x52, 0x0a, 0x72, 0x69, 0x73, 0x6b, 0x66, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x12, 0x17, 0x0a,
-	0x04, 0x63, 0x6c, 0x74, 0x76, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x48, 0x00, 0x52, 0x04, 0x63,
-	0x6c, 0x74, 0x76, 0x88, 0x01, 0x01, 0x12, 0x1b, 0x0a, 0x06, 0x66, 0x72, 0x6f, 0x6d, 0x69, 0x64,
-	0x18, 0x05, 0x20, 0x01, 0x28, 0x0c, 0x48, 0x01, 0x52, 0x06, 0x66, 0x72, 0x6f, 0x6d, 0x69, 0x64,
-	0x88, 0x01, 0x01, 0x12, 0x25, 0x0a, 0x0b, 0x66, 0x75, 0x7a, 0x7a, 0x70, 0x65, 0x72, 0x63, 0x65,
-	0x6e, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0d, 0x48, 0x02, 0x52, 0x0b, 0x66, 0x75, 0x7a, 0x7a,
-	0x70, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x88, 0x01, 0x01, 0x12, 0x18, 0x0a, 0x07, 0x65, 0x78,
-	0x63, 0x6c, 0x75, 0x64, 0x65, 0x18, 0x07, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x65, 0x78, 0x63,
-	0x6c, 0x75, 0x64, 0x65, 0x12, 0x1d, 0x0a, 0x07, 0x6d, 0x61, 0x78, 0x68, 0x6f, 0x70, 0x73, 0x18,
-	0x08, 0x20, 0x01, 0x28, 0x0d, 0x48, 0x03, 0x52, 0x07, 0x6d, 0x61, 0x78, 0x68, 0x6f, 0x70, 0x73,
-	0x88, 0x01, 0x01, 0x42, 0x07, 0x0a, 0x05, 0x5f, 0x63, 0x6c, 0x74, 0x76, 0x42, 0x09, 0x0a, 0x07,
-	0x5f, 0x66, 0x72, 0x6f, 0x6d, 0x69, 0x64, 0x42, 0x0e, 0x0a, 0x0c, 0x5f, 0x66, 0x75, 0x7a, 0x7a,
-	0x70, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x42, 0x0a, 0x0a, 0x08, 0x5f, 0x6d, 0x61, 0x78, 0x68,
-	0x6f, 0x70, 0x73, 0x22, 0x3c, 0x0a, 0x10, 0x47, 0x65, 0x74, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x28, 0x0a, 0x05, 0x72, 0x6f, 0x75, 0x74, 0x65,
-	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x63, 0x6c, 0x6e, 0x2e, 0x47, 0x65, 0x74,
-	0x72, 0x6f, 0x75, 0x74, 0x65, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x52, 0x05, 0x72, 0x6f, 0x75, 0x74,
-	0x65, 0x22, 0xa5, 0x02, 0x0a, 0x0d, 0x47, 0x65, 0x74, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x52, 0x6f,
-	0x75, 0x74, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52,
-	0x02, 0x69, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x12, 0x1c, 0x0a,
-	0x09, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d,
-	0x52, 0x09, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1f, 0x0a, 0x08, 0x6d,
-	0x73, 0x61, 0x74, 0x6f, 0x73, 0x68, 0x69, 0x18, 0x07, 0x20, 0x01, 0x28, 0x04, 0x48, 0x00, 0x52,
-	0x08, 0x6d, 0x73, 0x61, 0x74, 0x6f, 0x73, 0x68, 0x69, 0x88, 0x01, 0x01, 0x12, 0x2c, 0x0a, 0x0b,
-	0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x6d, 0x73, 0x61, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x0b, 0x2e, 0x63, 0x6c, 0x6e, 0x2e, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x0a,
-	0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x4d, 0x73, 0x61, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x64, 0x65,
-	0x6c, 0x61, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x64, 0x65, 0x6c, 0x61, 0x79,
-	0x12, 0x3b, 0x0a, 0x05, 0x73, 0x74, 0x79, 0x6c, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0e, 0x32,
-	0x25, 0x2e, 0x63, 0x6c, 0x6e, 0x2e, 0x47, 0x65, 0x74, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x52, 0x6f,
-	0x75, 0x74, 0x65, 0x2e, 0x47, 0x65, 0x74, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x52, 0x6f, 0x75, 0x74,
-	0x65, 0x53, 0x74, 0x79, 0x6c, 0x65, 0x52, 0x05, 0x73, 0x74, 0x79, 0x6c, 0x65, 0x22, 0x1d, 0x0a,
-	0x12, 0x47, 0x65, 0x74, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x53, 0x74,
-	0x79, 0x6c, 0x65, 0x12, 0x07, 0x0a, 0x03, 0x54, 0x4c, 0x56, 0x10, 0x00, 0x42, 0x0b, 0x0a, 0x09,
-	0x5f, 0x6d, 0x73, 0x61, 0x74, 0x6f, 0x73, 0x68, 0x69, 0x22, 0xa1, 0x02, 0x0a, 0x13, 0x4c, 0x69,
-	0x73, 0x74, 0x66, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x12, 0x48, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x0e, 0x32, 0x2b, 0x2e, 0x63, 0x6c, 0x6e, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x66, 0x6f, 0x72, 0x77,
-	0x61, 0x72, 0x64, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x4c, 0x69, 0x73, 0x74,
-	0x66, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x73, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x48, 0x00,
-	0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x88, 0x01, 0x01, 0x12, 0x22, 0x0a, 0x0a, 0x69,
-	0x6e, 0x5f, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x48,
-	0x01, 0x52, 0x09, 0x69, 0x6e, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x88, 0x01, 0x01, 0x12,
-	0x24, 0x0a, 0x0b, 0x6f, 0x75, 0x74, 0x5f, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x18, 0x03,
-	0x20, 0x01, 0x28, 0x09, 0x48, 0x02, 0x52, 0x0a, 0x6f, 0x75, 0x74, 0x43, 0x68, 0x61, 0x6e, 0x6e,
-	0x65, 0x6c, 0x88, 0x01, 0x01, 0x22, 0x4c, 0x0a, 0x12, 0x4c, 0x69, 0x73, 0x74, 0x66, 0x6f, 0x72,
-	0x77, 0x61, 0x72, 0x64, 0x73, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x0b, 0x0a, 0x07, 0x4f,
-	0x46, 0x46, 0x45, 0x52, 0x45, 0x44, 0x10, 0x00, 0x12, 0x0b, 0x0a, 0x07, 0x53, 0x45, 0x54, 0x54,
-	0x4c, 0x45, 0x44, 0x10, 0x01, 0x12, 0x10, 0x0a, 0x0c, 0x4c, 0x4f, 0x43, 0x41, 0x4c, 0x5f, 0x46,
-	0x41, 0x49, 0x4c, 0x45, 0x44, 0x10, 0x02, 0x12, 0x0a, 0x0a, 0x06, 0x46, 0x41, 0x49, 0x4c, 0x45,
-	0x44, 0x10, 0x03, 0x42, 0x09, 0x0a, 0x07, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x42, 0x0d,
-	0x0a, 0x0b, 0x5f, 0x69, 0x6e, 0x5f, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x42, 0x0e, 0x0a,
-	0x0c, 0x5f, 0x6f, 0x75, 0x74, 0x5f, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x22, 0x4d, 0x0a,
-	0x14, 0x4c, 0x69, 0x73, 0x74, 0x66, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x73, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x35, 0x0a, 0x08, 0x66, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64,
-	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x63, 0x6c, 0x6e, 0x2e, 0x4c, 0x69,
-	0x73, 0x74, 0x66, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x73, 0x46, 0x6f, 0x72, 0x77, 0x61, 0x72,
-	0x64, 0x73, 0x52, 0x08, 0x66, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x73, 0x22, 0xc1, 0x05, 0x0a,
-	0x14, 0x4c, 0x69, 0x73, 0x74, 0x66, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x73, 0x46, 0x6f, 0x72,
-	0x77, 0x61, 0x72, 0x64, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x69, 0x6e, 0x5f, 0x63, 0x68, 0x61, 0x6e,
-	0x6e, 0x65, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x69, 0x6e, 0x43, 0x68, 0x61,
-	0x6e, 0x6e, 0x65, 0x6c, 0x12, 0x21, 0x0a, 0x0a, 0x69, 0x6e, 0x5f, 0x68, 0x74, 0x6c, 0x63, 0x5f,
-	0x69, 0x64, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x04, 0x48, 0x00, 0x52, 0x08, 0x69, 0x6e, 0x48, 0x74,
-	0x6c, 0x63, 0x49, 0x64, 0x88, 0x01, 0x01, 0x12, 0x24, 0x0a, 0x07, 0x69, 0x6e, 0x5f, 0x6d, 0x73,
-	0x61, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x63, 0x6c, 0x6e, 0x2e, 0x41,
-	0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x06, 0x69, 0x6e, 0x4d, 0x73, 0x61, 0x74, 0x12, 0x4c, 0x0a,
-	0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x34, 0x2e,
-	0x63, 0x6c, 0x6e, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x66, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x73,
-	0x46, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x73, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x66, 0x6f, 0x72,
-	0x77, 0x61, 0x72, 0x64, 0x73, 0x46, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x73, 0x53, 0x74, 0x61,
-	0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x23, 0x0a, 0x0d, 0x72,
-	0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x64, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01,
-	0x28, 0x01, 0x52, 0x0c, 0x72, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x64, 0x54, 0x69, 0x6d, 0x65,
-	0x12, 0x24, 0x0a, 0x0b, 0x6f, 0x75, 0x74, 0x5f, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x18,
-	0x05, 0x20, 0x01, 0x28, 0x09, 0x48, 0x01, 0x52, 0x0a, 0x6f, 0x75, 0x74, 0x43, 0x68, 0x61, 0x6e,
-	0x6e, 0x65, 0x6c, 0x88, 0x01, 0x01, 0x12, 0x23, 0x0a, 0x0b, 0x6f, 0x75, 0x74, 0x5f, 0x68, 0x74,
-	0x6c, 0x63, 0x5f, 0x69, 0x64, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x04, 0x48, 0x02, 0x52, 0x09, 0x6f,
-	0x75, 0x74, 0x48, 0x74, 0x6c, 0x63, 0x49, 0x64, 0x88, 0x01, 0x01, 0x12, 0x4e, 0x0a, 0x05, 0x73,
-	0x74, 0x79, 0x6c, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x33, 0x2e, 0x63, 0x6c, 0x6e,
-	0x2e, 0x4c, 0x69, 0x73, 0x74, 0x66, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x73, 0x46, 0x6f, 0x72,
-	0x77, 0x61, 0x72, 0x64, 0x73, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x66, 0x6f, 0x72, 0x77, 0x61, 0x72,
-	0x64, 0x73, 0x46, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x73, 0x53, 0x74, 0x79, 0x6c, 0x65, 0x48,
-	0x03, 0x52, 0x05, 0x73, 0x74, 0x79, 0x6c, 0x65, 0x88, 0x01, 0x01, 0x12, 0x2b, 0x0a, 0x08, 0x66,
-	0x65, 0x65, 0x5f, 0x6d, 0x73, 0x61, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0b, 0x2e,
-	0x63, 0x6c, 0x6e, 0x2e, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x48, 0x04, 0x52, 0x07, 0x66, 0x65,
-	0x65, 0x4d, 0x73, 0x61, 0x74, 0x88, 0x01, 0x01, 0x12, 0x2b, 0x0a, 0x08, 0x6f, 0x75, 0x74, 0x5f,
-	0x6d, 0x73, 0x61, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x63, 0x6c, 0x6e,
-	0x2e, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x48, 0x05, 0x52, 0x07, 0x6f, 0x75, 0x74, 0x4d, 0x73,
-	0x61, 0x74, 0x88, 0x01, 0x01, 0x22, 0x54, 0x0a, 0x1a, 0x4c, 0x69, 0x73, 0x74, 0x66, 0x6f, 0x72,
-	0x77, 0x61, 0x72, 0x64, 0x73, 0x46, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x73, 0x53, 0x74, 0x61,
-	0x74, 0x75, 0x73, 0x12, 0x0b, 0x0a, 0x07, 0x4f, 0x46, 0x46, 0x45, 0x52, 0x45, 0x44, 0x10, 0x00,
-	0x12, 0x0b, 0x0a, 0x07, 0x53, 0x45, 0x54, 0x54, 0x4c, 0x45, 0x44, 0x10, 0x01, 0x12, 0x10, 0x0a,
-	0x0c, 0x4c, 0x4f, 0x43, 0x41, 0x4c, 0x5f, 0x46, 0x41, 0x49, 0x4c, 0x45, 0x44, 0x10, 0x02, 0x12,
-	0x0a, 0x0a, 0x06, 0x46, 0x41, 0x49, 0x4c, 0x45, 0x44, 0x10, 0x03, 0x22, 0x30, 0x0a, 0x19, 0x4c,
-	0x69, 0x73, 0x74, 0x66, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x73, 0x46, 0x6f, 0x72, 0x77, 0x61,
-	0x72, 0x64, 0x73, 0x53, 0x74, 0x79, 0x6c, 0x65, 0x12, 0x0a, 0x0a, 0x06, 0x4c, 0x45, 0x47, 0x41,
-	0x43, 0x59, 0x10, 0x00, 0x12, 0x07, 0x0a, 0x03, 0x54, 0x4c, 0x56, 0x10, 0x01, 0x42, 0x0d, 0x0a,
-	0x0b, 0x5f, 0x69, 0x6e, 0x5f, 0x68, 0x74, 0x6c, 0x63, 0x5f, 0x69, 0x64, 0x42, 0x0e, 0x0a, 0x0c,
-	0x5f, 0x6f, 0x75, 0x74, 0x5f, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x42, 0x0e, 0x0a, 0x0c,
-	0x5f, 0x6f, 0x75, 0x74, 0x5f, 0x68, 0x74, 0x6c, 0x63, 0x5f, 0x69, 0x64, 0x42, 0x08, 0x0a, 0x06,
-	0x5f, 0x73, 0x74, 0x79, 0x6c, 0x65, 0x42, 0x0b, 0x0a, 0x09, 0x5f, 0x66, 0x65, 0x65, 0x5f, 0x6d,
-	0x73, 0x61, 0x74, 0x42, 0x0b, 0x0a, 0x09, 0x5f, 0x6f, 0x75, 0x74, 0x5f, 0x6d, 0x73, 0x61, 0x74,
-	0x22, 0xf8, 0x01, 0x0a, 0x0f, 0x4c, 0x69, 0x73, 0x74, 0x70, 0x61, 0x79, 0x73, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x06, 0x62, 0x6f, 0x6c, 0x74, 0x31, 0x31, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x06, 0x62, 0x6f, 0x6c, 0x74, 0x31, 0x31, 0x88, 0x01,
-	0x01, 0x12, 0x26, 0x0a, 0x0c, 0x70, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x68, 0x61, 0x73,
-	0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x48, 0x01, 0x52, 0x0b, 0x70, 0x61, 0x79, 0x6d, 0x65,
-	0x6e, 0x74, 0x48, 0x61, 0x73, 0x68, 0x88, 0x01, 0x01, 0x12, 0x40, 0x0a, 0x06, 0x73, 0x74, 0x61,
-	0x74, 0x75, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x23, 0x2e, 0x63, 0x6c, 0x6e, 0x2e,
-	0x4c, 0x69, 0x73, 0x74, 0x70, 0x61, 0x79, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e,
-	0x4c, 0x69, 0x73, 0x74, 0x70, 0x61, 0x79, 0x73, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x48, 0x02,
-	0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x88, 0x01, 0x01, 0x22, 0x37, 0x0a, 0x0e, 0x4c,
-	0x69, 0x73, 0x74, 0x70, 0x61, 0x79, 0x73, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x0b, 0x0a,
-	0x07, 0x50, 0x45, 0x4e, 0x44, 0x49, 0x4e, 0x47, 0x10, 0x00, 0x12, 0x0c, 0x0a, 0x08, 0x43, 0x4f,
-	0x4d, 0x50, 0x4c, 0x45, 0x54, 0x45, 0x10, 0x01, 0x12, 0x0a, 0x0a, 0x06, 0x46, 0x41, 0x49, 0x4c,
-	0x45, 0x44, 0x10, 0x02, 0x42, 0x09, 0x0a, 0x07, 0x5f, 0x62, 0x6f, 0x6c, 0x74, 0x31, 0x31, 0x42,
-	0x0f, 0x0a, 0x0d, 0x5f, 0x70, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x68, 0x61, 0x73, 0x68,
-	0x42, 0x09, 0x0a, 0x07, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0x39, 0x0a, 0x10, 0x4c,
-	0x69, 0x73, 0x74, 0x70, 0x61, 0x79, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
-	0x25, 0x0a, 0x04, 0x70, 0x61, 0x79, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e,
-	0x63, 0x6c, 0x6e, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x70, 0x61, 0x79, 0x73, 0x50, 0x61, 0x79, 0x73,
-	0x52, 0x04, 0x70, 0x61, 0x79, 0x73, 0x22, 0x8a, 0x05, 0x0a, 0x0c, 0x4c, 0x69, 0x73, 0x74, 0x70,
-	0x61, 0x79, 0x73, 0x50, 0x61, 0x79, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x70, 0x61, 0x79, 0x6d, 0x65,
-	0x6e, 0x74, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0b, 0x70,
-	0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x48, 0x61, 0x73, 0x68, 0x12, 0x3c, 0x0a, 0x06, 0x73, 0x74,
-	0x61, 0x74, 0x75, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x24, 0x2e, 0x63, 0x6c, 0x6e,
-	0x2e, 0x4c, 0x69, 0x73, 0x74, 0x70, 0x61, 0x79, 0x73, 0x50, 0x61, 0x79, 0x73, 0x2e, 0x4c, 0x69,
-	0x73, 0x74, 0x70, 0x61, 0x79, 0x73, 0x50, 0x61, 0x79, 0x73, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
-	0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x25, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x74,
-	0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x48, 0x00, 0x52,
-	0x0b, 0x64, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x88, 0x01, 0x01, 0x12,
-	0x1d, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x04, 0x20,
-	0x01, 0x28, 0x04, 0x52, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x26,
-	0x0a, 0x0c, 0x63, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x0c,
-	0x20, 0x01, 0x28, 0x04, 0x48, 0x01, 0x52, 0x0b, 0x63, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65,
-	0x64, 0x41, 0x74, 0x88, 0x01, 0x01, 0x12, 0x19, 0x0a, 0x05, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x18,
-	0x05, 0x20, 0x01, 0x28, 0x09, 0x48, 0x02, 0x52, 0x05, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x88, 0x01,
-	0x01, 0x12, 0x1b, 0x0a, 0x06, 0x62, 0x6f, 0x6c, 0x74, 0x31, 0x31, 0x18, 0x06, 0x20, 0x01, 0x28,
-	0x09, 0x48, 0x03, 0x52, 0x06, 0x62, 0x6f, 0x6c, 0x74, 0x31, 0x31, 0x88, 0x01, 0x01, 0x12, 0x25,
-	0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x0b, 0x20,
-	0x01, 0x28, 0x09, 0x48, 0x04, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69,
-	0x6f, 0x6e, 0x88, 0x01, 0x01, 0x12, 0x1b, 0x0a, 0x06, 0x62, 0x6f, 0x6c, 0x74, 0x31, 0x32, 0x18,
-	0x07, 0x20, 0x01, 0x28, 0x09, 0x48, 0x05, 0x52, 0x06, 0x62, 0x6f, 0x6c, 0x74, 0x31, 0x32, 0x88,
-	0x01, 0x01, 0x12, 0x1f, 0x0a, 0x08, 0x70, 0x72, 0x65, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x18, 0x0d,
-	0x20, 0x01, 0x28, 0x0c, 0x48, 0x06, 0x52, 0x08, 0x70, 0x72, 0x65, 0x69, 0x6d, 0x61, 0x67, 0x65,
-	0x88, 0x01, 0x01, 0x12, 0x2b, 0x0a, 0x0f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x5f, 0x6f, 0x66,
-	0x5f, 0x70, 0x61, 0x72, 0x74, 0x73, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x04, 0x48, 0x07, 0x52, 0x0d,
-	0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x4f, 0x66, 0x50, 0x61, 0x72, 0x74, 0x73, 0x88, 0x01, 0x01,
-	0x12, 0x23, 0x0a, 0x0a, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x6f, 0x6e, 0x69, 0x6f, 0x6e, 0x18, 0x0a,
-	0x20, 0x01, 0x28, 0x0c, 0x48, 0x08, 0x52, 0x0a, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x6f, 0x6e, 0x69,
-	0x6f, 0x6e, 0x88, 0x01, 0x01, 0x22, 0x3b, 0x0a, 0x12, 0x4c, 0x69, 0x73, 0x74, 0x70, 0x61, 0x79,
-	0x73, 0x50, 0x61, 0x79, 0x73, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x0b, 0x0a, 0x07, 0x50,
-	0x45, 0x4e, 0x44, 0x49, 0x4e, 0x47, 0x10, 0x00, 0x12, 0x0a, 0x0a, 0x06, 0x46, 0x41, 0x49, 0x4c,
-	0x45, 0x44, 0x10, 0x01, 0x12, 0x0c, 0x0a, 0x08, 0x43, 0x4f, 0x4d, 0x50, 0x4c, 0x45, 0x54, 0x45,
-	0x10, 0x02, 0x42, 0x0e, 0x0a, 0x0c, 0x5f, 0x64, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69,
-	0x6f, 0x6e, 0x42, 0x0f, 0x0a, 0x0d, 0x5f, 0x63, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x64,
-	0x5f, 0x61, 0x74, 0x42, 0x08, 0x0a, 0x06, 0x5f, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x42, 0x09, 0x0a,
-	0x07, 0x5f, 0x62, 0x6f, 0x6c, 0x74, 0x31, 0x31, 0x42, 0x0e, 0x0a, 0x0c, 0x5f, 0x64, 0x65, 0x73,
-	0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x42, 0x09, 0x0a, 0x07, 0x5f, 0x62, 0x6f, 0x6c,
-	0x74, 0x31, 0x32, 0x42, 0x0b, 0x0a, 0x09, 0x5f, 0x70, 0x72, 0x65, 0x69, 0x6d, 0x61, 0x67, 0x65,
-	0x42, 0x12, 0x0a, 0x10, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x5f, 0x6f, 0x66, 0x5f, 0x70,
-	0x61, 0x72, 0x74, 0x73, 0x42, 0x0d, 0x0a, 0x0b, 0x5f, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x6f, 0x6e,
-	0x69, 0x6f, 0x6e, 0x22, 0x6d, 0x0a, 0x0b, 0x50, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x02,
-	0x69, 0x64, 0x12, 0x15, 0x0a, 0x03, 0x6c, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x48,
-	0x00, 0x52, 0x03, 0x6c, 0x65, 0x6e, 0x88, 0x01, 0x01, 0x12, 0x21, 0x0a, 0x09, 0x70, 0x6f, 0x6e,
-	0x67, 0x62, 0x79, 0x74, 0x65, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x48, 0x01, 0x52, 0x09,
-	0x70, 0x6f, 0x6e, 0x67, 0x62, 0x79, 0x74, 0x65, 0x73, 0x88, 0x01, 0x01, 0x42, 0x06, 0x0a, 0x04,
-	0x5f, 0x6c, 0x65, 0x6e, 0x42, 0x0c, 0x0a, 0x0a, 0x5f, 0x70, 0x6f, 0x6e, 0x67, 0x62, 0x79, 0x74,
-	0x65, 0x73, 0x22, 0x26, 0x0a, 0x0c, 0x50, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x74, 0x6f, 0x74, 0x6c, 0x65, 0x6e, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x0d, 0x52, 0x06, 0x74, 0x6f, 0x74, 0x6c, 0x65, 0x6e, 0x22, 0xad, 0x02, 0x0a, 0x11, 0x53,
-	0x65, 0x74, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64,
-	0x12, 0x2a, 0x0a, 0x07, 0x66, 0x65, 0x65, 0x62, 0x61, 0x73, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x0b, 0x2e, 0x63, 0x6c, 0x6e, 0x2e, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x48, 0x00,
-	0x52, 0x07, 0x66, 0x65, 0x65, 0x62, 0x61, 0x73, 0x65, 0x88, 0x01, 0x01, 0x12, 0x1b, 0x0a, 0x06,
-	0x66, 0x65, 0x65, 0x70, 0x70, 0x6d, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x48, 0x01, 0x52, 0x06,
-	0x66, 0x65, 0x65, 0x70, 0x70, 0x6d, 0x88, 0x01, 0x01, 0x12, 0x2a, 0x0a, 0x07, 0x68, 0x74, 0x6c,
-	0x63, 0x6d, 0x69, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x63, 0x6c, 0x6e,
-	0x2e, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x48, 0x02, 0x52, 0x07, 0x68, 0x74, 0x6c, 0x63, 0x6d,
-	0x69, 0x6e, 0x88, 0x01, 0x01, 0x12, 0x2a, 0x0a, 0x07, 0x68, 0x74, 0x6c, 0x63, 0x6d, 0x61, 0x78,
-	0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x63, 0x6c, 0x6e, 0x2e, 0x41, 0x6d, 0x6f,
-	0x75, 0x6e, 0x74, 0x48, 0x03, 0x52, 0x07, 0x68, 0x74, 0x6c, 0x63, 0x6d, 0x61, 0x78, 0x88, 0x01,
-	0x01, 0x12, 0x27, 0x0a, 0x0c, 0x65, 0x6e, 0x66, 0x6f, 0x72, 0x63, 0x65, 0x64, 0x65, 0x6c, 0x61,
-	0x79, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0d, 0x48, 0x04, 0x52, 0x0c, 0x65, 0x6e, 0x66, 0x6f, 0x72,
-	0x63, 0x65, 0x64, 0x65, 0x6c, 0x61, 0x79, 0x88, 0x01, 0x01, 0x42, 0x0a, 0x0a, 0x08, 0x5f, 0x66,
-	0x65, 0x65, 0x62, 0x61, 0x73, 0x65, 0x42, 0x09, 0x0a, 0x07, 0x5f, 0x66, 0x65, 0x65, 0x70, 0x70,
-	0x6d, 0x42, 0x0a, 0x0a, 0x08, 0x5f, 0x68, 0x74, 0x6c, 0x63, 0x6d, 0x69, 0x6e, 0x42, 0x0a, 0x0a,
-	0x08, 0x5f, 0x68, 0x74, 0x6c, 0x63, 0x6d, 0x61, 0x78, 0x42, 0x0f, 0x0a, 0x0d, 0x5f, 0x65, 0x6e,
-	0x66, 0x6f, 0x72, 0x63, 0x65, 0x64, 0x65, 0x6c, 0x61, 0x79, 0x22, 0x49, 0x0a, 0x12, 0x53, 0x65,
-	0x74, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x12, 0x33, 0x0a, 0x08, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x73, 0x18, 0x01, 0x20, 0x03,
-	0x28, 0x0b, 0x32, 0x17, 0x2e, 0x63, 0x6c, 0x6e, 0x2e, 0x53, 0x65, 0x74, 0x63, 0x68, 0x61, 0x6e,
-	0x6e, 0x65, 0x6c, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x73, 0x52, 0x08, 0x63, 0x68, 0x61,
-	0x6e, 0x6e, 0x65, 0x6c, 0x73, 0x22, 0xb4, 0x04, 0x0a, 0x12, 0x53, 0x65, 0x74, 0x63, 0x68, 0x61,
-	0x6e, 0x6e, 0x65, 0x6c, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x73, 0x12, 0x17, 0x0a, 0x07,
-	0x70, 0x65, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x70,
-	0x65, 0x65, 0x72, 0x49, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c,
-	0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x63, 0x68, 0x61, 0x6e, 0x6e,
-	0x65, 0x6c, 0x49, 0x64, 0x12, 0x2d, 0x0a, 0x10, 0x73, 0x68, 0x6f, 0x72, 0x74, 0x5f, 0x63, 0x68,
-	0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00,
-	0x52, 0x0e, 0x73, 0x68, 0x6f, 0x72, 0x74, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x49, 0x64,
-	0x88, 0x01, 0x01, 0x12, 0x2f, 0x0a, 0x0d, 0x66, 0x65, 0x65, 0x5f, 0x62, 0x61, 0x73, 0x65, 0x5f,
-	0x6d, 0x73, 0x61, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x63, 0x6c, 0x6e,
-	0x2e, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x0b, 0x66, 0x65, 0x65, 0x42, 0x61, 0x73, 0x65,
-	0x4d, 0x73, 0x61, 0x74, 0x12, 0x3e, 0x0a, 0x1b, 0x66, 0x65, 0x65, 0x5f, 0x70, 0x72, 0x6f, 0x70,
-	0x6f, 0x72, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x5f, 0x6d, 0x69, 0x6c, 0x6c, 0x69, 0x6f, 0x6e,
-	0x74, 0x68, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x19, 0x66, 0x65, 0x65, 0x50, 0x72,
-	0x6f, 0x70, 0x6f, 0x72, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x4d, 0x69, 0x6c, 0x6c, 0x69, 0x6f,
-	0x6e, 0x74, 0x68, 0x73, 0x12, 0x3e, 0x0a, 0x15, 0x6d, 0x69, 0x6e, 0x69, 0x6d, 0x75, 0x6d, 0x5f,
-	0x68, 0x74, 0x6c, 0x63, 0x5f, 0x6f, 0x75, 0x74, 0x5f, 0x6d, 0x73, 0x61, 0x74, 0x18, 0x06, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x63, 0x6c, 0x6e, 0x2e, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74,
-	0x52, 0x12, 0x6d, 0x69, 0x6e, 0x69, 0x6d, 0x75, 0x6d, 0x48, 0x74, 0x6c, 0x63, 0x4f, 0x75, 0x74,
-	0x4d, 0x73, 0x61, 0x74, 0x12, 0x3a, 0x0a, 0x17, 0x77, 0x61, 0x72, 0x6e, 0x69, 0x6e, 0x67, 0x5f,
-	0x68, 0x74, 0x6c, 0x63, 0x6d, 0x69, 0x6e, 0x5f, 0x74, 0x6f, 0x6f, 0x5f, 0x6c, 0x6f, 0x77, 0x18,
-	0x07, 0x20, 0x01, 0x28, 0x09, 0x48, 0x01, 0x52, 0x14, 0x77, 0x61, 0x72, 0x6e, 0x69, 0x6e, 0x67,
-	0x48, 0x74, 0x6c, 0x63, 0x6d, 0x69, 0x6e, 0x54, 0x6f, 0x6f, 0x4c, 0x6f, 0x77, 0x88, 0x01, 0x01,
-	0x12, 0x3e, 0x0a, 0x15, 0x6d, 0x61, 0x78, 0x69, 0x6d, 0x75, 0x6d, 0x5f, 0x68, 0x74, 0x6c, 0x63,
-	0x5f, 0x6f, 0x75, 0x74, 0x5f, 0x6d, 0x73, 0x61, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x0b, 0x2e, 0x63, 0x6c, 0x6e, 0x2e, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x12, 0x6d, 0x61,
-	0x78, 0x69, 0x6d, 0x75, 0x6d, 0x48, 0x74, 0x6c, 0x63, 0x4f, 0x75, 0x74, 0x4d, 0x73, 0x61, 0x74,
-	0x12, 0x3c, 0x0a, 0x18, 0x77, 0x61, 0x72, 0x6e, 0x69, 0x6e, 0x67, 0x5f, 0x68, 0x74, 0x6c, 0x63,
-	0x6d, 0x61, 0x78, 0x5f, 0x74, 0x6f, 0x6f, 0x5f, 0x68, 0x69, 0x67, 0x68, 0x18, 0x09, 0x20, 0x01,
-	0x28, 0x09, 0x48, 0x02, 0x52, 0x15, 0x77, 0x61, 0x72, 0x6e, 0x69, 0x6e, 0x67, 0x48, 0x74, 0x6c,
-	0x63, 0x6d, 0x61, 0x78, 0x54, 0x6f, 0x6f, 0x48, 0x69, 0x67, 0x68, 0x88, 0x01, 0x01, 0x42, 0x13,
-	0x0a, 0x11, 0x5f, 0x73, 0x68, 0x6f, 0x72, 0x74, 0x5f, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c,
-	0x5f, 0x69, 0x64, 0x42, 0x1a, 0x0a, 0x18, 0x5f, 0x77, 0x61, 0x72, 0x6e, 0x69, 0x6e, 0x67, 0x5f,
-	0x68, 0x74, 0x6c, 0x63, 0x6d, 0x69, 0x6e, 0x5f, 0x74, 0x6f, 0x6f, 0x5f, 0x6c, 0x6f, 0x77, 0x42,
-	0x1b, 0x0a, 0x19, 0x5f, 0x77, 0x61, 0x72, 0x6e, 0x69, 0x6e, 0x67, 0x5f, 0x68, 0x74, 0x6c, 0x63,
-	0x6d, 0x61, 0x78, 0x5f, 0x74, 0x6f, 0x6f, 0x5f, 0x68, 0x69, 0x67, 0x68, 0x22, 0x2e, 0x0a, 0x12,
-	0x53, 0x69, 0x67, 0x6e, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x5f, 0x0a, 0x13,
-	0x53, 0x69, 0x67, 0x6e, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72,
-	0x65, 0x12, 0x14, 0x0a, 0x05, 0x72, 0x65, 0x63, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c,
-	0x52, 0x05, 0x72, 0x65, 0x63, 0x69, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x7a, 0x62, 0x61, 0x73, 0x65,
-	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x7a, 0x62, 0x61, 0x73, 0x65, 0x22, 0x0d, 0x0a,
-	0x0b, 0x53, 0x74, 0x6f, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x0e, 0x0a, 0x0c,
-	0x53, 0x74, 0x6f, 0x70, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x32, 0xb1, 0x17, 0x0a,
-	0x04, 0x4e, 0x6f, 0x64, 0x65, 0x12, 0x36, 0x0a, 0x07, 0x47, 0x65, 0x74, 0x69, 0x6e, 0x66, 0x6f,
-	0x12, 0x13, 0x2e, 0x63, 0x6c, 0x6e, 0x2e, 0x47, 0x65, 0x74, 0x69, 0x6e, 0x66, 0x6f, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x63, 0x6c, 0x6e, 0x2e, 0x47, 0x65, 0x74, 0x69,
-	0x6e, 0x66, 0x6f, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x3c, 0x0a,
-	0x09, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x65, 0x65, 0x72, 0x73, 0x12, 0x15, 0x2e, 0x63, 0x6c, 0x6e,
-	0x2e, 0x4c, 0x69, 0x73, 0x74, 0x70, 0x65, 0x65, 0x72, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x1a, 0x16, 0x2e, 0x63, 0x6c, 0x6e, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x70, 0x65, 0x65, 0x72,
-	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x3c, 0x0a, 0x09, 0x4c,
-	0x69, 0x73, 0x74, 0x46, 0x75, 0x6e, 0x64, 0x73, 0x12, 0x15, 0x2e, 0x63, 0x6c, 0x6e, 0x2e, 0x4c,
-	0x69, 0x73, 0x74, 0x66, 0x75, 0x6e, 0x64, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
-	0x16, 0x2e, 0x63, 0x6c, 0x6e, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x66, 0x75, 0x6e, 0x64, 0x73, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x36, 0x0a, 0x07, 0x53, 0x65, 0x6e,
-	0x64, 0x50, 0x61, 0x79, 0x12, 0x13, 0x2e, 0x63, 0x6c, 0x6e, 0x2e, 0x53, 0x65, 0x6e, 0x64, 0x70,
-	0x61, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x63, 0x6c, 0x6e, 0x2e,
-	0x53, 0x65, 0x6e, 0x64, 0x70, 0x61, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
-	0x00, 0x12, 0x45, 0x0a, 0x0c, 0x4c, 0x69, 0x73, 0x74, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c,
-	0x73, 0x12, 0x18, 0x2e, 0x63, 0x6c, 0x6e, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x63, 0x68, 0x61, 0x6e,
-	0x6e, 0x65, 0x6c, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x63, 0x6c,
-	0x6e, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x73, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x3c, 0x0a, 0x09, 0x41, 0x64, 0x64, 0x47,
-	0x6f, 0x73, 0x73, 0x69, 0x70, 0x12, 0x15, 0x2e, 0x63, 0x6c, 0x6e, 0x2e, 0x41, 0x64, 0x64, 0x67,
-	0x6f, 0x73, 0x73, 0x69, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x63,
-	0x6c, 0x6e, 0x2e, 0x41, 0x64, 0x64, 0x67, 0x6f, 0x73, 0x73, 0x69, 0x70, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x51, 0x0a, 0x10, 0x41, 0x75, 0x74, 0x6f, 0x43, 0x6c,
-	0x65, 0x61, 0x6e, 0x49, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x12, 0x1c, 0x2e, 0x63, 0x6c, 0x6e,
-	0x2e, 0x41, 0x75, 0x74, 0x6f, 0x63, 0x6c, 0x65, 0x61, 0x6e, 0x69, 0x6e, 0x76, 0x6f, 0x69, 0x63,
-	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x63, 0x6c, 0x6e, 0x2e, 0x41,
-	0x75, 0x74, 0x6f, 0x63, 0x6c, 0x65, 0x61, 0x6e, 0x69, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x45, 0x0a, 0x0c, 0x43, 0x68, 0x65,
-	0x63, 0x6b, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x18, 0x2e, 0x63, 0x6c, 0x6e, 0x2e,
-	0x43, 0x68, 0x65, 0x63, 0x6b, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x63, 0x6c, 0x6e, 0x2e, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x6d,
-	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00,
-	0x12, 0x30, 0x0a, 0x05, 0x43, 0x6c, 0x6f, 0x73, 0x65, 0x12, 0x11, 0x2e, 0x63, 0x6c, 0x6e, 0x2e,
-	0x43, 0x6c, 0x6f, 0x73, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x12, 0x2e, 0x63,
-	0x6c, 0x6e, 0x2e, 0x43, 0x6c, 0x6f, 0x73, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x22, 0x00, 0x12, 0x3a, 0x0a, 0x0b, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x50, 0x65, 0x65,
-	0x72, 0x12, 0x13, 0x2e, 0x63, 0x6c, 0x6e, 0x2e, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x63, 0x6c, 0x6e, 0x2e, 0x43, 0x6f, 0x6e,
-	0x6e, 0x65, 0x63, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x48,
-	0x0a, 0x0d, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x49, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x12,
-	0x19, 0x2e, 0x63, 0x6c, 0x6e, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x69, 0x6e, 0x76, 0x6f,
-	0x69, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x63, 0x6c, 0x6e,
-	0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x69, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x3c, 0x0a, 0x09, 0x44, 0x61, 0x74, 0x61,
-	0x73, 0x74, 0x6f, 0x72, 0x65, 0x12, 0x15, 0x2e, 0x63, 0x6c, 0x6e, 0x2e, 0x44, 0x61, 0x74, 0x61,
-	0x73, 0x74, 0x6f, 0x72, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x63,
-	0x6c, 0x6e, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x42, 0x0a, 0x0b, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65,
-	0x4f, 0x6e, 0x69, 0x6f, 0x6e, 0x12, 0x17, 0x2e, 0x63, 0x6c, 0x6e, 0x2e, 0x43, 0x72, 0x65, 0x61,
-	0x74, 0x65, 0x6f, 0x6e, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18,
-	0x2e, 0x63, 0x6c, 0x6e, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x6f, 0x6e, 0x69, 0x6f, 0x6e,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x45, 0x0a, 0x0c, 0x44, 0x65,
-	0x6c, 0x44, 0x61, 0x74, 0x61, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x12, 0x18, 0x2e, 0x63, 0x6c, 0x6e,
-	0x2e, 0x44, 0x65, 0x6c, 0x64, 0x61, 0x74, 0x61, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x63, 0x6c, 0x6e, 0x2e, 0x44, 0x65, 0x6c, 0x64, 0x61,
-	0x74, 0x61, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
-	0x00, 0x12, 0x54, 0x0a, 0x11, 0x44, 0x65, 0x6c, 0x45, 0x78, 0x70, 0x69, 0x72, 0x65, 0x64, 0x49,
-	0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x12, 0x1d, 0x2e, 0x63, 0x6c, 0x6e, 0x2e, 0x44, 0x65, 0x6c,
-	0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x64, 0x69, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x63, 0x6c, 0x6e, 0x2e, 0x44, 0x65, 0x6c, 0x65,
-	0x78, 0x70, 0x69, 0x72, 0x65, 0x64, 0x69, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x3f, 0x0a, 0x0a, 0x44, 0x65, 0x6c, 0x49, 0x6e,
-	0x76, 0x6f, 0x69, 0x63, 0x65, 0x12, 0x16, 0x2e, 0x63, 0x6c, 0x6e, 0x2e, 0x44, 0x65, 0x6c, 0x69,
-	0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e,
-	0x63, 0x6c, 0x6e, 0x2e, 0x44, 0x65, 0x6c, 0x69, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x36, 0x0a, 0x07, 0x49, 0x6e, 0x76, 0x6f,
-	0x69, 0x63, 0x65, 0x12, 0x13, 0x2e, 0x63, 0x6c, 0x6e, 0x2e, 0x49, 0x6e, 0x76, 0x6f, 0x69, 0x63,
-	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x63, 0x6c, 0x6e, 0x2e, 0x49,
-	0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00,
-	0x12, 0x48, 0x0a, 0x0d, 0x4c, 0x69, 0x73, 0x74, 0x44, 0x61, 0x74, 0x61, 0x73, 0x74, 0x6f, 0x72,
-	0x65, 0x12, 0x19, 0x2e, 0x63, 0x6c, 0x6e, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x64, 0x61, 0x74, 0x61,
-	0x73, 0x74, 0x6f, 0x72, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x63,
-	0x6c, 0x6e, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x64, 0x61, 0x74, 0x61, 0x73, 0x74, 0x6f, 0x72, 0x65,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x45, 0x0a, 0x0c, 0x4c, 0x69,
-	0x73, 0x74, 0x49, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x73, 0x12, 0x18, 0x2e, 0x63, 0x6c, 0x6e,
-	0x2e, 0x4c, 0x69, 0x73, 0x74, 0x69, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x73, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x63, 0x6c, 0x6e, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x69,
-	0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
-	0x00, 0x12, 0x3c, 0x0a, 0x09, 0x53, 0x65, 0x6e, 0x64, 0x4f, 0x6e, 0x69, 0x6f, 0x6e, 0x12, 0x15,
-	0x2e, 0x63, 0x6c, 0x6e, 0x2e, 0x53, 0x65, 0x6e, 0x64, 0x6f, 0x6e, 0x69, 0x6f, 0x6e, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x63, 0x6c, 0x6e, 0x2e, 0x53, 0x65, 0x6e, 0x64,
-	0x6f, 0x6e, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12,
-	0x45, 0x0a, 0x0c, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x65, 0x6e, 0x64, 0x50, 0x61, 0x79, 0x73, 0x12,
-	0x18, 0x2e, 0x63, 0x6c, 0x6e, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x73, 0x65, 0x6e, 0x64, 0x70, 0x61,
-	0x79, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x63, 0x6c, 0x6e, 0x2e,
-	0x4c, 0x69, 0x73, 0x74, 0x73, 0x65, 0x6e, 0x64, 0x70, 0x61, 0x79, 0x73, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x51, 0x0a, 0x10, 0x4c, 0x69, 0x73, 0x74, 0x54, 0x72,
-	0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x1c, 0x2e, 0x63, 0x6c, 0x6e,
-	0x2e, 0x4c, 0x69, 0x73, 0x74, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e,
-	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x63, 0x6c, 0x6e, 0x2e, 0x4c,
-	0x69, 0x73, 0x74, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x2a, 0x0a, 0x03, 0x50, 0x61, 0x79,
-	0x12, 0x0f, 0x2e, 0x63, 0x6c, 0x6e, 0x2e, 0x50, 0x61, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x1a, 0x10, 0x2e, 0x63, 0x6c, 0x6e, 0x2e, 0x50, 0x61, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x3c, 0x0a, 0x09, 0x4c, 0x69, 0x73, 0x74, 0x4e, 0x6f, 0x64,
-	0x65, 0x73, 0x12, 0x15, 0x2e, 0x63, 0x6c, 0x6e, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x6e, 0x6f, 0x64,
-	0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x63, 0x6c, 0x6e, 0x2e,
-	0x4c, 0x69, 0x73, 0x74, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x22, 0x00, 0x12, 0x4b, 0x0a, 0x0e, 0x57, 0x61, 0x69, 0x74, 0x41, 0x6e, 0x79, 0x49, 0x6e,
-	0x76, 0x6f, 0x69, 0x63, 0x65, 0x12, 0x1a, 0x2e, 0x63, 0x6c, 0x6e, 0x2e, 0x57, 0x61, 0x69, 0x74,
-	0x61, 0x6e, 0x79, 0x69, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x1a, 0x1b, 0x2e, 0x63, 0x6c, 0x6e, 0x2e, 0x57, 0x61, 0x69, 0x74, 0x61, 0x6e, 0x79, 0x69,
+	0x74, 0x48, 0x04, 0x52, 0x07, 0x66, 0x65, 0x65, 0x4d, 0x73, 0x61, 0x74, 0x88, 0x01, 0x01, 0x12,
+	0x2b, 0x0a, 0x08, 0x6f, 0x75, 0x74, 0x5f, 0x6d, 0x73, 0x61, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x0b, 0x2e, 0x63, 0x6c, 0x6e, 0x2e, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x48, 0x05,
+	0x52, 0x07, 0x6f, 0x75, 0x74, 0x4d, 0x73, 0x61, 0x74, 0x88, 0x01, 0x01, 0x22, 0x54, 0x0a, 0x1a,
+	0x4c, 0x69, 0x73, 0x74, 0x66, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x73, 0x46, 0x6f, 0x72, 0x77,
+	0x61, 0x72, 0x64, 0x73, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x0b, 0x0a, 0x07, 0x4f, 0x46,
+	0x46, 0x45, 0x52, 0x45, 0x44, 0x10, 0x00, 0x12, 0x0b, 0x0a, 0x07, 0x53, 0x45, 0x54, 0x54, 0x4c,
+	0x45, 0x44, 0x10, 0x01, 0x12, 0x10, 0x0a, 0x0c, 0x4c, 0x4f, 0x43, 0x41, 0x4c, 0x5f, 0x46, 0x41,
+	0x49, 0x4c, 0x45, 0x44, 0x10, 0x02, 0x12, 0x0a, 0x0a, 0x06, 0x46, 0x41, 0x49, 0x4c, 0x45, 0x44,
+	0x10, 0x03, 0x22, 0x30, 0x0a, 0x19, 0x4c, 0x69, 0x73, 0x74, 0x66, 0x6f, 0x72, 0x77, 0x61, 0x72,
+	0x64, 0x73, 0x46, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x73, 0x53, 0x74, 0x79, 0x6c, 0x65, 0x12,
+	0x0a, 0x0a, 0x06, 0x4c, 0x45, 0x47, 0x41, 0x43, 0x59, 0x10, 0x00, 0x12, 0x07, 0x0a, 0x03, 0x54,
+	0x4c, 0x56, 0x10, 0x01, 0x42, 0x0d, 0x0a, 0x0b, 0x5f, 0x69, 0x6e, 0x5f, 0x68, 0x74, 0x6c, 0x63,
+	0x5f, 0x69, 0x64, 0x42, 0x0e, 0x0a, 0x0c, 0x5f, 0x6f, 0x75, 0x74, 0x5f, 0x63, 0x68, 0x61, 0x6e,
+	0x6e, 0x65, 0x6c, 0x42, 0x0e, 0x0a, 0x0c, 0x5f, 0x6f, 0x75, 0x74, 0x5f, 0x68, 0x74, 0x6c, 0x63,
+	0x5f, 0x69, 0x64, 0x42, 0x08, 0x0a, 0x06, 0x5f, 0x73, 0x74, 0x79, 0x6c, 0x65, 0x42, 0x0b, 0x0a,
+	0x09, 0x5f, 0x66, 0x65, 0x65, 0x5f, 0x6d, 0x73, 0x61, 0x74, 0x42, 0x0b, 0x0a, 0x09, 0x5f, 0x6f,
+	0x75, 0x74, 0x5f, 0x6d, 0x73, 0x61, 0x74, 0x22, 0xf8, 0x01, 0x0a, 0x0f, 0x4c, 0x69, 0x73, 0x74,
+	0x70, 0x61, 0x79, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x06, 0x62,
+	0x6f, 0x6c, 0x74, 0x31, 0x31, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x06, 0x62,
+	0x6f, 0x6c, 0x74, 0x31, 0x31, 0x88, 0x01, 0x01, 0x12, 0x26, 0x0a, 0x0c, 0x70, 0x61, 0x79, 0x6d,
+	0x65, 0x6e, 0x74, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x48, 0x01,
+	0x52, 0x0b, 0x70, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x48, 0x61, 0x73, 0x68, 0x88, 0x01, 0x01,
+	0x12, 0x40, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e,
+	0x32, 0x23, 0x2e, 0x63, 0x6c, 0x6e, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x70, 0x61, 0x79, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x70, 0x61, 0x79, 0x73, 0x53,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x48, 0x02, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x88,
+	0x01, 0x01, 0x22, 0x37, 0x0a, 0x0e, 0x4c, 0x69, 0x73, 0x74, 0x70, 0x61, 0x79, 0x73, 0x53, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x12, 0x0b, 0x0a, 0x07, 0x50, 0x45, 0x4e, 0x44, 0x49, 0x4e, 0x47, 0x10,
+	0x00, 0x12, 0x0c, 0x0a, 0x08, 0x43, 0x4f, 0x4d, 0x50, 0x4c, 0x45, 0x54, 0x45, 0x10, 0x01, 0x12,
+	0x0a, 0x0a, 0x06, 0x46, 0x41, 0x49, 0x4c, 0x45, 0x44, 0x10, 0x02, 0x42, 0x09, 0x0a, 0x07, 0x5f,
+	0x62, 0x6f, 0x6c, 0x74, 0x31, 0x31, 0x42, 0x0f, 0x0a, 0x0d, 0x5f, 0x70, 0x61, 0x79, 0x6d, 0x65,
+	0x6e, 0x74, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x42, 0x09, 0x0a, 0x07, 0x5f, 0x73, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x22, 0x39, 0x0a, 0x10, 0x4c, 0x69, 0x73, 0x74, 0x70, 0x61, 0x79, 0x73, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x25, 0x0a, 0x04, 0x70, 0x61, 0x79, 0x73, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x63, 0x6c, 0x6e, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x70,
+	0x61, 0x79, 0x73, 0x50, 0x61, 0x79, 0x73, 0x52, 0x04, 0x70, 0x61, 0x79, 0x73, 0x22, 0x8a, 0x05,
+	0x0a, 0x0c, 0x4c, 0x69, 0x73, 0x74, 0x70, 0x61, 0x79, 0x73, 0x50, 0x61, 0x79, 0x73, 0x12, 0x21,
+	0x0a, 0x0c, 0x70, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x0b, 0x70, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x48, 0x61, 0x73,
+	0x68, 0x12, 0x3c, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0e, 0x32, 0x24, 0x2e, 0x63, 0x6c, 0x6e, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x70, 0x61, 0x79, 0x73,
+	0x50, 0x61, 0x79, 0x73, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x70, 0x61, 0x79, 0x73, 0x50, 0x61, 0x79,
+	0x73, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12,
+	0x25, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x0c, 0x48, 0x00, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x88, 0x01, 0x01, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65,
+	0x64, 0x5f, 0x61, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x63, 0x72, 0x65, 0x61,
+	0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x26, 0x0a, 0x0c, 0x63, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74,
+	0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x04, 0x48, 0x01, 0x52, 0x0b, 0x63,
+	0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x41, 0x74, 0x88, 0x01, 0x01, 0x12, 0x19, 0x0a,
+	0x05, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x48, 0x02, 0x52, 0x05,
+	0x6c, 0x61, 0x62, 0x65, 0x6c, 0x88, 0x01, 0x01, 0x12, 0x1b, 0x0a, 0x06, 0x62, 0x6f, 0x6c, 0x74,
+	0x31, 0x31, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x48, 0x03, 0x52, 0x06, 0x62, 0x6f, 0x6c, 0x74,
+	0x31, 0x31, 0x88, 0x01, 0x01, 0x12, 0x25, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70,
+	0x74, 0x69, 0x6f, 0x6e, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x09, 0x48, 0x04, 0x52, 0x0b, 0x64, 0x65,
+	0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x88, 0x01, 0x01, 0x12, 0x1b, 0x0a, 0x06,
+	0x62, 0x6f, 0x6c, 0x74, 0x31, 0x32, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x48, 0x05, 0x52, 0x06,
+	0x62, 0x6f, 0x6c, 0x74, 0x31, 0x32, 0x88, 0x01, 0x01, 0x12, 0x1f, 0x0a, 0x08, 0x70, 0x72, 0x65,
+	0x69, 0x6d, 0x61, 0x67, 0x65, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x0c, 0x48, 0x06, 0x52, 0x08, 0x70,
+	0x72, 0x65, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x88, 0x01, 0x01, 0x12, 0x2b, 0x0a, 0x0f, 0x6e, 0x75,
+	0x6d, 0x62, 0x65, 0x72, 0x5f, 0x6f, 0x66, 0x5f, 0x70, 0x61, 0x72, 0x74, 0x73, 0x18, 0x0e, 0x20,
+	0x01, 0x28, 0x04, 0x48, 0x07, 0x52, 0x0d, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x4f, 0x66, 0x50,
+	0x61, 0x72, 0x74, 0x73, 0x88, 0x01, 0x01, 0x12, 0x23, 0x0a, 0x0a, 0x65, 0x72, 0x72, 0x6f, 0x72,
+	0x6f, 0x6e, 0x69, 0x6f, 0x6e, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0c, 0x48, 0x08, 0x52, 0x0a, 0x65,
+	0x72, 0x72, 0x6f, 0x72, 0x6f, 0x6e, 0x69, 0x6f, 0x6e, 0x88, 0x01, 0x01, 0x22, 0x3b, 0x0a, 0x12,
+	0x4c, 0x69, 0x73, 0x74, 0x70, 0x61, 0x79, 0x73, 0x50, 0x61, 0x79, 0x73, 0x53, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x12, 0x0b, 0x0a, 0x07, 0x50, 0x45, 0x4e, 0x44, 0x49, 0x4e, 0x47, 0x10, 0x00, 0x12,
+	0x0a, 0x0a, 0x06, 0x46, 0x41, 0x49, 0x4c, 0x45, 0x44, 0x10, 0x01, 0x12, 0x0c, 0x0a, 0x08, 0x43,
+	0x4f, 0x4d, 0x50, 0x4c, 0x45, 0x54, 0x45, 0x10, 0x02, 0x42, 0x0e, 0x0a, 0x0c, 0x5f, 0x64, 0x65,
+	0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x42, 0x0f, 0x0a, 0x0d, 0x5f, 0x63, 0x6f,
+	0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x42, 0x08, 0x0a, 0x06, 0x5f, 0x6c,
+	0x61, 0x62, 0x65, 0x6c, 0x42, 0x09, 0x0a, 0x07, 0x5f, 0x62, 0x6f, 0x6c, 0x74, 0x31, 0x31, 0x42,
+	0x0e, 0x0a, 0x0c, 0x5f, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x42,
+	0x09, 0x0a, 0x07, 0x5f, 0x62, 0x6f, 0x6c, 0x74, 0x31, 0x32, 0x42, 0x0b, 0x0a, 0x09, 0x5f, 0x70,
+	0x72, 0x65, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x42, 0x12, 0x0a, 0x10, 0x5f, 0x6e, 0x75, 0x6d, 0x62,
+	0x65, 0x72, 0x5f, 0x6f, 0x66, 0x5f, 0x70, 0x61, 0x72, 0x74, 0x73, 0x42, 0x0d, 0x0a, 0x0b, 0x5f,
+	0x65, 0x72, 0x72, 0x6f, 0x72, 0x6f, 0x6e, 0x69, 0x6f, 0x6e, 0x22, 0x6d, 0x0a, 0x0b, 0x50, 0x69,
+	0x6e, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x02, 0x69, 0x64, 0x12, 0x15, 0x0a, 0x03, 0x6c, 0x65, 0x6e,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x48, 0x00, 0x52, 0x03, 0x6c, 0x65, 0x6e, 0x88, 0x01, 0x01,
+	0x12, 0x21, 0x0a, 0x09, 0x70, 0x6f, 0x6e, 0x67, 0x62, 0x79, 0x74, 0x65, 0x73, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x01, 0x48, 0x01, 0x52, 0x09, 0x70, 0x6f, 0x6e, 0x67, 0x62, 0x79, 0x74, 0x65, 0x73,
+	0x88, 0x01, 0x01, 0x42, 0x06, 0x0a, 0x04, 0x5f, 0x6c, 0x65, 0x6e, 0x42, 0x0c, 0x0a, 0x0a, 0x5f,
+	0x70, 0x6f, 0x6e, 0x67, 0x62, 0x79, 0x74, 0x65, 0x73, 0x22, 0x26, 0x0a, 0x0c, 0x50, 0x69, 0x6e,
+	0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x74, 0x6f, 0x74,
+	0x6c, 0x65, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x74, 0x6f, 0x74, 0x6c, 0x65,
+	0x6e, 0x22, 0xad, 0x02, 0x0a, 0x11, 0x53, 0x65, 0x74, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x2a, 0x0a, 0x07, 0x66, 0x65, 0x65, 0x62, 0x61,
+	0x73, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x63, 0x6c, 0x6e, 0x2e, 0x41,
+	0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x48, 0x00, 0x52, 0x07, 0x66, 0x65, 0x65, 0x62, 0x61, 0x73, 0x65,
+	0x88, 0x01, 0x01, 0x12, 0x1b, 0x0a, 0x06, 0x66, 0x65, 0x65, 0x70, 0x70, 0x6d, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0d, 0x48, 0x01, 0x52, 0x06, 0x66, 0x65, 0x65, 0x70, 0x70, 0x6d, 0x88, 0x01, 0x01,
+	0x12, 0x2a, 0x0a, 0x07, 0x68, 0x74, 0x6c, 0x63, 0x6d, 0x69, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x0b, 0x2e, 0x63, 0x6c, 0x6e, 0x2e, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x48, 0x02,
+	0x52, 0x07, 0x68, 0x74, 0x6c, 0x63, 0x6d, 0x69, 0x6e, 0x88, 0x01, 0x01, 0x12, 0x2a, 0x0a, 0x07,
+	0x68, 0x74, 0x6c, 0x63, 0x6d, 0x61, 0x78, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0b, 0x2e,
+	0x63, 0x6c, 0x6e, 0x2e, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x48, 0x03, 0x52, 0x07, 0x68, 0x74,
+	0x6c, 0x63, 0x6d, 0x61, 0x78, 0x88, 0x01, 0x01, 0x12, 0x27, 0x0a, 0x0c, 0x65, 0x6e, 0x66, 0x6f,
+	0x72, 0x63, 0x65, 0x64, 0x65, 0x6c, 0x61, 0x79, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0d, 0x48, 0x04,
+	0x52, 0x0c, 0x65, 0x6e, 0x66, 0x6f, 0x72, 0x63, 0x65, 0x64, 0x65, 0x6c, 0x61, 0x79, 0x88, 0x01,
+	0x01, 0x42, 0x0a, 0x0a, 0x08, 0x5f, 0x66, 0x65, 0x65, 0x62, 0x61, 0x73, 0x65, 0x42, 0x09, 0x0a,
+	0x07, 0x5f, 0x66, 0x65, 0x65, 0x70, 0x70, 0x6d, 0x42, 0x0a, 0x0a, 0x08, 0x5f, 0x68, 0x74, 0x6c,
+	0x63, 0x6d, 0x69, 0x6e, 0x42, 0x0a, 0x0a, 0x08, 0x5f, 0x68, 0x74, 0x6c, 0x63, 0x6d, 0x61, 0x78,
+	0x42, 0x0f, 0x0a, 0x0d, 0x5f, 0x65, 0x6e, 0x66, 0x6f, 0x72, 0x63, 0x65, 0x64, 0x65, 0x6c, 0x61,
+	0x79, 0x22, 0x49, 0x0a, 0x12, 0x53, 0x65, 0x74, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x33, 0x0a, 0x08, 0x63, 0x68, 0x61, 0x6e, 0x6e,
+	0x65, 0x6c, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x63, 0x6c, 0x6e, 0x2e,
+	0x53, 0x65, 0x74, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65,
+	0x6c, 0x73, 0x52, 0x08, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x73, 0x22, 0xb4, 0x04, 0x0a,
+	0x12, 0x53, 0x65, 0x74, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x43, 0x68, 0x61, 0x6e, 0x6e,
+	0x65, 0x6c, 0x73, 0x12, 0x17, 0x0a, 0x07, 0x70, 0x65, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x70, 0x65, 0x65, 0x72, 0x49, 0x64, 0x12, 0x1d, 0x0a, 0x0a,
+	0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x09, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x49, 0x64, 0x12, 0x2d, 0x0a, 0x10, 0x73,
+	0x68, 0x6f, 0x72, 0x74, 0x5f, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x5f, 0x69, 0x64, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x0e, 0x73, 0x68, 0x6f, 0x72, 0x74, 0x43, 0x68,
+	0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x49, 0x64, 0x88, 0x01, 0x01, 0x12, 0x2f, 0x0a, 0x0d, 0x66, 0x65,
+	0x65, 0x5f, 0x62, 0x61, 0x73, 0x65, 0x5f, 0x6d, 0x73, 0x61, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x0b, 0x2e, 0x63, 0x6c, 0x6e, 0x2e, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x0b,
+	0x66, 0x65, 0x65, 0x42, 0x61, 0x73, 0x65, 0x4d, 0x73, 0x61, 0x74, 0x12, 0x3e, 0x0a, 0x1b, 0x66,
+	0x65, 0x65, 0x5f, 0x70, 0x72, 0x6f, 0x70, 0x6f, 0x72, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x5f,
+	0x6d, 0x69, 0x6c, 0x6c, 0x69, 0x6f, 0x6e, 0x74, 0x68, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x19, 0x66, 0x65, 0x65, 0x50, 0x72, 0x6f, 0x70, 0x6f, 0x72, 0x74, 0x69, 0x6f, 0x6e, 0x61,
+	0x6c, 0x4d, 0x69, 0x6c, 0x6c, 0x69, 0x6f, 0x6e, 0x74, 0x68, 0x73, 0x12, 0x3e, 0x0a, 0x15, 0x6d,
+	0x69, 0x6e, 0x69, 0x6d, 0x75, 0x6d, 0x5f, 0x68, 0x74, 0x6c, 0x63, 0x5f, 0x6f, 0x75, 0x74, 0x5f,
+	0x6d, 0x73, 0x61, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x63, 0x6c, 0x6e,
+	0x2e, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x12, 0x6d, 0x69, 0x6e, 0x69, 0x6d, 0x75, 0x6d,
+	0x48, 0x74, 0x6c, 0x63, 0x4f, 0x75, 0x74, 0x4d, 0x73, 0x61, 0x74, 0x12, 0x3a, 0x0a, 0x17, 0x77,
+	0x61, 0x72, 0x6e, 0x69, 0x6e, 0x67, 0x5f, 0x68, 0x74, 0x6c, 0x63, 0x6d, 0x69, 0x6e, 0x5f, 0x74,
+	0x6f, 0x6f, 0x5f, 0x6c, 0x6f, 0x77, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x48, 0x01, 0x52, 0x14,
+	0x77, 0x61, 0x72, 0x6e, 0x69, 0x6e, 0x67, 0x48, 0x74, 0x6c, 0x63, 0x6d, 0x69, 0x6e, 0x54, 0x6f,
+	0x6f, 0x4c, 0x6f, 0x77, 0x88, 0x01, 0x01, 0x12, 0x3e, 0x0a, 0x15, 0x6d, 0x61, 0x78, 0x69, 0x6d,
+	0x75, 0x6d, 0x5f, 0x68, 0x74, 0x6c, 0x63, 0x5f, 0x6f, 0x75, 0x74, 0x5f, 0x6d, 0x73, 0x61, 0x74,
+	0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x63, 0x6c, 0x6e, 0x2e, 0x41, 0x6d, 0x6f,
+	0x75, 0x6e, 0x74, 0x52, 0x12, 0x6d, 0x61, 0x78, 0x69, 0x6d, 0x75, 0x6d, 0x48, 0x74, 0x6c, 0x63,
+	0x4f, 0x75, 0x74, 0x4d, 0x73, 0x61, 0x74, 0x12, 0x3c, 0x0a, 0x18, 0x77, 0x61, 0x72, 0x6e, 0x69,
+	0x6e, 0x67, 0x5f, 0x68, 0x74, 0x6c, 0x63, 0x6d, 0x61, 0x78, 0x5f, 0x74, 0x6f, 0x6f, 0x5f, 0x68,
+	0x69, 0x67, 0x68, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x48, 0x02, 0x52, 0x15, 0x77, 0x61, 0x72,
+	0x6e, 0x69, 0x6e, 0x67, 0x48, 0x74, 0x6c, 0x63, 0x6d, 0x61, 0x78, 0x54, 0x6f, 0x6f, 0x48, 0x69,
+	0x67, 0x68, 0x88, 0x01, 0x01, 0x42, 0x13, 0x0a, 0x11, 0x5f, 0x73, 0x68, 0x6f, 0x72, 0x74, 0x5f,
+	0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x5f, 0x69, 0x64, 0x42, 0x1a, 0x0a, 0x18, 0x5f, 0x77,
+	0x61, 0x72, 0x6e, 0x69, 0x6e, 0x67, 0x5f, 0x68, 0x74, 0x6c, 0x63, 0x6d, 0x69, 0x6e, 0x5f, 0x74,
+	0x6f, 0x6f, 0x5f, 0x6c, 0x6f, 0x77, 0x42, 0x1b, 0x0a, 0x19, 0x5f, 0x77, 0x61, 0x72, 0x6e, 0x69,
+	0x6e, 0x67, 0x5f, 0x68, 0x74, 0x6c, 0x63, 0x6d, 0x61, 0x78, 0x5f, 0x74, 0x6f, 0x6f, 0x5f, 0x68,
+	0x69, 0x67, 0x68, 0x22, 0x2e, 0x0a, 0x12, 0x53, 0x69, 0x67, 0x6e, 0x6d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x22, 0x5f, 0x0a, 0x13, 0x53, 0x69, 0x67, 0x6e, 0x6d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x69,
+	0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x73,
+	0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x72, 0x65, 0x63, 0x69,
+	0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x72, 0x65, 0x63, 0x69, 0x64, 0x12, 0x14,
+	0x0a, 0x05, 0x7a, 0x62, 0x61, 0x73, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x7a,
+	0x62, 0x61, 0x73, 0x65, 0x22, 0x0d, 0x0a, 0x0b, 0x53, 0x74, 0x6f, 0x70, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x22, 0x0e, 0x0a, 0x0c, 0x53, 0x74, 0x6f, 0x70, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x32, 0x84, 0x18, 0x0a, 0x04, 0x4e, 0x6f, 0x64, 0x65, 0x12, 0x36, 0x0a, 0x07,
+	0x47, 0x65, 0x74, 0x69, 0x6e, 0x66, 0x6f, 0x12, 0x13, 0x2e, 0x63, 0x6c, 0x6e, 0x2e, 0x47, 0x65,
+	0x74, 0x69, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x63,
+	0x6c, 0x6e, 0x2e, 0x47, 0x65, 0x74, 0x69, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x22, 0x00, 0x12, 0x3c, 0x0a, 0x09, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x65, 0x65, 0x72,
+	0x73, 0x12, 0x15, 0x2e, 0x63, 0x6c, 0x6e, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x70, 0x65, 0x65, 0x72,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x63, 0x6c, 0x6e, 0x2e, 0x4c,
+	0x69, 0x73, 0x74, 0x70, 0x65, 0x65, 0x72, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x22, 0x00, 0x12, 0x3c, 0x0a, 0x09, 0x4c, 0x69, 0x73, 0x74, 0x46, 0x75, 0x6e, 0x64, 0x73, 0x12,
+	0x15, 0x2e, 0x63, 0x6c, 0x6e, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x66, 0x75, 0x6e, 0x64, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x63, 0x6c, 0x6e, 0x2e, 0x4c, 0x69, 0x73,
+	0x74, 0x66, 0x75, 0x6e, 0x64, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00,
+	0x12, 0x36, 0x0a, 0x07, 0x53, 0x65, 0x6e, 0x64, 0x50, 0x61, 0x79, 0x12, 0x13, 0x2e, 0x63, 0x6c,
+	0x6e, 0x2e, 0x53, 0x65, 0x6e, 0x64, 0x70, 0x61, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x14, 0x2e, 0x63, 0x6c, 0x6e, 0x2e, 0x53, 0x65, 0x6e, 0x64, 0x70, 0x61, 0x79, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x45, 0x0a, 0x0c, 0x4c, 0x69, 0x73, 0x74,
+	0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x73, 0x12, 0x18, 0x2e, 0x63, 0x6c, 0x6e, 0x2e, 0x4c,
+	0x69, 0x73, 0x74, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x19, 0x2e, 0x63, 0x6c, 0x6e, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x63, 0x68, 0x61,
+	0x6e, 0x6e, 0x65, 0x6c, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12,
+	0x3c, 0x0a, 0x09, 0x41, 0x64, 0x64, 0x47, 0x6f, 0x73, 0x73, 0x69, 0x70, 0x12, 0x15, 0x2e, 0x63,
+	0x6c, 0x6e, 0x2e, 0x41, 0x64, 0x64, 0x67, 0x6f, 0x73, 0x73, 0x69, 0x70, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x63, 0x6c, 0x6e, 0x2e, 0x41, 0x64, 0x64, 0x67, 0x6f, 0x73,
+	0x73, 0x69, 0x70, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x51, 0x0a,
+	0x10, 0x41, 0x75, 0x74, 0x6f, 0x43, 0x6c, 0x65, 0x61, 0x6e, 0x49, 0x6e, 0x76, 0x6f, 0x69, 0x63,
+	0x65, 0x12, 0x1c, 0x2e, 0x63, 0x6c, 0x6e, 0x2e, 0x41, 0x75, 0x74, 0x6f, 0x63, 0x6c, 0x65, 0x61,
+	0x6e, 0x69, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x1d, 0x2e, 0x63, 0x6c, 0x6e, 0x2e, 0x41, 0x75, 0x74, 0x6f, 0x63, 0x6c, 0x65, 0x61, 0x6e, 0x69,
 	0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00,
-	0x12, 0x42, 0x0a, 0x0b, 0x57, 0x61, 0x69, 0x74, 0x49, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x12,
-	0x17, 0x2e, 0x63, 0x6c, 0x6e, 0x2e, 0x57, 0x61, 0x69, 0x74, 0x69, 0x6e, 0x76, 0x6f, 0x69, 0x63,
-	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x63, 0x6c, 0x6e, 0x2e, 0x57,
-	0x61, 0x69, 0x74, 0x69, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x22, 0x00, 0x12, 0x42, 0x0a, 0x0b, 0x57, 0x61, 0x69, 0x74, 0x53, 0x65, 0x6e, 0x64,
-	0x50, 0x61, 0x79, 0x12, 0x17, 0x2e, 0x63, 0x6c, 0x6e, 0x2e, 0x57, 0x61, 0x69, 0x74, 0x73, 0x65,
-	0x6e, 0x64, 0x70, 0x61, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x63,
-	0x6c, 0x6e, 0x2e, 0x57, 0x61, 0x69, 0x74, 0x73, 0x65, 0x6e, 0x64, 0x70, 0x61, 0x79, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x36, 0x0a, 0x07, 0x4e, 0x65, 0x77, 0x41,
-	0x64, 0x64, 0x72, 0x12, 0x13, 0x2e, 0x63, 0x6c, 0x6e, 0x2e, 0x4e, 0x65, 0x77, 0x61, 0x64, 0x64,
-	0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x63, 0x6c, 0x6e, 0x2e, 0x4e,
-	0x65, 0x77, 0x61, 0x64, 0x64, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00,
-	0x12, 0x39, 0x0a, 0x08, 0x57, 0x69, 0x74, 0x68, 0x64, 0x72, 0x61, 0x77, 0x12, 0x14, 0x2e, 0x63,
-	0x6c, 0x6e, 0x2e, 0x57, 0x69, 0x74, 0x68, 0x64, 0x72, 0x61, 0x77, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x1a, 0x15, 0x2e, 0x63, 0x6c, 0x6e, 0x2e, 0x57, 0x69, 0x74, 0x68, 0x64, 0x72, 0x61,
-	0x77, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x36, 0x0a, 0x07, 0x4b,
-	0x65, 0x79, 0x53, 0x65, 0x6e, 0x64, 0x12, 0x13, 0x2e, 0x63, 0x6c, 0x6e, 0x2e, 0x4b, 0x65, 0x79,
-	0x73, 0x65, 0x6e, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x63, 0x6c,
-	0x6e, 0x2e, 0x4b, 0x65, 0x79, 0x73, 0x65, 0x6e, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x22, 0x00, 0x12, 0x39, 0x0a, 0x08, 0x46, 0x75, 0x6e, 0x64, 0x50, 0x73, 0x62, 0x74, 0x12,
-	0x14, 0x2e, 0x63, 0x6c, 0x6e, 0x2e, 0x46, 0x75, 0x6e, 0x64, 0x70, 0x73, 0x62, 0x74, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e, 0x63, 0x6c, 0x6e, 0x2e, 0x46, 0x75, 0x6e, 0x64,
-	0x70, 0x73, 0x62, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x39,
-	0x0a, 0x08, 0x53, 0x65, 0x6e, 0x64, 0x50, 0x73, 0x62, 0x74, 0x12, 0x14, 0x2e, 0x63, 0x6c, 0x6e,
-	0x2e, 0x53, 0x65, 0x6e, 0x64, 0x70, 0x73, 0x62, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x1a, 0x15, 0x2e, 0x63, 0x6c, 0x6e, 0x2e, 0x53, 0x65, 0x6e, 0x64, 0x70, 0x73, 0x62, 0x74, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x39, 0x0a, 0x08, 0x53, 0x69, 0x67,
-	0x6e, 0x50, 0x73, 0x62, 0x74, 0x12, 0x14, 0x2e, 0x63, 0x6c, 0x6e, 0x2e, 0x53, 0x69, 0x67, 0x6e,
-	0x70, 0x73, 0x62, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e, 0x63, 0x6c,
-	0x6e, 0x2e, 0x53, 0x69, 0x67, 0x6e, 0x70, 0x73, 0x62, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x22, 0x00, 0x12, 0x39, 0x0a, 0x08, 0x55, 0x74, 0x78, 0x6f, 0x50, 0x73, 0x62, 0x74,
-	0x12, 0x14, 0x2e, 0x63, 0x6c, 0x6e, 0x2e, 0x55, 0x74, 0x78, 0x6f, 0x70, 0x73, 0x62, 0x74, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e, 0x63, 0x6c, 0x6e, 0x2e, 0x55, 0x74, 0x78,
-	0x6f, 0x70, 0x73, 0x62, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12,
-	0x3c, 0x0a, 0x09, 0x54, 0x78, 0x44, 0x69, 0x73, 0x63, 0x61, 0x72, 0x64, 0x12, 0x15, 0x2e, 0x63,
-	0x6c, 0x6e, 0x2e, 0x54, 0x78, 0x64, 0x69, 0x73, 0x63, 0x61, 0x72, 0x64, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x63, 0x6c, 0x6e, 0x2e, 0x54, 0x78, 0x64, 0x69, 0x73, 0x63,
-	0x61, 0x72, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x3c, 0x0a,
-	0x09, 0x54, 0x78, 0x50, 0x72, 0x65, 0x70, 0x61, 0x72, 0x65, 0x12, 0x15, 0x2e, 0x63, 0x6c, 0x6e,
-	0x2e, 0x54, 0x78, 0x70, 0x72, 0x65, 0x70, 0x61, 0x72, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x1a, 0x16, 0x2e, 0x63, 0x6c, 0x6e, 0x2e, 0x54, 0x78, 0x70, 0x72, 0x65, 0x70, 0x61, 0x72,
-	0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x33, 0x0a, 0x06, 0x54,
-	0x78, 0x53, 0x65, 0x6e, 0x64, 0x12, 0x12, 0x2e, 0x63, 0x6c, 0x6e, 0x2e, 0x54, 0x78, 0x73, 0x65,
-	0x6e, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x63, 0x6c, 0x6e, 0x2e,
-	0x54, 0x78, 0x73, 0x65, 0x6e, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00,
-	0x12, 0x3f, 0x0a, 0x0a, 0x44, 0x69, 0x73, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x12, 0x16,
-	0x2e, 0x63, 0x6c, 0x6e, 0x2e, 0x44, 0x69, 0x73, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x63, 0x6c, 0x6e, 0x2e, 0x44, 0x69, 0x73,
-	0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
-	0x00, 0x12, 0x39, 0x0a, 0x08, 0x46, 0x65, 0x65, 0x72, 0x61, 0x74, 0x65, 0x73, 0x12, 0x14, 0x2e,
-	0x63, 0x6c, 0x6e, 0x2e, 0x46, 0x65, 0x65, 0x72, 0x61, 0x74, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e, 0x63, 0x6c, 0x6e, 0x2e, 0x46, 0x65, 0x65, 0x72, 0x61, 0x74,
-	0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x42, 0x0a, 0x0b,
-	0x46, 0x75, 0x6e, 0x64, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x12, 0x17, 0x2e, 0x63, 0x6c,
-	0x6e, 0x2e, 0x46, 0x75, 0x6e, 0x64, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x63, 0x6c, 0x6e, 0x2e, 0x46, 0x75, 0x6e, 0x64, 0x63,
-	0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00,
-	0x12, 0x39, 0x0a, 0x08, 0x47, 0x65, 0x74, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x12, 0x14, 0x2e, 0x63,
-	0x6c, 0x6e, 0x2e, 0x47, 0x65, 0x74, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x1a, 0x15, 0x2e, 0x63, 0x6c, 0x6e, 0x2e, 0x47, 0x65, 0x74, 0x72, 0x6f, 0x75, 0x74,
-	0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x45, 0x0a, 0x0c, 0x4c,
-	0x69, 0x73, 0x74, 0x46, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x73, 0x12, 0x18, 0x2e, 0x63, 0x6c,
-	0x6e, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x66, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x73, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x63, 0x6c, 0x6e, 0x2e, 0x4c, 0x69, 0x73, 0x74,
-	0x66, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x22, 0x00, 0x12, 0x39, 0x0a, 0x08, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x61, 0x79, 0x73, 0x12, 0x14,
-	0x2e, 0x63, 0x6c, 0x6e, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x70, 0x61, 0x79, 0x73, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e, 0x63, 0x6c, 0x6e, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x70,
-	0x61, 0x79, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x2d, 0x0a,
-	0x04, 0x50, 0x69, 0x6e, 0x67, 0x12, 0x10, 0x2e, 0x63, 0x6c, 0x6e, 0x2e, 0x50, 0x69, 0x6e, 0x67,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x11, 0x2e, 0x63, 0x6c, 0x6e, 0x2e, 0x50, 0x69,
-	0x6e, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x3f, 0x0a, 0x0a,
-	0x53, 0x65, 0x74, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x12, 0x16, 0x2e, 0x63, 0x6c, 0x6e,
-	0x2e, 0x53, 0x65, 0x74, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x1a, 0x17, 0x2e, 0x63, 0x6c, 0x6e, 0x2e, 0x53, 0x65, 0x74, 0x63, 0x68, 0x61, 0x6e,
-	0x6e, 0x65, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x42, 0x0a,
-	0x0b, 0x53, 0x69, 0x67, 0x6e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x17, 0x2e, 0x63,
-	0x6c, 0x6e, 0x2e, 0x53, 0x69, 0x67, 0x6e, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x63, 0x6c, 0x6e, 0x2e, 0x53, 0x69, 0x67, 0x6e,
-	0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
-	0x00, 0x12, 0x2d, 0x0a, 0x04, 0x53, 0x74, 0x6f, 0x70, 0x12, 0x10, 0x2e, 0x63, 0x6c, 0x6e, 0x2e,
-	0x53, 0x74, 0x6f, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x11, 0x2e, 0x63, 0x6c,
-	0x6e, 0x2e, 0x53, 0x74, 0x6f, 0x70, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00,
-	0x42, 0x1f, 0x5a, 0x1d, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6c,
-	0x6e, 0x63, 0x61, 0x70, 0x69, 0x74, 0x61, 0x6c, 0x2f, 0x74, 0x6f, 0x72, 0x71, 0x2f, 0x63, 0x6c,
-	0x6e, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x12, 0x45, 0x0a, 0x0c, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x12, 0x18, 0x2e, 0x63, 0x6c, 0x6e, 0x2e, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x6d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x63, 0x6c, 0x6e,
+	0x2e, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x30, 0x0a, 0x05, 0x43, 0x6c, 0x6f, 0x73, 0x65,
+	0x12, 0x11, 0x2e, 0x63, 0x6c, 0x6e, 0x2e, 0x43, 0x6c, 0x6f, 0x73, 0x65, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x12, 0x2e, 0x63, 0x6c, 0x6e, 0x2e, 0x43, 0x6c, 0x6f, 0x73, 0x65, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x3a, 0x0a, 0x0b, 0x43, 0x6f, 0x6e,
+	0x6e, 0x65, 0x63, 0x74, 0x50, 0x65, 0x65, 0x72, 0x12, 0x13, 0x2e, 0x63, 0x6c, 0x6e, 0x2e, 0x43,
+	0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e,
+	0x63, 0x6c, 0x6e, 0x2e, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x48, 0x0a, 0x0d, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x49,
+	0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x12, 0x19, 0x2e, 0x63, 0x6c, 0x6e, 0x2e, 0x43, 0x72, 0x65,
+	0x61, 0x74, 0x65, 0x69, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x1a, 0x2e, 0x63, 0x6c, 0x6e, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x69, 0x6e,
+	0x76, 0x6f, 0x69, 0x63, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12,
+	0x3c, 0x0a, 0x09, 0x44, 0x61, 0x74, 0x61, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x12, 0x15, 0x2e, 0x63,
+	0x6c, 0x6e, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x63, 0x6c, 0x6e, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x73, 0x74,
+	0x6f, 0x72, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x42, 0x0a,
+	0x0b, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x4f, 0x6e, 0x69, 0x6f, 0x6e, 0x12, 0x17, 0x2e, 0x63,
+	0x6c, 0x6e, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x6f, 0x6e, 0x69, 0x6f, 0x6e, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x63, 0x6c, 0x6e, 0x2e, 0x43, 0x72, 0x65, 0x61,
+	0x74, 0x65, 0x6f, 0x6e, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
+	0x00, 0x12, 0x45, 0x0a, 0x0c, 0x44, 0x65, 0x6c, 0x44, 0x61, 0x74, 0x61, 0x73, 0x74, 0x6f, 0x72,
+	0x65, 0x12, 0x18, 0x2e, 0x63, 0x6c, 0x6e, 0x2e, 0x44, 0x65, 0x6c, 0x64, 0x61, 0x74, 0x61, 0x73,
+	0x74, 0x6f, 0x72, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x63, 0x6c,
+	0x6e, 0x2e, 0x44, 0x65, 0x6c, 0x64, 0x61, 0x74, 0x61, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x54, 0x0a, 0x11, 0x44, 0x65, 0x6c, 0x45,
+	0x78, 0x70, 0x69, 0x72, 0x65, 0x64, 0x49, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x12, 0x1d, 0x2e,
+	0x63, 0x6c, 0x6e, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x64, 0x69, 0x6e,
+	0x76, 0x6f, 0x69, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x63,
+	0x6c, 0x6e, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x64, 0x69, 0x6e, 0x76,
+	0x6f, 0x69, 0x63, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x3f,
+	0x0a, 0x0a, 0x44, 0x65, 0x6c, 0x49, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x12, 0x16, 0x2e, 0x63,
+	0x6c, 0x6e, 0x2e, 0x44, 0x65, 0x6c, 0x69, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x63, 0x6c, 0x6e, 0x2e, 0x44, 0x65, 0x6c, 0x69, 0x6e,
+	0x76, 0x6f, 0x69, 0x63, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12,
+	0x36, 0x0a, 0x07, 0x49, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x12, 0x13, 0x2e, 0x63, 0x6c, 0x6e,
+	0x2e, 0x49, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x14, 0x2e, 0x63, 0x6c, 0x6e, 0x2e, 0x49, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x48, 0x0a, 0x0d, 0x4c, 0x69, 0x73, 0x74, 0x44,
+	0x61, 0x74, 0x61, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x12, 0x19, 0x2e, 0x63, 0x6c, 0x6e, 0x2e, 0x4c,
+	0x69, 0x73, 0x74, 0x64, 0x61, 0x74, 0x61, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x63, 0x6c, 0x6e, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x64, 0x61,
+	0x74, 0x61, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
+	0x00, 0x12, 0x45, 0x0a, 0x0c, 0x4c, 0x69, 0x73, 0x74, 0x49, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65,
+	0x73, 0x12, 0x18, 0x2e, 0x63, 0x6c, 0x6e, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x69, 0x6e, 0x76, 0x6f,
+	0x69, 0x63, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x63, 0x6c,
+	0x6e, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x69, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x73, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x3c, 0x0a, 0x09, 0x53, 0x65, 0x6e, 0x64,
+	0x4f, 0x6e, 0x69, 0x6f, 0x6e, 0x12, 0x15, 0x2e, 0x63, 0x6c, 0x6e, 0x2e, 0x53, 0x65, 0x6e, 0x64,
+	0x6f, 0x6e, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x63,
+	0x6c, 0x6e, 0x2e, 0x53, 0x65, 0x6e, 0x64, 0x6f, 0x6e, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x45, 0x0a, 0x0c, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x65,
+	0x6e, 0x64, 0x50, 0x61, 0x79, 0x73, 0x12, 0x18, 0x2e, 0x63, 0x6c, 0x6e, 0x2e, 0x4c, 0x69, 0x73,
+	0x74, 0x73, 0x65, 0x6e, 0x64, 0x70, 0x61, 0x79, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x19, 0x2e, 0x63, 0x6c, 0x6e, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x73, 0x65, 0x6e, 0x64, 0x70,
+	0x61, 0x79, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x51, 0x0a,
+	0x10, 0x4c, 0x69, 0x73, 0x74, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x73, 0x12, 0x1c, 0x2e, 0x63, 0x6c, 0x6e, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x74, 0x72, 0x61, 0x6e,
+	0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x1d, 0x2e, 0x63, 0x6c, 0x6e, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00,
+	0x12, 0x2a, 0x0a, 0x03, 0x50, 0x61, 0x79, 0x12, 0x0f, 0x2e, 0x63, 0x6c, 0x6e, 0x2e, 0x50, 0x61,
+	0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x10, 0x2e, 0x63, 0x6c, 0x6e, 0x2e, 0x50,
+	0x61, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x3c, 0x0a, 0x09,
+	0x4c, 0x69, 0x73, 0x74, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x12, 0x15, 0x2e, 0x63, 0x6c, 0x6e, 0x2e,
+	0x4c, 0x69, 0x73, 0x74, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x16, 0x2e, 0x63, 0x6c, 0x6e, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x6e, 0x6f, 0x64, 0x65, 0x73,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x4b, 0x0a, 0x0e, 0x57, 0x61,
+	0x69, 0x74, 0x41, 0x6e, 0x79, 0x49, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x12, 0x1a, 0x2e, 0x63,
+	0x6c, 0x6e, 0x2e, 0x57, 0x61, 0x69, 0x74, 0x61, 0x6e, 0x79, 0x69, 0x6e, 0x76, 0x6f, 0x69, 0x63,
+	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x63, 0x6c, 0x6e, 0x2e, 0x57,
+	0x61, 0x69, 0x74, 0x61, 0x6e, 0x79, 0x69, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x42, 0x0a, 0x0b, 0x57, 0x61, 0x69, 0x74, 0x49,
+	0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x12, 0x17, 0x2e, 0x63, 0x6c, 0x6e, 0x2e, 0x57, 0x61, 0x69,
+	0x74, 0x69, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x18, 0x2e, 0x63, 0x6c, 0x6e, 0x2e, 0x57, 0x61, 0x69, 0x74, 0x69, 0x6e, 0x76, 0x6f, 0x69, 0x63,
+	0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x42, 0x0a, 0x0b, 0x57,
+	0x61, 0x69, 0x74, 0x53, 0x65, 0x6e, 0x64, 0x50, 0x61, 0x79, 0x12, 0x17, 0x2e, 0x63, 0x6c, 0x6e,
+	0x2e, 0x57, 0x61, 0x69, 0x74, 0x73, 0x65, 0x6e, 0x64, 0x70, 0x61, 0x79, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x63, 0x6c, 0x6e, 0x2e, 0x57, 0x61, 0x69, 0x74, 0x73, 0x65,
+	0x6e, 0x64, 0x70, 0x61, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12,
+	0x36, 0x0a, 0x07, 0x4e, 0x65, 0x77, 0x41, 0x64, 0x64, 0x72, 0x12, 0x13, 0x2e, 0x63, 0x6c, 0x6e,
+	0x2e, 0x4e, 0x65, 0x77, 0x61, 0x64, 0x64, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x14, 0x2e, 0x63, 0x6c, 0x6e, 0x2e, 0x4e, 0x65, 0x77, 0x61, 0x64, 0x64, 0x72, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x39, 0x0a, 0x08, 0x57, 0x69, 0x74, 0x68, 0x64,
+	0x72, 0x61, 0x77, 0x12, 0x14, 0x2e, 0x63, 0x6c, 0x6e, 0x2e, 0x57, 0x69, 0x74, 0x68, 0x64, 0x72,
+	0x61, 0x77, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e, 0x63, 0x6c, 0x6e, 0x2e,
+	0x57, 0x69, 0x74, 0x68, 0x64, 0x72, 0x61, 0x77, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x22, 0x00, 0x12, 0x36, 0x0a, 0x07, 0x4b, 0x65, 0x79, 0x53, 0x65, 0x6e, 0x64, 0x12, 0x13, 0x2e,
+	0x63, 0x6c, 0x6e, 0x2e, 0x4b, 0x65, 0x79, 0x73, 0x65, 0x6e, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x14, 0x2e, 0x63, 0x6c, 0x6e, 0x2e, 0x4b, 0x65, 0x79, 0x73, 0x65, 0x6e, 0x64,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x39, 0x0a, 0x08, 0x46, 0x75,
+	0x6e, 0x64, 0x50, 0x73, 0x62, 0x74, 0x12, 0x14, 0x2e, 0x63, 0x6c, 0x6e, 0x2e, 0x46, 0x75, 0x6e,
+	0x64, 0x70, 0x73, 0x62, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e, 0x63,
+	0x6c, 0x6e, 0x2e, 0x46, 0x75, 0x6e, 0x64, 0x70, 0x73, 0x62, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x39, 0x0a, 0x08, 0x53, 0x65, 0x6e, 0x64, 0x50, 0x73, 0x62,
+	0x74, 0x12, 0x14, 0x2e, 0x63, 0x6c, 0x6e, 0x2e, 0x53, 0x65, 0x6e, 0x64, 0x70, 0x73, 0x62, 0x74,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e, 0x63, 0x6c, 0x6e, 0x2e, 0x53, 0x65,
+	0x6e, 0x64, 0x70, 0x73, 0x62, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00,
+	0x12, 0x39, 0x0a, 0x08, 0x53, 0x69, 0x67, 0x6e, 0x50, 0x73, 0x62, 0x74, 0x12, 0x14, 0x2e, 0x63,
+	0x6c, 0x6e, 0x2e, 0x53, 0x69, 0x67, 0x6e, 0x70, 0x73, 0x62, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x15, 0x2e, 0x63, 0x6c, 0x6e, 0x2e, 0x53, 0x69, 0x67, 0x6e, 0x70, 0x73, 0x62,
+	0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x39, 0x0a, 0x08, 0x55,
+	0x74, 0x78, 0x6f, 0x50, 0x73, 0x62, 0x74, 0x12, 0x14, 0x2e, 0x63, 0x6c, 0x6e, 0x2e, 0x55, 0x74,
+	0x78, 0x6f, 0x70, 0x73, 0x62, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e,
+	0x63, 0x6c, 0x6e, 0x2e, 0x55, 0x74, 0x78, 0x6f, 0x70, 0x73, 0x62, 0x74, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x3c, 0x0a, 0x09, 0x54, 0x78, 0x44, 0x69, 0x73, 0x63,
+	0x61, 0x72, 0x64, 0x12, 0x15, 0x2e, 0x63, 0x6c, 0x6e, 0x2e, 0x54, 0x78, 0x64, 0x69, 0x73, 0x63,
+	0x61, 0x72, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x63, 0x6c, 0x6e,
+	0x2e, 0x54, 0x78, 0x64, 0x69, 0x73, 0x63, 0x61, 0x72, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x22, 0x00, 0x12, 0x3c, 0x0a, 0x09, 0x54, 0x78, 0x50, 0x72, 0x65, 0x70, 0x61, 0x72,
+	0x65, 0x12, 0x15, 0x2e, 0x63, 0x6c, 0x6e, 0x2e, 0x54, 0x78, 0x70, 0x72, 0x65, 0x70, 0x61, 0x72,
+	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x63, 0x6c, 0x6e, 0x2e, 0x54,
+	0x78, 0x70, 0x72, 0x65, 0x70, 0x61, 0x72, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x22, 0x00, 0x12, 0x33, 0x0a, 0x06, 0x54, 0x78, 0x53, 0x65, 0x6e, 0x64, 0x12, 0x12, 0x2e, 0x63,
+	0x6c, 0x6e, 0x2e, 0x54, 0x78, 0x73, 0x65, 0x6e, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x13, 0x2e, 0x63, 0x6c, 0x6e, 0x2e, 0x54, 0x78, 0x73, 0x65, 0x6e, 0x64, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x3f, 0x0a, 0x0a, 0x44, 0x69, 0x73, 0x63, 0x6f,
+	0x6e, 0x6e, 0x65, 0x63, 0x74, 0x12, 0x16, 0x2e, 0x63, 0x6c, 0x6e, 0x2e, 0x44, 0x69, 0x73, 0x63,
+	0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e,
+	0x63, 0x6c, 0x6e, 0x2e, 0x44, 0x69, 0x73, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x39, 0x0a, 0x08, 0x46, 0x65, 0x65, 0x72,
+	0x61, 0x74, 0x65, 0x73, 0x12, 0x14, 0x2e, 0x63, 0x6c, 0x6e, 0x2e, 0x46, 0x65, 0x65, 0x72, 0x61,
+	0x74, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e, 0x63, 0x6c, 0x6e,
+	0x2e, 0x46, 0x65, 0x65, 0x72, 0x61, 0x74, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x22, 0x00, 0x12, 0x42, 0x0a, 0x0b, 0x46, 0x75, 0x6e, 0x64, 0x43, 0x68, 0x61, 0x6e, 0x6e,
+	0x65, 0x6c, 0x12, 0x17, 0x2e, 0x63, 0x6c, 0x6e, 0x2e, 0x46, 0x75, 0x6e, 0x64, 0x63, 0x68, 0x61,
+	0x6e, 0x6e, 0x65, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x63, 0x6c,
+	0x6e, 0x2e, 0x46, 0x75, 0x6e, 0x64, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x51, 0x0a, 0x10, 0x4d, 0x75, 0x6c, 0x74, 0x69,
+	0x46, 0x75, 0x6e, 0x64, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x12, 0x1c, 0x2e, 0x63, 0x6c,
+	0x6e, 0x2e, 0x4d, 0x75, 0x6c, 0x74, 0x69, 0x66, 0x75, 0x6e, 0x64, 0x63, 0x68, 0x61, 0x6e, 0x6e,
+	0x65, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x63, 0x6c, 0x6e, 0x2e,
+	0x4d, 0x75, 0x6c, 0x74, 0x69, 0x66, 0x75, 0x6e, 0x64, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x39, 0x0a, 0x08, 0x47, 0x65,
+	0x74, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x12, 0x14, 0x2e, 0x63, 0x6c, 0x6e, 0x2e, 0x47, 0x65, 0x74,
+	0x72, 0x6f, 0x75, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e, 0x63,
+	0x6c, 0x6e, 0x2e, 0x47, 0x65, 0x74, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x45, 0x0a, 0x0c, 0x4c, 0x69, 0x73, 0x74, 0x46, 0x6f, 0x72,
+	0x77, 0x61, 0x72, 0x64, 0x73, 0x12, 0x18, 0x2e, 0x63, 0x6c, 0x6e, 0x2e, 0x4c, 0x69, 0x73, 0x74,
+	0x66, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x19, 0x2e, 0x63, 0x6c, 0x6e, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x66, 0x6f, 0x72, 0x77, 0x61, 0x72,
+	0x64, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x39, 0x0a, 0x08,
+	0x4c, 0x69, 0x73, 0x74, 0x50, 0x61, 0x79, 0x73, 0x12, 0x14, 0x2e, 0x63, 0x6c, 0x6e, 0x2e, 0x4c,
+	0x69, 0x73, 0x74, 0x70, 0x61, 0x79, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x15,
+	0x2e, 0x63, 0x6c, 0x6e, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x70, 0x61, 0x79, 0x73, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x2d, 0x0a, 0x04, 0x50, 0x69, 0x6e, 0x67, 0x12,
+	0x10, 0x2e, 0x63, 0x6c, 0x6e, 0x2e, 0x50, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x11, 0x2e, 0x63, 0x6c, 0x6e, 0x2e, 0x50, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x3f, 0x0a, 0x0a, 0x53, 0x65, 0x74, 0x43, 0x68, 0x61,
+	0x6e, 0x6e, 0x65, 0x6c, 0x12, 0x16, 0x2e, 0x63, 0x6c, 0x6e, 0x2e, 0x53, 0x65, 0x74, 0x63, 0x68,
+	0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x63,
+	0x6c, 0x6e, 0x2e, 0x53, 0x65, 0x74, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x42, 0x0a, 0x0b, 0x53, 0x69, 0x67, 0x6e, 0x4d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x17, 0x2e, 0x63, 0x6c, 0x6e, 0x2e, 0x53, 0x69, 0x67,
+	0x6e, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x18, 0x2e, 0x63, 0x6c, 0x6e, 0x2e, 0x53, 0x69, 0x67, 0x6e, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x2d, 0x0a, 0x04, 0x53,
+	0x74, 0x6f, 0x70, 0x12, 0x10, 0x2e, 0x63, 0x6c, 0x6e, 0x2e, 0x53, 0x74, 0x6f, 0x70, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x11, 0x2e, 0x63, 0x6c, 0x6e, 0x2e, 0x53, 0x74, 0x6f, 0x70,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x42, 0x1f, 0x5a, 0x1d, 0x67, 0x69,
+	0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6c, 0x6e, 0x63, 0x61, 0x70, 0x69, 0x74,
+	0x61, 0x6c, 0x2f, 0x74, 0x6f, 0x72, 0x71, 0x2f, 0x63, 0x6c, 0x6e, 0x62, 0x06, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x33,
 }
 
 var (
@@ -14520,8 +15134,8 @@ func file_proto_cln_node_proto_rawDescGZIP() []byte {
 	return file_proto_cln_node_proto_rawDescData
 }
 
-var file_proto_cln_node_proto_enumTypes = make([]protoimpl.EnumInfo, 34)
-var file_proto_cln_node_proto_msgTypes = make([]protoimpl.MessageInfo, 129)
+var file_proto_cln_node_proto_enumTypes = make([]protoimpl.EnumInfo, 35)
+var file_proto_cln_node_proto_msgTypes = make([]protoimpl.MessageInfo, 135)
 var file_proto_cln_node_proto_goTypes = []interface{}{
 	(GetinfoAddress_GetinfoAddressType)(0),                                           // 0: cln.GetinfoAddress.GetinfoAddressType
 	(GetinfoBinding_GetinfoBindingType)(0),                                           // 1: cln.GetinfoBinding.GetinfoBindingType
@@ -14551,416 +15165,437 @@ var file_proto_cln_node_proto_goTypes = []interface{}{
 	(NewaddrRequest_NewaddrAddresstype)(0),                                           // 25: cln.NewaddrRequest.NewaddrAddresstype
 	(KeysendResponse_KeysendStatus)(0),                                               // 26: cln.KeysendResponse.KeysendStatus
 	(FeeratesRequest_FeeratesStyle)(0),                                               // 27: cln.FeeratesRequest.FeeratesStyle
-	(GetrouteRoute_GetrouteRouteStyle)(0),                                            // 28: cln.GetrouteRoute.GetrouteRouteStyle
-	(ListforwardsRequest_ListforwardsStatus)(0),                                      // 29: cln.ListforwardsRequest.ListforwardsStatus
-	(ListforwardsForwards_ListforwardsForwardsStatus)(0),                             // 30: cln.ListforwardsForwards.ListforwardsForwardsStatus
-	(ListforwardsForwards_ListforwardsForwardsStyle)(0),                              // 31: cln.ListforwardsForwards.ListforwardsForwardsStyle
-	(ListpaysRequest_ListpaysStatus)(0),                                              // 32: cln.ListpaysRequest.ListpaysStatus
-	(ListpaysPays_ListpaysPaysStatus)(0),                                             // 33: cln.ListpaysPays.ListpaysPaysStatus
-	(*GetinfoRequest)(nil),                                                           // 34: cln.GetinfoRequest
-	(*GetinfoResponse)(nil),                                                          // 35: cln.GetinfoResponse
-	(*GetinfoOurFeatures)(nil),                                                       // 36: cln.GetinfoOur_features
-	(*GetinfoAddress)(nil),                                                           // 37: cln.GetinfoAddress
-	(*GetinfoBinding)(nil),                                                           // 38: cln.GetinfoBinding
-	(*ListpeersRequest)(nil),                                                         // 39: cln.ListpeersRequest
-	(*ListpeersResponse)(nil),                                                        // 40: cln.ListpeersResponse
-	(*ListpeersPeers)(nil),                                                           // 41: cln.ListpeersPeers
-	(*ListpeersPeersLog)(nil),                                                        // 42: cln.ListpeersPeersLog
-	(*ListpeersPeersChannels)(nil),                                                   // 43: cln.ListpeersPeersChannels
-	(*ListpeersPeersChannelsFeerate)(nil),                                            // 44: cln.ListpeersPeersChannelsFeerate
-	(*ListpeersPeersChannelsInflight)(nil),                                           // 45: cln.ListpeersPeersChannelsInflight
-	(*ListpeersPeersChannelsFunding)(nil),                                            // 46: cln.ListpeersPeersChannelsFunding
-	(*ListpeersPeersChannelsAlias)(nil),                                              // 47: cln.ListpeersPeersChannelsAlias
-	(*ListpeersPeersChannelsHtlcs)(nil),                                              // 48: cln.ListpeersPeersChannelsHtlcs
-	(*ListfundsRequest)(nil),                                                         // 49: cln.ListfundsRequest
-	(*ListfundsResponse)(nil),                                                        // 50: cln.ListfundsResponse
-	(*ListfundsOutputs)(nil),                                                         // 51: cln.ListfundsOutputs
-	(*ListfundsChannels)(nil),                                                        // 52: cln.ListfundsChannels
-	(*SendpayRequest)(nil),                                                           // 53: cln.SendpayRequest
-	(*SendpayResponse)(nil),                                                          // 54: cln.SendpayResponse
-	(*SendpayRoute)(nil),                                                             // 55: cln.SendpayRoute
-	(*ListchannelsRequest)(nil),                                                      // 56: cln.ListchannelsRequest
-	(*ListchannelsResponse)(nil),                                                     // 57: cln.ListchannelsResponse
-	(*ListchannelsChannels)(nil),                                                     // 58: cln.ListchannelsChannels
-	(*AddgossipRequest)(nil),                                                         // 59: cln.AddgossipRequest
-	(*AddgossipResponse)(nil),                                                        // 60: cln.AddgossipResponse
-	(*AutocleaninvoiceRequest)(nil),                                                  // 61: cln.AutocleaninvoiceRequest
-	(*AutocleaninvoiceResponse)(nil),                                                 // 62: cln.AutocleaninvoiceResponse
-	(*CheckmessageRequest)(nil),                                                      // 63: cln.CheckmessageRequest
-	(*CheckmessageResponse)(nil),                                                     // 64: cln.CheckmessageResponse
-	(*CloseRequest)(nil),                                                             // 65: cln.CloseRequest
-	(*CloseResponse)(nil),                                                            // 66: cln.CloseResponse
-	(*ConnectRequest)(nil),                                                           // 67: cln.ConnectRequest
-	(*ConnectResponse)(nil),                                                          // 68: cln.ConnectResponse
-	(*ConnectAddress)(nil),                                                           // 69: cln.ConnectAddress
-	(*CreateinvoiceRequest)(nil),                                                     // 70: cln.CreateinvoiceRequest
-	(*CreateinvoiceResponse)(nil),                                                    // 71: cln.CreateinvoiceResponse
-	(*DatastoreRequest)(nil),                                                         // 72: cln.DatastoreRequest
-	(*DatastoreResponse)(nil),                                                        // 73: cln.DatastoreResponse
-	(*CreateonionRequest)(nil),                                                       // 74: cln.CreateonionRequest
-	(*CreateonionResponse)(nil),                                                      // 75: cln.CreateonionResponse
-	(*CreateonionHops)(nil),                                                          // 76: cln.CreateonionHops
-	(*DeldatastoreRequest)(nil),                                                      // 77: cln.DeldatastoreRequest
-	(*DeldatastoreResponse)(nil),                                                     // 78: cln.DeldatastoreResponse
-	(*DelexpiredinvoiceRequest)(nil),                                                 // 79: cln.DelexpiredinvoiceRequest
-	(*DelexpiredinvoiceResponse)(nil),                                                // 80: cln.DelexpiredinvoiceResponse
-	(*DelinvoiceRequest)(nil),                                                        // 81: cln.DelinvoiceRequest
-	(*DelinvoiceResponse)(nil),                                                       // 82: cln.DelinvoiceResponse
-	(*InvoiceRequest)(nil),                                                           // 83: cln.InvoiceRequest
-	(*InvoiceResponse)(nil),                                                          // 84: cln.InvoiceResponse
-	(*ListdatastoreRequest)(nil),                                                     // 85: cln.ListdatastoreRequest
-	(*ListdatastoreResponse)(nil),                                                    // 86: cln.ListdatastoreResponse
-	(*ListdatastoreDatastore)(nil),                                                   // 87: cln.ListdatastoreDatastore
-	(*ListinvoicesRequest)(nil),                                                      // 88: cln.ListinvoicesRequest
-	(*ListinvoicesResponse)(nil),                                                     // 89: cln.ListinvoicesResponse
-	(*ListinvoicesInvoices)(nil),                                                     // 90: cln.ListinvoicesInvoices
-	(*SendonionRequest)(nil),                                                         // 91: cln.SendonionRequest
-	(*SendonionResponse)(nil),                                                        // 92: cln.SendonionResponse
-	(*SendonionFirstHop)(nil),                                                        // 93: cln.SendonionFirst_hop
-	(*ListsendpaysRequest)(nil),                                                      // 94: cln.ListsendpaysRequest
-	(*ListsendpaysResponse)(nil),                                                     // 95: cln.ListsendpaysResponse
-	(*ListsendpaysPayments)(nil),                                                     // 96: cln.ListsendpaysPayments
-	(*ListtransactionsRequest)(nil),                                                  // 97: cln.ListtransactionsRequest
-	(*ListtransactionsResponse)(nil),                                                 // 98: cln.ListtransactionsResponse
-	(*ListtransactionsTransactions)(nil),                                             // 99: cln.ListtransactionsTransactions
-	(*ListtransactionsTransactionsInputs)(nil),                                       // 100: cln.ListtransactionsTransactionsInputs
-	(*ListtransactionsTransactionsOutputs)(nil),                                      // 101: cln.ListtransactionsTransactionsOutputs
-	(*PayRequest)(nil),                                                               // 102: cln.PayRequest
-	(*PayResponse)(nil),                                                              // 103: cln.PayResponse
-	(*ListnodesRequest)(nil),                                                         // 104: cln.ListnodesRequest
-	(*ListnodesResponse)(nil),                                                        // 105: cln.ListnodesResponse
-	(*ListnodesNodes)(nil),                                                           // 106: cln.ListnodesNodes
-	(*ListnodesNodesAddresses)(nil),                                                  // 107: cln.ListnodesNodesAddresses
-	(*WaitanyinvoiceRequest)(nil),                                                    // 108: cln.WaitanyinvoiceRequest
-	(*WaitanyinvoiceResponse)(nil),                                                   // 109: cln.WaitanyinvoiceResponse
-	(*WaitinvoiceRequest)(nil),                                                       // 110: cln.WaitinvoiceRequest
-	(*WaitinvoiceResponse)(nil),                                                      // 111: cln.WaitinvoiceResponse
-	(*WaitsendpayRequest)(nil),                                                       // 112: cln.WaitsendpayRequest
-	(*WaitsendpayResponse)(nil),                                                      // 113: cln.WaitsendpayResponse
-	(*NewaddrRequest)(nil),                                                           // 114: cln.NewaddrRequest
-	(*NewaddrResponse)(nil),                                                          // 115: cln.NewaddrResponse
-	(*WithdrawRequest)(nil),                                                          // 116: cln.WithdrawRequest
-	(*WithdrawResponse)(nil),                                                         // 117: cln.WithdrawResponse
-	(*KeysendRequest)(nil),                                                           // 118: cln.KeysendRequest
-	(*KeysendResponse)(nil),                                                          // 119: cln.KeysendResponse
-	(*FundpsbtRequest)(nil),                                                          // 120: cln.FundpsbtRequest
-	(*FundpsbtResponse)(nil),                                                         // 121: cln.FundpsbtResponse
-	(*FundpsbtReservations)(nil),                                                     // 122: cln.FundpsbtReservations
-	(*SendpsbtRequest)(nil),                                                          // 123: cln.SendpsbtRequest
-	(*SendpsbtResponse)(nil),                                                         // 124: cln.SendpsbtResponse
-	(*SignpsbtRequest)(nil),                                                          // 125: cln.SignpsbtRequest
-	(*SignpsbtResponse)(nil),                                                         // 126: cln.SignpsbtResponse
-	(*UtxopsbtRequest)(nil),                                                          // 127: cln.UtxopsbtRequest
-	(*UtxopsbtResponse)(nil),                                                         // 128: cln.UtxopsbtResponse
-	(*UtxopsbtReservations)(nil),                                                     // 129: cln.UtxopsbtReservations
-	(*TxdiscardRequest)(nil),                                                         // 130: cln.TxdiscardRequest
-	(*TxdiscardResponse)(nil),                                                        // 131: cln.TxdiscardResponse
-	(*TxprepareRequest)(nil),                                                         // 132: cln.TxprepareRequest
-	(*TxprepareResponse)(nil),                                                        // 133: cln.TxprepareResponse
-	(*TxsendRequest)(nil),                                                            // 134: cln.TxsendRequest
-	(*TxsendResponse)(nil),                                                           // 135: cln.TxsendResponse
-	(*DisconnectRequest)(nil),                                                        // 136: cln.DisconnectRequest
-	(*DisconnectResponse)(nil),                                                       // 137: cln.DisconnectResponse
-	(*FeeratesRequest)(nil),                                                          // 138: cln.FeeratesRequest
-	(*FeeratesResponse)(nil),                                                         // 139: cln.FeeratesResponse
-	(*FeeratesPerkb)(nil),                                                            // 140: cln.FeeratesPerkb
-	(*FeeratesPerkw)(nil),                                                            // 141: cln.FeeratesPerkw
-	(*FeeratesOnchainFeeEstimates)(nil),                                              // 142: cln.FeeratesOnchain_fee_estimates
-	(*FundchannelRequest)(nil),                                                       // 143: cln.FundchannelRequest
-	(*FundchannelResponse)(nil),                                                      // 144: cln.FundchannelResponse
-	(*GetrouteRequest)(nil),                                                          // 145: cln.GetrouteRequest
-	(*GetrouteResponse)(nil),                                                         // 146: cln.GetrouteResponse
-	(*GetrouteRoute)(nil),                                                            // 147: cln.GetrouteRoute
-	(*ListforwardsRequest)(nil),                                                      // 148: cln.ListforwardsRequest
-	(*ListforwardsResponse)(nil),                                                     // 149: cln.ListforwardsResponse
-	(*ListforwardsForwards)(nil),                                                     // 150: cln.ListforwardsForwards
-	(*ListpaysRequest)(nil),                                                          // 151: cln.ListpaysRequest
-	(*ListpaysResponse)(nil),                                                         // 152: cln.ListpaysResponse
-	(*ListpaysPays)(nil),                                                             // 153: cln.ListpaysPays
-	(*PingRequest)(nil),                                                              // 154: cln.PingRequest
-	(*PingResponse)(nil),                                                             // 155: cln.PingResponse
-	(*SetchannelRequest)(nil),                                                        // 156: cln.SetchannelRequest
-	(*SetchannelResponse)(nil),                                                       // 157: cln.SetchannelResponse
-	(*SetchannelChannels)(nil),                                                       // 158: cln.SetchannelChannels
-	(*SignmessageRequest)(nil),                                                       // 159: cln.SignmessageRequest
-	(*SignmessageResponse)(nil),                                                      // 160: cln.SignmessageResponse
-	(*StopRequest)(nil),                                                              // 161: cln.StopRequest
-	(*StopResponse)(nil),                                                             // 162: cln.StopResponse
-	(*Amount)(nil),                                                                   // 163: cln.Amount
-	(ChannelSide)(0),                                                                 // 164: cln.ChannelSide
-	(ChannelState)(0),                                                                // 165: cln.ChannelState
-	(*Outpoint)(nil),                                                                 // 166: cln.Outpoint
-	(*Feerate)(nil),                                                                  // 167: cln.Feerate
-	(*AmountOrAny)(nil),                                                              // 168: cln.AmountOrAny
-	(*AmountOrAll)(nil),                                                              // 169: cln.AmountOrAll
-	(*RoutehintList)(nil),                                                            // 170: cln.RoutehintList
-	(*TlvStream)(nil),                                                                // 171: cln.TlvStream
-	(*OutputDesc)(nil),                                                               // 172: cln.OutputDesc
+	(MultifundchannelFailed_MultifundchannelFailedMethod)(0),                         // 28: cln.MultifundchannelFailed.MultifundchannelFailedMethod
+	(GetrouteRoute_GetrouteRouteStyle)(0),                                            // 29: cln.GetrouteRoute.GetrouteRouteStyle
+	(ListforwardsRequest_ListforwardsStatus)(0),                                      // 30: cln.ListforwardsRequest.ListforwardsStatus
+	(ListforwardsForwards_ListforwardsForwardsStatus)(0),                             // 31: cln.ListforwardsForwards.ListforwardsForwardsStatus
+	(ListforwardsForwards_ListforwardsForwardsStyle)(0),                              // 32: cln.ListforwardsForwards.ListforwardsForwardsStyle
+	(ListpaysRequest_ListpaysStatus)(0),                                              // 33: cln.ListpaysRequest.ListpaysStatus
+	(ListpaysPays_ListpaysPaysStatus)(0),                                             // 34: cln.ListpaysPays.ListpaysPaysStatus
+	(*GetinfoRequest)(nil),                                                           // 35: cln.GetinfoRequest
+	(*GetinfoResponse)(nil),                                                          // 36: cln.GetinfoResponse
+	(*GetinfoOurFeatures)(nil),                                                       // 37: cln.GetinfoOur_features
+	(*GetinfoAddress)(nil),                                                           // 38: cln.GetinfoAddress
+	(*GetinfoBinding)(nil),                                                           // 39: cln.GetinfoBinding
+	(*ListpeersRequest)(nil),                                                         // 40: cln.ListpeersRequest
+	(*ListpeersResponse)(nil),                                                        // 41: cln.ListpeersResponse
+	(*ListpeersPeers)(nil),                                                           // 42: cln.ListpeersPeers
+	(*ListpeersPeersLog)(nil),                                                        // 43: cln.ListpeersPeersLog
+	(*ListpeersPeersChannels)(nil),                                                   // 44: cln.ListpeersPeersChannels
+	(*ListpeersPeersChannelsFeerate)(nil),                                            // 45: cln.ListpeersPeersChannelsFeerate
+	(*ListpeersPeersChannelsInflight)(nil),                                           // 46: cln.ListpeersPeersChannelsInflight
+	(*ListpeersPeersChannelsFunding)(nil),                                            // 47: cln.ListpeersPeersChannelsFunding
+	(*ListpeersPeersChannelsAlias)(nil),                                              // 48: cln.ListpeersPeersChannelsAlias
+	(*ListpeersPeersChannelsHtlcs)(nil),                                              // 49: cln.ListpeersPeersChannelsHtlcs
+	(*ListfundsRequest)(nil),                                                         // 50: cln.ListfundsRequest
+	(*ListfundsResponse)(nil),                                                        // 51: cln.ListfundsResponse
+	(*ListfundsOutputs)(nil),                                                         // 52: cln.ListfundsOutputs
+	(*ListfundsChannels)(nil),                                                        // 53: cln.ListfundsChannels
+	(*SendpayRequest)(nil),                                                           // 54: cln.SendpayRequest
+	(*SendpayResponse)(nil),                                                          // 55: cln.SendpayResponse
+	(*SendpayRoute)(nil),                                                             // 56: cln.SendpayRoute
+	(*ListchannelsRequest)(nil),                                                      // 57: cln.ListchannelsRequest
+	(*ListchannelsResponse)(nil),                                                     // 58: cln.ListchannelsResponse
+	(*ListchannelsChannels)(nil),                                                     // 59: cln.ListchannelsChannels
+	(*AddgossipRequest)(nil),                                                         // 60: cln.AddgossipRequest
+	(*AddgossipResponse)(nil),                                                        // 61: cln.AddgossipResponse
+	(*AutocleaninvoiceRequest)(nil),                                                  // 62: cln.AutocleaninvoiceRequest
+	(*AutocleaninvoiceResponse)(nil),                                                 // 63: cln.AutocleaninvoiceResponse
+	(*CheckmessageRequest)(nil),                                                      // 64: cln.CheckmessageRequest
+	(*CheckmessageResponse)(nil),                                                     // 65: cln.CheckmessageResponse
+	(*CloseRequest)(nil),                                                             // 66: cln.CloseRequest
+	(*CloseResponse)(nil),                                                            // 67: cln.CloseResponse
+	(*ConnectRequest)(nil),                                                           // 68: cln.ConnectRequest
+	(*ConnectResponse)(nil),                                                          // 69: cln.ConnectResponse
+	(*ConnectAddress)(nil),                                                           // 70: cln.ConnectAddress
+	(*CreateinvoiceRequest)(nil),                                                     // 71: cln.CreateinvoiceRequest
+	(*CreateinvoiceResponse)(nil),                                                    // 72: cln.CreateinvoiceResponse
+	(*DatastoreRequest)(nil),                                                         // 73: cln.DatastoreRequest
+	(*DatastoreResponse)(nil),                                                        // 74: cln.DatastoreResponse
+	(*CreateonionRequest)(nil),                                                       // 75: cln.CreateonionRequest
+	(*CreateonionResponse)(nil),                                                      // 76: cln.CreateonionResponse
+	(*CreateonionHops)(nil),                                                          // 77: cln.CreateonionHops
+	(*DeldatastoreRequest)(nil),                                                      // 78: cln.DeldatastoreRequest
+	(*DeldatastoreResponse)(nil),                                                     // 79: cln.DeldatastoreResponse
+	(*DelexpiredinvoiceRequest)(nil),                                                 // 80: cln.DelexpiredinvoiceRequest
+	(*DelexpiredinvoiceResponse)(nil),                                                // 81: cln.DelexpiredinvoiceResponse
+	(*DelinvoiceRequest)(nil),                                                        // 82: cln.DelinvoiceRequest
+	(*DelinvoiceResponse)(nil),                                                       // 83: cln.DelinvoiceResponse
+	(*InvoiceRequest)(nil),                                                           // 84: cln.InvoiceRequest
+	(*InvoiceResponse)(nil),                                                          // 85: cln.InvoiceResponse
+	(*ListdatastoreRequest)(nil),                                                     // 86: cln.ListdatastoreRequest
+	(*ListdatastoreResponse)(nil),                                                    // 87: cln.ListdatastoreResponse
+	(*ListdatastoreDatastore)(nil),                                                   // 88: cln.ListdatastoreDatastore
+	(*ListinvoicesRequest)(nil),                                                      // 89: cln.ListinvoicesRequest
+	(*ListinvoicesResponse)(nil),                                                     // 90: cln.ListinvoicesResponse
+	(*ListinvoicesInvoices)(nil),                                                     // 91: cln.ListinvoicesInvoices
+	(*SendonionRequest)(nil),                                                         // 92: cln.SendonionRequest
+	(*SendonionResponse)(nil),                                                        // 93: cln.SendonionResponse
+	(*SendonionFirstHop)(nil),                                                        // 94: cln.SendonionFirst_hop
+	(*ListsendpaysRequest)(nil),                                                      // 95: cln.ListsendpaysRequest
+	(*ListsendpaysResponse)(nil),                                                     // 96: cln.ListsendpaysResponse
+	(*ListsendpaysPayments)(nil),                                                     // 97: cln.ListsendpaysPayments
+	(*ListtransactionsRequest)(nil),                                                  // 98: cln.ListtransactionsRequest
+	(*ListtransactionsResponse)(nil),                                                 // 99: cln.ListtransactionsResponse
+	(*ListtransactionsTransactions)(nil),                                             // 100: cln.ListtransactionsTransactions
+	(*ListtransactionsTransactionsInputs)(nil),                                       // 101: cln.ListtransactionsTransactionsInputs
+	(*ListtransactionsTransactionsOutputs)(nil),                                      // 102: cln.ListtransactionsTransactionsOutputs
+	(*PayRequest)(nil),                                                               // 103: cln.PayRequest
+	(*PayResponse)(nil),                                                              // 104: cln.PayResponse
+	(*ListnodesRequest)(nil),                                                         // 105: cln.ListnodesRequest
+	(*ListnodesResponse)(nil),                                                        // 106: cln.ListnodesResponse
+	(*ListnodesNodes)(nil),                                                           // 107: cln.ListnodesNodes
+	(*ListnodesNodesAddresses)(nil),                                                  // 108: cln.ListnodesNodesAddresses
+	(*WaitanyinvoiceRequest)(nil),                                                    // 109: cln.WaitanyinvoiceRequest
+	(*WaitanyinvoiceResponse)(nil),                                                   // 110: cln.WaitanyinvoiceResponse
+	(*WaitinvoiceRequest)(nil),                                                       // 111: cln.WaitinvoiceRequest
+	(*WaitinvoiceResponse)(nil),                                                      // 112: cln.WaitinvoiceResponse
+	(*WaitsendpayRequest)(nil),                                                       // 113: cln.WaitsendpayRequest
+	(*WaitsendpayResponse)(nil),                                                      // 114: cln.WaitsendpayResponse
+	(*NewaddrRequest)(nil),                                                           // 115: cln.NewaddrRequest
+	(*NewaddrResponse)(nil),                                                          // 116: cln.NewaddrResponse
+	(*WithdrawRequest)(nil),                                                          // 117: cln.WithdrawRequest
+	(*WithdrawResponse)(nil),                                                         // 118: cln.WithdrawResponse
+	(*KeysendRequest)(nil),                                                           // 119: cln.KeysendRequest
+	(*KeysendResponse)(nil),                                                          // 120: cln.KeysendResponse
+	(*FundpsbtRequest)(nil),                                                          // 121: cln.FundpsbtRequest
+	(*FundpsbtResponse)(nil),                                                         // 122: cln.FundpsbtResponse
+	(*FundpsbtReservations)(nil),                                                     // 123: cln.FundpsbtReservations
+	(*SendpsbtRequest)(nil),                                                          // 124: cln.SendpsbtRequest
+	(*SendpsbtResponse)(nil),                                                         // 125: cln.SendpsbtResponse
+	(*SignpsbtRequest)(nil),                                                          // 126: cln.SignpsbtRequest
+	(*SignpsbtResponse)(nil),                                                         // 127: cln.SignpsbtResponse
+	(*UtxopsbtRequest)(nil),                                                          // 128: cln.UtxopsbtRequest
+	(*UtxopsbtResponse)(nil),                                                         // 129: cln.UtxopsbtResponse
+	(*UtxopsbtReservations)(nil),                                                     // 130: cln.UtxopsbtReservations
+	(*TxdiscardRequest)(nil),                                                         // 131: cln.TxdiscardRequest
+	(*TxdiscardResponse)(nil),                                                        // 132: cln.TxdiscardResponse
+	(*TxprepareRequest)(nil),                                                         // 133: cln.TxprepareRequest
+	(*TxprepareResponse)(nil),                                                        // 134: cln.TxprepareResponse
+	(*TxsendRequest)(nil),                                                            // 135: cln.TxsendRequest
+	(*TxsendResponse)(nil),                                                           // 136: cln.TxsendResponse
+	(*DisconnectRequest)(nil),                                                        // 137: cln.DisconnectRequest
+	(*DisconnectResponse)(nil),                                                       // 138: cln.DisconnectResponse
+	(*FeeratesRequest)(nil),                                                          // 139: cln.FeeratesRequest
+	(*FeeratesResponse)(nil),                                                         // 140: cln.FeeratesResponse
+	(*FeeratesPerkb)(nil),                                                            // 141: cln.FeeratesPerkb
+	(*FeeratesPerkw)(nil),                                                            // 142: cln.FeeratesPerkw
+	(*FeeratesOnchainFeeEstimates)(nil),                                              // 143: cln.FeeratesOnchain_fee_estimates
+	(*FundchannelRequest)(nil),                                                       // 144: cln.FundchannelRequest
+	(*FundchannelResponse)(nil),                                                      // 145: cln.FundchannelResponse
+	(*MultifundchannelRequest)(nil),                                                  // 146: cln.MultifundchannelRequest
+	(*MultifundchannelDestinations)(nil),                                             // 147: cln.MultifundchannelDestinations
+	(*MultifundchannelResponse)(nil),                                                 // 148: cln.MultifundchannelResponse
+	(*MultifundchannelChannelIds)(nil),                                               // 149: cln.MultifundchannelChannel_ids
+	(*MultifundchannelFailed)(nil),                                                   // 150: cln.MultifundchannelFailed
+	(*MultifundchannelFailedError)(nil),                                              // 151: cln.MultifundchannelFailedError
+	(*GetrouteRequest)(nil),                                                          // 152: cln.GetrouteRequest
+	(*GetrouteResponse)(nil),                                                         // 153: cln.GetrouteResponse
+	(*GetrouteRoute)(nil),                                                            // 154: cln.GetrouteRoute
+	(*ListforwardsRequest)(nil),                                                      // 155: cln.ListforwardsRequest
+	(*ListforwardsResponse)(nil),                                                     // 156: cln.ListforwardsResponse
+	(*ListforwardsForwards)(nil),                                                     // 157: cln.ListforwardsForwards
+	(*ListpaysRequest)(nil),                                                          // 158: cln.ListpaysRequest
+	(*ListpaysResponse)(nil),                                                         // 159: cln.ListpaysResponse
+	(*ListpaysPays)(nil),                                                             // 160: cln.ListpaysPays
+	(*PingRequest)(nil),                                                              // 161: cln.PingRequest
+	(*PingResponse)(nil),                                                             // 162: cln.PingResponse
+	(*SetchannelRequest)(nil),                                                        // 163: cln.SetchannelRequest
+	(*SetchannelResponse)(nil),                                                       // 164: cln.SetchannelResponse
+	(*SetchannelChannels)(nil),                                                       // 165: cln.SetchannelChannels
+	(*SignmessageRequest)(nil),                                                       // 166: cln.SignmessageRequest
+	(*SignmessageResponse)(nil),                                                      // 167: cln.SignmessageResponse
+	(*StopRequest)(nil),                                                              // 168: cln.StopRequest
+	(*StopResponse)(nil),                                                             // 169: cln.StopResponse
+	(*Amount)(nil),                                                                   // 170: cln.Amount
+	(ChannelSide)(0),                                                                 // 171: cln.ChannelSide
+	(ChannelState)(0),                                                                // 172: cln.ChannelState
+	(*Outpoint)(nil),                                                                 // 173: cln.Outpoint
+	(*Feerate)(nil),                                                                  // 174: cln.Feerate
+	(*AmountOrAny)(nil),                                                              // 175: cln.AmountOrAny
+	(*AmountOrAll)(nil),                                                              // 176: cln.AmountOrAll
+	(*RoutehintList)(nil),                                                            // 177: cln.RoutehintList
+	(*TlvStream)(nil),                                                                // 178: cln.TlvStream
+	(*OutputDesc)(nil),                                                               // 179: cln.OutputDesc
 }
 var file_proto_cln_node_proto_depIdxs = []int32{
-	163, // 0: cln.GetinfoResponse.fees_collected_msat:type_name -> cln.Amount
-	37,  // 1: cln.GetinfoResponse.address:type_name -> cln.GetinfoAddress
-	38,  // 2: cln.GetinfoResponse.binding:type_name -> cln.GetinfoBinding
+	170, // 0: cln.GetinfoResponse.fees_collected_msat:type_name -> cln.Amount
+	38,  // 1: cln.GetinfoResponse.address:type_name -> cln.GetinfoAddress
+	39,  // 2: cln.GetinfoResponse.binding:type_name -> cln.GetinfoBinding
 	0,   // 3: cln.GetinfoAddress.item_type:type_name -> cln.GetinfoAddress.GetinfoAddressType
 	1,   // 4: cln.GetinfoBinding.item_type:type_name -> cln.GetinfoBinding.GetinfoBindingType
-	41,  // 5: cln.ListpeersResponse.peers:type_name -> cln.ListpeersPeers
-	42,  // 6: cln.ListpeersPeers.log:type_name -> cln.ListpeersPeersLog
-	43,  // 7: cln.ListpeersPeers.channels:type_name -> cln.ListpeersPeersChannels
+	42,  // 5: cln.ListpeersResponse.peers:type_name -> cln.ListpeersPeers
+	43,  // 6: cln.ListpeersPeers.log:type_name -> cln.ListpeersPeersLog
+	44,  // 7: cln.ListpeersPeers.channels:type_name -> cln.ListpeersPeersChannels
 	2,   // 8: cln.ListpeersPeersLog.item_type:type_name -> cln.ListpeersPeersLog.ListpeersPeersLogType
 	3,   // 9: cln.ListpeersPeersChannels.state:type_name -> cln.ListpeersPeersChannels.ListpeersPeersChannelsState
-	45,  // 10: cln.ListpeersPeersChannels.inflight:type_name -> cln.ListpeersPeersChannelsInflight
-	164, // 11: cln.ListpeersPeersChannels.opener:type_name -> cln.ChannelSide
-	164, // 12: cln.ListpeersPeersChannels.closer:type_name -> cln.ChannelSide
-	163, // 13: cln.ListpeersPeersChannels.to_us_msat:type_name -> cln.Amount
-	163, // 14: cln.ListpeersPeersChannels.min_to_us_msat:type_name -> cln.Amount
-	163, // 15: cln.ListpeersPeersChannels.max_to_us_msat:type_name -> cln.Amount
-	163, // 16: cln.ListpeersPeersChannels.total_msat:type_name -> cln.Amount
-	163, // 17: cln.ListpeersPeersChannels.fee_base_msat:type_name -> cln.Amount
-	163, // 18: cln.ListpeersPeersChannels.dust_limit_msat:type_name -> cln.Amount
-	163, // 19: cln.ListpeersPeersChannels.max_total_htlc_in_msat:type_name -> cln.Amount
-	163, // 20: cln.ListpeersPeersChannels.their_reserve_msat:type_name -> cln.Amount
-	163, // 21: cln.ListpeersPeersChannels.our_reserve_msat:type_name -> cln.Amount
-	163, // 22: cln.ListpeersPeersChannels.spendable_msat:type_name -> cln.Amount
-	163, // 23: cln.ListpeersPeersChannels.receivable_msat:type_name -> cln.Amount
-	163, // 24: cln.ListpeersPeersChannels.minimum_htlc_in_msat:type_name -> cln.Amount
-	163, // 25: cln.ListpeersPeersChannels.minimum_htlc_out_msat:type_name -> cln.Amount
-	163, // 26: cln.ListpeersPeersChannels.maximum_htlc_out_msat:type_name -> cln.Amount
-	163, // 27: cln.ListpeersPeersChannels.in_offered_msat:type_name -> cln.Amount
-	163, // 28: cln.ListpeersPeersChannels.in_fulfilled_msat:type_name -> cln.Amount
-	163, // 29: cln.ListpeersPeersChannels.out_offered_msat:type_name -> cln.Amount
-	163, // 30: cln.ListpeersPeersChannels.out_fulfilled_msat:type_name -> cln.Amount
-	48,  // 31: cln.ListpeersPeersChannels.htlcs:type_name -> cln.ListpeersPeersChannelsHtlcs
-	163, // 32: cln.ListpeersPeersChannelsInflight.total_funding_msat:type_name -> cln.Amount
-	163, // 33: cln.ListpeersPeersChannelsInflight.our_funding_msat:type_name -> cln.Amount
-	163, // 34: cln.ListpeersPeersChannelsFunding.local_msat:type_name -> cln.Amount
-	163, // 35: cln.ListpeersPeersChannelsFunding.remote_msat:type_name -> cln.Amount
-	163, // 36: cln.ListpeersPeersChannelsFunding.pushed_msat:type_name -> cln.Amount
-	163, // 37: cln.ListpeersPeersChannelsFunding.local_funds_msat:type_name -> cln.Amount
-	163, // 38: cln.ListpeersPeersChannelsFunding.remote_funds_msat:type_name -> cln.Amount
-	163, // 39: cln.ListpeersPeersChannelsFunding.fee_paid_msat:type_name -> cln.Amount
-	163, // 40: cln.ListpeersPeersChannelsFunding.fee_rcvd_msat:type_name -> cln.Amount
+	46,  // 10: cln.ListpeersPeersChannels.inflight:type_name -> cln.ListpeersPeersChannelsInflight
+	171, // 11: cln.ListpeersPeersChannels.opener:type_name -> cln.ChannelSide
+	171, // 12: cln.ListpeersPeersChannels.closer:type_name -> cln.ChannelSide
+	170, // 13: cln.ListpeersPeersChannels.to_us_msat:type_name -> cln.Amount
+	170, // 14: cln.ListpeersPeersChannels.min_to_us_msat:type_name -> cln.Amount
+	170, // 15: cln.ListpeersPeersChannels.max_to_us_msat:type_name -> cln.Amount
+	170, // 16: cln.ListpeersPeersChannels.total_msat:type_name -> cln.Amount
+	170, // 17: cln.ListpeersPeersChannels.fee_base_msat:type_name -> cln.Amount
+	170, // 18: cln.ListpeersPeersChannels.dust_limit_msat:type_name -> cln.Amount
+	170, // 19: cln.ListpeersPeersChannels.max_total_htlc_in_msat:type_name -> cln.Amount
+	170, // 20: cln.ListpeersPeersChannels.their_reserve_msat:type_name -> cln.Amount
+	170, // 21: cln.ListpeersPeersChannels.our_reserve_msat:type_name -> cln.Amount
+	170, // 22: cln.ListpeersPeersChannels.spendable_msat:type_name -> cln.Amount
+	170, // 23: cln.ListpeersPeersChannels.receivable_msat:type_name -> cln.Amount
+	170, // 24: cln.ListpeersPeersChannels.minimum_htlc_in_msat:type_name -> cln.Amount
+	170, // 25: cln.ListpeersPeersChannels.minimum_htlc_out_msat:type_name -> cln.Amount
+	170, // 26: cln.ListpeersPeersChannels.maximum_htlc_out_msat:type_name -> cln.Amount
+	170, // 27: cln.ListpeersPeersChannels.in_offered_msat:type_name -> cln.Amount
+	170, // 28: cln.ListpeersPeersChannels.in_fulfilled_msat:type_name -> cln.Amount
+	170, // 29: cln.ListpeersPeersChannels.out_offered_msat:type_name -> cln.Amount
+	170, // 30: cln.ListpeersPeersChannels.out_fulfilled_msat:type_name -> cln.Amount
+	49,  // 31: cln.ListpeersPeersChannels.htlcs:type_name -> cln.ListpeersPeersChannelsHtlcs
+	170, // 32: cln.ListpeersPeersChannelsInflight.total_funding_msat:type_name -> cln.Amount
+	170, // 33: cln.ListpeersPeersChannelsInflight.our_funding_msat:type_name -> cln.Amount
+	170, // 34: cln.ListpeersPeersChannelsFunding.local_msat:type_name -> cln.Amount
+	170, // 35: cln.ListpeersPeersChannelsFunding.remote_msat:type_name -> cln.Amount
+	170, // 36: cln.ListpeersPeersChannelsFunding.pushed_msat:type_name -> cln.Amount
+	170, // 37: cln.ListpeersPeersChannelsFunding.local_funds_msat:type_name -> cln.Amount
+	170, // 38: cln.ListpeersPeersChannelsFunding.remote_funds_msat:type_name -> cln.Amount
+	170, // 39: cln.ListpeersPeersChannelsFunding.fee_paid_msat:type_name -> cln.Amount
+	170, // 40: cln.ListpeersPeersChannelsFunding.fee_rcvd_msat:type_name -> cln.Amount
 	4,   // 41: cln.ListpeersPeersChannelsHtlcs.direction:type_name -> cln.ListpeersPeersChannelsHtlcs.ListpeersPeersChannelsHtlcsDirection
-	163, // 42: cln.ListpeersPeersChannelsHtlcs.amount_msat:type_name -> cln.Amount
-	51,  // 43: cln.ListfundsResponse.outputs:type_name -> cln.ListfundsOutputs
-	52,  // 44: cln.ListfundsResponse.channels:type_name -> cln.ListfundsChannels
-	163, // 45: cln.ListfundsOutputs.amount_msat:type_name -> cln.Amount
+	170, // 42: cln.ListpeersPeersChannelsHtlcs.amount_msat:type_name -> cln.Amount
+	52,  // 43: cln.ListfundsResponse.outputs:type_name -> cln.ListfundsOutputs
+	53,  // 44: cln.ListfundsResponse.channels:type_name -> cln.ListfundsChannels
+	170, // 45: cln.ListfundsOutputs.amount_msat:type_name -> cln.Amount
 	5,   // 46: cln.ListfundsOutputs.status:type_name -> cln.ListfundsOutputs.ListfundsOutputsStatus
-	163, // 47: cln.ListfundsChannels.our_amount_msat:type_name -> cln.Amount
-	163, // 48: cln.ListfundsChannels.amount_msat:type_name -> cln.Amount
-	165, // 49: cln.ListfundsChannels.state:type_name -> cln.ChannelState
-	55,  // 50: cln.SendpayRequest.route:type_name -> cln.SendpayRoute
-	163, // 51: cln.SendpayRequest.amount_msat:type_name -> cln.Amount
+	170, // 47: cln.ListfundsChannels.our_amount_msat:type_name -> cln.Amount
+	170, // 48: cln.ListfundsChannels.amount_msat:type_name -> cln.Amount
+	172, // 49: cln.ListfundsChannels.state:type_name -> cln.ChannelState
+	56,  // 50: cln.SendpayRequest.route:type_name -> cln.SendpayRoute
+	170, // 51: cln.SendpayRequest.amount_msat:type_name -> cln.Amount
 	6,   // 52: cln.SendpayResponse.status:type_name -> cln.SendpayResponse.SendpayStatus
-	163, // 53: cln.SendpayResponse.amount_msat:type_name -> cln.Amount
-	163, // 54: cln.SendpayResponse.amount_sent_msat:type_name -> cln.Amount
-	163, // 55: cln.SendpayRoute.amount_msat:type_name -> cln.Amount
-	58,  // 56: cln.ListchannelsResponse.channels:type_name -> cln.ListchannelsChannels
-	163, // 57: cln.ListchannelsChannels.amount_msat:type_name -> cln.Amount
-	163, // 58: cln.ListchannelsChannels.htlc_minimum_msat:type_name -> cln.Amount
-	163, // 59: cln.ListchannelsChannels.htlc_maximum_msat:type_name -> cln.Amount
-	166, // 60: cln.CloseRequest.wrong_funding:type_name -> cln.Outpoint
-	167, // 61: cln.CloseRequest.feerange:type_name -> cln.Feerate
+	170, // 53: cln.SendpayResponse.amount_msat:type_name -> cln.Amount
+	170, // 54: cln.SendpayResponse.amount_sent_msat:type_name -> cln.Amount
+	170, // 55: cln.SendpayRoute.amount_msat:type_name -> cln.Amount
+	59,  // 56: cln.ListchannelsResponse.channels:type_name -> cln.ListchannelsChannels
+	170, // 57: cln.ListchannelsChannels.amount_msat:type_name -> cln.Amount
+	170, // 58: cln.ListchannelsChannels.htlc_minimum_msat:type_name -> cln.Amount
+	170, // 59: cln.ListchannelsChannels.htlc_maximum_msat:type_name -> cln.Amount
+	173, // 60: cln.CloseRequest.wrong_funding:type_name -> cln.Outpoint
+	174, // 61: cln.CloseRequest.feerange:type_name -> cln.Feerate
 	7,   // 62: cln.CloseResponse.item_type:type_name -> cln.CloseResponse.CloseType
 	8,   // 63: cln.ConnectResponse.direction:type_name -> cln.ConnectResponse.ConnectDirection
 	9,   // 64: cln.ConnectAddress.item_type:type_name -> cln.ConnectAddress.ConnectAddressType
-	163, // 65: cln.CreateinvoiceResponse.amount_msat:type_name -> cln.Amount
+	170, // 65: cln.CreateinvoiceResponse.amount_msat:type_name -> cln.Amount
 	10,  // 66: cln.CreateinvoiceResponse.status:type_name -> cln.CreateinvoiceResponse.CreateinvoiceStatus
-	163, // 67: cln.CreateinvoiceResponse.amount_received_msat:type_name -> cln.Amount
+	170, // 67: cln.CreateinvoiceResponse.amount_received_msat:type_name -> cln.Amount
 	11,  // 68: cln.DatastoreRequest.mode:type_name -> cln.DatastoreRequest.DatastoreMode
-	76,  // 69: cln.CreateonionRequest.hops:type_name -> cln.CreateonionHops
+	77,  // 69: cln.CreateonionRequest.hops:type_name -> cln.CreateonionHops
 	12,  // 70: cln.DelinvoiceRequest.status:type_name -> cln.DelinvoiceRequest.DelinvoiceStatus
-	163, // 71: cln.DelinvoiceResponse.amount_msat:type_name -> cln.Amount
+	170, // 71: cln.DelinvoiceResponse.amount_msat:type_name -> cln.Amount
 	13,  // 72: cln.DelinvoiceResponse.status:type_name -> cln.DelinvoiceResponse.DelinvoiceStatus
-	168, // 73: cln.InvoiceRequest.amount_msat:type_name -> cln.AmountOrAny
-	87,  // 74: cln.ListdatastoreResponse.datastore:type_name -> cln.ListdatastoreDatastore
-	90,  // 75: cln.ListinvoicesResponse.invoices:type_name -> cln.ListinvoicesInvoices
+	175, // 73: cln.InvoiceRequest.amount_msat:type_name -> cln.AmountOrAny
+	88,  // 74: cln.ListdatastoreResponse.datastore:type_name -> cln.ListdatastoreDatastore
+	91,  // 75: cln.ListinvoicesResponse.invoices:type_name -> cln.ListinvoicesInvoices
 	14,  // 76: cln.ListinvoicesInvoices.status:type_name -> cln.ListinvoicesInvoices.ListinvoicesInvoicesStatus
-	163, // 77: cln.ListinvoicesInvoices.amount_msat:type_name -> cln.Amount
-	163, // 78: cln.ListinvoicesInvoices.amount_received_msat:type_name -> cln.Amount
-	163, // 79: cln.SendonionRequest.amount_msat:type_name -> cln.Amount
+	170, // 77: cln.ListinvoicesInvoices.amount_msat:type_name -> cln.Amount
+	170, // 78: cln.ListinvoicesInvoices.amount_received_msat:type_name -> cln.Amount
+	170, // 79: cln.SendonionRequest.amount_msat:type_name -> cln.Amount
 	15,  // 80: cln.SendonionResponse.status:type_name -> cln.SendonionResponse.SendonionStatus
-	163, // 81: cln.SendonionResponse.amount_msat:type_name -> cln.Amount
-	163, // 82: cln.SendonionResponse.amount_sent_msat:type_name -> cln.Amount
-	163, // 83: cln.SendonionFirst_hop.amount_msat:type_name -> cln.Amount
+	170, // 81: cln.SendonionResponse.amount_msat:type_name -> cln.Amount
+	170, // 82: cln.SendonionResponse.amount_sent_msat:type_name -> cln.Amount
+	170, // 83: cln.SendonionFirst_hop.amount_msat:type_name -> cln.Amount
 	16,  // 84: cln.ListsendpaysRequest.status:type_name -> cln.ListsendpaysRequest.ListsendpaysStatus
-	96,  // 85: cln.ListsendpaysResponse.payments:type_name -> cln.ListsendpaysPayments
+	97,  // 85: cln.ListsendpaysResponse.payments:type_name -> cln.ListsendpaysPayments
 	17,  // 86: cln.ListsendpaysPayments.status:type_name -> cln.ListsendpaysPayments.ListsendpaysPaymentsStatus
-	163, // 87: cln.ListsendpaysPayments.amount_msat:type_name -> cln.Amount
-	163, // 88: cln.ListsendpaysPayments.amount_sent_msat:type_name -> cln.Amount
-	99,  // 89: cln.ListtransactionsResponse.transactions:type_name -> cln.ListtransactionsTransactions
-	100, // 90: cln.ListtransactionsTransactions.inputs:type_name -> cln.ListtransactionsTransactionsInputs
-	101, // 91: cln.ListtransactionsTransactions.outputs:type_name -> cln.ListtransactionsTransactionsOutputs
+	170, // 87: cln.ListsendpaysPayments.amount_msat:type_name -> cln.Amount
+	170, // 88: cln.ListsendpaysPayments.amount_sent_msat:type_name -> cln.Amount
+	100, // 89: cln.ListtransactionsResponse.transactions:type_name -> cln.ListtransactionsTransactions
+	101, // 90: cln.ListtransactionsTransactions.inputs:type_name -> cln.ListtransactionsTransactionsInputs
+	102, // 91: cln.ListtransactionsTransactions.outputs:type_name -> cln.ListtransactionsTransactionsOutputs
 	18,  // 92: cln.ListtransactionsTransactionsInputs.item_type:type_name -> cln.ListtransactionsTransactionsInputs.ListtransactionsTransactionsInputsType
-	163, // 93: cln.ListtransactionsTransactionsOutputs.amount_msat:type_name -> cln.Amount
+	170, // 93: cln.ListtransactionsTransactionsOutputs.amount_msat:type_name -> cln.Amount
 	19,  // 94: cln.ListtransactionsTransactionsOutputs.item_type:type_name -> cln.ListtransactionsTransactionsOutputs.ListtransactionsTransactionsOutputsType
-	163, // 95: cln.PayRequest.amount_msat:type_name -> cln.Amount
-	163, // 96: cln.PayRequest.exemptfee:type_name -> cln.Amount
-	163, // 97: cln.PayRequest.maxfee:type_name -> cln.Amount
-	163, // 98: cln.PayResponse.amount_msat:type_name -> cln.Amount
-	163, // 99: cln.PayResponse.amount_sent_msat:type_name -> cln.Amount
+	170, // 95: cln.PayRequest.amount_msat:type_name -> cln.Amount
+	170, // 96: cln.PayRequest.exemptfee:type_name -> cln.Amount
+	170, // 97: cln.PayRequest.maxfee:type_name -> cln.Amount
+	170, // 98: cln.PayResponse.amount_msat:type_name -> cln.Amount
+	170, // 99: cln.PayResponse.amount_sent_msat:type_name -> cln.Amount
 	20,  // 100: cln.PayResponse.status:type_name -> cln.PayResponse.PayStatus
-	106, // 101: cln.ListnodesResponse.nodes:type_name -> cln.ListnodesNodes
-	107, // 102: cln.ListnodesNodes.addresses:type_name -> cln.ListnodesNodesAddresses
+	107, // 101: cln.ListnodesResponse.nodes:type_name -> cln.ListnodesNodes
+	108, // 102: cln.ListnodesNodes.addresses:type_name -> cln.ListnodesNodesAddresses
 	21,  // 103: cln.ListnodesNodesAddresses.item_type:type_name -> cln.ListnodesNodesAddresses.ListnodesNodesAddressesType
 	22,  // 104: cln.WaitanyinvoiceResponse.status:type_name -> cln.WaitanyinvoiceResponse.WaitanyinvoiceStatus
-	163, // 105: cln.WaitanyinvoiceResponse.amount_msat:type_name -> cln.Amount
-	163, // 106: cln.WaitanyinvoiceResponse.amount_received_msat:type_name -> cln.Amount
+	170, // 105: cln.WaitanyinvoiceResponse.amount_msat:type_name -> cln.Amount
+	170, // 106: cln.WaitanyinvoiceResponse.amount_received_msat:type_name -> cln.Amount
 	23,  // 107: cln.WaitinvoiceResponse.status:type_name -> cln.WaitinvoiceResponse.WaitinvoiceStatus
-	163, // 108: cln.WaitinvoiceResponse.amount_msat:type_name -> cln.Amount
-	163, // 109: cln.WaitinvoiceResponse.amount_received_msat:type_name -> cln.Amount
+	170, // 108: cln.WaitinvoiceResponse.amount_msat:type_name -> cln.Amount
+	170, // 109: cln.WaitinvoiceResponse.amount_received_msat:type_name -> cln.Amount
 	24,  // 110: cln.WaitsendpayResponse.status:type_name -> cln.WaitsendpayResponse.WaitsendpayStatus
-	163, // 111: cln.WaitsendpayResponse.amount_msat:type_name -> cln.Amount
-	163, // 112: cln.WaitsendpayResponse.amount_sent_msat:type_name -> cln.Amount
+	170, // 111: cln.WaitsendpayResponse.amount_msat:type_name -> cln.Amount
+	170, // 112: cln.WaitsendpayResponse.amount_sent_msat:type_name -> cln.Amount
 	25,  // 113: cln.NewaddrRequest.addresstype:type_name -> cln.NewaddrRequest.NewaddrAddresstype
-	169, // 114: cln.WithdrawRequest.satoshi:type_name -> cln.AmountOrAll
-	167, // 115: cln.WithdrawRequest.feerate:type_name -> cln.Feerate
-	166, // 116: cln.WithdrawRequest.utxos:type_name -> cln.Outpoint
-	163, // 117: cln.KeysendRequest.amount_msat:type_name -> cln.Amount
-	163, // 118: cln.KeysendRequest.exemptfee:type_name -> cln.Amount
-	170, // 119: cln.KeysendRequest.routehints:type_name -> cln.RoutehintList
-	171, // 120: cln.KeysendRequest.extratlvs:type_name -> cln.TlvStream
-	163, // 121: cln.KeysendResponse.amount_msat:type_name -> cln.Amount
-	163, // 122: cln.KeysendResponse.amount_sent_msat:type_name -> cln.Amount
+	176, // 114: cln.WithdrawRequest.satoshi:type_name -> cln.AmountOrAll
+	174, // 115: cln.WithdrawRequest.feerate:type_name -> cln.Feerate
+	173, // 116: cln.WithdrawRequest.utxos:type_name -> cln.Outpoint
+	170, // 117: cln.KeysendRequest.amount_msat:type_name -> cln.Amount
+	170, // 118: cln.KeysendRequest.exemptfee:type_name -> cln.Amount
+	177, // 119: cln.KeysendRequest.routehints:type_name -> cln.RoutehintList
+	178, // 120: cln.KeysendRequest.extratlvs:type_name -> cln.TlvStream
+	170, // 121: cln.KeysendResponse.amount_msat:type_name -> cln.Amount
+	170, // 122: cln.KeysendResponse.amount_sent_msat:type_name -> cln.Amount
 	26,  // 123: cln.KeysendResponse.status:type_name -> cln.KeysendResponse.KeysendStatus
-	169, // 124: cln.FundpsbtRequest.satoshi:type_name -> cln.AmountOrAll
-	167, // 125: cln.FundpsbtRequest.feerate:type_name -> cln.Feerate
-	163, // 126: cln.FundpsbtResponse.excess_msat:type_name -> cln.Amount
-	122, // 127: cln.FundpsbtResponse.reservations:type_name -> cln.FundpsbtReservations
-	163, // 128: cln.UtxopsbtRequest.satoshi:type_name -> cln.Amount
-	167, // 129: cln.UtxopsbtRequest.feerate:type_name -> cln.Feerate
-	166, // 130: cln.UtxopsbtRequest.utxos:type_name -> cln.Outpoint
-	163, // 131: cln.UtxopsbtResponse.excess_msat:type_name -> cln.Amount
-	129, // 132: cln.UtxopsbtResponse.reservations:type_name -> cln.UtxopsbtReservations
-	172, // 133: cln.TxprepareRequest.outputs:type_name -> cln.OutputDesc
-	167, // 134: cln.TxprepareRequest.feerate:type_name -> cln.Feerate
-	166, // 135: cln.TxprepareRequest.utxos:type_name -> cln.Outpoint
+	176, // 124: cln.FundpsbtRequest.satoshi:type_name -> cln.AmountOrAll
+	174, // 125: cln.FundpsbtRequest.feerate:type_name -> cln.Feerate
+	170, // 126: cln.FundpsbtResponse.excess_msat:type_name -> cln.Amount
+	123, // 127: cln.FundpsbtResponse.reservations:type_name -> cln.FundpsbtReservations
+	170, // 128: cln.UtxopsbtRequest.satoshi:type_name -> cln.Amount
+	174, // 129: cln.UtxopsbtRequest.feerate:type_name -> cln.Feerate
+	173, // 130: cln.UtxopsbtRequest.utxos:type_name -> cln.Outpoint
+	170, // 131: cln.UtxopsbtResponse.excess_msat:type_name -> cln.Amount
+	130, // 132: cln.UtxopsbtResponse.reservations:type_name -> cln.UtxopsbtReservations
+	179, // 133: cln.TxprepareRequest.outputs:type_name -> cln.OutputDesc
+	174, // 134: cln.TxprepareRequest.feerate:type_name -> cln.Feerate
+	173, // 135: cln.TxprepareRequest.utxos:type_name -> cln.Outpoint
 	27,  // 136: cln.FeeratesRequest.style:type_name -> cln.FeeratesRequest.FeeratesStyle
-	169, // 137: cln.FundchannelRequest.amount:type_name -> cln.AmountOrAll
-	167, // 138: cln.FundchannelRequest.feerate:type_name -> cln.Feerate
-	163, // 139: cln.FundchannelRequest.push_msat:type_name -> cln.Amount
-	163, // 140: cln.FundchannelRequest.request_amt:type_name -> cln.Amount
-	166, // 141: cln.FundchannelRequest.utxos:type_name -> cln.Outpoint
-	163, // 142: cln.FundchannelRequest.reserve:type_name -> cln.Amount
-	163, // 143: cln.GetrouteRequest.amount_msat:type_name -> cln.Amount
-	147, // 144: cln.GetrouteResponse.route:type_name -> cln.GetrouteRoute
-	163, // 145: cln.GetrouteRoute.amount_msat:type_name -> cln.Amount
-	28,  // 146: cln.GetrouteRoute.style:type_name -> cln.GetrouteRoute.GetrouteRouteStyle
-	29,  // 147: cln.ListforwardsRequest.status:type_name -> cln.ListforwardsRequest.ListforwardsStatus
-	150, // 148: cln.ListforwardsResponse.forwards:type_name -> cln.ListforwardsForwards
-	163, // 149: cln.ListforwardsForwards.in_msat:type_name -> cln.Amount
-	30,  // 150: cln.ListforwardsForwards.status:type_name -> cln.ListforwardsForwards.ListforwardsForwardsStatus
-	31,  // 151: cln.ListforwardsForwards.style:type_name -> cln.ListforwardsForwards.ListforwardsForwardsStyle
-	163, // 152: cln.ListforwardsForwards.fee_msat:type_name -> cln.Amount
-	163, // 153: cln.ListforwardsForwards.out_msat:type_name -> cln.Amount
-	32,  // 154: cln.ListpaysRequest.status:type_name -> cln.ListpaysRequest.ListpaysStatus
-	153, // 155: cln.ListpaysResponse.pays:type_name -> cln.ListpaysPays
-	33,  // 156: cln.ListpaysPays.status:type_name -> cln.ListpaysPays.ListpaysPaysStatus
-	163, // 157: cln.SetchannelRequest.feebase:type_name -> cln.Amount
-	163, // 158: cln.SetchannelRequest.htlcmin:type_name -> cln.Amount
-	163, // 159: cln.SetchannelRequest.htlcmax:type_name -> cln.Amount
-	158, // 160: cln.SetchannelResponse.channels:type_name -> cln.SetchannelChannels
-	163, // 161: cln.SetchannelChannels.fee_base_msat:type_name -> cln.Amount
-	163, // 162: cln.SetchannelChannels.minimum_htlc_out_msat:type_name -> cln.Amount
-	163, // 163: cln.SetchannelChannels.maximum_htlc_out_msat:type_name -> cln.Amount
-	34,  // 164: cln.Node.Getinfo:input_type -> cln.GetinfoRequest
-	39,  // 165: cln.Node.ListPeers:input_type -> cln.ListpeersRequest
-	49,  // 166: cln.Node.ListFunds:input_type -> cln.ListfundsRequest
-	53,  // 167: cln.Node.SendPay:input_type -> cln.SendpayRequest
-	56,  // 168: cln.Node.ListChannels:input_type -> cln.ListchannelsRequest
-	59,  // 169: cln.Node.AddGossip:input_type -> cln.AddgossipRequest
-	61,  // 170: cln.Node.AutoCleanInvoice:input_type -> cln.AutocleaninvoiceRequest
-	63,  // 171: cln.Node.CheckMessage:input_type -> cln.CheckmessageRequest
-	65,  // 172: cln.Node.Close:input_type -> cln.CloseRequest
-	67,  // 173: cln.Node.ConnectPeer:input_type -> cln.ConnectRequest
-	70,  // 174: cln.Node.CreateInvoice:input_type -> cln.CreateinvoiceRequest
-	72,  // 175: cln.Node.Datastore:input_type -> cln.DatastoreRequest
-	74,  // 176: cln.Node.CreateOnion:input_type -> cln.CreateonionRequest
-	77,  // 177: cln.Node.DelDatastore:input_type -> cln.DeldatastoreRequest
-	79,  // 178: cln.Node.DelExpiredInvoice:input_type -> cln.DelexpiredinvoiceRequest
-	81,  // 179: cln.Node.DelInvoice:input_type -> cln.DelinvoiceRequest
-	83,  // 180: cln.Node.Invoice:input_type -> cln.InvoiceRequest
-	85,  // 181: cln.Node.ListDatastore:input_type -> cln.ListdatastoreRequest
-	88,  // 182: cln.Node.ListInvoices:input_type -> cln.ListinvoicesRequest
-	91,  // 183: cln.Node.SendOnion:input_type -> cln.SendonionRequest
-	94,  // 184: cln.Node.ListSendPays:input_type -> cln.ListsendpaysRequest
-	97,  // 185: cln.Node.ListTransactions:input_type -> cln.ListtransactionsRequest
-	102, // 186: cln.Node.Pay:input_type -> cln.PayRequest
-	104, // 187: cln.Node.ListNodes:input_type -> cln.ListnodesRequest
-	108, // 188: cln.Node.WaitAnyInvoice:input_type -> cln.WaitanyinvoiceRequest
-	110, // 189: cln.Node.WaitInvoice:input_type -> cln.WaitinvoiceRequest
-	112, // 190: cln.Node.WaitSendPay:input_type -> cln.WaitsendpayRequest
-	114, // 191: cln.Node.NewAddr:input_type -> cln.NewaddrRequest
-	116, // 192: cln.Node.Withdraw:input_type -> cln.WithdrawRequest
-	118, // 193: cln.Node.KeySend:input_type -> cln.KeysendRequest
-	120, // 194: cln.Node.FundPsbt:input_type -> cln.FundpsbtRequest
-	123, // 195: cln.Node.SendPsbt:input_type -> cln.SendpsbtRequest
-	125, // 196: cln.Node.SignPsbt:input_type -> cln.SignpsbtRequest
-	127, // 197: cln.Node.UtxoPsbt:input_type -> cln.UtxopsbtRequest
-	130, // 198: cln.Node.TxDiscard:input_type -> cln.TxdiscardRequest
-	132, // 199: cln.Node.TxPrepare:input_type -> cln.TxprepareRequest
-	134, // 200: cln.Node.TxSend:input_type -> cln.TxsendRequest
-	136, // 201: cln.Node.Disconnect:input_type -> cln.DisconnectRequest
-	138, // 202: cln.Node.Feerates:input_type -> cln.FeeratesRequest
-	143, // 203: cln.Node.FundChannel:input_type -> cln.FundchannelRequest
-	145, // 204: cln.Node.GetRoute:input_type -> cln.GetrouteRequest
-	148, // 205: cln.Node.ListForwards:input_type -> cln.ListforwardsRequest
-	151, // 206: cln.Node.ListPays:input_type -> cln.ListpaysRequest
-	154, // 207: cln.Node.Ping:input_type -> cln.PingRequest
-	156, // 208: cln.Node.SetChannel:input_type -> cln.SetchannelRequest
-	159, // 209: cln.Node.SignMessage:input_type -> cln.SignmessageRequest
-	161, // 210: cln.Node.Stop:input_type -> cln.StopRequest
-	35,  // 211: cln.Node.Getinfo:output_type -> cln.GetinfoResponse
-	40,  // 212: cln.Node.ListPeers:output_type -> cln.ListpeersResponse
-	50,  // 213: cln.Node.ListFunds:output_type -> cln.ListfundsResponse
-	54,  // 214: cln.Node.SendPay:output_type -> cln.SendpayResponse
-	57,  // 215: cln.Node.ListChannels:output_type -> cln.ListchannelsResponse
-	60,  // 216: cln.Node.AddGossip:output_type -> cln.AddgossipResponse
-	62,  // 217: cln.Node.AutoCleanInvoice:output_type -> cln.AutocleaninvoiceResponse
-	64,  // 218: cln.Node.CheckMessage:output_type -> cln.CheckmessageResponse
-	66,  // 219: cln.Node.Close:output_type -> cln.CloseResponse
-	68,  // 220: cln.Node.ConnectPeer:output_type -> cln.ConnectResponse
-	71,  // 221: cln.Node.CreateInvoice:output_type -> cln.CreateinvoiceResponse
-	73,  // 222: cln.Node.Datastore:output_type -> cln.DatastoreResponse
-	75,  // 223: cln.Node.CreateOnion:output_type -> cln.CreateonionResponse
-	78,  // 224: cln.Node.DelDatastore:output_type -> cln.DeldatastoreResponse
-	80,  // 225: cln.Node.DelExpiredInvoice:output_type -> cln.DelexpiredinvoiceResponse
-	82,  // 226: cln.Node.DelInvoice:output_type -> cln.DelinvoiceResponse
-	84,  // 227: cln.Node.Invoice:output_type -> cln.InvoiceResponse
-	86,  // 228: cln.Node.ListDatastore:output_type -> cln.ListdatastoreResponse
-	89,  // 229: cln.Node.ListInvoices:output_type -> cln.ListinvoicesResponse
-	92,  // 230: cln.Node.SendOnion:output_type -> cln.SendonionResponse
-	95,  // 231: cln.Node.ListSendPays:output_type -> cln.ListsendpaysResponse
-	98,  // 232: cln.Node.ListTransactions:output_type -> cln.ListtransactionsResponse
-	103, // 233: cln.Node.Pay:output_type -> cln.PayResponse
-	105, // 234: cln.Node.ListNodes:output_type -> cln.ListnodesResponse
-	109, // 235: cln.Node.WaitAnyInvoice:output_type -> cln.WaitanyinvoiceResponse
-	111, // 236: cln.Node.WaitInvoice:output_type -> cln.WaitinvoiceResponse
-	113, // 237: cln.Node.WaitSendPay:output_type -> cln.WaitsendpayResponse
-	115, // 238: cln.Node.NewAddr:output_type -> cln.NewaddrResponse
-	117, // 239: cln.Node.Withdraw:output_type -> cln.WithdrawResponse
-	119, // 240: cln.Node.KeySend:output_type -> cln.KeysendResponse
-	121, // 241: cln.Node.FundPsbt:output_type -> cln.FundpsbtResponse
-	124, // 242: cln.Node.SendPsbt:output_type -> cln.SendpsbtResponse
-	126, // 243: cln.Node.SignPsbt:output_type -> cln.SignpsbtResponse
-	128, // 244: cln.Node.UtxoPsbt:output_type -> cln.UtxopsbtResponse
-	131, // 245: cln.Node.TxDiscard:output_type -> cln.TxdiscardResponse
-	133, // 246: cln.Node.TxPrepare:output_type -> cln.TxprepareResponse
-	135, // 247: cln.Node.TxSend:output_type -> cln.TxsendResponse
-	137, // 248: cln.Node.Disconnect:output_type -> cln.DisconnectResponse
-	139, // 249: cln.Node.Feerates:output_type -> cln.FeeratesResponse
-	144, // 250: cln.Node.FundChannel:output_type -> cln.FundchannelResponse
-	146, // 251: cln.Node.GetRoute:output_type -> cln.GetrouteResponse
-	149, // 252: cln.Node.ListForwards:output_type -> cln.ListforwardsResponse
-	152, // 253: cln.Node.ListPays:output_type -> cln.ListpaysResponse
-	155, // 254: cln.Node.Ping:output_type -> cln.PingResponse
-	157, // 255: cln.Node.SetChannel:output_type -> cln.SetchannelResponse
-	160, // 256: cln.Node.SignMessage:output_type -> cln.SignmessageResponse
-	162, // 257: cln.Node.Stop:output_type -> cln.StopResponse
-	211, // [211:258] is the sub-list for method output_type
-	164, // [164:211] is the sub-list for method input_type
-	164, // [164:164] is the sub-list for extension type_name
-	164, // [164:164] is the sub-list for extension extendee
-	0,   // [0:164] is the sub-list for field type_name
+	176, // 137: cln.FundchannelRequest.amount:type_name -> cln.AmountOrAll
+	174, // 138: cln.FundchannelRequest.feerate:type_name -> cln.Feerate
+	170, // 139: cln.FundchannelRequest.push_msat:type_name -> cln.Amount
+	170, // 140: cln.FundchannelRequest.request_amt:type_name -> cln.Amount
+	173, // 141: cln.FundchannelRequest.utxos:type_name -> cln.Outpoint
+	170, // 142: cln.FundchannelRequest.reserve:type_name -> cln.Amount
+	147, // 143: cln.MultifundchannelRequest.destinations:type_name -> cln.MultifundchannelDestinations
+	174, // 144: cln.MultifundchannelRequest.feerate:type_name -> cln.Feerate
+	173, // 145: cln.MultifundchannelRequest.utxos:type_name -> cln.Outpoint
+	174, // 146: cln.MultifundchannelRequest.commitment_feerate:type_name -> cln.Feerate
+	176, // 147: cln.MultifundchannelDestinations.amount:type_name -> cln.AmountOrAll
+	170, // 148: cln.MultifundchannelDestinations.push_msat:type_name -> cln.Amount
+	170, // 149: cln.MultifundchannelDestinations.request_amt:type_name -> cln.Amount
+	170, // 150: cln.MultifundchannelDestinations.reserve:type_name -> cln.Amount
+	149, // 151: cln.MultifundchannelResponse.channel_ids:type_name -> cln.MultifundchannelChannel_ids
+	150, // 152: cln.MultifundchannelResponse.failed:type_name -> cln.MultifundchannelFailed
+	28,  // 153: cln.MultifundchannelFailed.method:type_name -> cln.MultifundchannelFailed.MultifundchannelFailedMethod
+	151, // 154: cln.MultifundchannelFailed.error:type_name -> cln.MultifundchannelFailedError
+	170, // 155: cln.GetrouteRequest.amount_msat:type_name -> cln.Amount
+	154, // 156: cln.GetrouteResponse.route:type_name -> cln.GetrouteRoute
+	170, // 157: cln.GetrouteRoute.amount_msat:type_name -> cln.Amount
+	29,  // 158: cln.GetrouteRoute.style:type_name -> cln.GetrouteRoute.GetrouteRouteStyle
+	30,  // 159: cln.ListforwardsRequest.status:type_name -> cln.ListforwardsRequest.ListforwardsStatus
+	157, // 160: cln.ListforwardsResponse.forwards:type_name -> cln.ListforwardsForwards
+	170, // 161: cln.ListforwardsForwards.in_msat:type_name -> cln.Amount
+	31,  // 162: cln.ListforwardsForwards.status:type_name -> cln.ListforwardsForwards.ListforwardsForwardsStatus
+	32,  // 163: cln.ListforwardsForwards.style:type_name -> cln.ListforwardsForwards.ListforwardsForwardsStyle
+	170, // 164: cln.ListforwardsForwards.fee_msat:type_name -> cln.Amount
+	170, // 165: cln.ListforwardsForwards.out_msat:type_name -> cln.Amount
+	33,  // 166: cln.ListpaysRequest.status:type_name -> cln.ListpaysRequest.ListpaysStatus
+	160, // 167: cln.ListpaysResponse.pays:type_name -> cln.ListpaysPays
+	34,  // 168: cln.ListpaysPays.status:type_name -> cln.ListpaysPays.ListpaysPaysStatus
+	170, // 169: cln.SetchannelRequest.feebase:type_name -> cln.Amount
+	170, // 170: cln.SetchannelRequest.htlcmin:type_name -> cln.Amount
+	170, // 171: cln.SetchannelRequest.htlcmax:type_name -> cln.Amount
+	165, // 172: cln.SetchannelResponse.channels:type_name -> cln.SetchannelChannels
+	170, // 173: cln.SetchannelChannels.fee_base_msat:type_name -> cln.Amount
+	170, // 174: cln.SetchannelChannels.minimum_htlc_out_msat:type_name -> cln.Amount
+	170, // 175: cln.SetchannelChannels.maximum_htlc_out_msat:type_name -> cln.Amount
+	35,  // 176: cln.Node.Getinfo:input_type -> cln.GetinfoRequest
+	40,  // 177: cln.Node.ListPeers:input_type -> cln.ListpeersRequest
+	50,  // 178: cln.Node.ListFunds:input_type -> cln.ListfundsRequest
+	54,  // 179: cln.Node.SendPay:input_type -> cln.SendpayRequest
+	57,  // 180: cln.Node.ListChannels:input_type -> cln.ListchannelsRequest
+	60,  // 181: cln.Node.AddGossip:input_type -> cln.AddgossipRequest
+	62,  // 182: cln.Node.AutoCleanInvoice:input_type -> cln.AutocleaninvoiceRequest
+	64,  // 183: cln.Node.CheckMessage:input_type -> cln.CheckmessageRequest
+	66,  // 184: cln.Node.Close:input_type -> cln.CloseRequest
+	68,  // 185: cln.Node.ConnectPeer:input_type -> cln.ConnectRequest
+	71,  // 186: cln.Node.CreateInvoice:input_type -> cln.CreateinvoiceRequest
+	73,  // 187: cln.Node.Datastore:input_type -> cln.DatastoreRequest
+	75,  // 188: cln.Node.CreateOnion:input_type -> cln.CreateonionRequest
+	78,  // 189: cln.Node.DelDatastore:input_type -> cln.DeldatastoreRequest
+	80,  // 190: cln.Node.DelExpiredInvoice:input_type -> cln.DelexpiredinvoiceRequest
+	82,  // 191: cln.Node.DelInvoice:input_type -> cln.DelinvoiceRequest
+	84,  // 192: cln.Node.Invoice:input_type -> cln.InvoiceRequest
+	86,  // 193: cln.Node.ListDatastore:input_type -> cln.ListdatastoreRequest
+	89,  // 194: cln.Node.ListInvoices:input_type -> cln.ListinvoicesRequest
+	92,  // 195: cln.Node.SendOnion:input_type -> cln.SendonionRequest
+	95,  // 196: cln.Node.ListSendPays:input_type -> cln.ListsendpaysRequest
+	98,  // 197: cln.Node.ListTransactions:input_type -> cln.ListtransactionsRequest
+	103, // 198: cln.Node.Pay:input_type -> cln.PayRequest
+	105, // 199: cln.Node.ListNodes:input_type -> cln.ListnodesRequest
+	109, // 200: cln.Node.WaitAnyInvoice:input_type -> cln.WaitanyinvoiceRequest
+	111, // 201: cln.Node.WaitInvoice:input_type -> cln.WaitinvoiceRequest
+	113, // 202: cln.Node.WaitSendPay:input_type -> cln.WaitsendpayRequest
+	115, // 203: cln.Node.NewAddr:input_type -> cln.NewaddrRequest
+	117, // 204: cln.Node.Withdraw:input_type -> cln.WithdrawRequest
+	119, // 205: cln.Node.KeySend:input_type -> cln.KeysendRequest
+	121, // 206: cln.Node.FundPsbt:input_type -> cln.FundpsbtRequest
+	124, // 207: cln.Node.SendPsbt:input_type -> cln.SendpsbtRequest
+	126, // 208: cln.Node.SignPsbt:input_type -> cln.SignpsbtRequest
+	128, // 209: cln.Node.UtxoPsbt:input_type -> cln.UtxopsbtRequest
+	131, // 210: cln.Node.TxDiscard:input_type -> cln.TxdiscardRequest
+	133, // 211: cln.Node.TxPrepare:input_type -> cln.TxprepareRequest
+	135, // 212: cln.Node.TxSend:input_type -> cln.TxsendRequest
+	137, // 213: cln.Node.Disconnect:input_type -> cln.DisconnectRequest
+	139, // 214: cln.Node.Feerates:input_type -> cln.FeeratesRequest
+	144, // 215: cln.Node.FundChannel:input_type -> cln.FundchannelRequest
+	146, // 216: cln.Node.MultiFundChannel:input_type -> cln.MultifundchannelRequest
+	152, // 217: cln.Node.GetRoute:input_type -> cln.GetrouteRequest
+	155, // 218: cln.Node.ListForwards:input_type -> cln.ListforwardsRequest
+	158, // 219: cln.Node.ListPays:input_type -> cln.ListpaysRequest
+	161, // 220: cln.Node.Ping:input_type -> cln.PingRequest
+	163, // 221: cln.Node.SetChannel:input_type -> cln.SetchannelRequest
+	166, // 222: cln.Node.SignMessage:input_type -> cln.SignmessageRequest
+	168, // 223: cln.Node.Stop:input_type -> cln.StopRequest
+	36,  // 224: cln.Node.Getinfo:output_type -> cln.GetinfoResponse
+	41,  // 225: cln.Node.ListPeers:output_type -> cln.ListpeersResponse
+	51,  // 226: cln.Node.ListFunds:output_type -> cln.ListfundsResponse
+	55,  // 227: cln.Node.SendPay:output_type -> cln.SendpayResponse
+	58,  // 228: cln.Node.ListChannels:output_type -> cln.ListchannelsResponse
+	61,  // 229: cln.Node.AddGossip:output_type -> cln.AddgossipResponse
+	63,  // 230: cln.Node.AutoCleanInvoice:output_type -> cln.AutocleaninvoiceResponse
+	65,  // 231: cln.Node.CheckMessage:output_type -> cln.CheckmessageResponse
+	67,  // 232: cln.Node.Close:output_type -> cln.CloseResponse
+	69,  // 233: cln.Node.ConnectPeer:output_type -> cln.ConnectResponse
+	72,  // 234: cln.Node.CreateInvoice:output_type -> cln.CreateinvoiceResponse
+	74,  // 235: cln.Node.Datastore:output_type -> cln.DatastoreResponse
+	76,  // 236: cln.Node.CreateOnion:output_type -> cln.CreateonionResponse
+	79,  // 237: cln.Node.DelDatastore:output_type -> cln.DeldatastoreResponse
+	81,  // 238: cln.Node.DelExpiredInvoice:output_type -> cln.DelexpiredinvoiceResponse
+	83,  // 239: cln.Node.DelInvoice:output_type -> cln.DelinvoiceResponse
+	85,  // 240: cln.Node.Invoice:output_type -> cln.InvoiceResponse
+	87,  // 241: cln.Node.ListDatastore:output_type -> cln.ListdatastoreResponse
+	90,  // 242: cln.Node.ListInvoices:output_type -> cln.ListinvoicesResponse
+	93,  // 243: cln.Node.SendOnion:output_type -> cln.SendonionResponse
+	96,  // 244: cln.Node.ListSendPays:output_type -> cln.ListsendpaysResponse
+	99,  // 245: cln.Node.ListTransactions:output_type -> cln.ListtransactionsResponse
+	104, // 246: cln.Node.Pay:output_type -> cln.PayResponse
+	106, // 247: cln.Node.ListNodes:output_type -> cln.ListnodesResponse
+	110, // 248: cln.Node.WaitAnyInvoice:output_type -> cln.WaitanyinvoiceResponse
+	112, // 249: cln.Node.WaitInvoice:output_type -> cln.WaitinvoiceResponse
+	114, // 250: cln.Node.WaitSendPay:output_type -> cln.WaitsendpayResponse
+	116, // 251: cln.Node.NewAddr:output_type -> cln.NewaddrResponse
+	118, // 252: cln.Node.Withdraw:output_type -> cln.WithdrawResponse
+	120, // 253: cln.Node.KeySend:output_type -> cln.KeysendResponse
+	122, // 254: cln.Node.FundPsbt:output_type -> cln.FundpsbtResponse
+	125, // 255: cln.Node.SendPsbt:output_type -> cln.SendpsbtResponse
+	127, // 256: cln.Node.SignPsbt:output_type -> cln.SignpsbtResponse
+	129, // 257: cln.Node.UtxoPsbt:output_type -> cln.UtxopsbtResponse
+	132, // 258: cln.Node.TxDiscard:output_type -> cln.TxdiscardResponse
+	134, // 259: cln.Node.TxPrepare:output_type -> cln.TxprepareResponse
+	136, // 260: cln.Node.TxSend:output_type -> cln.TxsendResponse
+	138, // 261: cln.Node.Disconnect:output_type -> cln.DisconnectResponse
+	140, // 262: cln.Node.Feerates:output_type -> cln.FeeratesResponse
+	145, // 263: cln.Node.FundChannel:output_type -> cln.FundchannelResponse
+	148, // 264: cln.Node.MultiFundChannel:output_type -> cln.MultifundchannelResponse
+	153, // 265: cln.Node.GetRoute:output_type -> cln.GetrouteResponse
+	156, // 266: cln.Node.ListForwards:output_type -> cln.ListforwardsResponse
+	159, // 267: cln.Node.ListPays:output_type -> cln.ListpaysResponse
+	162, // 268: cln.Node.Ping:output_type -> cln.PingResponse
+	164, // 269: cln.Node.SetChannel:output_type -> cln.SetchannelResponse
+	167, // 270: cln.Node.SignMessage:output_type -> cln.SignmessageResponse
+	169, // 271: cln.Node.Stop:output_type -> cln.StopResponse
+	224, // [224:272] is the sub-list for method output_type
+	176, // [176:224] is the sub-list for method input_type
+	176, // [176:176] is the sub-list for extension type_name
+	176, // [176:176] is the sub-list for extension extendee
+	0,   // [0:176] is the sub-list for field type_name
 }
 
 func init() { file_proto_cln_node_proto_init() }
@@ -16303,7 +16938,7 @@ func file_proto_cln_node_proto_init() {
 			}
 		}
 		file_proto_cln_node_proto_msgTypes[111].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetrouteRequest); i {
+			switch v := v.(*MultifundchannelRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -16315,7 +16950,7 @@ func file_proto_cln_node_proto_init() {
 			}
 		}
 		file_proto_cln_node_proto_msgTypes[112].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetrouteResponse); i {
+			switch v := v.(*MultifundchannelDestinations); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -16327,7 +16962,7 @@ func file_proto_cln_node_proto_init() {
 			}
 		}
 		file_proto_cln_node_proto_msgTypes[113].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetrouteRoute); i {
+			switch v := v.(*MultifundchannelResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -16339,7 +16974,7 @@ func file_proto_cln_node_proto_init() {
 			}
 		}
 		file_proto_cln_node_proto_msgTypes[114].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ListforwardsRequest); i {
+			switch v := v.(*MultifundchannelChannelIds); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -16351,7 +16986,7 @@ func file_proto_cln_node_proto_init() {
 			}
 		}
 		file_proto_cln_node_proto_msgTypes[115].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ListforwardsResponse); i {
+			switch v := v.(*MultifundchannelFailed); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -16363,7 +16998,7 @@ func file_proto_cln_node_proto_init() {
 			}
 		}
 		file_proto_cln_node_proto_msgTypes[116].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ListforwardsForwards); i {
+			switch v := v.(*MultifundchannelFailedError); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -16375,7 +17010,7 @@ func file_proto_cln_node_proto_init() {
 			}
 		}
 		file_proto_cln_node_proto_msgTypes[117].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ListpaysRequest); i {
+			switch v := v.(*GetrouteRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -16387,7 +17022,7 @@ func file_proto_cln_node_proto_init() {
 			}
 		}
 		file_proto_cln_node_proto_msgTypes[118].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ListpaysResponse); i {
+			switch v := v.(*GetrouteResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -16399,7 +17034,7 @@ func file_proto_cln_node_proto_init() {
 			}
 		}
 		file_proto_cln_node_proto_msgTypes[119].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ListpaysPays); i {
+			switch v := v.(*GetrouteRoute); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -16411,7 +17046,7 @@ func file_proto_cln_node_proto_init() {
 			}
 		}
 		file_proto_cln_node_proto_msgTypes[120].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PingRequest); i {
+			switch v := v.(*ListforwardsRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -16423,7 +17058,7 @@ func file_proto_cln_node_proto_init() {
 			}
 		}
 		file_proto_cln_node_proto_msgTypes[121].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PingResponse); i {
+			switch v := v.(*ListforwardsResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -16435,7 +17070,7 @@ func file_proto_cln_node_proto_init() {
 			}
 		}
 		file_proto_cln_node_proto_msgTypes[122].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SetchannelRequest); i {
+			switch v := v.(*ListforwardsForwards); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -16447,7 +17082,7 @@ func file_proto_cln_node_proto_init() {
 			}
 		}
 		file_proto_cln_node_proto_msgTypes[123].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SetchannelResponse); i {
+			switch v := v.(*ListpaysRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -16459,7 +17094,7 @@ func file_proto_cln_node_proto_init() {
 			}
 		}
 		file_proto_cln_node_proto_msgTypes[124].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SetchannelChannels); i {
+			switch v := v.(*ListpaysResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -16471,7 +17106,7 @@ func file_proto_cln_node_proto_init() {
 			}
 		}
 		file_proto_cln_node_proto_msgTypes[125].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SignmessageRequest); i {
+			switch v := v.(*ListpaysPays); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -16483,7 +17118,7 @@ func file_proto_cln_node_proto_init() {
 			}
 		}
 		file_proto_cln_node_proto_msgTypes[126].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SignmessageResponse); i {
+			switch v := v.(*PingRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -16495,7 +17130,7 @@ func file_proto_cln_node_proto_init() {
 			}
 		}
 		file_proto_cln_node_proto_msgTypes[127].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*StopRequest); i {
+			switch v := v.(*PingResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -16507,6 +17142,78 @@ func file_proto_cln_node_proto_init() {
 			}
 		}
 		file_proto_cln_node_proto_msgTypes[128].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SetchannelRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_cln_node_proto_msgTypes[129].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SetchannelResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_cln_node_proto_msgTypes[130].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SetchannelChannels); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_cln_node_proto_msgTypes[131].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SignmessageRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_cln_node_proto_msgTypes[132].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SignmessageResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_cln_node_proto_msgTypes[133].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StopRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_cln_node_proto_msgTypes[134].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*StopResponse); i {
 			case 0:
 				return &v.state
@@ -16592,21 +17299,24 @@ func file_proto_cln_node_proto_init() {
 	file_proto_cln_node_proto_msgTypes[109].OneofWrappers = []interface{}{}
 	file_proto_cln_node_proto_msgTypes[110].OneofWrappers = []interface{}{}
 	file_proto_cln_node_proto_msgTypes[111].OneofWrappers = []interface{}{}
-	file_proto_cln_node_proto_msgTypes[113].OneofWrappers = []interface{}{}
+	file_proto_cln_node_proto_msgTypes[112].OneofWrappers = []interface{}{}
 	file_proto_cln_node_proto_msgTypes[114].OneofWrappers = []interface{}{}
-	file_proto_cln_node_proto_msgTypes[116].OneofWrappers = []interface{}{}
 	file_proto_cln_node_proto_msgTypes[117].OneofWrappers = []interface{}{}
 	file_proto_cln_node_proto_msgTypes[119].OneofWrappers = []interface{}{}
 	file_proto_cln_node_proto_msgTypes[120].OneofWrappers = []interface{}{}
 	file_proto_cln_node_proto_msgTypes[122].OneofWrappers = []interface{}{}
-	file_proto_cln_node_proto_msgTypes[124].OneofWrappers = []interface{}{}
+	file_proto_cln_node_proto_msgTypes[123].OneofWrappers = []interface{}{}
+	file_proto_cln_node_proto_msgTypes[125].OneofWrappers = []interface{}{}
+	file_proto_cln_node_proto_msgTypes[126].OneofWrappers = []interface{}{}
+	file_proto_cln_node_proto_msgTypes[128].OneofWrappers = []interface{}{}
+	file_proto_cln_node_proto_msgTypes[130].OneofWrappers = []interface{}{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_proto_cln_node_proto_rawDesc,
-			NumEnums:      34,
-			NumMessages:   129,
+			NumEnums:      35,
+			NumMessages:   135,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
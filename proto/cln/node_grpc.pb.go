@@ -59,6 +59,7 @@ const (
 	Node_Disconnect_FullMethodName        = "/cln.Node/Disconnect"
 	Node_Feerates_FullMethodName          = "/cln.Node/Feerates"
 	Node_FundChannel_FullMethodName       = "/cln.Node/FundChannel"
+	Node_MultiFundChannel_FullMethodName  = "/cln.Node/MultiFundChannel"
 	Node_GetRoute_FullMethodName          = "/cln.Node/GetRoute"
 	Node_ListForwards_FullMethodName      = "/cln.Node/ListForwards"
 	Node_ListPays_FullMethodName          = "/cln.Node/ListPays"
@@ -112,6 +113,7 @@ type NodeClient interface {
 	Disconnect(ctx context.Context, in *DisconnectRequest, opts ...grpc.CallOption) (*DisconnectResponse, error)
 	Feerates(ctx context.Context, in *FeeratesRequest, opts ...grpc.CallOption) (*FeeratesResponse, error)
 	FundChannel(ctx context.Context, in *FundchannelRequest, opts ...grpc.CallOption) (*FundchannelResponse, error)
+	MultiFundChannel(ctx context.Context, in *MultifundchannelRequest, opts ...grpc.CallOption) (*MultifundchannelResponse, error)
 	GetRoute(ctx context.Context, in *GetrouteRequest, opts ...grpc.CallOption) (*GetrouteResponse, error)
 	ListForwards(ctx context.Context, in *ListforwardsRequest, opts ...grpc.CallOption) (*ListforwardsResponse, error)
 	ListPays(ctx context.Context, in *ListpaysRequest, opts ...grpc.CallOption) (*ListpaysResponse, error)
@@ -489,6 +491,15 @@ func (c *nodeClient) FundChannel(ctx context.Context, in *FundchannelRequest, op
 	return out, nil
 }
 
+func (c *nodeClient) MultiFundChannel(ctx context.Context, in *MultifundchannelRequest, opts ...grpc.CallOption) (*MultifundchannelResponse, error) {
+	out := new(MultifundchannelResponse)
+	err := c.cc.Invoke(ctx, Node_MultiFundChannel_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *nodeClient) GetRoute(ctx context.Context, in *GetrouteRequest, opts ...grpc.CallOption) (*GetrouteResponse, error) {
 	out := new(GetrouteResponse)
 	err := c.cc.Invoke(ctx, Node_GetRoute_FullMethodName, in, out, opts...)
@@ -596,6 +607,7 @@ type NodeServer interface {
 	Disconnect(context.Context, *DisconnectRequest) (*DisconnectResponse, error)
 	Feerates(context.Context, *FeeratesRequest) (*FeeratesResponse, error)
 	FundChannel(context.Context, *FundchannelRequest) (*FundchannelResponse, error)
+	MultiFundChannel(context.Context, *MultifundchannelRequest) (*MultifundchannelResponse, error)
 	GetRoute(context.Context, *GetrouteRequest) (*GetrouteResponse, error)
 	ListForwards(context.Context, *ListforwardsRequest) (*ListforwardsResponse, error)
 	ListPays(context.Context, *ListpaysRequest) (*ListpaysResponse, error)
@@ -730,6 +742,9 @@ func (UnimplementedNodeServer) Feerates(context.Context, *FeeratesRequest) (*Fee
 func (UnimplementedNodeServer) FundChannel(context.Context, *FundchannelRequest) (*FundchannelResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method FundChannel not implemented")
 }
+func (UnimplementedNodeServer) MultiFundChannel(context.Context, *MultifundchannelRequest) (*MultifundchannelResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MultiFundChannel not implemented")
+}
 func (UnimplementedNodeServer) GetRoute(context.Context, *GetrouteRequest) (*GetrouteResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetRoute not implemented")
 }
@@ -1484,6 +1499,24 @@ func _Node_FundChannel_Handler(srv interface{}, ctx context.Context, dec func(in
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Node_MultiFundChannel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MultifundchannelRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeServer).MultiFundChannel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Node_MultiFundChannel_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeServer).MultiFundChannel(ctx, req.(*MultifundchannelRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Node_GetRoute_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetrouteRequest)
 	if err := dec(in); err != nil {
@@ -1777,6 +1810,10 @@ var Node_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "FundChannel",
 			Handler:    _Node_FundChannel_Handler,
 		},
+		{
+			MethodName: "MultiFundChannel",
+			Handler:    _Node_MultiFundChannel_Handler,
+		},
 		{
 			MethodName: "GetRoute",
 			Handler:    _Node_GetRoute_Handler,
@@ -77,6 +77,7 @@ export const torqApi = createApi({
     "peers",
     "tagsForChannel",
     "tagsForNode",
+    "communications",
   ],
   endpoints: (builder) => ({
     getFlow: builder.query<FlowData[], GetFlowQueryParams>({
@@ -146,6 +147,14 @@ export const torqApi = createApi({
         body: new URLSearchParams(form as any),
       }),
     }),
+    totpLogin: builder.mutation<LoginResponse, FormData>({
+      query: (form) => ({
+        url: "login/totp",
+        method: "POST",
+        // eslint-disable-next-line @typescript-eslint/no-explicit-any
+        body: new URLSearchParams(form as any),
+      }),
+    }),
     cookieLogin: builder.mutation<LoginResponse, string>({
       query: (key) => ({
         url: `cookie-login`,
@@ -275,6 +284,7 @@ export const {
   useGetForwardsQuery,
   useGetChannelsQuery,
   useLoginMutation,
+  useTotpLoginMutation,
   useCookieLoginMutation,
   useLogoutMutation,
   useGetSettingsQuery,
@@ -9,6 +9,10 @@ export interface settings {
   slackBotAppToken: string;
   telegramHighPriorityCredentials: string;
   telegramLowPriorityCredentials: string;
+  approvalThresholdSat?: number;
+  approvalExpiryMinutes: number;
+  htlcFailureBurstWindowMinutes: number;
+  htlcFailureBurstThreshold: number;
 }
 export interface updateSettingsRequest {
   defaultDateRange: string;
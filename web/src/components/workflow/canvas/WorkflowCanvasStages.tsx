@@ -25,6 +25,9 @@ import {
   ChannelCloseTriggerNode,
   DataSourceTorqChannelsNode,
   ChannelBalanceEventFilterNode,
+  SendNotificationNode,
+  SetVariableNode,
+  FilterOnVariableNode,
 } from "components/workflow/nodes/nodes";
 import { WorkflowVersionNode } from "pages/WorkflowPage/workflowTypes";
 import classNames from "classnames";
@@ -223,6 +226,12 @@ function getNodeComponent(node: WorkflowVersionNode) {
       return <DataSourceTorqChannelsNode {...node} key={"node-id-" + node.workflowVersionNodeId} />;
     case WorkflowNodeType.ChannelBalanceEventFilter:
       return <ChannelBalanceEventFilterNode {...node} key={"node-id-" + node.workflowVersionNodeId} />;
+    case WorkflowNodeType.SendNotification:
+      return <SendNotificationNode {...node} key={"node-id-" + node.workflowVersionNodeId} />;
+    case WorkflowNodeType.SetVariable:
+      return <SetVariableNode {...node} key={"node-id-" + node.workflowVersionNodeId} />;
+    case WorkflowNodeType.FilterOnVariable:
+      return <FilterOnVariableNode {...node} key={"node-id-" + node.workflowVersionNodeId} />;
     default:
       return null;
   }
@@ -20,6 +20,7 @@ import { Status } from "constants/backend";
 import ToastContext from "features/toast/context";
 import { toastCategory } from "features/toast/Toasts";
 import Note, { NoteType } from "features/note/Note";
+import { FormErrors, mergeServerError, ServerErrorType } from "components/errors/errors";
 
 type ChannelPolicyConfiguratorNodeProps = Omit<WorkflowNodeProps, "colorVariant">;
 
@@ -29,6 +30,11 @@ export type ChannelPolicyConfiguration = {
   maxHtlcMsat?: number;
   minHtlcMsat?: number;
   timeLockDelta?: number;
+  feeRateFormula?: string;
+  feeBaseFormula?: string;
+  minHtlcFormula?: string;
+  maxHtlcFormula?: string;
+  timeLockDeltaFormula?: string;
 };
 
 export function ChannelPolicyConfiguratorNode({ ...wrapperProps }: ChannelPolicyConfiguratorNodeProps) {
@@ -38,7 +44,17 @@ export function ChannelPolicyConfiguratorNode({ ...wrapperProps }: ChannelPolicy
   const editingDisabled = workflowStatus === Status.Active;
   const toastRef = React.useContext(ToastContext);
 
-  const [updateNode] = useUpdateNodeMutation();
+  const [updateNode, updateNodeResponse] = useUpdateNodeMutation();
+  const [formErrors, setFormErrors] = useState<FormErrors>({});
+
+  useEffect(() => {
+    if (updateNodeResponse.isError && updateNodeResponse.error && "data" in updateNodeResponse.error) {
+      setFormErrors(mergeServerError(updateNodeResponse.error.data as ServerErrorType, {}));
+    }
+    if (updateNodeResponse.isSuccess) {
+      setFormErrors({});
+    }
+  }, [updateNodeResponse]);
 
   const [channelPolicy, setChannelPolicy] = useState<ChannelPolicyConfiguration>({
     feeBaseMsat: undefined,
@@ -113,6 +129,30 @@ export function ChannelPolicyConfiguratorNode({ ...wrapperProps }: ChannelPolicy
     };
   }
 
+  type ChannelPolicyFormula =
+    | "feeRateFormula"
+    | "feeBaseFormula"
+    | "minHtlcFormula"
+    | "maxHtlcFormula"
+    | "timeLockDeltaFormula";
+
+  function createChangeFormulaHandler(key: ChannelPolicyFormula) {
+    return (e: React.ChangeEvent<HTMLInputElement>) => {
+      setChannelPolicy((prev) => ({
+        ...prev,
+        [key]: e.target.value.trim() === "" ? undefined : e.target.value,
+      }));
+    };
+  }
+
+  function getFormulaError(key: ChannelPolicyFormula): string | undefined {
+    const fieldErrors = formErrors.fields?.[key];
+    if (!fieldErrors || fieldErrors.length === 0) {
+      return undefined;
+    }
+    return fieldErrors.map((fieldError) => fieldError.description || fieldError.code).join(", ");
+  }
+
   function handleSubmit(e: React.FormEvent<HTMLFormElement>) {
     e.preventDefault();
 
@@ -227,6 +267,61 @@ export function ChannelPolicyConfiguratorNode({ ...wrapperProps }: ChannelPolicy
           sizeVariant={InputSizeVariant.small}
           disabled={editingDisabled}
         />
+        <Input
+          intercomTarget={"channel-policy-configurator-fee-rate-formula-input"}
+          value={channelPolicy.feeRateFormula || ""}
+          onChange={createChangeFormulaHandler("feeRateFormula")}
+          label={t.updateChannelPolicy.feeRateFormula}
+          placeholder={t.updateChannelPolicy.formulaPlaceholder}
+          helpText={t.updateChannelPolicy.formulaHelpText}
+          errorText={getFormulaError("feeRateFormula")}
+          sizeVariant={InputSizeVariant.small}
+          disabled={editingDisabled}
+        />
+        <Input
+          intercomTarget={"channel-policy-configurator-fee-base-formula-input"}
+          value={channelPolicy.feeBaseFormula || ""}
+          onChange={createChangeFormulaHandler("feeBaseFormula")}
+          label={t.updateChannelPolicy.feeBaseFormula}
+          placeholder={t.updateChannelPolicy.formulaPlaceholder}
+          helpText={t.updateChannelPolicy.formulaHelpText}
+          errorText={getFormulaError("feeBaseFormula")}
+          sizeVariant={InputSizeVariant.small}
+          disabled={editingDisabled}
+        />
+        <Input
+          intercomTarget={"channel-policy-configurator-min-htlc-formula-input"}
+          value={channelPolicy.minHtlcFormula || ""}
+          onChange={createChangeFormulaHandler("minHtlcFormula")}
+          label={t.updateChannelPolicy.minHtlcFormula}
+          placeholder={t.updateChannelPolicy.formulaPlaceholder}
+          helpText={t.updateChannelPolicy.formulaHelpText}
+          errorText={getFormulaError("minHtlcFormula")}
+          sizeVariant={InputSizeVariant.small}
+          disabled={editingDisabled}
+        />
+        <Input
+          intercomTarget={"channel-policy-configurator-max-htlc-formula-input"}
+          value={channelPolicy.maxHtlcFormula || ""}
+          onChange={createChangeFormulaHandler("maxHtlcFormula")}
+          label={t.updateChannelPolicy.maxHtlcFormula}
+          placeholder={t.updateChannelPolicy.formulaPlaceholder}
+          helpText={t.updateChannelPolicy.formulaHelpText}
+          errorText={getFormulaError("maxHtlcFormula")}
+          sizeVariant={InputSizeVariant.small}
+          disabled={editingDisabled}
+        />
+        <Input
+          intercomTarget={"channel-policy-configurator-time-lock-delta-formula-input"}
+          value={channelPolicy.timeLockDeltaFormula || ""}
+          onChange={createChangeFormulaHandler("timeLockDeltaFormula")}
+          label={t.updateChannelPolicy.timeLockDeltaFormula}
+          placeholder={t.updateChannelPolicy.formulaPlaceholder}
+          helpText={t.updateChannelPolicy.formulaHelpText}
+          errorText={getFormulaError("timeLockDeltaFormula")}
+          sizeVariant={InputSizeVariant.small}
+          disabled={editingDisabled}
+        />
         <Button
           intercomTarget={"channel-policy-configurator-save-button"}
           type="submit"
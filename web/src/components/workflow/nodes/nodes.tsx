@@ -31,3 +31,9 @@ export { DataSourceTorqChannelsNodeButton } from "components/workflow/nodes/data
 export { DataSourceTorqChannelsNode } from "components/workflow/nodes/dataSourceTorqChannels/DataSourceTorqChannelsNode";
 export { ChannelBalanceEventFilterNode } from "components/workflow/nodes/channelBalanceEventsFilter/ChannelBalanceEventFilterNode";
 export { ChannelBalanceEventFilterNodeButton } from "components/workflow/nodes/channelBalanceEventsFilter/ChannelBalanceEventFilterNodeButton";
+export { SendNotificationNode } from "components/workflow/nodes/sendNotification/SendNotificationNode";
+export { SendNotificationNodeButton } from "components/workflow/nodes/sendNotification/SendNotificationNodeButton";
+export { SetVariableNode } from "components/workflow/nodes/variables/SetVariableNode";
+export { SetVariableNodeButton } from "components/workflow/nodes/variables/SetVariableNodeButton";
+export { FilterOnVariableNode } from "components/workflow/nodes/variables/FilterOnVariableNode";
+export { FilterOnVariableNodeButton } from "components/workflow/nodes/variables/FilterOnVariableNodeButton";
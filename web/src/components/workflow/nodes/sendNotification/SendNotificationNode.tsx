@@ -0,0 +1,170 @@
+import React, { useContext, useEffect, useState } from "react";
+import { Alert20Regular as NotificationIcon, Save16Regular as SaveIcon } from "@fluentui/react-icons";
+import useTranslations from "services/i18n/useTranslations";
+import WorkflowNodeWrapper, { WorkflowNodeProps } from "components/workflow/nodeWrapper/WorkflowNodeWrapper";
+import Form from "components/forms/form/Form";
+import Socket from "components/forms/socket/Socket";
+import { NodeColorVariant } from "components/workflow/nodes/nodeVariants";
+import { SelectWorkflowNodeLinks, SelectWorkflowNodes, useUpdateNodeMutation } from "pages/WorkflowPage/workflowApi";
+import Button, { ColorVariant, SizeVariant } from "components/buttons/Button";
+import { useSelector } from "react-redux";
+import { InputSizeVariant, Select, TextArea } from "components/forms/forms";
+import Spinny from "features/spinny/Spinny";
+import { WorkflowContext } from "components/workflow/WorkflowContext";
+import { Status } from "constants/backend";
+import ToastContext from "features/toast/context";
+import { toastCategory } from "features/toast/Toasts";
+import { useGetCommunicationTargetsQuery } from "features/communications/communicationsApi";
+import { CommunicationTarget } from "features/communications/communicationsTypes";
+
+type SendNotificationNodeProps = Omit<WorkflowNodeProps, "colorVariant">;
+
+type SendNotificationParameters = {
+  messageTemplate: string;
+  communicationIds: number[];
+};
+
+type CommunicationOption = {
+  value: number;
+  label: string;
+};
+
+function getCommunicationLabel(communication: CommunicationTarget): string {
+  return communication.targetName || "#" + communication.communicationId;
+}
+
+export function SendNotificationNode({ ...wrapperProps }: SendNotificationNodeProps) {
+  const { t } = useTranslations();
+
+  const { workflowStatus } = useContext(WorkflowContext);
+  const editingDisabled = workflowStatus === Status.Active;
+  const toastRef = React.useContext(ToastContext);
+
+  const [updateNode] = useUpdateNodeMutation();
+
+  const { data: communicationsResponse } = useGetCommunicationTargetsQuery();
+
+  const communicationOptions: CommunicationOption[] = (communicationsResponse || []).map((communication) => {
+    return {
+      value: communication.communicationId,
+      label: getCommunicationLabel(communication),
+    };
+  });
+
+  const parameters = wrapperProps.parameters as SendNotificationParameters;
+  const [messageTemplate, setMessageTemplate] = useState<string>(parameters.messageTemplate || "");
+  const [communicationIds, setCommunicationIds] = useState<number[]>(parameters.communicationIds || []);
+
+  const [dirty, setDirty] = useState(false);
+  const [processing, setProcessing] = useState(false);
+  useEffect(() => {
+    const currentParameters = wrapperProps.parameters as SendNotificationParameters;
+    if (
+      (currentParameters.messageTemplate || "") !== messageTemplate ||
+      JSON.stringify([...(currentParameters.communicationIds || [])].sort()) !==
+        JSON.stringify([...communicationIds].sort())
+    ) {
+      setDirty(true);
+    } else {
+      setDirty(false);
+    }
+  }, [messageTemplate, communicationIds, wrapperProps.parameters]);
+
+  function handleCommunicationsChange(newValue: unknown) {
+    setCommunicationIds(((newValue as CommunicationOption[]) || []).map((option) => option.value));
+  }
+
+  function handleSubmit(e: React.FormEvent<HTMLFormElement>) {
+    e.preventDefault();
+
+    if (editingDisabled) {
+      toastRef?.current?.addToast(t.toast.cannotModifyWorkflowActive, toastCategory.warn);
+      return;
+    }
+
+    setProcessing(true);
+    updateNode({
+      workflowVersionNodeId: wrapperProps.workflowVersionNodeId,
+      parameters: {
+        messageTemplate: messageTemplate,
+        communicationIds: communicationIds,
+      },
+    }).finally(() => {
+      setProcessing(false);
+    });
+  }
+
+  const { childLinks } = useSelector(
+    SelectWorkflowNodeLinks({
+      version: wrapperProps.version,
+      workflowId: wrapperProps.workflowId,
+      nodeId: wrapperProps.workflowVersionNodeId,
+      stage: wrapperProps.stage,
+    })
+  );
+
+  const channelIds =
+    childLinks
+      ?.filter((n) => {
+        return n.childInput === "channels";
+      })
+      ?.map((link) => link.parentWorkflowVersionNodeId) ?? [];
+
+  const channels = useSelector(
+    SelectWorkflowNodes({
+      version: wrapperProps.version,
+      workflowId: wrapperProps.workflowId,
+      nodeIds: channelIds,
+    })
+  );
+
+  return (
+    <WorkflowNodeWrapper
+      {...wrapperProps}
+      headerIcon={<NotificationIcon />}
+      colorVariant={NodeColorVariant.accent2}
+      outputName={"channels"}
+    >
+      <Form onSubmit={handleSubmit} intercomTarget={"send-notification-node-form"}>
+        <Socket
+          collapsed={wrapperProps.visibilitySettings.collapsed}
+          label={t.channels}
+          selectedNodes={channels || []}
+          workflowVersionId={wrapperProps.workflowVersionId}
+          workflowVersionNodeId={wrapperProps.workflowVersionNodeId}
+          inputName={"channels"}
+          editingDisabled={editingDisabled}
+        />
+        <TextArea
+          intercomTarget={"send-notification-node-message-template-input"}
+          label={t.workflowNodes.messageTemplate}
+          helpText={t.workflowNodes.messageTemplateHelpText}
+          sizeVariant={InputSizeVariant.small}
+          value={messageTemplate}
+          onChange={(e) => setMessageTemplate(e.target.value)}
+          disabled={editingDisabled}
+        />
+        <Select
+          intercomTarget={"send-notification-node-communications-select"}
+          isMulti={true}
+          options={communicationOptions}
+          onChange={handleCommunicationsChange}
+          label={t.workflowNodes.communications}
+          sizeVariant={InputSizeVariant.small}
+          value={communicationOptions.filter((option) => communicationIds.includes(option.value))}
+          isDisabled={editingDisabled}
+        />
+        <Button
+          intercomTarget={"send-notification-node-save-button"}
+          type="submit"
+          buttonColor={ColorVariant.success}
+          buttonSize={SizeVariant.small}
+          icon={!processing ? <SaveIcon /> : <Spinny />}
+          disabled={!dirty || processing || editingDisabled}
+        >
+          {!processing ? t.save.toString() : t.saving.toString()}
+        </Button>
+      </Form>
+    </WorkflowNodeWrapper>
+  );
+}
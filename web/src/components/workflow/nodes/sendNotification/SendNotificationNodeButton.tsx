@@ -0,0 +1,20 @@
+import { Alert20Regular as NotificationIcon } from "@fluentui/react-icons";
+import useTranslations from "services/i18n/useTranslations";
+import WorkflowNodeButtonWrapper from "components/workflow/nodeButtonWrapper/NodeButtonWrapper";
+import { WorkflowNodeType } from "pages/WorkflowPage/constants";
+import { NodeColorVariant } from "../nodeVariants";
+
+export function SendNotificationNodeButton() {
+  const { t } = useTranslations();
+
+  return (
+    <WorkflowNodeButtonWrapper
+      intercomTarget={"send-notification-node-button"}
+      colorVariant={NodeColorVariant.accent2}
+      nodeType={WorkflowNodeType.SendNotification}
+      icon={<NotificationIcon />}
+      title={t.workflowNodes.sendNotification}
+      parameters={'{ "messageTemplate": "", "communicationIds": [] }'}
+    />
+  );
+}
@@ -0,0 +1,286 @@
+import React, { useContext, useEffect, useState } from "react";
+import { Filter20Regular as FilterIcon, Save16Regular as SaveIcon } from "@fluentui/react-icons";
+import useTranslations from "services/i18n/useTranslations";
+import WorkflowNodeWrapper, { WorkflowNodeProps } from "components/workflow/nodeWrapper/WorkflowNodeWrapper";
+import Form from "components/forms/form/Form";
+import Socket from "components/forms/socket/Socket";
+import NodeConnector from "components/workflow/nodeWrapper/NodeConnector";
+import { NodeColorVariant } from "components/workflow/nodes/nodeVariants";
+import { SelectWorkflowNodeLinks, SelectWorkflowNodes, useUpdateNodeMutation } from "pages/WorkflowPage/workflowApi";
+import Button, { ColorVariant, SizeVariant } from "components/buttons/Button";
+import { NumberFormatValues } from "react-number-format";
+import { useSelector } from "react-redux";
+import { Input, InputSizeVariant, RadioChips, Select } from "components/forms/forms";
+import { FilterCategoryType } from "features/sidebar/sections/filter/filter";
+import { getFilterFunctions } from "features/sidebar/sections/filter/FilterRow";
+import { AllChannelsColumns } from "features/channels/channelsColumns.generated";
+import Spinny from "features/spinny/Spinny";
+import { WorkflowContext } from "components/workflow/WorkflowContext";
+import { Status } from "constants/backend";
+import ToastContext from "features/toast/context";
+import { toastCategory } from "features/toast/Toasts";
+import styles from "./filter_on_variable.module.scss";
+
+type FilterOnVariableNodeProps = Omit<WorkflowNodeProps, "colorVariant">;
+
+// When channelKey is empty the variable is compared to value, otherwise the channels are compared to the variable.
+export type FilterOnVariableConfiguration = {
+  variableName: string;
+  category: FilterCategoryType;
+  funcName: string;
+  channelKey: string;
+  value?: number | string | boolean;
+};
+
+type SelectOption = {
+  value: string;
+  label: string;
+};
+
+// The categories that can be compared to a plain variable value
+const valueCategories: FilterCategoryType[] = ["number", "string", "boolean"];
+const channelKeyCategories: FilterCategoryType[] = ["number", "string", "boolean", "duration", "date"];
+
+function getDefaultValue(category: FilterCategoryType): number | string | boolean {
+  switch (category) {
+    case "string":
+      return "";
+    case "boolean":
+      return true;
+    default:
+      return 0;
+  }
+}
+
+export function FilterOnVariableNode({ ...wrapperProps }: FilterOnVariableNodeProps) {
+  const { t } = useTranslations();
+
+  const { workflowStatus } = useContext(WorkflowContext);
+  const editingDisabled = workflowStatus === Status.Active;
+  const toastRef = React.useContext(ToastContext);
+
+  const [updateNode] = useUpdateNodeMutation();
+
+  const [configuration, setConfiguration] = useState<FilterOnVariableConfiguration>({
+    variableName: (wrapperProps.parameters.variableName || "") as string,
+    category: (wrapperProps.parameters.category || "number") as FilterCategoryType,
+    funcName: (wrapperProps.parameters.funcName || "gte") as string,
+    channelKey: (wrapperProps.parameters.channelKey || "") as string,
+    value: wrapperProps.parameters.value as number | string | boolean | undefined,
+  });
+
+  const channelColumns = AllChannelsColumns.filter((column) =>
+    channelKeyCategories.includes(column.valueType as FilterCategoryType)
+  );
+  const channelKeyOptions: SelectOption[] = [
+    { value: "", label: t.workflowNodes.variableValueComparison },
+    ...channelColumns.map((column) => ({ value: column.key as string, label: column.heading })),
+  ];
+  const categoryOptions: SelectOption[] = valueCategories.map((category) => ({
+    value: category,
+    label: t.workflowNodes[category],
+  }));
+  const funcNameOptions: SelectOption[] = getFilterFunctions(configuration.category);
+
+  const [dirty, setDirty] = useState(false);
+  const [processing, setProcessing] = useState(false);
+  useEffect(() => {
+    if (JSON.stringify(wrapperProps.parameters) !== JSON.stringify(configuration)) {
+      setDirty(true);
+    } else {
+      setDirty(false);
+    }
+  }, [configuration, wrapperProps.parameters]);
+
+  function setCategory(channelKey: string, category: FilterCategoryType) {
+    setConfiguration((prev) => ({
+      ...prev,
+      channelKey: channelKey,
+      category: category,
+      funcName: category === prev.category ? prev.funcName : getFilterFunctions(category)[0].value,
+      value: channelKey === "" ? getDefaultValue(category) : undefined,
+    }));
+  }
+
+  function handleChannelKeyChange(newValue: unknown) {
+    const channelKey = (newValue as SelectOption)?.value || "";
+    const column = channelColumns.find((column) => column.key === channelKey);
+    setCategory(channelKey, column ? (column.valueType as FilterCategoryType) : "number");
+  }
+
+  function handleSubmit(e: React.FormEvent<HTMLFormElement>) {
+    e.preventDefault();
+
+    if (editingDisabled) {
+      toastRef?.current?.addToast(t.toast.cannotModifyWorkflowActive, toastCategory.warn);
+      return;
+    }
+
+    setProcessing(true);
+    updateNode({
+      workflowVersionNodeId: wrapperProps.workflowVersionNodeId,
+      parameters: configuration,
+    }).finally(() => {
+      setProcessing(false);
+    });
+  }
+
+  const { childLinks } = useSelector(
+    SelectWorkflowNodeLinks({
+      version: wrapperProps.version,
+      workflowId: wrapperProps.workflowId,
+      nodeId: wrapperProps.workflowVersionNodeId,
+      stage: wrapperProps.stage,
+    })
+  );
+
+  const channelIds =
+    childLinks
+      ?.filter((n) => {
+        return n.childInput === "channels";
+      })
+      ?.map((link) => link.parentWorkflowVersionNodeId) ?? [];
+
+  const channels = useSelector(
+    SelectWorkflowNodes({
+      version: wrapperProps.version,
+      workflowId: wrapperProps.workflowId,
+      nodeIds: channelIds,
+    })
+  );
+
+  function getValueInput() {
+    switch (configuration.category) {
+      case "string":
+        return (
+          <Input
+            intercomTarget={"filter-on-variable-node-value-string-input"}
+            label={t.workflowNodes.variableValue}
+            sizeVariant={InputSizeVariant.small}
+            value={configuration.value as string}
+            onChange={(e) => setConfiguration((prev) => ({ ...prev, value: e.target.value }))}
+            disabled={editingDisabled}
+          />
+        );
+      case "boolean":
+        return (
+          <RadioChips
+            label={t.workflowNodes.variableValue}
+            sizeVariant={InputSizeVariant.small}
+            groupName={"filter-on-variable-value-switch-" + wrapperProps.workflowVersionNodeId}
+            options={[
+              {
+                label: t.workflowNodes.true,
+                id: "filter-on-variable-value-true-" + wrapperProps.workflowVersionNodeId,
+                checked: configuration.value === true,
+                onChange: () => setConfiguration((prev) => ({ ...prev, value: true })),
+              },
+              {
+                label: t.workflowNodes.false,
+                id: "filter-on-variable-value-false-" + wrapperProps.workflowVersionNodeId,
+                checked: configuration.value === false,
+                onChange: () => setConfiguration((prev) => ({ ...prev, value: false })),
+              },
+            ]}
+            editingDisabled={editingDisabled}
+          />
+        );
+      default:
+        return (
+          <Input
+            intercomTarget={"filter-on-variable-node-value-number-input"}
+            formatted={true}
+            label={t.workflowNodes.variableValue}
+            sizeVariant={InputSizeVariant.small}
+            value={configuration.value as number}
+            thousandSeparator={","}
+            onValueChange={(values: NumberFormatValues) =>
+              setConfiguration((prev) => ({ ...prev, value: values.floatValue ?? 0 }))
+            }
+            disabled={editingDisabled}
+          />
+        );
+    }
+  }
+
+  return (
+    <WorkflowNodeWrapper
+      {...wrapperProps}
+      headerIcon={<FilterIcon />}
+      colorVariant={NodeColorVariant.accent1}
+      outputName={"channels"}
+    >
+      <Form onSubmit={handleSubmit} intercomTarget={"filter-on-variable-node-form"}>
+        <Socket
+          collapsed={wrapperProps.visibilitySettings.collapsed}
+          label={t.channels}
+          selectedNodes={channels || []}
+          workflowVersionId={wrapperProps.workflowVersionId}
+          workflowVersionNodeId={wrapperProps.workflowVersionNodeId}
+          inputName={"channels"}
+          editingDisabled={editingDisabled}
+        />
+        <Input
+          intercomTarget={"filter-on-variable-node-name-input"}
+          label={t.workflowNodes.variableName}
+          sizeVariant={InputSizeVariant.small}
+          value={configuration.variableName}
+          onChange={(e) => setConfiguration((prev) => ({ ...prev, variableName: e.target.value }))}
+          disabled={editingDisabled}
+        />
+        <Select
+          intercomTarget={"filter-on-variable-node-channel-key-select"}
+          label={t.workflowNodes.compareWith}
+          sizeVariant={InputSizeVariant.small}
+          options={channelKeyOptions}
+          value={channelKeyOptions.find((option) => option.value === configuration.channelKey)}
+          onChange={handleChannelKeyChange}
+          isDisabled={editingDisabled}
+        />
+        {configuration.channelKey === "" && (
+          <Select
+            intercomTarget={"filter-on-variable-node-category-select"}
+            label={t.workflowNodes.variableType}
+            sizeVariant={InputSizeVariant.small}
+            options={categoryOptions}
+            value={categoryOptions.find((option) => option.value === configuration.category)}
+            onChange={(newValue: unknown) => setCategory("", (newValue as SelectOption).value as FilterCategoryType)}
+            isDisabled={editingDisabled}
+          />
+        )}
+        <Select
+          intercomTarget={"filter-on-variable-node-function-select"}
+          label={t.workflowNodes.comparison}
+          sizeVariant={InputSizeVariant.small}
+          options={funcNameOptions}
+          value={funcNameOptions.find((option) => option.value === configuration.funcName)}
+          onChange={(newValue: unknown) =>
+            setConfiguration((prev) => ({ ...prev, funcName: (newValue as SelectOption).value }))
+          }
+          isDisabled={editingDisabled}
+        />
+        {configuration.channelKey === "" && getValueInput()}
+        <div className={styles.nonMatchingOutput}>
+          <label>{t.workflowNodes.nonMatchingChannels}</label>
+          <NodeConnector
+            id={`parentLinkConnector-${wrapperProps.workflowVersionNodeId}-nonMatchingChannels`}
+            name={t.workflowNodes.nonMatchingChannels}
+            outputName={"nonMatchingChannels"}
+            workflowVersionNodeId={wrapperProps.workflowVersionNodeId}
+            workflowVersionId={wrapperProps.workflowVersionId}
+          />
+        </div>
+        <Button
+          intercomTarget={"filter-on-variable-node-save-button"}
+          type="submit"
+          buttonColor={ColorVariant.success}
+          buttonSize={SizeVariant.small}
+          icon={!processing ? <SaveIcon /> : <Spinny />}
+          disabled={!dirty || processing || editingDisabled}
+        >
+          {!processing ? t.save.toString() : t.saving.toString()}
+        </Button>
+      </Form>
+    </WorkflowNodeWrapper>
+  );
+}
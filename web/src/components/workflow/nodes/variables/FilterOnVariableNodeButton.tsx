@@ -0,0 +1,20 @@
+import { Filter20Regular as FilterIcon } from "@fluentui/react-icons";
+import useTranslations from "services/i18n/useTranslations";
+import WorkflowNodeButtonWrapper from "components/workflow/nodeButtonWrapper/NodeButtonWrapper";
+import { WorkflowNodeType } from "pages/WorkflowPage/constants";
+import { NodeColorVariant } from "../nodeVariants";
+
+export function FilterOnVariableNodeButton() {
+  const { t } = useTranslations();
+
+  return (
+    <WorkflowNodeButtonWrapper
+      intercomTarget={"filter-on-variable-node-button"}
+      colorVariant={NodeColorVariant.accent1}
+      nodeType={WorkflowNodeType.FilterOnVariable}
+      icon={<FilterIcon />}
+      title={t.workflowNodes.filterOnVariable}
+      parameters={'{ "variableName": "", "category": "number", "funcName": "gte", "channelKey": "", "value": 0 }'}
+    />
+  );
+}
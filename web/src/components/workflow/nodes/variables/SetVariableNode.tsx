@@ -0,0 +1,180 @@
+import React, { useContext, useEffect, useState } from "react";
+import { Braces20Regular as VariableIcon, Save16Regular as SaveIcon } from "@fluentui/react-icons";
+import useTranslations from "services/i18n/useTranslations";
+import WorkflowNodeWrapper, { WorkflowNodeProps } from "components/workflow/nodeWrapper/WorkflowNodeWrapper";
+import Form from "components/forms/form/Form";
+import Socket from "components/forms/socket/Socket";
+import { NodeColorVariant } from "components/workflow/nodes/nodeVariants";
+import { SelectWorkflowNodeLinks, SelectWorkflowNodes, useUpdateNodeMutation } from "pages/WorkflowPage/workflowApi";
+import Button, { ColorVariant, SizeVariant } from "components/buttons/Button";
+import { NumberFormatValues } from "react-number-format";
+import { useSelector } from "react-redux";
+import { Input, InputSizeVariant, RadioChips } from "components/forms/forms";
+import Spinny from "features/spinny/Spinny";
+import { WorkflowContext } from "components/workflow/WorkflowContext";
+import { Status } from "constants/backend";
+import ToastContext from "features/toast/context";
+import { toastCategory } from "features/toast/Toasts";
+
+type SetVariableNodeProps = Omit<WorkflowNodeProps, "colorVariant">;
+
+// Either valueString or valueNumber is populated
+export type SetVariableConfiguration = {
+  variableName: string;
+  valueString?: string;
+  valueNumber?: number;
+};
+
+export function SetVariableNode({ ...wrapperProps }: SetVariableNodeProps) {
+  const { t } = useTranslations();
+
+  const { workflowStatus } = useContext(WorkflowContext);
+  const editingDisabled = workflowStatus === Status.Active;
+  const toastRef = React.useContext(ToastContext);
+
+  const [updateNode] = useUpdateNodeMutation();
+
+  const parameters = wrapperProps.parameters as SetVariableConfiguration;
+  const [variableName, setVariableName] = useState<string>(parameters.variableName || "");
+  const [isText, setIsText] = useState<boolean>(parameters.valueString !== undefined);
+  const [valueString, setValueString] = useState<string>(parameters.valueString || "");
+  const [valueNumber, setValueNumber] = useState<number | undefined>(parameters.valueNumber);
+
+  function getConfiguration(): SetVariableConfiguration {
+    if (isText) {
+      return { variableName: variableName, valueString: valueString };
+    }
+    return { variableName: variableName, valueNumber: valueNumber ?? 0 };
+  }
+
+  const [dirty, setDirty] = useState(false);
+  const [processing, setProcessing] = useState(false);
+  useEffect(() => {
+    if (JSON.stringify(wrapperProps.parameters) !== JSON.stringify(getConfiguration())) {
+      setDirty(true);
+    } else {
+      setDirty(false);
+    }
+  }, [variableName, isText, valueString, valueNumber, wrapperProps.parameters]);
+
+  function handleSubmit(e: React.FormEvent<HTMLFormElement>) {
+    e.preventDefault();
+
+    if (editingDisabled) {
+      toastRef?.current?.addToast(t.toast.cannotModifyWorkflowActive, toastCategory.warn);
+      return;
+    }
+
+    setProcessing(true);
+    updateNode({
+      workflowVersionNodeId: wrapperProps.workflowVersionNodeId,
+      parameters: getConfiguration(),
+    }).finally(() => {
+      setProcessing(false);
+    });
+  }
+
+  const { childLinks } = useSelector(
+    SelectWorkflowNodeLinks({
+      version: wrapperProps.version,
+      workflowId: wrapperProps.workflowId,
+      nodeId: wrapperProps.workflowVersionNodeId,
+      stage: wrapperProps.stage,
+    })
+  );
+
+  const channelIds =
+    childLinks
+      ?.filter((n) => {
+        return n.childInput === "channels";
+      })
+      ?.map((link) => link.parentWorkflowVersionNodeId) ?? [];
+
+  const channels = useSelector(
+    SelectWorkflowNodes({
+      version: wrapperProps.version,
+      workflowId: wrapperProps.workflowId,
+      nodeIds: channelIds,
+    })
+  );
+
+  return (
+    <WorkflowNodeWrapper
+      {...wrapperProps}
+      headerIcon={<VariableIcon />}
+      colorVariant={NodeColorVariant.primary}
+      outputName={"channels"}
+    >
+      <Form onSubmit={handleSubmit} intercomTarget={"set-variable-node-form"}>
+        <Socket
+          collapsed={wrapperProps.visibilitySettings.collapsed}
+          label={t.channels}
+          selectedNodes={channels || []}
+          workflowVersionId={wrapperProps.workflowVersionId}
+          workflowVersionNodeId={wrapperProps.workflowVersionNodeId}
+          inputName={"channels"}
+          editingDisabled={editingDisabled}
+        />
+        <Input
+          intercomTarget={"set-variable-node-name-input"}
+          label={t.workflowNodes.variableName}
+          sizeVariant={InputSizeVariant.small}
+          value={variableName}
+          onChange={(e) => setVariableName(e.target.value)}
+          disabled={editingDisabled}
+        />
+        <RadioChips
+          label={t.workflowNodes.variableType}
+          sizeVariant={InputSizeVariant.small}
+          groupName={"set-variable-type-switch-" + wrapperProps.workflowVersionNodeId}
+          options={[
+            {
+              label: t.workflowNodes.number,
+              id: "set-variable-type-number-" + wrapperProps.workflowVersionNodeId,
+              checked: !isText,
+              onChange: () => setIsText(false),
+            },
+            {
+              label: t.workflowNodes.string,
+              id: "set-variable-type-text-" + wrapperProps.workflowVersionNodeId,
+              checked: isText,
+              onChange: () => setIsText(true),
+            },
+          ]}
+          editingDisabled={editingDisabled}
+        />
+        {isText ? (
+          <Input
+            intercomTarget={"set-variable-node-value-string-input"}
+            label={t.workflowNodes.variableValue}
+            sizeVariant={InputSizeVariant.small}
+            value={valueString}
+            onChange={(e) => setValueString(e.target.value)}
+            disabled={editingDisabled}
+          />
+        ) : (
+          <Input
+            intercomTarget={"set-variable-node-value-number-input"}
+            formatted={true}
+            label={t.workflowNodes.variableValue}
+            sizeVariant={InputSizeVariant.small}
+            value={valueNumber}
+            thousandSeparator={","}
+            onValueChange={(values: NumberFormatValues) => setValueNumber(values.floatValue)}
+            disabled={editingDisabled}
+          />
+        )}
+        <Button
+          intercomTarget={"set-variable-node-save-button"}
+          type="submit"
+          buttonColor={ColorVariant.success}
+          buttonSize={SizeVariant.small}
+          icon={!processing ? <SaveIcon /> : <Spinny />}
+          disabled={!dirty || processing || editingDisabled}
+        >
+          {!processing ? t.save.toString() : t.saving.toString()}
+        </Button>
+      </Form>
+    </WorkflowNodeWrapper>
+  );
+}
@@ -0,0 +1,20 @@
+import { Braces20Regular as VariableIcon } from "@fluentui/react-icons";
+import useTranslations from "services/i18n/useTranslations";
+import WorkflowNodeButtonWrapper from "components/workflow/nodeButtonWrapper/NodeButtonWrapper";
+import { WorkflowNodeType } from "pages/WorkflowPage/constants";
+import { NodeColorVariant } from "../nodeVariants";
+
+export function SetVariableNodeButton() {
+  const { t } = useTranslations();
+
+  return (
+    <WorkflowNodeButtonWrapper
+      intercomTarget={"set-variable-node-button"}
+      colorVariant={NodeColorVariant.primary}
+      nodeType={WorkflowNodeType.SetVariable}
+      icon={<VariableIcon />}
+      title={t.workflowNodes.setVariable}
+      parameters={'{ "variableName": "", "valueNumber": 0 }'}
+    />
+  );
+}
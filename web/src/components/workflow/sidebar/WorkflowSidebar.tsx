@@ -27,6 +27,9 @@ import {
   ChannelOpenTriggerNodeButton,
   DataSourceTorqChannelsNodeButton,
   ChannelBalanceEventFilterNodeButton,
+  SendNotificationNodeButton,
+  SetVariableNodeButton,
+  FilterOnVariableNodeButton,
 } from "components/workflow/nodes/nodes";
 import { userEvents } from "utils/userEvents";
 
@@ -96,6 +99,7 @@ export default function WorkflowSidebar(props: WorkflowSidebarProps) {
           <RebalanceAutoRunNodeButton />
           <AddTagNodeButton />
           <RemoveTagNodeButton />
+          <SendNotificationNodeButton />
         </SectionContainer>
         <SectionContainer
           intercomTarget={"workflow-advanced-actions-section"}
@@ -109,6 +113,8 @@ export default function WorkflowSidebar(props: WorkflowSidebarProps) {
           <ChannelPolicyRunNodeButton />
           <RebalanceConfiguratorNodeButton />
           <RebalanceRunNodeButton />
+          <SetVariableNodeButton />
+          <FilterOnVariableNodeButton />
         </SectionContainer>
       </Sidebar>
     </div>
@@ -3,7 +3,7 @@ import { ReactComponent as TorqLogo } from "icons/torq-logo.svg";
 import { LockOpen20Regular as UnlockIcon } from "@fluentui/react-icons";
 import "./login_page.scss";
 import { useLocation, useNavigate } from "react-router-dom";
-import { useLoginMutation } from "apiSlice";
+import { useLoginMutation, useTotpLoginMutation } from "apiSlice";
 import ToastContext from "features/toast/context";
 import { toastCategory } from "features/toast/Toasts";
 import type { LoginResponse } from "types/api";
@@ -16,6 +16,8 @@ function LoginPage() {
   const { t } = useTranslations();
   const { track } = userEvents();
   const [login] = useLoginMutation();
+  const [totpLogin] = useTotpLoginMutation();
+  const [totpRequired, setTotpRequired] = React.useState(false);
 
   const navigate = useNavigate();
   const location = useLocation();
@@ -43,11 +45,17 @@ function LoginPage() {
   const submit = async (e: React.FormEvent<HTMLFormElement>) => {
     e.preventDefault();
     const formData = new FormData(e.currentTarget);
-    formData.append("username", "admin");
+    // Without user accounts the shared password is used with the username admin
+    if (!formData.get("username")) {
+      formData.set("username", "admin");
+    }
     const res = (await login(formData)) as LoginResponse;
     if (res?.error) {
       const errorMessage = res.error?.data?.error ? "Incorrect Password!" : "Api not reachable!";
       toastRef?.current?.addToast(errorMessage, toastCategory.error);
+    } else if (res?.data?.totpRequired) {
+      setTotpRequired(true);
+      return;
     } else {
       navigate(from, { replace: true });
     }
@@ -56,6 +64,20 @@ function LoginPage() {
     }
   };
 
+  const submitTotp = async (e: React.FormEvent<HTMLFormElement>) => {
+    e.preventDefault();
+    const res = (await totpLogin(new FormData(e.currentTarget))) as LoginResponse;
+    if (res?.error) {
+      const errorMessage = res.error?.data?.error ? res.error.data.error : "Api not reachable!";
+      toastRef?.current?.addToast(errorMessage, toastCategory.error);
+      if (res.error?.data?.error?.startsWith("Login expired")) {
+        setTotpRequired(false);
+      }
+    } else {
+      navigate(from, { replace: true });
+    }
+  };
+
   // TODO: unify the styling here once standardised button styles are done.
   return (
     <div className="login-page-wrapper">
@@ -63,18 +85,48 @@ function LoginPage() {
         <div className="logo">
           <TorqLogo />
         </div>
-        <form className="login-form" onSubmit={submit}>
-          <Input type="password" name={"password"} placeholder={"Password..."} id={"password-field"} autoFocus={true} />
-          <Button
-            type="submit"
-            icon={<UnlockIcon />}
-            buttonColor={ColorVariant.success}
-            id={"submit-button"}
-            intercomTarget={"login-button"}
-          >
-            {t.login}
-          </Button>
-        </form>
+        {totpRequired && (
+          <form className="login-form" onSubmit={submitTotp}>
+            <Input
+              type="text"
+              name={"code"}
+              placeholder={"Authentication or recovery code..."}
+              id={"totp-code-field"}
+              autoComplete={"one-time-code"}
+              autoFocus={true}
+            />
+            <Button
+              type="submit"
+              icon={<UnlockIcon />}
+              buttonColor={ColorVariant.success}
+              id={"submit-totp-button"}
+              intercomTarget={"login-totp-button"}
+            >
+              {t.login}
+            </Button>
+          </form>
+        )}
+        {!totpRequired && (
+          <form className="login-form" onSubmit={submit}>
+            <Input type="text" name={"username"} placeholder={"Username (optional)..."} id={"username-field"} />
+            <Input
+              type="password"
+              name={"password"}
+              placeholder={"Password..."}
+              id={"password-field"}
+              autoFocus={true}
+            />
+            <Button
+              type="submit"
+              icon={<UnlockIcon />}
+              buttonColor={ColorVariant.success}
+              id={"submit-button"}
+              intercomTarget={"login-button"}
+            >
+              {t.login}
+            </Button>
+          </form>
+        )}
       </div>
     </div>
   );
@@ -344,6 +344,30 @@ export const AllChannelsColumns: ColumnMetaData<channel>[] = [
 		key: "private",
 		valueType: "boolean",
 	},
+	{
+		heading: "Probe Reachability",
+		type: "NumericCell",
+		key: "probeReachability",
+		valueType: "number",
+	},
+	{
+		heading: "Probe Fee (PPM)",
+		type: "NumericCell",
+		key: "probeFeePpm",
+		valueType: "number",
+	},
+	{
+		heading: "Probe Liquidity Lower Bound",
+		type: "NumericCell",
+		key: "probeLiquidityLowerBound",
+		valueType: "number",
+	},
+	{
+		heading: "Probed On",
+		type: "DateCell",
+		key: "probedOn",
+		valueType: "date",
+	},
 ];
 
 
@@ -402,6 +426,10 @@ export const ChannelsSortableColumns: Array<keyof channel> = [
 	"remotePubkey",
 	"peerGauge",
 	"private",
+	"probeReachability",
+	"probeFeePpm",
+	"probeLiquidityLowerBound",
+	"probedOn",
 ];
 
 
@@ -461,4 +489,8 @@ export const ChannelsFilterableColumns: Array<keyof channel> = [
 	"remotePubkey",
 	"peerGauge",
 	"private",
+	"probeReachability",
+	"probeFeePpm",
+	"probeLiquidityLowerBound",
+	"probedOn",
 ];
\ No newline at end of file
@@ -90,6 +90,10 @@ export type channel = {
   peerLocalBalance: number;
   peerGauge: number;
   private: boolean;
+  probeReachability?: number;
+  probeFeePpm?: number;
+  probeLiquidityLowerBound?: number;
+  probedOn?: Date;
 };
 
 export type PolicyInterface = {
@@ -0,0 +1,13 @@
+import { torqApi } from "apiSlice";
+import { CommunicationTarget } from "./communicationsTypes";
+
+export const communicationsApi = torqApi.injectEndpoints({
+  endpoints: (builder) => ({
+    getCommunicationTargets: builder.query<Array<CommunicationTarget>, void>({
+      query: () => `communication-targets`,
+      providesTags: ["communications"],
+    }),
+  }),
+});
+
+export const { useGetCommunicationTargetsQuery } = communicationsApi;
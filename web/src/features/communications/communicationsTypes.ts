@@ -0,0 +1,7 @@
+export type CommunicationTarget = {
+  communicationId: number;
+  nodeId: number;
+  channelId?: number;
+  targetType: number;
+  targetName: string;
+};
@@ -109,6 +109,22 @@ function Settings() {
     setSettingsState({ ...settingsState, telegramLowPriorityCredentials: value });
   };
 
+  const handleApprovalThresholdSatChange = (value: string) => {
+    setSettingsState({ ...settingsState, approvalThresholdSat: value === "" ? undefined : Number(value) });
+  };
+
+  const handleApprovalExpiryMinutesChange = (value: string) => {
+    setSettingsState({ ...settingsState, approvalExpiryMinutes: Number(value) });
+  };
+
+  const handleHtlcFailureBurstWindowMinutesChange = (value: string) => {
+    setSettingsState({ ...settingsState, htlcFailureBurstWindowMinutes: Number(value) });
+  };
+
+  const handleHtlcFailureBurstThresholdChange = (value: string) => {
+    setSettingsState({ ...settingsState, htlcFailureBurstThreshold: Number(value) });
+  };
+
   const submitPreferences = async (e: React.FormEvent<HTMLFormElement>) => {
     e.preventDefault();
     updateSettings(settingsState);
@@ -209,6 +225,46 @@ function Settings() {
                     }
                   />
                 </div>
+                <div data-intercom-target={"settings-approval-section"}>
+                  <Input
+                    intercomTarget="settings-approval-threshold-sat"
+                    label={t.approvalThresholdSat}
+                    value={settingsState?.approvalThresholdSat ?? ""}
+                    type={"number"}
+                    onChange={(e: React.ChangeEvent<HTMLInputElement>) =>
+                      handleApprovalThresholdSatChange(e.target.value)
+                    }
+                  />
+                  <Input
+                    intercomTarget="settings-approval-expiry-minutes"
+                    label={t.approvalExpiryMinutes}
+                    value={settingsState?.approvalExpiryMinutes}
+                    type={"number"}
+                    onChange={(e: React.ChangeEvent<HTMLInputElement>) =>
+                      handleApprovalExpiryMinutesChange(e.target.value)
+                    }
+                  />
+                </div>
+                <div data-intercom-target={"settings-htlc-failure-burst-section"}>
+                  <Input
+                    intercomTarget="settings-htlc-failure-burst-window-minutes"
+                    label={t.htlcFailureBurstWindowMinutes}
+                    value={settingsState?.htlcFailureBurstWindowMinutes}
+                    type={"number"}
+                    onChange={(e: React.ChangeEvent<HTMLInputElement>) =>
+                      handleHtlcFailureBurstWindowMinutesChange(e.target.value)
+                    }
+                  />
+                  <Input
+                    intercomTarget="settings-htlc-failure-burst-threshold"
+                    label={t.htlcFailureBurstThreshold}
+                    value={settingsState?.htlcFailureBurstThreshold}
+                    type={"number"}
+                    onChange={(e: React.ChangeEvent<HTMLInputElement>) =>
+                      handleHtlcFailureBurstThresholdChange(e.target.value)
+                    }
+                  />
+                </div>
                 <Button
                   intercomTarget="settings-save-button"
                   type={"submit"}
@@ -37,7 +37,7 @@ const ffLabels = new Map<string, string>([
   ["notAny", "don't contain"],
 ]);
 
-function getFilterFunctions(filterCategory: FilterCategoryType) {
+export function getFilterFunctions(filterCategory: FilterCategoryType) {
   const filterFuncs = FilterFunctions.get(filterCategory)?.entries();
   if (!filterFuncs) {
     throw new Error("Filter category not found in list of filters");
@@ -21,7 +21,8 @@ export enum WorkflowNodeType {
   ChannelPolicyAutoRun,
   RebalanceAutoRun,
   DataSourceTorqChannels,
-  ChannelBalanceEventFilter
+  ChannelBalanceEventFilter,
+  SendNotification,
 }
 
 export const TriggerNodeTypes = [
@@ -129,6 +129,7 @@ export type TriggerWorkflowRequest = {
   workflowVersionId: number;
   workflowId: number;
   workflowVersionNodeId: number;
+  dryRun?: boolean;
 };
 
 export type WorkflowStages = {
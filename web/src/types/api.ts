@@ -70,6 +70,7 @@ type LoginFail = {
 };
 type LoginSuccess = {
   message: string;
+  totpRequired?: boolean;
 };
 
 export type GetPaymentsQueryParams = BaseQueryCollectionParams & ActiveNetwork;